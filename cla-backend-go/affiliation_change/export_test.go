@@ -0,0 +1,26 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package affiliation_change
+
+import (
+	"time"
+)
+
+// SignaturesPageSize exposes signaturesPageSize to the tests
+const SignaturesPageSize = signaturesPageSize
+
+// NewTestService creates the service with the clock of the test
+func NewTestService(repo Repository, userRepo UserRepository, companyRepo CompanyRepository, signatureRepo SignatureRepository,
+	claGroupRepo ClaGroupRepository, approvalListService ApprovalListService, eventsService EventsService, now func() time.Time) Service {
+	return &service{
+		repo:                repo,
+		userRepo:            userRepo,
+		companyRepo:         companyRepo,
+		signatureRepo:       signatureRepo,
+		claGroupRepo:        claGroupRepo,
+		approvalListService: approvalListService,
+		eventsService:       eventsService,
+		now:                 now,
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package affiliation_change
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// RemovalRequest is an approval list the CLA managers of the previous company were asked to remove the user from
+type RemovalRequest struct {
+	SignatureID      string   `dynamodbav:"signature_id" json:"signature_id"`
+	ClaGroupID       string   `dynamodbav:"cla_group_id" json:"cla_group_id"`
+	ClaGroupName     string   `dynamodbav:"cla_group_name" json:"cla_group_name"`
+	Entries          []string `dynamodbav:"entries" json:"entries"`
+	ManagersNotified int64    `dynamodbav:"managers_notified" json:"managers_notified"`
+}
+
+// Affiliation is the database model for the user affiliations table, a period during which the user contributed on
+// behalf of the company. The current affiliation has no end date.
+type Affiliation struct {
+	AffiliationID         string           `dynamodbav:"affiliation_id" json:"affiliation_id"`
+	UserID                string           `dynamodbav:"user_id" json:"user_id"`
+	CompanyID             string           `dynamodbav:"company_id" json:"company_id"`
+	CompanySFID           string           `dynamodbav:"company_sfid" json:"company_sfid"`
+	CompanyName           string           `dynamodbav:"company_name" json:"company_name"`
+	ClaGroupID            string           `dynamodbav:"cla_group_id,omitempty" json:"cla_group_id,omitempty"`
+	ApprovalListRequestID string           `dynamodbav:"approval_list_request_id,omitempty" json:"approval_list_request_id,omitempty"`
+	DateStarted           string           `dynamodbav:"date_started" json:"date_started"`
+	DateEnded             string           `dynamodbav:"date_ended,omitempty" json:"date_ended,omitempty"`
+	RemovalRequests       []RemovalRequest `dynamodbav:"removal_requests,omitempty" json:"removal_requests,omitempty"`
+	DateModified          string           `dynamodbav:"date_modified" json:"date_modified"`
+}
+
+// isOpen returns true for the current affiliation of the user
+func (a *Affiliation) isOpen() bool {
+	return a.DateEnded == ""
+}
+
+// ToModel converts the database model to the API model
+func (a *Affiliation) ToModel() *models.UserAffiliation {
+	if a == nil {
+		return nil
+	}
+	removalRequests := make([]*models.UserAffiliationRemovalRequest, 0, len(a.RemovalRequests))
+	for _, removalRequest := range a.RemovalRequests {
+		removalRequests = append(removalRequests, &models.UserAffiliationRemovalRequest{
+			SignatureID:      removalRequest.SignatureID,
+			ClaGroupID:       removalRequest.ClaGroupID,
+			ClaGroupName:     removalRequest.ClaGroupName,
+			Entries:          removalRequest.Entries,
+			ManagersNotified: removalRequest.ManagersNotified,
+		})
+	}
+	return &models.UserAffiliation{
+		AffiliationID:         a.AffiliationID,
+		UserID:                a.UserID,
+		CompanyID:             a.CompanyID,
+		CompanySFID:           a.CompanySFID,
+		CompanyName:           a.CompanyName,
+		ClaGroupID:            a.ClaGroupID,
+		ApprovalListRequestID: a.ApprovalListRequestID,
+		DateStarted:           a.DateStarted,
+		DateEnded:             a.DateEnded,
+		RemovalRequests:       removalRequests,
+	}
+}
+
+// Change is the outcome of an affiliation change, the previous affiliation is nil if the user had no company
+type Change struct {
+	PreviousAffiliation *Affiliation
+	Affiliation         *Affiliation
+}
+
+// ToModel converts the affiliation change to the API model
+func (c *Change) ToModel() *models.UserAffiliationChange {
+	return &models.UserAffiliationChange{
+		PreviousAffiliation: c.PreviousAffiliation.ToModel(),
+		Affiliation:         c.Affiliation.ToModel(),
+	}
+}
+
+// ChangeInput holds the new company and the CLA group to request coverage for
+type ChangeInput struct {
+	CompanySFID string
+	ClaGroupID  string
+	Message     string
+}
+
+// Requester is the user asking for the affiliation change
+type Requester struct {
+	LFUsername string
+	Admin      bool
+}
@@ -0,0 +1,143 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package affiliation_change
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// UserIDIndex is the user affiliations table index by user
+const UserIDIndex = "user-id-index"
+
+// Repository interface defines the functions for the user affiliations data model
+type Repository interface {
+	GetUserAffiliations(ctx context.Context, userID string) ([]*Affiliation, error)
+	PutAffiliation(ctx context.Context, affiliation *Affiliation) error
+	DeleteAffiliation(ctx context.Context, affiliationID string) error
+}
+
+type repository struct {
+	stage          string
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewRepository creates a new instance of the user affiliations repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:          stage,
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-user-affiliations", stage),
+	}
+}
+
+// GetUserAffiliations returns the current and the ended affiliations of the user
+func (repo *repository) GetUserAffiliations(ctx context.Context, userID string) ([]*Affiliation, error) {
+	f := logrus.Fields{
+		"functionName":   "GetUserAffiliations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"userID":         userID,
+	}
+
+	condition := expression.Key("user_id").Equal(expression.Value(userID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for user affiliations query, error: %v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.tableName),
+		IndexName:                 aws.String(UserIDIndex),
+	}
+	var resultList []map[string]*dynamodb.AttributeValue
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).Warnf("error retrieving user affiliations, error: %v", err)
+			return nil, err
+		}
+		resultList = append(resultList, results.Items...)
+		if len(results.LastEvaluatedKey) != 0 {
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+
+	var affiliations []*Affiliation
+	err = dynamodbattribute.UnmarshalListOfMaps(resultList, &affiliations)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling user affiliations from database, error: %v", err)
+		return nil, err
+	}
+	return affiliations, nil
+}
+
+// PutAffiliation creates or replaces the affiliation record
+func (repo *repository) PutAffiliation(ctx context.Context, affiliation *Affiliation) error {
+	f := logrus.Fields{
+		"functionName":   "PutAffiliation",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"affiliationID":  affiliation.AffiliationID,
+		"userID":         affiliation.UserID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(affiliation)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal user affiliation, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store user affiliation, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteAffiliation removes the affiliation record
+func (repo *repository) DeleteAffiliation(ctx context.Context, affiliationID string) error {
+	f := logrus.Fields{
+		"functionName":   "DeleteAffiliation",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"affiliationID":  affiliationID,
+	}
+
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"affiliation_id": {
+				S: aws.String(affiliationID),
+			},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to delete user affiliation, error: %+v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,518 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package affiliation_change
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/signatures"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// errors
+var (
+	ErrUserNotFound    = errors.New("user not found")
+	ErrCompanyNotFound = errors.New("company not found")
+	ErrCCLANotSigned   = errors.New("company has not signed a CCLA for the CLA group")
+	ErrSameCompany     = errors.New("user is already affiliated with the company")
+	ErrNotAllowed      = errors.New("only the user or an admin can access the affiliations of the user")
+)
+
+// signaturesPageSize is the page size used to load the CCLA signatures of the previous company
+const signaturesPageSize = 100
+
+// UserRepository is the part of the users repository used to load and update the user
+type UserRepository interface {
+	GetUser(userID string) (*v1Models.User, error)
+	Save(user *v1Models.UserUpdate) (*v1Models.User, error)
+	RemoveCompany(userID string) error
+}
+
+// CompanyRepository is the part of the company repository used to load the previous and the new company
+type CompanyRepository interface {
+	GetCompany(ctx context.Context, companyID string) (*v1Models.Company, error)
+	GetCompanyByExternalID(ctx context.Context, companySFID string) (*v1Models.Company, error)
+}
+
+// SignatureRepository is the part of the signatures repository used to find the approval lists of the user
+type SignatureRepository interface {
+	GetCompanySignatures(ctx context.Context, params signatures.GetCompanySignaturesParams, pageSize int64, loadACL bool) (*v1Models.Signatures, error)
+	GetProjectCompanySignature(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*v1Models.Signature, error)
+}
+
+// ClaGroupRepository is the part of the project repository used to name the CLA groups
+type ClaGroupRepository interface {
+	GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*v1Models.ClaGroup, error)
+}
+
+// ApprovalListService is the part of the approval list service used to request coverage at the new company
+type ApprovalListService interface {
+	AddCclaWhitelistRequest(ctx context.Context, companyID string, claGroupID string, args v1Models.CclaWhitelistRequestInput) (string, error)
+}
+
+// EventsService is the part of the events service used to log the affiliation changes
+type EventsService interface {
+	LogEvent(args *events.LogEventArgs)
+}
+
+// Service interface defines the affiliation change service methods
+type Service interface {
+	GetAffiliations(ctx context.Context, userID string, requester Requester) ([]*Affiliation, error)
+	ChangeAffiliation(ctx context.Context, userID string, input ChangeInput, requester Requester) (*Change, error)
+}
+
+type service struct {
+	repo                Repository
+	userRepo            UserRepository
+	companyRepo         CompanyRepository
+	signatureRepo       SignatureRepository
+	claGroupRepo        ClaGroupRepository
+	approvalListService ApprovalListService
+	eventsService       EventsService
+	now                 func() time.Time
+}
+
+// NewService creates a new affiliation change service
+func NewService(repo Repository, userRepo UserRepository, companyRepo CompanyRepository, signatureRepo SignatureRepository,
+	claGroupRepo ClaGroupRepository, approvalListService ApprovalListService, eventsService EventsService) Service {
+	return &service{
+		repo:                repo,
+		userRepo:            userRepo,
+		companyRepo:         companyRepo,
+		signatureRepo:       signatureRepo,
+		claGroupRepo:        claGroupRepo,
+		approvalListService: approvalListService,
+		eventsService:       eventsService,
+		now:                 time.Now,
+	}
+}
+
+// GetAffiliations returns the affiliation history of the user, the oldest first
+func (s *service) GetAffiliations(ctx context.Context, userID string, requester Requester) ([]*Affiliation, error) {
+	userModel, err := s.loadUser(userID, requester)
+	if err != nil {
+		return nil, err
+	}
+	affiliations, err := s.repo.GetUserAffiliations(ctx, userModel.UserID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(affiliations, func(i, j int) bool {
+		return affiliations[i].DateStarted < affiliations[j].DateStarted
+	})
+	return affiliations, nil
+}
+
+// ChangeAffiliation ends the affiliation of the user with the previous company and starts the affiliation with the new
+// company. The CLA managers of the previous company are asked to remove the user from the approval lists and an
+// approval list request is created at the new company for the CLA group, the change is rolled back if the request can
+// not be created. The ended affiliation is kept, so the history tells which company covered the contributions of the
+// user at any time.
+func (s *service) ChangeAffiliation(ctx context.Context, userID string, input ChangeInput, requester Requester) (*Change, error) {
+	f := logrus.Fields{
+		"functionName":   "ChangeAffiliation",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"userID":         userID,
+		"companySFID":    input.CompanySFID,
+		"claGroupID":     input.ClaGroupID,
+		"requester":      requester.LFUsername,
+	}
+
+	userModel, err := s.loadUser(userID, requester)
+	if err != nil {
+		return nil, err
+	}
+
+	companyModel, err := s.companyRepo.GetCompanyByExternalID(ctx, input.CompanySFID)
+	if err != nil || companyModel == nil {
+		log.WithFields(f).Warnf("unable to load company by SFID: %s, error: %+v", input.CompanySFID, err)
+		return nil, ErrCompanyNotFound
+	}
+	if userModel.CompanyID == companyModel.CompanyID {
+		return nil, ErrSameCompany
+	}
+
+	claGroupModel, err := s.claGroupRepo.GetCLAGroupByID(ctx, input.ClaGroupID, false)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load CLA group, error: %+v", err)
+		return nil, err
+	}
+	signed, approved := true, true
+	sigModel, err := s.signatureRepo.GetProjectCompanySignature(ctx, companyModel.CompanyID, input.ClaGroupID, &signed, &approved, nil, aws.Int64(1))
+	if err != nil {
+		return nil, err
+	}
+	if sigModel == nil {
+		return nil, ErrCCLANotSigned
+	}
+
+	affiliations, err := s.repo.GetUserAffiliations(ctx, userModel.UserID)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.currentAffiliation(ctx, userModel, affiliations)
+	if err != nil {
+		return nil, err
+	}
+
+	now := utils.TimeToString(s.now().UTC())
+	affiliationID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	affiliation := &Affiliation{
+		AffiliationID: affiliationID.String(),
+		UserID:        userModel.UserID,
+		CompanyID:     companyModel.CompanyID,
+		CompanySFID:   companyModel.CompanyExternalID,
+		CompanyName:   companyModel.CompanyName,
+		ClaGroupID:    input.ClaGroupID,
+		DateStarted:   now,
+		DateModified:  now,
+	}
+
+	// The user and the affiliations are changed before the approval list request is created, the change is rolled back
+	// if it fails or if the new company does not accept the request. Affiliations left open by a company change outside
+	// of this flow end now as well.
+	var ended, originals []*Affiliation
+	for _, existing := range affiliations {
+		if existing.isOpen() {
+			copied := *existing
+			originals = append(originals, &copied)
+			ended = append(ended, existing)
+		}
+	}
+	created := []*Affiliation{affiliation}
+	if previous != nil && !containsAffiliation(affiliations, previous) {
+		ended = append(ended, previous)
+		created = append(created, previous)
+	}
+	if err := s.saveChange(ctx, userModel, companyModel.CompanyID, ended, affiliation, now); err != nil {
+		log.WithFields(f).Warnf("unable to change the affiliation of the user, error: %+v", err)
+		s.rollbackChange(ctx, userModel, originals, created)
+		return nil, err
+	}
+
+	requestID, err := s.approvalListService.AddCclaWhitelistRequest(ctx, companyModel.CompanyID, input.ClaGroupID, v1Models.CclaWhitelistRequestInput{
+		ContributorID:    userModel.UserID,
+		ContributorName:  userModel.Username,
+		ContributorEmail: userEmail(userModel),
+		Message:          input.Message,
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to create the approval list request at the new company, error: %+v", err)
+		s.rollbackChange(ctx, userModel, originals, created)
+		return nil, err
+	}
+
+	// The change is complete once the request exists, failing to record the request or the removal requests is only logged
+	affiliation.ApprovalListRequestID = requestID
+	if err := s.repo.PutAffiliation(ctx, affiliation); err != nil {
+		log.WithFields(f).Warnf("unable to store the approval list request: %s of the affiliation, error: %+v", requestID, err)
+	}
+	var previousCompanyName string
+	var removalRequests int
+	if previous != nil {
+		previousCompanyName = previous.CompanyName
+		previous.RemovalRequests = s.requestApprovalListRemovals(ctx, userModel, previous)
+		removalRequests = len(previous.RemovalRequests)
+		if err := s.repo.PutAffiliation(ctx, previous); err != nil {
+			log.WithFields(f).Warnf("unable to store the approval list removal requests of the previous affiliation, error: %+v", err)
+		}
+	}
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:     events.CCLAApprovalListRequestCreated,
+		ProjectID:     input.ClaGroupID,
+		ClaGroupModel: claGroupModel,
+		CompanyID:     companyModel.CompanyID,
+		CompanyModel:  companyModel,
+		UserID:        userModel.UserID,
+		EventData:     &events.CCLAApprovalListRequestCreatedEventData{RequestID: requestID},
+	})
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:     events.ContributorAffiliationChanged,
+		ProjectID:     input.ClaGroupID,
+		ClaGroupModel: claGroupModel,
+		CompanyID:     companyModel.CompanyID,
+		CompanyModel:  companyModel,
+		LfUsername:    requester.LFUsername,
+		UserID:        userModel.UserID,
+		UserModel:     userModel,
+		EventData: &events.ContributorAffiliationChangedEventData{
+			PreviousCompanyName:   previousCompanyName,
+			CompanyName:           companyModel.CompanyName,
+			ProjectName:           claGroupModel.ProjectName,
+			ApprovalListRequestID: requestID,
+			RemovalRequests:       removalRequests,
+		},
+	})
+
+	return &Change{
+		PreviousAffiliation: previous,
+		Affiliation:         affiliation,
+	}, nil
+}
+
+// saveChange moves the user to the new company, ends the open affiliations and stores the new affiliation
+func (s *service) saveChange(ctx context.Context, userModel *v1Models.User, companyID string, ended []*Affiliation, affiliation *Affiliation, now string) error {
+	_, err := s.userRepo.Save(&v1Models.UserUpdate{
+		UserID:    userModel.UserID,
+		CompanyID: companyID,
+	})
+	if err != nil {
+		return err
+	}
+	for _, existing := range ended {
+		existing.DateEnded = now
+		existing.DateModified = now
+		if err := s.repo.PutAffiliation(ctx, existing); err != nil {
+			return err
+		}
+	}
+	return s.repo.PutAffiliation(ctx, affiliation)
+}
+
+// rollbackChange restores the company of the user and the affiliations as they were before the change, the created
+// affiliations are removed
+func (s *service) rollbackChange(ctx context.Context, userModel *v1Models.User, originals []*Affiliation, created []*Affiliation) {
+	f := logrus.Fields{
+		"functionName":   "rollbackChange",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"userID":         userModel.UserID,
+		"companyID":      userModel.CompanyID,
+	}
+	if userModel.CompanyID == "" {
+		// the user had no company, the save only sets a company so the one of the change is removed
+		if err := s.userRepo.RemoveCompany(userModel.UserID); err != nil {
+			log.WithFields(f).Warnf("unable to remove the company of the user, error: %+v", err)
+		}
+	} else if _, err := s.userRepo.Save(&v1Models.UserUpdate{UserID: userModel.UserID, CompanyID: userModel.CompanyID}); err != nil {
+		log.WithFields(f).Warnf("unable to restore the company of the user, error: %+v", err)
+	}
+	for _, original := range originals {
+		if err := s.repo.PutAffiliation(ctx, original); err != nil {
+			log.WithFields(f).Warnf("unable to restore affiliation: %s, error: %+v", original.AffiliationID, err)
+		}
+	}
+	for _, affiliation := range created {
+		if err := s.repo.DeleteAffiliation(ctx, affiliation.AffiliationID); err != nil {
+			log.WithFields(f).Warnf("unable to remove affiliation: %s, error: %+v", affiliation.AffiliationID, err)
+		}
+	}
+}
+
+// containsAffiliation returns true if the affiliation is one of the affiliations
+func containsAffiliation(affiliations []*Affiliation, affiliation *Affiliation) bool {
+	for _, existing := range affiliations {
+		if existing == affiliation {
+			return true
+		}
+	}
+	return false
+}
+
+// loadUser returns the user, only the user or an admin may see and change the affiliations
+func (s *service) loadUser(userID string, requester Requester) (*v1Models.User, error) {
+	userModel, err := s.userRepo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if userModel == nil || userModel.UserID == "" {
+		return nil, ErrUserNotFound
+	}
+	if !requester.Admin && (userModel.LfUsername == "" || userModel.LfUsername != requester.LFUsername) {
+		return nil, ErrNotAllowed
+	}
+	return userModel, nil
+}
+
+// currentAffiliation returns the open affiliation of the user. The users affiliated before the history was kept have
+// no affiliation record, one is created from the company of the user record.
+func (s *service) currentAffiliation(ctx context.Context, userModel *v1Models.User, affiliations []*Affiliation) (*Affiliation, error) {
+	for _, affiliation := range affiliations {
+		if affiliation.isOpen() && affiliation.CompanyID == userModel.CompanyID {
+			return affiliation, nil
+		}
+	}
+	if userModel.CompanyID == "" {
+		return nil, nil
+	}
+
+	companyModel, err := s.companyRepo.GetCompany(ctx, userModel.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	affiliationID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	affiliation := &Affiliation{
+		AffiliationID: affiliationID.String(),
+		UserID:        userModel.UserID,
+		CompanyID:     userModel.CompanyID,
+		DateStarted:   userModel.DateCreated,
+	}
+	if companyModel != nil {
+		affiliation.CompanySFID = companyModel.CompanyExternalID
+		affiliation.CompanyName = companyModel.CompanyName
+	}
+	return affiliation, nil
+}
+
+// requestApprovalListRemovals emails the CLA managers of each CCLA signature of the previous company which has the
+// email addresses or the GitHub username of the user on the approval list. The managers decide about the removal, the
+// entries may still be valid if the user keeps contributing on behalf of the previous company.
+func (s *service) requestApprovalListRemovals(ctx context.Context, userModel *v1Models.User, previous *Affiliation) []RemovalRequest {
+	f := logrus.Fields{
+		"functionName":   "requestApprovalListRemovals",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"userID":         userModel.UserID,
+		"companyID":      previous.CompanyID,
+	}
+
+	var removalRequests []RemovalRequest
+	var nextKey *string
+	for {
+		sigModels, err := s.signatureRepo.GetCompanySignatures(ctx, signatures.GetCompanySignaturesParams{
+			CompanyID:     previous.CompanyID,
+			SignatureType: aws.String(utils.ClaTypeCCLA),
+			NextKey:       nextKey,
+		}, signaturesPageSize, true)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to load the CCLA signatures of the previous company, error: %+v", err)
+			return removalRequests
+		}
+
+		for _, sig := range sigModels.Signatures {
+			entries := approvalListEntries(userModel, sig)
+			if len(entries) == 0 {
+				continue
+			}
+			removalRequest := RemovalRequest{
+				SignatureID: sig.SignatureID.String(),
+				ClaGroupID:  sig.ProjectID,
+				Entries:     entries,
+			}
+			claGroupModel, claGroupErr := s.claGroupRepo.GetCLAGroupByID(ctx, sig.ProjectID, false)
+			if claGroupErr != nil || claGroupModel == nil {
+				log.WithFields(f).Warnf("unable to load CLA group: %s, error: %+v", sig.ProjectID, claGroupErr)
+				claGroupModel = &v1Models.ClaGroup{ProjectID: sig.ProjectID, ProjectName: sig.ProjectID}
+			}
+			removalRequest.ClaGroupName = claGroupModel.ProjectName
+			removalRequest.ManagersNotified = int64(sendRemovalRequestEmails(userModel, previous, claGroupModel, sig, entries))
+			removalRequests = append(removalRequests, removalRequest)
+		}
+
+		if sigModels.LastKeyScanned == "" {
+			break
+		}
+		nextKey = aws.String(sigModels.LastKeyScanned)
+	}
+	return removalRequests
+}
+
+// approvalListEntries returns the email addresses and the GitHub username of the user on the approval lists of the
+// signature as stored on the lists, the lookup ignores the case
+func approvalListEntries(userModel *v1Models.User, sig *v1Models.Signature) []string {
+	var entries []string
+	for _, email := range userEmails(userModel) {
+		for _, entry := range sig.EmailApprovalList {
+			if strings.EqualFold(entry, email) && !utils.StringInSlice(entry, entries) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	if userModel.GithubUsername != "" {
+		for _, entry := range sig.GithubUsernameApprovalList {
+			if strings.EqualFold(entry, userModel.GithubUsername) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+// userEmails returns the LF email and the other email addresses of the user
+func userEmails(userModel *v1Models.User) []string {
+	var emails []string
+	if userModel.LfEmail != "" {
+		emails = append(emails, userModel.LfEmail)
+	}
+	for _, email := range userModel.Emails {
+		if email != "" && !utils.StringInSlice(email, emails) {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// userEmail returns the preferred email address of the user
+func userEmail(userModel *v1Models.User) string {
+	emails := userEmails(userModel)
+	if len(emails) == 0 {
+		return ""
+	}
+	return emails[0]
+}
+
+// sendRemovalRequestEmails asks the CLA managers of the signature to remove the user from the approval list, returns
+// the number of managers notified
+func sendRemovalRequestEmails(userModel *v1Models.User, previous *Affiliation, claGroupModel *v1Models.ClaGroup, sig *v1Models.Signature, entries []string) int {
+	var notified int
+	for _, manager := range sig.SignatureACL {
+		recipient := manager.LfEmail
+		if recipient == "" && len(manager.Emails) > 0 {
+			recipient = manager.Emails[0]
+		}
+		if recipient == "" {
+			log.Warnf("unable to send email to manager: %s - no email on file...", manager.LfUsername)
+			continue
+		}
+		subject, body := removalRequestEmailContent(userModel, previous, claGroupModel, manager.Username, entries)
+		if err := utils.SendEmail(subject, body, []string{recipient}); err != nil {
+			log.Warnf("problem sending email with subject: %s to recipient: %s, error: %+v", subject, recipient, err)
+			continue
+		}
+		notified++
+	}
+	return notified
+}
+
+func removalRequestEmailContent(userModel *v1Models.User, previous *Affiliation, claGroupModel *v1Models.ClaGroup, recipientName string, entries []string) (string, string) {
+	entryList := "<ul>"
+	for _, entry := range entries {
+		entryList += fmt.Sprintf("<li>%s</li>", entry)
+	}
+	entryList += "</ul>"
+
+	subject := fmt.Sprintf("EasyCLA: Request to Remove %s from the Approval List for %s", userModel.Username, claGroupModel.ProjectName)
+	body := fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>%s has informed EasyCLA that they no longer contribute on behalf of %s. The following entries of the
+contributor are on the Approval List of %s for %s:</p>
+%s
+<p>If the contributor should no longer be authorized to contribute on behalf of %s, please log into the EasyCLA
+Corporate Console at %s, select the project %s and remove the entries from the Approval List.</p>
+%s
+%s`,
+		recipientName, claGroupModel.ProjectName,
+		userModel.Username, previous.CompanyName, previous.CompanyName, claGroupModel.ProjectName,
+		entryList,
+		previous.CompanyName, utils.GetCorporateURL(claGroupModel.Version == utils.V2), claGroupModel.ProjectName,
+		utils.GetEmailHelpContent(claGroupModel.Version == utils.V2), utils.GetEmailSignOffContent())
+	return subject, body
+}
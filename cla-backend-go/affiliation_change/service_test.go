@@ -0,0 +1,248 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package affiliation_change_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/communitybridge/easycla/cla-backend-go/affiliation_change"
+	"github.com/communitybridge/easycla/cla-backend-go/affiliation_change/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+var testNow = time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeEmailSender struct {
+	recipients []string
+}
+
+func (s *fakeEmailSender) SendEmail(subject string, body string, recipients []string) error {
+	s.recipients = append(s.recipients, recipients...)
+	return nil
+}
+
+type testMocks struct {
+	repo                *mock.MockRepository
+	userRepo            *mock.MockUserRepository
+	approvalListService *mock.MockApprovalListService
+	eventsService       *mock.MockEventsService
+	// affiliations holds the stored affiliations by affiliation ID
+	affiliations map[string]*affiliation_change.Affiliation
+	service      affiliation_change.Service
+}
+
+// newTestMocks creates a contributor of the old company, the old company has the contributor on the approval list of
+// one of its two CCLA signatures and the new company has signed a CCLA for cla-group-1
+func newTestMocks(ctrl *gomock.Controller) *testMocks {
+	m := &testMocks{
+		repo:                mock.NewMockRepository(ctrl),
+		userRepo:            mock.NewMockUserRepository(ctrl),
+		approvalListService: mock.NewMockApprovalListService(ctrl),
+		eventsService:       mock.NewMockEventsService(ctrl),
+		affiliations:        map[string]*affiliation_change.Affiliation{},
+	}
+	m.repo.EXPECT().GetUserAffiliations(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, userID string) ([]*affiliation_change.Affiliation, error) {
+		var affiliations []*affiliation_change.Affiliation
+		for _, affiliation := range m.affiliations {
+			if affiliation.UserID == userID {
+				copied := *affiliation
+				affiliations = append(affiliations, &copied)
+			}
+		}
+		return affiliations, nil
+	}).AnyTimes()
+	m.userRepo.EXPECT().GetUser("user-1").Return(&v1Models.User{UserID: "user-1", Username: "Jane", LfUsername: "jane",
+		LfEmail: "jane@old.com", GithubUsername: "jane-gh", CompanyID: "old", DateCreated: "2019-01-01T00:00:00Z"}, nil).AnyTimes()
+	m.userRepo.EXPECT().GetUser("user-2").Return(&v1Models.User{UserID: "user-2", Username: "John", LfUsername: "john",
+		LfEmail: "john@example.com", DateCreated: "2019-01-01T00:00:00Z"}, nil).AnyTimes()
+	m.userRepo.EXPECT().GetUser(gomock.Not("user-1")).Return(nil, nil).AnyTimes()
+
+	oldCompany := &v1Models.Company{CompanyID: "old", CompanyExternalID: "old-sfid", CompanyName: "Old Corp"}
+	newCompany := &v1Models.Company{CompanyID: "new", CompanyExternalID: "new-sfid", CompanyName: "New Corp"}
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	companyRepo.EXPECT().GetCompany(gomock.Any(), "old").Return(oldCompany, nil).AnyTimes()
+	companyRepo.EXPECT().GetCompanyByExternalID(gomock.Any(), "old-sfid").Return(oldCompany, nil).AnyTimes()
+	companyRepo.EXPECT().GetCompanyByExternalID(gomock.Any(), "new-sfid").Return(newCompany, nil).AnyTimes()
+	companyRepo.EXPECT().GetCompanyByExternalID(gomock.Any(), "unknown").Return(nil, nil).AnyTimes()
+
+	signatureRepo := mock.NewMockSignatureRepository(ctrl)
+	signatureRepo.EXPECT().GetCompanySignatures(gomock.Any(), signatures.GetCompanySignaturesParams{
+		CompanyID: "old", SignatureType: aws.String(utils.ClaTypeCCLA),
+	}, int64(affiliation_change.SignaturesPageSize), true).Return(&v1Models.Signatures{Signatures: []*v1Models.Signature{
+		{
+			SignatureID:                strfmt.UUID4("old-sig-1"),
+			ProjectID:                  "cla-group-1",
+			EmailApprovalList:          []string{"other@old.com", "Jane@Old.com"},
+			GithubUsernameApprovalList: []string{"jane-gh"},
+			SignatureACL:               []v1Models.User{{Username: "Manager", LfUsername: "manager", LfEmail: "manager@old.com"}},
+		},
+		{
+			SignatureID:       strfmt.UUID4("old-sig-2"),
+			ProjectID:         "cla-group-2",
+			EmailApprovalList: []string{"other@old.com"},
+			SignatureACL:      []v1Models.User{{Username: "Manager", LfUsername: "manager", LfEmail: "manager@old.com"}},
+		},
+	}}, nil).AnyTimes()
+	signatureRepo.EXPECT().GetProjectCompanySignature(gomock.Any(), "new", "cla-group-1", gomock.Any(), gomock.Any(), nil, gomock.Any()).
+		Return(&v1Models.Signature{SignatureID: strfmt.UUID4("new-sig-1"), ProjectID: "cla-group-1"}, nil).AnyTimes()
+	signatureRepo.EXPECT().GetProjectCompanySignature(gomock.Any(), "new", "cla-group-2", gomock.Any(), gomock.Any(), nil, gomock.Any()).
+		Return(nil, nil).AnyTimes()
+
+	claGroupRepo := mock.NewMockClaGroupRepository(ctrl)
+	claGroupRepo.EXPECT().GetCLAGroupByID(gomock.Any(), gomock.Any(), false).DoAndReturn(func(ctx context.Context, claGroupID string, loadRepoDetails bool) (*v1Models.ClaGroup, error) {
+		return &v1Models.ClaGroup{ProjectID: claGroupID, ProjectName: "Project " + claGroupID}, nil
+	}).AnyTimes()
+
+	m.service = affiliation_change.NewTestService(m.repo, m.userRepo, companyRepo, signatureRepo, claGroupRepo, m.approvalListService,
+		m.eventsService, func() time.Time { return testNow })
+	return m
+}
+
+// expectStored stores and removes the affiliations in the affiliations of the mocks
+func (m *testMocks) expectStored() {
+	m.repo.EXPECT().PutAffiliation(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, affiliation *affiliation_change.Affiliation) error {
+		copied := *affiliation
+		m.affiliations[affiliation.AffiliationID] = &copied
+		return nil
+	}).AnyTimes()
+	m.repo.EXPECT().DeleteAffiliation(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, affiliationID string) error {
+		delete(m.affiliations, affiliationID)
+		return nil
+	}).AnyTimes()
+}
+
+func TestChangeAffiliation(t *testing.T) {
+	previous := utils.GetEmailSender()
+	sender := &fakeEmailSender{}
+	utils.SetEmailSender(sender)
+	defer utils.SetEmailSender(previous)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := newTestMocks(ctrl)
+	m.expectStored()
+	m.userRepo.EXPECT().Save(&v1Models.UserUpdate{UserID: "user-1", CompanyID: "new"}).Return(&v1Models.User{UserID: "user-1"}, nil)
+	m.approvalListService.EXPECT().AddCclaWhitelistRequest(gomock.Any(), "new", "cla-group-1", v1Models.CclaWhitelistRequestInput{
+		ContributorID: "user-1", ContributorName: "Jane", ContributorEmail: "jane@old.com", Message: "I moved",
+	}).Return("request-1", nil)
+	var eventTypes []string
+	m.eventsService.EXPECT().LogEvent(gomock.Any()).Do(func(args *events.LogEventArgs) {
+		eventTypes = append(eventTypes, args.EventType)
+	}).Times(2)
+
+	input := affiliation_change.ChangeInput{CompanySFID: "new-sfid", ClaGroupID: "cla-group-1", Message: "I moved"}
+	change, err := m.service.ChangeAffiliation(context.Background(), "user-1", input, affiliation_change.Requester{LFUsername: "jane"})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// The affiliation with the old company is created from the user record and ended
+	assert.Equal(t, "old", change.PreviousAffiliation.CompanyID)
+	assert.Equal(t, "2019-01-01T00:00:00Z", change.PreviousAffiliation.DateStarted)
+	assert.Equal(t, utils.TimeToString(testNow), change.PreviousAffiliation.DateEnded)
+	assert.Equal(t, []affiliation_change.RemovalRequest{{
+		SignatureID:      "old-sig-1",
+		ClaGroupID:       "cla-group-1",
+		ClaGroupName:     "Project cla-group-1",
+		Entries:          []string{"Jane@Old.com", "jane-gh"},
+		ManagersNotified: 1,
+	}}, change.PreviousAffiliation.RemovalRequests)
+	assert.Equal(t, []string{"manager@old.com"}, sender.recipients)
+
+	assert.Equal(t, "new", change.Affiliation.CompanyID)
+	assert.Equal(t, "request-1", change.Affiliation.ApprovalListRequestID)
+	assert.Equal(t, utils.TimeToString(testNow), change.Affiliation.DateStarted)
+	assert.Empty(t, change.Affiliation.DateEnded)
+	assert.Equal(t, []string{events.CCLAApprovalListRequestCreated, events.ContributorAffiliationChanged}, eventTypes)
+
+	// Both affiliations are kept as history
+	affiliations, err := m.service.GetAffiliations(context.Background(), "user-1", affiliation_change.Requester{Admin: true})
+	assert.Nil(t, err)
+	if assert.Len(t, affiliations, 2) {
+		assert.Equal(t, change.PreviousAffiliation, affiliations[0])
+		assert.Equal(t, change.Affiliation, affiliations[1])
+	}
+}
+
+func TestChangeAffiliationErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// Nothing is changed when the change is rejected, the user, the affiliations and the requests are not expected
+	m := newTestMocks(ctrl)
+	input := affiliation_change.ChangeInput{CompanySFID: "new-sfid", ClaGroupID: "cla-group-1"}
+
+	_, err := m.service.ChangeAffiliation(context.Background(), "unknown", input, affiliation_change.Requester{Admin: true})
+	assert.True(t, errors.Is(err, affiliation_change.ErrUserNotFound))
+	_, err = m.service.ChangeAffiliation(context.Background(), "user-1", input, affiliation_change.Requester{LFUsername: "someone-else"})
+	assert.True(t, errors.Is(err, affiliation_change.ErrNotAllowed))
+	_, err = m.service.ChangeAffiliation(context.Background(), "user-1", affiliation_change.ChangeInput{CompanySFID: "unknown", ClaGroupID: "cla-group-1"}, affiliation_change.Requester{LFUsername: "jane"})
+	assert.True(t, errors.Is(err, affiliation_change.ErrCompanyNotFound))
+	_, err = m.service.ChangeAffiliation(context.Background(), "user-1", affiliation_change.ChangeInput{CompanySFID: "old-sfid", ClaGroupID: "cla-group-1"}, affiliation_change.Requester{LFUsername: "jane"})
+	assert.True(t, errors.Is(err, affiliation_change.ErrSameCompany))
+	_, err = m.service.ChangeAffiliation(context.Background(), "user-1", affiliation_change.ChangeInput{CompanySFID: "new-sfid", ClaGroupID: "cla-group-2"}, affiliation_change.Requester{LFUsername: "jane"})
+	assert.True(t, errors.Is(err, affiliation_change.ErrCCLANotSigned))
+}
+
+func TestChangeAffiliationRollsBackWhenRequestRejected(t *testing.T) {
+	previous := utils.GetEmailSender()
+	sender := &fakeEmailSender{}
+	utils.SetEmailSender(sender)
+	defer utils.SetEmailSender(previous)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := newTestMocks(ctrl)
+	m.expectStored()
+	current := &affiliation_change.Affiliation{AffiliationID: "affiliation-1", UserID: "user-1", CompanyID: "old", CompanyName: "Old Corp",
+		DateStarted: "2019-01-01T00:00:00Z", DateModified: "2019-01-01T00:00:00Z"}
+	m.affiliations[current.AffiliationID] = current
+	gomock.InOrder(
+		m.userRepo.EXPECT().Save(&v1Models.UserUpdate{UserID: "user-1", CompanyID: "new"}).Return(&v1Models.User{UserID: "user-1"}, nil),
+		m.approvalListService.EXPECT().AddCclaWhitelistRequest(gomock.Any(), "new", "cla-group-1", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, companyID string, claGroupID string, args v1Models.CclaWhitelistRequestInput) (string, error) {
+				// the change is stored before the request is created
+				assert.Equal(t, utils.TimeToString(testNow), m.affiliations["affiliation-1"].DateEnded)
+				assert.Len(t, m.affiliations, 2)
+				return "", errors.New("request already exists")
+			}),
+		m.userRepo.EXPECT().Save(&v1Models.UserUpdate{UserID: "user-1", CompanyID: "old"}).Return(&v1Models.User{UserID: "user-1"}, nil),
+	)
+
+	input := affiliation_change.ChangeInput{CompanySFID: "new-sfid", ClaGroupID: "cla-group-1"}
+	_, err := m.service.ChangeAffiliation(context.Background(), "user-1", input, affiliation_change.Requester{LFUsername: "jane"})
+	assert.NotNil(t, err)
+
+	// The user is back at the old company without removal requests sent to its CLA managers
+	assert.Equal(t, map[string]*affiliation_change.Affiliation{"affiliation-1": current}, m.affiliations)
+	assert.Empty(t, sender.recipients)
+}
+
+func TestChangeAffiliationRollsBackToNoCompany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := newTestMocks(ctrl)
+	m.expectStored()
+	// The user had no company to restore, the company of the change is removed from the user
+	gomock.InOrder(
+		m.userRepo.EXPECT().Save(&v1Models.UserUpdate{UserID: "user-2", CompanyID: "new"}).Return(&v1Models.User{UserID: "user-2"}, nil),
+		m.approvalListService.EXPECT().AddCclaWhitelistRequest(gomock.Any(), "new", "cla-group-1", gomock.Any()).Return("", errors.New("request already exists")),
+		m.userRepo.EXPECT().RemoveCompany("user-2").Return(nil),
+	)
+
+	input := affiliation_change.ChangeInput{CompanySFID: "new-sfid", ClaGroupID: "cla-group-1"}
+	_, err := m.service.ChangeAffiliation(context.Background(), "user-2", input, affiliation_change.Requester{LFUsername: "john"})
+	assert.NotNil(t, err)
+	assert.Empty(t, m.affiliations)
+}
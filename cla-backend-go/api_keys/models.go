@@ -0,0 +1,92 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package api_keys
+
+import (
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// access levels
+const (
+	AccessLevelRead  = "read"
+	AccessLevelWrite = "write"
+)
+
+// APIKey is the database model for the api keys table - only the hash of the key value is stored
+type APIKey struct {
+	APIKeyID       string   `dynamodbav:"api_key_id" json:"api_key_id"`
+	Name           string   `dynamodbav:"name" json:"name"`
+	Description    string   `dynamodbav:"description" json:"description"`
+	KeyHash        string   `dynamodbav:"key_hash" json:"key_hash"`
+	KeyPrefix      string   `dynamodbav:"key_prefix" json:"key_prefix"`
+	FoundationSFID string   `dynamodbav:"foundation_sfid" json:"foundation_sfid"`
+	ClaGroupIDs    []string `dynamodbav:"cla_group_ids" json:"cla_group_ids"`
+	AccessLevel    string   `dynamodbav:"access_level" json:"access_level"`
+	ExpirationDate string   `dynamodbav:"expiration_date" json:"expiration_date"`
+	LastUsed       string   `dynamodbav:"last_used" json:"last_used"`
+	Revoked        bool     `dynamodbav:"revoked" json:"revoked"`
+	DateRevoked    string   `dynamodbav:"date_revoked" json:"date_revoked"`
+	RevokedBy      string   `dynamodbav:"revoked_by" json:"revoked_by"`
+	CreatedBy      string   `dynamodbav:"created_by" json:"created_by"`
+	DateCreated    string   `dynamodbav:"date_created" json:"date_created"`
+	DateModified   string   `dynamodbav:"date_modified" json:"date_modified"`
+	Version        string   `dynamodbav:"version" json:"version"`
+}
+
+// IsExpired returns true if the key expiration date is before the specified time
+func (k *APIKey) IsExpired(now time.Time) bool {
+	expirationDate, err := utils.ParseDateTime(k.ExpirationDate)
+	if err != nil {
+		// Treat an unreadable expiration date as expired rather than granting access
+		return true
+	}
+	return now.After(expirationDate)
+}
+
+// AllowsMethod returns true if the key access level permits the HTTP method - read keys are limited to GET and HEAD
+func (k *APIKey) AllowsMethod(method string) bool {
+	if k.AccessLevel == AccessLevelWrite {
+		return true
+	}
+	return method == "GET" || method == "HEAD"
+}
+
+// AllowsCLAGroup returns true if the key is scoped to the CLA group - keys without a CLA group list apply to all
+// the foundation CLA groups
+func (k *APIKey) AllowsCLAGroup(claGroupID string) bool {
+	if len(k.ClaGroupIDs) == 0 {
+		return true
+	}
+	for _, id := range k.ClaGroupIDs {
+		if id == claGroupID {
+			return true
+		}
+	}
+	return false
+}
+
+// ToModel converts the database model to the API model
+func (k *APIKey) ToModel() *models.APIKey {
+	return &models.APIKey{
+		APIKeyID:       k.APIKeyID,
+		Name:           k.Name,
+		Description:    k.Description,
+		KeyPrefix:      k.KeyPrefix,
+		FoundationSfid: k.FoundationSFID,
+		ClaGroupIDList: k.ClaGroupIDs,
+		AccessLevel:    k.AccessLevel,
+		ExpirationDate: k.ExpirationDate,
+		LastUsed:       k.LastUsed,
+		Revoked:        k.Revoked,
+		DateRevoked:    k.DateRevoked,
+		RevokedBy:      k.RevokedBy,
+		CreatedBy:      k.CreatedBy,
+		DateCreated:    k.DateCreated,
+		DateModified:   k.DateModified,
+		Version:        k.Version,
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package api_keys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// indexes
+const (
+	KeyHashIndex        = "key-hash-index"
+	FoundationSFIDIndex = "foundation-sfid-index"
+)
+
+// errors
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+)
+
+// Repository interface defines the functions for the api keys data model
+type Repository interface {
+	CreateAPIKey(ctx context.Context, apiKey *APIKey) error
+	GetAPIKey(ctx context.Context, apiKeyID string) (*APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	GetAPIKeysByFoundation(ctx context.Context, foundationSFID string) ([]*APIKey, error)
+	UpdateLastUsed(ctx context.Context, apiKeyID string, lastUsed string) error
+	RevokeAPIKey(ctx context.Context, apiKeyID string, revokedBy string) error
+}
+
+type repository struct {
+	stage          string
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewRepository creates a new instance of the api keys repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:          stage,
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-api-keys", stage),
+	}
+}
+
+// CreateAPIKey stores the new api key
+func (repo *repository) CreateAPIKey(ctx context.Context, apiKey *APIKey) error {
+	f := logrus.Fields{
+		"functionName":   "CreateAPIKey",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"apiKeyID":       apiKey.APIKeyID,
+		"foundationSFID": apiKey.FoundationSFID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(apiKey)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal api key, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.tableName),
+		ConditionExpression: aws.String("attribute_not_exists(api_key_id)"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store api key, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// GetAPIKey returns the api key by ID
+func (repo *repository) GetAPIKey(ctx context.Context, apiKeyID string) (*APIKey, error) {
+	f := logrus.Fields{
+		"functionName":   "GetAPIKey",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"apiKeyID":       apiKeyID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"api_key_id": {
+				S: aws.String(apiKeyID),
+			},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load api key, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	var apiKey APIKey
+	err = dynamodbattribute.UnmarshalMap(result.Item, &apiKey)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling api key table data, error: %v", err)
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+// GetAPIKeyByHash returns the api key matching the hash of the key value
+func (repo *repository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error) {
+	keyCondition := expression.Key("key_hash").Equal(expression.Value(keyHash))
+	apiKeys, err := repo.queryAPIKeys(ctx, keyCondition, KeyHashIndex)
+	if err != nil {
+		return nil, err
+	}
+	if len(apiKeys) == 0 {
+		return nil, ErrAPIKeyNotFound
+	}
+	return apiKeys[0], nil
+}
+
+// GetAPIKeysByFoundation returns the api keys scoped to the foundation
+func (repo *repository) GetAPIKeysByFoundation(ctx context.Context, foundationSFID string) ([]*APIKey, error) {
+	keyCondition := expression.Key("foundation_sfid").Equal(expression.Value(foundationSFID))
+	return repo.queryAPIKeys(ctx, keyCondition, FoundationSFIDIndex)
+}
+
+func (repo *repository) queryAPIKeys(ctx context.Context, keyCondition expression.KeyConditionBuilder, indexName string) ([]*APIKey, error) {
+	f := logrus.Fields{
+		"functionName":   "queryAPIKeys",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"indexName":      indexName,
+	}
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for api key query, error: %v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.tableName),
+		IndexName:                 aws.String(indexName),
+	}
+
+	var apiKeys []*APIKey
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.WithFields(f).Warnf("error retrieving api keys, error: %v", errQuery)
+			return nil, errQuery
+		}
+
+		var apiKeysTmp []*APIKey
+		err := dynamodbattribute.UnmarshalListOfMaps(results.Items, &apiKeysTmp)
+		if err != nil {
+			log.WithFields(f).Warnf("error unmarshalling api keys from database, error: %v", err)
+			return nil, err
+		}
+		apiKeys = append(apiKeys, apiKeysTmp...)
+
+		if len(results.LastEvaluatedKey) != 0 {
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+
+	return apiKeys, nil
+}
+
+// UpdateLastUsed records when the api key was last used
+func (repo *repository) UpdateLastUsed(ctx context.Context, apiKeyID string, lastUsed string) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateLastUsed",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"apiKeyID":       apiKeyID,
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"api_key_id": {
+				S: aws.String(apiKeyID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#L": aws.String("last_used"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":l": {
+				S: aws.String(lastUsed),
+			},
+		},
+		UpdateExpression: aws.String("SET #L = :l"),
+		TableName:        aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to update api key last used, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// RevokeAPIKey marks the api key as revoked - the record is kept for auditing
+func (repo *repository) RevokeAPIKey(ctx context.Context, apiKeyID string, revokedBy string) error {
+	f := logrus.Fields{
+		"functionName":   "RevokeAPIKey",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"apiKeyID":       apiKeyID,
+		"revokedBy":      revokedBy,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"api_key_id": {
+				S: aws.String(apiKeyID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#R": aws.String("revoked"),
+			"#D": aws.String("date_revoked"),
+			"#B": aws.String("revoked_by"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":r": {
+				BOOL: aws.Bool(true),
+			},
+			":d": {
+				S: aws.String(currentTime),
+			},
+			":b": {
+				S: aws.String(revokedBy),
+			},
+			":m": {
+				S: aws.String(currentTime),
+			},
+		},
+		UpdateExpression: aws.String("SET #R = :r, #D = :d, #B = :b, #M = :m"),
+		TableName:        aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to revoke api key, error: %+v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,348 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package api_keys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+
+	swagerrors "github.com/go-openapi/errors"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+const (
+	// HeaderName is the request header used to send the API key
+	HeaderName = "X-API-KEY"
+	// keyValuePrefix makes EasyCLA keys easy to recognize, e.g. by secret scanners
+	keyValuePrefix = "ecla_"
+	// keyPrefixLength is the number of characters of the key value stored to help identify the key
+	keyPrefixLength = 12
+	// defaultExpiresInDays is used when the expiration is not specified
+	defaultExpiresInDays = 90
+	// cacheTTL is how long an authenticated key is cached - a revoked key may be accepted for up to this long
+	// on the other server instances
+	cacheTTL = time.Minute
+	// usageInterval limits how often the last used date is stored
+	usageInterval = 5 * time.Minute
+)
+
+// errors
+var (
+	ErrInvalidAPIKey        = errors.New("invalid api key")
+	ErrAPIKeyRevoked        = errors.New("api key has been revoked")
+	ErrAPIKeyExpired        = errors.New("api key has expired")
+	ErrInvalidAccessLevel   = errors.New("invalid access level")
+	ErrCLAGroupNotInProject = errors.New("cla group is not associated with the foundation")
+)
+
+// Service interface defines the api key service methods
+type Service interface {
+	CreateAPIKey(ctx context.Context, foundationSFID string, input *models.APIKeyInput, createdBy string) (*APIKey, string, error)
+	GetAPIKeys(ctx context.Context, foundationSFID string) ([]*APIKey, error)
+	RevokeAPIKey(ctx context.Context, foundationSFID, apiKeyID, revokedBy string) (*APIKey, error)
+	Authenticate(ctx context.Context, keyValue string) (*APIKey, error)
+	RecordUsage(ctx context.Context, apiKey *APIKey, method, path string)
+	SwaggerAuth(token string) (*auth.User, error)
+	IsAPIKeyAuthorizedForProject(apiKeyID, projectSFID string) bool
+}
+
+type cachedAPIKey struct {
+	apiKey   *APIKey
+	loadedAt time.Time
+}
+
+type service struct {
+	repo                  Repository
+	projectsClaGroupsRepo projects_cla_groups.Repository
+	eventsService         events.Service
+
+	lock      sync.Mutex
+	byHash    map[string]cachedAPIKey
+	byID      map[string]cachedAPIKey
+	lastUsage map[string]time.Time
+}
+
+// NewService creates a new api key service
+func NewService(repo Repository, projectsClaGroupsRepo projects_cla_groups.Repository, eventsService events.Service) Service {
+	return &service{
+		repo:                  repo,
+		projectsClaGroupsRepo: projectsClaGroupsRepo,
+		eventsService:         eventsService,
+		byHash:                map[string]cachedAPIKey{},
+		byID:                  map[string]cachedAPIKey{},
+		lastUsage:             map[string]time.Time{},
+	}
+}
+
+// hashKey returns the hash stored for the key value - the key values are random so a plain SHA-256 is sufficient
+func hashKey(keyValue string) string {
+	sum := sha256.Sum256([]byte(keyValue))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateKey returns a new random key value
+func generateKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return keyValuePrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CreateAPIKey creates a new api key scoped to the foundation and returns the key value - only the hash of the value
+// is stored so it cannot be retrieved again
+func (s *service) CreateAPIKey(ctx context.Context, foundationSFID string, input *models.APIKeyInput, createdBy string) (*APIKey, string, error) {
+	f := logrus.Fields{
+		"functionName":   "CreateAPIKey",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"foundationSFID": foundationSFID,
+		"createdBy":      createdBy,
+	}
+
+	accessLevel := aws.StringValue(input.AccessLevel)
+	if accessLevel != AccessLevelRead && accessLevel != AccessLevelWrite {
+		return nil, "", ErrInvalidAccessLevel
+	}
+
+	for _, claGroupID := range input.ClaGroupIDList {
+		pcgs, err := s.projectsClaGroupsRepo.GetProjectsIdsForClaGroup(claGroupID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to load the projects of CLA group %s", claGroupID)
+			return nil, "", err
+		}
+		if len(pcgs) == 0 || pcgs[0].FoundationSFID != foundationSFID {
+			log.WithFields(f).Warnf("CLA group %s is not associated with the foundation", claGroupID)
+			return nil, "", ErrCLAGroupNotInProject
+		}
+	}
+
+	keyValue, err := generateKey()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate api key")
+		return nil, "", err
+	}
+	apiKeyID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate a UUID for the api key")
+		return nil, "", err
+	}
+
+	expiresInDays := input.ExpiresInDays
+	if expiresInDays == 0 {
+		expiresInDays = defaultExpiresInDays
+	}
+	now, currentTime := utils.CurrentTime()
+	apiKey := &APIKey{
+		APIKeyID:       apiKeyID.String(),
+		Name:           aws.StringValue(input.Name),
+		Description:    input.Description,
+		KeyHash:        hashKey(keyValue),
+		KeyPrefix:      keyValue[:keyPrefixLength],
+		FoundationSFID: foundationSFID,
+		ClaGroupIDs:    input.ClaGroupIDList,
+		AccessLevel:    accessLevel,
+		ExpirationDate: utils.TimeToString(now.AddDate(0, 0, int(expiresInDays))),
+		CreatedBy:      createdBy,
+		DateCreated:    currentTime,
+		DateModified:   currentTime,
+		Version:        "v1",
+	}
+
+	if err := s.repo.CreateAPIKey(ctx, apiKey); err != nil {
+		return nil, "", err
+	}
+	return apiKey, keyValue, nil
+}
+
+// GetAPIKeys returns the api keys scoped to the foundation
+func (s *service) GetAPIKeys(ctx context.Context, foundationSFID string) ([]*APIKey, error) {
+	return s.repo.GetAPIKeysByFoundation(ctx, foundationSFID)
+}
+
+// RevokeAPIKey revokes the api key - the key must belong to the foundation
+func (s *service) RevokeAPIKey(ctx context.Context, foundationSFID, apiKeyID, revokedBy string) (*APIKey, error) {
+	apiKey, err := s.repo.GetAPIKey(ctx, apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey.FoundationSFID != foundationSFID {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	if err := s.repo.RevokeAPIKey(ctx, apiKeyID, revokedBy); err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	delete(s.byHash, apiKey.KeyHash)
+	delete(s.byID, apiKey.APIKeyID)
+	s.lock.Unlock()
+
+	return apiKey, nil
+}
+
+// Authenticate returns the api key for the key value if it is valid, not revoked and not expired
+func (s *service) Authenticate(ctx context.Context, keyValue string) (*APIKey, error) {
+	if !strings.HasPrefix(keyValue, keyValuePrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+	keyHash := hashKey(keyValue)
+
+	s.lock.Lock()
+	cached, ok := s.byHash[keyHash]
+	s.lock.Unlock()
+
+	apiKey := cached.apiKey
+	if !ok || time.Since(cached.loadedAt) > cacheTTL {
+		var err error
+		apiKey, err = s.repo.GetAPIKeyByHash(ctx, keyHash)
+		if err != nil {
+			if err == ErrAPIKeyNotFound {
+				return nil, ErrInvalidAPIKey
+			}
+			return nil, err
+		}
+		s.cache(apiKey)
+	}
+
+	return apiKey, validate(apiKey)
+}
+
+// validate confirms the api key has not been revoked and has not expired
+func validate(apiKey *APIKey) error {
+	if apiKey.Revoked {
+		return ErrAPIKeyRevoked
+	}
+	if apiKey.IsExpired(time.Now()) {
+		return ErrAPIKeyExpired
+	}
+	return nil
+}
+
+func (s *service) cache(apiKey *APIKey) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	entry := cachedAPIKey{apiKey: apiKey, loadedAt: time.Now()}
+	s.byHash[apiKey.KeyHash] = entry
+	s.byID[apiKey.APIKeyID] = entry
+}
+
+// RecordUsage records the request in the events log and stores the last used date - the last used date is stored at
+// most once per usage interval for each key as it is only used to spot unused keys
+func (s *service) RecordUsage(ctx context.Context, apiKey *APIKey, method, path string) {
+	f := logrus.Fields{
+		"functionName":   "RecordUsage",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"apiKeyID":       apiKey.APIKeyID,
+		"method":         method,
+		"path":           path,
+	}
+
+	now, currentTime := utils.CurrentTime()
+	s.lock.Lock()
+	lastUsage, ok := s.lastUsage[apiKey.APIKeyID]
+	recent := ok && now.Sub(lastUsage) < usageInterval
+	if !recent {
+		s.lastUsage[apiKey.APIKeyID] = now
+	}
+	s.lock.Unlock()
+
+	if !recent {
+		if err := s.repo.UpdateLastUsed(ctx, apiKey.APIKeyID, currentTime); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to update the api key last used date")
+		}
+	}
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		LfUsername:        apiKey.CreatedBy,
+		EventType:         events.APIKeyUsed,
+		ExternalProjectID: apiKey.FoundationSFID,
+		EventData: &events.APIKeyUsedEventData{
+			APIKeyID: apiKey.APIKeyID,
+			Name:     apiKey.Name,
+			Method:   method,
+			Path:     path,
+		},
+	})
+}
+
+// SwaggerAuth authenticates requests using the api-key security definition - the returned user is identified by
+// the api key ID and has no ACL, the key scope is checked by the utils authorization helpers
+func (s *service) SwaggerAuth(token string) (*auth.User, error) {
+	f := logrus.Fields{
+		"functionName": "SwaggerAuth",
+	}
+
+	apiKey, err := s.Authenticate(utils.NewContext(), token)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("api key authentication failed")
+		if err == ErrInvalidAPIKey || err == ErrAPIKeyRevoked || err == ErrAPIKeyExpired {
+			return nil, swagerrors.New(401, err.Error())
+		}
+		return nil, err
+	}
+
+	return &auth.User{
+		UserName: utils.APIKeyUserName(apiKey.APIKeyID),
+	}, nil
+}
+
+// IsAPIKeyAuthorizedForProject returns true if the project is the api key foundation or one of its projects - if the
+// key is limited to a list of CLA groups, the project must belong to one of them
+func (s *service) IsAPIKeyAuthorizedForProject(apiKeyID, projectSFID string) bool {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "IsAPIKeyAuthorizedForProject",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"apiKeyID":       apiKeyID,
+		"projectSFID":    projectSFID,
+	}
+
+	s.lock.Lock()
+	cached, ok := s.byID[apiKeyID]
+	s.lock.Unlock()
+
+	apiKey := cached.apiKey
+	if !ok || time.Since(cached.loadedAt) > cacheTTL {
+		var err error
+		apiKey, err = s.repo.GetAPIKey(ctx, apiKeyID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load api key")
+			return false
+		}
+		s.cache(apiKey)
+	}
+	if validate(apiKey) != nil {
+		return false
+	}
+
+	if projectSFID == apiKey.FoundationSFID {
+		// Foundation level requests span all the CLA groups
+		return len(apiKey.ClaGroupIDs) == 0
+	}
+
+	pcg, err := s.projectsClaGroupsRepo.GetClaGroupIDForProject(projectSFID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Debug("unable to load the CLA group of the project")
+		return false
+	}
+	return pcg.FoundationSFID == apiKey.FoundationSFID && apiKey.AllowsCLAGroup(pcg.ClaGroupID)
+}
@@ -0,0 +1,116 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package api_keys_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/communitybridge/easycla/cla-backend-go/api_keys"
+	"github.com/communitybridge/easycla/cla-backend-go/api_keys/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	eventsMock "github.com/communitybridge/easycla/cla-backend-go/events/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+func TestAPIKeyAllows(t *testing.T) {
+	apiKey := &api_keys.APIKey{AccessLevel: api_keys.AccessLevelRead, ClaGroupIDs: []string{"cla-group-1"}}
+	assert.True(t, apiKey.AllowsMethod("GET"))
+	assert.False(t, apiKey.AllowsMethod("POST"))
+	assert.True(t, apiKey.AllowsCLAGroup("cla-group-1"))
+	assert.False(t, apiKey.AllowsCLAGroup("cla-group-2"))
+
+	apiKey = &api_keys.APIKey{AccessLevel: api_keys.AccessLevelWrite}
+	assert.True(t, apiKey.AllowsMethod("DELETE"))
+	assert.True(t, apiKey.AllowsCLAGroup("cla-group-2"))
+}
+
+func TestAPIKeyIsExpired(t *testing.T) {
+	now := time.Now()
+	assert.False(t, (&api_keys.APIKey{ExpirationDate: utils.TimeToString(now.Add(time.Hour))}).IsExpired(now))
+	assert.True(t, (&api_keys.APIKey{ExpirationDate: utils.TimeToString(now.Add(-time.Hour))}).IsExpired(now))
+	assert.True(t, (&api_keys.APIKey{ExpirationDate: "not-a-date"}).IsExpired(now))
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	// Only the hash of the key value is stored - keep the created keys so they can be looked up by hash
+	stored := map[string]*api_keys.APIKey{}
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, apiKey *api_keys.APIKey) error {
+		stored[apiKey.Name] = apiKey
+		return nil
+	}).Times(3)
+	repo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, keyHash string) (*api_keys.APIKey, error) {
+		for _, apiKey := range stored {
+			if apiKey.KeyHash == keyHash {
+				return apiKey, nil
+			}
+		}
+		return nil, api_keys.ErrAPIKeyNotFound
+	}).AnyTimes()
+	service := api_keys.NewService(repo, nil, nil)
+
+	keyValues := map[string]string{}
+	for _, name := range []string{"valid", "revoked", "expired"} {
+		apiKey, keyValue, err := service.CreateAPIKey(ctx, "foundation-1", &models.APIKeyInput{
+			Name:        aws.String(name),
+			AccessLevel: aws.String(api_keys.AccessLevelRead),
+		}, "project-manager")
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.NotEqual(t, keyValue, apiKey.KeyHash)
+		assert.True(t, strings.HasPrefix(keyValue, apiKey.KeyPrefix))
+		keyValues[name] = keyValue
+	}
+	stored["revoked"].Revoked = true
+	stored["expired"].ExpirationDate = utils.TimeToString(time.Now().Add(-time.Hour))
+
+	apiKey, err := service.Authenticate(ctx, keyValues["valid"])
+	if assert.Nil(t, err) {
+		assert.Equal(t, "valid", apiKey.Name)
+	}
+	_, err = service.Authenticate(ctx, keyValues["revoked"])
+	assert.Equal(t, api_keys.ErrAPIKeyRevoked, err)
+	_, err = service.Authenticate(ctx, keyValues["expired"])
+	assert.Equal(t, api_keys.ErrAPIKeyExpired, err)
+	_, err = service.Authenticate(ctx, keyValues["valid"]+"unknown")
+	assert.Equal(t, api_keys.ErrInvalidAPIKey, err)
+	_, err = service.Authenticate(ctx, "Bearer abc")
+	assert.Equal(t, api_keys.ErrInvalidAPIKey, err)
+}
+
+func TestRecordUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	apiKey := &api_keys.APIKey{APIKeyID: "api-key-1", Name: "nightly-report", FoundationSFID: "foundation-1", CreatedBy: "project-manager"}
+
+	// The last used date is only stored once per interval but every request is recorded in the events log
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().UpdateLastUsed(gomock.Any(), apiKey.APIKeyID, gomock.Any()).Return(nil).Times(1)
+	eventsService := eventsMock.NewMockService(ctrl)
+	var methods []string
+	eventsService.EXPECT().LogEvent(gomock.Any()).Do(func(args *events.LogEventArgs) {
+		assert.Equal(t, events.APIKeyUsed, args.EventType)
+		methods = append(methods, args.EventData.(*events.APIKeyUsedEventData).Method)
+	}).Times(3)
+	service := api_keys.NewService(repo, nil, eventsService)
+
+	service.RecordUsage(ctx, apiKey, "GET", "/v4/events/foundation/foundation-1")
+	service.RecordUsage(ctx, apiKey, "GET", "/v4/events/foundation/foundation-1")
+	service.RecordUsage(ctx, apiKey, "POST", "/v4/project/foundation-1/github/organizations/org/drift/scan")
+	assert.Equal(t, []string{"GET", "GET", "POST"}, methods)
+}
@@ -0,0 +1,17 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list
+
+import "github.com/aws/aws-sdk-go/service/dynamodb"
+
+// RequestApprovedEmailToRecipientContent exposes requestApprovedEmailToRecipientContent to the tests
+var RequestApprovedEmailToRecipientContent = requestApprovedEmailToRecipientContent
+
+// ConflictError exposes conflictError to the tests
+var ConflictError = conflictError
+
+// RequestStatusUpdate exposes the request status update of the repository to the tests
+func RequestStatusUpdate(requestID, status, currentTime string, currentStatuses ...string) *dynamodb.Update {
+	return repository{tableName: "cla-test-ccla-whitelist-requests"}.requestStatusUpdate(requestID, status, currentTime, currentStatuses...)
+}
@@ -5,6 +5,7 @@ package approval_list
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/sirupsen/logrus"
 
@@ -13,7 +14,6 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/company"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
-	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/user"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/go-openapi/runtime/middleware"
@@ -21,7 +21,7 @@ import (
 )
 
 // Configure setups handlers on api with service
-func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore.Store, signatureService signatures.SignatureService, eventsService events.Service) {
+func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore.Store, eventsService events.Service) {
 
 	api.CompanyAddCclaWhitelistRequestHandler = company.AddCclaWhitelistRequestHandlerFunc(
 		func(params company.AddCclaWhitelistRequestParams) middleware.Responder {
@@ -47,8 +47,26 @@ func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore
 		func(params company.ApproveCclaWhitelistRequestParams, claUser *user.CLAUser) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
 			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
-			err := service.ApproveCclaWhitelistRequest(ctx, params.CompanyID, params.ProjectID, params.RequestID)
+			entry, err := service.ApproveCclaWhitelistRequest(ctx, claUser, params.CompanyID, params.ProjectID, params.RequestID)
+			if entry != nil {
+				logApprovalListUpdated(eventsService, params.ProjectID, params.CompanyID, claUser, entry, true)
+			}
 			if err != nil {
+				if err == ErrNotAuthorized {
+					msg := fmt.Sprintf("EasyCLA - 403 Forbidden - user %s / %s is not a CLA Manager or approval list delegate allowed to approve requests for company ID: %s, project ID: %s",
+						claUser.LFUsername, claUser.LFEmail, params.CompanyID, params.ProjectID)
+					log.Warn(msg)
+					return company.NewApproveCclaWhitelistRequestForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code:    "403",
+						Message: msg,
+					})
+				}
+				if err == ErrCclaApprovalRequestNotFound {
+					return company.NewApproveCclaWhitelistRequestNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				if err == ErrCclaApprovalRequestConflict {
+					return company.NewApproveCclaWhitelistRequestConflict().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
 				return company.NewApproveCclaWhitelistRequestBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
 			}
 
@@ -67,8 +85,27 @@ func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore
 		func(params company.RejectCclaWhitelistRequestParams, claUser *user.CLAUser) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
 			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
-			err := service.RejectCclaWhitelistRequest(ctx, params.CompanyID, params.ProjectID, params.RequestID)
+			removeFromApprovalList := params.RemoveFromApprovalList != nil && *params.RemoveFromApprovalList
+			entry, err := service.RejectCclaWhitelistRequest(ctx, claUser, params.CompanyID, params.ProjectID, params.RequestID, removeFromApprovalList)
+			if entry != nil {
+				logApprovalListUpdated(eventsService, params.ProjectID, params.CompanyID, claUser, entry, false)
+			}
 			if err != nil {
+				if err == ErrNotAuthorized {
+					msg := fmt.Sprintf("EasyCLA - 403 Forbidden - user %s / %s is not a CLA Manager or approval list delegate allowed to reject requests for company ID: %s, project ID: %s",
+						claUser.LFUsername, claUser.LFEmail, params.CompanyID, params.ProjectID)
+					log.Warn(msg)
+					return company.NewRejectCclaWhitelistRequestForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code:    "403",
+						Message: msg,
+					})
+				}
+				if err == ErrCclaApprovalRequestNotFound {
+					return company.NewRejectCclaWhitelistRequestNotFound().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				if err == ErrCclaApprovalRequestConflict {
+					return company.NewRejectCclaWhitelistRequestConflict().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
 				return company.NewRejectCclaWhitelistRequestBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
 			}
 
@@ -126,6 +163,30 @@ func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore
 		})
 }
 
+// logApprovalListUpdated logs the approval list change made by approving or rejecting a request
+func logApprovalListUpdated(eventsService events.Service, projectID, companyID string, claUser *user.CLAUser, entry *ApprovalListEntry, added bool) {
+	var eventData events.EventData
+	switch {
+	case entry.IsEmail() && added:
+		eventData = &events.CLAApprovalListAddEmailData{UserName: claUser.LFUsername, UserEmail: claUser.LFEmail, UserLFID: claUser.UserID, ApprovalListEmail: entry.Value}
+	case entry.IsEmail():
+		eventData = &events.CLAApprovalListRemoveEmailData{UserName: claUser.LFUsername, UserEmail: claUser.LFEmail, UserLFID: claUser.UserID, ApprovalListEmail: entry.Value}
+	case added:
+		eventData = &events.CLAApprovalListAddGitHubUsernameData{UserName: claUser.LFUsername, UserEmail: claUser.LFEmail, UserLFID: claUser.UserID, ApprovalListGitHubUsername: entry.Value}
+	default:
+		eventData = &events.CLAApprovalListRemoveGitHubUsernameData{UserName: claUser.LFUsername, UserEmail: claUser.LFEmail, UserLFID: claUser.UserID, ApprovalListGitHubUsername: entry.Value}
+	}
+
+	eventsService.LogEvent(&events.LogEventArgs{
+		EventType:  events.ClaApprovalListUpdated,
+		ProjectID:  projectID,
+		CompanyID:  companyID,
+		UserID:     claUser.UserID,
+		LfUsername: claUser.LFUsername,
+		EventData:  eventData,
+	})
+}
+
 type codedResponse interface {
 	Code() string
 }
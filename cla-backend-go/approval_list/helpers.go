@@ -4,6 +4,8 @@
 package approval_list
 
 import (
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
@@ -69,3 +71,29 @@ func addConditionToFilter(filter expression.ConditionBuilder, cond expression.Co
 	}
 	return filter
 }
+
+// approvalListEntry returns the approval list entry the request was made with, the GitHub username
+// takes precedence over the email
+func approvalListEntry(request *CLARequestModel, signature *models.Signature) (*ApprovalListEntry, error) {
+	if request.UserGithubUsername != "" {
+		return &ApprovalListEntry{SignatureID: signature.SignatureID.String(), Column: GithubApprovalListColumn, Value: request.UserGithubUsername}, nil
+	}
+	if len(request.UserEmails) > 0 && request.UserEmails[0] != "" {
+		return &ApprovalListEntry{SignatureID: signature.SignatureID.String(), Column: EmailApprovalListColumn, Value: request.UserEmails[0]}, nil
+	}
+	return nil, ErrApprovalListEntryMissing
+}
+
+// approvalListIndex returns the position of the entry on the signature approval list or -1 if missing
+func approvalListIndex(signature *models.Signature, entry *ApprovalListEntry) int {
+	approvalList := signature.GithubUsernameApprovalList
+	if entry.IsEmail() {
+		approvalList = signature.EmailApprovalList
+	}
+	for i, value := range approvalList {
+		if strings.EqualFold(value, entry.Value) {
+			return i
+		}
+	}
+	return -1
+}
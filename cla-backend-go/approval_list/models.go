@@ -40,3 +40,15 @@ type CclaWhitelistRequest struct {
 	DateModified       string   `dynamodbav:"date_modified"`
 	Version            string   `dynamodbav:"version"`
 }
+
+// ApprovalListEntry is the email or GitHub username of a request on the CCLA signature approval list
+type ApprovalListEntry struct {
+	SignatureID string
+	Column      string
+	Value       string
+}
+
+// IsEmail returns true if the entry is on the email approval list
+func (e *ApprovalListEntry) IsEmail() bool {
+	return e.Column == EmailApprovalListColumn
+}
@@ -6,6 +6,7 @@ package approval_list
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/communitybridge/easycla/cla-backend-go/project"
 
@@ -19,6 +20,7 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 )
@@ -28,9 +30,18 @@ const (
 	Version = "v1"
 	// StatusPending is status of CclaWhitelistRequest
 	StatusPending = "pending"
+	// StatusApproved is the status of an approved CclaWhitelistRequest
+	StatusApproved = "approved"
+	// StatusRejected is the status of a rejected CclaWhitelistRequest
+	StatusRejected = "rejected"
 
 	// ProjectIDIndex is the index for for the project_id secondary index
 	ProjectIDIndex = "ccla-approval-list-request-project-id-index"
+
+	// EmailApprovalListColumn is the signature column of the email approval list
+	EmailApprovalListColumn = "email_whitelist"
+	// GithubApprovalListColumn is the signature column of the GitHub username approval list
+	GithubApprovalListColumn = "github_whitelist"
 )
 
 // IRepository interface defines the functions for the whitelist service
@@ -39,23 +50,30 @@ type IRepository interface {
 	GetCclaWhitelistRequest(requestID string) (*CLARequestModel, error)
 	ApproveCclaWhitelistRequest(requestID string) error
 	RejectCclaWhitelistRequest(requestID string) error
+	ApproveCclaWhitelistRequestWithApprovalListEntry(requestID string, entry *ApprovalListEntry) error
+	RejectCclaWhitelistRequestWithApprovalListRemoval(requestID string, entry *ApprovalListEntry, index int) error
 	ListCclaWhitelistRequest(companyID string, projectID, status, userID *string) (*models.CclaWhitelistRequestList, error)
 	GetRequestsByCLAGroup(claGroupID string) ([]CLARequestModel, error)
 	UpdateRequestsByCLAGroup(model *project.DBProjectModel) error
 }
 
+// ErrCclaApprovalRequestConflict is returned when the request or the approval list was changed by a concurrent update
+var ErrCclaApprovalRequestConflict = errors.New("approval request or approval list was changed concurrently")
+
 type repository struct {
-	stage          string
-	dynamoDBClient *dynamodb.DynamoDB
-	tableName      string
+	stage              string
+	dynamoDBClient     *dynamodb.DynamoDB
+	tableName          string
+	signatureTableName string
 }
 
 // NewRepository creates a new instance of the whitelist service
 func NewRepository(awsSession *session.Session, stage string) IRepository {
 	return repository{
-		stage:          stage,
-		dynamoDBClient: dynamodb.New(awsSession),
-		tableName:      fmt.Sprintf("cla-%s-ccla-whitelist-requests", stage),
+		stage:              stage,
+		dynamoDBClient:     dynamodb.New(awsSession),
+		tableName:          fmt.Sprintf("cla-%s-ccla-whitelist-requests", stage),
+		signatureTableName: fmt.Sprintf("cla-%s-signatures", stage),
 	}
 }
 
@@ -141,7 +159,8 @@ func (repo repository) GetCclaWhitelistRequest(requestID string) (*CLARequestMod
 	return &requestModel, nil
 }
 
-// ApproveCclaWhitelistRequest approves the specified request
+// ApproveCclaWhitelistRequest approves the specified request, returns ErrCclaApprovalRequestConflict if the request
+// is no longer pending
 func (repo repository) ApproveCclaWhitelistRequest(requestID string) error {
 	f := logrus.Fields{
 		"functionName": "ApproveCclaWhitelistRequest",
@@ -149,29 +168,7 @@ func (repo repository) ApproveCclaWhitelistRequest(requestID string) error {
 	}
 
 	_, currentTime := utils.CurrentTime()
-	input := &dynamodb.UpdateItemInput{
-		Key: map[string]*dynamodb.AttributeValue{
-			"request_id": {
-				S: aws.String(requestID),
-			},
-		},
-		ExpressionAttributeNames: map[string]*string{
-			"#S": aws.String("request_status"),
-			"#M": aws.String("date_modified"),
-		},
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":s": {
-				S: aws.String("approved"),
-			},
-			":m": {
-				S: aws.String(currentTime),
-			},
-		},
-		UpdateExpression: aws.String("SET #S = :s, #M = :m"),
-		TableName:        aws.String(repo.tableName),
-	}
-
-	_, err := repo.dynamoDBClient.UpdateItem(input)
+	err := repo.updateRequestStatus(requestID, StatusApproved, currentTime, StatusPending)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("unable to update approval request with approved status, error: %v", err)
 		return err
@@ -180,7 +177,8 @@ func (repo repository) ApproveCclaWhitelistRequest(requestID string) error {
 	return nil
 }
 
-// RejectCclaWhitelistRequest rejects the specified request
+// RejectCclaWhitelistRequest rejects the specified request, returns ErrCclaApprovalRequestConflict if the request
+// is no longer pending or approved
 func (repo repository) RejectCclaWhitelistRequest(requestID string) error {
 	f := logrus.Fields{
 		"functionName": "RejectCclaWhitelistRequest",
@@ -188,36 +186,160 @@ func (repo repository) RejectCclaWhitelistRequest(requestID string) error {
 	}
 
 	_, currentTime := utils.CurrentTime()
-	input := &dynamodb.UpdateItemInput{
-		Key: map[string]*dynamodb.AttributeValue{
-			"request_id": {
-				S: aws.String(requestID),
+	err := repo.updateRequestStatus(requestID, StatusRejected, currentTime, StatusPending, StatusApproved)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to update approval request with rejected status, error: %v",
+			err)
+		return err
+	}
+
+	return nil
+}
+
+// updateRequestStatus updates the status of the request if it still has one of the current statuses
+func (repo repository) updateRequestStatus(requestID, status, currentTime string, currentStatuses ...string) error {
+	update := repo.requestStatusUpdate(requestID, status, currentTime, currentStatuses...)
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName:                 update.TableName,
+		Key:                       update.Key,
+		ExpressionAttributeNames:  update.ExpressionAttributeNames,
+		ExpressionAttributeValues: update.ExpressionAttributeValues,
+		ConditionExpression:       update.ConditionExpression,
+		UpdateExpression:          update.UpdateExpression,
+	})
+	return conflictError(err)
+}
+
+// ApproveCclaWhitelistRequestWithApprovalListEntry approves the specified request and adds the entry to the
+// approval list of the signature in a single transaction. The transaction fails with ErrCclaApprovalRequestConflict
+// if the request is no longer pending, the signature is no longer signed and approved or the entry is already on the
+// approval list.
+func (repo repository) ApproveCclaWhitelistRequestWithApprovalListEntry(requestID string, entry *ApprovalListEntry) error {
+	f := logrus.Fields{
+		"functionName": "ApproveCclaWhitelistRequestWithApprovalListEntry",
+		"requestID":    requestID,
+		"signatureID":  entry.SignatureID,
+		"column":       entry.Column,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{Update: repo.requestStatusUpdate(requestID, StatusApproved, currentTime, StatusPending)},
+			{
+				Update: &dynamodb.Update{
+					TableName: aws.String(repo.signatureTableName),
+					Key: map[string]*dynamodb.AttributeValue{
+						"signature_id": {S: aws.String(entry.SignatureID)},
+					},
+					ExpressionAttributeNames: map[string]*string{
+						"#L": aws.String(entry.Column),
+						"#S": aws.String("signature_signed"),
+						"#A": aws.String("signature_approved"),
+						"#M": aws.String("date_modified"),
+					},
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":v":     {S: aws.String(entry.Value)},
+						":l":     {L: []*dynamodb.AttributeValue{{S: aws.String(entry.Value)}}},
+						":empty": {L: []*dynamodb.AttributeValue{}},
+						":t":     {BOOL: aws.Bool(true)},
+						":m":     {S: aws.String(currentTime)},
+					},
+					ConditionExpression: aws.String("#S = :t AND #A = :t AND NOT contains(#L, :v)"),
+					UpdateExpression:    aws.String("SET #L = list_append(if_not_exists(#L, :empty), :l), #M = :m"),
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to approve request and update the approval list, error: %v", err)
+		return conflictError(err)
+	}
+
+	return nil
+}
+
+// RejectCclaWhitelistRequestWithApprovalListRemoval rejects the specified request and removes the entry at the
+// index from the approval list of the signature in a single transaction. The transaction fails with
+// ErrCclaApprovalRequestConflict if the request is no longer approved or the approval list was modified in the meantime.
+func (repo repository) RejectCclaWhitelistRequestWithApprovalListRemoval(requestID string, entry *ApprovalListEntry, index int) error {
+	f := logrus.Fields{
+		"functionName": "RejectCclaWhitelistRequestWithApprovalListRemoval",
+		"requestID":    requestID,
+		"signatureID":  entry.SignatureID,
+		"column":       entry.Column,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{Update: repo.requestStatusUpdate(requestID, StatusRejected, currentTime, StatusApproved)},
+			{
+				Update: &dynamodb.Update{
+					TableName: aws.String(repo.signatureTableName),
+					Key: map[string]*dynamodb.AttributeValue{
+						"signature_id": {S: aws.String(entry.SignatureID)},
+					},
+					ExpressionAttributeNames: map[string]*string{
+						"#L": aws.String(entry.Column),
+						"#M": aws.String("date_modified"),
+					},
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":v": {S: aws.String(entry.Value)},
+						":m": {S: aws.String(currentTime)},
+					},
+					ConditionExpression: aws.String(fmt.Sprintf("#L[%d] = :v", index)),
+					UpdateExpression:    aws.String(fmt.Sprintf("REMOVE #L[%d] SET #M = :m", index)),
+				},
 			},
 		},
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to reject request and update the approval list, error: %v", err)
+		return conflictError(err)
+	}
+
+	return nil
+}
+
+// requestStatusUpdate returns the update of the request status, conditional on the request still having one of the
+// current statuses
+func (repo repository) requestStatusUpdate(requestID, status, currentTime string, currentStatuses ...string) *dynamodb.Update {
+	values := map[string]*dynamodb.AttributeValue{
+		":s": {S: aws.String(status)},
+		":m": {S: aws.String(currentTime)},
+	}
+	conditions := make([]string, 0, len(currentStatuses))
+	for i, currentStatus := range currentStatuses {
+		name := fmt.Sprintf(":c%d", i)
+		values[name] = &dynamodb.AttributeValue{S: aws.String(currentStatus)}
+		conditions = append(conditions, "#S = "+name)
+	}
+
+	return &dynamodb.Update{
+		TableName: aws.String(repo.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"request_id": {S: aws.String(requestID)},
+		},
 		ExpressionAttributeNames: map[string]*string{
 			"#S": aws.String("request_status"),
 			"#M": aws.String("date_modified"),
 		},
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":s": {
-				S: aws.String("rejected"),
-			},
-			":m": {
-				S: aws.String(currentTime),
-			},
-		},
-		UpdateExpression: aws.String("SET #S = :s, #M = :m"),
-		TableName:        aws.String(repo.tableName),
+		ExpressionAttributeValues: values,
+		ConditionExpression:       aws.String(strings.Join(conditions, " OR ")),
+		UpdateExpression:          aws.String("SET #S = :s, #M = :m"),
 	}
+}
 
-	_, err := repo.dynamoDBClient.UpdateItem(input)
-	if err != nil {
-		log.WithFields(f).WithError(err).Warnf("unable to update approval request with rejected status, error: %v",
-			err)
-		return err
+// conflictError returns ErrCclaApprovalRequestConflict if the update or transaction failed on one of its conditions
+func conflictError(err error) error {
+	if aerr, ok := err.(awserr.Error); ok {
+		switch aerr.Code() {
+		case dynamodb.ErrCodeConditionalCheckFailedException, dynamodb.ErrCodeTransactionCanceledException:
+			return ErrCclaApprovalRequestConflict
+		}
 	}
-
-	return nil
+	return err
 }
 
 // ListCclaWhitelistRequest list the requests for the specified query parameters
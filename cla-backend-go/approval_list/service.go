@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/communitybridge/easycla/cla-backend-go/company"
 	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/user"
 	"github.com/communitybridge/easycla/cla-backend-go/users"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
@@ -24,6 +26,11 @@ import (
 // errors
 var (
 	ErrCclaApprovalRequestAlreadyExists = errors.New("approval request already exist")
+	ErrCclaApprovalRequestNotFound      = errors.New("approval request not found")
+	ErrApprovalListEntryMissing         = errors.New("approval request has no email or github username")
+	ErrCCLANotSigned                    = errors.New("company has no signed and approved CCLA for the CLA group")
+	ErrCclaApprovalRequestNotReviewable = errors.New("approval request was already reviewed")
+	ErrNotAuthorized                    = errors.New("user is not a CLA manager or approval list delegate of the company CCLA")
 )
 
 // constants
@@ -34,8 +41,8 @@ const (
 // IService interface defines the service methods/functions
 type IService interface {
 	AddCclaWhitelistRequest(ctx context.Context, companyID string, claGroupID string, args models.CclaWhitelistRequestInput) (string, error)
-	ApproveCclaWhitelistRequest(ctx context.Context, companyID, claGroupID, requestID string) error
-	RejectCclaWhitelistRequest(ctx context.Context, companyID, claGroupID, requestID string) error
+	ApproveCclaWhitelistRequest(ctx context.Context, claUser *user.CLAUser, companyID, claGroupID, requestID string) (*ApprovalListEntry, error)
+	RejectCclaWhitelistRequest(ctx context.Context, claUser *user.CLAUser, companyID, claGroupID, requestID string, removeFromApprovalList bool) (*ApprovalListEntry, error)
 	ListCclaWhitelistRequest(companyID string, claGroupID, status *string) (*models.CclaWhitelistRequestList, error)
 	ListCclaWhitelistRequestByCompanyProjectUser(companyID string, claGroupID, status, userID *string) (*models.CclaWhitelistRequestList, error)
 }
@@ -121,93 +128,209 @@ func (s service) AddCclaWhitelistRequest(ctx context.Context, companyID string,
 	return requestID, nil
 }
 
-// ApproveCclaWhitelistRequest is the handler for the approve CLA request
-func (s service) ApproveCclaWhitelistRequest(ctx context.Context, companyID, claGroupID, requestID string) error {
-	err := s.repo.ApproveCclaWhitelistRequest(requestID)
+// ApproveCclaWhitelistRequest is the handler for the approve CLA request, the requester is added to the
+// approval list of the company CCLA signature. Only a pending request can be approved, by a CLA manager or an
+// approval list delegate of the signature. Returns the added entry or nil if it was already on the list.
+func (s service) ApproveCclaWhitelistRequest(ctx context.Context, claUser *user.CLAUser, companyID, claGroupID, requestID string) (*ApprovalListEntry, error) {
+	signature, err := s.getCCLASignature(ctx, companyID, claGroupID)
 	if err != nil {
-		log.Warnf("ApproveCclaWhitelistRequest - problem updating approved list with 'approved' status for request: %s, error: %+v",
-			requestID, err)
-		return err
+		log.Warnf("ApproveCclaWhitelistRequest - unable to lookup signature by company id: %s project id: %s, error: %+v",
+			companyID, claGroupID, err)
+		return nil, err
 	}
 
-	requestModel, err := s.repo.GetCclaWhitelistRequest(requestID)
+	err = s.authorize(ctx, claUser, signature)
+	if err != nil {
+		log.Warnf("ApproveCclaWhitelistRequest - user %s / %s is not allowed to approve request: %s for company id: %s project id: %s, error: %+v",
+			claUser.LFUsername, claUser.LFEmail, requestID, companyID, claGroupID, err)
+		return nil, err
+	}
+
+	requestModel, err := s.getRequest(requestID, companyID, claGroupID, "pending")
 	if err != nil {
 		log.Warnf("ApproveCclaWhitelistRequest - unable to lookup request by id: %s, error: %+v", requestID, err)
-		return err
+		return nil, err
+	}
+
+	entry, err := approvalListEntry(requestModel, signature)
+	if err != nil {
+		log.Warnf("ApproveCclaWhitelistRequest - unable to approve request: %s, error: %+v", requestID, err)
+		return nil, err
+	}
+
+	if approvalListIndex(signature, entry) >= 0 {
+		log.Debugf("ApproveCclaWhitelistRequest - %s is already on the %s approval list of signature: %s",
+			entry.Value, entry.Column, entry.SignatureID)
+		entry = nil
+		err = s.repo.ApproveCclaWhitelistRequest(requestID)
+	} else {
+		err = s.repo.ApproveCclaWhitelistRequestWithApprovalListEntry(requestID, entry)
+	}
+	if err != nil {
+		log.Warnf("ApproveCclaWhitelistRequest - problem updating approved list with 'approved' status for request: %s, error: %+v",
+			requestID, err)
+		return nil, err
 	}
 
 	companyModel, err := s.companyRepo.GetCompany(ctx, companyID)
 	if err != nil {
 		log.Warnf("ApproveCclaWhitelistRequest - unable to lookup company by id: %s, error: %+v", companyID, err)
-		return err
+		return entry, err
 	}
 	claGroupModel, err := s.projectRepo.GetCLAGroupByID(ctx, claGroupID, DontLoadRepoDetails)
 	if err != nil {
 		log.Warnf("ApproveCclaWhitelistRequest - unable to lookup project by id: %s, error: %+v", claGroupID, err)
-		return err
+		return entry, err
 	}
 
 	if requestModel.UserEmails == nil {
 		msg := fmt.Sprintf("ApproveCclaWhitelistRequest - unable to send approval email - email missing for request: %+v, error: %+v",
 			requestModel, err)
 		log.Warnf(msg)
-		return errors.New(msg)
+		return entry, errors.New(msg)
 	}
 
 	// Send the email
 	sendRequestApprovedEmailToRecipient(companyModel, claGroupModel, requestModel.UserName, requestModel.UserEmails[0])
 
-	return nil
+	return entry, nil
 }
 
-// RejectCclaWhitelistRequest is the handler for the decline CLA request
-func (s service) RejectCclaWhitelistRequest(ctx context.Context, companyID, claGroupID, requestID string) error {
-	err := s.repo.RejectCclaWhitelistRequest(requestID)
+// RejectCclaWhitelistRequest is the handler for the reject CLA request. A pending request or a request which was
+// approved before can be rejected, by a CLA manager or an approval list delegate of the signature. If the request
+// was approved and removeFromApprovalList is set, the requester is removed from the approval list of the company
+// CCLA signature. Returns the removed entry or nil if nothing was removed.
+func (s service) RejectCclaWhitelistRequest(ctx context.Context, claUser *user.CLAUser, companyID, claGroupID, requestID string, removeFromApprovalList bool) (*ApprovalListEntry, error) {
+	sig, err := s.getCCLASignature(ctx, companyID, claGroupID)
 	if err != nil {
-		log.Warnf("RejectCclaWhitelistRequest - problem updating approved list with 'rejected' status for request: %s, error: %+v", requestID, err)
-		return err
+		log.Warnf("RejectCclaWhitelistRequest - unable to lookup signature by company id: %s project id: %s, error: %+v",
+			companyID, claGroupID, err)
+		return nil, err
 	}
 
-	requestModel, err := s.repo.GetCclaWhitelistRequest(requestID)
+	err = s.authorize(ctx, claUser, sig)
+	if err != nil {
+		log.Warnf("RejectCclaWhitelistRequest - user %s / %s is not allowed to reject request: %s for company id: %s project id: %s, error: %+v",
+			claUser.LFUsername, claUser.LFEmail, requestID, companyID, claGroupID, err)
+		return nil, err
+	}
+
+	requestModel, err := s.getRequest(requestID, companyID, claGroupID, "pending", "approved")
 	if err != nil {
 		log.Warnf("RejectCclaWhitelistRequest - unable to lookup request by id: %s, error: %+v", requestID, err)
-		return err
+		return nil, err
+	}
+
+	var entry *ApprovalListEntry
+	if removeFromApprovalList && requestModel.RequestStatus == "approved" {
+		entry, err = approvalListEntry(requestModel, sig)
+		if err != nil {
+			log.Warnf("RejectCclaWhitelistRequest - unable to reject request: %s, error: %+v", requestID, err)
+			return nil, err
+		}
+		index := approvalListIndex(sig, entry)
+		if index < 0 {
+			entry = nil
+		} else {
+			// use the value as stored on the list, the lookup ignores the case
+			if entry.IsEmail() {
+				entry.Value = sig.EmailApprovalList[index]
+			} else {
+				entry.Value = sig.GithubUsernameApprovalList[index]
+			}
+			err = s.repo.RejectCclaWhitelistRequestWithApprovalListRemoval(requestID, entry, index)
+			if err != nil {
+				log.Warnf("RejectCclaWhitelistRequest - problem removing %s from the approval list for request: %s, error: %+v",
+					entry.Value, requestID, err)
+				return nil, err
+			}
+		}
+	}
+	if entry == nil {
+		err = s.repo.RejectCclaWhitelistRequest(requestID)
+		if err != nil {
+			log.Warnf("RejectCclaWhitelistRequest - problem updating approved list with 'rejected' status for request: %s, error: %+v", requestID, err)
+			return nil, err
+		}
 	}
 
 	companyModel, err := s.companyRepo.GetCompany(ctx, companyID)
 	if err != nil {
 		log.Warnf("RejectCclaWhitelistRequest - unable to lookup company by id: %s, error: %+v", companyID, err)
-		return err
+		return entry, err
 	}
 	claGroupModel, err := s.projectRepo.GetCLAGroupByID(ctx, claGroupID, DontLoadRepoDetails)
 	if err != nil {
 		log.Warnf("RejectCclaWhitelistRequest - unable to lookup project by id: %s, error: %+v", claGroupID, err)
-		return err
-	}
-
-	signed, approved := true, true
-	sortOrder := utils.SortOrderAscending
-	pageSize := int64(5)
-	sig, sigErr := s.signatureRepo.GetProjectCompanySignatures(ctx, companyID, claGroupID, &signed, &approved, nil, &sortOrder, &pageSize)
-	if sigErr != nil || sig == nil || sig.Signatures == nil {
-		log.Warnf("RejectCclaWhitelistRequest - unable to lookup signature by company id: %s project id: %s - (or no managers), sig: %+v, error: %+v",
-			companyID, claGroupID, sig, err)
-		return err
+		return entry, err
 	}
 
 	if requestModel.UserEmails == nil {
 		msg := fmt.Sprintf("RejectCclaWhitelistRequest - unable to send approval email - email missing for request: %+v, error: %+v",
 			requestModel, err)
 		log.Warnf(msg)
-		return errors.New(msg)
+		return entry, errors.New(msg)
 	}
 
 	// Send the email
-	s.sendRequestRejectedEmailToRecipient(companyModel, claGroupModel, sig.Signatures[0], requestModel.UserName, requestModel.UserEmails[0])
+	s.sendRequestRejectedEmailToRecipient(companyModel, claGroupModel, sig, requestModel.UserName, requestModel.UserEmails[0])
+
+	return entry, nil
+}
 
+// getRequest returns the approval request, ErrCclaApprovalRequestNotFound if it doesn't exist or was made for another
+// company or CLA group, ErrCclaApprovalRequestNotReviewable if it doesn't have one of the statuses
+func (s service) getRequest(requestID, companyID, claGroupID string, statuses ...string) (*CLARequestModel, error) {
+	requestModel, err := s.repo.GetCclaWhitelistRequest(requestID)
+	if err != nil {
+		return nil, err
+	}
+	if requestModel == nil || requestModel.RequestID == "" || requestModel.CompanyID != companyID || requestModel.ProjectID != claGroupID {
+		return nil, ErrCclaApprovalRequestNotFound
+	}
+	if !utils.StringInSlice(requestModel.RequestStatus, statuses) {
+		return nil, ErrCclaApprovalRequestNotReviewable
+	}
+	return requestModel, nil
+}
+
+// authorize returns ErrNotAuthorized unless the user is a CLA manager of the signature or holds an active approval
+// list delegation on the signature
+func (s service) authorize(ctx context.Context, claUser *user.CLAUser, signature *models.Signature) error {
+	for _, manager := range signature.SignatureACL {
+		if claUser.UserID != "" && manager.UserID == claUser.UserID {
+			return nil
+		}
+	}
+	if claUser.LFUsername == "" {
+		return ErrNotAuthorized
+	}
+	delegates, err := s.signatureRepo.GetSignatureDelegates(ctx, signature.SignatureID.String())
+	if err != nil {
+		return err
+	}
+	delegate := signatures.FindDelegate(delegates, claUser.LFUsername)
+	if delegate == nil || !delegate.Allows(time.Now().UTC(), signatures.DelegateScopeApprovalList) {
+		return ErrNotAuthorized
+	}
 	return nil
 }
 
+// getCCLASignature returns the signed and approved CCLA signature of the company, ErrCCLANotSigned if missing
+func (s service) getCCLASignature(ctx context.Context, companyID, claGroupID string) (*models.Signature, error) {
+	signed, approved := true, true
+	sortOrder := utils.SortOrderAscending
+	pageSize := int64(5)
+	sig, err := s.signatureRepo.GetProjectCompanySignatures(ctx, companyID, claGroupID, &signed, &approved, nil, &sortOrder, &pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if sig == nil || len(sig.Signatures) == 0 {
+		return nil, ErrCCLANotSigned
+	}
+	return sig.Signatures[0], nil
+}
+
 // ListCclaWhitelistRequest is the handler for the list CLA request
 func (s service) ListCclaWhitelistRequest(companyID string, claGroupID, status *string) (*models.CclaWhitelistRequestList, error) {
 	return s.repo.ListCclaWhitelistRequest(companyID, claGroupID, status, nil)
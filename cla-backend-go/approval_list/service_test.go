@@ -1,17 +1,29 @@
 // Copyright The Linux Foundation and each contributor to CommunityBridge.
 // SPDX-License-Identifier: MIT
 
-package approval_list
+package approval_list_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list/mock"
+	companyMock "github.com/communitybridge/easycla/cla-backend-go/company/mock"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	projectMock "github.com/communitybridge/easycla/cla-backend-go/project/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	signatureMock "github.com/communitybridge/easycla/cla-backend-go/signatures/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/user"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestRequestApprovedEmailToRecipientContent(t *testing.T) {
-	subject, body, recipients := requestApprovedEmailToRecipientContent(
+	subject, body, recipients := approval_list.RequestApprovedEmailToRecipientContent(
 		&models.Company{
 			CompanyName: "gardenerLtd"},
 		&models.ClaGroup{Version: "v2"},
@@ -24,3 +36,177 @@ func TestRequestApprovedEmailToRecipientContent(t *testing.T) {
 	assert.Contains(t, body, "This is a notification email from EasyCLA regarding the company gardenerLtd")
 	assert.Contains(t, body, "You have now been added to the approval list for gardenerLtd")
 }
+
+var manager = &user.CLAUser{UserID: "manager-1", LFUsername: "manager"}
+
+// newTestService returns the service with the company CCLA signature managed by manager-1
+func newTestService(ctrl *gomock.Controller, sig *models.Signature) (approval_list.IService, *mock.MockIRepository, *signatureMock.MockSignatureRepository) {
+	repo := mock.NewMockIRepository(ctrl)
+	signatureRepo := signatureMock.NewMockSignatureRepository(ctrl)
+	companyRepo := companyMock.NewMockIRepository(ctrl)
+	projectRepo := projectMock.NewMockProjectRepository(ctrl)
+
+	result := &models.Signatures{}
+	if sig != nil {
+		sig.SignatureACL = append(sig.SignatureACL, models.User{UserID: "manager-1", Username: "manager"})
+		result.Signatures = []*models.Signature{sig}
+	}
+	signatureRepo.EXPECT().GetProjectCompanySignatures(gomock.Any(), "company-1", "cla-group-1", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(result, nil).AnyTimes()
+	companyRepo.EXPECT().GetCompany(gomock.Any(), gomock.Any()).Return(&models.Company{CompanyID: "company-1", CompanyName: "gardenerLtd"}, nil).AnyTimes()
+	projectRepo.EXPECT().GetCLAGroupByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.ClaGroup{ProjectID: "cla-group-1", ProjectName: "garden"}, nil).AnyTimes()
+
+	return approval_list.NewService(repo, nil, companyRepo, projectRepo, signatureRepo, "", nil), repo, signatureRepo
+}
+
+// expectRequest returns the request for company-1 and cla-group-1 with the status
+func expectRequest(repo *mock.MockIRepository, request approval_list.CLARequestModel) {
+	request.CompanyID = "company-1"
+	request.ProjectID = "cla-group-1"
+	repo.EXPECT().GetCclaWhitelistRequest(request.RequestID).Return(&request, nil)
+}
+
+func TestApproveCclaWhitelistRequestUpdatesApprovalList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, repo, _ := newTestService(ctrl, &models.Signature{SignatureID: "sig-1", GithubUsernameApprovalList: []string{"Jane"}})
+
+	expectRequest(repo, approval_list.CLARequestModel{RequestID: "request-1", RequestStatus: "pending", UserName: "john", UserEmails: []string{"john@john.com"}})
+	expected := &approval_list.ApprovalListEntry{SignatureID: "sig-1", Column: approval_list.EmailApprovalListColumn, Value: "john@john.com"}
+	repo.EXPECT().ApproveCclaWhitelistRequestWithApprovalListEntry("request-1", expected).Return(nil)
+	entry, err := service.ApproveCclaWhitelistRequest(context.Background(), manager, "company-1", "cla-group-1", "request-1")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, entry)
+
+	// the GitHub username is already on the list - only the status is updated
+	expectRequest(repo, approval_list.CLARequestModel{RequestID: "request-2", RequestStatus: "pending", UserName: "jane", UserEmails: []string{"jane@jane.com"}, UserGithubUsername: "jane"})
+	repo.EXPECT().ApproveCclaWhitelistRequest("request-2").Return(nil)
+	entry, err = service.ApproveCclaWhitelistRequest(context.Background(), manager, "company-1", "cla-group-1", "request-2")
+	assert.Nil(t, err)
+	assert.Nil(t, entry)
+
+	repo.EXPECT().GetCclaWhitelistRequest("request-3").Return(&approval_list.CLARequestModel{}, nil)
+	_, err = service.ApproveCclaWhitelistRequest(context.Background(), manager, "company-1", "cla-group-1", "request-3")
+	assert.Equal(t, approval_list.ErrCclaApprovalRequestNotFound, err)
+}
+
+func TestApproveCclaWhitelistRequestRequiresSignedCCLA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// nothing is loaded or updated without a signature - any request call is an unexpected call
+	service, _, _ := newTestService(ctrl, nil)
+
+	_, err := service.ApproveCclaWhitelistRequest(context.Background(), manager, "company-1", "cla-group-1", "request-1")
+	assert.Equal(t, approval_list.ErrCCLANotSigned, err)
+}
+
+func TestApproveCclaWhitelistRequestAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, repo, signatureRepo := newTestService(ctrl, &models.Signature{SignatureID: "sig-1"})
+	expires := time.Now().UTC().Add(24 * time.Hour).Format(time.RFC3339)
+	signatureRepo.EXPECT().GetSignatureDelegates(gomock.Any(), "sig-1").Return([]signatures.Delegate{
+		{LfUsername: "delegate", Scope: signatures.DelegateScopeApprovalList, DateExpires: expires},
+		{LfUsername: "viewer", Scope: signatures.DelegateScopeViewOnly, DateExpires: expires},
+	}, nil).AnyTimes()
+
+	for _, claUser := range []*user.CLAUser{
+		{UserID: "user-2", LFUsername: "other"},
+		{UserID: "user-3", LFUsername: "viewer"},
+		{UserID: "user-4"},
+	} {
+		_, err := service.ApproveCclaWhitelistRequest(context.Background(), claUser, "company-1", "cla-group-1", "request-1")
+		assert.Equal(t, approval_list.ErrNotAuthorized, err)
+	}
+
+	// a delegate with the approval list scope can approve
+	expectRequest(repo, approval_list.CLARequestModel{RequestID: "request-1", RequestStatus: "pending", UserEmails: []string{"john@john.com"}})
+	repo.EXPECT().ApproveCclaWhitelistRequestWithApprovalListEntry("request-1", gomock.Any()).Return(nil)
+	_, err := service.ApproveCclaWhitelistRequest(context.Background(), &user.CLAUser{UserID: "user-5", LFUsername: "delegate"}, "company-1", "cla-group-1", "request-1")
+	assert.Nil(t, err)
+}
+
+func TestApproveCclaWhitelistRequestValidatesRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, repo, _ := newTestService(ctrl, &models.Signature{SignatureID: "sig-1"})
+
+	// the request was made for another company
+	repo.EXPECT().GetCclaWhitelistRequest("request-1").Return(&approval_list.CLARequestModel{RequestID: "request-1", RequestStatus: "pending", CompanyID: "company-2", ProjectID: "cla-group-1"}, nil)
+	_, err := service.ApproveCclaWhitelistRequest(context.Background(), manager, "company-1", "cla-group-1", "request-1")
+	assert.Equal(t, approval_list.ErrCclaApprovalRequestNotFound, err)
+
+	// the request was made for another CLA group
+	repo.EXPECT().GetCclaWhitelistRequest("request-2").Return(&approval_list.CLARequestModel{RequestID: "request-2", RequestStatus: "pending", CompanyID: "company-1", ProjectID: "cla-group-2"}, nil)
+	_, err = service.ApproveCclaWhitelistRequest(context.Background(), manager, "company-1", "cla-group-1", "request-2")
+	assert.Equal(t, approval_list.ErrCclaApprovalRequestNotFound, err)
+
+	for _, status := range []string{"approved", "rejected"} {
+		expectRequest(repo, approval_list.CLARequestModel{RequestID: "request-3", RequestStatus: status, UserEmails: []string{"john@john.com"}})
+		_, err = service.ApproveCclaWhitelistRequest(context.Background(), manager, "company-1", "cla-group-1", "request-3")
+		assert.Equal(t, approval_list.ErrCclaApprovalRequestNotReviewable, err)
+	}
+
+	// a rejected request can't be rejected again
+	expectRequest(repo, approval_list.CLARequestModel{RequestID: "request-3", RequestStatus: "rejected", UserEmails: []string{"john@john.com"}})
+	_, err = service.RejectCclaWhitelistRequest(context.Background(), manager, "company-1", "cla-group-1", "request-3", false)
+	assert.Equal(t, approval_list.ErrCclaApprovalRequestNotReviewable, err)
+}
+
+func TestRejectCclaWhitelistRequestRemovesApprovalListEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, repo, _ := newTestService(ctrl, &models.Signature{SignatureID: "sig-1", EmailApprovalList: []string{"other@other.com", "John@John.com"}})
+	approved := approval_list.CLARequestModel{RequestID: "request-1", RequestStatus: "approved", UserName: "john", UserEmails: []string{"john@john.com"}}
+
+	expectRequest(repo, approved)
+	repo.EXPECT().RejectCclaWhitelistRequest("request-1").Return(nil)
+	entry, err := service.RejectCclaWhitelistRequest(context.Background(), manager, "company-1", "cla-group-1", "request-1", false)
+	assert.Nil(t, err)
+	assert.Nil(t, entry)
+
+	expectRequest(repo, approved)
+	expected := &approval_list.ApprovalListEntry{SignatureID: "sig-1", Column: approval_list.EmailApprovalListColumn, Value: "John@John.com"}
+	repo.EXPECT().RejectCclaWhitelistRequestWithApprovalListRemoval("request-1", expected, 1).Return(nil)
+	entry, err = service.RejectCclaWhitelistRequest(context.Background(), manager, "company-1", "cla-group-1", "request-1", true)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, entry)
+}
+
+func TestReviewCclaWhitelistRequestConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, repo, _ := newTestService(ctrl, &models.Signature{SignatureID: "sig-1", EmailApprovalList: []string{"john@john.com"}})
+
+	// the request was reviewed by another manager after it was loaded
+	expectRequest(repo, approval_list.CLARequestModel{RequestID: "request-1", RequestStatus: "pending", UserEmails: []string{"jane@jane.com"}})
+	repo.EXPECT().ApproveCclaWhitelistRequestWithApprovalListEntry("request-1", gomock.Any()).Return(approval_list.ErrCclaApprovalRequestConflict)
+	entry, err := service.ApproveCclaWhitelistRequest(context.Background(), manager, "company-1", "cla-group-1", "request-1")
+	assert.Equal(t, approval_list.ErrCclaApprovalRequestConflict, err)
+	assert.Nil(t, entry)
+
+	expectRequest(repo, approval_list.CLARequestModel{RequestID: "request-2", RequestStatus: "approved", UserEmails: []string{"john@john.com"}})
+	repo.EXPECT().RejectCclaWhitelistRequestWithApprovalListRemoval("request-2", gomock.Any(), 0).Return(approval_list.ErrCclaApprovalRequestConflict)
+	entry, err = service.RejectCclaWhitelistRequest(context.Background(), manager, "company-1", "cla-group-1", "request-2", true)
+	assert.Equal(t, approval_list.ErrCclaApprovalRequestConflict, err)
+	assert.Nil(t, entry)
+}
+
+func TestRequestStatusUpdateCondition(t *testing.T) {
+	update := approval_list.RequestStatusUpdate("request-1", approval_list.StatusApproved, "now", approval_list.StatusPending)
+	assert.Equal(t, "#S = :c0", *update.ConditionExpression)
+	assert.Equal(t, approval_list.StatusPending, *update.ExpressionAttributeValues[":c0"].S)
+	assert.Equal(t, approval_list.StatusApproved, *update.ExpressionAttributeValues[":s"].S)
+
+	update = approval_list.RequestStatusUpdate("request-1", approval_list.StatusRejected, "now", approval_list.StatusPending, approval_list.StatusApproved)
+	assert.Equal(t, "#S = :c0 OR #S = :c1", *update.ConditionExpression)
+	assert.Equal(t, approval_list.StatusPending, *update.ExpressionAttributeValues[":c0"].S)
+	assert.Equal(t, approval_list.StatusApproved, *update.ExpressionAttributeValues[":c1"].S)
+
+	// a failed condition of the update or the transaction is a conflict, any other error is returned as is
+	assert.Equal(t, approval_list.ErrCclaApprovalRequestConflict, approval_list.ConflictError(awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "condition failed", nil)))
+	assert.Equal(t, approval_list.ErrCclaApprovalRequestConflict, approval_list.ConflictError(awserr.New(dynamodb.ErrCodeTransactionCanceledException, "transaction cancelled", nil)))
+	throttled := awserr.New(dynamodb.ErrCodeProvisionedThroughputExceededException, "throttled", nil)
+	assert.Equal(t, throttled, approval_list.ConflictError(throttled))
+	assert.Nil(t, approval_list.ConflictError(nil))
+}
@@ -0,0 +1,21 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list_expiry
+
+import "time"
+
+// FormatTime exposes formatTime to the tests
+var FormatTime = formatTime
+
+// NewTestService creates the service with the clock of the test
+func NewTestService(repo Repository, signatureRepo SignatureRepository, claGroupRepo ClaGroupRepository, eventsService EventsService, warningDays func() int, now func() time.Time) Service {
+	return &service{
+		repo:          repo,
+		signatureRepo: signatureRepo,
+		claGroupRepo:  claGroupRepo,
+		eventsService: eventsService,
+		warningDays:   warningDays,
+		now:           now,
+	}
+}
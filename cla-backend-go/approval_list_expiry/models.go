@@ -0,0 +1,98 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list_expiry
+
+import (
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// ListType is the approval list of the CCLA signature holding the entry
+type ListType string
+
+// approval list types
+const (
+	ListTypeEmail          ListType = "email"
+	ListTypeDomain         ListType = "domain"
+	ListTypeGithubUsername ListType = "github_username"
+	ListTypeGithubOrg      ListType = "github_org"
+)
+
+// Status is the state of a time-bound approval list entry
+type Status string
+
+// entry status values
+const (
+	// StatusScheduled entries are not valid yet and are added to the approval list by the expiry job
+	StatusScheduled Status = "scheduled"
+	// StatusActive entries are on the approval list
+	StatusActive Status = "active"
+	// StatusExpired entries were removed from the approval list by the expiry job
+	StatusExpired Status = "expired"
+	// StatusRemoved entries were removed from the approval list by a CLA manager
+	StatusRemoved Status = "removed"
+	// StatusCancelled entries were added again without a validity period and no longer expire
+	StatusCancelled Status = "cancelled"
+)
+
+// Validity is the optional period an approval list entry is valid for
+type Validity struct {
+	ValidFrom  time.Time
+	ValidUntil time.Time
+}
+
+// Entry is the database model for the approval list entries table, it tracks the entries with a validity period
+// and keeps the expired ones as history
+type Entry struct {
+	SignatureID     string   `dynamodbav:"signature_id" json:"signature_id"`
+	EntryID         string   `dynamodbav:"entry_id" json:"entry_id"`
+	ClaGroupID      string   `dynamodbav:"cla_group_id" json:"cla_group_id"`
+	CompanyID       string   `dynamodbav:"company_id" json:"company_id"`
+	ListType        ListType `dynamodbav:"list_type" json:"list_type"`
+	Value           string   `dynamodbav:"value" json:"value"`
+	ValidFrom       string   `dynamodbav:"valid_from,omitempty" json:"valid_from,omitempty"`
+	ValidUntil      string   `dynamodbav:"valid_until,omitempty" json:"valid_until,omitempty"`
+	Status          Status   `dynamodbav:"entry_status" json:"entry_status"`
+	CreatedBy       string   `dynamodbav:"created_by" json:"created_by"`
+	WarningSentDate string   `dynamodbav:"warning_sent_date,omitempty" json:"warning_sent_date,omitempty"`
+	DateActivated   string   `dynamodbav:"date_activated,omitempty" json:"date_activated,omitempty"`
+	DateClosed      string   `dynamodbav:"date_closed,omitempty" json:"date_closed,omitempty"`
+	DateCreated     string   `dynamodbav:"date_created" json:"date_created"`
+	DateModified    string   `dynamodbav:"date_modified" json:"date_modified"`
+}
+
+// isOpen returns true if the entry is still waiting to be activated or expired
+func (e *Entry) isOpen() bool {
+	return e.Status == StatusScheduled || e.Status == StatusActive
+}
+
+// ToModel converts the database model to the API model
+func (e *Entry) ToModel() *models.ApprovalListEntry {
+	return &models.ApprovalListEntry{
+		EntryID:         e.EntryID,
+		SignatureID:     e.SignatureID,
+		ClaGroupID:      e.ClaGroupID,
+		CompanyID:       e.CompanyID,
+		ListType:        string(e.ListType),
+		Value:           e.Value,
+		ValidFrom:       e.ValidFrom,
+		ValidUntil:      e.ValidUntil,
+		Status:          string(e.Status),
+		CreatedBy:       e.CreatedBy,
+		WarningSentDate: e.WarningSentDate,
+		DateActivated:   e.DateActivated,
+		DateClosed:      e.DateClosed,
+		DateCreated:     e.DateCreated,
+		DateModified:    e.DateModified,
+	}
+}
+
+// Report is the outcome of a run of the expiry job
+type Report struct {
+	Activated    int
+	Expired      int
+	WarningsSent int
+	Failed       int
+}
@@ -0,0 +1,136 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list_expiry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// EntryStatusIndex is the index of the approval list entries by status
+const EntryStatusIndex = "entry-status-index"
+
+// Repository interface defines the functions for the approval list entries data model
+type Repository interface {
+	GetEntries(ctx context.Context, signatureID string) ([]*Entry, error)
+	GetEntriesByStatus(ctx context.Context, status Status) ([]*Entry, error)
+	PutEntry(ctx context.Context, entry *Entry) error
+}
+
+type repository struct {
+	stage          string
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewRepository creates a new instance of the approval list entries repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:          stage,
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-approval-list-entries", stage),
+	}
+}
+
+// GetEntries returns the time-bound entries of the signature approval lists, including the expired ones
+func (repo *repository) GetEntries(ctx context.Context, signatureID string) ([]*Entry, error) {
+	f := logrus.Fields{
+		"functionName":   "GetEntries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"signatureID":    signatureID,
+	}
+
+	condition := expression.Key("signature_id").Equal(expression.Value(signatureID))
+	return repo.query(f, condition, nil)
+}
+
+// GetEntriesByStatus returns the entries of all the signatures with the specified status
+func (repo *repository) GetEntriesByStatus(ctx context.Context, status Status) ([]*Entry, error) {
+	f := logrus.Fields{
+		"functionName":   "GetEntriesByStatus",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"status":         status,
+	}
+
+	condition := expression.Key("entry_status").Equal(expression.Value(string(status)))
+	return repo.query(f, condition, aws.String(EntryStatusIndex))
+}
+
+// PutEntry creates or replaces the entry
+func (repo *repository) PutEntry(ctx context.Context, entry *Entry) error {
+	f := logrus.Fields{
+		"functionName":   "PutEntry",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"signatureID":    entry.SignatureID,
+		"entryID":        entry.EntryID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(entry)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal approval list entry, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store approval list entry, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+func (repo *repository) query(f logrus.Fields, condition expression.KeyConditionBuilder, indexName *string) ([]*Entry, error) {
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for approval list entries query, error: %v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.tableName),
+		IndexName:                 indexName,
+	}
+	var resultList []map[string]*dynamodb.AttributeValue
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).Warnf("error retrieving approval list entries, error: %v", err)
+			return nil, err
+		}
+		resultList = append(resultList, results.Items...)
+		if len(results.LastEvaluatedKey) != 0 {
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+
+	var entries []*Entry
+	err = dynamodbattribute.UnmarshalListOfMaps(resultList, &entries)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling approval list entries from database, error: %v", err)
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,493 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list_expiry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// errors
+var (
+	ErrInvalidValidFrom     = errors.New("validFrom must be an RFC3339 timestamp")
+	ErrInvalidValidUntil    = errors.New("validUntil must be an RFC3339 timestamp")
+	ErrValidUntilInPast     = errors.New("validUntil must be in the future")
+	ErrInvalidValidityRange = errors.New("validUntil must be after validFrom")
+)
+
+// SignatureRepository is the part of the signatures repository used to update the approval lists
+type SignatureRepository interface {
+	GetSignature(ctx context.Context, signatureID string) (*v1Models.Signature, error)
+	UpdateApprovalList(ctx context.Context, projectID, companyID string, params *v1Models.ApprovalList) (*v1Models.Signature, error)
+}
+
+// ClaGroupRepository is the part of the project repository used to build the warning emails
+type ClaGroupRepository interface {
+	GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*v1Models.ClaGroup, error)
+}
+
+// EventsService is the part of the events service used to log the activated and expired entries
+type EventsService interface {
+	LogEvent(args *events.LogEventArgs)
+}
+
+// Service interface defines the approval list expiry service methods
+type Service interface {
+	ParseValidity(validFrom, validUntil string) (*Validity, error)
+	ActiveApprovalList(params *v1Models.ApprovalList, validity *Validity) *v1Models.ApprovalList
+	RecordEntries(ctx context.Context, signatureID, claGroupID, companyID string, params *v1Models.ApprovalList, validity *Validity, createdBy string) error
+	GetEntries(ctx context.Context, signatureID string) ([]*Entry, error)
+	ProcessEntries(ctx context.Context) (*Report, error)
+}
+
+type service struct {
+	repo          Repository
+	signatureRepo SignatureRepository
+	claGroupRepo  ClaGroupRepository
+	eventsService EventsService
+	warningDays   func() int
+	now           func() time.Time
+}
+
+// NewService creates a new approval list expiry service, the CLA managers are warned the number of days returned by
+// warningDays before an entry expires - zero disables the warnings. The value is read on every run, so a reloaded
+// configuration applies to the next run.
+func NewService(repo Repository, signatureRepo SignatureRepository, claGroupRepo ClaGroupRepository, eventsService EventsService, warningDays func() int) Service {
+	return &service{
+		repo:          repo,
+		signatureRepo: signatureRepo,
+		claGroupRepo:  claGroupRepo,
+		eventsService: eventsService,
+		warningDays:   warningDays,
+		now:           time.Now,
+	}
+}
+
+// ParseValidity validates the optional validity period of the approval list update. Returns nil if the entries are
+// valid from now on and never expire.
+func (s *service) ParseValidity(validFrom, validUntil string) (*Validity, error) {
+	validity := &Validity{}
+	if validFrom != "" {
+		t, err := time.Parse(time.RFC3339, validFrom)
+		if err != nil {
+			return nil, ErrInvalidValidFrom
+		}
+		validity.ValidFrom = t.UTC()
+	}
+	if validUntil != "" {
+		t, err := time.Parse(time.RFC3339, validUntil)
+		if err != nil {
+			return nil, ErrInvalidValidUntil
+		}
+		validity.ValidUntil = t.UTC()
+	}
+
+	now := s.now()
+	if validity.ValidUntil.IsZero() {
+		if !validity.ValidFrom.After(now) {
+			return nil, nil
+		}
+		return validity, nil
+	}
+	if !validity.ValidUntil.After(now) {
+		return nil, ErrValidUntilInPast
+	}
+	if !validity.ValidFrom.IsZero() && !validity.ValidUntil.After(validity.ValidFrom) {
+		return nil, ErrInvalidValidityRange
+	}
+	return validity, nil
+}
+
+// ActiveApprovalList returns the approval list update to apply now - entries which are not valid yet are left out
+// and added by the expiry job once they become valid
+func (s *service) ActiveApprovalList(params *v1Models.ApprovalList, validity *Validity) *v1Models.ApprovalList {
+	if validity == nil || !validity.ValidFrom.After(s.now()) {
+		return params
+	}
+	active := *params
+	active.AddEmailApprovalList = nil
+	active.AddDomainApprovalList = nil
+	active.AddGithubUsernameApprovalList = nil
+	active.AddGithubOrgApprovalList = nil
+	return &active
+}
+
+// RecordEntries tracks the validity period of the entries added by the approval list update. Entries added without
+// a validity period no longer expire and removed entries are closed - both stay in the history.
+func (s *service) RecordEntries(ctx context.Context, signatureID, claGroupID, companyID string, params *v1Models.ApprovalList, validity *Validity, createdBy string) error {
+	f := logrus.Fields{
+		"functionName":   "RecordEntries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	existing, err := s.repo.GetEntries(ctx, signatureID)
+	if err != nil {
+		return err
+	}
+	open := make(map[string]*Entry)
+	for _, entry := range existing {
+		if entry.isOpen() {
+			open[entryKey(entry.ListType, entry.Value)] = entry
+		}
+	}
+
+	now := s.now()
+	for _, change := range approvalListChanges(params) {
+		for _, value := range change.remove {
+			if entry, ok := open[entryKey(change.listType, value)]; ok {
+				if err := s.closeEntry(ctx, entry, StatusRemoved, now); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, value := range change.add {
+			entry, ok := open[entryKey(change.listType, value)]
+			if validity == nil {
+				if ok {
+					if err := s.closeEntry(ctx, entry, StatusCancelled, now); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			if !ok {
+				entryID, err := uuid.NewV4()
+				if err != nil {
+					log.WithFields(f).Warnf("unable to generate a UUID for the approval list entry, error: %v", err)
+					return err
+				}
+				entry = &Entry{
+					SignatureID: signatureID,
+					EntryID:     entryID.String(),
+					ClaGroupID:  claGroupID,
+					CompanyID:   companyID,
+					ListType:    change.listType,
+					Value:       value,
+					CreatedBy:   createdBy,
+					DateCreated: utils.TimeToString(now),
+				}
+			}
+			entry.ValidFrom = formatTime(validity.ValidFrom)
+			entry.ValidUntil = formatTime(validity.ValidUntil)
+			entry.WarningSentDate = ""
+			entry.DateModified = utils.TimeToString(now)
+			if validity.ValidFrom.After(now) {
+				entry.Status = StatusScheduled
+			} else {
+				entry.Status = StatusActive
+				if entry.DateActivated == "" {
+					entry.DateActivated = utils.TimeToString(now)
+				}
+			}
+			if err := s.repo.PutEntry(ctx, entry); err != nil {
+				return err
+			}
+			log.WithFields(f).Debugf("recorded %s approval list entry %s valid from: %s until: %s",
+				entry.ListType, entry.Value, entry.ValidFrom, entry.ValidUntil)
+		}
+	}
+	return nil
+}
+
+// GetEntries returns the time-bound entries of the signature approval lists, newest first
+func (s *service) GetEntries(ctx context.Context, signatureID string) ([]*Entry, error) {
+	entries, err := s.repo.GetEntries(ctx, signatureID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].DateCreated > entries[j].DateCreated
+	})
+	return entries, nil
+}
+
+// ProcessEntries adds the scheduled entries which became valid to the approval lists, removes the expired entries
+// and warns the CLA managers about the entries which expire soon
+func (s *service) ProcessEntries(ctx context.Context) (*Report, error) {
+	f := logrus.Fields{
+		"functionName":   "ProcessEntries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	report := &Report{}
+	now := s.now()
+	warningDays := s.warningDays()
+
+	scheduled, err := s.repo.GetEntriesByStatus(ctx, StatusScheduled)
+	if err != nil {
+		return nil, err
+	}
+	var activated []*Entry
+	for _, entry := range scheduled {
+		if parseTime(entry.ValidFrom).After(now) {
+			continue
+		}
+		if err := s.activateEntry(ctx, entry, now); err != nil {
+			log.WithFields(f).Warnf("unable to activate %s approval list entry %s of signature: %s, error: %+v",
+				entry.ListType, entry.Value, entry.SignatureID, err)
+			report.Failed++
+			continue
+		}
+		report.Activated++
+		activated = append(activated, entry)
+	}
+
+	active, err := s.repo.GetEntriesByStatus(ctx, StatusActive)
+	if err != nil {
+		return nil, err
+	}
+	active = append(active, activated...)
+
+	expiring := make(map[string][]*Entry)
+	handled := make(map[string]bool)
+	for _, entry := range active {
+		if entry.ValidUntil == "" || handled[entry.EntryID] {
+			continue
+		}
+		handled[entry.EntryID] = true
+
+		validUntil := parseTime(entry.ValidUntil)
+		if !validUntil.After(now) {
+			if err := s.expireEntry(ctx, entry, now); err != nil {
+				log.WithFields(f).Warnf("unable to expire %s approval list entry %s of signature: %s, error: %+v",
+					entry.ListType, entry.Value, entry.SignatureID, err)
+				report.Failed++
+				continue
+			}
+			report.Expired++
+			continue
+		}
+
+		if warningDays > 0 && entry.WarningSentDate == "" && validUntil.Sub(now) <= time.Duration(warningDays)*24*time.Hour {
+			expiring[entry.SignatureID] = append(expiring[entry.SignatureID], entry)
+		}
+	}
+
+	for signatureID, entries := range expiring {
+		if err := s.sendExpiryWarning(ctx, signatureID, entries); err != nil {
+			log.WithFields(f).Warnf("unable to warn the CLA managers of signature: %s about expiring entries, error: %+v", signatureID, err)
+			report.Failed++
+			continue
+		}
+		for _, entry := range entries {
+			entry.WarningSentDate = utils.TimeToString(now)
+			entry.DateModified = utils.TimeToString(now)
+			if err := s.repo.PutEntry(ctx, entry); err != nil {
+				report.Failed++
+				continue
+			}
+			report.WarningsSent++
+		}
+	}
+
+	return report, nil
+}
+
+// activateEntry adds the scheduled entry to the signature approval list
+func (s *service) activateEntry(ctx context.Context, entry *Entry, now time.Time) error {
+	_, err := s.signatureRepo.UpdateApprovalList(ctx, entry.ClaGroupID, entry.CompanyID, approvalListUpdate(entry, true))
+	if err != nil {
+		return err
+	}
+
+	entry.Status = StatusActive
+	entry.DateActivated = utils.TimeToString(now)
+	entry.DateModified = utils.TimeToString(now)
+	if err := s.repo.PutEntry(ctx, entry); err != nil {
+		return err
+	}
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType: events.ClaApprovalListUpdated,
+		ProjectID: entry.ClaGroupID,
+		CompanyID: entry.CompanyID,
+		EventData: &events.CLAApprovalListEntryActivatedData{
+			ListType:  string(entry.ListType),
+			Value:     entry.Value,
+			ValidFrom: entry.ValidFrom,
+		},
+	})
+	return nil
+}
+
+// expireEntry removes the expired entry from the signature approval list, the entry is kept as history
+func (s *service) expireEntry(ctx context.Context, entry *Entry, now time.Time) error {
+	_, err := s.signatureRepo.UpdateApprovalList(ctx, entry.ClaGroupID, entry.CompanyID, approvalListUpdate(entry, false))
+	if err != nil {
+		return err
+	}
+
+	if err := s.closeEntry(ctx, entry, StatusExpired, now); err != nil {
+		return err
+	}
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType: events.ClaApprovalListUpdated,
+		ProjectID: entry.ClaGroupID,
+		CompanyID: entry.CompanyID,
+		EventData: &events.CLAApprovalListEntryExpiredData{
+			ListType:   string(entry.ListType),
+			Value:      entry.Value,
+			ValidUntil: entry.ValidUntil,
+		},
+	})
+	return nil
+}
+
+func (s *service) closeEntry(ctx context.Context, entry *Entry, status Status, now time.Time) error {
+	entry.Status = status
+	entry.DateClosed = utils.TimeToString(now)
+	entry.DateModified = utils.TimeToString(now)
+	return s.repo.PutEntry(ctx, entry)
+}
+
+// sendExpiryWarning emails the CLA managers of the signature the entries which expire soon
+func (s *service) sendExpiryWarning(ctx context.Context, signatureID string, entries []*Entry) error {
+	signature, err := s.signatureRepo.GetSignature(ctx, signatureID)
+	if err != nil {
+		return err
+	}
+	if signature == nil {
+		return fmt.Errorf("signature %s not found", signatureID)
+	}
+	claGroup, err := s.claGroupRepo.GetCLAGroupByID(ctx, entries[0].ClaGroupID, false)
+	if err != nil {
+		return err
+	}
+
+	var recipients []string
+	for _, manager := range signature.SignatureACL {
+		if email := managerEmail(manager); email != "" {
+			recipients = append(recipients, email)
+		}
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("signature %s has no CLA manager with an email", signatureID)
+	}
+
+	subject, body := expiryWarningEmailContent(signature.SignatureReferenceName, claGroup, entries)
+	return utils.SendEmail(subject, body, recipients)
+}
+
+func expiryWarningEmailContent(companyName string, claGroup *v1Models.ClaGroup, entries []*Entry) (string, string) {
+	summary := "<ul>"
+	for _, entry := range entries {
+		summary += fmt.Sprintf("<li>%s %s expires on %s</li>", listTypeName(entry.ListType), entry.Value, entry.ValidUntil)
+	}
+	summary += "</ul>"
+
+	subject := fmt.Sprintf("EasyCLA: Approval List Entries Expiring for %s on %s", companyName, claGroup.ProjectName)
+	body := fmt.Sprintf(`
+<p>Hello CLA Manager,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>The following entries of the EasyCLA approval list for %s for project %s will expire soon:</p>
+%s
+<p>Expired entries are removed from the approval list automatically. To extend the validity of an entry, add it
+again to the approval list with a new end date.</p>
+%s
+%s`,
+		claGroup.ProjectName, companyName, claGroup.ProjectName, summary,
+		utils.GetEmailHelpContent(claGroup.Version == utils.V2), utils.GetEmailSignOffContent())
+	return subject, body
+}
+
+type approvalListChange struct {
+	listType ListType
+	add      []string
+	remove   []string
+}
+
+func approvalListChanges(params *v1Models.ApprovalList) []approvalListChange {
+	return []approvalListChange{
+		{listType: ListTypeEmail, add: params.AddEmailApprovalList, remove: params.RemoveEmailApprovalList},
+		{listType: ListTypeDomain, add: params.AddDomainApprovalList, remove: params.RemoveDomainApprovalList},
+		{listType: ListTypeGithubUsername, add: params.AddGithubUsernameApprovalList, remove: params.RemoveGithubUsernameApprovalList},
+		{listType: ListTypeGithubOrg, add: params.AddGithubOrgApprovalList, remove: params.RemoveGithubOrgApprovalList},
+	}
+}
+
+// approvalListUpdate returns the approval list update which adds or removes the entry
+func approvalListUpdate(entry *Entry, add bool) *v1Models.ApprovalList {
+	values := []string{entry.Value}
+	params := &v1Models.ApprovalList{}
+	switch {
+	case entry.ListType == ListTypeEmail && add:
+		params.AddEmailApprovalList = values
+	case entry.ListType == ListTypeEmail:
+		params.RemoveEmailApprovalList = values
+	case entry.ListType == ListTypeDomain && add:
+		params.AddDomainApprovalList = values
+	case entry.ListType == ListTypeDomain:
+		params.RemoveDomainApprovalList = values
+	case entry.ListType == ListTypeGithubUsername && add:
+		params.AddGithubUsernameApprovalList = values
+	case entry.ListType == ListTypeGithubUsername:
+		params.RemoveGithubUsernameApprovalList = values
+	case add:
+		params.AddGithubOrgApprovalList = values
+	default:
+		params.RemoveGithubOrgApprovalList = values
+	}
+	return params
+}
+
+func listTypeName(listType ListType) string {
+	switch listType {
+	case ListTypeEmail:
+		return "Email"
+	case ListTypeDomain:
+		return "Domain"
+	case ListTypeGithubUsername:
+		return "GitHub Username"
+	default:
+		return "GitHub Organization"
+	}
+}
+
+func managerEmail(manager v1Models.User) string {
+	if manager.LfEmail != "" {
+		return manager.LfEmail
+	}
+	for _, email := range manager.Emails {
+		if email != "" {
+			return email
+		}
+	}
+	return ""
+}
+
+// entryKey identifies the entry on the approval list - the values are compared case insensitive
+func entryKey(listType ListType, value string) string {
+	return string(listType) + "#" + strings.ToLower(value)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return utils.TimeToString(t)
+}
+
+func parseTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,219 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list_expiry_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry/mock"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+// putEntries returns the entries stored by the service, as they were when stored
+func putEntries(repo *mock.MockRepository, times int) *[]*approval_list_expiry.Entry {
+	var stored []*approval_list_expiry.Entry
+	repo.EXPECT().PutEntry(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entry *approval_list_expiry.Entry) error {
+		copied := *entry
+		stored = append(stored, &copied)
+		return nil
+	}).Times(times)
+	return &stored
+}
+
+func newTestService(ctrl *gomock.Controller, repo approval_list_expiry.Repository, signatureRepo approval_list_expiry.SignatureRepository, eventsService approval_list_expiry.EventsService) approval_list_expiry.Service {
+	return newTestServiceWithWarningDays(ctrl, repo, signatureRepo, eventsService, func() int { return 7 })
+}
+
+func newTestServiceWithWarningDays(ctrl *gomock.Controller, repo approval_list_expiry.Repository, signatureRepo approval_list_expiry.SignatureRepository, eventsService approval_list_expiry.EventsService, warningDays func() int) approval_list_expiry.Service {
+	claGroupRepo := mock.NewMockClaGroupRepository(ctrl)
+	claGroupRepo.EXPECT().GetCLAGroupByID(gomock.Any(), gomock.Any(), false).DoAndReturn(
+		func(ctx context.Context, claGroupID string, loadRepoDetails bool) (*v1Models.ClaGroup, error) {
+			return &v1Models.ClaGroup{ProjectID: claGroupID, ProjectName: "project"}, nil
+		}).AnyTimes()
+	return approval_list_expiry.NewTestService(repo, signatureRepo, claGroupRepo, eventsService, warningDays, func() time.Time { return testNow })
+}
+
+func TestParseValidity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s := newTestService(ctrl, mock.NewMockRepository(ctrl), mock.NewMockSignatureRepository(ctrl), mock.NewMockEventsService(ctrl))
+
+	validity, err := s.ParseValidity("", "")
+	assert.Nil(t, err)
+	assert.Nil(t, validity)
+
+	validity, err = s.ParseValidity("2020-09-01T00:00:00Z", "")
+	assert.Nil(t, err)
+	assert.Nil(t, validity, "a start date in the past without an end date is a permanent entry")
+
+	validity, err = s.ParseValidity("2020-10-05T00:00:00Z", "2020-11-01T00:00:00Z")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2020, 10, 5, 0, 0, 0, 0, time.UTC), validity.ValidFrom)
+	assert.Equal(t, time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC), validity.ValidUntil)
+
+	_, err = s.ParseValidity("tomorrow", "")
+	assert.Equal(t, approval_list_expiry.ErrInvalidValidFrom, err)
+	_, err = s.ParseValidity("", "2020-13-01")
+	assert.Equal(t, approval_list_expiry.ErrInvalidValidUntil, err)
+	_, err = s.ParseValidity("", "2020-09-01T00:00:00Z")
+	assert.Equal(t, approval_list_expiry.ErrValidUntilInPast, err)
+	_, err = s.ParseValidity("2020-11-01T00:00:00Z", "2020-10-05T00:00:00Z")
+	assert.Equal(t, approval_list_expiry.ErrInvalidValidityRange, err)
+}
+
+func TestActiveApprovalList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s := newTestService(ctrl, mock.NewMockRepository(ctrl), mock.NewMockSignatureRepository(ctrl), mock.NewMockEventsService(ctrl))
+	params := &v1Models.ApprovalList{
+		AddEmailApprovalList:    []string{"john@example.com"},
+		RemoveEmailApprovalList: []string{"jane@example.com"},
+	}
+
+	assert.Equal(t, params, s.ActiveApprovalList(params, nil))
+	assert.Equal(t, params, s.ActiveApprovalList(params, &approval_list_expiry.Validity{ValidUntil: testNow.Add(time.Hour)}))
+
+	active := s.ActiveApprovalList(params, &approval_list_expiry.Validity{ValidFrom: testNow.Add(time.Hour)})
+	assert.Empty(t, active.AddEmailApprovalList)
+	assert.Equal(t, []string{"jane@example.com"}, active.RemoveEmailApprovalList)
+	assert.Equal(t, []string{"john@example.com"}, params.AddEmailApprovalList, "the request parameters are not changed")
+}
+
+func TestRecordEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := utils.TimeToString(testNow)
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetEntries(gomock.Any(), "sig-1").Return([]*approval_list_expiry.Entry{
+		{SignatureID: "sig-1", EntryID: "removed", ListType: approval_list_expiry.ListTypeEmail, Value: "jane@example.com", Status: approval_list_expiry.StatusActive},
+		{SignatureID: "sig-1", EntryID: "cancelled", ListType: approval_list_expiry.ListTypeDomain, Value: "example.com", Status: approval_list_expiry.StatusActive},
+	}, nil).Times(2)
+	stored := putEntries(repo, 3)
+	s := newTestService(ctrl, repo, mock.NewMockSignatureRepository(ctrl), mock.NewMockEventsService(ctrl))
+
+	validity := &approval_list_expiry.Validity{ValidFrom: testNow.Add(24 * time.Hour), ValidUntil: testNow.Add(30 * 24 * time.Hour)}
+	err := s.RecordEntries(context.Background(), "sig-1", "cla-group-1", "company-1", &v1Models.ApprovalList{
+		AddEmailApprovalList:    []string{"John@Example.com"},
+		RemoveEmailApprovalList: []string{"JANE@example.com"},
+	}, validity, "manager")
+	assert.Nil(t, err)
+
+	// the open entry of an entry added again without a validity period no longer expires
+	err = s.RecordEntries(context.Background(), "sig-1", "cla-group-1", "company-1", &v1Models.ApprovalList{
+		AddDomainApprovalList: []string{"example.com"},
+	}, nil, "manager")
+	assert.Nil(t, err)
+
+	if assert.Len(t, *stored, 3) {
+		removed, scheduled, cancelled := (*stored)[0], (*stored)[1], (*stored)[2]
+		assert.Equal(t, "removed", removed.EntryID)
+		assert.Equal(t, approval_list_expiry.StatusRemoved, removed.Status)
+		assert.Equal(t, now, removed.DateClosed)
+
+		assert.Equal(t, "John@Example.com", scheduled.Value)
+		assert.Equal(t, approval_list_expiry.StatusScheduled, scheduled.Status)
+		assert.Equal(t, "cla-group-1", scheduled.ClaGroupID)
+		assert.Equal(t, "manager", scheduled.CreatedBy)
+		assert.Equal(t, approval_list_expiry.FormatTime(validity.ValidFrom), scheduled.ValidFrom)
+		assert.Equal(t, approval_list_expiry.FormatTime(validity.ValidUntil), scheduled.ValidUntil)
+
+		assert.Equal(t, "cancelled", cancelled.EntryID)
+		assert.Equal(t, approval_list_expiry.StatusCancelled, cancelled.Status)
+	}
+}
+
+func TestProcessEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	utils.SetEmailSender(&utils.MockEmailSender{})
+	due := &approval_list_expiry.Entry{SignatureID: "sig-1", EntryID: "due", ClaGroupID: "cla-group-1", CompanyID: "company-1", ListType: approval_list_expiry.ListTypeEmail, Value: "due@example.com",
+		Status: approval_list_expiry.StatusScheduled, ValidFrom: "2020-10-01T00:00:00Z", ValidUntil: "2020-12-01T00:00:00Z"}
+	future := &approval_list_expiry.Entry{SignatureID: "sig-1", EntryID: "future", ClaGroupID: "cla-group-1", CompanyID: "company-1", ListType: approval_list_expiry.ListTypeEmail, Value: "future@example.com",
+		Status: approval_list_expiry.StatusScheduled, ValidFrom: "2020-10-20T00:00:00Z"}
+	expired := &approval_list_expiry.Entry{SignatureID: "sig-1", EntryID: "expired", ClaGroupID: "cla-group-1", CompanyID: "company-1", ListType: approval_list_expiry.ListTypeGithubUsername, Value: "octocat",
+		Status: approval_list_expiry.StatusActive, ValidUntil: "2020-10-01T00:00:00Z"}
+	expiring := &approval_list_expiry.Entry{SignatureID: "sig-1", EntryID: "expiring", ClaGroupID: "cla-group-1", CompanyID: "company-1", ListType: approval_list_expiry.ListTypeDomain, Value: "example.com",
+		Status: approval_list_expiry.StatusActive, ValidUntil: "2020-10-05T00:00:00Z"}
+
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetEntriesByStatus(gomock.Any(), approval_list_expiry.StatusScheduled).Return([]*approval_list_expiry.Entry{due, future}, nil)
+	repo.EXPECT().GetEntriesByStatus(gomock.Any(), approval_list_expiry.StatusActive).Return([]*approval_list_expiry.Entry{expired, expiring}, nil)
+	stored := putEntries(repo, 3)
+	signatureRepo := mock.NewMockSignatureRepository(ctrl)
+	signatureRepo.EXPECT().UpdateApprovalList(gomock.Any(), "cla-group-1", "company-1", &v1Models.ApprovalList{
+		AddEmailApprovalList: []string{"due@example.com"},
+	}).Return(&v1Models.Signature{}, nil)
+	signatureRepo.EXPECT().UpdateApprovalList(gomock.Any(), "cla-group-1", "company-1", &v1Models.ApprovalList{
+		RemoveGithubUsernameApprovalList: []string{"octocat"},
+	}).Return(&v1Models.Signature{}, nil)
+	signatureRepo.EXPECT().GetSignature(gomock.Any(), "sig-1").Return(&v1Models.Signature{
+		SignatureReferenceName: "company",
+		SignatureACL:           []v1Models.User{{LfEmail: "manager@example.com"}},
+	}, nil)
+	eventsService := mock.NewMockEventsService(ctrl)
+	eventsService.EXPECT().LogEvent(gomock.Any()).Times(2)
+	s := newTestService(ctrl, repo, signatureRepo, eventsService)
+
+	report, err := s.ProcessEntries(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &approval_list_expiry.Report{Activated: 1, Expired: 1, WarningsSent: 1}, report)
+
+	statuses := make(map[string]*approval_list_expiry.Entry)
+	for _, entry := range *stored {
+		statuses[entry.EntryID] = entry
+	}
+	assert.Equal(t, approval_list_expiry.StatusActive, statuses["due"].Status)
+	assert.Nil(t, statuses["future"])
+	assert.Equal(t, approval_list_expiry.StatusExpired, statuses["expired"].Status)
+	assert.NotEmpty(t, statuses["expiring"].WarningSentDate)
+
+	// the warnings are sent once - nothing is updated, stored or emailed
+	repo.EXPECT().GetEntriesByStatus(gomock.Any(), approval_list_expiry.StatusScheduled).Return([]*approval_list_expiry.Entry{future}, nil)
+	repo.EXPECT().GetEntriesByStatus(gomock.Any(), approval_list_expiry.StatusActive).Return([]*approval_list_expiry.Entry{statuses["due"], statuses["expiring"]}, nil)
+	report, err = s.ProcessEntries(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &approval_list_expiry.Report{}, report)
+}
+
+func TestProcessEntriesReadsWarningDaysOnEveryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	utils.SetEmailSender(&utils.MockEmailSender{})
+	expiring := &approval_list_expiry.Entry{SignatureID: "sig-1", EntryID: "expiring", ClaGroupID: "cla-group-1", CompanyID: "company-1", ListType: approval_list_expiry.ListTypeEmail, Value: "jane@example.com",
+		Status: approval_list_expiry.StatusActive, ValidUntil: "2020-10-05T00:00:00Z"}
+
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetEntriesByStatus(gomock.Any(), approval_list_expiry.StatusScheduled).Return(nil, nil).Times(2)
+	repo.EXPECT().GetEntriesByStatus(gomock.Any(), approval_list_expiry.StatusActive).Return([]*approval_list_expiry.Entry{expiring}, nil).Times(2)
+	stored := putEntries(repo, 1)
+	signatureRepo := mock.NewMockSignatureRepository(ctrl)
+	signatureRepo.EXPECT().GetSignature(gomock.Any(), "sig-1").Return(&v1Models.Signature{
+		SignatureReferenceName: "company",
+		SignatureACL:           []v1Models.User{{LfEmail: "manager@example.com"}},
+	}, nil)
+	warningDays := 0
+	s := newTestServiceWithWarningDays(ctrl, repo, signatureRepo, mock.NewMockEventsService(ctrl), func() int { return warningDays })
+
+	// the warnings are disabled
+	report, err := s.ProcessEntries(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &approval_list_expiry.Report{}, report)
+
+	// the reloaded configuration applies to the next run
+	warningDays = 7
+	report, err = s.ProcessEntries(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &approval_list_expiry.Report{WarningsSent: 1}, report)
+	if assert.Len(t, *stored, 1) {
+		assert.NotEmpty(t, (*stored)[0].WarningSentDate)
+	}
+}
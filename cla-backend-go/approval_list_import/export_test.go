@@ -0,0 +1,10 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list_import
+
+// ParseRows exposes parseRows to the tests
+var ParseRows = parseRows
+
+// ImportBatchSize exposes importBatchSize to the tests
+const ImportBatchSize = importBatchSize
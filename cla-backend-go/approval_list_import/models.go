@@ -0,0 +1,93 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list_import
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// Format is the document format of an approval list import or export
+type Format string
+
+// supported formats
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// Action is the change a row of the import makes to the approval list
+type Action string
+
+// row actions
+const (
+	ActionAdd    Action = "add"
+	ActionRemove Action = "remove"
+)
+
+// RowStatus is the outcome of a row of the import
+type RowStatus string
+
+// row status values
+const (
+	// RowStatusAdd rows add a new entry to the approval list
+	RowStatusAdd RowStatus = "add"
+	// RowStatusRemove rows remove an existing entry from the approval list
+	RowStatusRemove RowStatus = "remove"
+	// RowStatusUnchanged rows match the current approval list
+	RowStatusUnchanged RowStatus = "unchanged"
+	// RowStatusInvalid rows failed the validation and are not applied
+	RowStatusInvalid RowStatus = "invalid"
+)
+
+// Row is a single entry of an approval list import or export
+type Row struct {
+	Line     int                           `json:"-"`
+	ListType approval_list_expiry.ListType `json:"type"`
+	Value    string                        `json:"value"`
+	Action   Action                        `json:"action,omitempty"`
+	Status   RowStatus                     `json:"-"`
+	Error    string                        `json:"-"`
+}
+
+// Input is the approval list import request
+type Input struct {
+	Format  Format
+	Content string
+	DryRun  bool
+}
+
+// Result is the preview or the outcome of an approval list import
+type Result struct {
+	DryRun    bool
+	Rows      []*Row
+	Added     int
+	Removed   int
+	Unchanged int
+	Invalid   int
+}
+
+// ToModel converts the import result to the API model, the invalid rows are also returned as a CSV error report
+func (r *Result) ToModel() *models.ApprovalListImportResult {
+	result := &models.ApprovalListImportResult{
+		DryRun:      r.DryRun,
+		Added:       int64(r.Added),
+		Removed:     int64(r.Removed),
+		Unchanged:   int64(r.Unchanged),
+		Invalid:     int64(r.Invalid),
+		Rows:        []*models.ApprovalListImportRow{},
+		ErrorReport: errorReport(r.Rows),
+	}
+	for _, row := range r.Rows {
+		result.Rows = append(result.Rows, &models.ApprovalListImportRow{
+			Line:     int64(row.Line),
+			ListType: string(row.ListType),
+			Value:    row.Value,
+			Action:   string(row.Action),
+			Status:   string(row.Status),
+			Error:    row.Error,
+		})
+	}
+	return result
+}
@@ -0,0 +1,223 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list_import
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// MaxImportRows is the maximum number of rows of a single import
+const MaxImportRows = 10000
+
+const (
+	typeColumn   = "type"
+	valueColumn  = "value"
+	actionColumn = "action"
+)
+
+// parseRows reads the rows of the import document, the rows are validated separately
+func parseRows(format Format, content string) ([]*Row, error) {
+	var rows []*Row
+	var err error
+	switch format {
+	case FormatCSV:
+		rows, err = parseCSV(content)
+	case FormatJSON:
+		rows, err = parseJSON(content)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %s", ErrInvalidImport, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: the document has no approval list entries", ErrInvalidImport)
+	}
+	if len(rows) > MaxImportRows {
+		return nil, fmt.Errorf("%w: the document has %d entries, the limit is %d", ErrInvalidImport, len(rows), MaxImportRows)
+	}
+	return rows, nil
+}
+
+// parseCSV reads a CSV document with a header row - the type and value columns are required, the action column is optional
+func parseCSV(content string) ([]*Row, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	typeIndex, hasType := columns[typeColumn]
+	valueIndex, hasValue := columns[valueColumn]
+	if !hasType || !hasValue {
+		return nil, fmt.Errorf("%w: the header row must have the %s and %s columns", ErrInvalidImport, typeColumn, valueColumn)
+	}
+	actionIndex, hasAction := columns[actionColumn]
+
+	var rows []*Row
+	// the header is row 1, blank lines are not counted
+	line := 1
+	for {
+		line++
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+		if isBlank(record) {
+			continue
+		}
+
+		row := &Row{
+			Line:     line,
+			ListType: approval_list_expiry.ListType(field(record, typeIndex)),
+			Value:    field(record, valueIndex),
+		}
+		if hasAction {
+			row.Action = Action(field(record, actionIndex))
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseJSON reads a JSON array of entries with the type, value and optional action properties
+func parseJSON(content string) ([]*Row, error) {
+	var rows []*Row
+	if err := json.Unmarshal([]byte(content), &rows); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	for i, row := range rows {
+		if row == nil {
+			rows[i] = &Row{}
+			row = rows[i]
+		}
+		row.Line = i + 1
+		row.ListType = approval_list_expiry.ListType(strings.TrimSpace(string(row.ListType)))
+		row.Value = strings.TrimSpace(row.Value)
+		row.Action = Action(strings.TrimSpace(string(row.Action)))
+	}
+	return rows, nil
+}
+
+// validateRow normalizes the row and returns the reason the row is invalid, if any
+func validateRow(row *Row) string {
+	row.ListType = approval_list_expiry.ListType(strings.ToLower(string(row.ListType)))
+	row.Action = Action(strings.ToLower(string(row.Action)))
+	if row.Action == "" {
+		row.Action = ActionAdd
+	}
+	if row.Action != ActionAdd && row.Action != ActionRemove {
+		return fmt.Sprintf("invalid action %s - must be %s or %s", row.Action, ActionAdd, ActionRemove)
+	}
+	if row.Value == "" {
+		return "missing value"
+	}
+
+	switch row.ListType {
+	case approval_list_expiry.ListTypeEmail:
+		if !utils.ValidEmail(row.Value) {
+			return fmt.Sprintf("invalid email %s", row.Value)
+		}
+	case approval_list_expiry.ListTypeDomain:
+		if msg, valid := utils.ValidDomain(row.Value); !valid {
+			return fmt.Sprintf("invalid domain %s - %s", row.Value, msg)
+		}
+	case approval_list_expiry.ListTypeGithubUsername:
+		if msg, valid := utils.ValidGitHubUsername(row.Value); !valid {
+			return fmt.Sprintf("invalid GitHub Username %s - %s", row.Value, msg)
+		}
+	case approval_list_expiry.ListTypeGithubOrg:
+		if msg, valid := utils.ValidGitHubOrg(row.Value); !valid {
+			return fmt.Sprintf("invalid GitHub Org %s - %s", row.Value, msg)
+		}
+	default:
+		return fmt.Sprintf("invalid type %s - must be one of %s, %s, %s or %s", row.ListType,
+			approval_list_expiry.ListTypeEmail, approval_list_expiry.ListTypeDomain,
+			approval_list_expiry.ListTypeGithubUsername, approval_list_expiry.ListTypeGithubOrg)
+	}
+	return ""
+}
+
+// formatRows writes the rows in the export format, the document can be imported again as is
+func formatRows(format Format, rows []*Row) ([]byte, error) {
+	switch format {
+	case FormatCSV:
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		if err := writer.Write([]string{typeColumn, valueColumn}); err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if err := writer.Write([]string{string(row.ListType), row.Value}); err != nil {
+				return nil, err
+			}
+		}
+		writer.Flush()
+		return buf.Bytes(), writer.Error()
+	case FormatJSON:
+		if rows == nil {
+			rows = []*Row{}
+		}
+		return json.Marshal(rows)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %s", ErrInvalidImport, format)
+	}
+}
+
+// errorReport returns the invalid rows as a CSV document, or an empty string if all the rows are valid
+func errorReport(rows []*Row) string {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	invalid := 0
+	for _, row := range rows {
+		if row.Status != RowStatusInvalid {
+			continue
+		}
+		if invalid == 0 {
+			writer.Write([]string{"line", typeColumn, valueColumn, actionColumn, "error"}) // nolint
+		}
+		invalid++
+		writer.Write([]string{strconv.Itoa(row.Line), string(row.ListType), row.Value, string(row.Action), row.Error}) // nolint
+	}
+	writer.Flush()
+	return buf.String()
+}
+
+func field(record []string, index int) string {
+	if index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
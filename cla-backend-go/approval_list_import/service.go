@@ -0,0 +1,356 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list_import
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// importBatchSize is the maximum number of approval list changes applied by a single update
+const importBatchSize = 500
+
+// errors
+var (
+	ErrInvalidImport     = errors.New("invalid approval list import")
+	ErrCompanyNotFound   = errors.New("company not found")
+	ErrCLAGroupNotFound  = errors.New("CLA group not found")
+	ErrSignatureNotFound = errors.New("signed and approved CCLA signature not found")
+)
+
+// CompanyService is the part of the company service used to resolve the company
+type CompanyService interface {
+	GetCompanyByExternalID(ctx context.Context, companySFID string) (*v1Models.Company, error)
+}
+
+// ClaGroupService is the part of the project service used to resolve the CLA group
+type ClaGroupService interface {
+	GetCLAGroupByID(ctx context.Context, claGroupID string) (*v1Models.ClaGroup, error)
+}
+
+// SignatureService is the part of the signature service used to read and update the approval lists
+type SignatureService interface {
+	GetProjectCompanySignature(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*v1Models.Signature, error)
+	ImportApprovalList(ctx context.Context, authUser *auth.User, claGroupModel *v1Models.ClaGroup, companyModel *v1Models.Company, claGroupID string, params *v1Models.ApprovalList) (*v1Models.Signature, error)
+}
+
+// DomainVerificationService is the part of the domain verification service used to validate the domain entries
+type DomainVerificationService interface {
+	CheckApprovalListDomains(ctx context.Context, companyID string, domains []string) error
+}
+
+// EventsService is the part of the events service used to log the import
+type EventsService interface {
+	LogEvent(args *events.LogEventArgs)
+}
+
+// Service interface defines the approval list import and export service methods
+type Service interface {
+	Export(ctx context.Context, claGroupID, companySFID string, format Format) ([]byte, error)
+	Import(ctx context.Context, authUser *auth.User, claGroupID, companySFID string, input *Input) (*Result, error)
+}
+
+type service struct {
+	companyService     CompanyService
+	projectService     ClaGroupService
+	signatureService   SignatureService
+	domainVerification DomainVerificationService
+	eventsService      EventsService
+}
+
+// NewService creates a new approval list import service
+func NewService(companyService CompanyService, projectService ClaGroupService, signatureService SignatureService, domainVerification DomainVerificationService, eventsService EventsService) Service {
+	return &service{
+		companyService:     companyService,
+		projectService:     projectService,
+		signatureService:   signatureService,
+		domainVerification: domainVerification,
+		eventsService:      eventsService,
+	}
+}
+
+// Export returns the approval lists of the company CCLA signature as a document which can be imported again
+func (s *service) Export(ctx context.Context, claGroupID, companySFID string, format Format) ([]byte, error) {
+	_, _, signature, err := s.loadSignature(ctx, claGroupID, companySFID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*Row
+	for _, list := range approvalLists(signature) {
+		for _, value := range list.values {
+			rows = append(rows, &Row{ListType: list.listType, Value: value})
+		}
+	}
+	return formatRows(format, rows)
+}
+
+// Import validates the rows of the document and compares them with the current approval lists. Unless it is a dry
+// run, the valid rows are applied in batches and the invalid rows are returned in the error report. The contributors
+// are not notified of an import, the CLA managers receive one summary email.
+func (s *service) Import(ctx context.Context, authUser *auth.User, claGroupID, companySFID string, input *Input) (*Result, error) {
+	f := logrus.Fields{
+		"functionName":   "Import",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"companySFID":    companySFID,
+		"format":         input.Format,
+		"dryRun":         input.DryRun,
+	}
+
+	rows, err := parseRows(input.Format, input.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	companyModel, claGroupModel, signature, err := s.loadSignature(ctx, claGroupID, companySFID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.compare(ctx, companyModel.CompanyID, signature, rows)
+	result.DryRun = input.DryRun
+	log.WithFields(f).Debugf("import of %d rows - add: %d, remove: %d, unchanged: %d, invalid: %d",
+		len(rows), result.Added, result.Removed, result.Unchanged, result.Invalid)
+	if input.DryRun || result.Added+result.Removed == 0 {
+		return result, nil
+	}
+
+	err = s.apply(ctx, authUser, claGroupModel, companyModel, result)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to apply the approval list import, error: %+v", err)
+		return nil, err
+	}
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:         events.ClaApprovalListImported,
+		ProjectID:         claGroupModel.ProjectID,
+		ClaGroupModel:     claGroupModel,
+		CompanyID:         companyModel.CompanyID,
+		CompanyModel:      companyModel,
+		LfUsername:        authUser.UserName,
+		ExternalProjectID: claGroupModel.ProjectExternalID,
+		EventData: &events.CLAApprovalListImportedData{
+			Format:    string(input.Format),
+			Added:     result.Added,
+			Removed:   result.Removed,
+			Unchanged: result.Unchanged,
+			Invalid:   result.Invalid,
+		},
+	})
+	s.sendImportSummaryEmails(authUser, companyModel, claGroupModel, signature.SignatureACL, result)
+	return result, nil
+}
+
+// apply updates the approval lists in batches of the changed rows. If a batch fails after the first one was applied,
+// the rows of the remaining batches are reported as invalid and the batches which were applied are kept.
+func (s *service) apply(ctx context.Context, authUser *auth.User, claGroupModel *v1Models.ClaGroup, companyModel *v1Models.Company, result *Result) error {
+	var changed []*Row
+	for _, row := range result.Rows {
+		if row.Status == RowStatusAdd || row.Status == RowStatusRemove {
+			changed = append(changed, row)
+		}
+	}
+
+	for start := 0; start < len(changed); start += importBatchSize {
+		end := start + importBatchSize
+		if end > len(changed) {
+			end = len(changed)
+		}
+		_, err := s.signatureService.ImportApprovalList(ctx, authUser, claGroupModel, companyModel, claGroupModel.ProjectID, approvalListUpdate(changed[start:end]))
+		if err != nil {
+			if start == 0 {
+				return err
+			}
+			for _, row := range changed[start:] {
+				if row.Status == RowStatusAdd {
+					result.Added--
+				} else {
+					result.Removed--
+				}
+				setInvalid(result, row, fmt.Sprintf("not applied: %v", err))
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// sendImportSummaryEmails notifies the CLA managers of the import with the number of changed entries, instead of
+// one email per changed entry
+func (s *service) sendImportSummaryEmails(authUser *auth.User, companyModel *v1Models.Company, claGroupModel *v1Models.ClaGroup, claManagers []v1Models.User, result *Result) {
+	subject := fmt.Sprintf("EasyCLA: Approval List Update for %s on %s", companyModel.CompanyName, claGroupModel.ProjectName)
+	for _, claManager := range claManagers {
+		recipient := claManager.LfEmail
+		if recipient == "" && len(claManager.Emails) > 0 {
+			recipient = claManager.Emails[0]
+		}
+		if recipient == "" {
+			continue
+		}
+		body := fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>The EasyCLA approval list for %s for project %s was modified by an import of %s.</p>
+<p>%d entries were added and %d entries were removed.</p>
+<p>Contributors with previously failed pull requests to %s can close and re-open the pull request to force a recheck by
+the EasyCLA system.</p>
+%s
+%s`,
+			claManager.Username, claGroupModel.ProjectName, companyModel.CompanyName, claGroupModel.ProjectName, authUser.UserName,
+			result.Added, result.Removed, claGroupModel.ProjectName,
+			utils.GetEmailHelpContent(claGroupModel.Version == utils.V2), utils.GetEmailSignOffContent())
+		if err := utils.SendEmail(subject, body, []string{recipient}); err != nil {
+			log.Warnf("problem sending email with subject: %s to recipient: %s, error: %+v", subject, recipient, err)
+		}
+	}
+}
+
+// compare validates the rows and sets the status of each row against the current approval lists
+func (s *service) compare(ctx context.Context, companyID string, signature *v1Models.Signature, rows []*Row) *Result {
+	current := make(map[string]bool)
+	for _, list := range approvalLists(signature) {
+		for _, value := range list.values {
+			current[rowKey(list.listType, value)] = true
+		}
+	}
+
+	result := &Result{Rows: rows}
+	seen := make(map[string]int)
+	var domainRows []*Row
+	for _, row := range rows {
+		if msg := validateRow(row); msg != "" {
+			setInvalid(result, row, msg)
+			continue
+		}
+
+		key := rowKey(row.ListType, row.Value)
+		if line, ok := seen[key]; ok {
+			setInvalid(result, row, fmt.Sprintf("duplicate of row %d", line))
+			continue
+		}
+		seen[key] = row.Line
+
+		switch {
+		case row.Action == ActionAdd && current[key], row.Action == ActionRemove && !current[key]:
+			row.Status = RowStatusUnchanged
+			result.Unchanged++
+		case row.Action == ActionRemove:
+			row.Status = RowStatusRemove
+			result.Removed++
+		case row.ListType == approval_list_expiry.ListTypeDomain:
+			domainRows = append(domainRows, row)
+		default:
+			row.Status = RowStatusAdd
+			result.Added++
+		}
+	}
+
+	// Domains only take effect once the company proved it owns them - the rows are only checked one by one to
+	// report the failed domains when the check of all domains fails
+	var domains []string
+	for _, row := range domainRows {
+		domains = append(domains, row.Value)
+	}
+	allVerified := len(domains) == 0 || s.domainVerification.CheckApprovalListDomains(ctx, companyID, domains) == nil
+	for _, row := range domainRows {
+		if !allVerified {
+			if err := s.domainVerification.CheckApprovalListDomains(ctx, companyID, []string{row.Value}); err != nil {
+				setInvalid(result, row, err.Error())
+				continue
+			}
+		}
+		row.Status = RowStatusAdd
+		result.Added++
+	}
+	return result
+}
+
+func (s *service) loadSignature(ctx context.Context, claGroupID, companySFID string) (*v1Models.Company, *v1Models.ClaGroup, *v1Models.Signature, error) {
+	companyModel, err := s.companyService.GetCompanyByExternalID(ctx, companySFID)
+	if err != nil || companyModel == nil {
+		return nil, nil, nil, ErrCompanyNotFound
+	}
+	claGroupModel, err := s.projectService.GetCLAGroupByID(ctx, claGroupID)
+	if err != nil || claGroupModel == nil {
+		return nil, nil, nil, ErrCLAGroupNotFound
+	}
+
+	signed, approved := true, true
+	pageSize := int64(1)
+	signature, err := s.signatureService.GetProjectCompanySignature(ctx, companyModel.CompanyID, claGroupID, &signed, &approved, nil, &pageSize)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if signature == nil {
+		return nil, nil, nil, ErrSignatureNotFound
+	}
+	return companyModel, claGroupModel, signature, nil
+}
+
+type approvalList struct {
+	listType approval_list_expiry.ListType
+	values   []string
+}
+
+func approvalLists(signature *v1Models.Signature) []approvalList {
+	return []approvalList{
+		{listType: approval_list_expiry.ListTypeEmail, values: signature.EmailApprovalList},
+		{listType: approval_list_expiry.ListTypeDomain, values: signature.DomainApprovalList},
+		{listType: approval_list_expiry.ListTypeGithubUsername, values: signature.GithubUsernameApprovalList},
+		{listType: approval_list_expiry.ListTypeGithubOrg, values: signature.GithubOrgApprovalList},
+	}
+}
+
+// approvalListUpdate returns the approval list update of the rows which change the approval lists
+func approvalListUpdate(rows []*Row) *v1Models.ApprovalList {
+	params := &v1Models.ApprovalList{}
+	for _, row := range rows {
+		if row.Status != RowStatusAdd && row.Status != RowStatusRemove {
+			continue
+		}
+		add := row.Status == RowStatusAdd
+		switch {
+		case row.ListType == approval_list_expiry.ListTypeEmail && add:
+			params.AddEmailApprovalList = append(params.AddEmailApprovalList, row.Value)
+		case row.ListType == approval_list_expiry.ListTypeEmail:
+			params.RemoveEmailApprovalList = append(params.RemoveEmailApprovalList, row.Value)
+		case row.ListType == approval_list_expiry.ListTypeDomain && add:
+			params.AddDomainApprovalList = append(params.AddDomainApprovalList, row.Value)
+		case row.ListType == approval_list_expiry.ListTypeDomain:
+			params.RemoveDomainApprovalList = append(params.RemoveDomainApprovalList, row.Value)
+		case row.ListType == approval_list_expiry.ListTypeGithubUsername && add:
+			params.AddGithubUsernameApprovalList = append(params.AddGithubUsernameApprovalList, row.Value)
+		case row.ListType == approval_list_expiry.ListTypeGithubUsername:
+			params.RemoveGithubUsernameApprovalList = append(params.RemoveGithubUsernameApprovalList, row.Value)
+		case add:
+			params.AddGithubOrgApprovalList = append(params.AddGithubOrgApprovalList, row.Value)
+		default:
+			params.RemoveGithubOrgApprovalList = append(params.RemoveGithubOrgApprovalList, row.Value)
+		}
+	}
+	return params
+}
+
+func setInvalid(result *Result, row *Row, msg string) {
+	row.Status = RowStatusInvalid
+	row.Error = msg
+	result.Invalid++
+}
+
+// rowKey identifies the entry on the approval list - the values are compared case insensitive
+func rowKey(listType approval_list_expiry.ListType, value string) string {
+	return string(listType) + "#" + strings.ToLower(value)
+}
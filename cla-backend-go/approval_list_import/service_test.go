@@ -0,0 +1,207 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list_import_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_import"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_import/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestService returns the service for the company-sfid company with the CCLA signature of cla-group-1
+func newTestService(ctrl *gomock.Controller, signature *models.Signature) (approval_list_import.Service, *mock.MockSignatureService, *mock.MockEventsService) {
+	companyService := mock.NewMockCompanyService(ctrl)
+	companyService.EXPECT().GetCompanyByExternalID(gomock.Any(), "company-sfid").
+		Return(&models.Company{CompanyID: "company-1", CompanyExternalID: "company-sfid"}, nil).AnyTimes()
+	projectService := mock.NewMockClaGroupService(ctrl)
+	projectService.EXPECT().GetCLAGroupByID(gomock.Any(), "cla-group-1").Return(&models.ClaGroup{ProjectID: "cla-group-1"}, nil).AnyTimes()
+	signatureService := mock.NewMockSignatureService(ctrl)
+	signatureService.EXPECT().GetProjectCompanySignature(gomock.Any(), "company-1", "cla-group-1", gomock.Any(), gomock.Any(), nil, gomock.Any()).
+		Return(signature, nil).AnyTimes()
+	domainVerification := mock.NewMockDomainVerificationService(ctrl)
+	domainVerification.EXPECT().CheckApprovalListDomains(gomock.Any(), "company-1", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, companyID string, domains []string) error {
+			for _, domain := range domains {
+				if domain != "example.com" {
+					return errors.New(domain + ": domain is not verified")
+				}
+			}
+			return nil
+		}).AnyTimes()
+	eventsService := mock.NewMockEventsService(ctrl)
+	return approval_list_import.NewService(companyService, projectService, signatureService, domainVerification, eventsService), signatureService, eventsService
+}
+
+func TestParseRows(t *testing.T) {
+	rows, err := approval_list_import.ParseRows(approval_list_import.FormatCSV, "Type, Value, Action\nemail,john@example.com,add\n\ngithub_username, octocat ,remove\ndomain\n")
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(rows)) {
+		assert.Equal(t, &approval_list_import.Row{Line: 2, ListType: approval_list_expiry.ListTypeEmail, Value: "john@example.com", Action: approval_list_import.ActionAdd}, rows[0])
+		assert.Equal(t, &approval_list_import.Row{Line: 3, ListType: approval_list_expiry.ListTypeGithubUsername, Value: "octocat", Action: approval_list_import.ActionRemove}, rows[1])
+		assert.Equal(t, "", rows[2].Value)
+	}
+
+	rows, err = approval_list_import.ParseRows(approval_list_import.FormatJSON, `[{"type":"github_org","value":"kubernetes"}]`)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(rows)) {
+		assert.Equal(t, &approval_list_import.Row{Line: 1, ListType: approval_list_expiry.ListTypeGithubOrg, Value: "kubernetes"}, rows[0])
+	}
+
+	_, err = approval_list_import.ParseRows(approval_list_import.FormatCSV, "email,value\njohn@example.com,x\n")
+	assert.True(t, errors.Is(err, approval_list_import.ErrInvalidImport))
+	_, err = approval_list_import.ParseRows(approval_list_import.FormatCSV, "type,value\n")
+	assert.True(t, errors.Is(err, approval_list_import.ErrInvalidImport))
+	_, err = approval_list_import.ParseRows(approval_list_import.FormatJSON, `{"type":"email"}`)
+	assert.True(t, errors.Is(err, approval_list_import.ErrInvalidImport))
+	_, err = approval_list_import.ParseRows("xml", "<list/>")
+	assert.True(t, errors.Is(err, approval_list_import.ErrInvalidImport))
+}
+
+func TestImportDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// a dry run does not change the approval list - no ImportApprovalList or LogEvent calls are expected
+	service, _, _ := newTestService(ctrl, &models.Signature{
+		EmailApprovalList: []string{"John@Example.com", "jane@example.com"},
+	})
+
+	result, err := service.Import(context.Background(), &auth.User{UserName: "manager"}, "cla-group-1", "company-sfid", &approval_list_import.Input{
+		Format:  approval_list_import.FormatCSV,
+		Content: "type,value,action\nemail,john@example.com,add\nemail,jane@example.com,remove\nemail,new@example.com,add\n",
+		DryRun:  true,
+	})
+	assert.Nil(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 1, result.Added)
+	assert.Equal(t, 1, result.Removed)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, 0, result.Invalid)
+	assert.Equal(t, approval_list_import.RowStatusUnchanged, result.Rows[0].Status)
+	assert.Equal(t, approval_list_import.RowStatusRemove, result.Rows[1].Status)
+	assert.Equal(t, approval_list_import.RowStatusAdd, result.Rows[2].Status)
+}
+
+func TestImportPartialApply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, signatureService, eventsService := newTestService(ctrl, &models.Signature{})
+	signatureService.EXPECT().ImportApprovalList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "cla-group-1", &models.ApprovalList{
+		AddEmailApprovalList:          []string{"john@example.com"},
+		AddDomainApprovalList:         []string{"example.com"},
+		AddGithubUsernameApprovalList: []string{"octocat"},
+	}).Return(&models.Signature{}, nil)
+	eventsService.EXPECT().LogEvent(gomock.Any()).Do(func(args *events.LogEventArgs) {
+		assert.Equal(t, events.ClaApprovalListImported, args.EventType)
+		assert.Equal(t, &events.CLAApprovalListImportedData{Format: "json", Added: 3, Invalid: 4}, args.EventData)
+	})
+
+	result, err := service.Import(context.Background(), &auth.User{UserName: "manager"}, "cla-group-1", "company-sfid", &approval_list_import.Input{
+		Format: approval_list_import.FormatJSON,
+		Content: `[
+			{"type": "email", "value": "john@example.com"},
+			{"type": "email", "value": "not-an-email"},
+			{"type": "domain", "value": "example.com"},
+			{"type": "domain", "value": "unverified.org"},
+			{"type": "github_username", "value": "octocat"},
+			{"type": "EMAIL", "value": "JOHN@example.com"},
+			{"type": "gitlab_username", "value": "octocat"}
+		]`,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.Added)
+	assert.Equal(t, 4, result.Invalid)
+
+	report := strings.Split(strings.TrimSpace(result.ToModel().ErrorReport), "\n")
+	if assert.Equal(t, 5, len(report)) {
+		assert.Equal(t, "line,type,value,action,error", report[0])
+		assert.Equal(t, "2,email,not-an-email,add,invalid email not-an-email", report[1])
+		assert.Equal(t, "4,domain,unverified.org,add,unverified.org: domain is not verified", report[2])
+		assert.Equal(t, "6,email,JOHN@example.com,add,duplicate of row 1", report[3])
+	}
+}
+
+func TestExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _, _ := newTestService(ctrl, &models.Signature{
+		EmailApprovalList:          []string{"john@example.com"},
+		DomainApprovalList:         []string{"example.com"},
+		GithubUsernameApprovalList: []string{"octocat"},
+	})
+
+	content, err := service.Export(context.Background(), "cla-group-1", "company-sfid", approval_list_import.FormatCSV)
+	assert.Nil(t, err)
+	assert.Equal(t, "type,value\nemail,john@example.com\ndomain,example.com\ngithub_username,octocat\n", string(content))
+
+	content, err = service.Export(context.Background(), "cla-group-1", "company-sfid", approval_list_import.FormatJSON)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"type":"email","value":"john@example.com"},{"type":"domain","value":"example.com"},{"type":"github_username","value":"octocat"}]`, string(content))
+
+	result, err := service.Import(context.Background(), &auth.User{UserName: "manager"}, "cla-group-1", "company-sfid", &approval_list_import.Input{
+		Format:  approval_list_import.FormatJSON,
+		Content: string(content),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.Unchanged, "the export can be imported again")
+}
+
+func TestImportBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, signatureService, eventsService := newTestService(ctrl, &models.Signature{})
+	content := "type,value\n"
+	for i := 0; i < 2*approval_list_import.ImportBatchSize+10; i++ {
+		content += fmt.Sprintf("email,user%d@example.com\n", i)
+	}
+
+	// the second batch fails - the first batch is kept and the rows of the remaining batches are reported
+	gomock.InOrder(
+		signatureService.EXPECT().ImportApprovalList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "cla-group-1", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error) {
+				assert.Equal(t, approval_list_import.ImportBatchSize, len(params.AddEmailApprovalList))
+				assert.Equal(t, "user0@example.com", params.AddEmailApprovalList[0])
+				return &models.Signature{}, nil
+			}),
+		signatureService.EXPECT().ImportApprovalList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "cla-group-1", gomock.Any()).
+			Return(nil, errors.New("update failed")),
+	)
+	eventsService.EXPECT().LogEvent(gomock.Any()).Do(func(args *events.LogEventArgs) {
+		assert.Equal(t, &events.CLAApprovalListImportedData{Format: "csv", Added: approval_list_import.ImportBatchSize, Invalid: approval_list_import.ImportBatchSize + 10}, args.EventData)
+	})
+
+	result, err := service.Import(context.Background(), &auth.User{UserName: "manager"}, "cla-group-1", "company-sfid", &approval_list_import.Input{
+		Format:  approval_list_import.FormatCSV,
+		Content: content,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, approval_list_import.ImportBatchSize, result.Added)
+	assert.Equal(t, approval_list_import.ImportBatchSize+10, result.Invalid)
+	assert.Equal(t, approval_list_import.RowStatusAdd, result.Rows[approval_list_import.ImportBatchSize-1].Status)
+	assert.Equal(t, approval_list_import.RowStatusInvalid, result.Rows[approval_list_import.ImportBatchSize].Status)
+	assert.Equal(t, "not applied: update failed", result.Rows[approval_list_import.ImportBatchSize].Error)
+
+	// nothing was applied when the first batch fails
+	signatureService.EXPECT().ImportApprovalList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "cla-group-1", gomock.Any()).
+		Return(nil, errors.New("forbidden"))
+	_, err = service.Import(context.Background(), &auth.User{UserName: "manager"}, "cla-group-1", "company-sfid", &approval_list_import.Input{
+		Format:  approval_list_import.FormatCSV,
+		Content: content,
+	})
+	assert.NotNil(t, err)
+}
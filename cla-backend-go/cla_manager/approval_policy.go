@@ -0,0 +1,223 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	v2Models "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// approver roles
+const (
+	// ApproverRoleCLAManager approvers are on the ACL of the company CCLA signature
+	ApproverRoleCLAManager = "cla_manager"
+	// ApproverRoleCompanyAdmin approvers are company admins of the organization
+	ApproverRoleCompanyAdmin = "company_admin"
+)
+
+// approval policy limits
+const (
+	MaxRequiredApprovals = 10
+	MaxPolicyDays        = 365
+)
+
+// ErrInvalidApprovalPolicy is returned when the approval policy update is not valid
+var ErrInvalidApprovalPolicy = errors.New("invalid CLA manager approval policy")
+
+// ApprovalPolicy is the database model for the CLA manager approval policies table, it defines how the CLA manager
+// requests of a company are approved
+type ApprovalPolicy struct {
+	CompanyID string `dynamodbav:"company_id" json:"company_id"`
+	// RequiredApprovals is the number of approvals - the N of N of M
+	RequiredApprovals int    `dynamodbav:"required_approvals" json:"required_approvals"`
+	ApproverRole      string `dynamodbav:"approver_role" json:"approver_role"`
+	// Approvers are the LF usernames allowed to approve - the M of N of M, any user with the approver role when empty
+	Approvers []string `dynamodbav:"approvers,omitempty" json:"approvers,omitempty"`
+	// ExpiryDays is the number of days after which a pending request expires, zero to never expire
+	ExpiryDays int `dynamodbav:"expiry_days" json:"expiry_days"`
+	// ReminderDays is the number of days between the reminders sent to the approvers, zero to send none
+	ReminderDays int    `dynamodbav:"reminder_days" json:"reminder_days"`
+	UpdatedBy    string `dynamodbav:"updated_by,omitempty" json:"updated_by,omitempty"`
+	DateCreated  string `dynamodbav:"date_created,omitempty" json:"date_created,omitempty"`
+	DateModified string `dynamodbav:"date_modified,omitempty" json:"date_modified,omitempty"`
+}
+
+// DefaultApprovalPolicy returns the policy of the companies without one - a single approval by any CLA manager
+func DefaultApprovalPolicy(companyID string) *ApprovalPolicy {
+	return &ApprovalPolicy{
+		CompanyID:         companyID,
+		RequiredApprovals: 1,
+		ApproverRole:      ApproverRoleCLAManager,
+	}
+}
+
+// Validate normalizes the approvers and checks the policy limits
+func (p *ApprovalPolicy) Validate() error {
+	var approvers []string
+	seen := map[string]bool{}
+	for _, approver := range p.Approvers {
+		approver = strings.TrimSpace(approver)
+		if approver == "" || seen[strings.ToLower(approver)] {
+			continue
+		}
+		seen[strings.ToLower(approver)] = true
+		approvers = append(approvers, approver)
+	}
+	p.Approvers = approvers
+
+	switch {
+	case p.ApproverRole != ApproverRoleCLAManager && p.ApproverRole != ApproverRoleCompanyAdmin:
+		return fmt.Errorf("%w: approver role must be %s or %s", ErrInvalidApprovalPolicy, ApproverRoleCLAManager, ApproverRoleCompanyAdmin)
+	case p.RequiredApprovals < 1 || p.RequiredApprovals > MaxRequiredApprovals:
+		return fmt.Errorf("%w: required approvals must be between 1 and %d", ErrInvalidApprovalPolicy, MaxRequiredApprovals)
+	case len(p.Approvers) > 0 && p.RequiredApprovals > len(p.Approvers):
+		return fmt.Errorf("%w: required approvals must not exceed the %d approvers", ErrInvalidApprovalPolicy, len(p.Approvers))
+	case p.ExpiryDays < 0 || p.ExpiryDays > MaxPolicyDays:
+		return fmt.Errorf("%w: expiry days must be between 0 and %d", ErrInvalidApprovalPolicy, MaxPolicyDays)
+	case p.ReminderDays < 0 || p.ReminderDays > MaxPolicyDays:
+		return fmt.Errorf("%w: reminder days must be between 0 and %d", ErrInvalidApprovalPolicy, MaxPolicyDays)
+	}
+	return nil
+}
+
+// isApprover returns true if the user may approve or deny the requests under the policy
+func (p *ApprovalPolicy) isApprover(approver *Approver) bool {
+	if approver == nil || approver.LFUsername == "" {
+		return false
+	}
+	if p.ApproverRole == ApproverRoleCompanyAdmin && !approver.IsCompanyAdmin {
+		return false
+	}
+	if p.ApproverRole != ApproverRoleCompanyAdmin && !approver.IsCLAManager {
+		return false
+	}
+	if len(p.Approvers) == 0 {
+		return true
+	}
+	for _, lfUsername := range p.Approvers {
+		if strings.EqualFold(lfUsername, approver.LFUsername) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToModel converts the database model to the API model
+func (p *ApprovalPolicy) ToModel() *v2Models.ClaManagerApprovalPolicy {
+	approvers := p.Approvers
+	if approvers == nil {
+		approvers = []string{}
+	}
+	return &v2Models.ClaManagerApprovalPolicy{
+		CompanyID:         p.CompanyID,
+		RequiredApprovals: int64(p.RequiredApprovals),
+		ApproverRole:      p.ApproverRole,
+		Approvers:         approvers,
+		ExpiryDays:        int64(p.ExpiryDays),
+		ReminderDays:      int64(p.ReminderDays),
+		UpdatedBy:         p.UpdatedBy,
+		DateCreated:       p.DateCreated,
+		DateModified:      p.DateModified,
+	}
+}
+
+// requestPolicy returns the approval policy the request was made under, the requests made before the approval
+// policies existed need a single CLA manager approval
+func requestPolicy(request *CLAManagerRequest) *ApprovalPolicy {
+	if request.RequiredApprovals == 0 {
+		return DefaultApprovalPolicy(request.CompanyID)
+	}
+	return &ApprovalPolicy{
+		CompanyID:         request.CompanyID,
+		RequiredApprovals: request.RequiredApprovals,
+		ApproverRole:      request.ApproverRole,
+		Approvers:         request.Approvers,
+	}
+}
+
+// PolicyRepository interface defines the functions for the CLA manager approval policies data model
+type PolicyRepository interface {
+	GetApprovalPolicy(companyID string) (*ApprovalPolicy, error)
+	PutApprovalPolicy(policy *ApprovalPolicy) error
+}
+
+type policyRepository struct {
+	stage          string
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewPolicyRepository creates a new instance of the CLA manager approval policies repository
+func NewPolicyRepository(awsSession *session.Session, stage string) PolicyRepository {
+	return &policyRepository{
+		stage:          stage,
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-cla-manager-approval-policies", stage),
+	}
+}
+
+// GetApprovalPolicy returns the approval policy of the company or nil if the company has none
+func (repo *policyRepository) GetApprovalPolicy(companyID string) (*ApprovalPolicy, error) {
+	f := logrus.Fields{
+		"functionName": "GetApprovalPolicy",
+		"tableName":    repo.tableName,
+		"companyID":    companyID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {S: aws.String(companyID)},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the CLA manager approval policy, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, nil
+	}
+
+	var policy ApprovalPolicy
+	err = dynamodbattribute.UnmarshalMap(result.Item, &policy)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to unmarshal the CLA manager approval policy, error: %+v", err)
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// PutApprovalPolicy creates or replaces the approval policy of the company
+func (repo *policyRepository) PutApprovalPolicy(policy *ApprovalPolicy) error {
+	f := logrus.Fields{
+		"functionName": "PutApprovalPolicy",
+		"tableName":    repo.tableName,
+		"companyID":    policy.CompanyID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(policy)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal the CLA manager approval policy, error: %+v", err)
+		return err
+	}
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store the CLA manager approval policy, error: %+v", err)
+		return err
+	}
+	return nil
+}
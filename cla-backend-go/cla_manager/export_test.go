@@ -0,0 +1,73 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+)
+
+// StartRound exposes startRound to the tests
+var StartRound = startRound
+
+// ApprovalCount exposes approvalCount to the tests
+var ApprovalCount = approvalCount
+
+// HasApproved exposes hasApproved to the tests
+var HasApproved = hasApproved
+
+// RoundStarted exposes roundStarted to the tests
+var RoundStarted = roundStarted
+
+// IsExpired exposes isExpired to the tests
+var IsExpired = isExpired
+
+// ReminderDue exposes reminderDue to the tests
+var ReminderDue = reminderDue
+
+// ApproverContact exposes approverContact to the tests
+type ApproverContact = approverContact
+
+// IsApprover exposes isApprover to the tests
+func (p *ApprovalPolicy) IsApprover(approver *Approver) bool {
+	return p.isApprover(approver)
+}
+
+// NewTestService creates the service with the clock of the test
+func NewTestService(repo IRepository, policyRepo PolicyRepository, companyService company.IService, projectService project.Service,
+	sigService signatures.SignatureService, eventsService events.Service, now func() time.Time) IService {
+	return service{
+		repo:           repo,
+		policyRepo:     policyRepo,
+		companyService: companyService,
+		projectService: projectService,
+		sigService:     sigService,
+		eventsService:  eventsService,
+		now:            now,
+	}
+}
+
+// NewTestRequestJob creates the request job with the clock of the test
+func NewTestRequestJob(repo IRepository, policyRepo PolicyRepository, signatureRepo SignatureRepository, usersRepo UserRepository,
+	claGroupRepo ClaGroupRepository, eventsService events.Service, now func() time.Time) RequestJob {
+	return &requestJob{
+		repo:          repo,
+		policyRepo:    policyRepo,
+		signatureRepo: signatureRepo,
+		usersRepo:     usersRepo,
+		claGroupRepo:  claGroupRepo,
+		eventsService: eventsService,
+		now:           now,
+	}
+}
+
+// PendingApprovers exposes pendingApprovers of the request job to the tests
+func PendingApprovers(ctx context.Context, job RequestJob, request *CLAManagerRequest) ([]ApproverContact, error) {
+	return job.(*requestJob).pendingApprovers(ctx, request)
+}
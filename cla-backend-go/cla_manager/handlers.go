@@ -5,6 +5,7 @@ package cla_manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/cla_manager"
@@ -111,7 +112,7 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 		// If no previous requests...
 		if existingRequests == nil || existingRequests.Requests == nil {
 			var createErr error
-			request, createErr = service.CreateRequest(&CLAManagerRequest{
+			request, createErr = service.CreateRequest(ctx, &CLAManagerRequest{
 				CompanyID:         params.CompanyID,
 				CompanyExternalID: companyModel.CompanyExternalID,
 				CompanyName:       companyModel.CompanyName,
@@ -148,7 +149,7 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 
 			// Ok - existing state which is either denied or approved - allow them to create another request
 			var updateErr error
-			request, updateErr = service.PendingRequest(params.CompanyID, params.ProjectID, existingRequests.Requests[0].RequestID)
+			request, updateErr = service.PendingRequest(ctx, params.CompanyID, params.ProjectID, existingRequests.Requests[0].RequestID)
 			if updateErr != nil {
 				msg := buildErrorMessage("pending request error", params, updateErr)
 				log.Warn(msg)
@@ -251,24 +252,10 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 	api.ClaManagerApproveCLAManagerRequestHandler = cla_manager.ApproveCLAManagerRequestHandlerFunc(func(params cla_manager.ApproveCLAManagerRequestParams, claUser *user.CLAUser) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
 		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
-
-		companyModel, companyErr := companyService.GetCompany(ctx, params.CompanyID)
-		if companyErr != nil || companyModel == nil {
-			msg := buildErrorMessageForApprove(params, companyErr)
-			log.Warn(msg)
-			return cla_manager.NewCreateCLAManagerRequestBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
-				Message: msg,
-				Code:    "400",
-			})
-		}
-
-		claGroupModel, projectErr := projectService.GetCLAGroupByID(ctx, params.ProjectID)
-		if projectErr != nil || claGroupModel == nil {
-			msg := buildErrorMessageForApprove(params, projectErr)
-			log.Warn(msg)
-			return cla_manager.NewCreateCLAManagerRequestBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
-				Message: msg,
-				Code:    "400",
+		if !isValidUser(claUser) {
+			return cla_manager.NewApproveCLAManagerRequestUnauthorized().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Message: "unauthorized",
+				Code:    "401",
 			})
 		}
 
@@ -280,7 +267,7 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 			NextKey:     nil,
 			PageSize:    aws.Int64(5),
 		})
-		if sigErr != nil || sigModels == nil {
+		if sigErr != nil || sigModels == nil || len(sigModels.Signatures) == 0 {
 			msg := buildErrorMessageForApprove(params, sigErr)
 			log.Warn(msg)
 			return cla_manager.NewApproveCLAManagerRequestBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
@@ -293,89 +280,42 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 				params.CompanyID, params.ProjectID, params.RequestID)
 		}
 
-		sigModel := sigModels.Signatures[0]
-		claManagers := sigModel.SignatureACL
-		if !currentUserInACL(claUser, claManagers) {
-			return cla_manager.NewApproveCLAManagerRequestUnauthorized().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
-				Message: fmt.Sprintf("CLA Manager %s / %s / %s not authorized to approve request for company ID: %s, project ID: %s",
-					claUser.UserID, claUser.Name, claUser.LFEmail, params.CompanyID, params.ProjectID),
-				Code: "401",
-			})
+		// Approve the request - the request is approved once it has the approvals required by its approval policy
+		approver := &Approver{
+			LFUsername:   claUser.LFUsername,
+			Name:         claUser.Name,
+			Email:        claUser.LFEmail,
+			IsCLAManager: currentUserInACL(claUser, sigModels.Signatures[0].SignatureACL),
 		}
-
-		// Approve the request
-		request, err := service.ApproveRequest(params.CompanyID, params.ProjectID, params.RequestID)
+		request, err := service.ApproveRequest(ctx, params.CompanyID, params.ProjectID, params.RequestID, approver, "")
 		if err != nil {
 			msg := buildErrorMessageForApprove(params, err)
 			log.Warn(msg)
+			if errors.Is(err, ErrNotApprover) || errors.Is(err, ErrAlreadyApproved) {
+				return cla_manager.NewApproveCLAManagerRequestUnauthorized().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Message: fmt.Sprintf("CLA Manager %s / %s / %s not authorized to approve request for company ID: %s, project ID: %s - %v",
+						claUser.UserID, claUser.Name, claUser.LFEmail, params.CompanyID, params.ProjectID, err),
+					Code: "401",
+				})
+			}
 			return cla_manager.NewApproveCLAManagerRequestBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
 				Message: msg,
 				Code:    "400",
 			})
 		}
 
-		// Update the signature ACL
-		_, aclErr := sigService.AddCLAManager(ctx, sigModel.SignatureID.String(), request.UserID)
-		if aclErr != nil {
-			msg := buildErrorMessageForApprove(params, aclErr)
-			log.Warn(msg)
-			return cla_manager.NewApproveCLAManagerRequestBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
-				Message: msg,
-				Code:    "400",
-			})
-		}
-
-		// Send an event
-		eventsService.LogEvent(&events.LogEventArgs{
-			EventType: events.ClaManagerAccessRequestApproved,
-			ProjectID: params.ProjectID,
-			CompanyID: params.CompanyID,
-			UserID:    claUser.UserID,
-			EventData: &events.CLAManagerRequestApprovedEventData{
-				RequestID:    request.RequestID,
-				CompanyName:  companyModel.CompanyName,
-				ProjectName:  claGroupModel.ProjectName,
-				UserName:     request.UserName,
-				UserEmail:    request.UserEmail,
-				ManagerName:  claUser.Name,    // from the request
-				ManagerEmail: claUser.LFEmail, // from the request
-			},
-		})
-
-		// Notify CLA Managers - send email to each manager
-		for _, manager := range claManagers {
-			sendRequestApprovedEmailToCLAManagers(companyModel, claGroupModel, request.UserName, request.UserEmail,
-				manager.Username, manager.LfEmail)
-		}
-
-		// Notify the requester
-		sendRequestApprovedEmailToRequester(companyModel, claGroupModel, request.UserName, request.UserEmail)
-
-		return cla_manager.NewCreateCLAManagerRequestOK().WithXRequestID(reqID).WithPayload(request)
+		respModel := dbModelToServiceModel(*request)
+		return cla_manager.NewCreateCLAManagerRequestOK().WithXRequestID(reqID).WithPayload(&respModel)
 	})
 
 	// Deny Request
 	api.ClaManagerDenyCLAManagerRequestHandler = cla_manager.DenyCLAManagerRequestHandlerFunc(func(params cla_manager.DenyCLAManagerRequestParams, claUser *user.CLAUser) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
 		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
-
-		companyModel, companyErr := companyService.GetCompany(ctx, params.CompanyID)
-		if companyErr != nil || companyModel == nil {
-			msg := buildErrorMessageForDeny(params, companyErr)
-			log.Warn(msg)
-			return cla_manager.NewDenyCLAManagerRequestBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
-				Message: msg,
-				Code:    "400",
-			})
-		}
-
-		claGroupModel, projectErr := projectService.GetCLAGroupByID(ctx, params.ProjectID)
-		if projectErr != nil || claGroupModel == nil {
-			msg := buildErrorMessageForDeny(params, projectErr)
-			log.Warn(msg)
-			return cla_manager.NewDenyCLAManagerRequestBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
-				Message: msg,
-				Code:    "400",
+		if !isValidUser(claUser) {
+			return cla_manager.NewDenyCLAManagerRequestUnauthorized().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Message: "unauthorized",
+				Code:    "401",
 			})
 		}
 
@@ -387,10 +327,10 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 			NextKey:     nil,
 			PageSize:    aws.Int64(5),
 		})
-		if sigErr != nil || sigModels == nil {
+		if sigErr != nil || sigModels == nil || len(sigModels.Signatures) == 0 {
 			msg := buildErrorMessageForDeny(params, sigErr)
 			log.Warn(msg)
-			return cla_manager.NewApproveCLAManagerRequestBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+			return cla_manager.NewDenyCLAManagerRequestBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
 				Message: "CLA Manager Deny Request - error reading CCLA Signatures - " + msg,
 				Code:    "400",
 			})
@@ -400,53 +340,31 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 				params.CompanyID, params.ProjectID, params.RequestID)
 		}
 
-		sigModel := sigModels.Signatures[0]
-		claManagers := sigModel.SignatureACL
-		if !currentUserInACL(claUser, claManagers) {
-			return cla_manager.NewApproveCLAManagerRequestUnauthorized().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
-				Message: fmt.Sprintf("CLA Manager %s / %s / %s not authorized to approve request for company ID: %s, project ID: %s",
-					claUser.UserID, claUser.Name, claUser.LFEmail, params.CompanyID, params.ProjectID),
-				Code: "401",
-			})
+		approver := &Approver{
+			LFUsername:   claUser.LFUsername,
+			Name:         claUser.Name,
+			Email:        claUser.LFEmail,
+			IsCLAManager: currentUserInACL(claUser, sigModels.Signatures[0].SignatureACL),
 		}
-
-		request, err := service.DenyRequest(params.CompanyID, params.ProjectID, params.RequestID)
+		request, err := service.DenyRequest(ctx, params.CompanyID, params.ProjectID, params.RequestID, approver, "")
 		if err != nil {
 			msg := buildErrorMessageForDeny(params, err)
 			log.Warn(msg)
+			if errors.Is(err, ErrNotApprover) {
+				return cla_manager.NewDenyCLAManagerRequestUnauthorized().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Message: fmt.Sprintf("CLA Manager %s / %s / %s not authorized to deny request for company ID: %s, project ID: %s",
+						claUser.UserID, claUser.Name, claUser.LFEmail, params.CompanyID, params.ProjectID),
+					Code: "401",
+				})
+			}
 			return cla_manager.NewDenyCLAManagerRequestBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
 				Message: msg,
 				Code:    "400",
 			})
 		}
 
-		// Send an event
-		eventsService.LogEvent(&events.LogEventArgs{
-			EventType: events.ClaManagerAccessRequestDenied,
-			ProjectID: params.ProjectID,
-			CompanyID: params.CompanyID,
-			UserID:    claUser.UserID,
-			EventData: &events.CLAManagerRequestDeniedEventData{
-				RequestID:    request.RequestID,
-				CompanyName:  companyModel.CompanyName,
-				ProjectName:  claGroupModel.ProjectName,
-				UserName:     request.UserName,
-				UserEmail:    request.UserEmail,
-				ManagerName:  claUser.Name,    // from the request
-				ManagerEmail: claUser.LFEmail, // from the request
-			},
-		})
-
-		// Notify CLA Managers - send email to each manager
-		for _, manager := range claManagers {
-			sendRequestDeniedEmailToCLAManagers(companyModel, claGroupModel, request.UserName, request.UserEmail,
-				manager.Username, manager.LfEmail)
-		}
-
-		// Notify the requester
-		sendRequestDeniedEmailToRequester(companyModel, claGroupModel, request.UserName, request.UserEmail)
-
-		return cla_manager.NewCreateCLAManagerRequestOK().WithPayload(request)
+		respModel := dbModelToServiceModel(*request)
+		return cla_manager.NewCreateCLAManagerRequestOK().WithXRequestID(reqID).WithPayload(&respModel)
 	})
 
 	// Delete Request
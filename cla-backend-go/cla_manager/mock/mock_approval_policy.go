@@ -0,0 +1,68 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+//
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cla_manager/approval_policy.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	cla_manager "github.com/communitybridge/easycla/cla-backend-go/cla_manager"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPolicyRepository is a mock of PolicyRepository interface
+type MockPolicyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyRepositoryMockRecorder
+}
+
+// MockPolicyRepositoryMockRecorder is the mock recorder for MockPolicyRepository
+type MockPolicyRepositoryMockRecorder struct {
+	mock *MockPolicyRepository
+}
+
+// NewMockPolicyRepository creates a new mock instance
+func NewMockPolicyRepository(ctrl *gomock.Controller) *MockPolicyRepository {
+	mock := &MockPolicyRepository{ctrl: ctrl}
+	mock.recorder = &MockPolicyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPolicyRepository) EXPECT() *MockPolicyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetApprovalPolicy mocks base method
+func (m *MockPolicyRepository) GetApprovalPolicy(companyID string) (*cla_manager.ApprovalPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovalPolicy", companyID)
+	ret0, _ := ret[0].(*cla_manager.ApprovalPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovalPolicy indicates an expected call of GetApprovalPolicy
+func (mr *MockPolicyRepositoryMockRecorder) GetApprovalPolicy(companyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovalPolicy", reflect.TypeOf((*MockPolicyRepository)(nil).GetApprovalPolicy), companyID)
+}
+
+// PutApprovalPolicy mocks base method
+func (m *MockPolicyRepository) PutApprovalPolicy(policy *cla_manager.ApprovalPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutApprovalPolicy", policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutApprovalPolicy indicates an expected call of PutApprovalPolicy
+func (mr *MockPolicyRepositoryMockRecorder) PutApprovalPolicy(policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutApprovalPolicy", reflect.TypeOf((*MockPolicyRepository)(nil).PutApprovalPolicy), policy)
+}
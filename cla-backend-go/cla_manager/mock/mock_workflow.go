@@ -0,0 +1,170 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+//
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cla_manager/workflow.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cla_manager "github.com/communitybridge/easycla/cla-backend-go/cla_manager"
+	models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSignatureRepository is a mock of SignatureRepository interface
+type MockSignatureRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignatureRepositoryMockRecorder
+}
+
+// MockSignatureRepositoryMockRecorder is the mock recorder for MockSignatureRepository
+type MockSignatureRepositoryMockRecorder struct {
+	mock *MockSignatureRepository
+}
+
+// NewMockSignatureRepository creates a new mock instance
+func NewMockSignatureRepository(ctrl *gomock.Controller) *MockSignatureRepository {
+	mock := &MockSignatureRepository{ctrl: ctrl}
+	mock.recorder = &MockSignatureRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSignatureRepository) EXPECT() *MockSignatureRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetProjectCompanySignature mocks base method
+func (m *MockSignatureRepository) GetProjectCompanySignature(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*models.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectCompanySignature", ctx, companyID, projectID, signed, approved, nextKey, pageSize)
+	ret0, _ := ret[0].(*models.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectCompanySignature indicates an expected call of GetProjectCompanySignature
+func (mr *MockSignatureRepositoryMockRecorder) GetProjectCompanySignature(ctx, companyID, projectID, signed, approved, nextKey, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectCompanySignature", reflect.TypeOf((*MockSignatureRepository)(nil).GetProjectCompanySignature), ctx, companyID, projectID, signed, approved, nextKey, pageSize)
+}
+
+// MockUserRepository is a mock of UserRepository interface
+type MockUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRepositoryMockRecorder
+}
+
+// MockUserRepositoryMockRecorder is the mock recorder for MockUserRepository
+type MockUserRepositoryMockRecorder struct {
+	mock *MockUserRepository
+}
+
+// NewMockUserRepository creates a new mock instance
+func NewMockUserRepository(ctrl *gomock.Controller) *MockUserRepository {
+	mock := &MockUserRepository{ctrl: ctrl}
+	mock.recorder = &MockUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserByLFUserName mocks base method
+func (m *MockUserRepository) GetUserByLFUserName(lfUserName string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByLFUserName", lfUserName)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByLFUserName indicates an expected call of GetUserByLFUserName
+func (mr *MockUserRepositoryMockRecorder) GetUserByLFUserName(lfUserName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLFUserName", reflect.TypeOf((*MockUserRepository)(nil).GetUserByLFUserName), lfUserName)
+}
+
+// MockClaGroupRepository is a mock of ClaGroupRepository interface
+type MockClaGroupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockClaGroupRepositoryMockRecorder
+}
+
+// MockClaGroupRepositoryMockRecorder is the mock recorder for MockClaGroupRepository
+type MockClaGroupRepositoryMockRecorder struct {
+	mock *MockClaGroupRepository
+}
+
+// NewMockClaGroupRepository creates a new mock instance
+func NewMockClaGroupRepository(ctrl *gomock.Controller) *MockClaGroupRepository {
+	mock := &MockClaGroupRepository{ctrl: ctrl}
+	mock.recorder = &MockClaGroupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockClaGroupRepository) EXPECT() *MockClaGroupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCLAGroupByID mocks base method
+func (m *MockClaGroupRepository) GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*models.ClaGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCLAGroupByID", ctx, claGroupID, loadRepoDetails)
+	ret0, _ := ret[0].(*models.ClaGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCLAGroupByID indicates an expected call of GetCLAGroupByID
+func (mr *MockClaGroupRepositoryMockRecorder) GetCLAGroupByID(ctx, claGroupID, loadRepoDetails interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCLAGroupByID", reflect.TypeOf((*MockClaGroupRepository)(nil).GetCLAGroupByID), ctx, claGroupID, loadRepoDetails)
+}
+
+// MockRequestJob is a mock of RequestJob interface
+type MockRequestJob struct {
+	ctrl     *gomock.Controller
+	recorder *MockRequestJobMockRecorder
+}
+
+// MockRequestJobMockRecorder is the mock recorder for MockRequestJob
+type MockRequestJobMockRecorder struct {
+	mock *MockRequestJob
+}
+
+// NewMockRequestJob creates a new mock instance
+func NewMockRequestJob(ctrl *gomock.Controller) *MockRequestJob {
+	mock := &MockRequestJob{ctrl: ctrl}
+	mock.recorder = &MockRequestJobMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRequestJob) EXPECT() *MockRequestJobMockRecorder {
+	return m.recorder
+}
+
+// ProcessRequests mocks base method
+func (m *MockRequestJob) ProcessRequests(ctx context.Context) (*cla_manager.RequestJobReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessRequests", ctx)
+	ret0, _ := ret[0].(*cla_manager.RequestJobReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessRequests indicates an expected call of ProcessRequests
+func (mr *MockRequestJobMockRecorder) ProcessRequests(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessRequests", reflect.TypeOf((*MockRequestJob)(nil).ProcessRequests), ctx)
+}
@@ -3,7 +3,10 @@
 
 package cla_manager
 
-import "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	v2Models "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
 
 // CLAManagerRequests data model
 type CLAManagerRequests struct {
@@ -26,6 +29,26 @@ type CLAManagerRequest struct {
 	Status            string `json:"status"`
 	Created           string `json:"date_created"`
 	Updated           string `json:"date_modified"`
+
+	// the approval policy of the company when the request was made
+	RequiredApprovals int      `json:"required_approvals,omitempty"`
+	ApproverRole      string   `json:"approver_role,omitempty"`
+	Approvers         []string `json:"approvers,omitempty"`
+	DateExpires       string   `json:"date_expires,omitempty"`
+
+	DateReminded string                `json:"date_reminded,omitempty"`
+	History      []RequestHistoryEntry `json:"history,omitempty"`
+}
+
+// RequestHistoryEntry is a step of the CLA manager request workflow
+type RequestHistoryEntry struct {
+	Action     string `json:"action"`
+	LFUsername string `json:"lf_username,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Email      string `json:"email,omitempty"`
+	Role       string `json:"role,omitempty"`
+	Note       string `json:"note,omitempty"`
+	Date       string `json:"date"`
 }
 
 // dbModelToServiceModel converts a database model to a service model
@@ -45,5 +68,45 @@ func dbModelToServiceModel(dbModel CLAManagerRequest) models.ClaManagerRequest {
 		Status:            dbModel.Status,
 		Created:           dbModel.Created,
 		Updated:           dbModel.Updated,
+		RequiredApprovals: int64(requestPolicy(&dbModel).RequiredApprovals),
+		ApprovalCount:     int64(approvalCount(&dbModel)),
+		DateExpires:       dbModel.DateExpires,
+	}
+}
+
+// ToApprovalModel converts the request with its approval workflow history to the v2 API model
+func (r *CLAManagerRequest) ToApprovalModel() *v2Models.ClaManagerApprovalRequest {
+	policy := requestPolicy(r)
+	out := &v2Models.ClaManagerApprovalRequest{
+		RequestID:         r.RequestID,
+		CompanyID:         r.CompanyID,
+		CompanyName:       r.CompanyName,
+		ClaGroupID:        r.ProjectID,
+		ClaGroupName:      r.ProjectName,
+		UserID:            r.UserID,
+		UserName:          r.UserName,
+		UserEmail:         r.UserEmail,
+		Status:            r.Status,
+		RequiredApprovals: int64(policy.RequiredApprovals),
+		ApprovalCount:     int64(approvalCount(r)),
+		ApproverRole:      policy.ApproverRole,
+		Approvers:         policy.Approvers,
+		DateExpires:       r.DateExpires,
+		DateReminded:      r.DateReminded,
+		DateCreated:       r.Created,
+		DateModified:      r.Updated,
+		History:           []*v2Models.ClaManagerRequestHistoryEntry{},
+	}
+	for _, entry := range r.History {
+		out.History = append(out.History, &v2Models.ClaManagerRequestHistoryEntry{
+			Action:     entry.Action,
+			LfUsername: entry.LFUsername,
+			Name:       entry.Name,
+			Email:      entry.Email,
+			Role:       entry.Role,
+			Note:       entry.Note,
+			Date:       entry.Date,
+		})
 	}
+	return out
 }
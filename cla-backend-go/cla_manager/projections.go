@@ -23,5 +23,11 @@ func buildRequestProjection() expression.ProjectionBuilder {
 		expression.Name("status"),
 		expression.Name("date_created"),
 		expression.Name("date_modified"),
+		expression.Name("required_approvals"),
+		expression.Name("approver_role"),
+		expression.Name("approvers"),
+		expression.Name("date_expires"),
+		expression.Name("date_reminded"),
+		expression.Name("history"),
 	)
 }
@@ -5,6 +5,7 @@ package cla_manager
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/communitybridge/easycla/cla-backend-go/project"
 
@@ -34,8 +35,9 @@ type IRepository interface { //nolint
 	ApproveRequest(companyID, projectID, requestID string) (*CLAManagerRequest, error)
 	DenyRequest(companyID, projectID, requestID string) (*CLAManagerRequest, error)
 	PendingRequest(companyID, projectID, requestID string) (*CLAManagerRequest, error)
+	GetPendingRequests() ([]CLAManagerRequest, error)
+	UpdateRequestWorkflow(request *CLAManagerRequest, historyLength int) error
 	DeleteRequest(requestID string) error
-	updateRequestStatus(companyID, projectID, requestID, status string) (*CLAManagerRequest, error)
 }
 
 type repository struct {
@@ -116,6 +118,37 @@ func (repo repository) CreateRequest(reqModel *CLAManagerRequest) (*CLAManagerRe
 		},
 	}
 
+	// If provided the approval policy and history - add them
+	if reqModel.RequiredApprovals > 0 {
+		itemMap["required_approvals"] = &dynamodb.AttributeValue{
+			N: aws.String(strconv.Itoa(reqModel.RequiredApprovals)),
+		}
+		itemMap["approver_role"] = &dynamodb.AttributeValue{
+			S: aws.String(reqModel.ApproverRole),
+		}
+	}
+	if reqModel.DateExpires != "" {
+		itemMap["date_expires"] = &dynamodb.AttributeValue{
+			S: aws.String(reqModel.DateExpires),
+		}
+	}
+	if len(reqModel.Approvers) > 0 {
+		approvers, marshalErr := dynamodbattribute.Marshal(reqModel.Approvers)
+		if marshalErr != nil {
+			log.WithFields(f).Warnf("unable to marshal the CLA Manager request approvers, error: %v", marshalErr)
+			return nil, marshalErr
+		}
+		itemMap["approvers"] = approvers
+	}
+	if len(reqModel.History) > 0 {
+		history, marshalErr := dynamodbattribute.Marshal(reqModel.History)
+		if marshalErr != nil {
+			log.WithFields(f).Warnf("unable to marshal the CLA Manager request history, error: %v", marshalErr)
+			return nil, marshalErr
+		}
+		itemMap["history"] = history
+	}
+
 	// If provided the project external ID - add it
 	if reqModel.ProjectExternalID != "" {
 		itemMap["project_external_id"] = &dynamodb.AttributeValue{
@@ -413,6 +446,124 @@ func (repo repository) PendingRequest(companyID, projectID, requestID string) (*
 	return repo.updateRequestStatus(companyID, projectID, requestID, "pending")
 }
 
+// GetPendingRequests returns the pending requests of all the companies and CLA groups
+func (repo repository) GetPendingRequests() ([]CLAManagerRequest, error) {
+	f := logrus.Fields{
+		"functionName": "GetPendingRequests",
+		"tableName":    repo.tableName,
+	}
+
+	filter := expression.Name("status").Equal(expression.Value(RequestStatusPending))
+	expr, err := expression.NewBuilder().
+		WithFilter(filter).
+		WithProjection(buildRequestProjection()).
+		Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for pending cla manager requests scan, error: %v", err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.tableName),
+	}
+
+	var requests []CLAManagerRequest
+	for {
+		results, errScan := repo.dynamoDBClient.Scan(scanInput)
+		if errScan != nil {
+			log.WithFields(f).Warnf("error scanning pending cla manager requests, error: %v", errScan)
+			return nil, errScan
+		}
+
+		var page []CLAManagerRequest
+		unmarshallErr := dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if unmarshallErr != nil {
+			log.WithFields(f).Warnf("error converting DB model cla manager requests, error: %v", unmarshallErr)
+			return nil, unmarshallErr
+		}
+		requests = append(requests, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return requests, nil
+}
+
+// UpdateRequestWorkflow stores the status, approval policy and history of the request. The update fails with
+// ErrRequestModified if the stored history no longer has historyLength entries - another step was recorded meanwhile.
+func (repo repository) UpdateRequestWorkflow(request *CLAManagerRequest, historyLength int) error {
+	f := logrus.Fields{
+		"functionName": "UpdateRequestWorkflow",
+		"tableName":    repo.tableName,
+		"requestID":    request.RequestID,
+		"status":       request.Status,
+	}
+
+	_, now := utils.CurrentTime()
+	request.Updated = now
+
+	update := expression.Set(expression.Name("status"), expression.Value(request.Status)).
+		Set(expression.Name("history"), expression.Value(request.History)).
+		Set(expression.Name("date_modified"), expression.Value(now))
+	if request.RequiredApprovals > 0 {
+		update = update.Set(expression.Name("required_approvals"), expression.Value(request.RequiredApprovals)).
+			Set(expression.Name("approver_role"), expression.Value(request.ApproverRole))
+	}
+	if len(request.Approvers) > 0 {
+		update = update.Set(expression.Name("approvers"), expression.Value(request.Approvers))
+	} else {
+		update = update.Remove(expression.Name("approvers"))
+	}
+	if request.DateExpires != "" {
+		update = update.Set(expression.Name("date_expires"), expression.Value(request.DateExpires))
+	} else {
+		update = update.Remove(expression.Name("date_expires"))
+	}
+	if request.DateReminded != "" {
+		update = update.Set(expression.Name("date_reminded"), expression.Value(request.DateReminded))
+	} else {
+		update = update.Remove(expression.Name("date_reminded"))
+	}
+
+	condition := expression.Name("history").AttributeNotExists()
+	if historyLength > 0 {
+		condition = expression.Name("history").Size().Equal(expression.Value(historyLength))
+	}
+
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for cla manager request update, error: %v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"request_id": {S: aws.String(request.RequestID)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		TableName:                 aws.String(repo.tableName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Debug("the cla manager request history changed meanwhile")
+			return ErrRequestModified
+		}
+		log.WithFields(f).Warnf("unable to update cla manager request, error: %v", err)
+		return err
+	}
+	return nil
+}
+
 func (repo repository) GetRequestsByCLAGroup(claGroupID string) ([]CLAManagerRequest, error) {
 	f := logrus.Fields{
 		"functionName": "GetRequestsByCLAGroup",
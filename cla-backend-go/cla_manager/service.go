@@ -6,6 +6,10 @@ package cla_manager
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/communitybridge/easycla/cla-backend-go/company"
@@ -22,45 +26,60 @@ import (
 
 // IService interface defining the functions for the company service
 type IService interface {
-	CreateRequest(reqModel *CLAManagerRequest) (*models.ClaManagerRequest, error)
+	CreateRequest(ctx context.Context, reqModel *CLAManagerRequest) (*models.ClaManagerRequest, error)
 	GetRequests(companyID, claGroupID string) (*models.ClaManagerRequestList, error)
 	GetRequestsByUserID(companyID, claGroupID, userID string) (*models.ClaManagerRequestList, error)
 	GetRequest(requestID string) (*models.ClaManagerRequest, error)
+	GetRequestsWithHistory(ctx context.Context, companyID, claGroupID string) ([]CLAManagerRequest, error)
 
-	ApproveRequest(companyID, claGroupID, requestID string) (*models.ClaManagerRequest, error)
-	DenyRequest(companyID, claGroupID, requestID string) (*models.ClaManagerRequest, error)
-	PendingRequest(companyID, claGroupID, requestID string) (*models.ClaManagerRequest, error)
+	ApproveRequest(ctx context.Context, companyID, claGroupID, requestID string, approver *Approver, note string) (*CLAManagerRequest, error)
+	DenyRequest(ctx context.Context, companyID, claGroupID, requestID string, approver *Approver, note string) (*CLAManagerRequest, error)
+	PendingRequest(ctx context.Context, companyID, claGroupID, requestID string) (*models.ClaManagerRequest, error)
 	DeleteRequest(requestID string) error
 
+	GetApprovalPolicy(ctx context.Context, companyID string) (*ApprovalPolicy, error)
+	UpdateApprovalPolicy(ctx context.Context, policy *ApprovalPolicy, updatedBy string) (*ApprovalPolicy, error)
+
 	AddClaManager(ctx context.Context, companyID string, claGroupID string, LFID string) (*models.Signature, error)
 	RemoveClaManager(ctx context.Context, companyID string, claGroupID string, LFID string) (*models.Signature, error)
 }
 
 type service struct {
 	repo                IRepository
+	policyRepo          PolicyRepository
 	companyService      company.IService
 	projectService      project.Service
 	usersService        users.Service
 	sigService          signatures.SignatureService
 	eventsService       events.Service
 	corporateConsoleURL string
+	now                 func() time.Time
 }
 
 // NewService creates a new service object
-func NewService(repo IRepository, companyService company.IService, projectService project.Service, usersService users.Service, sigService signatures.SignatureService, eventsService events.Service, corporateConsoleURL string) IService {
+func NewService(repo IRepository, policyRepo PolicyRepository, companyService company.IService, projectService project.Service, usersService users.Service, sigService signatures.SignatureService, eventsService events.Service, corporateConsoleURL string) IService {
 	return service{
 		repo:                repo,
+		policyRepo:          policyRepo,
 		companyService:      companyService,
 		projectService:      projectService,
 		usersService:        usersService,
 		sigService:          sigService,
 		eventsService:       eventsService,
 		corporateConsoleURL: corporateConsoleURL,
+		now:                 time.Now,
 	}
 }
 
-// CreateRequest creates a request based on the specified parameters
-func (s service) CreateRequest(reqModel *CLAManagerRequest) (*models.ClaManagerRequest, error) {
+// CreateRequest creates a request based on the specified parameters, the request is approved under the current
+// approval policy of the company
+func (s service) CreateRequest(ctx context.Context, reqModel *CLAManagerRequest) (*models.ClaManagerRequest, error) {
+	policy, err := s.GetApprovalPolicy(ctx, reqModel.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	startRound(reqModel, policy, s.now())
+
 	request, err := s.repo.CreateRequest(reqModel)
 	if err != nil {
 		log.Warnf("problem with approving request for company ID: %s, project ID: %s, user ID: %s, user name: %s, error :%+v",
@@ -128,46 +147,294 @@ func (s service) GetRequest(requestID string) (*models.ClaManagerRequest, error)
 	return &respModel, err
 }
 
-// ApproveRequest approves the request based on the specified parameters
-func (s service) ApproveRequest(companyID, claGroupID, requestID string) (*models.ClaManagerRequest, error) {
-	request, err := s.repo.ApproveRequest(companyID, claGroupID, requestID)
+// GetRequestsWithHistory returns the requests of the company for the CLA group with their approval workflow history
+func (s service) GetRequestsWithHistory(ctx context.Context, companyID, claGroupID string) ([]CLAManagerRequest, error) {
+	requests, err := s.repo.GetRequests(companyID, claGroupID)
 	if err != nil {
-		log.Warnf("problem with approving request for company ID: %s, project ID: %s, request ID: %s, error :%+v",
-			companyID, claGroupID, requestID, err)
+		log.WithField(utils.XREQUESTID, ctx.Value(utils.XREQUESTID)).Warnf("problem with fetching request for company ID: %s, project ID: %s, error :%+v",
+			companyID, claGroupID, err)
 		return nil, err
 	}
+	return requests.Requests, nil
+}
 
-	respModel := dbModelToServiceModel(*request)
+// ApproveRequest records the approval of the request by the approver. The request is approved and the requester added
+// as CLA manager once it has the approvals required by the approval policy it was made under.
+func (s service) ApproveRequest(ctx context.Context, companyID, claGroupID, requestID string, approver *Approver, note string) (*CLAManagerRequest, error) {
+	f := logrus.Fields{
+		"functionName":   "ApproveRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+		"claGroupID":     claGroupID,
+		"requestID":      requestID,
+	}
 
-	return &respModel, err
+	request, policy, err := s.loadPendingRequest(ctx, companyID, claGroupID, requestID, approver)
+	if err != nil {
+		return nil, err
+	}
+	if hasApproved(request, approver.LFUsername) {
+		return nil, ErrAlreadyApproved
+	}
+
+	historyLength := len(request.History)
+	request.History = append(request.History, RequestHistoryEntry{
+		Action:     HistoryActionApproved,
+		LFUsername: approver.LFUsername,
+		Name:       approver.Name,
+		Email:      approver.Email,
+		Role:       policy.ApproverRole,
+		Note:       note,
+		Date:       utils.TimeToString(s.now()),
+	})
+	var sigModel *models.Signature
+	if approvalCount(request) >= policy.RequiredApprovals {
+		// load the signature before the request is approved, so the approval is only stored when the ACL can be updated
+		sigModel, err = s.getCompanySignature(ctx, companyID, claGroupID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load the CCLA signature to add the CLA manager")
+			return nil, err
+		}
+		request.Status = RequestStatusApproved
+	}
+	if err = s.repo.UpdateRequestWorkflow(request, historyLength); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem with approving request")
+		return nil, err
+	}
+	if request.Status != RequestStatusApproved {
+		log.WithFields(f).Debugf("recorded approval %d of %d", approvalCount(request), policy.RequiredApprovals)
+		s.eventsService.LogEvent(&events.LogEventArgs{
+			EventType:  events.ClaManagerAccessRequestApprovalRecorded,
+			ProjectID:  claGroupID,
+			CompanyID:  companyID,
+			LfUsername: approver.LFUsername,
+			EventData: &events.CLAManagerRequestApprovalRecordedEventData{
+				RequestID:         request.RequestID,
+				CompanyName:       request.CompanyName,
+				ProjectName:       request.ProjectName,
+				UserName:          request.UserName,
+				UserEmail:         request.UserEmail,
+				ManagerName:       approver.Name,
+				ManagerEmail:      approver.Email,
+				Approvals:         approvalCount(request),
+				RequiredApprovals: policy.RequiredApprovals,
+			},
+		})
+		return request, nil
+	}
+
+	// Update the signature ACL - the approval is rolled back when the CLA manager can't be added, so it can be retried
+	if _, err = s.sigService.AddCLAManager(ctx, sigModel.SignatureID.String(), request.UserID); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to add the CLA manager - rolling back the approval")
+		request.Status = RequestStatusPending
+		request.History = request.History[:historyLength]
+		if rollbackErr := s.repo.UpdateRequestWorkflow(request, historyLength+1); rollbackErr != nil {
+			log.WithFields(f).WithError(rollbackErr).Warn("unable to roll back the approval of the request")
+		}
+		return nil, err
+	}
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:  events.ClaManagerAccessRequestApproved,
+		ProjectID:  claGroupID,
+		CompanyID:  companyID,
+		LfUsername: approver.LFUsername,
+		EventData: &events.CLAManagerRequestApprovedEventData{
+			RequestID:    request.RequestID,
+			CompanyName:  request.CompanyName,
+			ProjectName:  request.ProjectName,
+			UserName:     request.UserName,
+			UserEmail:    request.UserEmail,
+			ManagerName:  approver.Name,
+			ManagerEmail: approver.Email,
+		},
+	})
+
+	companyModel, claGroupModel, err := s.requestModels(ctx, request)
+	if err == nil {
+		// Notify CLA Managers - send email to each manager
+		for _, manager := range sigModel.SignatureACL {
+			sendRequestApprovedEmailToCLAManagers(companyModel, claGroupModel, request.UserName, request.UserEmail,
+				manager.Username, manager.LfEmail)
+		}
+		// Notify the requester
+		sendRequestApprovedEmailToRequester(companyModel, claGroupModel, request.UserName, request.UserEmail)
+	}
+	return request, nil
 }
 
-// PendingRequest updates the specified request to the pending state
-func (s service) PendingRequest(companyID, claGroupID, requestID string) (*models.ClaManagerRequest, error) {
-	request, err := s.repo.PendingRequest(companyID, claGroupID, requestID)
+// PendingRequest makes a denied or expired request again under the current approval policy of the company
+func (s service) PendingRequest(ctx context.Context, companyID, claGroupID, requestID string) (*models.ClaManagerRequest, error) {
+	request, err := s.repo.GetRequest(requestID)
 	if err != nil {
 		log.Warnf("problem with setting the pending status for company ID: %s, project ID: %s, request ID: %s, error :%+v",
 			companyID, claGroupID, requestID, err)
 		return nil, err
 	}
+	if request == nil || request.CompanyID != companyID || request.ProjectID != claGroupID {
+		return nil, ErrRequestNotFound
+	}
+	policy, err := s.GetApprovalPolicy(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	historyLength := len(request.History)
+	startRound(request, policy, s.now())
+	if err = s.repo.UpdateRequestWorkflow(request, historyLength); err != nil {
+		log.Warnf("problem with setting the pending status for company ID: %s, project ID: %s, request ID: %s, error :%+v",
+			companyID, claGroupID, requestID, err)
+		return nil, err
+	}
 
 	respModel := dbModelToServiceModel(*request)
+	return &respModel, nil
+}
 
-	return &respModel, err
+// DenyRequest denies the request based on the specified parameters, a single approver denies the request
+func (s service) DenyRequest(ctx context.Context, companyID, claGroupID, requestID string, approver *Approver, note string) (*CLAManagerRequest, error) {
+	f := logrus.Fields{
+		"functionName":   "DenyRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+		"claGroupID":     claGroupID,
+		"requestID":      requestID,
+	}
+
+	request, policy, err := s.loadPendingRequest(ctx, companyID, claGroupID, requestID, approver)
+	if err != nil {
+		return nil, err
+	}
+
+	historyLength := len(request.History)
+	request.Status = RequestStatusDenied
+	request.History = append(request.History, RequestHistoryEntry{
+		Action:     HistoryActionDenied,
+		LFUsername: approver.LFUsername,
+		Name:       approver.Name,
+		Email:      approver.Email,
+		Role:       policy.ApproverRole,
+		Note:       note,
+		Date:       utils.TimeToString(s.now()),
+	})
+	if err = s.repo.UpdateRequestWorkflow(request, historyLength); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem with denying request")
+		return nil, err
+	}
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:  events.ClaManagerAccessRequestDenied,
+		ProjectID:  claGroupID,
+		CompanyID:  companyID,
+		LfUsername: approver.LFUsername,
+		EventData: &events.CLAManagerRequestDeniedEventData{
+			RequestID:    request.RequestID,
+			CompanyName:  request.CompanyName,
+			ProjectName:  request.ProjectName,
+			UserName:     request.UserName,
+			UserEmail:    request.UserEmail,
+			ManagerName:  approver.Name,
+			ManagerEmail: approver.Email,
+		},
+	})
+
+	companyModel, claGroupModel, err := s.requestModels(ctx, request)
+	if err == nil {
+		// Notify CLA Managers - send email to each manager
+		if sigModel, sigErr := s.getCompanySignature(ctx, companyID, claGroupID); sigErr == nil {
+			for _, manager := range sigModel.SignatureACL {
+				sendRequestDeniedEmailToCLAManagers(companyModel, claGroupModel, request.UserName, request.UserEmail,
+					manager.Username, manager.LfEmail)
+			}
+		}
+		// Notify the requester
+		sendRequestDeniedEmailToRequester(companyModel, claGroupModel, request.UserName, request.UserEmail)
+	}
+	return request, nil
 }
 
-// DenyRequest denies the request based on the specified parameters
-func (s service) DenyRequest(companyID, claGroupID, requestID string) (*models.ClaManagerRequest, error) {
-	request, err := s.repo.DenyRequest(companyID, claGroupID, requestID)
+// loadPendingRequest returns the request if it is pending and the approver may act on it under the approval policy
+// the request was made under - a request past its expiry date is expired instead
+func (s service) loadPendingRequest(ctx context.Context, companyID, claGroupID, requestID string, approver *Approver) (*CLAManagerRequest, *ApprovalPolicy, error) {
+	request, err := s.repo.GetRequest(requestID)
 	if err != nil {
-		log.Warnf("problem with denying request for company ID: %s, project ID: %s, request ID: %s, error :%+v",
-			companyID, claGroupID, requestID, err)
+		return nil, nil, err
+	}
+	if request == nil || request.CompanyID != companyID || request.ProjectID != claGroupID {
+		return nil, nil, ErrRequestNotFound
+	}
+	if request.Status != RequestStatusPending {
+		return nil, nil, ErrRequestNotPending
+	}
+	now := s.now()
+	if isExpired(request, now) {
+		historyLength := len(request.History)
+		expire(request, now)
+		if err = s.repo.UpdateRequestWorkflow(request, historyLength); err != nil {
+			log.WithField(utils.XREQUESTID, ctx.Value(utils.XREQUESTID)).WithError(err).Warnf("unable to expire request %s", requestID)
+		}
+		return nil, nil, ErrRequestExpired
+	}
+
+	policy := requestPolicy(request)
+	// requesters cannot approve their own request
+	if !policy.isApprover(approver) || strings.EqualFold(approver.LFUsername, request.UserID) {
+		return nil, nil, ErrNotApprover
+	}
+	return request, policy, nil
+}
+
+// requestModels returns the company and the CLA group of the request
+func (s service) requestModels(ctx context.Context, request *CLAManagerRequest) (*models.Company, *models.ClaGroup, error) {
+	companyModel, err := s.companyService.GetCompany(ctx, request.CompanyID)
+	if err != nil {
+		log.WithField(utils.XREQUESTID, ctx.Value(utils.XREQUESTID)).WithError(err).Warnf("unable to load company %s", request.CompanyID)
+		return nil, nil, err
+	}
+	claGroupModel, err := s.projectService.GetCLAGroupByID(ctx, request.ProjectID)
+	if err != nil {
+		log.WithField(utils.XREQUESTID, ctx.Value(utils.XREQUESTID)).WithError(err).Warnf("unable to load CLA group %s", request.ProjectID)
+		return nil, nil, err
+	}
+	return companyModel, claGroupModel, nil
+}
+
+// GetApprovalPolicy returns the approval policy of the company, or the default policy if the company has none
+func (s service) GetApprovalPolicy(ctx context.Context, companyID string) (*ApprovalPolicy, error) {
+	policy, err := s.policyRepo.GetApprovalPolicy(companyID)
+	if err != nil {
+		log.WithField(utils.XREQUESTID, ctx.Value(utils.XREQUESTID)).WithError(err).Warnf("unable to load the approval policy of company %s", companyID)
 		return nil, err
 	}
+	if policy == nil {
+		return DefaultApprovalPolicy(companyID), nil
+	}
+	return policy, nil
+}
 
-	respModel := dbModelToServiceModel(*request)
+// UpdateApprovalPolicy validates and stores the approval policy of the company, it applies to the requests made from
+// now on
+func (s service) UpdateApprovalPolicy(ctx context.Context, policy *ApprovalPolicy, updatedBy string) (*ApprovalPolicy, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	existing, err := s.policyRepo.GetApprovalPolicy(policy.CompanyID)
+	if err != nil {
+		return nil, err
+	}
 
-	return &respModel, err
+	now := utils.TimeToString(s.now())
+	policy.DateCreated = now
+	if existing != nil {
+		policy.DateCreated = existing.DateCreated
+	}
+	policy.DateModified = now
+	policy.UpdatedBy = updatedBy
+	if err = s.policyRepo.PutApprovalPolicy(policy); err != nil {
+		log.WithField(utils.XREQUESTID, ctx.Value(utils.XREQUESTID)).WithError(err).Warnf("unable to store the approval policy of company %s", policy.CompanyID)
+		return nil, err
+	}
+	return policy, nil
 }
 
 // DeleteRequest deletes the request based on the specified parameters
@@ -271,6 +538,9 @@ func (s service) getCompanySignature(ctx context.Context, companyID string, claG
 			claGroupID, companyID, sigErr)
 		return nil, sigErr
 	}
+	if len(sigModels.Signatures) == 0 {
+		return nil, fmt.Errorf("no CCLA signature found for company ID: %s, project ID: %s", companyID, claGroupID)
+	}
 
 	if len(sigModels.Signatures) > 1 {
 		log.Warnf("returned multiple CCLA signature models for company ID: %s, project ID: %s",
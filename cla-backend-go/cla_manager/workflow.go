@@ -0,0 +1,425 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// request status values
+const (
+	RequestStatusPending  = "pending"
+	RequestStatusApproved = "approved"
+	RequestStatusDenied   = "denied"
+	RequestStatusExpired  = "expired"
+)
+
+// request history actions
+const (
+	HistoryActionRequested = "requested"
+	HistoryActionApproved  = "approved"
+	HistoryActionDenied    = "denied"
+	HistoryActionExpired   = "expired"
+	HistoryActionReminded  = "reminded"
+)
+
+// errors
+var (
+	ErrRequestNotFound   = errors.New("CLA manager request not found")
+	ErrRequestNotPending = errors.New("CLA manager request is not pending")
+	ErrRequestExpired    = errors.New("CLA manager request expired")
+	ErrNotApprover       = errors.New("user is not an approver of the CLA manager request")
+	ErrAlreadyApproved   = errors.New("user already approved the CLA manager request")
+	ErrRequestModified   = errors.New("CLA manager request was modified concurrently, please try again")
+)
+
+// Approver is the user approving or denying a CLA manager request, the roles are resolved by the API handlers
+type Approver struct {
+	LFUsername     string
+	Name           string
+	Email          string
+	IsCLAManager   bool
+	IsCompanyAdmin bool
+}
+
+// startRound sets the approval policy of the request and records the request in the history, it is done when the
+// request is made and when a denied or expired request is made again
+func startRound(request *CLAManagerRequest, policy *ApprovalPolicy, now time.Time) {
+	request.Status = RequestStatusPending
+	request.RequiredApprovals = policy.RequiredApprovals
+	request.ApproverRole = policy.ApproverRole
+	request.Approvers = policy.Approvers
+	request.DateExpires = ""
+	if policy.ExpiryDays > 0 {
+		request.DateExpires = utils.TimeToString(now.AddDate(0, 0, policy.ExpiryDays))
+	}
+	request.DateReminded = ""
+	request.History = append(request.History, RequestHistoryEntry{
+		Action:     HistoryActionRequested,
+		LFUsername: request.UserID,
+		Name:       request.UserName,
+		Email:      request.UserEmail,
+		Date:       utils.TimeToString(now),
+	})
+}
+
+// currentRound returns the history since the request was last made
+func currentRound(request *CLAManagerRequest) []RequestHistoryEntry {
+	for i := len(request.History) - 1; i >= 0; i-- {
+		if request.History[i].Action == HistoryActionRequested {
+			return request.History[i:]
+		}
+	}
+	return request.History
+}
+
+// approvalCount returns the number of approvals since the request was last made
+func approvalCount(request *CLAManagerRequest) int {
+	count := 0
+	for _, entry := range currentRound(request) {
+		if entry.Action == HistoryActionApproved {
+			count++
+		}
+	}
+	if count == 0 && request.Status == RequestStatusApproved {
+		// approved before the history was recorded
+		return requestPolicy(request).RequiredApprovals
+	}
+	return count
+}
+
+// hasApproved returns true if the user approved the request since it was last made
+func hasApproved(request *CLAManagerRequest, lfUsername string) bool {
+	for _, entry := range currentRound(request) {
+		if entry.Action == HistoryActionApproved && strings.EqualFold(entry.LFUsername, lfUsername) {
+			return true
+		}
+	}
+	return false
+}
+
+// roundStarted returns the time the request was last made
+func roundStarted(request *CLAManagerRequest) time.Time {
+	round := currentRound(request)
+	date := request.Created
+	if len(round) > 0 && round[0].Action == HistoryActionRequested {
+		date = round[0].Date
+	}
+	t, err := utils.ParseDateTime(date)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// isExpired returns true if the pending request is past its expiry date
+func isExpired(request *CLAManagerRequest, now time.Time) bool {
+	if request.Status != RequestStatusPending || request.DateExpires == "" {
+		return false
+	}
+	expires, err := utils.ParseDateTime(request.DateExpires)
+	return err == nil && !now.Before(expires)
+}
+
+// expire marks the request expired
+func expire(request *CLAManagerRequest, now time.Time) {
+	request.Status = RequestStatusExpired
+	request.History = append(request.History, RequestHistoryEntry{
+		Action: HistoryActionExpired,
+		Date:   utils.TimeToString(now),
+	})
+}
+
+// SignatureRepository is the part of the signatures repository used to find the CLA managers to remind
+type SignatureRepository interface {
+	GetProjectCompanySignature(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*models.Signature, error)
+}
+
+// UserRepository is the part of the users repository used to find the emails of the named approvers
+type UserRepository interface {
+	GetUserByLFUserName(lfUserName string) (*models.User, error)
+}
+
+// ClaGroupRepository is the part of the project repository used to build the emails
+type ClaGroupRepository interface {
+	GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*models.ClaGroup, error)
+}
+
+// RequestJobReport is the outcome of a run of the request job
+type RequestJobReport struct {
+	Expired       int
+	RemindersSent int
+	Failed        int
+}
+
+// RequestJob expires the stale CLA manager requests and reminds the approvers of the pending ones
+type RequestJob interface {
+	ProcessRequests(ctx context.Context) (*RequestJobReport, error)
+}
+
+type requestJob struct {
+	repo          IRepository
+	policyRepo    PolicyRepository
+	signatureRepo SignatureRepository
+	usersRepo     UserRepository
+	claGroupRepo  ClaGroupRepository
+	eventsService events.Service
+	now           func() time.Time
+}
+
+// NewRequestJob creates a new CLA manager request job
+func NewRequestJob(repo IRepository, policyRepo PolicyRepository, signatureRepo SignatureRepository, usersRepo UserRepository, claGroupRepo ClaGroupRepository, eventsService events.Service) RequestJob {
+	return &requestJob{
+		repo:          repo,
+		policyRepo:    policyRepo,
+		signatureRepo: signatureRepo,
+		usersRepo:     usersRepo,
+		claGroupRepo:  claGroupRepo,
+		eventsService: eventsService,
+		now:           time.Now,
+	}
+}
+
+// ProcessRequests expires the pending requests past their expiry date and sends a reminder to the approvers of the
+// other pending requests when the reminder interval of the company approval policy elapsed
+func (j *requestJob) ProcessRequests(ctx context.Context) (*RequestJobReport, error) {
+	f := logrus.Fields{
+		"functionName":   "ProcessRequests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	requests, err := j.repo.GetPendingRequests()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the pending CLA manager requests")
+		return nil, err
+	}
+
+	report := &RequestJobReport{}
+	policies := map[string]*ApprovalPolicy{}
+	for i := range requests {
+		request := &requests[i]
+		now := j.now()
+		if isExpired(request, now) {
+			if err := j.expireRequest(ctx, request, now); err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to expire CLA manager request %s", request.RequestID)
+				report.Failed++
+				continue
+			}
+			report.Expired++
+			continue
+		}
+
+		policy, ok := policies[request.CompanyID]
+		if !ok {
+			policy, err = j.policyRepo.GetApprovalPolicy(request.CompanyID)
+			if err != nil {
+				report.Failed++
+				continue
+			}
+			if policy == nil {
+				policy = DefaultApprovalPolicy(request.CompanyID)
+			}
+			policies[request.CompanyID] = policy
+		}
+		if !reminderDue(request, policy, now) {
+			continue
+		}
+		if err := j.remind(ctx, request, now); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to remind the approvers of CLA manager request %s", request.RequestID)
+			report.Failed++
+			continue
+		}
+		report.RemindersSent++
+	}
+	return report, nil
+}
+
+// reminderDue returns true if the reminder interval of the policy elapsed since the request was made or the approvers
+// were last reminded
+func reminderDue(request *CLAManagerRequest, policy *ApprovalPolicy, now time.Time) bool {
+	if policy.ReminderDays <= 0 {
+		return false
+	}
+	last := roundStarted(request)
+	if request.DateReminded != "" {
+		if reminded, err := utils.ParseDateTime(request.DateReminded); err == nil && reminded.After(last) {
+			last = reminded
+		}
+	}
+	return !now.Before(last.AddDate(0, 0, policy.ReminderDays))
+}
+
+func (j *requestJob) expireRequest(ctx context.Context, request *CLAManagerRequest, now time.Time) error {
+	historyLength := len(request.History)
+	expire(request, now)
+	if err := j.repo.UpdateRequestWorkflow(request, historyLength); err != nil {
+		return err
+	}
+
+	j.eventsService.LogEvent(&events.LogEventArgs{
+		EventType: events.ClaManagerAccessRequestExpired,
+		ProjectID: request.ProjectID,
+		CompanyID: request.CompanyID,
+		EventData: &events.CLAManagerRequestExpiredEventData{
+			RequestID:   request.RequestID,
+			CompanyName: request.CompanyName,
+			ProjectName: request.ProjectName,
+			UserName:    request.UserName,
+			UserEmail:   request.UserEmail,
+		},
+	})
+
+	claGroupModel, err := j.claGroupRepo.GetCLAGroupByID(ctx, request.ProjectID, false)
+	if err != nil {
+		return err
+	}
+	sendRequestExpiredEmailToRequester(request, claGroupModel)
+	return nil
+}
+
+func (j *requestJob) remind(ctx context.Context, request *CLAManagerRequest, now time.Time) error {
+	claGroupModel, err := j.claGroupRepo.GetCLAGroupByID(ctx, request.ProjectID, false)
+	if err != nil {
+		return err
+	}
+	recipients, err := j.pendingApprovers(ctx, request)
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("CLA manager request %s has no approver with an email to remind", request.RequestID)
+	}
+
+	historyLength := len(request.History)
+	request.DateReminded = utils.TimeToString(now)
+	request.History = append(request.History, RequestHistoryEntry{
+		Action: HistoryActionReminded,
+		Date:   request.DateReminded,
+	})
+	if err := j.repo.UpdateRequestWorkflow(request, historyLength); err != nil {
+		return err
+	}
+
+	for _, recipient := range recipients {
+		sendRequestReminderEmailToApprover(request, claGroupModel, recipient.Name, recipient.Email)
+	}
+	return nil
+}
+
+type approverContact struct {
+	Name  string
+	Email string
+}
+
+// pendingApprovers returns the named approvers of the request who did not approve it yet, or the CLA managers when
+// the policy does not name the approvers as they are the company contacts known to EasyCLA
+func (j *requestJob) pendingApprovers(ctx context.Context, request *CLAManagerRequest) ([]approverContact, error) {
+	var contacts []approverContact
+	policy := requestPolicy(request)
+	if len(policy.Approvers) > 0 {
+		for _, lfUsername := range policy.Approvers {
+			if hasApproved(request, lfUsername) {
+				continue
+			}
+			userModel, err := j.usersRepo.GetUserByLFUserName(lfUsername)
+			if err != nil || userModel == nil {
+				log.WithField(utils.XREQUESTID, ctx.Value(utils.XREQUESTID)).Warnf("unable to load approver %s - skipping", lfUsername)
+				continue
+			}
+			email := userModel.LfEmail
+			if email == "" && len(userModel.Emails) > 0 {
+				email = userModel.Emails[0]
+			}
+			if email != "" {
+				contacts = append(contacts, approverContact{Name: userModel.Username, Email: email})
+			}
+		}
+		return contacts, nil
+	}
+
+	signed, approved := true, true
+	pageSize := int64(1)
+	signature, err := j.signatureRepo.GetProjectCompanySignature(ctx, request.CompanyID, request.ProjectID, &signed, &approved, nil, &pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if signature == nil {
+		return nil, fmt.Errorf("company %s has no CCLA signature for CLA group %s", request.CompanyID, request.ProjectID)
+	}
+	for _, manager := range signature.SignatureACL {
+		if hasApproved(request, manager.LfUsername) || manager.LfEmail == "" {
+			continue
+		}
+		contacts = append(contacts, approverContact{Name: manager.Username, Email: manager.LfEmail})
+	}
+	return contacts, nil
+}
+
+func sendRequestReminderEmailToApprover(request *CLAManagerRequest, claGroupModel *models.ClaGroup, recipientName, recipientAddress string) {
+	companyName := request.CompanyName
+	projectName := claGroupModel.ProjectName
+
+	subject := fmt.Sprintf("EasyCLA: Reminder - Pending CLA Manager Access Request for %s on %s", companyName, projectName)
+	recipients := []string{recipientAddress}
+	body := fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>%s (%s) has requested to be added as another CLA Manager from %s for %s. The request has %d of the %d approvals
+it needs and is waiting for your decision.</p>
+<p>If you want to permit this, please log into the <a href="%s" target="_blank">EasyCLA Corporate Console</a>,
+select your company, then select the %s project. From the CLA Manager requests, you can approve or deny the request.</p>
+%s
+%s
+`,
+		recipientName, projectName,
+		request.UserName, request.UserEmail, companyName, projectName,
+		approvalCount(request), requestPolicy(request).RequiredApprovals,
+		utils.GetCorporateURL(claGroupModel.Version == utils.V2), projectName,
+		utils.GetEmailHelpContent(claGroupModel.Version == utils.V2), utils.GetEmailSignOffContent())
+
+	err := utils.SendEmail(subject, body, recipients)
+	if err != nil {
+		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+	} else {
+		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+	}
+}
+
+func sendRequestExpiredEmailToRequester(request *CLAManagerRequest, claGroupModel *models.ClaGroup) {
+	companyName := request.CompanyName
+	projectName := claGroupModel.ProjectName
+
+	subject := fmt.Sprintf("EasyCLA: CLA Manager Access Request Expired for %s", projectName)
+	recipients := []string{request.UserEmail}
+	body := fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>Your request to become a CLA Manager from %s for the project %s expired before it received the approvals it
+needed. You can make the request again from the <a href="%s" target="_blank">EasyCLA Corporate Console</a>.</p>
+%s
+%s
+`,
+		request.UserName, projectName,
+		companyName, projectName,
+		utils.GetCorporateURL(claGroupModel.Version == utils.V2),
+		utils.GetEmailHelpContent(claGroupModel.Version == utils.V2), utils.GetEmailSignOffContent())
+
+	err := utils.SendEmail(subject, body, recipients)
+	if err != nil {
+		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+	} else {
+		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+	}
+}
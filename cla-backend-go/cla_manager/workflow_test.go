@@ -0,0 +1,328 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/communitybridge/easycla/cla-backend-go/cla_manager"
+	"github.com/communitybridge/easycla/cla-backend-go/cla_manager/mock"
+	companyMock "github.com/communitybridge/easycla/cla-backend-go/company/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	eventsMock "github.com/communitybridge/easycla/cla-backend-go/events/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	projectMock "github.com/communitybridge/easycla/cla-backend-go/project/mock"
+	signaturesMock "github.com/communitybridge/easycla/cla-backend-go/signatures/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+var testNow = time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestRequest(policy *cla_manager.ApprovalPolicy, created time.Time) *cla_manager.CLAManagerRequest {
+	request := &cla_manager.CLAManagerRequest{
+		RequestID: "request-1",
+		CompanyID: "company-1",
+		ProjectID: "cla-group-1",
+		UserID:    "requester",
+		UserName:  "Requester",
+		UserEmail: "requester@example.com",
+		Created:   utils.TimeToString(created),
+	}
+	cla_manager.StartRound(request, policy, created)
+	return request
+}
+
+// copyRequest returns a copy of the stored request, so the changes of the service are only seen once they are stored
+func copyRequest(request *cla_manager.CLAManagerRequest) *cla_manager.CLAManagerRequest {
+	copied := *request
+	copied.History = append([]cla_manager.RequestHistoryEntry{}, request.History...)
+	return &copied
+}
+
+// expectGetRequest makes the repository mock return a copy of the stored request
+func expectGetRequest(repo *mock.MockIRepository, stored *cla_manager.CLAManagerRequest) *gomock.Call {
+	return repo.EXPECT().GetRequest(stored.RequestID).DoAndReturn(func(requestID string) (*cla_manager.CLAManagerRequest, error) {
+		return copyRequest(stored), nil
+	})
+}
+
+// expectUpdate makes the repository mock store the request updated over the given history length
+func expectUpdate(repo *mock.MockIRepository, stored *cla_manager.CLAManagerRequest, historyLength int) *gomock.Call {
+	return repo.EXPECT().UpdateRequestWorkflow(gomock.Any(), historyLength).DoAndReturn(func(request *cla_manager.CLAManagerRequest, historyLength int) error {
+		*stored = *copyRequest(request)
+		return nil
+	})
+}
+
+func TestApprovalPolicyValidate(t *testing.T) {
+	policy := &cla_manager.ApprovalPolicy{RequiredApprovals: 2, ApproverRole: cla_manager.ApproverRoleCLAManager, Approvers: []string{" alice ", "bob", "Alice", ""}}
+	assert.Nil(t, policy.Validate())
+	assert.Equal(t, []string{"alice", "bob"}, policy.Approvers)
+
+	for _, invalid := range []*cla_manager.ApprovalPolicy{
+		{RequiredApprovals: 1, ApproverRole: "owner"},
+		{RequiredApprovals: 0, ApproverRole: cla_manager.ApproverRoleCLAManager},
+		{RequiredApprovals: cla_manager.MaxRequiredApprovals + 1, ApproverRole: cla_manager.ApproverRoleCLAManager},
+		{RequiredApprovals: 3, ApproverRole: cla_manager.ApproverRoleCLAManager, Approvers: []string{"alice", "bob"}},
+		{RequiredApprovals: 1, ApproverRole: cla_manager.ApproverRoleCompanyAdmin, ExpiryDays: -1},
+		{RequiredApprovals: 1, ApproverRole: cla_manager.ApproverRoleCompanyAdmin, ReminderDays: cla_manager.MaxPolicyDays + 1},
+	} {
+		assert.True(t, errors.Is(invalid.Validate(), cla_manager.ErrInvalidApprovalPolicy))
+	}
+}
+
+func TestApprovalPolicyIsApprover(t *testing.T) {
+	policy := &cla_manager.ApprovalPolicy{RequiredApprovals: 1, ApproverRole: cla_manager.ApproverRoleCompanyAdmin, Approvers: []string{"alice"}}
+	assert.True(t, policy.IsApprover(&cla_manager.Approver{LFUsername: "Alice", IsCompanyAdmin: true}))
+	assert.False(t, policy.IsApprover(&cla_manager.Approver{LFUsername: "alice", IsCLAManager: true}))
+	assert.False(t, policy.IsApprover(&cla_manager.Approver{LFUsername: "bob", IsCompanyAdmin: true}))
+
+	policy = cla_manager.DefaultApprovalPolicy("company-1")
+	assert.True(t, policy.IsApprover(&cla_manager.Approver{LFUsername: "bob", IsCLAManager: true}))
+	assert.False(t, policy.IsApprover(&cla_manager.Approver{LFUsername: "bob", IsCompanyAdmin: true}))
+	assert.False(t, policy.IsApprover(nil))
+}
+
+func TestApprovalCountAcrossRounds(t *testing.T) {
+	policy := &cla_manager.ApprovalPolicy{RequiredApprovals: 2, ApproverRole: cla_manager.ApproverRoleCLAManager}
+	request := newTestRequest(policy, testNow)
+	request.History = append(request.History,
+		cla_manager.RequestHistoryEntry{Action: cla_manager.HistoryActionApproved, LFUsername: "alice"},
+		cla_manager.RequestHistoryEntry{Action: cla_manager.HistoryActionDenied, LFUsername: "bob"})
+	assert.Equal(t, 1, cla_manager.ApprovalCount(request))
+	assert.True(t, cla_manager.HasApproved(request, "ALICE"))
+
+	// approvals given before the request was made again do not count
+	cla_manager.StartRound(request, policy, testNow.Add(time.Hour))
+	assert.Equal(t, 0, cla_manager.ApprovalCount(request))
+	assert.False(t, cla_manager.HasApproved(request, "alice"))
+	assert.Equal(t, testNow.Add(time.Hour), cla_manager.RoundStarted(request))
+
+	// requests approved before the history existed count as fully approved
+	legacy := &cla_manager.CLAManagerRequest{Status: cla_manager.RequestStatusApproved}
+	assert.Equal(t, 1, cla_manager.ApprovalCount(legacy))
+}
+
+func TestExpiryAndReminders(t *testing.T) {
+	policy := &cla_manager.ApprovalPolicy{RequiredApprovals: 1, ApproverRole: cla_manager.ApproverRoleCLAManager, ExpiryDays: 14, ReminderDays: 3}
+	request := newTestRequest(policy, testNow)
+	assert.False(t, cla_manager.IsExpired(request, testNow.AddDate(0, 0, 13)))
+	assert.True(t, cla_manager.IsExpired(request, testNow.AddDate(0, 0, 14)))
+
+	assert.False(t, cla_manager.ReminderDue(request, policy, testNow.AddDate(0, 0, 2)))
+	assert.True(t, cla_manager.ReminderDue(request, policy, testNow.AddDate(0, 0, 3)))
+	request.DateReminded = utils.TimeToString(testNow.AddDate(0, 0, 3))
+	assert.False(t, cla_manager.ReminderDue(request, policy, testNow.AddDate(0, 0, 5)))
+	assert.True(t, cla_manager.ReminderDue(request, policy, testNow.AddDate(0, 0, 6)))
+
+	assert.False(t, cla_manager.ReminderDue(request, cla_manager.DefaultApprovalPolicy("company-1"), testNow.AddDate(0, 0, 30)))
+}
+
+func TestApproveRequestNeedsRequiredApprovals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy := &cla_manager.ApprovalPolicy{RequiredApprovals: 2, ApproverRole: cla_manager.ApproverRoleCLAManager}
+	stored := newTestRequest(policy, testNow)
+	repo := mock.NewMockIRepository(ctrl)
+	expectGetRequest(repo, stored).Times(5)
+	expectUpdate(repo, stored, 1)
+	eventsService := eventsMock.NewMockService(ctrl)
+	eventsService.EXPECT().LogEvent(gomock.Any()).Do(func(args *events.LogEventArgs) {
+		assert.Equal(t, events.ClaManagerAccessRequestApprovalRecorded, args.EventType)
+	})
+	s := cla_manager.NewTestService(repo, mock.NewMockPolicyRepository(ctrl), nil, nil, nil, eventsService, func() time.Time { return testNow })
+
+	alice := &cla_manager.Approver{LFUsername: "alice", Name: "Alice", IsCLAManager: true}
+	request, err := s.ApproveRequest(context.Background(), "company-1", "cla-group-1", "request-1", alice, "looks good")
+	assert.Nil(t, err)
+	assert.Equal(t, cla_manager.RequestStatusPending, request.Status)
+	assert.Equal(t, 1, cla_manager.ApprovalCount(request))
+	assert.Equal(t, "looks good", request.History[len(request.History)-1].Note)
+
+	_, err = s.ApproveRequest(context.Background(), "company-1", "cla-group-1", "request-1", alice, "")
+	assert.Equal(t, cla_manager.ErrAlreadyApproved, err)
+
+	requester := &cla_manager.Approver{LFUsername: "requester", IsCLAManager: true}
+	_, err = s.ApproveRequest(context.Background(), "company-1", "cla-group-1", "request-1", requester, "")
+	assert.Equal(t, cla_manager.ErrNotApprover, err)
+
+	admin := &cla_manager.Approver{LFUsername: "carol", IsCompanyAdmin: true}
+	_, err = s.ApproveRequest(context.Background(), "company-1", "cla-group-1", "request-1", admin, "")
+	assert.Equal(t, cla_manager.ErrNotApprover, err)
+
+	_, err = s.ApproveRequest(context.Background(), "company-2", "cla-group-1", "request-1", alice, "")
+	assert.Equal(t, cla_manager.ErrRequestNotFound, err)
+}
+
+func TestApproveExpiredRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy := &cla_manager.ApprovalPolicy{RequiredApprovals: 1, ApproverRole: cla_manager.ApproverRoleCLAManager, ExpiryDays: 7}
+	stored := newTestRequest(policy, testNow.AddDate(0, 0, -8))
+	repo := mock.NewMockIRepository(ctrl)
+	expectGetRequest(repo, stored).Times(2)
+	expectUpdate(repo, stored, 1)
+	s := cla_manager.NewTestService(repo, mock.NewMockPolicyRepository(ctrl), nil, nil, nil, eventsMock.NewMockService(ctrl), func() time.Time { return testNow })
+
+	_, err := s.ApproveRequest(context.Background(), "company-1", "cla-group-1", "request-1", &cla_manager.Approver{LFUsername: "alice", IsCLAManager: true}, "")
+	assert.Equal(t, cla_manager.ErrRequestExpired, err)
+	assert.Equal(t, cla_manager.RequestStatusExpired, stored.Status)
+
+	_, err = s.ApproveRequest(context.Background(), "company-1", "cla-group-1", "request-1", &cla_manager.Approver{LFUsername: "alice", IsCLAManager: true}, "")
+	assert.Equal(t, cla_manager.ErrRequestNotPending, err)
+}
+
+func TestApproveRequestRollsBackWhenManagerNotAdded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy := &cla_manager.ApprovalPolicy{RequiredApprovals: 1, ApproverRole: cla_manager.ApproverRoleCLAManager}
+	stored := newTestRequest(policy, testNow)
+	repo := mock.NewMockIRepository(ctrl)
+	expectGetRequest(repo, stored).Times(2)
+	// the approval is stored, rolled back, then stored again when retried
+	expectUpdate(repo, stored, 1).Times(2)
+	expectUpdate(repo, stored, 2)
+
+	addErr := errors.New("unable to update the ACL")
+	sigService := signaturesMock.NewMockSignatureService(ctrl)
+	sigService.EXPECT().GetProjectCompanySignatures(gomock.Any(), gomock.Any()).
+		Return(&models.Signatures{Signatures: []*models.Signature{{SignatureID: "signature-1"}}}, nil).Times(2)
+	sigService.EXPECT().AddCLAManager(gomock.Any(), "signature-1", "requester").Return(nil, addErr)
+	sigService.EXPECT().AddCLAManager(gomock.Any(), "signature-1", "requester").Return(&models.Signature{SignatureID: strfmt.UUID("signature-1")}, nil)
+	companyService := companyMock.NewMockIService(ctrl)
+	companyService.EXPECT().GetCompany(gomock.Any(), "company-1").Return(&models.Company{CompanyID: "company-1", CompanyName: "company"}, nil)
+	projectService := projectMock.NewMockService(ctrl)
+	projectService.EXPECT().GetCLAGroupByID(gomock.Any(), "cla-group-1").Return(&models.ClaGroup{ProjectID: "cla-group-1", ProjectName: "project"}, nil)
+	// no event is logged for the rolled back approval
+	eventsService := eventsMock.NewMockService(ctrl)
+	eventsService.EXPECT().LogEvent(gomock.Any()).Do(func(args *events.LogEventArgs) {
+		assert.Equal(t, events.ClaManagerAccessRequestApproved, args.EventType)
+	})
+	s := cla_manager.NewTestService(repo, mock.NewMockPolicyRepository(ctrl), companyService, projectService, sigService, eventsService, func() time.Time { return testNow })
+	alice := &cla_manager.Approver{LFUsername: "alice", Name: "Alice", IsCLAManager: true}
+
+	_, err := s.ApproveRequest(context.Background(), "company-1", "cla-group-1", "request-1", alice, "")
+	assert.Equal(t, addErr, err)
+	assert.Equal(t, cla_manager.RequestStatusPending, stored.Status)
+	assert.Equal(t, 0, cla_manager.ApprovalCount(stored))
+
+	// the approval can be retried once the ACL can be updated
+	request, err := s.ApproveRequest(context.Background(), "company-1", "cla-group-1", "request-1", alice, "")
+	assert.Nil(t, err)
+	assert.Equal(t, cla_manager.RequestStatusApproved, request.Status)
+	assert.Equal(t, cla_manager.RequestStatusApproved, stored.Status)
+}
+
+func TestPendingRequestUsesCurrentPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored := newTestRequest(cla_manager.DefaultApprovalPolicy("company-1"), testNow.AddDate(0, 0, -1))
+	stored.Status = cla_manager.RequestStatusDenied
+	repo := mock.NewMockIRepository(ctrl)
+	expectGetRequest(repo, stored)
+	expectUpdate(repo, stored, 1)
+	policyRepo := mock.NewMockPolicyRepository(ctrl)
+	policyRepo.EXPECT().GetApprovalPolicy("company-1").Return(&cla_manager.ApprovalPolicy{
+		CompanyID: "company-1", RequiredApprovals: 2, ApproverRole: cla_manager.ApproverRoleCompanyAdmin, ExpiryDays: 10,
+	}, nil)
+	s := cla_manager.NewTestService(repo, policyRepo, nil, nil, nil, eventsMock.NewMockService(ctrl), func() time.Time { return testNow })
+
+	model, err := s.PendingRequest(context.Background(), "company-1", "cla-group-1", "request-1")
+	assert.Nil(t, err)
+	assert.Equal(t, cla_manager.RequestStatusPending, model.Status)
+	assert.Equal(t, int64(2), model.RequiredApprovals)
+	assert.Equal(t, utils.TimeToString(testNow.AddDate(0, 0, 10)), model.DateExpires)
+	assert.Len(t, stored.History, 2)
+}
+
+func TestUpdateApprovalPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var saved *cla_manager.ApprovalPolicy
+	policyRepo := mock.NewMockPolicyRepository(ctrl)
+	policyRepo.EXPECT().GetApprovalPolicy("company-1").Return(nil, nil).Times(2)
+	policyRepo.EXPECT().PutApprovalPolicy(gomock.Any()).Do(func(policy *cla_manager.ApprovalPolicy) {
+		saved = policy
+	}).Return(nil)
+	s := cla_manager.NewTestService(mock.NewMockIRepository(ctrl), policyRepo, nil, nil, nil, eventsMock.NewMockService(ctrl), func() time.Time { return testNow })
+
+	policy, err := s.GetApprovalPolicy(context.Background(), "company-1")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, policy.RequiredApprovals)
+
+	// an invalid policy is not stored
+	_, err = s.UpdateApprovalPolicy(context.Background(), &cla_manager.ApprovalPolicy{CompanyID: "company-1", RequiredApprovals: 0, ApproverRole: cla_manager.ApproverRoleCLAManager}, "admin")
+	assert.True(t, errors.Is(err, cla_manager.ErrInvalidApprovalPolicy))
+
+	policy, err = s.UpdateApprovalPolicy(context.Background(), &cla_manager.ApprovalPolicy{CompanyID: "company-1", RequiredApprovals: 2, ApproverRole: cla_manager.ApproverRoleCompanyAdmin}, "admin")
+	assert.Nil(t, err)
+	assert.Equal(t, "admin", policy.UpdatedBy)
+	assert.Equal(t, utils.TimeToString(testNow), policy.DateCreated)
+	if assert.NotNil(t, saved) {
+		assert.Equal(t, 2, saved.RequiredApprovals)
+	}
+}
+
+func TestProcessRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy := &cla_manager.ApprovalPolicy{RequiredApprovals: 2, ApproverRole: cla_manager.ApproverRoleCLAManager, ExpiryDays: 14, ReminderDays: 3}
+	stale := newTestRequest(policy, testNow.AddDate(0, 0, -15))
+	waiting := newTestRequest(policy, testNow.AddDate(0, 0, -4))
+	waiting.RequestID = "request-2"
+	waiting.History = append(waiting.History, cla_manager.RequestHistoryEntry{Action: cla_manager.HistoryActionApproved, LFUsername: "alice"})
+	fresh := newTestRequest(policy, testNow.AddDate(0, 0, -1))
+	fresh.RequestID = "request-3"
+
+	repo := mock.NewMockIRepository(ctrl)
+	repo.EXPECT().GetPendingRequests().Return([]cla_manager.CLAManagerRequest{*copyRequest(stale), *copyRequest(waiting), *copyRequest(fresh)}, nil)
+	stored := map[string]*cla_manager.CLAManagerRequest{}
+	repo.EXPECT().UpdateRequestWorkflow(gomock.Any(), gomock.Any()).DoAndReturn(func(request *cla_manager.CLAManagerRequest, historyLength int) error {
+		stored[request.RequestID] = copyRequest(request)
+		return nil
+	}).Times(2)
+	policyRepo := mock.NewMockPolicyRepository(ctrl)
+	policyRepo.EXPECT().GetApprovalPolicy("company-1").Return(policy, nil)
+	signatureRepo := mock.NewMockSignatureRepository(ctrl)
+	signatureRepo.EXPECT().GetProjectCompanySignature(gomock.Any(), "company-1", "cla-group-1", gomock.Any(), gomock.Any(), nil, gomock.Any()).
+		Return(&models.Signature{SignatureACL: []models.User{
+			{LfUsername: "alice", LfEmail: "alice@example.com"},
+			{LfUsername: "bob", LfEmail: "bob@example.com"},
+		}}, nil).Times(2)
+	claGroupRepo := mock.NewMockClaGroupRepository(ctrl)
+	claGroupRepo.EXPECT().GetCLAGroupByID(gomock.Any(), "cla-group-1", false).
+		Return(&models.ClaGroup{ProjectID: "cla-group-1", ProjectName: "project"}, nil).Times(2)
+	eventsService := eventsMock.NewMockService(ctrl)
+	eventsService.EXPECT().LogEvent(gomock.Any()).Do(func(args *events.LogEventArgs) {
+		assert.Equal(t, events.ClaManagerAccessRequestExpired, args.EventType)
+	})
+	job := cla_manager.NewTestRequestJob(repo, policyRepo, signatureRepo, mock.NewMockUserRepository(ctrl), claGroupRepo, eventsService,
+		func() time.Time { return testNow })
+
+	report, err := job.ProcessRequests(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &cla_manager.RequestJobReport{Expired: 1, RemindersSent: 1}, report)
+	if !assert.Len(t, stored, 2) {
+		return
+	}
+	assert.Equal(t, cla_manager.RequestStatusExpired, stored["request-1"].Status)
+	assert.Equal(t, utils.TimeToString(testNow), stored["request-2"].DateReminded)
+
+	contacts, err := cla_manager.PendingApprovers(context.Background(), job, stored["request-2"])
+	assert.Nil(t, err)
+	assert.Equal(t, []cla_manager.ApproverContact{{Email: "bob@example.com"}}, contacts)
+}
@@ -0,0 +1,334 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager_succession
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/project"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/signatures"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/organization-service/client/organizations"
+	orgModels "github.com/communitybridge/easycla/cla-backend-go/v2/organization-service/models"
+)
+
+// errors
+var (
+	ErrNoCompanyAdmins    = errors.New("company has no admins to notify")
+	ErrCompanySFIDMissing = errors.New("company has no SFID")
+)
+
+const (
+	// renotifyPeriod is how long the detector waits before notifying the company admins about the same signature again
+	renotifyPeriod = 7 * 24 * time.Hour
+	// signaturePageSize is the number of CCLA signatures of a CLA group loaded at once
+	signaturePageSize = 100
+)
+
+// AdminDirectory is the part of the organization service client used to find the company admins
+type AdminDirectory interface {
+	ListOrgUserAdminScopes(orgID string, role *string) (*orgModels.UserrolescopesList, error)
+}
+
+// Detector interface defines the orphaned CCLA detector methods
+type Detector interface {
+	DetectOrphanedSignatures(ctx context.Context) (*Report, error)
+}
+
+type detector struct {
+	managerChecker
+	repo           Repository
+	signatureRepo  SignatureRepository
+	claGroupRepo   ClaGroupRepository
+	companyRepo    CompanyRepository
+	adminDirectory AdminDirectory
+	eventsService  EventsService
+	now            func() time.Time
+}
+
+// NewDetector creates a new orphaned CCLA detector
+func NewDetector(repo Repository, signatureRepo SignatureRepository, claGroupRepo ClaGroupRepository, companyRepo CompanyRepository,
+	userRepo UserRepository, userDirectory UserDirectory, adminDirectory AdminDirectory, eventsService EventsService) Detector {
+	return &detector{
+		managerChecker: managerChecker{
+			userRepo:      userRepo,
+			userDirectory: userDirectory,
+		},
+		repo:           repo,
+		signatureRepo:  signatureRepo,
+		claGroupRepo:   claGroupRepo,
+		companyRepo:    companyRepo,
+		adminDirectory: adminDirectory,
+		eventsService:  eventsService,
+		now:            time.Now,
+	}
+}
+
+// DetectOrphanedSignatures checks the CLA managers of the signed CCLA signatures of all the CLA groups. The signatures
+// without an active CLA manager are flagged and the company admins are notified, the flagged signatures with an
+// active CLA manager again are resolved.
+func (d *detector) DetectOrphanedSignatures(ctx context.Context) (*Report, error) {
+	f := logrus.Fields{
+		"functionName":   "DetectOrphanedSignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	flagged, err := d.repo.GetOrphanedSignaturesByStatus(ctx, StatusOrphaned)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the orphaned signatures, error: %+v", err)
+		return nil, err
+	}
+	flaggedBySignature := make(map[string]*OrphanedSignature, len(flagged))
+	for _, orphan := range flagged {
+		flaggedBySignature[orphan.SignatureID] = orphan
+	}
+
+	report := &Report{}
+	var nextKey *string
+	for {
+		claGroups, err := d.claGroupRepo.GetCLAGroups(ctx, &project.GetProjectsParams{NextKey: nextKey, PageSize: aws.Int64(100)})
+		if err != nil {
+			log.WithFields(f).Warnf("unable to load the CLA groups, error: %+v", err)
+			return nil, err
+		}
+		for i := range claGroups.Projects {
+			d.detectClaGroup(ctx, &claGroups.Projects[i], flaggedBySignature, report)
+		}
+		if claGroups.LastKeyScanned == "" {
+			break
+		}
+		nextKey = aws.String(claGroups.LastKeyScanned)
+	}
+
+	return report, nil
+}
+
+// detectClaGroup checks the signed CCLA signatures of the CLA group, page by page
+func (d *detector) detectClaGroup(ctx context.Context, claGroup *v1Models.ClaGroup, flagged map[string]*OrphanedSignature, report *Report) {
+	f := logrus.Fields{
+		"functionName":   "detectClaGroup",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroup.ProjectID,
+	}
+
+	var nextKey *string
+	for {
+		sigModels, err := d.signatureRepo.GetProjectSignatures(ctx, signatures.GetProjectSignaturesParams{
+			ClaType:   aws.String(utils.ClaTypeCCLA),
+			ProjectID: claGroup.ProjectID,
+			NextKey:   nextKey,
+			PageSize:  aws.Int64(signaturePageSize),
+		}, signaturePageSize)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to load the CCLA signatures, error: %+v", err)
+			report.Failed++
+			return
+		}
+		for _, sig := range sigModels.Signatures {
+			if sig.SignatureSigned && sig.SignatureApproved {
+				d.checkSignature(ctx, sig, claGroup, flagged, report)
+			}
+		}
+		if sigModels.LastKeyScanned == "" {
+			return
+		}
+		nextKey = aws.String(sigModels.LastKeyScanned)
+	}
+}
+
+// checkSignature flags the signature if it has no active CLA manager, or resolves the flag if it has one again
+func (d *detector) checkSignature(ctx context.Context, sig *v1Models.Signature, claGroup *v1Models.ClaGroup, flagged map[string]*OrphanedSignature, report *Report) {
+	f := logrus.Fields{
+		"functionName":   "checkSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroup.ProjectID,
+	}
+
+	report.Checked++
+	signatureID := sig.SignatureID.String()
+
+	aclEntries, err := d.signatureRepo.GetSignatureACL(ctx, signatureID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the ACL of signature: %s, error: %+v", signatureID, err)
+		report.Failed++
+		return
+	}
+	managers, err := d.checkManagers(aclEntries)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to check the CLA managers of signature: %s, error: %+v", signatureID, err)
+		report.Failed++
+		return
+	}
+
+	orphan := flagged[signatureID]
+	now := d.now().UTC()
+	if !isOrphaned(managers) {
+		if orphan != nil {
+			// A CLA manager was added or became active again since the signature was flagged
+			orphan.Managers = managers
+			orphan.Status = StatusResolved
+			orphan.DateResolved = utils.TimeToString(now)
+			orphan.DateModified = utils.TimeToString(now)
+			if err := d.repo.PutOrphanedSignature(ctx, orphan); err != nil {
+				report.Failed++
+				return
+			}
+			report.Resolved++
+		}
+		return
+	}
+
+	detected := orphan == nil
+	if detected {
+		orphan, err = d.newOrphanedSignature(ctx, sig, claGroup, now)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to load the company of signature: %s, error: %+v", signatureID, err)
+			report.Failed++
+			return
+		}
+		report.Detected++
+	}
+	orphan.Managers = managers
+	orphan.DateModified = utils.TimeToString(now)
+
+	if notificationDue(orphan, now) {
+		adminsNotified, notifyErr := d.notifyCompanyAdmins(ctx, orphan, claGroup)
+		if notifyErr != nil {
+			log.WithFields(f).Warnf("unable to notify the company admins about signature: %s, error: %+v", signatureID, notifyErr)
+		} else {
+			orphan.AdminsNotified = int64(adminsNotified)
+			orphan.DateNotified = utils.TimeToString(now)
+			report.Notified++
+		}
+	}
+
+	if err := d.repo.PutOrphanedSignature(ctx, orphan); err != nil {
+		report.Failed++
+		return
+	}
+
+	if detected {
+		d.eventsService.LogEvent(&events.LogEventArgs{
+			EventType:     events.ClaManagerOrphanedCCLADetected,
+			ProjectID:     claGroup.ProjectID,
+			ClaGroupModel: claGroup,
+			CompanyID:     orphan.CompanyID,
+			EventData: &events.CLAManagerOrphanedCCLADetectedEventData{
+				CompanyName:    orphan.CompanyName,
+				ProjectName:    claGroup.ProjectName,
+				Managers:       orphan.managerUsernames(),
+				AdminsNotified: int(orphan.AdminsNotified),
+			},
+		})
+	}
+}
+
+func (d *detector) newOrphanedSignature(ctx context.Context, sig *v1Models.Signature, claGroup *v1Models.ClaGroup, now time.Time) (*OrphanedSignature, error) {
+	companyModel, err := d.companyRepo.GetCompany(ctx, sig.SignatureReferenceID.String())
+	if err != nil {
+		return nil, err
+	}
+	if companyModel == nil {
+		return nil, ErrCompanyNotFound
+	}
+	return &OrphanedSignature{
+		SignatureID:  sig.SignatureID.String(),
+		ClaGroupID:   claGroup.ProjectID,
+		ClaGroupName: claGroup.ProjectName,
+		CompanyID:    companyModel.CompanyID,
+		CompanySFID:  companyModel.CompanyExternalID,
+		CompanyName:  companyModel.CompanyName,
+		Status:       StatusOrphaned,
+		DateDetected: utils.TimeToString(now),
+	}, nil
+}
+
+// notificationDue returns true if the company admins were not notified about the signature recently
+func notificationDue(orphan *OrphanedSignature, now time.Time) bool {
+	if orphan.DateNotified == "" {
+		return true
+	}
+	notified, err := utils.ParseDateTime(orphan.DateNotified)
+	if err != nil {
+		return true
+	}
+	return now.Sub(notified) >= renotifyPeriod
+}
+
+// notifyCompanyAdmins emails the admins of the company how to appoint a successor CLA manager, returns the number
+// of admins notified
+func (d *detector) notifyCompanyAdmins(ctx context.Context, orphan *OrphanedSignature, claGroup *v1Models.ClaGroup) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "notifyCompanyAdmins",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    orphan.SignatureID,
+		"companySFID":    orphan.CompanySFID,
+	}
+
+	if orphan.CompanySFID == "" {
+		return 0, ErrCompanySFIDMissing
+	}
+	admins, err := d.adminDirectory.ListOrgUserAdminScopes(orphan.CompanySFID, nil)
+	if err != nil {
+		if _, ok := err.(*organizations.ListOrgUsrAdminScopesNotFound); ok {
+			return 0, ErrNoCompanyAdmins
+		}
+		return 0, err
+	}
+
+	var recipients []string
+	for _, admin := range admins.Userroles {
+		if admin.Contact != nil && admin.Contact.EmailAddress != "" {
+			recipients = append(recipients, admin.Contact.EmailAddress)
+		}
+	}
+	if len(recipients) == 0 {
+		return 0, ErrNoCompanyAdmins
+	}
+
+	log.WithFields(f).Debugf("notifying %d company admins", len(recipients))
+	subject, body := orphanedSignatureEmailContent(orphan, claGroup)
+	if err := utils.SendEmail(subject, body, recipients); err != nil {
+		return 0, err
+	}
+	return len(recipients), nil
+}
+
+func orphanedSignatureEmailContent(orphan *OrphanedSignature, claGroup *v1Models.ClaGroup) (string, string) {
+	summary := "<p>The signature has no CLA Managers.</p>"
+	if len(orphan.Managers) > 0 {
+		summary = "<ul>"
+		for _, manager := range orphan.Managers {
+			summary += fmt.Sprintf("<li>%s (%s)</li>", manager.LfUsername, strings.ReplaceAll(string(manager.Status), "_", " "))
+		}
+		summary += "</ul>"
+	}
+
+	subject := fmt.Sprintf("EasyCLA: No Active CLA Manager for %s on %s", orphan.CompanyName, claGroup.ProjectName)
+	body := fmt.Sprintf(`
+<p>Hello Company Admin,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>None of the CLA Managers of the Corporate CLA signed by %s for project %s is active anymore, so nobody can maintain
+the approval list of your contributors:</p>
+%s
+<p>As a company admin you can appoint a successor CLA Manager. Log into the EasyCLA Corporate Console at %s, select
+your company and the project %s, and appoint the successor. The inactive CLA Managers can be removed at the same
+time.</p>
+%s
+%s`,
+		claGroup.ProjectName, orphan.CompanyName, claGroup.ProjectName, summary,
+		utils.GetCorporateURL(claGroup.Version == utils.V2), claGroup.ProjectName,
+		utils.GetEmailHelpContent(claGroup.Version == utils.V2), utils.GetEmailSignOffContent())
+	return subject, body
+}
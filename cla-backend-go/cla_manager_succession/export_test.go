@@ -0,0 +1,35 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager_succession
+
+import (
+	"time"
+)
+
+// RenotifyPeriod exposes renotifyPeriod to the tests
+const RenotifyPeriod = renotifyPeriod
+
+// IsOrphaned exposes isOrphaned to the tests
+var IsOrphaned = isOrphaned
+
+// NewTestDetector creates the detector with the clock of the test
+func NewTestDetector(repo Repository, signatureRepo SignatureRepository, claGroupRepo ClaGroupRepository, companyRepo CompanyRepository,
+	userRepo UserRepository, userDirectory UserDirectory, adminDirectory AdminDirectory, eventsService EventsService, now func() time.Time) Detector {
+	d := NewDetector(repo, signatureRepo, claGroupRepo, companyRepo, userRepo, userDirectory, adminDirectory, eventsService).(*detector)
+	d.now = now
+	return d
+}
+
+// NewTestService creates the service with the clock of the test
+func NewTestService(repo Repository, signatureRepo SignatureRepository, claGroupRepo ClaGroupRepository, companyRepo CompanyRepository,
+	userRepo UserRepository, userDirectory UserDirectory, managerService ManagerService, eventsService EventsService, now func() time.Time) Service {
+	s := NewService(repo, signatureRepo, claGroupRepo, companyRepo, userRepo, userDirectory, managerService, eventsService).(*service)
+	s.now = now
+	return s
+}
+
+// CheckManagers exposes checkManagers of the detector to the tests
+func CheckManagers(d Detector, aclEntries []string) ([]Manager, error) {
+	return d.(*detector).checkManagers(aclEntries)
+}
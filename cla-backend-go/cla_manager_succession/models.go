@@ -0,0 +1,115 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager_succession
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// ManagerStatus is the state of a CLA manager of a CCLA signature
+type ManagerStatus string
+
+// manager status values
+const (
+	// ManagerStatusActive managers have an EasyCLA user, an LF account and an email
+	ManagerStatusActive ManagerStatus = "active"
+	// ManagerStatusDeleted managers no longer have an EasyCLA user record
+	ManagerStatusDeleted ManagerStatus = "deleted"
+	// ManagerStatusInactive managers no longer have an LF account in the user service
+	ManagerStatusInactive ManagerStatus = "inactive"
+	// ManagerStatusNoEmail managers can not be reached by email
+	ManagerStatusNoEmail ManagerStatus = "no_email"
+)
+
+// Status is the state of an orphaned CCLA signature
+type Status string
+
+// orphaned signature status values
+const (
+	// StatusOrphaned signatures have no active CLA manager
+	StatusOrphaned Status = "orphaned"
+	// StatusResolved signatures have an active CLA manager again
+	StatusResolved Status = "resolved"
+)
+
+// Manager is a CLA manager of an orphaned signature as found by the detector
+type Manager struct {
+	LfUsername string        `dynamodbav:"lf_username" json:"lf_username"`
+	Status     ManagerStatus `dynamodbav:"manager_status" json:"manager_status"`
+}
+
+// OrphanedSignature is the database model for the orphaned CCLAs table, the resolved signatures are kept as history
+type OrphanedSignature struct {
+	SignatureID    string    `dynamodbav:"signature_id" json:"signature_id"`
+	ClaGroupID     string    `dynamodbav:"cla_group_id" json:"cla_group_id"`
+	ClaGroupName   string    `dynamodbav:"cla_group_name" json:"cla_group_name"`
+	CompanyID      string    `dynamodbav:"company_id" json:"company_id"`
+	CompanySFID    string    `dynamodbav:"company_sfid" json:"company_sfid"`
+	CompanyName    string    `dynamodbav:"company_name" json:"company_name"`
+	Managers       []Manager `dynamodbav:"managers" json:"managers"`
+	Status         Status    `dynamodbav:"orphan_status" json:"orphan_status"`
+	DateDetected   string    `dynamodbav:"date_detected" json:"date_detected"`
+	DateNotified   string    `dynamodbav:"date_notified,omitempty" json:"date_notified,omitempty"`
+	AdminsNotified int64     `dynamodbav:"admins_notified" json:"admins_notified"`
+	Successor      string    `dynamodbav:"successor,omitempty" json:"successor,omitempty"`
+	ResolvedBy     string    `dynamodbav:"resolved_by,omitempty" json:"resolved_by,omitempty"`
+	DateResolved   string    `dynamodbav:"date_resolved,omitempty" json:"date_resolved,omitempty"`
+	DateModified   string    `dynamodbav:"date_modified" json:"date_modified"`
+}
+
+// managerUsernames returns the LF usernames of the CLA managers
+func (o *OrphanedSignature) managerUsernames() []string {
+	usernames := make([]string, 0, len(o.Managers))
+	for _, manager := range o.Managers {
+		usernames = append(usernames, manager.LfUsername)
+	}
+	return usernames
+}
+
+// ToModel converts the database model to the API model
+func (o *OrphanedSignature) ToModel() *models.OrphanedCcla {
+	managers := make([]*models.OrphanedCclaManager, 0, len(o.Managers))
+	for _, manager := range o.Managers {
+		managers = append(managers, &models.OrphanedCclaManager{
+			LfUsername: manager.LfUsername,
+			Status:     string(manager.Status),
+		})
+	}
+	return &models.OrphanedCcla{
+		SignatureID:    o.SignatureID,
+		ClaGroupID:     o.ClaGroupID,
+		ClaGroupName:   o.ClaGroupName,
+		CompanyID:      o.CompanyID,
+		CompanySFID:    o.CompanySFID,
+		CompanyName:    o.CompanyName,
+		Managers:       managers,
+		Status:         string(o.Status),
+		DateDetected:   o.DateDetected,
+		DateNotified:   o.DateNotified,
+		AdminsNotified: o.AdminsNotified,
+		Successor:      o.Successor,
+		ResolvedBy:     o.ResolvedBy,
+		DateResolved:   o.DateResolved,
+		DateModified:   o.DateModified,
+	}
+}
+
+// isOrphaned returns true if none of the managers is active, a signature without managers is orphaned too
+func isOrphaned(managers []Manager) bool {
+	for _, manager := range managers {
+		if manager.Status == ManagerStatusActive {
+			return false
+		}
+	}
+	return true
+}
+
+// Report is the outcome of a run of the orphaned CCLA detector
+type Report struct {
+	Checked  int
+	Detected int
+	Notified int
+	Resolved int
+	Failed   int
+}
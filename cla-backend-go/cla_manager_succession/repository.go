@@ -0,0 +1,171 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager_succession
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// indexes of the orphaned CCLAs table
+const (
+	OrphanStatusIndex = "orphan-status-index"
+	CompanySFIDIndex  = "company-sfid-index"
+)
+
+// Repository interface defines the functions for the orphaned CCLAs data model
+type Repository interface {
+	GetOrphanedSignature(ctx context.Context, signatureID string) (*OrphanedSignature, error)
+	GetOrphanedSignaturesByStatus(ctx context.Context, status Status) ([]*OrphanedSignature, error)
+	GetOrphanedSignaturesByCompany(ctx context.Context, companySFID string) ([]*OrphanedSignature, error)
+	PutOrphanedSignature(ctx context.Context, orphan *OrphanedSignature) error
+}
+
+type repository struct {
+	stage          string
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewRepository creates a new instance of the orphaned CCLAs repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:          stage,
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-orphaned-cclas", stage),
+	}
+}
+
+// GetOrphanedSignature returns the orphaned signature record, nil if the signature was never flagged
+func (repo *repository) GetOrphanedSignature(ctx context.Context, signatureID string) (*OrphanedSignature, error) {
+	f := logrus.Fields{
+		"functionName":   "GetOrphanedSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"signatureID":    signatureID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {S: aws.String(signatureID)},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("error retrieving orphaned signature, error: %v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, nil
+	}
+
+	var orphan OrphanedSignature
+	err = dynamodbattribute.UnmarshalMap(result.Item, &orphan)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling orphaned signature from database, error: %v", err)
+		return nil, err
+	}
+	return &orphan, nil
+}
+
+// GetOrphanedSignaturesByStatus returns the orphaned signature records of all the companies with the specified status
+func (repo *repository) GetOrphanedSignaturesByStatus(ctx context.Context, status Status) ([]*OrphanedSignature, error) {
+	f := logrus.Fields{
+		"functionName":   "GetOrphanedSignaturesByStatus",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"status":         status,
+	}
+
+	condition := expression.Key("orphan_status").Equal(expression.Value(string(status)))
+	return repo.query(f, condition, OrphanStatusIndex)
+}
+
+// GetOrphanedSignaturesByCompany returns the orphaned signature records of the company, including the resolved ones
+func (repo *repository) GetOrphanedSignaturesByCompany(ctx context.Context, companySFID string) ([]*OrphanedSignature, error) {
+	f := logrus.Fields{
+		"functionName":   "GetOrphanedSignaturesByCompany",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"companySFID":    companySFID,
+	}
+
+	condition := expression.Key("company_sfid").Equal(expression.Value(companySFID))
+	return repo.query(f, condition, CompanySFIDIndex)
+}
+
+// PutOrphanedSignature creates or replaces the orphaned signature record
+func (repo *repository) PutOrphanedSignature(ctx context.Context, orphan *OrphanedSignature) error {
+	f := logrus.Fields{
+		"functionName":   "PutOrphanedSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"signatureID":    orphan.SignatureID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(orphan)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal orphaned signature, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store orphaned signature, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+func (repo *repository) query(f logrus.Fields, condition expression.KeyConditionBuilder, indexName string) ([]*OrphanedSignature, error) {
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for orphaned signatures query, error: %v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.tableName),
+		IndexName:                 aws.String(indexName),
+	}
+	var resultList []map[string]*dynamodb.AttributeValue
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).Warnf("error retrieving orphaned signatures, error: %v", err)
+			return nil, err
+		}
+		resultList = append(resultList, results.Items...)
+		if len(results.LastEvaluatedKey) != 0 {
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+
+	var orphans []*OrphanedSignature
+	err = dynamodbattribute.UnmarshalListOfMaps(resultList, &orphans)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling orphaned signatures from database, error: %v", err)
+		return nil, err
+	}
+	return orphans, nil
+}
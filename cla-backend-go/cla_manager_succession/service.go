@@ -0,0 +1,277 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager_succession
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/project"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/signatures"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	userService "github.com/communitybridge/easycla/cla-backend-go/v2/user-service"
+	userModels "github.com/communitybridge/easycla/cla-backend-go/v2/user-service/models"
+)
+
+// errors
+var (
+	ErrCompanyNotFound   = errors.New("company not found")
+	ErrCCLANotSigned     = errors.New("company has not signed a CCLA for the CLA group")
+	ErrNotOrphaned       = errors.New("the CCLA signature has an active CLA manager")
+	ErrSuccessorNotFound = errors.New("successor not found")
+	ErrInvalidSuccessor  = errors.New("invalid successor")
+)
+
+// SignatureRepository is the part of the signatures repository used to inspect and update the CLA managers
+type SignatureRepository interface {
+	GetProjectSignatures(ctx context.Context, params signatures.GetProjectSignaturesParams, pageSize int64) (*v1Models.Signatures, error)
+	GetProjectCompanySignature(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*v1Models.Signature, error)
+	GetSignatureACL(ctx context.Context, signatureID string) ([]string, error)
+	RemoveCLAManager(ctx context.Context, signatureID, claManagerID string) (*v1Models.Signature, error)
+}
+
+// ClaGroupRepository is the part of the project repository used to walk the CLA groups
+type ClaGroupRepository interface {
+	GetCLAGroups(ctx context.Context, params *project.GetProjectsParams) (*v1Models.ClaGroups, error)
+	GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*v1Models.ClaGroup, error)
+}
+
+// CompanyRepository is the part of the company repository used to resolve the companies of the signatures
+type CompanyRepository interface {
+	GetCompany(ctx context.Context, companyID string) (*v1Models.Company, error)
+	GetCompanyByExternalID(ctx context.Context, companySFID string) (*v1Models.Company, error)
+}
+
+// UserRepository is the part of the EasyCLA users repository used to check the CLA managers
+type UserRepository interface {
+	GetUserByLFUserName(lfUserName string) (*v1Models.User, error)
+}
+
+// UserDirectory is the part of the user service client used to check the LF accounts of the CLA managers
+type UserDirectory interface {
+	GetUserByUsername(lfUsername string) (*userModels.User, error)
+}
+
+// ManagerService is the part of the CLA manager service used to add the successor
+type ManagerService interface {
+	AddClaManager(ctx context.Context, companyID string, claGroupID string, LFID string) (*v1Models.Signature, error)
+}
+
+// EventsService is the part of the events service used to log the detected and resolved signatures
+type EventsService interface {
+	LogEvent(args *events.LogEventArgs)
+}
+
+// Service interface defines the CLA manager succession service methods
+type Service interface {
+	GetOrphanedSignatures(ctx context.Context, companySFID string) ([]*OrphanedSignature, error)
+	AppointSuccessor(ctx context.Context, companySFID, claGroupID, successorLFID string, removeInactiveManagers bool, authUser *auth.User) (*OrphanedSignature, error)
+}
+
+// managerChecker determines the status of the CLA managers, it is shared by the detector and the service
+type managerChecker struct {
+	userRepo      UserRepository
+	userDirectory UserDirectory
+}
+
+type service struct {
+	managerChecker
+	repo           Repository
+	signatureRepo  SignatureRepository
+	claGroupRepo   ClaGroupRepository
+	companyRepo    CompanyRepository
+	managerService ManagerService
+	eventsService  EventsService
+	now            func() time.Time
+}
+
+// NewService creates a new CLA manager succession service
+func NewService(repo Repository, signatureRepo SignatureRepository, claGroupRepo ClaGroupRepository, companyRepo CompanyRepository,
+	userRepo UserRepository, userDirectory UserDirectory, managerService ManagerService, eventsService EventsService) Service {
+	return &service{
+		managerChecker: managerChecker{
+			userRepo:      userRepo,
+			userDirectory: userDirectory,
+		},
+		repo:           repo,
+		signatureRepo:  signatureRepo,
+		claGroupRepo:   claGroupRepo,
+		companyRepo:    companyRepo,
+		managerService: managerService,
+		eventsService:  eventsService,
+		now:            time.Now,
+	}
+}
+
+// checkManagers determines the status of each CLA manager of the signature ACL
+func (c *managerChecker) checkManagers(aclEntries []string) ([]Manager, error) {
+	managers := make([]Manager, 0, len(aclEntries))
+	for _, lfUsername := range aclEntries {
+		status, err := c.managerStatus(lfUsername)
+		if err != nil {
+			return nil, err
+		}
+		managers = append(managers, Manager{LfUsername: lfUsername, Status: status})
+	}
+	return managers, nil
+}
+
+// managerStatus checks the EasyCLA user and the LF account of the CLA manager. Errors other than a missing user are
+// returned so a signature is not flagged while the user service is unavailable.
+func (c *managerChecker) managerStatus(lfUsername string) (ManagerStatus, error) {
+	userModel, err := c.userRepo.GetUserByLFUserName(lfUsername)
+	if err != nil {
+		return "", err
+	}
+	if userModel == nil {
+		return ManagerStatusDeleted, nil
+	}
+
+	lfUser, err := c.userDirectory.GetUserByUsername(lfUsername)
+	if err != nil {
+		if errors.Is(err, userService.ErrUserNotFound) {
+			return ManagerStatusInactive, nil
+		}
+		return "", err
+	}
+
+	if userModel.LfEmail != "" || len(userModel.Emails) > 0 {
+		return ManagerStatusActive, nil
+	}
+	for _, email := range lfUser.Emails {
+		if email != nil && email.EmailAddress != nil && *email.EmailAddress != "" {
+			return ManagerStatusActive, nil
+		}
+	}
+	return ManagerStatusNoEmail, nil
+}
+
+// GetOrphanedSignatures returns the orphaned signature records of the company, including the resolved ones
+func (s *service) GetOrphanedSignatures(ctx context.Context, companySFID string) ([]*OrphanedSignature, error) {
+	return s.repo.GetOrphanedSignaturesByCompany(ctx, companySFID)
+}
+
+// AppointSuccessor adds the successor as CLA manager of the orphaned CCLA signature of the company for the CLA group
+// and resolves it, optionally the inactive CLA managers are removed from the signature
+func (s *service) AppointSuccessor(ctx context.Context, companySFID, claGroupID, successorLFID string, removeInactiveManagers bool, authUser *auth.User) (*OrphanedSignature, error) {
+	f := logrus.Fields{
+		"functionName":   "AppointSuccessor",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companySFID":    companySFID,
+		"claGroupID":     claGroupID,
+		"successorLFID":  successorLFID,
+		"authUsername":   authUser.UserName,
+	}
+
+	companyModel, err := s.companyRepo.GetCompanyByExternalID(ctx, companySFID)
+	if err != nil || companyModel == nil {
+		log.WithFields(f).Warnf("unable to load company by SFID: %s, error: %+v", companySFID, err)
+		return nil, ErrCompanyNotFound
+	}
+
+	signed, approved := true, true
+	sigModel, err := s.signatureRepo.GetProjectCompanySignature(ctx, companyModel.CompanyID, claGroupID, &signed, &approved, nil, aws.Int64(1))
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the CCLA signature of company: %s, error: %+v", companyModel.CompanyID, err)
+		return nil, err
+	}
+	if sigModel == nil {
+		return nil, ErrCCLANotSigned
+	}
+	signatureID := sigModel.SignatureID.String()
+
+	orphan, err := s.repo.GetOrphanedSignature(ctx, signatureID)
+	if err != nil {
+		return nil, err
+	}
+	if orphan == nil || orphan.Status != StatusOrphaned {
+		return nil, ErrNotOrphaned
+	}
+
+	aclEntries, err := s.signatureRepo.GetSignatureACL(ctx, signatureID)
+	if err != nil {
+		return nil, err
+	}
+	for _, lfUsername := range aclEntries {
+		if lfUsername == successorLFID {
+			return nil, fmt.Errorf("%w: %s is already a CLA manager of the signature", ErrInvalidSuccessor, successorLFID)
+		}
+	}
+	successorStatus, err := s.managerStatus(successorLFID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to check the successor, error: %+v", err)
+		return nil, err
+	}
+	switch successorStatus {
+	case ManagerStatusActive:
+	case ManagerStatusDeleted:
+		return nil, ErrSuccessorNotFound
+	default:
+		return nil, fmt.Errorf("%w: %s is %s", ErrInvalidSuccessor, successorLFID, strings.ReplaceAll(string(successorStatus), "_", " "))
+	}
+
+	// Adds the successor to the signature and company ACLs, notifies the successor and logs the CLA manager event
+	if _, err := s.managerService.AddClaManager(ctx, companyModel.CompanyID, claGroupID, successorLFID); err != nil {
+		log.WithFields(f).Warnf("unable to add the successor as CLA manager, error: %+v", err)
+		return nil, err
+	}
+
+	var removed []string
+	if removeInactiveManagers {
+		for _, lfUsername := range aclEntries {
+			status, statusErr := s.managerStatus(lfUsername)
+			if statusErr != nil || status == ManagerStatusActive {
+				continue
+			}
+			if _, removeErr := s.signatureRepo.RemoveCLAManager(ctx, signatureID, lfUsername); removeErr != nil {
+				log.WithFields(f).Warnf("unable to remove the inactive CLA manager: %s, error: %+v", lfUsername, removeErr)
+				continue
+			}
+			removed = append(removed, lfUsername)
+		}
+	}
+
+	now := s.now().UTC()
+	orphan.Status = StatusResolved
+	orphan.Successor = successorLFID
+	orphan.ResolvedBy = authUser.UserName
+	orphan.DateResolved = utils.TimeToString(now)
+	orphan.DateModified = utils.TimeToString(now)
+	if err := s.repo.PutOrphanedSignature(ctx, orphan); err != nil {
+		return nil, err
+	}
+
+	claGroupName := orphan.ClaGroupName
+	claGroupModel, claGroupErr := s.claGroupRepo.GetCLAGroupByID(ctx, claGroupID, false)
+	if claGroupErr == nil && claGroupModel != nil {
+		claGroupName = claGroupModel.ProjectName
+	}
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:     events.ClaManagerSuccessorAppointed,
+		ProjectID:     claGroupID,
+		ClaGroupModel: claGroupModel,
+		CompanyID:     companyModel.CompanyID,
+		CompanyModel:  companyModel,
+		LfUsername:    authUser.UserName,
+		EventData: &events.CLAManagerSuccessorAppointedEventData{
+			CompanyName:     companyModel.CompanyName,
+			ProjectName:     claGroupName,
+			UserName:        successorLFID,
+			UserLFID:        successorLFID,
+			RemovedManagers: removed,
+		},
+	})
+
+	return orphan, nil
+}
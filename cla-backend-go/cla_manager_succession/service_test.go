@@ -0,0 +1,252 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager_succession_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/communitybridge/easycla/cla-backend-go/cla_manager_succession"
+	"github.com/communitybridge/easycla/cla-backend-go/cla_manager_succession/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	orgModels "github.com/communitybridge/easycla/cla-backend-go/v2/organization-service/models"
+	userService "github.com/communitybridge/easycla/cla-backend-go/v2/user-service"
+	userModels "github.com/communitybridge/easycla/cla-backend-go/v2/user-service/models"
+)
+
+var testNow = time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+const testSignatureID = "7a4b1c2d-3e4f-4a5b-8c6d-7e8f9a0b1c2d"
+
+func testSignature() *v1Models.Signature {
+	return &v1Models.Signature{
+		SignatureID:          strfmt.UUID4(testSignatureID),
+		SignatureReferenceID: strfmt.UUID4("company-1"),
+		SignatureSigned:      true,
+		SignatureApproved:    true,
+	}
+}
+
+type testMocks struct {
+	repo           *mock.MockRepository
+	signatureRepo  *mock.MockSignatureRepository
+	claGroupRepo   *mock.MockClaGroupRepository
+	companyRepo    *mock.MockCompanyRepository
+	userRepo       *mock.MockUserRepository
+	userDirectory  *mock.MockUserDirectory
+	adminDirectory *mock.MockAdminDirectory
+	managerService *mock.MockManagerService
+	eventsService  *mock.MockEventsService
+}
+
+func newTestMocks(ctrl *gomock.Controller) *testMocks {
+	m := &testMocks{
+		repo:           mock.NewMockRepository(ctrl),
+		signatureRepo:  mock.NewMockSignatureRepository(ctrl),
+		claGroupRepo:   mock.NewMockClaGroupRepository(ctrl),
+		companyRepo:    mock.NewMockCompanyRepository(ctrl),
+		userRepo:       mock.NewMockUserRepository(ctrl),
+		userDirectory:  mock.NewMockUserDirectory(ctrl),
+		adminDirectory: mock.NewMockAdminDirectory(ctrl),
+		managerService: mock.NewMockManagerService(ctrl),
+		eventsService:  mock.NewMockEventsService(ctrl),
+	}
+	m.claGroupRepo.EXPECT().GetCLAGroupByID(gomock.Any(), "cla-group-1", false).
+		Return(&v1Models.ClaGroup{ProjectID: "cla-group-1", ProjectName: "Project"}, nil).AnyTimes()
+	m.companyRepo.EXPECT().GetCompany(gomock.Any(), "company-1").
+		Return(&v1Models.Company{CompanyID: "company-1", CompanyExternalID: "company-sfid", CompanyName: "Acme"}, nil).AnyTimes()
+	m.companyRepo.EXPECT().GetCompanyByExternalID(gomock.Any(), "company-sfid").
+		Return(&v1Models.Company{CompanyID: "company-1", CompanyExternalID: "company-sfid", CompanyName: "Acme"}, nil).AnyTimes()
+	return m
+}
+
+// expectUser sets the EasyCLA user and the LF account of the user, a nil account is an LF account which doesn't exist
+func (m *testMocks) expectUser(lfUsername string, user *v1Models.User, account *userModels.User) {
+	m.userRepo.EXPECT().GetUserByLFUserName(lfUsername).Return(user, nil).AnyTimes()
+	if account == nil {
+		m.userDirectory.EXPECT().GetUserByUsername(lfUsername).Return(nil, userService.ErrUserNotFound).AnyTimes()
+		return
+	}
+	m.userDirectory.EXPECT().GetUserByUsername(lfUsername).Return(account, nil).AnyTimes()
+}
+
+// expectActiveUser sets a user with an EasyCLA record, an LF account and an email
+func (m *testMocks) expectActiveUser(lfUsername string) {
+	m.expectUser(lfUsername, &v1Models.User{LfUsername: lfUsername, LfEmail: lfUsername + "@acme.com"}, &userModels.User{Username: lfUsername})
+}
+
+// expectStored returns the orphaned signature as it was last stored
+func (m *testMocks) expectStored() func() *cla_manager_succession.OrphanedSignature {
+	var stored *cla_manager_succession.OrphanedSignature
+	m.repo.EXPECT().PutOrphanedSignature(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, orphan *cla_manager_succession.OrphanedSignature) error {
+		copied := *orphan
+		stored = &copied
+		return nil
+	}).AnyTimes()
+	return func() *cla_manager_succession.OrphanedSignature {
+		copied := *stored
+		return &copied
+	}
+}
+
+func (m *testMocks) detector(now time.Time) cla_manager_succession.Detector {
+	return cla_manager_succession.NewTestDetector(m.repo, m.signatureRepo, m.claGroupRepo, m.companyRepo, m.userRepo, m.userDirectory,
+		m.adminDirectory, m.eventsService, func() time.Time { return now })
+}
+
+func (m *testMocks) service() cla_manager_succession.Service {
+	return cla_manager_succession.NewTestService(m.repo, m.signatureRepo, m.claGroupRepo, m.companyRepo, m.userRepo, m.userDirectory,
+		m.managerService, m.eventsService, func() time.Time { return testNow })
+}
+
+func TestManagerStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := newTestMocks(ctrl)
+	m.expectActiveUser("active")
+	m.expectUser("gone", nil, nil)
+	m.expectUser("left", &v1Models.User{LfUsername: "left", LfEmail: "left@acme.com"}, nil)
+	m.expectUser("unreachable", &v1Models.User{LfUsername: "unreachable"}, &userModels.User{Username: "unreachable"})
+	m.expectUser("lf-email", &v1Models.User{LfUsername: "lf-email"},
+		&userModels.User{Username: "lf-email", Emails: []*userModels.UserEmail{{EmailAddress: aws.String("lf@acme.com")}}})
+
+	managers, err := cla_manager_succession.CheckManagers(m.detector(testNow), []string{"active", "gone", "left", "unreachable", "lf-email"})
+	assert.Nil(t, err)
+	assert.Equal(t, []cla_manager_succession.Manager{
+		{LfUsername: "active", Status: cla_manager_succession.ManagerStatusActive},
+		{LfUsername: "gone", Status: cla_manager_succession.ManagerStatusDeleted},
+		{LfUsername: "left", Status: cla_manager_succession.ManagerStatusInactive},
+		{LfUsername: "unreachable", Status: cla_manager_succession.ManagerStatusNoEmail},
+		{LfUsername: "lf-email", Status: cla_manager_succession.ManagerStatusActive},
+	}, managers)
+	assert.False(t, cla_manager_succession.IsOrphaned(managers))
+	assert.True(t, cla_manager_succession.IsOrphaned(managers[1:4]))
+	assert.True(t, cla_manager_succession.IsOrphaned(nil), "a signature without managers is orphaned")
+}
+
+func TestDetectOrphanedSignatures(t *testing.T) {
+	previous := utils.GetEmailSender()
+	utils.SetEmailSender(&utils.MockEmailSender{})
+	defer utils.SetEmailSender(previous)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := newTestMocks(ctrl)
+	m.expectUser("gone", nil, nil)
+	m.expectActiveUser("new-manager")
+	m.claGroupRepo.EXPECT().GetCLAGroups(gomock.Any(), gomock.Any()).
+		Return(&v1Models.ClaGroups{Projects: []v1Models.ClaGroup{{ProjectID: "cla-group-1", ProjectName: "Project"}}}, nil).AnyTimes()
+	// the signed CCLA is on the second page of the CLA group signatures
+	m.signatureRepo.EXPECT().GetProjectSignatures(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, params signatures.GetProjectSignaturesParams, pageSize int64) (*v1Models.Signatures, error) {
+			if params.NextKey == nil {
+				unsigned := &v1Models.Signature{SignatureID: strfmt.UUID4("unsigned")}
+				return &v1Models.Signatures{Signatures: []*v1Models.Signature{unsigned}, LastKeyScanned: "unsigned"}, nil
+			}
+			assert.Equal(t, "unsigned", *params.NextKey)
+			return &v1Models.Signatures{Signatures: []*v1Models.Signature{testSignature()}}, nil
+		}).AnyTimes()
+	acl := []string{"gone"}
+	m.signatureRepo.EXPECT().GetSignatureACL(gomock.Any(), testSignatureID).DoAndReturn(func(ctx context.Context, signatureID string) ([]string, error) {
+		return acl, nil
+	}).AnyTimes()
+	m.adminDirectory.EXPECT().ListOrgUserAdminScopes("company-sfid", nil).Return(&orgModels.UserrolescopesList{
+		Userroles: []*orgModels.UserRoleScopes{{Contact: &orgModels.Contact{EmailAddress: "admin@acme.com", Name: "Admin"}}},
+	}, nil).Times(2)
+	m.eventsService.EXPECT().LogEvent(gomock.Any()).Do(func(args *events.LogEventArgs) {
+		assert.Equal(t, events.ClaManagerOrphanedCCLADetected, args.EventType)
+	})
+	stored := m.expectStored()
+
+	m.repo.EXPECT().GetOrphanedSignaturesByStatus(gomock.Any(), cla_manager_succession.StatusOrphaned).Return(nil, nil)
+	report, err := m.detector(testNow).DetectOrphanedSignatures(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &cla_manager_succession.Report{Checked: 1, Detected: 1, Notified: 1}, report)
+	orphan := stored()
+	assert.Equal(t, cla_manager_succession.StatusOrphaned, orphan.Status)
+	assert.Equal(t, "company-sfid", orphan.CompanySFID)
+	assert.Equal(t, int64(1), orphan.AdminsNotified)
+
+	// The admins are not notified again until the re-notification period passed
+	m.repo.EXPECT().GetOrphanedSignaturesByStatus(gomock.Any(), cla_manager_succession.StatusOrphaned).Return([]*cla_manager_succession.OrphanedSignature{stored()}, nil)
+	report, err = m.detector(testNow.Add(24 * time.Hour)).DetectOrphanedSignatures(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &cla_manager_succession.Report{Checked: 1}, report)
+
+	m.repo.EXPECT().GetOrphanedSignaturesByStatus(gomock.Any(), cla_manager_succession.StatusOrphaned).Return([]*cla_manager_succession.OrphanedSignature{stored()}, nil)
+	report, err = m.detector(testNow.Add(cla_manager_succession.RenotifyPeriod)).DetectOrphanedSignatures(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &cla_manager_succession.Report{Checked: 1, Notified: 1}, report)
+
+	// A CLA manager added by other means resolves the signature
+	acl = []string{"gone", "new-manager"}
+	m.repo.EXPECT().GetOrphanedSignaturesByStatus(gomock.Any(), cla_manager_succession.StatusOrphaned).Return([]*cla_manager_succession.OrphanedSignature{stored()}, nil)
+	report, err = m.detector(testNow.Add(cla_manager_succession.RenotifyPeriod)).DetectOrphanedSignatures(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &cla_manager_succession.Report{Checked: 1, Resolved: 1}, report)
+	assert.Equal(t, cla_manager_succession.StatusResolved, stored().Status)
+}
+
+func TestAppointSuccessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := newTestMocks(ctrl)
+	m.expectUser("gone", nil, nil)
+	m.expectUser("left", &v1Models.User{LfUsername: "left"}, nil)
+	m.expectActiveUser("successor")
+	m.expectUser("unknown", nil, nil)
+	m.expectUser("no-account", &v1Models.User{LfUsername: "no-account"}, nil)
+	m.signatureRepo.EXPECT().GetProjectCompanySignature(gomock.Any(), "company-1", "cla-group-1", gomock.Any(), gomock.Any(), nil, gomock.Any()).
+		Return(testSignature(), nil).AnyTimes()
+	m.signatureRepo.EXPECT().GetSignatureACL(gomock.Any(), testSignatureID).Return([]string{"gone", "left"}, nil).AnyTimes()
+	admin := &auth.User{UserName: "admin"}
+	s := m.service()
+
+	// Only orphaned signatures get a successor
+	m.repo.EXPECT().GetOrphanedSignature(gomock.Any(), testSignatureID).Return(nil, nil)
+	_, err := s.AppointSuccessor(context.Background(), "company-sfid", "cla-group-1", "successor", true, admin)
+	assert.True(t, errors.Is(err, cla_manager_succession.ErrNotOrphaned))
+
+	m.repo.EXPECT().GetOrphanedSignature(gomock.Any(), testSignatureID).
+		Return(&cla_manager_succession.OrphanedSignature{SignatureID: testSignatureID, CompanySFID: "company-sfid", Status: cla_manager_succession.StatusOrphaned}, nil).Times(4)
+	_, err = s.AppointSuccessor(context.Background(), "company-sfid", "cla-group-1", "unknown", true, admin)
+	assert.True(t, errors.Is(err, cla_manager_succession.ErrSuccessorNotFound))
+	_, err = s.AppointSuccessor(context.Background(), "company-sfid", "cla-group-1", "no-account", true, admin)
+	assert.True(t, errors.Is(err, cla_manager_succession.ErrInvalidSuccessor))
+	_, err = s.AppointSuccessor(context.Background(), "company-sfid", "cla-group-1", "left", true, admin)
+	assert.True(t, errors.Is(err, cla_manager_succession.ErrInvalidSuccessor))
+
+	m.managerService.EXPECT().AddClaManager(gomock.Any(), "company-1", "cla-group-1", "successor").
+		Return(&v1Models.Signature{SignatureID: strfmt.UUID4(testSignatureID)}, nil)
+	gomock.InOrder(
+		m.signatureRepo.EXPECT().RemoveCLAManager(gomock.Any(), testSignatureID, "gone").Return(testSignature(), nil),
+		m.signatureRepo.EXPECT().RemoveCLAManager(gomock.Any(), testSignatureID, "left").Return(testSignature(), nil),
+	)
+	stored := m.expectStored()
+	m.eventsService.EXPECT().LogEvent(gomock.Any()).Do(func(args *events.LogEventArgs) {
+		assert.Equal(t, events.ClaManagerSuccessorAppointed, args.EventType)
+		assert.Equal(t, []string{"gone", "left"}, args.EventData.(*events.CLAManagerSuccessorAppointedEventData).RemovedManagers)
+	})
+	orphan, err := s.AppointSuccessor(context.Background(), "company-sfid", "cla-group-1", "successor", true, admin)
+	assert.Nil(t, err)
+	assert.Equal(t, cla_manager_succession.StatusResolved, orphan.Status)
+	assert.Equal(t, "successor", orphan.Successor)
+	assert.Equal(t, "admin", orphan.ResolvedBy)
+	assert.Equal(t, orphan, stored())
+
+	m.repo.EXPECT().GetOrphanedSignature(gomock.Any(), testSignatureID).Return(stored(), nil)
+	_, err = s.AppointSuccessor(context.Background(), "company-sfid", "cla-group-1", "successor", true, admin)
+	assert.True(t, errors.Is(err, cla_manager_succession.ErrNotOrphaned))
+}
@@ -0,0 +1,21 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_status
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+)
+
+// MatchesDomain exposes matchesDomain to the tests
+var MatchesDomain = matchesDomain
+
+// ParseApprovalListChange exposes parseApprovalListChange to the tests
+func ParseApprovalListChange(event *models.Event) (added bool, listType approval_list_expiry.ListType, value string, ok bool) {
+	change := parseApprovalListChange(event)
+	if change == nil {
+		return false, "", "", false
+	}
+	return change.added, change.listType, change.value, true
+}
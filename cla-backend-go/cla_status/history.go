@@ -0,0 +1,618 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/affiliation_change"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// ErrInvalidHistoryInput is returned when the historical coverage lookup is missing the CLA group, the author or a
+// timestamp in the past
+var ErrInvalidHistoryInput = errors.New("the CLA group, a timestamp which is not in the future and an email or github login are required")
+
+// EventsService is the part of the events service used to replay the approval list changes
+type EventsService interface {
+	GetClaGroupEvents(claGroupID string, nextKey *string, paramPageSize *int64, all bool, searchTerm *string) (*models.EventList, error)
+}
+
+// AffiliationRepository is the part of the user affiliations repository used to find the company of the user at the
+// requested time
+type AffiliationRepository interface {
+	GetUserAffiliations(ctx context.Context, userID string) ([]*affiliation_change.Affiliation, error)
+}
+
+// approvalListChangePatterns match the details of the events logged when an approval list entry is added or removed,
+// the list type defaults to github_org for the github organization whitelist events
+var approvalListChangePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^CLA Manager \[.*\] (?P<action>added|removed) (?P<list>Email|Domain|GitHub Username|GitHub Org) (?P<value>\S+) (?:to|from) the approval list for Company: `),
+	regexp.MustCompile(`^CLA Manager \[.*\] (?P<action>added|removed) GitHub Organization \[(?P<value>[^\]]+)\] (?:to|from) the whitelist for project `),
+	regexp.MustCompile(`^EasyCLA (?P<action>added|removed) (?P<list>email|domain|github_username|github_org) (?P<value>\S+) (?:valid from|which expired on|merged from company) `),
+}
+
+// approvalListChange is an approval list entry added or removed by an event
+type approvalListChange struct {
+	listType approval_list_expiry.ListType
+	value    string
+	added    bool
+	date     time.Time
+	event    *models.Event
+}
+
+// approvalListEntry is an entry of the approval list at the requested time with the evidence of its presence, assumed
+// entries are on the current approval list without any recorded change
+type approvalListEntry struct {
+	listType approval_list_expiry.ListType
+	value    string
+	assumed  bool
+	evidence *Evidence
+}
+
+// historyLookup holds the state of one historical coverage lookup
+type historyLookup struct {
+	*service
+	claGroupID string
+	at         time.Time
+	result     *HistoricalCoverage
+	// changes are the approval list changes of the CLA group, loaded once
+	changes []*approvalListChange
+	loaded  bool
+}
+
+// GetClaGroupProjectSFIDs returns the SFIDs of the foundation and the projects of the CLA group, used to authorize
+// the lookup
+func (s *service) GetClaGroupProjectSFIDs(claGroupID string) ([]string, error) {
+	projectClaGroups, err := s.projectsClaGroupsRepo.GetProjectsIdsForClaGroup(claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	var projectSFIDs []string
+	for _, projectClaGroup := range projectClaGroups {
+		for _, projectSFID := range []string{projectClaGroup.FoundationSFID, projectClaGroup.ProjectSFID} {
+			if projectSFID != "" && !utils.StringInSlice(projectSFID, projectSFIDs) {
+				projectSFIDs = append(projectSFIDs, projectSFID)
+			}
+		}
+	}
+	return projectSFIDs, nil
+}
+
+// GetHistoricalCoverage reconstructs the CLA coverage of the author at the requested time from the signature dates,
+// the affiliations of the user and the approval list change events. Only the signatures which are still signed and
+// approved are considered. The coverage is ccla_unverified when it depends on an entry of the current approval list
+// without any recorded change or on a github organization membership.
+func (s *service) GetHistoricalCoverage(ctx context.Context, claGroupID string, author Author, at time.Time) (*HistoricalCoverage, error) {
+	f := logrus.Fields{
+		"functionName":   "GetHistoricalCoverage",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"at":             utils.TimeToString(at),
+	}
+
+	if claGroupID == "" || (author.Email == "" && author.GithubLogin == "") || at.IsZero() || at.After(s.now()) {
+		return nil, ErrInvalidHistoryInput
+	}
+
+	lookup := &historyLookup{
+		service:    s,
+		claGroupID: claGroupID,
+		at:         at,
+		result: &HistoricalCoverage{
+			Author:     author,
+			ClaGroupID: claGroupID,
+			At:         at,
+			Coverage:   CoverageNone,
+		},
+	}
+
+	userModels, err := s.getUsers(author)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the users of the author")
+		return nil, err
+	}
+	if len(userModels) == 0 {
+		lookup.addEvidence(&Evidence{
+			Source:      EvidenceSourceCurrentState,
+			Description: "no EasyCLA user matches the email or github login of the author",
+		})
+		return lookup.result, nil
+	}
+
+	coverage := CoverageNone
+	for _, userModel := range userModels {
+		covered, iclaErr := lookup.checkICLA(ctx, userModel)
+		if iclaErr != nil {
+			log.WithFields(f).WithError(iclaErr).Warn("unable to check the ICLA of the user")
+			return nil, iclaErr
+		}
+		if covered {
+			lookup.result.Coverage = CoverageICLA
+			return lookup.result, nil
+		}
+
+		cclaCoverage, cclaErr := lookup.checkCCLA(ctx, userModel, author)
+		if cclaErr != nil {
+			log.WithFields(f).WithError(cclaErr).Warn("unable to check the CCLA coverage of the user")
+			return nil, cclaErr
+		}
+		if cclaCoverage == CoverageCCLA {
+			lookup.result.Coverage = CoverageCCLA
+			return lookup.result, nil
+		}
+		if cclaCoverage == CoverageCCLAUnverified {
+			coverage = CoverageCCLAUnverified
+		}
+	}
+
+	lookup.result.Coverage = coverage
+	return lookup.result, nil
+}
+
+func (l *historyLookup) addEvidence(evidence *Evidence) {
+	l.result.Evidence = append(l.result.Evidence, evidence)
+}
+
+// checkICLA returns true if the user signed an ICLA for the CLA group before the requested time
+func (l *historyLookup) checkICLA(ctx context.Context, userModel *models.User) (bool, error) {
+	iclaSignature, err := l.signatureRepo.GetIndividualSignature(ctx, l.claGroupID, userModel.UserID)
+	if err != nil {
+		return false, err
+	}
+	if iclaSignature == nil {
+		l.addEvidence(&Evidence{
+			Source:      EvidenceSourceSignature,
+			Description: fmt.Sprintf("user %s has no ICLA signature", userLabel(userModel)),
+		})
+		return false, nil
+	}
+
+	signedOn, ok := signatureDate(iclaSignature)
+	evidence := &Evidence{
+		Date:     formatDate(signedOn),
+		Source:   EvidenceSourceSignature,
+		RecordID: iclaSignature.SignatureID.String(),
+	}
+	l.addEvidence(evidence)
+	if !ok {
+		evidence.Description = fmt.Sprintf("the ICLA signature of user %s has no signing date", userLabel(userModel))
+		return false, nil
+	}
+	if signedOn.After(l.at) {
+		evidence.Description = fmt.Sprintf("user %s signed the ICLA after the requested time", userLabel(userModel))
+		return false, nil
+	}
+	evidence.Description = fmt.Sprintf("user %s signed the ICLA before the requested time", userLabel(userModel))
+	evidence.Supporting = true
+	return true, nil
+}
+
+// checkCCLA returns the CCLA coverage of the user, ccla if one of the companies the user was affiliated with at the
+// requested time had signed the CCLA with the user on its approval list and the user had acknowledged the CCLA
+func (l *historyLookup) checkCCLA(ctx context.Context, userModel *models.User, author Author) (string, error) {
+	companyIDs, err := l.companiesAt(ctx, userModel)
+	if err != nil {
+		return CoverageNone, err
+	}
+
+	coverage := CoverageNone
+	for _, companyID := range companyIDs {
+		companyCoverage, companyErr := l.checkCompany(ctx, companyID, userModel, author)
+		if companyErr != nil || companyCoverage == CoverageCCLA {
+			return companyCoverage, companyErr
+		}
+		if companyCoverage == CoverageCCLAUnverified {
+			coverage = CoverageCCLAUnverified
+		}
+	}
+	return coverage, nil
+}
+
+// companiesAt returns the companies the user was affiliated with at the requested time, the current company of the
+// user is used when the user has no affiliation history
+func (l *historyLookup) companiesAt(ctx context.Context, userModel *models.User) ([]string, error) {
+	var affiliations []*affiliation_change.Affiliation
+	if l.affiliationRepo != nil {
+		var err error
+		affiliations, err = l.affiliationRepo.GetUserAffiliations(ctx, userModel.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(affiliations) == 0 {
+		if userModel.CompanyID == "" {
+			l.addEvidence(&Evidence{
+				Source:      EvidenceSourceCurrentState,
+				Description: fmt.Sprintf("user %s has no company affiliation", userLabel(userModel)),
+			})
+			return nil, nil
+		}
+		l.addEvidence(&Evidence{
+			Source:      EvidenceSourceCurrentState,
+			RecordID:    userModel.CompanyID,
+			Description: fmt.Sprintf("user %s has no affiliation history, the current company %s is assumed", userLabel(userModel), userModel.CompanyID),
+		})
+		return []string{userModel.CompanyID}, nil
+	}
+
+	var companyIDs []string
+	for _, affiliation := range affiliations {
+		started, err := utils.ParseDateTime(affiliation.DateStarted)
+		if err != nil || started.After(l.at) {
+			continue
+		}
+		if affiliation.DateEnded != "" {
+			ended, err := utils.ParseDateTime(affiliation.DateEnded)
+			if err == nil && !ended.After(l.at) {
+				continue
+			}
+		}
+		l.addEvidence(&Evidence{
+			Date:        affiliation.DateStarted,
+			Source:      EvidenceSourceAffiliation,
+			RecordID:    affiliation.AffiliationID,
+			Description: fmt.Sprintf("user %s was affiliated with company %s", userLabel(userModel), affiliation.CompanyName),
+			Supporting:  true,
+		})
+		companyIDs = append(companyIDs, affiliation.CompanyID)
+	}
+	if len(companyIDs) == 0 {
+		l.addEvidence(&Evidence{
+			Source:      EvidenceSourceAffiliation,
+			Description: fmt.Sprintf("user %s had no company affiliation at the requested time", userLabel(userModel)),
+		})
+	}
+	return companyIDs, nil
+}
+
+// checkCompany returns the coverage of the user by the company CCLA at the requested time
+func (l *historyLookup) checkCompany(ctx context.Context, companyID string, userModel *models.User, author Author) (string, error) {
+	cclaSignature, err := l.signatureRepo.GetCorporateSignature(ctx, l.claGroupID, companyID)
+	if err != nil {
+		return CoverageNone, err
+	}
+	if cclaSignature == nil {
+		l.addEvidence(&Evidence{
+			Source:      EvidenceSourceSignature,
+			RecordID:    companyID,
+			Description: fmt.Sprintf("company %s has no CCLA signature", companyID),
+		})
+		return CoverageNone, nil
+	}
+
+	signedOn, ok := signatureDate(cclaSignature)
+	evidence := &Evidence{
+		Date:     formatDate(signedOn),
+		Source:   EvidenceSourceSignature,
+		RecordID: cclaSignature.SignatureID.String(),
+	}
+	l.addEvidence(evidence)
+	if !ok {
+		evidence.Description = fmt.Sprintf("the CCLA signature of company %s has no signing date", companyID)
+		return CoverageNone, nil
+	}
+	if signedOn.After(l.at) {
+		evidence.Description = fmt.Sprintf("company %s signed the CCLA after the requested time", companyID)
+		return CoverageNone, nil
+	}
+	evidence.Description = fmt.Sprintf("company %s signed the CCLA before the requested time", companyID)
+	evidence.Supporting = true
+
+	coverage, err := l.wasApproved(ctx, cclaSignature, companyID, userModel, author)
+	if err != nil || coverage == CoverageNone {
+		return CoverageNone, err
+	}
+
+	employeeSignature, err := l.signatureRepo.GetEmployeeSignature(ctx, l.claGroupID, companyID, userModel.UserID)
+	if err != nil {
+		return CoverageNone, err
+	}
+	if employeeSignature == nil {
+		l.addEvidence(&Evidence{
+			Source:      EvidenceSourceSignature,
+			Description: fmt.Sprintf("user %s has not acknowledged the CCLA of company %s", userLabel(userModel), companyID),
+		})
+		return CoverageNone, nil
+	}
+
+	acknowledgedOn, ok := signatureDate(employeeSignature)
+	evidence = &Evidence{
+		Date:     formatDate(acknowledgedOn),
+		Source:   EvidenceSourceSignature,
+		RecordID: employeeSignature.SignatureID.String(),
+	}
+	l.addEvidence(evidence)
+	if !ok {
+		evidence.Description = fmt.Sprintf("the CCLA acknowledgement of user %s has no date", userLabel(userModel))
+		return CoverageNone, nil
+	}
+	if acknowledgedOn.After(l.at) {
+		evidence.Description = fmt.Sprintf("user %s acknowledged the CCLA of company %s after the requested time", userLabel(userModel), companyID)
+		return CoverageNone, nil
+	}
+	evidence.Description = fmt.Sprintf("user %s acknowledged the CCLA of company %s before the requested time", userLabel(userModel), companyID)
+	evidence.Supporting = true
+	return coverage, nil
+}
+
+// wasApproved returns ccla if the user matched an entry of the CCLA approval lists at the requested time, a match of
+// an assumed entry or of a github organization, whose memberships can only be checked as of today, is unverified
+func (l *historyLookup) wasApproved(ctx context.Context, cclaSignature *models.Signature, companyID string, userModel *models.User, author Author) (string, error) {
+	entries, err := l.approvalListAt(cclaSignature, companyID)
+	if err != nil {
+		return CoverageNone, err
+	}
+
+	emails := append([]string{userModel.LfEmail}, userModel.Emails...)
+	if author.Email != "" {
+		emails = append(emails, author.Email)
+	}
+	githubUsername := strings.TrimSpace(userModel.GithubUsername)
+
+	var unverified *approvalListEntry
+	for _, entry := range entries {
+		matched, verified := false, !entry.assumed
+		switch entry.listType {
+		case approval_list_expiry.ListTypeEmail:
+			matched = containsIgnoreCase(emails, entry.value)
+		case approval_list_expiry.ListTypeDomain:
+			for _, email := range emails {
+				if matchesDomain(strings.TrimSpace(email), entry.value) {
+					matched = true
+					break
+				}
+			}
+		case approval_list_expiry.ListTypeGithubUsername:
+			matched = githubUsername != "" && strings.EqualFold(githubUsername, entry.value)
+		case approval_list_expiry.ListTypeGithubOrg:
+			if githubUsername == "" {
+				continue
+			}
+			isMember, memberErr := l.isOrganizationMember(ctx, entry.value, githubUsername)
+			if memberErr != nil {
+				log.WithField(utils.XREQUESTID, ctx.Value(utils.XREQUESTID)).WithError(memberErr).
+					Warnf("unable to check the membership of github organization %s - skipping", entry.value)
+				continue
+			}
+			if isMember {
+				matched, verified = true, false
+				entry.evidence.Description += fmt.Sprintf(" - user %s is a member of the organization today, the membership at the requested time can not be verified", githubUsername)
+			}
+		}
+		if !matched {
+			continue
+		}
+		if verified {
+			entry.evidence.Supporting = true
+			l.addEvidence(entry.evidence)
+			return CoverageCCLA, nil
+		}
+		if unverified == nil {
+			unverified = entry
+		}
+	}
+
+	// the unverified match is not supporting evidence, it is only reported when no entry verifies the approval
+	if unverified != nil {
+		l.addEvidence(unverified.evidence)
+		return CoverageCCLAUnverified, nil
+	}
+
+	l.addEvidence(&Evidence{
+		Source:      EvidenceSourceEvent,
+		RecordID:    cclaSignature.SignatureID.String(),
+		Description: fmt.Sprintf("user %s matched none of the %d approval list entries of company %s at the requested time", userLabel(userModel), len(entries), companyID),
+	})
+	return CoverageNone, nil
+}
+
+// approvalListAt replays the approval list changes of the company to return the entries present at the requested
+// time. An entry without a change before the requested time was present if its first change removed it, an entry on
+// the current approval list without any recorded change is assumed to be present.
+func (l *historyLookup) approvalListAt(cclaSignature *models.Signature, companyID string) ([]*approvalListEntry, error) {
+	changes, err := l.approvalListChanges()
+	if err != nil {
+		return nil, err
+	}
+
+	type entryHistory struct {
+		listType approval_list_expiry.ListType
+		value    string
+		current  bool
+		before   *approvalListChange
+		after    *approvalListChange
+	}
+	var keys []string
+	histories := map[string]*entryHistory{}
+	getHistory := func(listType approval_list_expiry.ListType, value string) *entryHistory {
+		key := string(listType) + ":" + strings.ToLower(value)
+		history, ok := histories[key]
+		if !ok {
+			history = &entryHistory{listType: listType, value: value}
+			histories[key] = history
+			keys = append(keys, key)
+		}
+		return history
+	}
+
+	currentLists := map[approval_list_expiry.ListType][]string{
+		approval_list_expiry.ListTypeEmail:          cclaSignature.EmailApprovalList,
+		approval_list_expiry.ListTypeDomain:         cclaSignature.DomainApprovalList,
+		approval_list_expiry.ListTypeGithubUsername: cclaSignature.GithubUsernameApprovalList,
+		approval_list_expiry.ListTypeGithubOrg:      cclaSignature.GithubOrgApprovalList,
+	}
+	for _, listType := range []approval_list_expiry.ListType{approval_list_expiry.ListTypeEmail, approval_list_expiry.ListTypeDomain,
+		approval_list_expiry.ListTypeGithubUsername, approval_list_expiry.ListTypeGithubOrg} {
+		for _, value := range currentLists[listType] {
+			if value = strings.TrimSpace(value); value != "" {
+				getHistory(listType, value).current = true
+			}
+		}
+	}
+
+	for _, change := range changes {
+		if change.event.EventCompanyID != companyID {
+			continue
+		}
+		history := getHistory(change.listType, change.value)
+		if !change.date.After(l.at) {
+			history.before = change
+		} else if history.after == nil {
+			history.after = change
+		}
+	}
+
+	var entries []*approvalListEntry
+	for _, key := range keys {
+		history := histories[key]
+		var evidence *Evidence
+		assumed := false
+		switch {
+		case history.before != nil:
+			if !history.before.added {
+				continue
+			}
+			evidence = changeEvidence(history.before, fmt.Sprintf("%s %s was added to the approval list of company %s before the requested time",
+				history.listType, history.value, companyID))
+		case history.after != nil:
+			if history.after.added {
+				continue
+			}
+			evidence = changeEvidence(history.after, fmt.Sprintf("%s %s was on the approval list of company %s until it was removed after the requested time",
+				history.listType, history.value, companyID))
+		case history.current:
+			evidence = &Evidence{
+				Source:   EvidenceSourceCurrentState,
+				RecordID: cclaSignature.SignatureID.String(),
+				Description: fmt.Sprintf("%s %s is on the current approval list of company %s without any recorded change, it is assumed to be present since the CCLA was signed",
+					history.listType, history.value, companyID),
+			}
+			assumed = true
+		default:
+			continue
+		}
+		entries = append(entries, &approvalListEntry{listType: history.listType, value: history.value, assumed: assumed, evidence: evidence})
+	}
+	return entries, nil
+}
+
+// approvalListChanges returns the approval list changes of the CLA group in chronological order
+func (l *historyLookup) approvalListChanges() ([]*approvalListChange, error) {
+	if l.loaded || l.eventsService == nil {
+		return l.changes, nil
+	}
+
+	eventList, err := l.eventsService.GetClaGroupEvents(l.claGroupID, nil, nil, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range eventList.Events {
+		switch event.EventType {
+		case events.ClaApprovalListUpdated, events.ApprovalListGithubOrganizationAdded, events.ApprovalListGithubOrganizationDeleted:
+		default:
+			continue
+		}
+		change := parseApprovalListChange(event)
+		if change != nil {
+			l.changes = append(l.changes, change)
+		}
+	}
+	sort.SliceStable(l.changes, func(i, j int) bool {
+		return l.changes[i].date.Before(l.changes[j].date)
+	})
+	l.loaded = true
+	return l.changes, nil
+}
+
+// parseApprovalListChange returns the approval list change described by the event, nil if the event does not add or
+// remove a single entry
+func parseApprovalListChange(event *models.Event) *approvalListChange {
+	for _, pattern := range approvalListChangePatterns {
+		match := pattern.FindStringSubmatch(event.EventData)
+		if match == nil {
+			continue
+		}
+		change := &approvalListChange{
+			listType: approval_list_expiry.ListTypeGithubOrg,
+			event:    event,
+			date:     time.Unix(event.EventTimeEpoch, 0).UTC(),
+		}
+		for i, name := range pattern.SubexpNames() {
+			switch name {
+			case "action":
+				change.added = match[i] == "added"
+			case "list":
+				change.listType = approval_list_expiry.ListType(strings.ToLower(strings.ReplaceAll(match[i], " ", "_")))
+			case "value":
+				change.value = match[i]
+			}
+		}
+		if event.EventTimeEpoch == 0 {
+			date, err := utils.ParseDateTime(event.EventTime)
+			if err != nil {
+				return nil
+			}
+			change.date = date
+		}
+		return change
+	}
+	return nil
+}
+
+// changeEvidence returns the evidence of an approval list change event
+func changeEvidence(change *approvalListChange, description string) *Evidence {
+	return &Evidence{
+		Date:        utils.TimeToString(change.date),
+		Source:      EvidenceSourceEvent,
+		RecordID:    change.event.EventID,
+		Description: description,
+	}
+}
+
+// signatureDate returns the date the signature was signed on, the creation date for the older signatures
+func signatureDate(signature *models.Signature) (time.Time, bool) {
+	for _, value := range []string{signature.SignedOn, signature.SignatureCreated} {
+		if value == "" {
+			continue
+		}
+		date, err := utils.ParseDateTime(value)
+		if err == nil {
+			return date.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// formatDate returns the date of the evidence, empty when unknown
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return utils.TimeToString(date)
+}
+
+// userLabel returns the identifier of the user used in the evidence descriptions
+func userLabel(userModel *models.User) string {
+	if userModel.LfUsername != "" {
+		return userModel.LfUsername
+	}
+	if userModel.GithubUsername != "" {
+		return userModel.GithubUsername
+	}
+	return userModel.UserID
+}
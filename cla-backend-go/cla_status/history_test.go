@@ -0,0 +1,193 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_status_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/communitybridge/easycla/cla-backend-go/affiliation_change"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	"github.com/communitybridge/easycla/cla-backend-go/cla_status"
+	"github.com/communitybridge/easycla/cla-backend-go/cla_status/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	githubOrgMembersMock "github.com/communitybridge/easycla/cla-backend-go/github_org_members/mock"
+	signaturesMock "github.com/communitybridge/easycla/cla-backend-go/signatures/mock"
+	usersMock "github.com/communitybridge/easycla/cla-backend-go/users/mock"
+)
+
+func testEvent(eventID, eventType, companyID, date, data string) *models.Event {
+	eventTime, _ := time.Parse(time.RFC3339, date)
+	return &models.Event{
+		EventID:        eventID,
+		EventType:      eventType,
+		EventCompanyID: companyID,
+		EventTimeEpoch: eventTime.Unix(),
+		EventData:      data,
+	}
+}
+
+func signedOn(signatureID, date string) *models.Signature {
+	return &models.Signature{SignatureID: strfmt.UUID4(signatureID), SignedOn: date}
+}
+
+func testDate(date string) time.Time {
+	t, _ := time.Parse(time.RFC3339, date)
+	return t
+}
+
+func newHistoryTestService(ctrl *gomock.Controller) cla_status.Service {
+	usersRepo := usersMock.NewMockUserRepository(ctrl)
+	expectUsers(usersRepo,
+		&models.User{UserID: "icla-user", LfUsername: "icla-user", LfEmail: "icla@example.org"},
+		&models.User{UserID: "dev-user", LfUsername: "dev", LfEmail: "dev@acme.com", CompanyID: "acme"},
+		&models.User{UserID: "gone-user", LfUsername: "gone", LfEmail: "gone@acme.com", CompanyID: "acme"},
+		&models.User{UserID: "late-user", LfUsername: "late", LfEmail: "late@acme.com", CompanyID: "acme"},
+		&models.User{UserID: "org-user", GithubUsername: "org-user", CompanyID: "acme"},
+		&models.User{UserID: "mover-user", LfUsername: "mover", LfEmail: "mover@acme.com", CompanyID: "other"},
+	)
+
+	cclaSignature := signedOn("ccla-acme", "2019-01-01T00:00:00Z")
+	cclaSignature.EmailApprovalList = []string{"dev@acme.com", "late@acme.com", "mover@acme.com"}
+	signatureRepo := signaturesMock.NewMockSignatureRepository(ctrl)
+	expectSignatures(signatureRepo,
+		map[string]*models.Signature{
+			"icla-user": signedOn("icla-1", "2020-01-01T00:00:00Z"),
+		},
+		map[string]*models.Signature{
+			"acme": cclaSignature,
+		},
+		map[string]*models.Signature{
+			"acme/dev-user":   signedOn("ecla-dev", "2019-02-01T00:00:00Z"),
+			"acme/gone-user":  signedOn("ecla-gone", "2019-02-01T00:00:00Z"),
+			"acme/late-user":  signedOn("ecla-late", "2019-02-01T00:00:00Z"),
+			"acme/org-user":   signedOn("ecla-org", "2019-02-01T00:00:00Z"),
+			"acme/mover-user": signedOn("ecla-mover", "2019-02-01T00:00:00Z"),
+		})
+
+	eventsService := mock.NewMockEventsService(ctrl)
+	eventsService.EXPECT().GetClaGroupEvents(testClaGroupID, gomock.Any(), gomock.Any(), true, gomock.Any()).Return(&models.EventList{Events: []*models.Event{
+		testEvent("event-3", events.ClaApprovalListUpdated, "acme", "2020-06-01T00:00:00Z",
+			"CLA Manager [manager / manager@acme.com / manager-id] added Email late@acme.com to the approval list for Company: Acme, Project: Project"),
+		testEvent("event-2", events.ClaApprovalListUpdated, "acme", "2020-03-01T00:00:00Z",
+			"EasyCLA removed email gone@acme.com which expired on 2020-03-01T00:00:00Z from the approval list for Company: Acme, Project: Project"),
+		testEvent("event-1", events.ApprovalListGithubOrganizationAdded, "acme", "2019-06-01T00:00:00Z",
+			"CLA Manager [manager] added GitHub Organization [acme-org] to the whitelist for project [Project] company [Acme]"),
+		testEvent("event-4", events.ApprovalListGithubOrganizationDeleted, "acme", "2020-01-01T00:00:00Z",
+			"CLA Manager [manager] removed GitHub Organization [acme-org] from the whitelist for project [Project] company [Acme]"),
+		testEvent("event-5", events.ClaApprovalListUpdated, "other", "2019-01-01T00:00:00Z",
+			"CLA Manager [manager / manager@other.com / manager-id] removed Email dev@acme.com from the approval list for Company: Other, Project: Project"),
+	}}, nil).AnyTimes()
+
+	affiliationRepo := mock.NewMockAffiliationRepository(ctrl)
+	affiliationRepo.EXPECT().GetUserAffiliations(gomock.Any(), "mover-user").Return([]*affiliation_change.Affiliation{
+		{AffiliationID: "affiliation-1", CompanyID: "acme", CompanyName: "Acme", DateStarted: "2019-01-01T00:00:00Z", DateEnded: "2020-01-01T00:00:00Z"},
+		{AffiliationID: "affiliation-2", CompanyID: "other", CompanyName: "Other", DateStarted: "2020-01-01T00:00:00Z"},
+	}, nil).AnyTimes()
+	affiliationRepo.EXPECT().GetUserAffiliations(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	githubOrgMembers := githubOrgMembersMock.NewMockService(ctrl)
+	expectOrganizationMembers(githubOrgMembers, map[string][]string{"acme-org": {"org-user"}})
+
+	return cla_status.NewService(nil, nil, usersRepo, signatureRepo, githubOrgMembers, eventsService, affiliationRepo, "")
+}
+
+func TestGetHistoricalCoverage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s := newHistoryTestService(ctrl)
+	tests := []struct {
+		name     string
+		author   cla_status.Author
+		at       string
+		coverage string
+	}{
+		{"icla not signed yet", cla_status.Author{Email: "icla@example.org"}, "2019-12-01T00:00:00Z", cla_status.CoverageNone},
+		{"icla signed", cla_status.Author{Email: "icla@example.org"}, "2020-02-01T00:00:00Z", cla_status.CoverageICLA},
+		{"ccla not signed yet", cla_status.Author{Email: "dev@acme.com"}, "2018-12-01T00:00:00Z", cla_status.CoverageNone},
+		{"entry without history", cla_status.Author{Email: "dev@acme.com"}, "2019-06-01T00:00:00Z", cla_status.CoverageCCLAUnverified},
+		{"entry removed later", cla_status.Author{Email: "gone@acme.com"}, "2020-02-01T00:00:00Z", cla_status.CoverageCCLA},
+		{"entry removed", cla_status.Author{Email: "gone@acme.com"}, "2020-04-01T00:00:00Z", cla_status.CoverageNone},
+		{"entry added later", cla_status.Author{Email: "late@acme.com"}, "2020-05-01T00:00:00Z", cla_status.CoverageNone},
+		{"entry added", cla_status.Author{Email: "late@acme.com"}, "2020-07-01T00:00:00Z", cla_status.CoverageCCLA},
+		{"github org added", cla_status.Author{GithubLogin: "org-user"}, "2019-07-01T00:00:00Z", cla_status.CoverageCCLAUnverified},
+		{"github org removed", cla_status.Author{GithubLogin: "org-user"}, "2020-02-01T00:00:00Z", cla_status.CoverageNone},
+		{"previous company", cla_status.Author{Email: "mover@acme.com"}, "2019-06-01T00:00:00Z", cla_status.CoverageCCLAUnverified},
+		{"current company", cla_status.Author{Email: "mover@acme.com"}, "2020-06-01T00:00:00Z", cla_status.CoverageNone},
+		{"unknown author", cla_status.Author{Email: "unknown@example.org"}, "2020-06-01T00:00:00Z", cla_status.CoverageNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.GetHistoricalCoverage(context.Background(), testClaGroupID, tt.author, testDate(tt.at))
+			assert.Nil(t, err)
+			assert.Equal(t, tt.coverage, result.Coverage)
+			assert.NotEmpty(t, result.Evidence)
+		})
+	}
+}
+
+func TestGetHistoricalCoverageEvidence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s := newHistoryTestService(ctrl)
+
+	result, err := s.GetHistoricalCoverage(context.Background(), testClaGroupID, cla_status.Author{Email: "late@acme.com"}, testDate("2020-07-01T00:00:00Z"))
+	assert.Nil(t, err)
+	var recordIDs []string
+	for _, evidence := range result.Evidence {
+		if evidence.Supporting {
+			recordIDs = append(recordIDs, evidence.RecordID)
+		}
+	}
+	assert.Equal(t, []string{"ccla-acme", "event-3", "ecla-late"}, recordIDs)
+
+	result, err = s.GetHistoricalCoverage(context.Background(), testClaGroupID, cla_status.Author{Email: "dev@acme.com"}, testDate("2019-06-01T00:00:00Z"))
+	assert.Nil(t, err)
+	var sources []string
+	for _, evidence := range result.Evidence {
+		sources = append(sources, evidence.Source)
+	}
+	assert.Equal(t, []string{cla_status.EvidenceSourceSignature, cla_status.EvidenceSourceCurrentState, cla_status.EvidenceSourceSignature, cla_status.EvidenceSourceCurrentState, cla_status.EvidenceSourceSignature}, sources)
+	// the entry without any recorded change is assumed to be present, it is not supporting evidence of the coverage
+	assert.False(t, result.Evidence[3].Supporting)
+	assert.False(t, result.ToModel().Covered)
+
+	// the github organization membership can only be checked as of today
+	result, err = s.GetHistoricalCoverage(context.Background(), testClaGroupID, cla_status.Author{GithubLogin: "org-user"}, testDate("2019-07-01T00:00:00Z"))
+	assert.Nil(t, err)
+	assert.Equal(t, cla_status.CoverageCCLAUnverified, result.Coverage)
+	assert.False(t, result.Covered())
+	for _, evidence := range result.Evidence {
+		assert.False(t, evidence.Source == cla_status.EvidenceSourceEvent && evidence.Supporting, "the github organization entry is not supporting evidence")
+	}
+}
+
+func TestGetHistoricalCoverageInvalidInput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s := newHistoryTestService(ctrl)
+
+	_, err := s.GetHistoricalCoverage(context.Background(), testClaGroupID, cla_status.Author{}, testDate("2020-06-01T00:00:00Z"))
+	assert.Equal(t, cla_status.ErrInvalidHistoryInput, err)
+	_, err = s.GetHistoricalCoverage(context.Background(), "", cla_status.Author{Email: "dev@acme.com"}, testDate("2020-06-01T00:00:00Z"))
+	assert.Equal(t, cla_status.ErrInvalidHistoryInput, err)
+	_, err = s.GetHistoricalCoverage(context.Background(), testClaGroupID, cla_status.Author{Email: "dev@acme.com"}, time.Now().Add(time.Hour))
+	assert.Equal(t, cla_status.ErrInvalidHistoryInput, err)
+}
+
+func TestParseApprovalListChangeMergedEntry(t *testing.T) {
+	added, listType, value, ok := cla_status.ParseApprovalListChange(testEvent("event-1", events.ClaApprovalListUpdated, "acme", "2020-06-01T00:00:00Z",
+		"EasyCLA added email dev@acme.com merged from company ACME, Inc. (acme-2) to the approval list for Company: Acme, Project: Project, merge run by user [admin]"))
+	if assert.True(t, ok) {
+		assert.True(t, added)
+		assert.Equal(t, approval_list_expiry.ListTypeEmail, listType)
+		assert.Equal(t, "dev@acme.com", value)
+	}
+}
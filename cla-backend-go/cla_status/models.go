@@ -0,0 +1,125 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_status
+
+import (
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// coverage values - the historical coverage is ccla_unverified when the CCLA coverage depends on an approval list entry
+// assumed to be present or on a github organization membership which can only be checked as of today, the status
+// check coverage when it depends on a github organization membership which was not checked
+const (
+	CoverageICLA           = "icla"
+	CoverageCCLA           = "ccla"
+	CoverageCCLAUnverified = "ccla_unverified"
+	CoverageNone           = "none"
+)
+
+// evidence sources
+const (
+	EvidenceSourceSignature    = "signature"
+	EvidenceSourceEvent        = "event"
+	EvidenceSourceAffiliation  = "affiliation"
+	EvidenceSourceCurrentState = "current_state"
+)
+
+// Author is a commit author identified by email and/or github login
+type Author struct {
+	Email       string
+	GithubLogin string
+}
+
+// AuthorCoverage is the CLA coverage of a commit author - only the identifiers provided by the caller are returned
+type AuthorCoverage struct {
+	Author
+	Coverage string
+	SignURL  string
+}
+
+// Covered returns true if the author is covered by an ICLA or CCLA
+func (a *AuthorCoverage) Covered() bool {
+	return a.Coverage == CoverageICLA || a.Coverage == CoverageCCLA
+}
+
+// CheckResult is the CLA coverage of a list of commit authors
+type CheckResult struct {
+	Authors []*AuthorCoverage
+}
+
+// AllCovered returns true if every author is covered
+func (r *CheckResult) AllCovered() bool {
+	for _, author := range r.Authors {
+		if !author.Covered() {
+			return false
+		}
+	}
+	return true
+}
+
+// ToModel converts to the response model
+func (r *CheckResult) ToModel() *models.ClaStatusCheck {
+	response := &models.ClaStatusCheck{
+		AllCovered: r.AllCovered(),
+		Authors:    []*models.ClaStatusAuthor{},
+	}
+	for _, author := range r.Authors {
+		response.Authors = append(response.Authors, &models.ClaStatusAuthor{
+			Email:       author.Email,
+			GithubLogin: author.GithubLogin,
+			Coverage:    author.Coverage,
+			SignURL:     author.SignURL,
+		})
+	}
+	return response
+}
+
+// Evidence is a record used to reconstruct the coverage of an author, supporting evidence is required for coverage
+type Evidence struct {
+	Date        string
+	Source      string
+	RecordID    string
+	Description string
+	Supporting  bool
+}
+
+// HistoricalCoverage is the CLA coverage of a commit author at a point in time with the evidence trail
+type HistoricalCoverage struct {
+	Author
+	ClaGroupID string
+	At         time.Time
+	Coverage   string
+	Evidence   []*Evidence
+}
+
+// Covered returns true if the author was covered by an ICLA or CCLA
+func (h *HistoricalCoverage) Covered() bool {
+	return h.Coverage == CoverageICLA || h.Coverage == CoverageCCLA
+}
+
+// ToModel converts to the response model
+func (h *HistoricalCoverage) ToModel() *models.ClaStatusHistory {
+	response := &models.ClaStatusHistory{
+		ClaGroupID:  h.ClaGroupID,
+		Email:       h.Email,
+		GithubLogin: h.GithubLogin,
+		Timestamp:   utils.TimeToString(h.At),
+		Coverage:    h.Coverage,
+		Covered:     h.Covered(),
+		Evidence:    []*models.ClaStatusEvidence{},
+	}
+	for _, evidence := range h.Evidence {
+		response.Evidence = append(response.Evidence, &models.ClaStatusEvidence{
+			Date:        evidence.Date,
+			Source:      evidence.Source,
+			RecordID:    evidence.RecordID,
+			Description: evidence.Description,
+			Supporting:  evidence.Supporting,
+		})
+	}
+	return response
+}
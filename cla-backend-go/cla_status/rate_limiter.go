@@ -0,0 +1,65 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// rate limits - anonymous clients are limited per IP address, API key clients per API key. The requests are counted
+// in the rate limits table so the limits apply across all the server instances.
+const (
+	AnonymousRequestsPerMinute = 30
+	APIKeyRequestsPerMinute    = 600
+
+	rateLimitWindow = time.Minute
+)
+
+// RateLimiter limits the number of status checks per client
+type RateLimiter interface {
+	Allow(ctx context.Context, clientKey string, isAPIKey bool) bool
+}
+
+type rateLimiter struct {
+	repo RateLimitRepository
+}
+
+// NewRateLimiter creates a new rate limiter backed by the rate limits repository
+func NewRateLimiter(repo RateLimitRepository) RateLimiter {
+	return &rateLimiter{
+		repo: repo,
+	}
+}
+
+// Allow returns true if the client identified by the IP address or API key ID may make another request in the current
+// one minute window - if the request count cannot be stored the request is allowed rather than failing every check
+func (r *rateLimiter) Allow(ctx context.Context, clientKey string, isAPIKey bool) bool {
+	f := logrus.Fields{
+		"functionName":   "Allow",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"clientKey":      clientKey,
+		"isAPIKey":       isAPIKey,
+	}
+
+	limit := int64(AnonymousRequestsPerMinute)
+	if isAPIKey {
+		clientKey = utils.APIKeyUserName(clientKey)
+		limit = APIKeyRequestsPerMinute
+	}
+
+	window := time.Now().UTC().Truncate(rateLimitWindow)
+	count, err := r.repo.IncrementRequestCount(ctx, fmt.Sprintf("%s#%d", clientKey, window.Unix()), window.Add(rateLimitWindow))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to count the request - allowing the request")
+		return true
+	}
+	return count <= limit
+}
@@ -0,0 +1,71 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_status
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// RateLimitRepository counts the status check requests of each client
+type RateLimitRepository interface {
+	IncrementRequestCount(ctx context.Context, windowKey string, windowEnd time.Time) (int64, error)
+}
+
+type rateLimitRepository struct {
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewRateLimitRepository creates a new instance of the rate limits repository
+func NewRateLimitRepository(awsSession *session.Session, stage string) RateLimitRepository {
+	return &rateLimitRepository{
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-cla-status-rate-limits", stage),
+	}
+}
+
+// IncrementRequestCount atomically increments the request count of the client window and returns the new count - the
+// record expires shortly after the end of the window
+func (repo *rateLimitRepository) IncrementRequestCount(ctx context.Context, windowKey string, windowEnd time.Time) (int64, error) {
+	f := logrus.Fields{
+		"functionName":   "IncrementRequestCount",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"windowKey":      windowKey,
+	}
+
+	result, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"window_key": {S: aws.String(windowKey)},
+		},
+		UpdateExpression: aws.String("ADD request_count :one SET expires = :expires"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":one":     {N: aws.String("1")},
+			":expires": {N: aws.String(strconv.FormatInt(windowEnd.Add(time.Hour).Unix(), 10))},
+		},
+		ReturnValues: aws.String(dynamodb.ReturnValueUpdatedNew),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to increment the request count")
+		return 0, err
+	}
+
+	countAttribute, ok := result.Attributes["request_count"]
+	if !ok || countAttribute.N == nil {
+		return 0, fmt.Errorf("request count missing from the update result of %s", windowKey)
+	}
+	return strconv.ParseInt(*countAttribute.N, 10, 64)
+}
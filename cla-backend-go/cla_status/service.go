@@ -0,0 +1,319 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	openapierrors "github.com/go-openapi/errors"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_org_members"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// MaxAuthors is the maximum number of authors which can be checked in one request
+const MaxAuthors = 50
+
+// MaxOrganizationLookups is the maximum number of github organization memberships checked in one request - the CCLA
+// coverage of the remaining authors depending on a github organization is reported as unverified
+const MaxOrganizationLookups = 10
+
+// errors
+var (
+	ErrInvalidInput    = errors.New("either the project SFID or the repository must be provided, with between 1 and 50 authors each having an email or github login")
+	ErrProjectNotFound = errors.New("project or repository is not associated with a CLA group")
+)
+
+// Service interface defines the CLA status check service methods
+type Service interface {
+	CheckAuthors(ctx context.Context, projectSFID, repositoryName string, authors []Author) (*CheckResult, error)
+	GetHistoricalCoverage(ctx context.Context, claGroupID string, author Author, at time.Time) (*HistoricalCoverage, error)
+	GetClaGroupProjectSFIDs(claGroupID string) ([]string, error)
+}
+
+type service struct {
+	projectsClaGroupsRepo projects_cla_groups.Repository
+	repositoriesRepo      repositories.Repository
+	usersRepo             users.UserRepository
+	signatureRepo         signatures.SignatureRepository
+	eventsService         EventsService
+	affiliationRepo       AffiliationRepository
+	contributorConsoleURL string
+	isOrganizationMember  func(ctx context.Context, organizationName, userName string) (bool, error)
+	now                   func() time.Time
+}
+
+// NewService creates a new CLA status check service, the github organization memberships are checked with the
+// members cache when the github organization members service is set. The events service and the affiliations
+// repository are used by the historical coverage lookup.
+func NewService(projectsClaGroupsRepo projects_cla_groups.Repository, repositoriesRepo repositories.Repository, usersRepo users.UserRepository, signatureRepo signatures.SignatureRepository, githubOrgMembersService github_org_members.Service, eventsService EventsService, affiliationRepo AffiliationRepository, contributorConsoleURL string) Service {
+	s := &service{
+		projectsClaGroupsRepo: projectsClaGroupsRepo,
+		repositoriesRepo:      repositoriesRepo,
+		usersRepo:             usersRepo,
+		signatureRepo:         signatureRepo,
+		eventsService:         eventsService,
+		affiliationRepo:       affiliationRepo,
+		contributorConsoleURL: contributorConsoleURL,
+		isOrganizationMember:  github.IsOrganizationMember,
+		now:                   time.Now,
+	}
+	if githubOrgMembersService != nil {
+		s.isOrganizationMember = githubOrgMembersService.IsMember
+	}
+	return s
+}
+
+// CheckAuthors returns the CLA coverage of each author for the CLA group of the project or repository
+func (s *service) CheckAuthors(ctx context.Context, projectSFID, repositoryName string, authors []Author) (*CheckResult, error) {
+	f := logrus.Fields{
+		"functionName":   "CheckAuthors",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+		"repositoryName": repositoryName,
+		"authorCount":    len(authors),
+	}
+
+	if (projectSFID == "") == (repositoryName == "") || len(authors) == 0 || len(authors) > MaxAuthors {
+		return nil, ErrInvalidInput
+	}
+	for _, author := range authors {
+		if author.Email == "" && author.GithubLogin == "" {
+			return nil, ErrInvalidInput
+		}
+	}
+
+	claGroupID, err := s.getClaGroupID(ctx, projectSFID, repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	f["claGroupID"] = claGroupID
+
+	result := &CheckResult{}
+	orgLookups := MaxOrganizationLookups
+	for _, author := range authors {
+		coverage, checkErr := s.getAuthorCoverage(ctx, claGroupID, author, &orgLookups)
+		if checkErr != nil {
+			log.WithFields(f).WithError(checkErr).Warn("unable to determine the CLA coverage of the author")
+			return nil, checkErr
+		}
+
+		authorCoverage := &AuthorCoverage{
+			Author:   author,
+			Coverage: coverage,
+		}
+		if authorCoverage.Coverage == CoverageNone {
+			authorCoverage.SignURL = s.signURL(claGroupID)
+		}
+		result.Authors = append(result.Authors, authorCoverage)
+	}
+
+	return result, nil
+}
+
+// getClaGroupID returns the CLA group ID of the project or the enabled repository
+func (s *service) getClaGroupID(ctx context.Context, projectSFID, repositoryName string) (string, error) {
+	if projectSFID != "" {
+		pcg, err := s.projectsClaGroupsRepo.GetClaGroupIDForProject(projectSFID)
+		if err != nil {
+			if err == projects_cla_groups.ErrProjectNotAssociatedWithClaGroup {
+				return "", ErrProjectNotFound
+			}
+			return "", err
+		}
+		return pcg.ClaGroupID, nil
+	}
+
+	repository, err := s.repositoriesRepo.GetRepositoryByName(ctx, repositoryName)
+	if err != nil {
+		if err == repositories.ErrGithubRepositoryNotFound {
+			return "", ErrProjectNotFound
+		}
+		return "", err
+	}
+	if !repository.Enabled || repository.RepositoryProjectID == "" {
+		return "", ErrProjectNotFound
+	}
+	return repository.RepositoryProjectID, nil
+}
+
+// getAuthorCoverage returns the CLA coverage of the author - users are matched by github login and by their LF email.
+// Each github organization membership check uses one of the remaining organization lookups of the request.
+func (s *service) getAuthorCoverage(ctx context.Context, claGroupID string, author Author, orgLookups *int) (string, error) {
+	userModels, err := s.getUsers(author)
+	if err != nil {
+		return "", err
+	}
+
+	coverage := CoverageNone
+	for _, userModel := range userModels {
+		iclaSignature, sigErr := s.signatureRepo.GetIndividualSignature(ctx, claGroupID, userModel.UserID)
+		if sigErr != nil {
+			return "", sigErr
+		}
+		if iclaSignature != nil {
+			return CoverageICLA, nil
+		}
+
+		cclaCoverage, cclaErr := s.getCCLACoverage(ctx, claGroupID, userModel, orgLookups)
+		if cclaErr != nil {
+			return "", cclaErr
+		}
+		if cclaCoverage == CoverageCCLA || coverage == CoverageNone {
+			coverage = cclaCoverage
+		}
+	}
+
+	return coverage, nil
+}
+
+// getUsers returns the distinct users matching the github login or email of the author
+func (s *service) getUsers(author Author) ([]*models.User, error) {
+	var userModels []*models.User
+	if author.GithubLogin != "" {
+		userModel, err := s.usersRepo.GetUserByGitHubUsername(author.GithubLogin)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		if userModel != nil {
+			userModels = append(userModels, userModel)
+		}
+	}
+
+	if author.Email != "" {
+		userModel, err := s.usersRepo.GetUserByEmail(author.Email)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		if userModel != nil && (len(userModels) == 0 || userModels[0].UserID != userModel.UserID) {
+			userModels = append(userModels, userModel)
+		}
+	}
+
+	return userModels, nil
+}
+
+// getCCLACoverage returns ccla if the company of the user signed the CCLA, the user acknowledged the CCLA and the
+// user is on the approval list - the coverage is ccla_unverified when the user could only be approved by a github
+// organization membership which was not checked because the request ran out of organization lookups
+func (s *service) getCCLACoverage(ctx context.Context, claGroupID string, userModel *models.User, orgLookups *int) (string, error) {
+	if userModel.CompanyID == "" {
+		return CoverageNone, nil
+	}
+
+	cclaSignature, err := s.signatureRepo.GetCorporateSignature(ctx, claGroupID, userModel.CompanyID)
+	if err != nil || cclaSignature == nil {
+		return CoverageNone, err
+	}
+
+	employeeSignature, err := s.signatureRepo.GetEmployeeSignature(ctx, claGroupID, userModel.CompanyID, userModel.UserID)
+	if err != nil || employeeSignature == nil {
+		return CoverageNone, err
+	}
+
+	return s.getApprovalCoverage(ctx, cclaSignature, userModel, orgLookups), nil
+}
+
+// getApprovalCoverage checks the approval lists of the CCLA signature - only the emails and the github username of
+// the user record are used, the identifiers sent by the caller are not verified
+func (s *service) getApprovalCoverage(ctx context.Context, cclaSignature *models.Signature, userModel *models.User, orgLookups *int) string {
+	emails := append([]string{userModel.LfEmail}, userModel.Emails...)
+	for _, email := range emails {
+		email = strings.TrimSpace(email)
+		if email == "" {
+			continue
+		}
+		if containsIgnoreCase(cclaSignature.EmailApprovalList, email) {
+			return CoverageCCLA
+		}
+		for _, pattern := range cclaSignature.DomainApprovalList {
+			if matchesDomain(email, pattern) {
+				return CoverageCCLA
+			}
+		}
+	}
+
+	githubUsername := strings.TrimSpace(userModel.GithubUsername)
+	if githubUsername == "" {
+		return CoverageNone
+	}
+	if containsIgnoreCase(cclaSignature.GithubUsernameApprovalList, githubUsername) {
+		return CoverageCCLA
+	}
+	for _, githubOrg := range cclaSignature.GithubOrgApprovalList {
+		if *orgLookups <= 0 {
+			log.WithField(utils.XREQUESTID, ctx.Value(utils.XREQUESTID)).
+				Debugf("no github organization lookups left - not checking github organization %s", githubOrg)
+			return CoverageCCLAUnverified
+		}
+		*orgLookups--
+		isMember, err := s.isOrganizationMember(ctx, githubOrg, githubUsername)
+		if err != nil {
+			log.WithField(utils.XREQUESTID, ctx.Value(utils.XREQUESTID)).WithError(err).
+				Warnf("unable to check the membership of github organization %s - skipping", githubOrg)
+			continue
+		}
+		if isMember {
+			return CoverageCCLA
+		}
+	}
+
+	return CoverageNone
+}
+
+// signURL returns the contributor console URL where the author can sign the CLA
+func (s *service) signURL(claGroupID string) string {
+	if s.contributorConsoleURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://%s/#/cla/project/%s", s.contributorConsoleURL, claGroupID)
+}
+
+// matchesDomain returns true if the email domain matches the approval list pattern - a naked domain must match
+// exactly while a '*', '*.' or '.' prefix also allows sub-domains
+func matchesDomain(email, pattern string) bool {
+	atIndex := strings.LastIndex(email, "@")
+	if atIndex < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[atIndex+1:])
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+	trimmed := strings.TrimLeft(pattern, "*.")
+	if trimmed == "" {
+		return false
+	}
+	if trimmed == pattern {
+		return domain == pattern
+	}
+	return domain == trimmed || strings.HasSuffix(domain, "."+trimmed)
+}
+
+func containsIgnoreCase(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNotFound(err error) bool {
+	apiErr, ok := err.(openapierrors.Error)
+	return ok && apiErr.Code() == http.StatusNotFound
+}
@@ -0,0 +1,284 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_status_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	openapierrors "github.com/go-openapi/errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/communitybridge/easycla/cla-backend-go/cla_status"
+	"github.com/communitybridge/easycla/cla-backend-go/cla_status/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	githubOrgMembersMock "github.com/communitybridge/easycla/cla-backend-go/github_org_members/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	repositoriesMock "github.com/communitybridge/easycla/cla-backend-go/repositories/mock"
+	signaturesMock "github.com/communitybridge/easycla/cla-backend-go/signatures/mock"
+	usersMock "github.com/communitybridge/easycla/cla-backend-go/users/mock"
+)
+
+const testClaGroupID = "cla-group-1"
+
+// expectUsers makes the users repository mock return the users by LF email and github username
+func expectUsers(usersRepo *usersMock.MockUserRepository, users ...*models.User) {
+	usersRepo.EXPECT().GetUserByEmail(gomock.Any()).DoAndReturn(func(userEmail string) (*models.User, error) {
+		for _, user := range users {
+			if user.LfEmail == userEmail {
+				return user, nil
+			}
+		}
+		return nil, openapierrors.NotFound("user not found when searching by lf_email: %s", userEmail)
+	}).AnyTimes()
+	usersRepo.EXPECT().GetUserByGitHubUsername(gomock.Any()).DoAndReturn(func(gitHubUsername string) (*models.User, error) {
+		for _, user := range users {
+			if user.GithubUsername == gitHubUsername {
+				return user, nil
+			}
+		}
+		return nil, openapierrors.NotFound("user not found when searching by user_github_username: %s", gitHubUsername)
+	}).AnyTimes()
+}
+
+// expectSignatures makes the signature repository mock return the ICLA signatures by user ID, the CCLA signatures by
+// company ID and the employee acknowledgements by company ID and user ID
+func expectSignatures(signatureRepo *signaturesMock.MockSignatureRepository, iclaSignatures, cclaSignatures, employeeSignatures map[string]*models.Signature) {
+	signatureRepo.EXPECT().GetIndividualSignature(gomock.Any(), testClaGroupID, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, claGroupID, userID string) (*models.Signature, error) {
+			return iclaSignatures[userID], nil
+		}).AnyTimes()
+	signatureRepo.EXPECT().GetCorporateSignature(gomock.Any(), testClaGroupID, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, claGroupID, companyID string) (*models.Signature, error) {
+			return cclaSignatures[companyID], nil
+		}).AnyTimes()
+	signatureRepo.EXPECT().GetEmployeeSignature(gomock.Any(), testClaGroupID, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, claGroupID, companyID, userID string) (*models.Signature, error) {
+			return employeeSignatures[companyID+"/"+userID], nil
+		}).AnyTimes()
+}
+
+// expectOrganizationMembers makes the github organization members mock report the members of each organization
+func expectOrganizationMembers(githubOrgMembers *githubOrgMembersMock.MockService, members map[string][]string) {
+	githubOrgMembers.EXPECT().IsMember(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, organizationName, githubUsername string) (bool, error) {
+			for _, member := range members[organizationName] {
+				if member == githubUsername {
+					return true, nil
+				}
+			}
+			return false, nil
+		}).AnyTimes()
+}
+
+func newCheckTestService(ctrl *gomock.Controller) cla_status.Service {
+	projectsClaGroupsRepo := projects_cla_groups.NewMockRepository(ctrl)
+	projectsClaGroupsRepo.EXPECT().GetClaGroupIDForProject(gomock.Any()).DoAndReturn(func(projectSFID string) (*projects_cla_groups.ProjectClaGroup, error) {
+		if projectSFID != "project-1" {
+			return nil, projects_cla_groups.ErrProjectNotAssociatedWithClaGroup
+		}
+		return &projects_cla_groups.ProjectClaGroup{ProjectSFID: projectSFID, ClaGroupID: testClaGroupID}, nil
+	}).AnyTimes()
+
+	repositoriesRepo := repositoriesMock.NewMockRepository(ctrl)
+	repositoriesRepo.EXPECT().GetRepositoryByName(gomock.Any(), "org/enabled").
+		Return(&models.GithubRepository{RepositoryName: "org/enabled", RepositoryProjectID: testClaGroupID, Enabled: true}, nil).AnyTimes()
+	repositoriesRepo.EXPECT().GetRepositoryByName(gomock.Any(), "org/disabled").
+		Return(&models.GithubRepository{RepositoryName: "org/disabled", RepositoryProjectID: testClaGroupID}, nil).AnyTimes()
+	repositoriesRepo.EXPECT().GetRepositoryByName(gomock.Any(), "org/unknown").
+		Return(nil, repositories.ErrGithubRepositoryNotFound).AnyTimes()
+
+	usersRepo := usersMock.NewMockUserRepository(ctrl)
+	expectUsers(usersRepo,
+		&models.User{UserID: "icla-user", LfEmail: "icla@example.org", GithubUsername: "icla-user"},
+		&models.User{UserID: "email-user", LfEmail: "dev@acme.com", CompanyID: "acme"},
+		&models.User{UserID: "domain-user", LfEmail: "dev@eng.acme.com", CompanyID: "acme"},
+		&models.User{UserID: "org-user", GithubUsername: "org-user", CompanyID: "acme"},
+		&models.User{UserID: "no-ack-user", LfEmail: "noack@acme.com", CompanyID: "acme"},
+		&models.User{UserID: "not-approved-user", LfEmail: "other@other.com", GithubUsername: "not-approved-user", CompanyID: "acme"},
+	)
+
+	signatureRepo := signaturesMock.NewMockSignatureRepository(ctrl)
+	acknowledged := &models.Signature{}
+	expectSignatures(signatureRepo,
+		map[string]*models.Signature{"icla-user": {}},
+		map[string]*models.Signature{
+			"acme": {
+				EmailApprovalList:     []string{"DEV@acme.com", "noack@acme.com"},
+				DomainApprovalList:    []string{"*.acme.com"},
+				GithubOrgApprovalList: []string{"acme-org"},
+			},
+		},
+		map[string]*models.Signature{
+			"acme/email-user":        acknowledged,
+			"acme/domain-user":       acknowledged,
+			"acme/org-user":          acknowledged,
+			"acme/not-approved-user": acknowledged,
+		})
+
+	githubOrgMembers := githubOrgMembersMock.NewMockService(ctrl)
+	expectOrganizationMembers(githubOrgMembers, map[string][]string{"acme-org": {"org-user"}})
+
+	return cla_status.NewService(projectsClaGroupsRepo, repositoriesRepo, usersRepo, signatureRepo, githubOrgMembers, nil, nil, "contributor.example.org")
+}
+
+func TestCheckAuthors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s := newCheckTestService(ctrl)
+
+	result, err := s.CheckAuthors(context.Background(), "project-1", "", []cla_status.Author{
+		{GithubLogin: "icla-user"},
+		{Email: "dev@acme.com"},
+		{Email: "dev@eng.acme.com"},
+		{GithubLogin: "org-user"},
+		{Email: "noack@acme.com"},
+		{Email: "other@other.com"},
+		{Email: "unknown@example.org", GithubLogin: "unknown"},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var coverage []string
+	for _, author := range result.Authors {
+		coverage = append(coverage, author.Coverage)
+	}
+	assert.Equal(t, []string{cla_status.CoverageICLA, cla_status.CoverageCCLA, cla_status.CoverageCCLA, cla_status.CoverageCCLA,
+		cla_status.CoverageNone, cla_status.CoverageNone, cla_status.CoverageNone}, coverage)
+	assert.False(t, result.AllCovered())
+	assert.Equal(t, "", result.Authors[0].SignURL)
+	assert.Equal(t, "https://contributor.example.org/#/cla/project/cla-group-1", result.Authors[6].SignURL)
+	assert.Equal(t, cla_status.Author{Email: "unknown@example.org", GithubLogin: "unknown"}, result.Authors[6].Author)
+}
+
+func TestCheckAuthorsDoesNotTrustCallerEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s := newCheckTestService(ctrl)
+
+	// The user is resolved by github login - the approved email sent along is not one of the user's emails
+	result, err := s.CheckAuthors(context.Background(), "project-1", "", []cla_status.Author{
+		{Email: "someone@acme.com", GithubLogin: "not-approved-user"},
+	})
+	if assert.Nil(t, err) {
+		assert.Equal(t, cla_status.CoverageNone, result.Authors[0].Coverage)
+	}
+}
+
+func TestCheckAuthorsLimitsOrganizationLookups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var users []*models.User
+	var authors []cla_status.Author
+	employeeSignatures := map[string]*models.Signature{}
+	for i := 0; i <= cla_status.MaxOrganizationLookups; i++ {
+		login := fmt.Sprintf("member-%d", i)
+		users = append(users, &models.User{UserID: login, GithubUsername: login, CompanyID: "acme"})
+		authors = append(authors, cla_status.Author{GithubLogin: login})
+		employeeSignatures["acme/"+login] = &models.Signature{}
+	}
+
+	projectsClaGroupsRepo := projects_cla_groups.NewMockRepository(ctrl)
+	projectsClaGroupsRepo.EXPECT().GetClaGroupIDForProject("project-1").Return(&projects_cla_groups.ProjectClaGroup{ClaGroupID: testClaGroupID}, nil)
+	usersRepo := usersMock.NewMockUserRepository(ctrl)
+	expectUsers(usersRepo, users...)
+	signatureRepo := signaturesMock.NewMockSignatureRepository(ctrl)
+	expectSignatures(signatureRepo, nil, map[string]*models.Signature{"acme": {GithubOrgApprovalList: []string{"acme-org"}}}, employeeSignatures)
+	githubOrgMembers := githubOrgMembersMock.NewMockService(ctrl)
+	githubOrgMembers.EXPECT().IsMember(gomock.Any(), "acme-org", gomock.Any()).Return(true, nil).Times(cla_status.MaxOrganizationLookups)
+
+	s := cla_status.NewService(projectsClaGroupsRepo, nil, usersRepo, signatureRepo, githubOrgMembers, nil, nil, "contributor.example.org")
+	result, err := s.CheckAuthors(context.Background(), "project-1", "", authors)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, cla_status.CoverageCCLA, result.Authors[0].Coverage)
+	last := result.Authors[cla_status.MaxOrganizationLookups]
+	assert.Equal(t, cla_status.CoverageCCLAUnverified, last.Coverage)
+	assert.Equal(t, "", last.SignURL)
+	assert.False(t, result.AllCovered())
+}
+
+func TestCheckAuthorsByRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s := newCheckTestService(ctrl)
+
+	result, err := s.CheckAuthors(context.Background(), "", "org/enabled", []cla_status.Author{{GithubLogin: "icla-user"}})
+	if assert.Nil(t, err) {
+		assert.True(t, result.AllCovered())
+	}
+
+	_, err = s.CheckAuthors(context.Background(), "", "org/disabled", []cla_status.Author{{GithubLogin: "icla-user"}})
+	assert.Equal(t, cla_status.ErrProjectNotFound, err)
+	_, err = s.CheckAuthors(context.Background(), "", "org/unknown", []cla_status.Author{{GithubLogin: "icla-user"}})
+	assert.Equal(t, cla_status.ErrProjectNotFound, err)
+	_, err = s.CheckAuthors(context.Background(), "project-2", "", []cla_status.Author{{GithubLogin: "icla-user"}})
+	assert.Equal(t, cla_status.ErrProjectNotFound, err)
+}
+
+func TestCheckAuthorsInvalidInput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s := newCheckTestService(ctrl)
+	ctx := context.Background()
+	author := []cla_status.Author{{Email: "dev@acme.com"}}
+
+	_, err := s.CheckAuthors(ctx, "", "", author)
+	assert.Equal(t, cla_status.ErrInvalidInput, err)
+	_, err = s.CheckAuthors(ctx, "project-1", "org/enabled", author)
+	assert.Equal(t, cla_status.ErrInvalidInput, err)
+	_, err = s.CheckAuthors(ctx, "project-1", "", nil)
+	assert.Equal(t, cla_status.ErrInvalidInput, err)
+	_, err = s.CheckAuthors(ctx, "project-1", "", []cla_status.Author{{}})
+	assert.Equal(t, cla_status.ErrInvalidInput, err)
+	_, err = s.CheckAuthors(ctx, "project-1", "", make([]cla_status.Author, cla_status.MaxAuthors+1))
+	assert.Equal(t, cla_status.ErrInvalidInput, err)
+}
+
+func TestMatchesDomain(t *testing.T) {
+	assert.True(t, cla_status.MatchesDomain("dev@acme.com", "acme.com"))
+	assert.False(t, cla_status.MatchesDomain("dev@eng.acme.com", "acme.com"))
+	assert.True(t, cla_status.MatchesDomain("dev@eng.acme.com", "*.acme.com"))
+	assert.True(t, cla_status.MatchesDomain("dev@acme.com", "*.acme.com"))
+	assert.True(t, cla_status.MatchesDomain("dev@eng.acme.com", ".acme.com"))
+	assert.False(t, cla_status.MatchesDomain("dev@notacme.com", "*acme.com"))
+	assert.False(t, cla_status.MatchesDomain("dev@acme.com", "*"))
+	assert.False(t, cla_status.MatchesDomain("not-an-email", "acme.com"))
+}
+
+func TestRateLimiter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	counts := map[string]int64{}
+	repo := mock.NewMockRateLimitRepository(ctrl)
+	repo.EXPECT().IncrementRequestCount(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, windowKey string, windowEnd interface{}) (int64, error) {
+			counts[windowKey]++
+			return counts[windowKey], nil
+		}).AnyTimes()
+	limiter := cla_status.NewRateLimiter(repo)
+
+	for i := 0; i < cla_status.AnonymousRequestsPerMinute; i++ {
+		assert.True(t, limiter.Allow(ctx, "10.0.0.1", false))
+	}
+	// The limit may be reached at a window boundary, the next window starts over
+	allowed := limiter.Allow(ctx, "10.0.0.1", false)
+	assert.Equal(t, len(counts) > 1, allowed)
+	assert.True(t, limiter.Allow(ctx, "10.0.0.2", false))
+	assert.True(t, limiter.Allow(ctx, "10.0.0.1", true))
+
+	// The requests are allowed when they cannot be counted
+	failing := mock.NewMockRateLimitRepository(ctrl)
+	failing.EXPECT().IncrementRequestCount(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), errors.New("throttled"))
+	assert.True(t, cla_status.NewRateLimiter(failing).Allow(ctx, "10.0.0.1", false))
+}
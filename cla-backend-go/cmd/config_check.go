@@ -0,0 +1,61 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	ini "github.com/communitybridge/easycla/cla-backend-go/init"
+	"github.com/spf13/cobra"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configuration commands",
+	Long:  "Commands to inspect the service configuration",
+	// Override the root pre-run so an invalid configuration can be reported rather than aborting the command
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+}
+
+// configCheckCmd represents the config check command
+var configCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Validate and print the resolved configuration",
+	Long:  "Loads the configuration from the local config file or SSM, applies the defaults and environment variable overrides, and prints the resolved values with the secrets redacted.",
+	Run:   runConfigCheck,
+}
+
+func init() {
+	configCmd.AddCommand(configCheckCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigCheck(cmd *cobra.Command, args []string) {
+	ini.SetConfigFile(configFile)
+	resolved, err := ini.ResolveConfigVariable()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tENV OVERRIDE\tREQUIRED\tRELOADABLE\tVALUE")
+	for _, field := range config.Fields {
+		fmt.Fprintf(w, "%s\t%s\t%t\t%t\t%s\n", field.Name, field.EnvKey(), field.Required, field.Reloadable, field.DisplayValue(&resolved))
+	}
+	if flushErr := w.Flush(); flushErr != nil {
+		fmt.Fprintf(os.Stderr, "unable to write configuration: %v\n", flushErr)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nconfiguration is NOT valid: %v\n", err)
+		os.Exit(1)
+	}
+	if metricsErr := resolved.MetricsReport.Validate(); metricsErr != nil {
+		fmt.Fprintf(os.Stderr, "\nmetrics report configuration is NOT valid: %v\n", metricsErr)
+		os.Exit(1)
+	}
+
+	fmt.Println("\nconfiguration is valid")
+}
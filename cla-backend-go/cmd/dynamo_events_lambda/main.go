@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"os"
 
+	"github.com/communitybridge/easycla/cla-backend-go/github_jobs"
 	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 
+	"github.com/communitybridge/easycla/cla-backend-go/v2/branch_protection"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/dynamo_events"
 
 	"github.com/communitybridge/easycla/cla-backend-go/token"
@@ -39,6 +41,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/users"
 
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/webhooks"
 
 	"github.com/aws/aws-lambda-go/lambda"
 
@@ -89,14 +92,18 @@ func init() {
 	claManagerRequestsRepo := cla_manager.NewRepository(awsSession, stage)
 	approvalListRequestsRepo := approval_list.NewRepository(awsSession, stage)
 	githubOrganizationsRepo := github_organizations.NewRepository(awsSession, stage)
+	webhooksRepo := webhooks.NewRepository(awsSession, stage)
 
 	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
 	github.Init(configFile.Github.AppID, configFile.Github.AppPrivateKey, configFile.Github.AccessToken)
+	github.InitEnterpriseFromSSM(awsSession, stage)
 
 	user_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
 	project_service.InitClient(configFile.APIGatewayURL)
 	githubOrganizationsService := github_organizations.NewService(githubOrganizationsRepo, repositoriesRepo, projectClaGroupRepo)
 	repositoriesService := repositories.NewService(repositoriesRepo, githubOrganizationsRepo, projectClaGroupRepo)
+	webhooksService := webhooks.NewService(webhooksRepo, projectClaGroupRepo)
+	branchProtectionService := branch_protection.NewService(branch_protection.NewRepository(awsSession, stage), repositoriesRepo, githubOrganizationsRepo, projectClaGroupRepo)
 
 	// Services
 	projectService := project.NewService(projectRepo, repositoriesRepo, gerritRepo, projectClaGroupRepo, usersRepo)
@@ -128,11 +135,14 @@ func init() {
 		githubOrganizationsService,
 		repositoriesService,
 		claManagerRequestsRepo,
-		approvalListRequestsRepo)
+		approvalListRequestsRepo,
+		webhooksService,
+		branchProtectionService,
+		github_jobs.NewService(github_jobs.NewRepository(awsSession, stage), githubOrganizationsRepo))
 }
 
-func handler(ctx context.Context, event events.DynamoDBEvent) {
-	dynamoEventsService.ProcessEvents(event)
+func handler(ctx context.Context, event events.DynamoDBEvent) error {
+	return dynamoEventsService.ProcessEvents(event)
 }
 
 func printBuildInfo() {
@@ -153,7 +163,9 @@ func main() {
 				log.Fatal(err)
 			}
 		}
-		handler(utils.NewContext(), dynamodbEvent)
+		if err := handler(utils.NewContext(), dynamodbEvent); err != nil {
+			log.Warnf("unable to process the events, error: %+v", err)
+		}
 	} else {
 		lambda.Start(handler)
 	}
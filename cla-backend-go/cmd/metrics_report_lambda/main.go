@@ -60,6 +60,10 @@ func init() {
 	if err != nil {
 		log.Panicf("Unable to load config - Error: %v", err)
 	}
+	// Fail fast on a missing SQS transport rather than when the report is sent
+	if err = configFile.MetricsReport.Validate(); err != nil {
+		log.Panicf("Invalid metrics report config - Error: %v", err)
+	}
 	pcgRepo := projects_cla_groups.NewRepository(awsSession, stage)
 	metricsRepo = metrics.NewRepository(awsSession, stage, configFile.APIGatewayURL, pcgRepo)
 	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
@@ -119,16 +123,12 @@ func handler(ctx context.Context, event events.CloudWatchEvent) {
 	log.WithFields(f).Debugf("going to send total count metrics to sqs queue %s", spew.Sdump(req))
 	conf := config.GetConfig()
 
-	var region, queueURL string
-	if conf.MetricsReport.AwsSQSRegion == "" {
-		log.WithFields(f).Fatal("aws sqs region config is missing")
-	}
-	region = conf.MetricsReport.AwsSQSRegion
-
-	if conf.MetricsReport.AwsSQSQueueURL == "" {
-		log.WithFields(f).Fatal("aws sqs queue url config is missing")
+	if !conf.MetricsReport.Enabled {
+		log.WithFields(f).Info("metrics report not sent - disabled in the configuration")
+		return
 	}
-	queueURL = conf.MetricsReport.AwsSQSQueueURL
+	region := conf.MetricsReport.AwsSQSRegion
+	queueURL := conf.MetricsReport.AwsSQSQueueURL
 
 	sqsSession := awsqs.New(session.Must(session.NewSession(&aws.Config{
 		Region: aws.String(region),
@@ -139,16 +139,12 @@ func handler(ctx context.Context, event events.CloudWatchEvent) {
 		QueueUrl:    aws.String(queueURL),
 	}
 
-	if conf.MetricsReport.Enabled {
-		_, err = sqsSession.SendMessage(input)
-		if err != nil {
-			log.WithFields(f).WithError(err).Fatal("sending the message to sqs failed")
-			return
-		}
-		log.WithFields(f).Infof("metrics report sent successfully to queue %s", queueURL)
-	} else {
-		log.WithFields(f).Info("metrics report not sent - disabled in the configuration")
+	_, err = sqsSession.SendMessage(input)
+	if err != nil {
+		log.WithFields(f).WithError(err).Fatal("sending the message to sqs failed")
+		return
 	}
+	log.WithFields(f).Infof("metrics report sent successfully to queue %s", queueURL)
 }
 
 func printBuildInfo() {
@@ -10,6 +10,7 @@ import (
 
 	ini "github.com/communitybridge/easycla/cla-backend-go/init"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/token"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -31,6 +32,9 @@ func init() {
 		"DB_MAX_CONNECTIONS": 1,
 		"STAGE":              "dev",
 
+		// how often the standalone server reloads the reloadable configuration values, 0 disables reloading
+		"CONFIG_RELOAD_INTERVAL": "60s",
+
 		// should we validate the user's GitHub organizations?
 		"GH_ORG_VALIDATION": "true",
 		// should we validate company API queries against the current authenticated user?
@@ -58,10 +62,19 @@ func init() {
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:   "cla-backend-go",
-	Short: "CLA Backend v3",
-	Long:  "CLA Backend supporting the /v3 endpoints",
-	Run:   runServer,
+	Use:              "cla-backend-go",
+	Short:            "CLA Backend v3",
+	Long:             "CLA Backend supporting the /v3 endpoints",
+	PersistentPreRun: loadConfig,
+	Run:              runServer,
+}
+
+// loadConfig loads and validates the configuration before any of the server commands run
+func loadConfig(cmd *cobra.Command, args []string) {
+	ini.SetConfigFile(configFile)
+	ini.ConfigVariable()
+	cfg := ini.GetConfig()
+	token.Init(cfg.Auth0Platform.ClientID, cfg.Auth0Platform.ClientSecret, cfg.Auth0Platform.URL, cfg.Auth0Platform.Audience)
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
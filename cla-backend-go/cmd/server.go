@@ -4,8 +4,10 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -17,6 +19,41 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/v2/dynamo_events"
 	v2GithubActivity "github.com/communitybridge/easycla/cla-backend-go/v2/github_activity"
 
+	"github.com/communitybridge/easycla/cla-backend-go/affiliation_change"
+	"github.com/communitybridge/easycla/cla-backend-go/api_keys"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_import"
+	"github.com/communitybridge/easycla/cla-backend-go/cla_manager_succession"
+	"github.com/communitybridge/easycla/cla-backend-go/cla_status"
+	"github.com/communitybridge/easycla/cla-backend-go/company_merge"
+	"github.com/communitybridge/easycla/cla-backend-go/data_subject"
+	"github.com/communitybridge/easycla/cla-backend-go/domain_verification"
+	"github.com/communitybridge/easycla/cla-backend-go/feature_flags"
+	"github.com/communitybridge/easycla/cla-backend-go/github_org_members"
+	"github.com/communitybridge/easycla/cla-backend-go/scim"
+	"github.com/communitybridge/easycla/cla-backend-go/user_merge"
+	v2AffiliationChange "github.com/communitybridge/easycla/cla-backend-go/v2/affiliation_change"
+	v2APIKeys "github.com/communitybridge/easycla/cla-backend-go/v2/api_keys"
+	v2ApprovalListExpiry "github.com/communitybridge/easycla/cla-backend-go/v2/approval_list_expiry"
+	v2ApprovalListImport "github.com/communitybridge/easycla/cla-backend-go/v2/approval_list_import"
+	v2ClaManagerApproval "github.com/communitybridge/easycla/cla-backend-go/v2/cla_manager_approval"
+	v2ClaManagerSuccession "github.com/communitybridge/easycla/cla-backend-go/v2/cla_manager_succession"
+	v2ClaStatus "github.com/communitybridge/easycla/cla-backend-go/v2/cla_status"
+	v2CompanyMerge "github.com/communitybridge/easycla/cla-backend-go/v2/company_merge"
+	v2DataSubject "github.com/communitybridge/easycla/cla-backend-go/v2/data_subject"
+	v2DomainVerification "github.com/communitybridge/easycla/cla-backend-go/v2/domain_verification"
+	v2FeatureFlags "github.com/communitybridge/easycla/cla-backend-go/v2/feature_flags"
+	v2GithubOrgMembers "github.com/communitybridge/easycla/cla-backend-go/v2/github_org_members"
+	v2Scim "github.com/communitybridge/easycla/cla-backend-go/v2/scim"
+	v2UserMerge "github.com/communitybridge/easycla/cla-backend-go/v2/user_merge"
+	v2Webhooks "github.com/communitybridge/easycla/cla-backend-go/v2/webhooks"
+
+	"github.com/communitybridge/easycla/cla-backend-go/github_jobs"
+	v2BranchProtection "github.com/communitybridge/easycla/cla-backend-go/v2/branch_protection"
+	v2GithubDrift "github.com/communitybridge/easycla/cla-backend-go/v2/github_drift"
+	v2GithubJobs "github.com/communitybridge/easycla/cla-backend-go/v2/github_jobs"
+	"github.com/communitybridge/easycla/cla-backend-go/webhooks"
+
 	"github.com/gofrs/uuid"
 
 	"github.com/communitybridge/easycla/cla-backend-go/approval_list"
@@ -73,6 +110,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations"
+	v2Models "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	v2RestAPI "github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi"
 	v2Ops "github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/github"
@@ -204,6 +242,7 @@ func server(localMode bool) http.Handler {
 		logrus.Panic(err)
 	}
 	github.Init(configFile.Github.AppID, configFile.Github.AppPrivateKey, configFile.Github.AccessToken)
+	github.InitEnterpriseFromSSM(awsSession, stage)
 
 	// Our backend repository handlers
 	userRepo := user.NewDynamoRepository(awsSession, stage)
@@ -219,7 +258,11 @@ func server(localMode bool) http.Handler {
 	eventsRepo := events.NewRepository(awsSession, stage)
 	metricsRepo := metrics.NewRepository(awsSession, stage, configFile.APIGatewayURL, projectClaGroupRepo)
 	githubOrganizationsRepo := github_organizations.NewRepository(awsSession, stage)
+	github.InitEnterpriseHosts(github_organizations.EnterpriseHostsLoader(githubOrganizationsRepo))
 	claManagerReqRepo := cla_manager.NewRepository(awsSession, stage)
+	featureFlagsRepo := feature_flags.NewRepository(awsSession, stage)
+	apiKeysRepo := api_keys.NewRepository(awsSession, stage)
+	webhooksRepo := webhooks.NewRepository(awsSession, stage)
 
 	// Our service layer handlers
 	eventsService := events.NewService(eventsRepo, combinedRepo{
@@ -236,33 +279,60 @@ func server(localMode bool) http.Handler {
 	organization_service.InitClient(configFile.APIGatewayURL, eventsService)
 	acs_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
 
+	// Every known feature flag and the value used until an admin stores the flag
+	featureFlagsService := feature_flags.NewService(featureFlagsRepo, stage, map[string]bool{
+		feature_flags.LFStyleTemplate:     strings.ToLower(stage) == "dev",
+		feature_flags.GithubOrgValidation: githubOrgValidation,
+	})
 	usersService := users.NewService(usersRepo, eventsService)
 	healthService := health.New(Version, Commit, Branch, BuildDate)
-	templateService := template.NewService(stage, templateRepo, docraptorClient, awsSession)
+	templateService := template.NewService(stage, templateRepo, docraptorClient, awsSession, featureFlagsService)
 	projectService := project.NewService(projectRepo, repositoriesRepo, gerritRepo, projectClaGroupRepo, usersRepo)
 	v2ProjectService := v2Project.NewService(projectService, projectRepo, projectClaGroupRepo)
 	companyService := company.NewService(companyRepo, configFile.CorporateConsoleURL, userRepo, usersService)
 	v2CompanyService := v2Company.NewService(companyService, signaturesRepo, projectRepo, usersRepo, companyRepo, projectClaGroupRepo, eventsService)
 	v2SignService := sign.NewService(configFile.ClaV1ApiURL, companyRepo, projectRepo, projectClaGroupRepo, companyService)
-	signaturesService := signatures.NewService(signaturesRepo, companyService, usersService, eventsService, githubOrgValidation)
+	domainVerificationService := domain_verification.NewService(domain_verification.NewRepository(awsSession, stage))
+	approvalListExpiryService := approval_list_expiry.NewService(approval_list_expiry.NewRepository(awsSession, stage), signaturesRepo, projectRepo, eventsService,
+		func() int { return ini.GetConfig().ApprovalListExpiryWarningDays })
+	githubOrgMembersService := github_org_members.NewService(github_org_members.NewRepository(awsSession, stage), githubOrganizationsRepo, companyRepo, signaturesRepo)
+	signaturesService := signatures.NewService(signaturesRepo, companyService, usersService, eventsService, featureFlagsService, domainVerificationService, approvalListExpiryService,
+		githubOrgMembersService)
+	approvalListImportService := approval_list_import.NewService(companyService, projectService, signaturesService, domainVerificationService, eventsService)
+	scimService := scim.NewService(scim.NewRepository(awsSession, stage), companyService, projectService, signaturesRepo, signaturesService, eventsService)
 	v2SignatureService := v2Signatures.NewService(awsSession, configFile.SignatureFilesBucket, projectService, companyService, signaturesService, projectClaGroupRepo)
-	v1ClaManagerService := cla_manager.NewService(claManagerReqRepo, companyService, projectService, usersService, signaturesService, eventsService, configFile.CorporateConsoleURL)
+	v1ClaManagerService := cla_manager.NewService(claManagerReqRepo, cla_manager.NewPolicyRepository(awsSession, stage), companyService, projectService, usersService, signaturesService, eventsService, configFile.CorporateConsoleURL)
+	claManagerSuccessionService := cla_manager_succession.NewService(cla_manager_succession.NewRepository(awsSession, stage), signaturesRepo, projectRepo, companyRepo,
+		usersRepo, user_service.GetClient(), v1ClaManagerService, eventsService)
 	repositoriesService := repositories.NewService(repositoriesRepo, githubOrganizationsRepo, projectClaGroupRepo)
 	v2RepositoriesService := v2Repositories.NewService(repositoriesRepo, projectClaGroupRepo, githubOrganizationsRepo)
-	v2ClaManagerService := v2ClaManager.NewService(companyService, projectService, v1ClaManagerService, usersService, repositoriesService, v2CompanyService, eventsService, projectClaGroupRepo)
+	v2ClaManagerService := v2ClaManager.NewService(companyService, projectService, v1ClaManagerService, usersService, repositoriesService, v2CompanyService, eventsService, projectClaGroupRepo, signaturesService)
 	approvalListService := approval_list.NewService(approvalListRepo, usersRepo, companyRepo, projectRepo, signaturesRepo, configFile.CorporateConsoleURL, http.DefaultClient)
 	authorizer := auth.NewAuthorizer(authValidator, userRepo)
 	v2MetricsService := metrics.NewService(metricsRepo, projectClaGroupRepo)
 	githubOrganizationsService := github_organizations.NewService(githubOrganizationsRepo, repositoriesRepo, projectClaGroupRepo)
 	v2GithubOrganizationsService := v2GithubOrganizations.NewService(githubOrganizationsRepo, repositoriesRepo)
 	autoEnableService := dynamo_events.NewAutoEnableService(repositoriesService, repositoriesRepo, githubOrganizationsRepo, projectClaGroupRepo, projectService)
-	v2GithubActivityService := v2GithubActivity.NewService(repositoriesRepo, eventsService, autoEnableService)
+	v2GithubActivityService := v2GithubActivity.NewService(repositoriesRepo, githubOrganizationsRepo, eventsService, autoEnableService, projectService)
 	gerritService := gerrits.NewService(gerritRepo, &gerrits.LFGroup{
 		LfBaseURL:    configFile.LFGroup.ClientURL,
 		ClientID:     configFile.LFGroup.ClientID,
 		ClientSecret: configFile.LFGroup.ClientSecret,
 		RefreshToken: configFile.LFGroup.RefreshToken,
 	})
+	apiKeysService := api_keys.NewService(apiKeysRepo, projectClaGroupRepo, eventsService)
+	webhooksService := webhooks.NewService(webhooksRepo, projectClaGroupRepo)
+	githubDriftService := v2GithubDrift.NewService(v2GithubDrift.NewRepository(awsSession, stage), repositoriesRepo, githubOrganizationsRepo, autoEnableService)
+	branchProtectionService := v2BranchProtection.NewService(v2BranchProtection.NewRepository(awsSession, stage), repositoriesRepo, githubOrganizationsRepo, projectClaGroupRepo)
+	githubJobsService := github_jobs.NewService(github_jobs.NewRepository(awsSession, stage), githubOrganizationsRepo)
+	companyMergeService := company_merge.NewService(company_merge.NewRepository(awsSession, stage), companyRepo, domainVerificationService)
+	userMergeService := user_merge.NewService(user_merge.NewRepository(awsSession, stage))
+	affiliationChangeRepo := affiliation_change.NewRepository(awsSession, stage)
+	affiliationChangeService := affiliation_change.NewService(affiliationChangeRepo, usersRepo, companyRepo, signaturesRepo,
+		projectRepo, approvalListService, eventsService)
+	dataSubjectService := data_subject.NewService(data_subject.NewRepository(awsSession, stage), data_subject.NewStorage(awsSession, configFile.SignatureFilesBucket), eventsService)
+	claStatusService := cla_status.NewService(projectClaGroupRepo, repositoriesRepo, usersRepo, signaturesRepo, githubOrgMembersService,
+		eventsService, affiliationChangeRepo, configFile.ContributorConsoleV2URL)
 	v2ClaGroupService := cla_groups.NewService(projectService, templateService, projectClaGroupRepo, v1ClaManagerService, signaturesService, metricsRepo, gerritService, repositoriesService, eventsService)
 
 	sessionStore, err := dynastore.New(dynastore.Path("/"), dynastore.HTTPOnly(), dynastore.TableName(configFile.SessionStoreTableName), dynastore.DynamoDB(dynamodb.New(awsSession)))
@@ -271,10 +341,12 @@ func server(localMode bool) http.Handler {
 	}
 	utils.SetSnsEmailSender(awsSession, configFile.SNSEventTopicARN, configFile.SenderEmailAddress)
 	utils.SetS3Storage(awsSession, configFile.SignatureFilesBucket)
+	utils.SetAPIKeyAuthorizer(apiKeysService)
 
 	// Setup security handlers
 	api.OauthSecurityAuth = authorizer.SecurityAuth
 	v2API.LfAuthAuth = lfxAuth.SwaggerAuth
+	v2API.APIKeyAuth = apiKeysService.SwaggerAuth
 
 	// Setup our API handlers
 	users.Configure(api, usersService, eventsService)
@@ -287,7 +359,7 @@ func server(localMode bool) http.Handler {
 	github.Configure(api, configFile.Github.ClientID, configFile.Github.ClientSecret, configFile.Github.AccessToken, sessionStore)
 	signatures.Configure(api, signaturesService, sessionStore, eventsService)
 	v2Signatures.Configure(v2API, projectService, projectRepo, companyService, signaturesService, sessionStore, eventsService, v2SignatureService, projectClaGroupRepo)
-	approval_list.Configure(api, approvalListService, sessionStore, signaturesService, eventsService)
+	approval_list.Configure(api, approvalListService, sessionStore, eventsService)
 	company.Configure(api, companyService, usersService, companyUserValidation, eventsService)
 	docs.Configure(api)
 	v2Docs.Configure(v2API)
@@ -308,6 +380,24 @@ func server(localMode bool) http.Handler {
 	sign.Configure(v2API, v2SignService)
 	cla_groups.Configure(v2API, v2ClaGroupService, projectService, projectClaGroupRepo, eventsService)
 	v2GithubActivity.Configure(v2API, v2GithubActivityService)
+	v2FeatureFlags.Configure(v2API, featureFlagsService, eventsService)
+	v2APIKeys.Configure(v2API, apiKeysService, eventsService)
+	v2ClaStatus.Configure(v2API, claStatusService, cla_status.NewRateLimiter(cla_status.NewRateLimitRepository(awsSession, stage)))
+	v2Webhooks.Configure(v2API, webhooksService, eventsService)
+	v2GithubDrift.Configure(v2API, githubDriftService)
+	v2BranchProtection.Configure(v2API, branchProtectionService, projectClaGroupRepo, eventsService)
+	v2GithubJobs.Configure(v2API, githubJobsService)
+	v2CompanyMerge.Configure(v2API, companyMergeService, eventsService)
+	v2UserMerge.Configure(v2API, userMergeService, eventsService)
+	v2AffiliationChange.Configure(v2API, affiliationChangeService)
+	v2DataSubject.Configure(v2API, dataSubjectService, eventsService)
+	v2DomainVerification.Configure(v2API, domainVerificationService, companyService, eventsService)
+	v2ApprovalListExpiry.Configure(v2API, approvalListExpiryService, companyService, signaturesService)
+	v2ApprovalListImport.Configure(v2API, approvalListImportService)
+	v2Scim.Configure(v2API, scimService, eventsService)
+	v2GithubOrgMembers.Configure(v2API, githubOrgMembersService)
+	v2ClaManagerApproval.Configure(v2API, v1ClaManagerService, companyService, signaturesService, eventsService)
+	v2ClaManagerSuccession.Configure(v2API, claManagerSuccessionService)
 
 	userCreaterMiddleware := func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -321,6 +411,12 @@ func server(localMode bool) http.Handler {
 	middlewareSetupfunc := func(handler http.Handler) http.Handler {
 		return setRequestIDHandler(responseLoggingMiddleware(userCreaterMiddleware(handler)))
 	}
+	// The v2 API also accepts API keys for machine clients
+	v2MiddlewareSetupfunc := func(handler http.Handler) http.Handler {
+		return setRequestIDHandler(apiKeyMiddleware(apiKeysService, responseLoggingMiddleware(userCreaterMiddleware(handler))))
+	}
+	// The SCIM endpoints are served next to the v2 API and use their own token authentication
+	scimHandler := setRequestIDHandler(responseLoggingMiddleware(scim.NewHandler(scimService)))
 
 	v2API.CsvProducer = openapi_runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
 		switch v := data.(type) {
@@ -375,23 +471,24 @@ func server(localMode bool) http.Handler {
 				// v1 API => /v3, python side is /v1 and /v2
 				api.Serve(middlewareSetupfunc), swaggerSpec.BasePath(),
 				// v2 API => /v4
-				v2API.Serve(middlewareSetupfunc), v2SwaggerSpec.BasePath()))
+				routeSCIM(scimHandler, v2API.Serve(v2MiddlewareSetupfunc), v2SwaggerSpec.BasePath()), v2SwaggerSpec.BasePath()))
 	} else {
 		apiHandler = setupCORSHandler(
 			wrapHandlers(
 				// v1 API => /v3, python side is /v1 and /v2
 				api.Serve(middlewareSetupfunc), swaggerSpec.BasePath(),
 				// v2 API => /v4
-				v2API.Serve(middlewareSetupfunc), v2SwaggerSpec.BasePath()),
-			configFile.AllowedOrigins)
+				routeSCIM(scimHandler, v2API.Serve(v2MiddlewareSetupfunc), v2SwaggerSpec.BasePath()), v2SwaggerSpec.BasePath()),
+			func() []string { return ini.GetConfig().AllowedOrigins })
 	}
 	return apiHandler
 }
 
-// setupCORSHandler sets up the CORS logic and creates the middleware HTTP handler
-func setupCORSHandler(handler http.Handler, allowedOrigins []string) http.Handler {
+// setupCORSHandler sets up the CORS logic and creates the middleware HTTP handler - the allowed origins are
+// evaluated for each request so that a configuration reload takes effect without a restart
+func setupCORSHandler(handler http.Handler, allowedOriginsFunc func() []string) http.Handler {
 
-	log.Debugf("Allowed origins: %v", allowedOrigins)
+	log.Debugf("Allowed origins: %v", allowedOriginsFunc())
 	c := cors.New(cors.Options{
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
 		AllowedHeaders:   []string{"Authorization", "Content-Type"},
@@ -404,6 +501,7 @@ func setupCORSHandler(handler http.Handler, allowedOrigins []string) http.Handle
 			}
 
 			// Ensure the origin is in our allowed list
+			allowedOrigins := allowedOriginsFunc()
 			allowedOrigin := utils.HostInSlice(u.Hostname(), allowedOrigins)
 			if allowedOrigin {
 				// localhost with HTTP is allowed
@@ -447,6 +545,19 @@ func wrapHandlers(v1 http.Handler, v1BasePath string, v2 http.Handler, v2BasePat
 	})
 }
 
+// routeSCIM routes the SCIM protocol requests of the identity providers to the SCIM handler - the SCIM endpoints
+// are not part of the swagger spec as the SCIM clients expect the SCIM schemas and error responses
+func routeSCIM(scimHandler http.Handler, v2 http.Handler, v2BasePath string) http.Handler {
+	scimBasePath := v2BasePath + scim.BasePath
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, scimBasePath) {
+			http.StripPrefix(scimBasePath, scimHandler).ServeHTTP(w, r)
+			return
+		}
+		v2.ServeHTTP(w, r)
+	})
+}
+
 // setupCORSHandlerLocal allows all origins and sets up the handler
 func setupCORSHandlerLocal(handler http.Handler) http.Handler {
 
@@ -509,6 +620,53 @@ func setRequestIDHandler(next http.Handler) http.Handler {
 	})
 }
 
+// apiKeyMiddleware rejects requests using an invalid API key or using a read only API key for a write operation,
+// and records the API key usage - the key scope is checked by the handlers
+func apiKeyMiddleware(apiKeysService api_keys.Service, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyValue := r.Header.Get(api_keys.HeaderName)
+		if keyValue == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		reqID := r.Header.Get("x-request-id")
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		apiKey, err := apiKeysService.Authenticate(ctx, keyValue)
+		if err != nil {
+			log.WithField(utils.XREQUESTID, reqID).Warnf("rejecting request %s %s - API key authentication failed, error: %v", r.Method, r.URL.Path, err)
+			writeErrorResponse(w, http.StatusUnauthorized, &v2Models.ErrorResponse{
+				Code:       "401",
+				Message:    "EasyCLA - 401 Unauthorized - invalid API key",
+				XRequestID: reqID,
+			})
+			return
+		}
+		if !apiKey.AllowsMethod(r.Method) && !isReadOnlyRequest(r) {
+			writeErrorResponse(w, http.StatusForbidden, utils.ErrorResponseForbidden(reqID,
+				fmt.Sprintf("API key %s is read only and cannot be used for %s requests", apiKey.APIKeyID, r.Method)))
+			return
+		}
+
+		apiKeysService.RecordUsage(ctx, apiKey, r.Method, r.URL.Path)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isReadOnlyRequest returns true for requests which do not modify any data even though they are not GET requests
+func isReadOnlyRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/cla-status/check")
+}
+
+// writeErrorResponse writes the error response as JSON
+func writeErrorResponse(w http.ResponseWriter, statusCode int, payload *v2Models.ErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		log.Warnf("unable to write error response, error: %v", err)
+	}
+}
+
 // responseLoggingMiddleware logs the responses from API endpoints
 func responseLoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
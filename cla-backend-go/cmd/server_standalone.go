@@ -12,6 +12,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	ini "github.com/communitybridge/easycla/cla-backend-go/init"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/spf13/cobra"
@@ -23,6 +24,14 @@ func runServer(cmd *cobra.Command, args []string) {
 
 	handler := server(true)
 
+	// Periodically refresh the reloadable configuration values, such as the allowed origins
+	reloadInterval := viper.GetDuration("CONFIG_RELOAD_INTERVAL")
+	if reloadInterval > 0 {
+		log.Infof("Reloading the configuration every %s - set CONFIG_RELOAD_INTERVAL environment variable to change the interval", reloadInterval)
+		stopReloader := ini.StartConfigReloader(reloadInterval)
+		defer stopReloader()
+	}
+
 	errs := make(chan error, 2)
 	go func() {
 		log.Infof("Running http server on port: %d - set PORT environment variable to change port", viper.GetInt("PORT"))
@@ -21,6 +21,9 @@ type DBModel struct {
 	Updated           string   `dynamodbav:"date_modified" json:"date_modified"`
 	Note              string   `dynamodbav:"note" json:"note"`
 	Version           string   `dynamodbav:"version" json:"version"`
+	// MergedIntoCompanyID is set on the tombstone of a company merged into another company
+	MergedIntoCompanyID string `dynamodbav:"merged_into_company_id,omitempty" json:"merged_into_company_id,omitempty"`
+	DateMerged          string `dynamodbav:"date_merged,omitempty" json:"date_merged,omitempty"`
 }
 
 // Invite data model
@@ -18,6 +18,7 @@ func buildCompanyProjection() expression.ProjectionBuilder {
 		expression.Name("date_modified"),
 		expression.Name("note"),
 		expression.Name("version"),
+		expression.Name("merged_into_company_id"),
 	)
 }
 
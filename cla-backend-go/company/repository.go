@@ -33,6 +33,9 @@ var (
 	ErrCompanyDoesNotExist = errors.New("company does not exist")
 )
 
+// maxMergedCompanyRedirects limits the merged company tombstones followed by a single lookup
+const maxMergedCompanyRedirects = 5
+
 // IRepository interface methods
 type IRepository interface { //nolint
 	CreateCompany(ctx context.Context, in *models.Company) (*models.Company, error)
@@ -56,6 +59,8 @@ type IRepository interface { //nolint
 	updateInviteRequestStatus(ctx context.Context, companyInviteID, status string) error
 
 	UpdateCompanyAccessList(ctx context.Context, companyID string, companyACL []string) error
+	UpdateCompanyExternalID(ctx context.Context, companyID, companySFID string) error
+	MarkCompanyMerged(ctx context.Context, companyID, mergedIntoCompanyID string) error
 }
 
 type repository struct {
@@ -188,12 +193,18 @@ func (repo repository) GetCompanyByExternalID(ctx context.Context, companySFID s
 	if len(results.Items) == 0 {
 		return nil, ErrCompanyDoesNotExist
 	}
-	dbCompanyModel := DBModel{}
-	err = dynamodbattribute.UnmarshalMap(results.Items[0], &dbCompanyModel)
+	var dbCompanyModels []DBModel
+	err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &dbCompanyModels)
 	if err != nil {
 		return nil, err
 	}
-	return dbCompanyModel.toModel()
+	// Prefer the company which was not merged, the tombstones of the merged companies may keep the same SFID
+	for i := range dbCompanyModels {
+		if dbCompanyModels[i].MergedIntoCompanyID == "" {
+			return dbCompanyModels[i].toModel()
+		}
+	}
+	return repo.resolveMergedCompany(ctx, &dbCompanyModels[0])
 }
 
 // GetCompanyByName searches the database and returns the matching company names
@@ -247,13 +258,50 @@ func (repo repository) GetCompanyByName(ctx context.Context, companyName string)
 	// TODO: DAD - review projection and unmarshalling logic, the 'note' column is not being loaded into the data model
 	//log.Debugf("DB response model: %#v", dbModels)
 
+	if dbModels[0].MergedIntoCompanyID != "" {
+		return repo.resolveMergedCompany(ctx, &dbModels[0])
+	}
 	return toSwaggerModel(&dbModels[0])
 }
 
-// GetCompany returns a company based on the company ID
+// GetCompany returns a company based on the company ID - the lookup of a merged company returns the surviving company
 func (repo repository) GetCompany(ctx context.Context, companyID string) (*models.Company, error) {
+	dbCompanyModel, err := repo.getCompanyDBModel(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if dbCompanyModel.MergedIntoCompanyID != "" {
+		return repo.resolveMergedCompany(ctx, dbCompanyModel)
+	}
+	return dbCompanyModel.toModel()
+}
+
+// resolveMergedCompany follows the tombstones of the merged companies to the surviving company
+func (repo repository) resolveMergedCompany(ctx context.Context, dbCompanyModel *DBModel) (*models.Company, error) {
 	f := logrus.Fields{
-		"functionName":   "GetCompany",
+		"functionName":   "resolveMergedCompany",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      dbCompanyModel.CompanyID,
+	}
+	for redirects := 0; dbCompanyModel.MergedIntoCompanyID != ""; redirects++ {
+		if redirects == maxMergedCompanyRedirects {
+			log.WithFields(f).Warnf("too many merged company redirects, last company: %s", dbCompanyModel.CompanyID)
+			return nil, ErrCompanyDoesNotExist
+		}
+		log.WithFields(f).Debugf("company %s was merged into company %s", dbCompanyModel.CompanyID, dbCompanyModel.MergedIntoCompanyID)
+		var err error
+		dbCompanyModel, err = repo.getCompanyDBModel(ctx, dbCompanyModel.MergedIntoCompanyID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dbCompanyModel.toModel()
+}
+
+// getCompanyDBModel returns the company record based on the company ID, merged companies are not resolved
+func (repo repository) getCompanyDBModel(ctx context.Context, companyID string) (*DBModel, error) {
+	f := logrus.Fields{
+		"functionName":   "getCompanyDBModel",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
 		"companyID":      companyID,
 	}
@@ -282,7 +330,7 @@ func (repo repository) GetCompany(ctx context.Context, companyID string) (*model
 		return nil, err
 	}
 
-	return dbCompanyModel.toModel()
+	return &dbCompanyModel, nil
 }
 
 // SearchCompanyByName locates companies by the matching name and return any potential matches
@@ -638,6 +686,7 @@ func buildCompanyModels(ctx context.Context, results *dynamodb.ScanOutput) ([]mo
 		CompanyExternalID string   `json:"company_external_id"`
 		Created           string   `json:"date_created"`
 		Modified          string   `json:"date_modified"`
+		MergedIntoCompany string   `json:"merged_into_company_id"`
 	}
 
 	// The DB company model
@@ -652,6 +701,11 @@ func buildCompanyModels(ctx context.Context, results *dynamodb.ScanOutput) ([]mo
 	now, _ := utils.CurrentTime()
 
 	for _, dbCompany := range dbCompanies {
+		// Skip the tombstones of the merged companies
+		if dbCompany.MergedIntoCompany != "" {
+			continue
+		}
+
 		createdDateTime, err := utils.ParseDateTime(dbCompany.Created)
 		if err != nil {
 			log.WithFields(f).Warnf("Unable to parse company created date time: %s, error: %v - using current time",
@@ -1167,3 +1221,83 @@ func (repo repository) CreateCompany(ctx context.Context, in *models.Company) (*
 	log.WithFields(f).Debugf("company created %#v\n", comp)
 	return comp.toModel()
 }
+
+// UpdateCompanyExternalID updates the company SFID
+func (repo repository) UpdateCompanyExternalID(ctx context.Context, companyID, companySFID string) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateCompanyExternalID",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+		"companySFID":    companySFID,
+	}
+	_, now := utils.CurrentTime()
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			"#E": aws.String("company_external_id"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":e": {
+				S: aws.String(companySFID),
+			},
+			":m": {
+				S: aws.String(now),
+			},
+		},
+		TableName: aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+		UpdateExpression: aws.String("SET #E = :e, #M = :m"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("Error updating company external id, error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// MarkCompanyMerged turns the company record into a tombstone redirecting the lookups to the surviving company
+func (repo repository) MarkCompanyMerged(ctx context.Context, companyID, mergedIntoCompanyID string) error {
+	f := logrus.Fields{
+		"functionName":        "MarkCompanyMerged",
+		utils.XREQUESTID:      ctx.Value(utils.XREQUESTID),
+		"companyID":           companyID,
+		"mergedIntoCompanyID": mergedIntoCompanyID,
+	}
+	_, now := utils.CurrentTime()
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			"#T": aws.String("merged_into_company_id"),
+			"#D": aws.String("date_merged"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":t": {
+				S: aws.String(mergedIntoCompanyID),
+			},
+			":m": {
+				S: aws.String(now),
+			},
+		},
+		TableName: aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+		ConditionExpression: aws.String("attribute_exists(company_id)"),
+		UpdateExpression:    aws.String("SET #T = :t, #D = :m, #M = :m"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("Error marking the company as merged, error: %v", err)
+		return err
+	}
+
+	return nil
+}
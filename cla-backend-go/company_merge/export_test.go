@@ -0,0 +1,7 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company_merge
+
+// NormalizeCompanyName exposes normalizeCompanyName to the tests
+var NormalizeCompanyName = normalizeCompanyName
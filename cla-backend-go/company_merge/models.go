@@ -0,0 +1,165 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company_merge
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// RequestType identifies the table of the requests referencing a company
+type RequestType string
+
+// request types
+const (
+	RequestTypeApprovalList RequestType = "approval_list"
+	RequestTypeClaManager   RequestType = "cla_manager"
+	RequestTypeInvite       RequestType = "invite"
+)
+
+// Signature is the part of the signature record used when merging companies
+type Signature struct {
+	SignatureID            string   `dynamodbav:"signature_id" json:"signature_id"`
+	SignatureProjectID     string   `dynamodbav:"signature_project_id" json:"signature_project_id"`
+	SignatureType          string   `dynamodbav:"signature_type" json:"signature_type"`
+	SignatureReferenceID   string   `dynamodbav:"signature_reference_id" json:"signature_reference_id"`
+	SignatureReferenceType string   `dynamodbav:"signature_reference_type" json:"signature_reference_type"`
+	SignatureUserCompanyID string   `dynamodbav:"signature_user_ccla_company_id" json:"signature_user_ccla_company_id"`
+	SignatureSigned        bool     `dynamodbav:"signature_signed" json:"signature_signed"`
+	SignatureApproved      bool     `dynamodbav:"signature_approved" json:"signature_approved"`
+	SignatureACL           []string `dynamodbav:"signature_acl" json:"signature_acl"`
+	EmailApprovalList      []string `dynamodbav:"email_whitelist" json:"email_whitelist"`
+	DomainApprovalList     []string `dynamodbav:"domain_whitelist" json:"domain_whitelist"`
+	GithubApprovalList     []string `dynamodbav:"github_whitelist" json:"github_whitelist"`
+	GithubOrgApprovalList  []string `dynamodbav:"github_org_whitelist" json:"github_org_whitelist"`
+}
+
+// clone returns a copy of the signature whose ACL and approval lists can be changed without changing the signature
+func (s *Signature) clone() *Signature {
+	signature := *s
+	signature.SignatureACL = append([]string(nil), s.SignatureACL...)
+	signature.EmailApprovalList = append([]string(nil), s.EmailApprovalList...)
+	signature.DomainApprovalList = append([]string(nil), s.DomainApprovalList...)
+	signature.GithubApprovalList = append([]string(nil), s.GithubApprovalList...)
+	signature.GithubOrgApprovalList = append([]string(nil), s.GithubOrgApprovalList...)
+	return &signature
+}
+
+// isCorporate returns true for the CCLA signature of the company, false for the employee acknowledgements
+func (s *Signature) isCorporate() bool {
+	return s.SignatureReferenceType == "company"
+}
+
+// isActive returns true if the signature is signed and approved
+func (s *Signature) isActive() bool {
+	return s.SignatureSigned && s.SignatureApproved
+}
+
+// Request is a company invite, an approval list request or a CLA manager request referencing a company
+type Request struct {
+	RequestID string
+	ProjectID string
+	UserID    string
+	Status    string
+}
+
+// DuplicateMergeReport lists the records moved from one duplicate company to the surviving company
+type DuplicateMergeReport struct {
+	CompanyID                 string
+	CompanyName               string
+	CompanyExternalID         string
+	ExternalIDMoved           bool
+	CompanyACLAdded           []string
+	SignaturesMoved           []string
+	SignaturesMerged          []string
+	ApprovalListRequestsMoved []string
+	ClaManagerRequestsMoved   []string
+	InvitesMoved              []string
+	ApprovalListEntriesAdded  []*ApprovalListEntry
+	// ApprovalListDomainsRejected are the domain entries of the duplicate not added to the approval lists of the
+	// surviving company because the domains are not verified for it or denied
+	ApprovalListDomainsRejected []*ApprovalListEntry
+}
+
+// ApprovalListEntry is an approval list entry of a duplicate added to the CCLA signature of the surviving company
+type ApprovalListEntry struct {
+	ClaGroupID string
+	ListType   approval_list_expiry.ListType
+	Value      string
+}
+
+// MergeReport is the result of merging the duplicate companies into the surviving company, the changes are only
+// planned for a dry run
+type MergeReport struct {
+	CompanyID         string
+	CompanyName       string
+	CompanyExternalID string
+	DryRun            bool
+	Duplicates        []*DuplicateMergeReport
+}
+
+// DuplicateCandidate is a group of companies which are likely the same company
+type DuplicateCandidate struct {
+	SuggestedCompanyID string
+	Companies          []DuplicateCompany
+	Reasons            []string
+}
+
+// DuplicateCompany is a company of the duplicate candidates
+type DuplicateCompany struct {
+	CompanyID         string
+	CompanyName       string
+	CompanyExternalID string
+}
+
+// ToModel converts the merge report to the API model
+func (r *MergeReport) ToModel() *models.CompanyMergeReport {
+	duplicates := make([]*models.CompanyMergeDuplicate, 0, len(r.Duplicates))
+	for _, duplicate := range r.Duplicates {
+		var rejectedDomains []string
+		for _, entry := range duplicate.ApprovalListDomainsRejected {
+			if !utils.StringInSlice(entry.Value, rejectedDomains) {
+				rejectedDomains = append(rejectedDomains, entry.Value)
+			}
+		}
+		duplicates = append(duplicates, &models.CompanyMergeDuplicate{
+			CompanyID:                   duplicate.CompanyID,
+			CompanyName:                 duplicate.CompanyName,
+			CompanyExternalID:           duplicate.CompanyExternalID,
+			ExternalIDMoved:             duplicate.ExternalIDMoved,
+			CompanyACLAdded:             duplicate.CompanyACLAdded,
+			SignaturesMoved:             duplicate.SignaturesMoved,
+			SignaturesMerged:            duplicate.SignaturesMerged,
+			ApprovalListRequestsMoved:   duplicate.ApprovalListRequestsMoved,
+			ClaManagerRequestsMoved:     duplicate.ClaManagerRequestsMoved,
+			InvitesMoved:                duplicate.InvitesMoved,
+			ApprovalListDomainsRejected: rejectedDomains,
+		})
+	}
+	return &models.CompanyMergeReport{
+		CompanyID:         r.CompanyID,
+		CompanyName:       r.CompanyName,
+		CompanyExternalID: r.CompanyExternalID,
+		DryRun:            r.DryRun,
+		Duplicates:        duplicates,
+	}
+}
+
+// ToModel converts the duplicate candidate to the API model
+func (c *DuplicateCandidate) ToModel() *models.CompanyDuplicateCandidate {
+	companies := make([]*models.CompanyDuplicate, 0, len(c.Companies))
+	for _, company := range c.Companies {
+		companies = append(companies, &models.CompanyDuplicate{
+			CompanyID:         company.CompanyID,
+			CompanyName:       company.CompanyName,
+			CompanyExternalID: company.CompanyExternalID,
+		})
+	}
+	return &models.CompanyDuplicateCandidate{
+		SuggestedCompanyID: c.SuggestedCompanyID,
+		Companies:          companies,
+		Reasons:            c.Reasons,
+	}
+}
@@ -0,0 +1,565 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company_merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// errors
+var (
+	ErrSignatureNotFound = errors.New("signature not found")
+	ErrSignatureChanged  = errors.New("signature was changed concurrently")
+)
+
+// Repository interface defines the functions moving the records of a company to another company
+type Repository interface {
+	GetCompanySignatures(ctx context.Context, companyID string) ([]*Signature, error)
+	UpdateSignatureCompany(ctx context.Context, signature *Signature, company *models.Company) error
+	GetSignature(ctx context.Context, signatureID string) (*Signature, error)
+	UpdateSignatureApprovalLists(ctx context.Context, current, updated *Signature) error
+	InvalidateSignature(ctx context.Context, signatureID, note string) error
+	AddCompanyManagers(ctx context.Context, companyID string, managers []string) error
+	GetCompanyApprovalListDomains(ctx context.Context) (map[string][]string, error)
+
+	GetCompanyRequests(ctx context.Context, requestType RequestType, companyID string) ([]*Request, error)
+	UpdateRequestCompany(ctx context.Context, requestType RequestType, requestID string, company *models.Company) error
+}
+
+// requestTable describes the table of a request type
+type requestTable struct {
+	tableName      string
+	indexName      string
+	keyColumn      string
+	companyColumn  string
+	statusColumn   string
+	hasCompanyName bool
+	hasCompanySFID bool
+}
+
+type repository struct {
+	stage              string
+	dynamoDBClient     *dynamodb.DynamoDB
+	signatureTableName string
+	companyTableName   string
+	requestTables      map[RequestType]requestTable
+}
+
+// NewRepository creates a new instance of the company merge repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:              stage,
+		dynamoDBClient:     dynamodb.New(awsSession),
+		signatureTableName: fmt.Sprintf("cla-%s-signatures", stage),
+		companyTableName:   fmt.Sprintf("cla-%s-companies", stage),
+		requestTables: map[RequestType]requestTable{
+			RequestTypeApprovalList: {
+				tableName:      fmt.Sprintf("cla-%s-ccla-whitelist-requests", stage),
+				indexName:      "company-id-project-id-index",
+				keyColumn:      "request_id",
+				companyColumn:  "company_id",
+				statusColumn:   "request_status",
+				hasCompanyName: true,
+			},
+			RequestTypeClaManager: {
+				tableName:      fmt.Sprintf("cla-%s-cla-manager-requests", stage),
+				indexName:      "cla-manager-requests-company-project-index",
+				keyColumn:      "request_id",
+				companyColumn:  "company_id",
+				statusColumn:   "status",
+				hasCompanyName: true,
+				hasCompanySFID: true,
+			},
+			RequestTypeInvite: {
+				tableName:     fmt.Sprintf("cla-%s-company-invites", stage),
+				indexName:     "requested-company-index",
+				keyColumn:     "company_invite_id",
+				companyColumn: "requested_company_id",
+				statusColumn:  "status",
+			},
+		},
+	}
+}
+
+// GetCompanySignatures returns the CCLA signatures of the company and the employee acknowledgements referencing it
+func (repo *repository) GetCompanySignatures(ctx context.Context, companyID string) ([]*Signature, error) {
+	f := logrus.Fields{
+		"functionName":   "GetCompanySignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+	}
+
+	corporateCondition := expression.Key("signature_reference_id").Equal(expression.Value(companyID))
+	corporateFilter := expression.Name("signature_reference_type").Equal(expression.Value("company"))
+	corporateSignatures, err := repo.querySignatures(ctx, "reference-signature-index", expression.NewBuilder().WithKeyCondition(corporateCondition).WithFilter(corporateFilter))
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the corporate signatures of the company, error: %+v", err)
+		return nil, err
+	}
+
+	employeeCondition := expression.Key("signature_user_ccla_company_id").Equal(expression.Value(companyID))
+	employeeSignatures, err := repo.querySignatures(ctx, "signature-user-ccla-company-index", expression.NewBuilder().WithKeyCondition(employeeCondition))
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the employee signatures of the company, error: %+v", err)
+		return nil, err
+	}
+
+	return append(corporateSignatures, employeeSignatures...), nil
+}
+
+// querySignatures returns all the signatures of the query
+func (repo *repository) querySignatures(ctx context.Context, indexName string, builder expression.Builder) ([]*Signature, error) {
+	expr, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.signatureTableName),
+		IndexName:                 aws.String(indexName),
+	}
+
+	var signatures []*Signature
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []*Signature
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return signatures, nil
+}
+
+// UpdateSignatureCompany points the signature to the company - the CCLA signature reference or the company of the
+// employee acknowledgement
+func (repo *repository) UpdateSignatureCompany(ctx context.Context, signature *Signature, company *models.Company) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateSignatureCompany",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signature.SignatureID,
+		"companyID":      company.CompanyID,
+	}
+
+	_, now := utils.CurrentTime()
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signature.SignatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":c": {S: aws.String(company.CompanyID)},
+			":m": {S: aws.String(now)},
+		},
+	}
+	if signature.isCorporate() {
+		input.ExpressionAttributeNames["#C"] = aws.String("signature_reference_id")
+		input.ExpressionAttributeNames["#N"] = aws.String("signature_reference_name")
+		input.ExpressionAttributeNames["#L"] = aws.String("signature_reference_name_lower")
+		input.ExpressionAttributeValues[":n"] = &dynamodb.AttributeValue{S: aws.String(company.CompanyName)}
+		input.ExpressionAttributeValues[":l"] = &dynamodb.AttributeValue{S: aws.String(strings.ToLower(company.CompanyName))}
+		input.UpdateExpression = aws.String("SET #C = :c, #N = :n, #L = :l, #M = :m")
+	} else {
+		input.ExpressionAttributeNames["#C"] = aws.String("signature_user_ccla_company_id")
+		input.UpdateExpression = aws.String("SET #C = :c, #M = :m")
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.WithFields(f).Warnf("problem updating the company of the signature, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// GetSignature returns the signature, the read is consistent so the signature can be updated conditionally
+func (repo *repository) GetSignature(ctx context.Context, signatureID string) (*Signature, error) {
+	f := logrus.Fields{
+		"functionName":   "GetSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the signature, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrSignatureNotFound
+	}
+
+	var signature Signature
+	err = dynamodbattribute.UnmarshalMap(result.Item, &signature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding the signature, error: %+v", err)
+		return nil, err
+	}
+	return &signature, nil
+}
+
+// UpdateSignatureApprovalLists stores the ACL and the approval lists of the updated signature. The signature is only
+// updated if its ACL and approval lists are still the ones of the current signature, ErrSignatureChanged is returned
+// if they were changed in the meantime.
+func (repo *repository) UpdateSignatureApprovalLists(ctx context.Context, current, updated *Signature) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateSignatureApprovalLists",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    updated.SignatureID,
+	}
+
+	_, now := utils.CurrentTime()
+	expressionAttributeNames := map[string]*string{
+		"#M": aws.String("date_modified"),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":m":    {S: aws.String(now)},
+		":zero": {N: aws.String("0")},
+	}
+	updateExpression := "SET #M = :m"
+	var conditions []string
+
+	// The ACL is a string set which can't be empty, the approval lists are lists
+	for _, column := range []struct {
+		placeholder string
+		name        string
+		isSet       bool
+		current     []string
+		updated     []string
+	}{
+		{"A", "signature_acl", true, current.SignatureACL, updated.SignatureACL},
+		{"E", "email_whitelist", false, current.EmailApprovalList, updated.EmailApprovalList},
+		{"D", "domain_whitelist", false, current.DomainApprovalList, updated.DomainApprovalList},
+		{"G", "github_whitelist", false, current.GithubApprovalList, updated.GithubApprovalList},
+		{"GO", "github_org_whitelist", false, current.GithubOrgApprovalList, updated.GithubOrgApprovalList},
+	} {
+		name, value := "#"+column.placeholder, ":"+strings.ToLower(column.placeholder)
+		expressionAttributeNames[name] = aws.String(column.name)
+		if len(column.current) == 0 {
+			conditions = append(conditions, fmt.Sprintf("(attribute_not_exists(%s) OR size(%s) = :zero)", name, name))
+		} else {
+			expressionAttributeValues[value+"c"] = stringsAttributeValue(column.isSet, column.current)
+			conditions = append(conditions, fmt.Sprintf("%s = %sc", name, value))
+		}
+		if len(column.updated) > 0 {
+			expressionAttributeValues[value+"u"] = stringsAttributeValue(column.isSet, column.updated)
+			updateExpression = fmt.Sprintf("%s, %s = %su", updateExpression, name, value)
+		}
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(updated.SignatureID),
+			},
+		},
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		ConditionExpression:       aws.String(strings.Join(conditions, " AND ")),
+		UpdateExpression:          aws.String(updateExpression),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Debug("the approval lists of the signature were changed concurrently")
+			return ErrSignatureChanged
+		}
+		log.WithFields(f).Warnf("problem updating the approval lists of the signature, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// stringsAttributeValue returns the values as a string set or as a list of strings
+func stringsAttributeValue(isSet bool, values []string) *dynamodb.AttributeValue {
+	if isSet {
+		return &dynamodb.AttributeValue{SS: aws.StringSlice(values)}
+	}
+	list := make([]*dynamodb.AttributeValue, 0, len(values))
+	for _, value := range values {
+		list = append(list, &dynamodb.AttributeValue{S: aws.String(value)})
+	}
+	return &dynamodb.AttributeValue{L: list}
+}
+
+// InvalidateSignature clears the approved flag of the signature and records the reason in the signature note
+func (repo *repository) InvalidateSignature(ctx context.Context, signatureID, note string) error {
+	f := logrus.Fields{
+		"functionName":   "InvalidateSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	_, now := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#A": aws.String("signature_approved"),
+			"#N": aws.String("note"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":a": {BOOL: aws.Bool(false)},
+			":n": {S: aws.String(note)},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression: aws.String("SET #A = :a, #N = :n, #M = :m"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem invalidating the signature, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// AddCompanyManagers adds the managers to the ACL of the company, the managers added concurrently are kept
+func (repo *repository) AddCompanyManagers(ctx context.Context, companyID string, managers []string) error {
+	f := logrus.Fields{
+		"functionName":   "AddCompanyManagers",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+		"managers":       strings.Join(managers, ","),
+	}
+
+	_, now := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#A": aws.String("company_acl"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":a": {SS: aws.StringSlice(managers)},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression: aws.String("ADD #A :a SET #M = :m"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem adding the managers to the company ACL, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// GetCompanyApprovalListDomains returns the domains of the CCLA approval lists by company ID
+func (repo *repository) GetCompanyApprovalListDomains(ctx context.Context) (map[string][]string, error) {
+	f := logrus.Fields{
+		"functionName":   "GetCompanyApprovalListDomains",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	filter := expression.Name("signature_reference_type").Equal(expression.Value("company")).
+		And(expression.AttributeExists(expression.Name("domain_whitelist")))
+	projection := expression.NamesList(expression.Name("signature_reference_id"), expression.Name("domain_whitelist"))
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(projection).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("problem building the signatures scan expression, error: %+v", err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.signatureTableName),
+	}
+
+	domains := make(map[string][]string)
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.WithFields(f).Warnf("problem scanning the signatures, error: %+v", err)
+			return nil, err
+		}
+
+		var page []*Signature
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).Warnf("problem decoding the signatures, error: %+v", err)
+			return nil, err
+		}
+		for _, signature := range page {
+			domains[signature.SignatureReferenceID] = append(domains[signature.SignatureReferenceID], signature.DomainApprovalList...)
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return domains, nil
+}
+
+// GetCompanyRequests returns the requests of the type referencing the company
+func (repo *repository) GetCompanyRequests(ctx context.Context, requestType RequestType, companyID string) ([]*Request, error) {
+	f := logrus.Fields{
+		"functionName":   "GetCompanyRequests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"requestType":    requestType,
+		"companyID":      companyID,
+	}
+	table, ok := repo.requestTables[requestType]
+	if !ok {
+		return nil, fmt.Errorf("unknown request type: %s", requestType)
+	}
+
+	condition := expression.Key(table.companyColumn).Equal(expression.Value(companyID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("problem building the requests query expression, error: %+v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(table.tableName),
+		IndexName:                 aws.String(table.indexName),
+	}
+
+	var requests []*Request
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).Warnf("problem loading the requests of the company, error: %+v", err)
+			return nil, err
+		}
+
+		var items []map[string]interface{}
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &items)
+		if err != nil {
+			log.WithFields(f).Warnf("problem decoding the requests of the company, error: %+v", err)
+			return nil, err
+		}
+		for _, item := range items {
+			requests = append(requests, &Request{
+				RequestID: stringValue(item[table.keyColumn]),
+				ProjectID: stringValue(item["project_id"]),
+				UserID:    stringValue(item["user_id"]),
+				Status:    stringValue(item[table.statusColumn]),
+			})
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return requests, nil
+}
+
+// UpdateRequestCompany points the request to the company
+func (repo *repository) UpdateRequestCompany(ctx context.Context, requestType RequestType, requestID string, company *models.Company) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateRequestCompany",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"requestType":    requestType,
+		"requestID":      requestID,
+		"companyID":      company.CompanyID,
+	}
+	table, ok := repo.requestTables[requestType]
+	if !ok {
+		return fmt.Errorf("unknown request type: %s", requestType)
+	}
+
+	_, now := utils.CurrentTime()
+	expressionAttributeNames := map[string]*string{
+		"#C": aws.String(table.companyColumn),
+		"#M": aws.String("date_modified"),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":c": {S: aws.String(company.CompanyID)},
+		":m": {S: aws.String(now)},
+	}
+	updateExpression := "SET #C = :c, #M = :m"
+	if table.hasCompanyName {
+		expressionAttributeNames["#N"] = aws.String("company_name")
+		expressionAttributeValues[":n"] = &dynamodb.AttributeValue{S: aws.String(company.CompanyName)}
+		updateExpression = updateExpression + ", #N = :n"
+	}
+	// The external company id is a GSI key which can't be set to an empty string
+	if table.hasCompanySFID && company.CompanyExternalID != "" {
+		expressionAttributeNames["#E"] = aws.String("company_external_id")
+		expressionAttributeValues[":e"] = &dynamodb.AttributeValue{S: aws.String(company.CompanyExternalID)}
+		updateExpression = updateExpression + ", #E = :e"
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(table.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			table.keyColumn: {
+				S: aws.String(requestID),
+			},
+		},
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		UpdateExpression:          aws.String(updateExpression),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem updating the company of the request, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// stringValue returns the string value of a decoded attribute, empty for the other types
+func stringValue(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
@@ -0,0 +1,476 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company_merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// mergeAttempts is the number of times the approval lists of a signature are merged when the signature is changed
+// concurrently
+const mergeAttempts = 3
+
+// errors
+var (
+	ErrNoDuplicateCompanies = errors.New("no duplicate companies to merge")
+	ErrCompanyAlreadyMerged = errors.New("company was already merged into another company")
+)
+
+// CompanyRepository is the part of the company repository used when merging companies
+type CompanyRepository interface {
+	GetCompany(ctx context.Context, companyID string) (*models.Company, error)
+	GetCompanies(ctx context.Context) (*models.Companies, error)
+	UpdateCompanyExternalID(ctx context.Context, companyID, companySFID string) error
+	MarkCompanyMerged(ctx context.Context, companyID, mergedIntoCompanyID string) error
+}
+
+// DomainVerificationService is the part of the domain verification service used when merging companies
+type DomainVerificationService interface {
+	CheckApprovalListDomains(ctx context.Context, companyID string, domains []string) error
+}
+
+// Service interface defines the company merge functions
+type Service interface {
+	MergeCompanies(ctx context.Context, companyID string, duplicateCompanyIDs []string, dryRun bool) (*MergeReport, error)
+	GetDuplicateCandidates(ctx context.Context) ([]*DuplicateCandidate, error)
+}
+
+type service struct {
+	repo               Repository
+	companyRepo        CompanyRepository
+	domainVerification DomainVerificationService
+}
+
+// NewService creates a new company merge service
+func NewService(repo Repository, companyRepo CompanyRepository, domainVerification DomainVerificationService) Service {
+	return &service{
+		repo:               repo,
+		companyRepo:        companyRepo,
+		domainVerification: domainVerification,
+	}
+}
+
+// MergeCompanies moves the signatures, the CLA managers, the invites, the approval list requests and the CLA manager
+// requests of the duplicate companies to the surviving company, then turns the duplicates into tombstones redirecting
+// the lookups to the surviving company. A CCLA signature of a duplicate is merged into the signed CCLA signature the
+// surviving company already has for the same CLA group. Nothing is changed for a dry run. A failed merge can be run
+// again, the records already moved are no longer found on the duplicate.
+func (s *service) MergeCompanies(ctx context.Context, companyID string, duplicateCompanyIDs []string, dryRun bool) (*MergeReport, error) {
+	f := logrus.Fields{
+		"functionName":        "MergeCompanies",
+		utils.XREQUESTID:      ctx.Value(utils.XREQUESTID),
+		"companyID":           companyID,
+		"duplicateCompanyIDs": strings.Join(duplicateCompanyIDs, ","),
+		"dryRun":              dryRun,
+	}
+
+	survivor, err := s.loadCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var duplicates []*models.Company
+	for _, duplicateCompanyID := range utils.RemoveDuplicates(duplicateCompanyIDs) {
+		if duplicateCompanyID == companyID {
+			continue
+		}
+		duplicate, loadErr := s.loadCompany(ctx, duplicateCompanyID)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+	if len(duplicates) == 0 {
+		return nil, ErrNoDuplicateCompanies
+	}
+
+	// The CCLA signatures of the surviving company by CLA group, the signatures of the duplicates are merged into them
+	survivorSignatures, err := s.repo.GetCompanySignatures(ctx, survivor.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	corporateSignatures := make(map[string]*Signature)
+	for _, signature := range survivorSignatures {
+		if signature.isCorporate() && signature.isActive() {
+			corporateSignatures[signature.SignatureProjectID] = signature
+		}
+	}
+
+	report := &MergeReport{
+		CompanyID:         survivor.CompanyID,
+		CompanyName:       survivor.CompanyName,
+		CompanyExternalID: survivor.CompanyExternalID,
+		DryRun:            dryRun,
+	}
+	companyACL := survivor.CompanyACL
+	for _, duplicate := range duplicates {
+		log.WithFields(f).Debugf("merging company %s (%s) into company %s (%s)", duplicate.CompanyName, duplicate.CompanyID, survivor.CompanyName, survivor.CompanyID)
+		duplicateReport := &DuplicateMergeReport{
+			CompanyID:         duplicate.CompanyID,
+			CompanyName:       duplicate.CompanyName,
+			CompanyExternalID: duplicate.CompanyExternalID,
+		}
+		report.Duplicates = append(report.Duplicates, duplicateReport)
+
+		// Reconcile the SFID - the surviving company keeps its own, the tombstone keeps the SFID of the duplicate
+		if report.CompanyExternalID == "" && duplicate.CompanyExternalID != "" {
+			report.CompanyExternalID = duplicate.CompanyExternalID
+			duplicateReport.ExternalIDMoved = true
+			if !dryRun {
+				err = s.companyRepo.UpdateCompanyExternalID(ctx, survivor.CompanyID, duplicate.CompanyExternalID)
+				if err != nil {
+					return nil, err
+				}
+			}
+			survivor.CompanyExternalID = duplicate.CompanyExternalID
+		}
+
+		for _, requestType := range []RequestType{RequestTypeApprovalList, RequestTypeClaManager, RequestTypeInvite} {
+			moved, moveErr := s.moveRequests(ctx, requestType, duplicate, survivor, dryRun)
+			if moveErr != nil {
+				return nil, moveErr
+			}
+			switch requestType {
+			case RequestTypeApprovalList:
+				duplicateReport.ApprovalListRequestsMoved = moved
+			case RequestTypeClaManager:
+				duplicateReport.ClaManagerRequestsMoved = moved
+			case RequestTypeInvite:
+				duplicateReport.InvitesMoved = moved
+			}
+		}
+
+		err = s.moveSignatures(ctx, duplicate, survivor, corporateSignatures, duplicateReport, dryRun)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, manager := range duplicate.CompanyACL {
+			if !utils.StringInSlice(manager, companyACL) {
+				companyACL = append(companyACL, manager)
+				duplicateReport.CompanyACLAdded = append(duplicateReport.CompanyACLAdded, manager)
+			}
+		}
+		if !dryRun && len(duplicateReport.CompanyACLAdded) > 0 {
+			err = s.repo.AddCompanyManagers(ctx, survivor.CompanyID, duplicateReport.CompanyACLAdded)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !dryRun {
+			err = s.companyRepo.MarkCompanyMerged(ctx, duplicate.CompanyID, survivor.CompanyID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// loadCompany returns the company, an error if the company was already merged into another company
+func (s *service) loadCompany(ctx context.Context, companyID string) (*models.Company, error) {
+	companyModel, err := s.companyRepo.GetCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	// The lookup of a merged company returns the surviving company
+	if companyModel.CompanyID != companyID {
+		return nil, fmt.Errorf("%s : %w", companyID, ErrCompanyAlreadyMerged)
+	}
+	return companyModel, nil
+}
+
+// moveRequests points the requests of the duplicate company to the surviving company, returns the moved request ids
+func (s *service) moveRequests(ctx context.Context, requestType RequestType, duplicate, survivor *models.Company, dryRun bool) ([]string, error) {
+	requests, err := s.repo.GetCompanyRequests(ctx, requestType, duplicate.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	var moved []string
+	for _, request := range requests {
+		if !dryRun {
+			err = s.repo.UpdateRequestCompany(ctx, requestType, request.RequestID, survivor)
+			if err != nil {
+				return nil, err
+			}
+		}
+		moved = append(moved, request.RequestID)
+	}
+	return moved, nil
+}
+
+// moveSignatures moves the signatures of the duplicate company to the surviving company - the CCLA signatures are
+// merged into the signed CCLA signature of the surviving company for the same CLA group if there is one
+func (s *service) moveSignatures(ctx context.Context, duplicate, survivor *models.Company, corporateSignatures map[string]*Signature, report *DuplicateMergeReport, dryRun bool) error {
+	signatures, err := s.repo.GetCompanySignatures(ctx, duplicate.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	for _, signature := range signatures {
+		// Only an active CCLA signature is merged, a signature which was never signed or was invalidated is moved as
+		// it is and its approval lists are not carried over
+		target, ok := corporateSignatures[signature.SignatureProjectID]
+		if !signature.isCorporate() || !signature.isActive() || !ok {
+			if !dryRun {
+				err = s.repo.UpdateSignatureCompany(ctx, signature, survivor)
+				if err != nil {
+					return err
+				}
+			}
+			if signature.isCorporate() && signature.isActive() {
+				corporateSignatures[signature.SignatureProjectID] = signature
+			}
+			report.SignaturesMoved = append(report.SignaturesMoved, signature.SignatureID)
+			continue
+		}
+
+		rejectedDomains := s.rejectedDomains(ctx, survivor.CompanyID, target, signature)
+		for _, domain := range signature.DomainApprovalList {
+			if rejectedDomains[domain] {
+				report.ApprovalListDomainsRejected = append(report.ApprovalListDomainsRejected, &ApprovalListEntry{
+					ClaGroupID: target.SignatureProjectID,
+					ListType:   approval_list_expiry.ListTypeDomain,
+					Value:      domain,
+				})
+			}
+		}
+		if dryRun {
+			for _, entry := range mergeApprovalLists(target, signature, rejectedDomains) {
+				entry.ClaGroupID = target.SignatureProjectID
+				report.ApprovalListEntriesAdded = append(report.ApprovalListEntriesAdded, entry)
+			}
+		} else {
+			added, mergeErr := s.mergeSignature(ctx, target, signature, rejectedDomains)
+			if mergeErr != nil {
+				return mergeErr
+			}
+			for _, entry := range added {
+				entry.ClaGroupID = target.SignatureProjectID
+				report.ApprovalListEntriesAdded = append(report.ApprovalListEntriesAdded, entry)
+			}
+			if signature.SignatureApproved {
+				err = s.repo.InvalidateSignature(ctx, signature.SignatureID, fmt.Sprintf("merged into signature %s of company %s", target.SignatureID, survivor.CompanyID))
+				if err != nil {
+					return err
+				}
+			}
+		}
+		report.SignaturesMerged = append(report.SignaturesMerged, signature.SignatureID)
+	}
+	return nil
+}
+
+// mergeSignature merges the CLA managers and the approval lists of the signature into the target signature and stores
+// the target signature, returns the approval list entries added. The target signature is only updated if it was not
+// changed since it was read, the merge is tried again with the current target signature otherwise.
+func (s *service) mergeSignature(ctx context.Context, target, signature *Signature, rejectedDomains map[string]bool) ([]*ApprovalListEntry, error) {
+	f := logrus.Fields{
+		"functionName":      "mergeSignature",
+		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
+		"signatureID":       signature.SignatureID,
+		"targetSignatureID": target.SignatureID,
+	}
+
+	current := target
+	for attempt := 1; ; attempt++ {
+		updated := current.clone()
+		added := mergeApprovalLists(updated, signature, rejectedDomains)
+		err := s.repo.UpdateSignatureApprovalLists(ctx, current, updated)
+		if err == nil {
+			// The signatures of the other duplicates are merged into the stored target signature
+			*target = *updated
+			return added, nil
+		}
+		if err != ErrSignatureChanged || attempt == mergeAttempts {
+			return nil, err
+		}
+		log.WithFields(f).Debugf("the target signature was changed during the merge, attempt %d of %d", attempt, mergeAttempts)
+		current, err = s.repo.GetSignature(ctx, target.SignatureID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rejectedDomains returns the domains of the signature the surviving company may not add to the approval list of the
+// target signature - the domains of a duplicate are not necessarily verified for the surviving company. The domains
+// are only checked one by one to find the rejected domains if the check of all domains fails.
+func (s *service) rejectedDomains(ctx context.Context, companyID string, target, signature *Signature) map[string]bool {
+	f := logrus.Fields{
+		"functionName":   "rejectedDomains",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+		"signatureID":    signature.SignatureID,
+	}
+
+	var domains []string
+	for _, domain := range signature.DomainApprovalList {
+		if !utils.StringInSlice(domain, target.DomainApprovalList) {
+			domains = append(domains, domain)
+		}
+	}
+	rejected := make(map[string]bool)
+	if len(domains) == 0 || s.domainVerification.CheckApprovalListDomains(ctx, companyID, domains) == nil {
+		return rejected
+	}
+	for _, domain := range domains {
+		if err := s.domainVerification.CheckApprovalListDomains(ctx, companyID, []string{domain}); err != nil {
+			log.WithFields(f).Debugf("the domain %s is not merged into the approval list, error: %+v", domain, err)
+			rejected[domain] = true
+		}
+	}
+	return rejected
+}
+
+// mergeApprovalLists adds the CLA managers and the approval list entries of the signature to the target signature,
+// returns the approval list entries the target signature did not have yet. The rejected domains are not added.
+func mergeApprovalLists(target, signature *Signature, rejectedDomains map[string]bool) []*ApprovalListEntry {
+	target.SignatureACL = utils.AppendMissing(target.SignatureACL, signature.SignatureACL)
+
+	var added []*ApprovalListEntry
+	for _, list := range []struct {
+		listType approval_list_expiry.ListType
+		target   *[]string
+		values   []string
+	}{
+		{approval_list_expiry.ListTypeEmail, &target.EmailApprovalList, signature.EmailApprovalList},
+		{approval_list_expiry.ListTypeDomain, &target.DomainApprovalList, signature.DomainApprovalList},
+		{approval_list_expiry.ListTypeGithubUsername, &target.GithubApprovalList, signature.GithubApprovalList},
+		{approval_list_expiry.ListTypeGithubOrg, &target.GithubOrgApprovalList, signature.GithubOrgApprovalList},
+	} {
+		for _, value := range list.values {
+			if list.listType == approval_list_expiry.ListTypeDomain && rejectedDomains[value] {
+				continue
+			}
+			if !utils.StringInSlice(value, *list.target) {
+				*list.target = append(*list.target, value)
+				added = append(added, &ApprovalListEntry{ListType: list.listType, Value: value})
+			}
+		}
+	}
+	return added
+}
+
+var (
+	nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+	// legalSuffixes are dropped from the end of the company names before comparing them
+	legalSuffixes = map[string]bool{
+		"inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true, "corp": true, "corporation": true,
+		"co": true, "company": true, "gmbh": true, "ag": true, "sa": true, "bv": true, "plc": true, "oy": true, "ab": true,
+	}
+)
+
+// normalizeCompanyName returns the company name without case, punctuation and legal suffixes, "ACME, Inc." and
+// "Acme Inc" are both "acme"
+func normalizeCompanyName(companyName string) string {
+	words := strings.Fields(nonAlphanumeric.ReplaceAllString(strings.ToLower(companyName), " "))
+	if len(words) > 0 && words[0] == "the" {
+		words = words[1:]
+	}
+	for len(words) > 1 && legalSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, "")
+}
+
+// normalizeDomain returns the domain of an approval list entry without the wildcard prefix
+func normalizeDomain(domain string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "*"), ".")
+}
+
+// GetDuplicateCandidates returns the groups of companies with the same normalized name or sharing a domain of their
+// CCLA approval lists
+func (s *service) GetDuplicateCandidates(ctx context.Context) ([]*DuplicateCandidate, error) {
+	f := logrus.Fields{
+		"functionName":   "GetDuplicateCandidates",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	companies, err := s.companyRepo.GetCompanies(ctx)
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the companies, error: %+v", err)
+		return nil, err
+	}
+	domains, err := s.repo.GetCompanyApprovalListDomains(ctx)
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the approval list domains, error: %+v", err)
+		return nil, err
+	}
+
+	// Group the companies sharing a normalized name or an approval list domain
+	duplicateGroups := utils.NewDuplicateGroups()
+	companyByID := make(map[string]models.Company)
+	for _, companyModel := range companies.Companies {
+		companyByID[companyModel.CompanyID] = companyModel
+		var keys []string
+		if name := normalizeCompanyName(companyModel.CompanyName); name != "" {
+			keys = append(keys, "name:"+name)
+		}
+		for _, domain := range domains[companyModel.CompanyID] {
+			if domain = normalizeDomain(domain); domain != "" {
+				keys = append(keys, "domain:"+domain)
+			}
+		}
+		duplicateGroups.Add(companyModel.CompanyID, keys...)
+	}
+	companyGroups, reasons := duplicateGroups.Groups()
+
+	var candidates []*DuplicateCandidate
+	for index, companyIDs := range companyGroups {
+		group := make([]models.Company, 0, len(companyIDs))
+		for _, companyID := range companyIDs {
+			group = append(group, companyByID[companyID])
+		}
+		sort.Slice(group, func(i, j int) bool {
+			return isPreferredSurvivor(&group[i], &group[j])
+		})
+		candidate := &DuplicateCandidate{
+			SuggestedCompanyID: group[0].CompanyID,
+			Reasons:            reasons[index],
+		}
+		for _, companyModel := range group {
+			candidate.Companies = append(candidate.Companies, DuplicateCompany{
+				CompanyID:         companyModel.CompanyID,
+				CompanyName:       companyModel.CompanyName,
+				CompanyExternalID: companyModel.CompanyExternalID,
+			})
+		}
+		sort.Strings(candidate.Reasons)
+		candidates = append(candidates, candidate)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Companies[0].CompanyName < candidates[j].Companies[0].CompanyName
+	})
+	return candidates, nil
+}
+
+// isPreferredSurvivor returns true if the first company should survive the merge rather than the second one - the
+// companies with an SFID first, then the oldest company
+func isPreferredSurvivor(first, second *models.Company) bool {
+	if (first.CompanyExternalID != "") != (second.CompanyExternalID != "") {
+		return first.CompanyExternalID != ""
+	}
+	firstCreated, secondCreated := time.Time(first.Created), time.Time(second.Created)
+	if !firstCreated.Equal(secondCreated) {
+		return firstCreated.Before(secondCreated)
+	}
+	return first.CompanyID < second.CompanyID
+}
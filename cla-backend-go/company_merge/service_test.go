@@ -0,0 +1,277 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company_merge_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	"github.com/communitybridge/easycla/cla-backend-go/company_merge"
+	"github.com/communitybridge/easycla/cla-backend-go/company_merge/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	survivorCompany  = &models.Company{CompanyID: "survivor", CompanyName: "Acme Inc", CompanyACL: []string{"manager-1"}}
+	duplicateCompany = &models.Company{CompanyID: "duplicate", CompanyName: "ACME, Inc.", CompanyACL: []string{"manager-1", "manager-2"}, CompanyExternalID: "sfid"}
+)
+
+// mergeFixtures sets up the surviving company with a CCLA for cla-group-1 and a duplicate company with CCLAs for
+// cla-group-1 and cla-group-2, an employee acknowledgement and one request of each type
+func mergeFixtures(repo *mock.MockRepository, companyRepo *mock.MockCompanyRepository, duplicateSignatures ...*company_merge.Signature) {
+	survivor, duplicate := *survivorCompany, *duplicateCompany
+	companyRepo.EXPECT().GetCompany(gomock.Any(), "survivor").Return(&survivor, nil)
+	companyRepo.EXPECT().GetCompany(gomock.Any(), "duplicate").Return(&duplicate, nil)
+
+	repo.EXPECT().GetCompanySignatures(gomock.Any(), "survivor").Return([]*company_merge.Signature{
+		{SignatureID: "survivor-ccla", SignatureProjectID: "cla-group-1", SignatureReferenceType: "company", SignatureSigned: true, SignatureApproved: true,
+			SignatureACL: []string{"manager-1"}, EmailApprovalList: []string{"a@acme.com"}},
+	}, nil)
+	repo.EXPECT().GetCompanySignatures(gomock.Any(), "duplicate").Return(append([]*company_merge.Signature{
+		{SignatureID: "duplicate-ccla-1", SignatureProjectID: "cla-group-1", SignatureReferenceType: "company", SignatureSigned: true, SignatureApproved: true,
+			SignatureACL: []string{"manager-2"}, EmailApprovalList: []string{"a@acme.com", "b@acme.com"}, DomainApprovalList: []string{"acme.com"}},
+		{SignatureID: "duplicate-ccla-2", SignatureProjectID: "cla-group-2", SignatureReferenceType: "company", SignatureSigned: true, SignatureApproved: true},
+		{SignatureID: "duplicate-ecla", SignatureProjectID: "cla-group-1", SignatureReferenceType: "user", SignatureUserCompanyID: "duplicate"},
+	}, duplicateSignatures...), nil)
+
+	repo.EXPECT().GetCompanyRequests(gomock.Any(), company_merge.RequestTypeApprovalList, "duplicate").Return([]*company_merge.Request{{RequestID: "approval-list-request"}}, nil)
+	repo.EXPECT().GetCompanyRequests(gomock.Any(), company_merge.RequestTypeClaManager, "duplicate").Return([]*company_merge.Request{{RequestID: "cla-manager-request"}}, nil)
+	repo.EXPECT().GetCompanyRequests(gomock.Any(), company_merge.RequestTypeInvite, "duplicate").Return([]*company_merge.Request{{RequestID: "invite"}}, nil)
+}
+
+func TestMergeCompaniesDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	domainVerification := mock.NewMockDomainVerificationService(ctrl)
+	// nothing is changed for a dry run - any update is an unexpected call
+	mergeFixtures(repo, companyRepo)
+	domainVerification.EXPECT().CheckApprovalListDomains(gomock.Any(), "survivor", []string{"acme.com"}).Return(nil)
+
+	report, err := company_merge.NewService(repo, companyRepo, domainVerification).MergeCompanies(context.Background(), "survivor", []string{"duplicate", "duplicate"}, true)
+	assert.Nil(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, "sfid", report.CompanyExternalID)
+	assert.Len(t, report.Duplicates, 1)
+	duplicate := report.Duplicates[0]
+	assert.True(t, duplicate.ExternalIDMoved)
+	assert.Equal(t, []string{"manager-2"}, duplicate.CompanyACLAdded)
+	assert.Equal(t, []string{"duplicate-ccla-2", "duplicate-ecla"}, duplicate.SignaturesMoved)
+	assert.Equal(t, []string{"duplicate-ccla-1"}, duplicate.SignaturesMerged)
+	assert.Equal(t, []string{"approval-list-request"}, duplicate.ApprovalListRequestsMoved)
+	assert.Equal(t, []string{"cla-manager-request"}, duplicate.ClaManagerRequestsMoved)
+	assert.Equal(t, []string{"invite"}, duplicate.InvitesMoved)
+	assert.Equal(t, []*company_merge.ApprovalListEntry{
+		{ClaGroupID: "cla-group-1", ListType: approval_list_expiry.ListTypeEmail, Value: "b@acme.com"},
+		{ClaGroupID: "cla-group-1", ListType: approval_list_expiry.ListTypeDomain, Value: "acme.com"},
+	}, duplicate.ApprovalListEntriesAdded)
+}
+
+func TestMergeCompanies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	domainVerification := mock.NewMockDomainVerificationService(ctrl)
+	mergeFixtures(repo, companyRepo)
+	domainVerification.EXPECT().CheckApprovalListDomains(gomock.Any(), "survivor", []string{"acme.com"}).Return(nil)
+
+	var merged *company_merge.Signature
+	companyRepo.EXPECT().UpdateCompanyExternalID(gomock.Any(), "survivor", "sfid").Return(nil)
+	for _, requestID := range []string{"approval-list-request", "cla-manager-request", "invite"} {
+		repo.EXPECT().UpdateRequestCompany(gomock.Any(), gomock.Any(), requestID, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, requestType company_merge.RequestType, requestID string, company *models.Company) error {
+				assert.Equal(t, "survivor", company.CompanyID)
+				return nil
+			})
+	}
+	for _, signatureID := range []string{"duplicate-ccla-2", "duplicate-ecla"} {
+		signatureID := signatureID
+		repo.EXPECT().UpdateSignatureCompany(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, signature *company_merge.Signature, company *models.Company) error {
+				assert.Equal(t, signatureID, signature.SignatureID)
+				assert.Equal(t, "survivor", company.CompanyID)
+				return nil
+			})
+	}
+	repo.EXPECT().UpdateSignatureApprovalLists(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, current, updated *company_merge.Signature) error {
+			// the update is conditional on the approval lists read
+			assert.Equal(t, []string{"a@acme.com"}, current.EmailApprovalList)
+			merged = updated
+			return nil
+		})
+	repo.EXPECT().InvalidateSignature(gomock.Any(), "duplicate-ccla-1", gomock.Any()).Return(nil)
+	repo.EXPECT().AddCompanyManagers(gomock.Any(), "survivor", []string{"manager-2"}).Return(nil)
+	companyRepo.EXPECT().MarkCompanyMerged(gomock.Any(), "duplicate", "survivor").Return(nil)
+
+	_, err := company_merge.NewService(repo, companyRepo, domainVerification).MergeCompanies(context.Background(), "survivor", []string{"duplicate"}, false)
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, merged) {
+		assert.Equal(t, "survivor-ccla", merged.SignatureID)
+		assert.Equal(t, []string{"manager-1", "manager-2"}, merged.SignatureACL)
+		assert.Equal(t, []string{"a@acme.com", "b@acme.com"}, merged.EmailApprovalList)
+		assert.Equal(t, []string{"acme.com"}, merged.DomainApprovalList)
+	}
+}
+
+func TestMergeCompaniesInactiveSignature(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	domainVerification := mock.NewMockDomainVerificationService(ctrl)
+	mergeFixtures(repo, companyRepo,
+		&company_merge.Signature{SignatureID: "duplicate-ccla-revoked", SignatureProjectID: "cla-group-1", SignatureReferenceType: "company", SignatureSigned: true,
+			EmailApprovalList: []string{"revoked@acme.com"}})
+	domainVerification.EXPECT().CheckApprovalListDomains(gomock.Any(), "survivor", []string{"acme.com"}).Return(nil)
+
+	report, err := company_merge.NewService(repo, companyRepo, domainVerification).MergeCompanies(context.Background(), "survivor", []string{"duplicate"}, true)
+	assert.Nil(t, err)
+
+	// the revoked CCLA is moved as it is, its approval list is not merged into the active CCLA
+	duplicate := report.Duplicates[0]
+	assert.Equal(t, []string{"duplicate-ccla-2", "duplicate-ecla", "duplicate-ccla-revoked"}, duplicate.SignaturesMoved)
+	assert.Equal(t, []string{"duplicate-ccla-1"}, duplicate.SignaturesMerged)
+	for _, entry := range duplicate.ApprovalListEntriesAdded {
+		assert.NotEqual(t, "revoked@acme.com", entry.Value)
+	}
+}
+
+func TestMergeCompaniesSignatureChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	domainVerification := mock.NewMockDomainVerificationService(ctrl)
+	mergeFixtures(repo, companyRepo)
+	domainVerification.EXPECT().CheckApprovalListDomains(gomock.Any(), "survivor", []string{"acme.com"}).Return(nil)
+
+	companyRepo.EXPECT().UpdateCompanyExternalID(gomock.Any(), "survivor", "sfid").Return(nil)
+	repo.EXPECT().UpdateRequestCompany(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(3)
+	repo.EXPECT().UpdateSignatureCompany(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	// a CLA manager approves another email while the companies are merged
+	var merged *company_merge.Signature
+	gomock.InOrder(
+		repo.EXPECT().UpdateSignatureApprovalLists(gomock.Any(), gomock.Any(), gomock.Any()).Return(company_merge.ErrSignatureChanged),
+		repo.EXPECT().GetSignature(gomock.Any(), "survivor-ccla").Return(&company_merge.Signature{
+			SignatureID: "survivor-ccla", SignatureProjectID: "cla-group-1", SignatureReferenceType: "company", SignatureSigned: true, SignatureApproved: true,
+			SignatureACL: []string{"manager-1"}, EmailApprovalList: []string{"a@acme.com", "c@acme.com"},
+		}, nil),
+		repo.EXPECT().UpdateSignatureApprovalLists(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, current, updated *company_merge.Signature) error {
+				assert.Equal(t, []string{"a@acme.com", "c@acme.com"}, current.EmailApprovalList)
+				merged = updated
+				return nil
+			}),
+	)
+	repo.EXPECT().InvalidateSignature(gomock.Any(), "duplicate-ccla-1", gomock.Any()).Return(nil)
+	repo.EXPECT().AddCompanyManagers(gomock.Any(), "survivor", []string{"manager-2"}).Return(nil)
+	companyRepo.EXPECT().MarkCompanyMerged(gomock.Any(), "duplicate", "survivor").Return(nil)
+
+	_, err := company_merge.NewService(repo, companyRepo, domainVerification).MergeCompanies(context.Background(), "survivor", []string{"duplicate"}, false)
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, merged) {
+		assert.Equal(t, []string{"a@acme.com", "c@acme.com", "b@acme.com"}, merged.EmailApprovalList)
+		assert.Equal(t, []string{"acme.com"}, merged.DomainApprovalList)
+	}
+}
+
+func TestMergeCompaniesRejectedDomain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	domainVerification := mock.NewMockDomainVerificationService(ctrl)
+	mergeFixtures(repo, companyRepo,
+		&company_merge.Signature{SignatureID: "duplicate-ccla-3", SignatureProjectID: "cla-group-1", SignatureReferenceType: "company", SignatureSigned: true, SignatureApproved: true,
+			DomainApprovalList: []string{"acme.com", "acme.org"}})
+	domainVerification.EXPECT().CheckApprovalListDomains(gomock.Any(), "survivor", []string{"acme.com"}).Return(nil)
+	// the domain of the duplicate is not verified for the surviving company
+	domainVerification.EXPECT().CheckApprovalListDomains(gomock.Any(), "survivor", []string{"acme.org"}).Return(errors.New("domain not verified")).Times(2)
+
+	report, err := company_merge.NewService(repo, companyRepo, domainVerification).MergeCompanies(context.Background(), "survivor", []string{"duplicate"}, true)
+	assert.Nil(t, err)
+
+	duplicate := report.Duplicates[0]
+	assert.Equal(t, []*company_merge.ApprovalListEntry{
+		{ClaGroupID: "cla-group-1", ListType: approval_list_expiry.ListTypeDomain, Value: "acme.org"},
+	}, duplicate.ApprovalListDomainsRejected)
+	for _, entry := range duplicate.ApprovalListEntriesAdded {
+		assert.NotEqual(t, "acme.org", entry.Value)
+	}
+	assert.Equal(t, []string{"acme.org"}, report.ToModel().Duplicates[0].ApprovalListDomainsRejected)
+}
+
+func TestMergeCompaniesAlreadyMerged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	domainVerification := mock.NewMockDomainVerificationService(ctrl)
+	// the lookup of a merged company returns the surviving company
+	companyRepo.EXPECT().GetCompany(gomock.Any(), "survivor").Return(survivorCompany, nil)
+	companyRepo.EXPECT().GetCompany(gomock.Any(), "duplicate").Return(survivorCompany, nil)
+
+	_, err := company_merge.NewService(repo, companyRepo, domainVerification).MergeCompanies(context.Background(), "survivor", []string{"duplicate"}, false)
+	assert.True(t, errors.Is(err, company_merge.ErrCompanyAlreadyMerged))
+}
+
+func TestMergeCompaniesWithoutDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	domainVerification := mock.NewMockDomainVerificationService(ctrl)
+	companyRepo.EXPECT().GetCompany(gomock.Any(), "survivor").Return(survivorCompany, nil)
+
+	_, err := company_merge.NewService(repo, companyRepo, domainVerification).MergeCompanies(context.Background(), "survivor", []string{"survivor"}, false)
+	assert.Equal(t, company_merge.ErrNoDuplicateCompanies, err)
+}
+
+func TestNormalizeCompanyName(t *testing.T) {
+	assert.Equal(t, "acme", company_merge.NormalizeCompanyName("Acme Inc"))
+	assert.Equal(t, "acme", company_merge.NormalizeCompanyName("ACME, Inc."))
+	assert.Equal(t, "acmesoftware", company_merge.NormalizeCompanyName("The ACME Software Co. Ltd"))
+	assert.Equal(t, "company", company_merge.NormalizeCompanyName("Company"))
+	assert.Equal(t, "", company_merge.NormalizeCompanyName(" - "))
+}
+
+func TestGetDuplicateCandidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	domainVerification := mock.NewMockDomainVerificationService(ctrl)
+
+	created := strfmt.DateTime(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	repo.EXPECT().GetCompanyApprovalListDomains(gomock.Any()).Return(map[string][]string{
+		"acme-labs": {"*.acme.com"},
+		"acme-2":    {"acme.com"},
+	}, nil)
+	companyRepo.EXPECT().GetCompanies(gomock.Any()).Return(&models.Companies{Companies: []models.Company{
+		{CompanyID: "acme-1", CompanyName: "Acme Inc", Created: strfmt.DateTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))},
+		{CompanyID: "acme-2", CompanyName: "ACME, Inc.", Created: created},
+		{CompanyID: "acme-labs", CompanyName: "Acme Labs", CompanyExternalID: "sfid", Created: created},
+		{CompanyID: "globex", CompanyName: "Globex"},
+	}}, nil)
+
+	candidates, err := company_merge.NewService(repo, companyRepo, domainVerification).GetDuplicateCandidates(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, "acme-labs", candidates[0].SuggestedCompanyID)
+	assert.Len(t, candidates[0].Companies, 3)
+	assert.Equal(t, "acme-2", candidates[0].Companies[1].CompanyID)
+	assert.Equal(t, []string{"domain:acme.com", "name:acme"}, candidates[0].Reasons)
+}
@@ -6,12 +6,18 @@ package config
 import (
 	"errors"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
 )
 
-var easyCLAConfig Config
+var (
+	easyCLAConfig Config
+	configLock    sync.RWMutex
+)
 
 // Config data model
 type Config struct {
@@ -50,8 +56,9 @@ type Config struct {
 	AllowedOriginsCommaSeparated string   `json:"allowedOriginsCommaSeparated"`
 	AllowedOrigins               []string `json:"-"`
 
-	CorporateConsoleURL   string `json:"corporateConsoleURL"`
-	CorporateConsoleV2URL string `json:"corporateConsoleV2URL"`
+	CorporateConsoleURL     string `json:"corporateConsoleURL"`
+	CorporateConsoleV2URL   string `json:"corporateConsoleV2URL"`
+	ContributorConsoleV2URL string `json:"contributorConsoleV2URL"`
 
 	// SNSEventTopic the topic ARN for events
 	SNSEventTopicARN string `json:"snsEventTopicARN"`
@@ -73,6 +80,14 @@ type Config struct {
 
 	// MetricsReport has the transport config to send the metrics data
 	MetricsReport MetricsReport `json:"metrics_report"`
+
+	// DeniedDomains are the public webmail domains which can't be added to a company approval list
+	DeniedDomainsCommaSeparated string   `json:"deniedDomainsCommaSeparated"`
+	DeniedDomains               []string `json:"-"`
+
+	// ApprovalListExpiryWarningDays is how many days before a time-bound approval list entry expires the CLA
+	// managers are warned - zero disables the warnings
+	ApprovalListExpiryWarningDays int `json:"approvalListExpiryWarningDays"`
 }
 
 // Auth0 model
@@ -128,33 +143,131 @@ type MetricsReport struct {
 
 // GetConfig returns the current EasyCLA configuration
 func GetConfig() Config {
+	configLock.RLock()
+	defer configLock.RUnlock()
 	return easyCLAConfig
 }
 
-// LoadConfig loads the configuration
-func LoadConfig(configFilePath string, awsSession *session.Session, awsStage string) (Config, error) {
+// setConfig replaces the current EasyCLA configuration
+func setConfig(c Config) {
+	configLock.Lock()
+	defer configLock.Unlock()
+	easyCLAConfig = c
+}
+
+// ResolveConfig loads the configuration from the local file or SSM, applies the defaults and environment variable
+// overrides and validates the result. Unlike LoadConfig, the current configuration is not replaced.
+func ResolveConfig(configFilePath string, awsSession *session.Session, awsStage string) (Config, error) {
+	var resolved Config
 	var err error
 
 	if configFilePath != "" {
 		// Read from local env.jso
 		log.Info("Loading local config...")
-		easyCLAConfig, err = loadLocalConfig(configFilePath)
-
+		resolved, err = loadLocalConfig(configFilePath)
 	} else if awsSession != nil {
 		// Read from SSM
 		log.Info("Loading SSM config...")
-		easyCLAConfig = loadSSMConfig(awsSession, awsStage)
-
+		resolved, err = loadSSMConfig(awsSession, awsStage)
 	} else {
 		return Config{}, errors.New("config not found")
 	}
 
 	if err != nil {
-		return Config{}, err
+		return resolved, err
+	}
+
+	if err = applyEnvOverrides(&resolved); err != nil {
+		return resolved, err
 	}
 
 	// Convert the allowed origins into an array of values
-	easyCLAConfig.AllowedOrigins = strings.Split(easyCLAConfig.AllowedOriginsCommaSeparated, ",")
+	resolved.AllowedOrigins = splitAllowedOrigins(resolved.AllowedOriginsCommaSeparated)
+	resolved.DeniedDomains = splitDomains(resolved.DeniedDomainsCommaSeparated)
+
+	return resolved, resolved.Validate()
+}
+
+// LoadConfig loads, validates and stores the configuration
+func LoadConfig(configFilePath string, awsSession *session.Session, awsStage string) (Config, error) {
+	resolved, err := ResolveConfig(configFilePath, awsSession, awsStage)
+	if err != nil {
+		return Config{}, err
+	}
 
-	return easyCLAConfig, nil
+	setConfig(resolved)
+	return resolved, nil
+}
+
+// Reload re-reads the configuration sources and applies the values which are marked as reloadable. Secrets and
+// values which are captured when the service starts are never changed by a reload.
+func Reload(configFilePath string, awsSession *session.Session, awsStage string) error {
+	f := logrus.Fields{
+		"functionName": "Reload",
+		"stage":        awsStage,
+	}
+
+	fresh, err := ResolveConfig(configFilePath, awsSession, awsStage)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to reload configuration - keeping the current values")
+		return err
+	}
+
+	configLock.Lock()
+	defer configLock.Unlock()
+	for _, field := range Fields {
+		if !field.Reloadable || field.Secret {
+			continue
+		}
+		newValue := field.get(&fresh)
+		if newValue == field.get(&easyCLAConfig) {
+			continue
+		}
+		if err := field.set(&easyCLAConfig, newValue); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to reload configuration value: %s", field.Name)
+			continue
+		}
+		log.WithFields(f).Infof("reloaded configuration value: %s = %s", field.Name, field.DisplayValue(&easyCLAConfig))
+	}
+	easyCLAConfig.AllowedOrigins = splitAllowedOrigins(easyCLAConfig.AllowedOriginsCommaSeparated)
+	easyCLAConfig.DeniedDomains = splitDomains(easyCLAConfig.DeniedDomainsCommaSeparated)
+
+	return nil
+}
+
+// StartReloader periodically reloads the configuration until the returned stop function is invoked
+func StartReloader(configFilePath string, awsSession *session.Session, awsStage string, interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				_ = Reload(configFilePath, awsSession, awsStage) // nolint - errors are logged by Reload
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+func splitAllowedOrigins(allowedOriginsCommaSeparated string) []string {
+	var allowedOrigins []string
+	for _, origin := range strings.Split(allowedOriginsCommaSeparated, ",") {
+		if trimmed := strings.TrimSpace(origin); trimmed != "" {
+			allowedOrigins = append(allowedOrigins, trimmed)
+		}
+	}
+	return allowedOrigins
+}
+
+func splitDomains(domainsCommaSeparated string) []string {
+	var domains []string
+	for _, domain := range splitAllowedOrigins(domainsCommaSeparated) {
+		domains = append(domains, strings.ToLower(domain))
+	}
+	return domains
 }
@@ -15,7 +15,12 @@ func loadLocalConfig(configFilePath string) (Config, error) {
 		return Config{}, err
 	}
 
+	// The defaults are set first, only the values present in the file replace them
 	localConfig := Config{}
+	err = applyDefaults(&localConfig, nil)
+	if err != nil {
+		return Config{}, err
+	}
 	err = json.Unmarshal(configData, &localConfig)
 	if err != nil {
 		return Config{}, err
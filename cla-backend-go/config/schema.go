@@ -0,0 +1,283 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const redactedValue = "********"
+
+// Field describes a single configuration value - where it is loaded from, how it is validated and whether it may
+// be reloaded while the service is running
+type Field struct {
+	// Name is the SSM parameter name without the stage suffix, e.g. cla-auth0-domain
+	Name string
+	// Required values must be non-empty once all the configuration sources have been applied
+	Required bool
+	// Secret values are redacted whenever the configuration is printed or logged
+	Secret bool
+	// Reloadable values are refreshed by the reloader without restarting the server
+	Reloadable bool
+	// Default is used when none of the configuration sources provide a value
+	Default string
+
+	get func(c *Config) string
+	set func(c *Config, value string) error
+}
+
+// SSMKey returns the SSM parameter name for the specified stage
+func (f Field) SSMKey(stage string) string {
+	return fmt.Sprintf("%s-%s", f.Name, stage)
+}
+
+// EnvKey returns the environment variable name which overrides this value, e.g. cla-auth0-domain => CLA_AUTH0_DOMAIN
+func (f Field) EnvKey() string {
+	return strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+}
+
+// Value returns the current value of the field from the specified configuration
+func (f Field) Value(c *Config) string {
+	return f.get(c)
+}
+
+// DisplayValue returns the value of the field suitable for printing - secrets are redacted
+func (f Field) DisplayValue(c *Config) string {
+	value := f.get(c)
+	if f.Secret && value != "" {
+		return redactedValue
+	}
+	return value
+}
+
+func stringField(name string, required, secret bool, target func(c *Config) *string) Field {
+	return Field{
+		Name:     name,
+		Required: required,
+		Secret:   secret,
+		get:      func(c *Config) string { return *target(c) },
+		set: func(c *Config, value string) error {
+			*target(c) = value
+			return nil
+		},
+	}
+}
+
+func boolField(name string, defaultValue bool, target func(c *Config) *bool) Field {
+	return Field{
+		Name:    name,
+		Default: strconv.FormatBool(defaultValue),
+		get:     func(c *Config) string { return strconv.FormatBool(*target(c)) },
+		set: func(c *Config, value string) error {
+			boolVal, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%s must be a boolean value, got: %s", name, value)
+			}
+			*target(c) = boolVal
+			return nil
+		},
+	}
+}
+
+func intField(name string, defaultValue int, target func(c *Config) *int) Field {
+	return Field{
+		Name:    name,
+		Default: strconv.Itoa(defaultValue),
+		get:     func(c *Config) string { return strconv.Itoa(*target(c)) },
+		set: func(c *Config, value string) error {
+			intVal, err := strconv.Atoi(value)
+			if err != nil || intVal < 0 {
+				return fmt.Errorf("%s must be a non-negative integer value, got: %s", name, value)
+			}
+			*target(c) = intVal
+			return nil
+		},
+	}
+}
+
+func reloadable(f Field) Field {
+	f.Reloadable = true
+	return f
+}
+
+func withDefault(f Field, defaultValue string) Field {
+	f.Default = defaultValue
+	return f
+}
+
+// defaultDeniedDomains are the public webmail domains which are never owned by a company
+const defaultDeniedDomains = "gmail.com,googlemail.com,yahoo.com,ymail.com,hotmail.com,outlook.com,live.com,msn.com," +
+	"aol.com,icloud.com,me.com,mac.com,protonmail.com,proton.me,gmx.com,gmx.net,mail.com,yandex.com,yandex.ru," +
+	"zoho.com,qq.com,163.com,126.com"
+
+// Fields is the configuration schema - every configuration value the service understands
+var Fields = []Field{
+	stringField("cla-auth0-domain", true, false, func(c *Config) *string { return &c.Auth0.Domain }),
+	stringField("cla-auth0-clientId", true, false, func(c *Config) *string { return &c.Auth0.ClientID }),
+	stringField("cla-auth0-username-claim", true, false, func(c *Config) *string { return &c.Auth0.UsernameClaim }),
+	stringField("cla-auth0-algorithm", true, false, func(c *Config) *string { return &c.Auth0.Algorithm }),
+	stringField("cla-gh-oauth-client-id-go-backend", true, false, func(c *Config) *string { return &c.Github.ClientID }),
+	stringField("cla-gh-oauth-secret-go-backend", true, true, func(c *Config) *string { return &c.Github.ClientSecret }),
+	stringField("cla-gh-access-token", true, true, func(c *Config) *string { return &c.Github.AccessToken }),
+	{
+		Name:     "cla-gh-app-id",
+		Required: true,
+		get: func(c *Config) string {
+			if c.Github.AppID == 0 {
+				return ""
+			}
+			return strconv.Itoa(c.Github.AppID)
+		},
+		set: func(c *Config, value string) error {
+			githubAppID, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("cla-gh-app-id must be an integer value, got: %s", value)
+			}
+			c.Github.AppID = githubAppID
+			return nil
+		},
+	},
+	stringField("cla-gh-app-private-key", true, true, func(c *Config) *string { return &c.Github.AppPrivateKey }),
+	{
+		Name:     "cla-corporate-base",
+		Required: true,
+		get:      func(c *Config) string { return c.CorporateConsoleURL },
+		set: func(c *Config, value string) error {
+			if value == "corporate.prod.lfcla.com" {
+				value = "corporate.lfcla.com"
+			}
+			c.CorporateConsoleURL = value
+			return nil
+		},
+	},
+	stringField("cla-corporate-v2-base", true, false, func(c *Config) *string { return &c.CorporateConsoleV2URL }),
+	stringField("cla-contributor-v2-base", false, false, func(c *Config) *string { return &c.ContributorConsoleV2URL }),
+	stringField("cla-doc-raptor-api-key", true, true, func(c *Config) *string { return &c.Docraptor.APIKey }),
+	stringField("cla-session-store-table", true, false, func(c *Config) *string { return &c.SessionStoreTableName }),
+	stringField("cla-ses-sender-email-address", true, false, func(c *Config) *string { return &c.SenderEmailAddress }),
+	reloadable(stringField("cla-allowed-origins", true, false, func(c *Config) *string { return &c.AllowedOriginsCommaSeparated })),
+	stringField("cla-sns-event-topic-arn", true, false, func(c *Config) *string { return &c.SNSEventTopicARN }),
+	stringField("cla-signature-files-bucket", true, false, func(c *Config) *string { return &c.SignatureFilesBucket }),
+	stringField("cla-auth0-platform-client-id", true, false, func(c *Config) *string { return &c.Auth0Platform.ClientID }),
+	stringField("cla-auth0-platform-client-secret", true, true, func(c *Config) *string { return &c.Auth0Platform.ClientSecret }),
+	stringField("cla-auth0-platform-audience", true, false, func(c *Config) *string { return &c.Auth0Platform.Audience }),
+	stringField("cla-auth0-platform-url", true, false, func(c *Config) *string { return &c.Auth0Platform.URL }),
+	stringField("cla-auth0-platform-api-gw", true, false, func(c *Config) *string { return &c.APIGatewayURL }),
+	stringField("cla-lf-group-client-id", true, false, func(c *Config) *string { return &c.LFGroup.ClientID }),
+	stringField("cla-lf-group-client-secret", true, true, func(c *Config) *string { return &c.LFGroup.ClientSecret }),
+	stringField("cla-lf-group-client-url", true, false, func(c *Config) *string { return &c.LFGroup.ClientURL }),
+	stringField("cla-lf-group-refresh-token", true, true, func(c *Config) *string { return &c.LFGroup.RefreshToken }),
+	stringField("cla-v1-api-url", true, false, func(c *Config) *string { return &c.ClaV1ApiURL }),
+	stringField("cla-acs-api-key", true, true, func(c *Config) *string { return &c.AcsAPIKey }),
+	stringField("cla-lfx-portal-url", true, false, func(c *Config) *string { return &c.LFXPortalURL }),
+	reloadable(stringField("cla-lfx-metrics-report-sqs-region", false, false, func(c *Config) *string { return &c.MetricsReport.AwsSQSRegion })),
+	reloadable(stringField("cla-lfx-metrics-report-sqs-url", false, false, func(c *Config) *string { return &c.MetricsReport.AwsSQSQueueURL })),
+	reloadable(boolField("cla-lfx-metrics-report-enabled", false, func(c *Config) *bool { return &c.MetricsReport.Enabled })),
+	reloadable(withDefault(stringField("cla-denied-domains", false, false, func(c *Config) *string { return &c.DeniedDomainsCommaSeparated }), defaultDeniedDomains)),
+	reloadable(intField("cla-approval-list-expiry-warning-days", 7, func(c *Config) *int { return &c.ApprovalListExpiryWarningDays })),
+}
+
+// ValidationError is returned when the resolved configuration is incomplete or contains invalid values
+type ValidationError struct {
+	Missing []string
+	Invalid []string
+}
+
+// Error returns a summary of the validation failures
+func (e *ValidationError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, fmt.Sprintf("missing required configuration values: %s", strings.Join(e.Missing, ", ")))
+	}
+	if len(e.Invalid) > 0 {
+		parts = append(parts, fmt.Sprintf("invalid configuration values: %s", strings.Join(e.Invalid, "; ")))
+	}
+	return strings.Join(parts, " - ")
+}
+
+func (e *ValidationError) empty() bool {
+	return len(e.Missing) == 0 && len(e.Invalid) == 0
+}
+
+// applyDefaults sets the default value for each field which was not loaded from a configuration source - the loaded
+// fields keep their value even if it is the zero value, e.g. a boolean explicitly set to false
+func applyDefaults(c *Config, loaded map[string]bool) error {
+	validationErr := &ValidationError{}
+	for _, f := range Fields {
+		if f.Default == "" || loaded[f.Name] {
+			continue
+		}
+		if err := f.set(c, f.Default); err != nil {
+			validationErr.Invalid = append(validationErr.Invalid, err.Error())
+		}
+	}
+	if !validationErr.empty() {
+		return validationErr
+	}
+	return nil
+}
+
+// applyEnvOverrides overrides any configuration value which has a matching environment variable, see Field.EnvKey
+func applyEnvOverrides(c *Config) error {
+	validationErr := &ValidationError{}
+	for _, f := range Fields {
+		value, ok := os.LookupEnv(f.EnvKey())
+		if !ok {
+			continue
+		}
+		if err := f.set(c, strings.TrimSpace(value)); err != nil {
+			validationErr.Invalid = append(validationErr.Invalid, err.Error())
+		}
+	}
+	if !validationErr.empty() {
+		return validationErr
+	}
+	return nil
+}
+
+// Validate confirms all the required configuration values are present
+func (c Config) Validate() error {
+	validationErr := &ValidationError{}
+	for _, f := range Fields {
+		if f.Required && f.get(&c) == "" {
+			validationErr.Missing = append(validationErr.Missing, f.Name)
+		}
+	}
+	if !validationErr.empty() {
+		sort.Strings(validationErr.Missing)
+		return validationErr
+	}
+	return nil
+}
+
+// Validate confirms the metrics report transport is configured when reporting is enabled
+func (m MetricsReport) Validate() error {
+	if !m.Enabled {
+		return nil
+	}
+	validationErr := &ValidationError{}
+	if m.AwsSQSRegion == "" {
+		validationErr.Missing = append(validationErr.Missing, "cla-lfx-metrics-report-sqs-region")
+	}
+	if m.AwsSQSQueueURL == "" {
+		validationErr.Missing = append(validationErr.Missing, "cla-lfx-metrics-report-sqs-url")
+	}
+	if !validationErr.empty() {
+		return validationErr
+	}
+	return nil
+}
+
+// Redacted returns the resolved configuration as a key/value map with the secret values redacted
+func (c Config) Redacted() map[string]string {
+	values := make(map[string]string, len(Fields))
+	for _, f := range Fields {
+		values[f.Name] = f.DisplayValue(&c)
+	}
+	return values
+}
@@ -0,0 +1,119 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func completeConfig(t *testing.T) Config {
+	c := Config{}
+	for _, f := range Fields {
+		value := "value-" + f.Name
+		switch f.Name {
+		case "cla-gh-app-id":
+			value = "1234"
+		case "cla-lfx-metrics-report-enabled":
+			value = "false"
+		case "cla-approval-list-expiry-warning-days":
+			value = "3"
+		}
+		assert.Nil(t, f.set(&c, value))
+	}
+	return c
+}
+
+func TestValidateMissingRequired(t *testing.T) {
+	c := completeConfig(t)
+	assert.Nil(t, c.Validate())
+
+	c.Auth0.Domain = ""
+	c.Github.AppID = 0
+	err := c.Validate()
+	if assert.NotNil(t, err) {
+		validationErr, ok := err.(*ValidationError)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"cla-auth0-domain", "cla-gh-app-id"}, validationErr.Missing)
+	}
+}
+
+func TestEnvOverrides(t *testing.T) {
+	c := completeConfig(t)
+	assert.Nil(t, os.Setenv("CLA_ALLOWED_ORIGINS", "a.example.org,b.example.org"))
+	assert.Nil(t, os.Setenv("CLA_GH_APP_ID", "5678"))
+	defer func() {
+		_ = os.Unsetenv("CLA_ALLOWED_ORIGINS")
+		_ = os.Unsetenv("CLA_GH_APP_ID")
+	}()
+
+	assert.Nil(t, applyEnvOverrides(&c))
+	assert.Equal(t, "a.example.org,b.example.org", c.AllowedOriginsCommaSeparated)
+	assert.Equal(t, 5678, c.Github.AppID)
+
+	assert.Nil(t, os.Setenv("CLA_GH_APP_ID", "not-a-number"))
+	assert.NotNil(t, applyEnvOverrides(&c))
+}
+
+func TestRedacted(t *testing.T) {
+	c := completeConfig(t)
+	redacted := c.Redacted()
+	assert.Equal(t, redactedValue, redacted["cla-gh-app-private-key"])
+	assert.Equal(t, redactedValue, redacted["cla-acs-api-key"])
+	assert.Equal(t, "value-cla-auth0-domain", redacted["cla-auth0-domain"])
+}
+
+func TestMetricsReportValidate(t *testing.T) {
+	assert.Nil(t, MetricsReport{Enabled: false}.Validate())
+	assert.NotNil(t, MetricsReport{Enabled: true, AwsSQSRegion: "us-east-1"}.Validate())
+	assert.Nil(t, MetricsReport{Enabled: true, AwsSQSRegion: "us-east-1", AwsSQSQueueURL: "https://sqs"}.Validate())
+}
+
+func TestSplitAllowedOrigins(t *testing.T) {
+	assert.Equal(t, []string{"a.example.org", "b.example.org"}, splitAllowedOrigins(" a.example.org, ,b.example.org "))
+	assert.Nil(t, splitAllowedOrigins(""))
+}
+
+func TestApplyDefaultsKeepsLoadedValues(t *testing.T) {
+	defer func(fields []Field) { Fields = fields }(Fields)
+	Fields = []Field{boolField("cla-test-enabled", true, func(c *Config) *bool { return &c.MetricsReport.Enabled })}
+
+	c := Config{}
+	assert.Nil(t, applyDefaults(&c, nil))
+	assert.True(t, c.MetricsReport.Enabled)
+
+	// a value explicitly loaded as false is not replaced by the default
+	c = Config{}
+	assert.Nil(t, applyDefaults(&c, map[string]bool{"cla-test-enabled": true}))
+	assert.False(t, c.MetricsReport.Enabled)
+}
+
+func TestLoadLocalConfigDefaults(t *testing.T) {
+	file, err := ioutil.TempFile("", "config-*.json")
+	assert.Nil(t, err)
+	defer func() { _ = os.Remove(file.Name()) }()
+	_, err = file.WriteString(`{"allowedOriginsCommaSeparated": "a.example.org", "deniedDomainsCommaSeparated": ""}`)
+	assert.Nil(t, err)
+	assert.Nil(t, file.Close())
+
+	c, err := loadLocalConfig(file.Name())
+	assert.Nil(t, err)
+	assert.Equal(t, "a.example.org", c.AllowedOriginsCommaSeparated)
+	// the value present in the file is kept even if it is empty
+	assert.Equal(t, "", c.DeniedDomainsCommaSeparated)
+}
+
+func TestApplyDefaultsIntField(t *testing.T) {
+	c := Config{}
+	assert.Nil(t, applyDefaults(&c, nil))
+	assert.Equal(t, 7, c.ApprovalListExpiryWarningDays)
+
+	// zero disables the warnings, an explicitly loaded zero is kept
+	c = Config{}
+	assert.Nil(t, applyDefaults(&c, map[string]bool{"cla-approval-list-expiry-warning-days": true}))
+	assert.Equal(t, 0, c.ApprovalListExpiryWarningDays)
+}
@@ -5,7 +5,6 @@ package config
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -13,6 +12,7 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ssm"
 )
@@ -21,6 +21,7 @@ import (
 type configLookupResponse struct {
 	key   string
 	value string
+	err   error
 }
 
 // getSSMString is a generic routine to fetch the specified key value
@@ -38,8 +39,18 @@ func getSSMString(ssmClient *ssm.SSM, key string) (string, error) {
 	return strings.TrimSpace(*value.Parameter.Value), nil
 }
 
-// loadSSMConfig fetches all the configuration values and populates the response Config model
-func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
+// isParameterNotFound returns true if the error indicates the SSM parameter does not exist
+func isParameterNotFound(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == ssm.ErrCodeParameterNotFound
+	}
+	return false
+}
+
+// loadSSMConfig fetches all the configuration values defined in the schema and populates the response Config model.
+// Parameters which do not exist get the default value of the field or are left empty - the caller is responsible for
+// validating the result. Any other lookup error is returned, the caller decides whether it is fatal.
+func loadSSMConfig(awsSession *session.Session, stage string) (Config, error) {
 	f := logrus.Fields{
 		"functionName": "loadSSMConfig",
 		"stage":        stage,
@@ -54,145 +65,91 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 	// A channel for the responses from the go routines
 	responseChannel := make(chan configLookupResponse)
 
-	// Our list of keys to lookup
-	ssmKeys := []string{
-		fmt.Sprintf("cla-auth0-domain-%s", stage),
-		fmt.Sprintf("cla-auth0-clientId-%s", stage),
-		fmt.Sprintf("cla-auth0-username-claim-%s", stage),
-		fmt.Sprintf("cla-auth0-algorithm-%s", stage),
-		fmt.Sprintf("cla-gh-oauth-client-id-go-backend-%s", stage),
-		fmt.Sprintf("cla-gh-oauth-secret-go-backend-%s", stage),
-		fmt.Sprintf("cla-gh-access-token-%s", stage),
-		fmt.Sprintf("cla-gh-app-id-%s", stage),
-		fmt.Sprintf("cla-gh-app-private-key-%s", stage),
-		fmt.Sprintf("cla-corporate-base-%s", stage),
-		fmt.Sprintf("cla-corporate-v2-base-%s", stage),
-		fmt.Sprintf("cla-doc-raptor-api-key-%s", stage),
-		fmt.Sprintf("cla-session-store-table-%s", stage),
-		fmt.Sprintf("cla-ses-sender-email-address-%s", stage),
-		fmt.Sprintf("cla-allowed-origins-%s", stage),
-		fmt.Sprintf("cla-sns-event-topic-arn-%s", stage),
-		fmt.Sprintf("cla-signature-files-bucket-%s", stage),
-		fmt.Sprintf("cla-auth0-platform-client-id-%s", stage),
-		fmt.Sprintf("cla-auth0-platform-client-secret-%s", stage),
-		fmt.Sprintf("cla-auth0-platform-audience-%s", stage),
-		fmt.Sprintf("cla-auth0-platform-url-%s", stage),
-		fmt.Sprintf("cla-auth0-platform-api-gw-%s", stage),
-		fmt.Sprintf("cla-lf-group-client-id-%s", stage),
-		fmt.Sprintf("cla-lf-group-client-secret-%s", stage),
-		fmt.Sprintf("cla-lf-group-client-url-%s", stage),
-		fmt.Sprintf("cla-lf-group-refresh-token-%s", stage),
-		fmt.Sprintf("cla-v1-api-url-%s", stage),
-		fmt.Sprintf("cla-acs-api-key-%s", stage),
-		fmt.Sprintf("cla-lfx-portal-url-%s", stage),
-		fmt.Sprintf("cla-lfx-metrics-report-sqs-region-%s", stage),
-		fmt.Sprintf("cla-lfx-metrics-report-sqs-url-%s", stage),
-		fmt.Sprintf("cla-lfx-metrics-report-enabled-%s", stage),
-	}
-
 	// For each key to lookup
-	for _, key := range ssmKeys {
+	for _, field := range Fields {
 		// Create a go routine to this concurrently
 		go func(theKey string) {
 			theValue, err := getSSMString(ssmClient, theKey)
-			if err != nil {
-				log.WithFields(f).WithError(err).Fatalf("error looking up key: %s", theKey)
+			if err != nil && isParameterNotFound(err) {
+				err = nil
 			}
 			// Send the response back through the channel
 			responseChannel <- configLookupResponse{
 				key:   theKey,
 				value: theValue,
+				err:   err,
 			}
-		}(key)
+		}(field.SSMKey(stage))
 	}
 
-	for i := 0; i < len(ssmKeys); i++ {
+	// Every response is read, so none of the go routines is left blocked on the channel
+	var lookupErr error
+	responses := make(map[string]string, len(Fields))
+	for i := 0; i < len(Fields); i++ {
 		resp := <-responseChannel
-		switch resp.key {
-		case fmt.Sprintf("cla-auth0-domain-%s", stage):
-			config.Auth0.Domain = resp.value
-		case fmt.Sprintf("cla-auth0-clientId-%s", stage):
-			config.Auth0.ClientID = resp.value
-		case fmt.Sprintf("cla-auth0-username-claim-%s", stage):
-			config.Auth0.UsernameClaim = resp.value
-		case fmt.Sprintf("cla-auth0-algorithm-%s", stage):
-			config.Auth0.Algorithm = resp.value
-		case fmt.Sprintf("cla-gh-oauth-client-id-go-backend-%s", stage):
-			config.Github.ClientID = resp.value
-		case fmt.Sprintf("cla-gh-oauth-secret-go-backend-%s", stage):
-			config.Github.ClientSecret = resp.value
-		case fmt.Sprintf("cla-gh-access-token-%s", stage):
-			config.Github.AccessToken = resp.value
-		case fmt.Sprintf("cla-gh-app-id-%s", stage):
-			githubAppID, err := strconv.Atoi(resp.value)
-			if err != nil {
-				errMsg := fmt.Sprintf("invalid value of key: %s", fmt.Sprintf("cla-gh-app-id-%s", stage))
-				log.WithFields(f).WithError(err).Fatal(errMsg)
-			}
-			config.Github.AppID = githubAppID
-		case fmt.Sprintf("cla-gh-app-private-key-%s", stage):
-			config.Github.AppPrivateKey = resp.value
-
-		case fmt.Sprintf("cla-corporate-base-%s", stage):
-			corporateConsoleURLValue := resp.value
-			if corporateConsoleURLValue == "corporate.prod.lfcla.com" {
-				corporateConsoleURLValue = "corporate.lfcla.com"
-			}
-			config.CorporateConsoleURL = corporateConsoleURLValue
-		case fmt.Sprintf("cla-corporate-v2-base-%s", stage):
-			config.CorporateConsoleV2URL = resp.value
-		case fmt.Sprintf("cla-doc-raptor-api-key-%s", stage):
-			config.Docraptor.APIKey = resp.value
-			config.Docraptor.TestMode = stage != "prod" && stage != "staging"
-		case fmt.Sprintf("cla-session-store-table-%s", stage):
-			config.SessionStoreTableName = resp.value
-		case fmt.Sprintf("cla-ses-sender-email-address-%s", stage):
-			config.SenderEmailAddress = resp.value
-		case fmt.Sprintf("cla-allowed-origins-%s", stage):
-			config.AllowedOriginsCommaSeparated = resp.value
-		case fmt.Sprintf("cla-sns-event-topic-arn-%s", stage):
-			config.SNSEventTopicARN = resp.value
-		case fmt.Sprintf("cla-signature-files-bucket-%s", stage):
-			config.SignatureFilesBucket = resp.value
-		case fmt.Sprintf("cla-auth0-platform-client-id-%s", stage):
-			config.Auth0Platform.ClientID = resp.value
-		case fmt.Sprintf("cla-auth0-platform-client-secret-%s", stage):
-			config.Auth0Platform.ClientSecret = resp.value
-		case fmt.Sprintf("cla-auth0-platform-audience-%s", stage):
-			config.Auth0Platform.Audience = resp.value
-		case fmt.Sprintf("cla-auth0-platform-url-%s", stage):
-			config.Auth0Platform.URL = resp.value
-		case fmt.Sprintf("cla-auth0-platform-api-gw-%s", stage):
-			config.APIGatewayURL = resp.value
-		case fmt.Sprintf("cla-lf-group-client-id-%s", stage):
-			config.LFGroup.ClientID = resp.value
-		case fmt.Sprintf("cla-lf-group-client-secret-%s", stage):
-			config.LFGroup.ClientSecret = resp.value
-		case fmt.Sprintf("cla-lf-group-client-url-%s", stage):
-			config.LFGroup.ClientURL = resp.value
-		case fmt.Sprintf("cla-lf-group-refresh-token-%s", stage):
-			config.LFGroup.RefreshToken = resp.value
-		case fmt.Sprintf("cla-v1-api-url-%s", stage):
-			config.ClaV1ApiURL = resp.value
-		case fmt.Sprintf("cla-acs-api-key-%s", stage):
-			config.AcsAPIKey = resp.value
-		case fmt.Sprintf("cla-lfx-portal-url-%s", stage):
-			config.LFXPortalURL = resp.value
-		case fmt.Sprintf("cla-lfx-metrics-report-sqs-region-%s", stage):
-			config.MetricsReport.AwsSQSRegion = resp.value
-		case fmt.Sprintf("cla-lfx-metrics-report-sqs-url-%s", stage):
-			config.MetricsReport.AwsSQSQueueURL = resp.value
-		case fmt.Sprintf("cla-lfx-metrics-report-enabled-%s", stage):
-			boolVal, err := strconv.ParseBool(resp.value)
-			if err != nil {
-				log.WithFields(f).WithError(err).Warnf("unable to convert %s value to a boolean - setting value to false in the configuration",
-					fmt.Sprintf("cla-lfx-metrics-report-enabled-%s", stage))
-				config.MetricsReport.Enabled = false
-			} else {
-				config.MetricsReport.Enabled = boolVal
+		if resp.err != nil {
+			log.WithFields(f).WithError(resp.err).Warnf("error looking up key: %s", resp.key)
+			if lookupErr == nil {
+				lookupErr = fmt.Errorf("unable to look up SSM parameter %s: %w", resp.key, resp.err)
 			}
+			continue
 		}
+		responses[resp.key] = resp.value
+	}
+	if lookupErr != nil {
+		return config, lookupErr
 	}
 
-	return config
+	validationErr := &ValidationError{}
+	loaded := make(map[string]bool, len(Fields))
+	for _, field := range Fields {
+		value := responses[field.SSMKey(stage)]
+		if value == "" {
+			continue
+		}
+		if err := field.set(&config, value); err != nil {
+			log.WithFields(f).WithError(err).Warnf("invalid value for key: %s", field.SSMKey(stage))
+			validationErr.Invalid = append(validationErr.Invalid, err.Error())
+			continue
+		}
+		loaded[field.Name] = true
+	}
+	config.Docraptor.TestMode = stage != "prod" && stage != "staging"
+
+	if !validationErr.empty() {
+		return config, validationErr
+	}
+
+	return config, applyDefaults(&config, loaded)
+}
+
+// GithubEnterprise contains the credentials of the EasyCLA GitHub App registered on a GitHub Enterprise Server host
+type GithubEnterprise struct {
+	AppPrivateKey string
+	AccessToken   string
+	WebhookSecret string
+	ClientID      string
+	ClientSecret  string
+}
+
+// LoadGithubEnterprise fetches the credentials of the GitHub Enterprise Server host - the SSM parameters are named like
+// their github.com counterparts with the host added, e.g. cla-gh-app-private-key-github.example.com-dev. Parameters
+// which do not exist are left empty.
+func LoadGithubEnterprise(awsSession *session.Session, stage, host string) (GithubEnterprise, error) {
+	ssmClient := ssm.New(awsSession)
+	githubEnterprise := GithubEnterprise{}
+	for name, target := range map[string]*string{
+		"cla-gh-app-private-key":            &githubEnterprise.AppPrivateKey,
+		"cla-gh-access-token":               &githubEnterprise.AccessToken,
+		"cla-gh-webhook-secret":             &githubEnterprise.WebhookSecret,
+		"cla-gh-oauth-client-id-go-backend": &githubEnterprise.ClientID,
+		"cla-gh-oauth-secret-go-backend":    &githubEnterprise.ClientSecret,
+	} {
+		value, err := getSSMString(ssmClient, fmt.Sprintf("%s-%s-%s", name, host, stage))
+		if err != nil && !isParameterNotFound(err) {
+			return GithubEnterprise{}, err
+		}
+		*target = value
+	}
+	return githubEnterprise, nil
 }
@@ -0,0 +1,7 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package data_subject
+
+// Pseudonym exposes pseudonym to the tests
+var Pseudonym = pseudonym
@@ -0,0 +1,158 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package data_subject
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// RequestType is the kind of data subject request
+type RequestType string
+
+// request types
+const (
+	RequestTypeExport  RequestType = "export"
+	RequestTypeErasure RequestType = "erasure"
+)
+
+// Status is the state of a data subject request
+type Status string
+
+// request statuses
+const (
+	StatusPending   Status = "pending"
+	StatusApproved  Status = "approved"
+	StatusRejected  Status = "rejected"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// audit actions
+const (
+	AuditActionCreated   = "created"
+	AuditActionApproved  = "approved"
+	AuditActionRejected  = "rejected"
+	AuditActionCompleted = "completed"
+	AuditActionFailed    = "failed"
+	AuditActionExported  = "export downloaded"
+)
+
+// AuditEntry is a step of the audit trail of a data subject request
+type AuditEntry struct {
+	Date   string `dynamodbav:"date" json:"date"`
+	Actor  string `dynamodbav:"actor" json:"actor"`
+	Action string `dynamodbav:"action" json:"action"`
+	Note   string `dynamodbav:"note,omitempty" json:"note,omitempty"`
+}
+
+// Summary counts the records found for an export or changed by an erasure
+type Summary struct {
+	Users                int `dynamodbav:"users" json:"users"`
+	Signatures           int `dynamodbav:"signatures" json:"signatures"`
+	SignedDocuments      int `dynamodbav:"signed_documents" json:"signed_documents"`
+	Events               int `dynamodbav:"events" json:"events"`
+	ApprovalLists        int `dynamodbav:"approval_lists" json:"approval_lists"`
+	ApprovalListRequests int `dynamodbav:"approval_list_requests" json:"approval_list_requests"`
+}
+
+// Request is the data subject request data model - the identifiers of the subject are dropped once an erasure
+// completes so the request doesn't keep the personal data it erased
+type Request struct {
+	RequestID      string       `dynamodbav:"request_id"`
+	RequestType    RequestType  `dynamodbav:"request_type"`
+	Status         Status       `dynamodbav:"request_status"`
+	UserID         string       `dynamodbav:"user_id,omitempty"`
+	Email          string       `dynamodbav:"user_email,omitempty"`
+	GithubUsername string       `dynamodbav:"user_github_username,omitempty"`
+	Reason         string       `dynamodbav:"reason,omitempty"`
+	RequestedBy    string       `dynamodbav:"requested_by"`
+	ReviewedBy     string       `dynamodbav:"reviewed_by,omitempty"`
+	Pseudonym      string       `dynamodbav:"pseudonym,omitempty"`
+	ExportKey      string       `dynamodbav:"export_key,omitempty"`
+	Summary        *Summary     `dynamodbav:"summary,omitempty"`
+	Error          string       `dynamodbav:"error,omitempty"`
+	AuditTrail     []AuditEntry `dynamodbav:"audit_trail"`
+	DateCreated    string       `dynamodbav:"date_created"`
+	DateModified   string       `dynamodbav:"date_modified"`
+	DateCompleted  string       `dynamodbav:"date_completed,omitempty"`
+}
+
+// Subject holds the records and identifiers found for the subject of a request
+type Subject struct {
+	UserIDs         []string `json:"user_ids"`
+	Names           []string `json:"names"`
+	LFUsernames     []string `json:"lf_usernames"`
+	Emails          []string `json:"emails"`
+	GithubUsernames []string `json:"github_usernames"`
+	GithubIDs       []string `json:"github_ids"`
+}
+
+// terms returns the unique identifiers of the subject which are searched for and replaced in free text - the names
+// aren't unique, they are only replaced in the events of the subject
+func (s *Subject) terms() []string {
+	var terms []string
+	for _, list := range [][]string{s.LFUsernames, s.Emails, s.GithubUsernames, s.GithubIDs} {
+		terms = appendMissing(terms, list)
+	}
+	return terms
+}
+
+// ProcessReport counts the approved requests processed by a run of the data subject job
+type ProcessReport struct {
+	Completed int
+	Failed    int
+}
+
+// Record is a raw database record
+type Record map[string]interface{}
+
+// ApprovalList is the email and GitHub username approval lists of a CCLA signature
+type ApprovalList struct {
+	SignatureID        string   `dynamodbav:"signature_id" json:"signature_id"`
+	ProjectID          string   `dynamodbav:"signature_project_id" json:"signature_project_id"`
+	CompanyID          string   `dynamodbav:"signature_reference_id" json:"signature_reference_id"`
+	EmailApprovalList  []string `dynamodbav:"email_whitelist" json:"email_approval_list"`
+	GithubApprovalList []string `dynamodbav:"github_whitelist" json:"github_username_approval_list"`
+}
+
+// ToModel converts the request to the API model
+func (r *Request) ToModel() *models.DataSubjectRequest {
+	auditTrail := make([]*models.DataSubjectRequestAuditEntry, 0, len(r.AuditTrail))
+	for _, entry := range r.AuditTrail {
+		auditTrail = append(auditTrail, &models.DataSubjectRequestAuditEntry{
+			Date:   entry.Date,
+			Actor:  entry.Actor,
+			Action: entry.Action,
+			Note:   entry.Note,
+		})
+	}
+	result := &models.DataSubjectRequest{
+		RequestID:      r.RequestID,
+		RequestType:    string(r.RequestType),
+		Status:         string(r.Status),
+		UserID:         r.UserID,
+		Email:          r.Email,
+		GithubUsername: r.GithubUsername,
+		Reason:         r.Reason,
+		RequestedBy:    r.RequestedBy,
+		ReviewedBy:     r.ReviewedBy,
+		Pseudonym:      r.Pseudonym,
+		Error:          r.Error,
+		AuditTrail:     auditTrail,
+		DateCreated:    r.DateCreated,
+		DateModified:   r.DateModified,
+		DateCompleted:  r.DateCompleted,
+	}
+	if r.Summary != nil {
+		result.Summary = &models.DataSubjectRequestSummary{
+			Users:                int64(r.Summary.Users),
+			Signatures:           int64(r.Summary.Signatures),
+			SignedDocuments:      int64(r.Summary.SignedDocuments),
+			Events:               int64(r.Summary.Events),
+			ApprovalLists:        int64(r.Summary.ApprovalLists),
+			ApprovalListRequests: int64(r.Summary.ApprovalListRequests),
+		}
+	}
+	return result
+}
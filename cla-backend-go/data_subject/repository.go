@@ -0,0 +1,623 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package data_subject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// errors
+var (
+	ErrRequestNotFound     = errors.New("data subject request not found")
+	ErrSignatureNotFound   = errors.New("signature not found")
+	ErrApprovalListChanged = errors.New("approval list was changed concurrently")
+)
+
+// Repository interface defines the functions for the data subject requests and the records of a data subject
+type Repository interface {
+	CreateRequest(ctx context.Context, request *Request) error
+	UpdateRequest(ctx context.Context, request *Request) error
+	GetRequest(ctx context.Context, requestID string) (*Request, error)
+	GetRequests(ctx context.Context, status Status) ([]*Request, error)
+
+	GetUsers(ctx context.Context, userID, email, githubUsername string) ([]Record, error)
+	GetUserSignatures(ctx context.Context, userID string) ([]Record, error)
+	GetEvents(ctx context.Context, userIDs, lfUsernames, terms []string) ([]Record, error)
+	GetApprovalLists(ctx context.Context, emails, githubUsernames []string) ([]*ApprovalList, error)
+	GetApprovalListRequests(ctx context.Context, userIDs, emails []string) ([]Record, error)
+
+	PseudonymizeUser(ctx context.Context, userID, pseudonym string) error
+	UpdateEvent(ctx context.Context, eventID string, values map[string]string) error
+	GetApprovalList(ctx context.Context, signatureID string) (*ApprovalList, error)
+	UpdateApprovalList(ctx context.Context, current, updated *ApprovalList) error
+	PseudonymizeApprovalListRequest(ctx context.Context, requestID, pseudonym string) error
+}
+
+type repository struct {
+	stage                        string
+	dynamoDBClient               *dynamodb.DynamoDB
+	tableName                    string
+	userTableName                string
+	signatureTableName           string
+	eventTableName               string
+	approvalListRequestTableName string
+}
+
+// NewRepository creates a new instance of the data subject repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:                        stage,
+		dynamoDBClient:               dynamodb.New(awsSession),
+		tableName:                    fmt.Sprintf("cla-%s-data-subject-requests", stage),
+		userTableName:                fmt.Sprintf("cla-%s-users", stage),
+		signatureTableName:           fmt.Sprintf("cla-%s-signatures", stage),
+		eventTableName:               fmt.Sprintf("cla-%s-events", stage),
+		approvalListRequestTableName: fmt.Sprintf("cla-%s-ccla-whitelist-requests", stage),
+	}
+}
+
+// CreateRequest stores the new data subject request
+func (repo *repository) CreateRequest(ctx context.Context, request *Request) error {
+	return repo.putRequest(ctx, request, "attribute_not_exists(request_id)")
+}
+
+// UpdateRequest stores the changes of the data subject request
+func (repo *repository) UpdateRequest(ctx context.Context, request *Request) error {
+	return repo.putRequest(ctx, request, "attribute_exists(request_id)")
+}
+
+func (repo *repository) putRequest(ctx context.Context, request *Request, condition string) error {
+	f := logrus.Fields{
+		"functionName":   "putRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"requestID":      request.RequestID,
+	}
+
+	item, err := dynamodbattribute.MarshalMap(request)
+	if err != nil {
+		log.WithFields(f).Warnf("error marshalling data subject request, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                item,
+		TableName:           aws.String(repo.tableName),
+		ConditionExpression: aws.String(condition),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem storing the data subject request, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// GetRequest returns the data subject request
+func (repo *repository) GetRequest(ctx context.Context, requestID string) (*Request, error) {
+	f := logrus.Fields{
+		"functionName":   "GetRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"requestID":      requestID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"request_id": {
+				S: aws.String(requestID),
+			},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load data subject request, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrRequestNotFound
+	}
+
+	var request Request
+	err = dynamodbattribute.UnmarshalMap(result.Item, &request)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling data subject request, error: %+v", err)
+		return nil, err
+	}
+	return &request, nil
+}
+
+// GetRequests returns the data subject requests, all requests if the status is empty
+func (repo *repository) GetRequests(ctx context.Context, status Status) ([]*Request, error) {
+	f := logrus.Fields{
+		"functionName":   "GetRequests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"status":         status,
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		TableName: aws.String(repo.tableName),
+	}
+	if status != "" {
+		expr, err := expression.NewBuilder().WithFilter(expression.Name("request_status").Equal(expression.Value(status))).Build()
+		if err != nil {
+			log.WithFields(f).Warnf("problem building the data subject requests scan expression, error: %+v", err)
+			return nil, err
+		}
+		scanInput.ExpressionAttributeNames = expr.Names()
+		scanInput.ExpressionAttributeValues = expr.Values()
+		scanInput.FilterExpression = expr.Filter()
+	}
+
+	var requests []*Request
+	err := repo.scan(ctx, scanInput, func(items []map[string]*dynamodb.AttributeValue) error {
+		var page []*Request
+		if err := dynamodbattribute.UnmarshalListOfMaps(items, &page); err != nil {
+			return err
+		}
+		requests = append(requests, page...)
+		return nil
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the data subject requests, error: %+v", err)
+		return nil, err
+	}
+	return requests, nil
+}
+
+// GetUsers returns the user records with the user ID, the email or the GitHub username
+func (repo *repository) GetUsers(ctx context.Context, userID, email, githubUsername string) ([]Record, error) {
+	f := logrus.Fields{
+		"functionName":   "GetUsers",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.userTableName,
+		"userID":         userID,
+	}
+
+	var conditions []expression.ConditionBuilder
+	if userID != "" {
+		conditions = append(conditions, expression.Name("user_id").Equal(expression.Value(userID)))
+	}
+	if email != "" {
+		conditions = append(conditions,
+			expression.Name("lf_email").Equal(expression.Value(email)),
+			expression.Name("user_emails").Contains(email))
+	}
+	if githubUsername != "" {
+		conditions = append(conditions, expression.Name("user_github_username").Equal(expression.Value(githubUsername)))
+	}
+
+	records, err := repo.scanRecords(ctx, repo.userTableName, conditions)
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the users of the data subject, error: %+v", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetUserSignatures returns the signature records of the user
+func (repo *repository) GetUserSignatures(ctx context.Context, userID string) ([]Record, error) {
+	f := logrus.Fields{
+		"functionName":   "GetUserSignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.signatureTableName,
+		"userID":         userID,
+	}
+
+	expr, err := expression.NewBuilder().WithKeyCondition(expression.Key("signature_reference_id").Equal(expression.Value(userID))).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("problem building the signatures query expression, error: %+v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.signatureTableName),
+		IndexName:                 aws.String("reference-signature-index"),
+	}
+
+	var records []Record
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).Warnf("problem loading the signatures of the user, error: %+v", err)
+			return nil, err
+		}
+
+		var page []Record
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).Warnf("error unmarshalling signatures from database, error: %v", err)
+			return nil, err
+		}
+		records = append(records, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return records, nil
+}
+
+// GetEvents returns the events of the users and the LF usernames and the events mentioning one of the terms
+func (repo *repository) GetEvents(ctx context.Context, userIDs, lfUsernames, terms []string) ([]Record, error) {
+	f := logrus.Fields{
+		"functionName":   "GetEvents",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.eventTableName,
+	}
+
+	var conditions []expression.ConditionBuilder
+	for _, userID := range userIDs {
+		conditions = append(conditions, expression.Name("event_user_id").Equal(expression.Value(userID)))
+	}
+	for _, lfUsername := range lfUsernames {
+		conditions = append(conditions, expression.Name("event_lf_username").Equal(expression.Value(lfUsername)))
+	}
+	for _, term := range terms {
+		conditions = append(conditions,
+			expression.Name("event_data").Contains(term),
+			expression.Name("event_summary").Contains(term))
+	}
+
+	records, err := repo.scanRecords(ctx, repo.eventTableName, conditions)
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the events of the data subject, error: %+v", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetApprovalLists returns the CCLA signatures with one of the emails or GitHub usernames on their approval lists
+func (repo *repository) GetApprovalLists(ctx context.Context, emails, githubUsernames []string) ([]*ApprovalList, error) {
+	f := logrus.Fields{
+		"functionName":   "GetApprovalLists",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.signatureTableName,
+	}
+
+	var conditions []expression.ConditionBuilder
+	for _, email := range emails {
+		conditions = append(conditions, expression.Name("email_whitelist").Contains(email))
+	}
+	for _, githubUsername := range githubUsernames {
+		conditions = append(conditions, expression.Name("github_whitelist").Contains(githubUsername))
+	}
+
+	items, err := repo.scanItems(ctx, repo.signatureTableName, conditions)
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the approval lists of the data subject, error: %+v", err)
+		return nil, err
+	}
+
+	var approvalLists []*ApprovalList
+	err = dynamodbattribute.UnmarshalListOfMaps(items, &approvalLists)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling approval lists, error: %+v", err)
+		return nil, err
+	}
+	return approvalLists, nil
+}
+
+// GetApprovalListRequests returns the approval list requests of the users or with one of the emails
+func (repo *repository) GetApprovalListRequests(ctx context.Context, userIDs, emails []string) ([]Record, error) {
+	f := logrus.Fields{
+		"functionName":   "GetApprovalListRequests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.approvalListRequestTableName,
+	}
+
+	var conditions []expression.ConditionBuilder
+	for _, userID := range userIDs {
+		conditions = append(conditions, expression.Name("user_id").Equal(expression.Value(userID)))
+	}
+	for _, email := range emails {
+		conditions = append(conditions, expression.Name("user_emails").Contains(email))
+	}
+
+	records, err := repo.scanRecords(ctx, repo.approvalListRequestTableName, conditions)
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the approval list requests of the data subject, error: %+v", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+// PseudonymizeUser replaces the name of the user with the pseudonym and removes the identities of the user, the
+// user ID is kept for the signatures referencing the user
+func (repo *repository) PseudonymizeUser(ctx context.Context, userID, pseudonym string) error {
+	f := logrus.Fields{
+		"functionName":   "PseudonymizeUser",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.userTableName,
+		"userID":         userID,
+	}
+
+	_, now := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.userTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"user_id": {
+				S: aws.String(userID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#N":  aws.String("user_name"),
+			"#D":  aws.String("date_erased"),
+			"#M":  aws.String("date_modified"),
+			"#X":  aws.String("user_external_id"),
+			"#U":  aws.String("lf_username"),
+			"#E":  aws.String("lf_email"),
+			"#ES": aws.String("user_emails"),
+			"#G":  aws.String("user_github_id"),
+			"#GU": aws.String("user_github_username"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":n": {S: aws.String(pseudonym)},
+			":d": {S: aws.String(now)},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression:    aws.String("SET #N = :n, #D = :d, #M = :m REMOVE #X, #U, #E, #ES, #G, #GU"),
+		ConditionExpression: aws.String("attribute_exists(user_id)"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem pseudonymizing the user, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateEvent sets the text columns of the event
+func (repo *repository) UpdateEvent(ctx context.Context, eventID string, values map[string]string) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.eventTableName,
+		"eventID":        eventID,
+	}
+
+	update := expression.UpdateBuilder{}
+	for column, value := range values {
+		update = update.Set(expression.Name(column), expression.Value(value))
+	}
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("problem building the event update expression, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.eventTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"event_id": {
+				S: aws.String(eventID),
+			},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem updating the event, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// GetApprovalList returns the approval lists of the CCLA signature, the read is consistent so the approval lists can
+// be updated conditionally
+func (repo *repository) GetApprovalList(ctx context.Context, signatureID string) (*ApprovalList, error) {
+	f := logrus.Fields{
+		"functionName":   "GetApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.signatureTableName,
+		"signatureID":    signatureID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		TableName:      aws.String(repo.signatureTableName),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the approval list, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrSignatureNotFound
+	}
+
+	var approvalList ApprovalList
+	err = dynamodbattribute.UnmarshalMap(result.Item, &approvalList)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling approval list, error: %+v", err)
+		return nil, err
+	}
+	return &approvalList, nil
+}
+
+// UpdateApprovalList stores the email and GitHub username approval lists of the CCLA signature. The approval lists
+// are only updated if they are still the current approval lists, ErrApprovalListChanged is returned if they were
+// changed in the meantime.
+func (repo *repository) UpdateApprovalList(ctx context.Context, current, updated *ApprovalList) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.signatureTableName,
+		"signatureID":    updated.SignatureID,
+	}
+
+	_, now := utils.CurrentTime()
+	update := expression.Set(expression.Name("date_modified"), expression.Value(now))
+	var conditions []expression.ConditionBuilder
+	for _, column := range []struct {
+		name    string
+		current []string
+		updated []string
+	}{
+		{"email_whitelist", current.EmailApprovalList, updated.EmailApprovalList},
+		{"github_whitelist", current.GithubApprovalList, updated.GithubApprovalList},
+	} {
+		if len(column.current) == 0 {
+			conditions = append(conditions, expression.Or(expression.AttributeNotExists(expression.Name(column.name)),
+				expression.Size(expression.Name(column.name)).Equal(expression.Value(0))))
+		} else {
+			conditions = append(conditions, expression.Name(column.name).Equal(expression.Value(column.current)))
+		}
+		if len(column.updated) == 0 {
+			update = update.Remove(expression.Name(column.name))
+			continue
+		}
+		update = update.Set(expression.Name(column.name), expression.Value(column.updated))
+	}
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(expression.And(conditions[0], conditions[1])).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("problem building the approval list update expression, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(updated.SignatureID),
+			},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ConditionExpression:       expr.Condition(),
+		UpdateExpression:          expr.Update(),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Debug("the approval list was changed concurrently")
+			return ErrApprovalListChanged
+		}
+		log.WithFields(f).Warnf("problem updating the approval list, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// PseudonymizeApprovalListRequest replaces the name of the requester with the pseudonym and removes the identities
+// of the requester
+func (repo *repository) PseudonymizeApprovalListRequest(ctx context.Context, requestID, pseudonym string) error {
+	f := logrus.Fields{
+		"functionName":   "PseudonymizeApprovalListRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.approvalListRequestTableName,
+		"requestID":      requestID,
+	}
+
+	_, now := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.approvalListRequestTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"request_id": {
+				S: aws.String(requestID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#N":  aws.String("user_name"),
+			"#M":  aws.String("date_modified"),
+			"#ES": aws.String("user_emails"),
+			"#G":  aws.String("user_github_id"),
+			"#GU": aws.String("user_github_username"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":n": {S: aws.String(pseudonym)},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression: aws.String("SET #N = :n, #M = :m REMOVE #ES, #G, #GU"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem pseudonymizing the approval list request, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// scanRecords returns the records of the table matching any of the conditions, nothing without conditions
+func (repo *repository) scanRecords(ctx context.Context, tableName string, conditions []expression.ConditionBuilder) ([]Record, error) {
+	items, err := repo.scanItems(ctx, tableName, conditions)
+	if err != nil {
+		return nil, err
+	}
+	var records []Record
+	err = dynamodbattribute.UnmarshalListOfMaps(items, &records)
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// scanItems returns the items of the table matching any of the conditions, nothing without conditions
+func (repo *repository) scanItems(ctx context.Context, tableName string, conditions []expression.ConditionBuilder) ([]map[string]*dynamodb.AttributeValue, error) {
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+	filter := conditions[0]
+	if len(conditions) > 1 {
+		filter = expression.Or(conditions[0], conditions[1], conditions[2:]...)
+	}
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(tableName),
+	}
+
+	var items []map[string]*dynamodb.AttributeValue
+	err = repo.scan(ctx, scanInput, func(page []map[string]*dynamodb.AttributeValue) error {
+		items = append(items, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// scan runs the scan until all the pages are read
+func (repo *repository) scan(ctx context.Context, scanInput *dynamodb.ScanInput, handlePage func(items []map[string]*dynamodb.AttributeValue) error) error {
+	for {
+		results, err := repo.dynamoDBClient.ScanWithContext(ctx, scanInput)
+		if err != nil {
+			return err
+		}
+		if err = handlePage(results.Items); err != nil {
+			return err
+		}
+		if len(results.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+}
@@ -0,0 +1,725 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package data_subject
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// errors
+var (
+	ErrInvalidRequestType  = errors.New("request type must be export or erasure")
+	ErrNoSubjectIdentifier = errors.New("a user ID, an email or a GitHub username is required")
+	ErrRequestNotPending   = errors.New("data subject request was already reviewed")
+	ErrSelfReview          = errors.New("data subject request must be reviewed by another admin than the requester")
+	ErrExportNotAvailable  = errors.New("data subject request has no export")
+)
+
+// erasedEmailDomain is the reserved domain of the pseudonymized email addresses, they never match a real address
+const erasedEmailDomain = "erased.invalid"
+
+// updateAttempts is the number of times an approval list is pseudonymized when it is changed concurrently
+const updateAttempts = 3
+
+// jobActor is the actor of the audit entries added by the data subject job
+const jobActor = "data subject job"
+
+// the event columns holding the name or the free text description of the event
+var (
+	eventUserColumns = []string{"event_user_name", "event_user_name_lower", "event_lf_username"}
+	eventTextColumns = []string{"event_data", "event_summary"}
+)
+
+// CreateRequestInput is the subject and the type of a new data subject request
+type CreateRequestInput struct {
+	RequestType    RequestType
+	UserID         string
+	Email          string
+	GithubUsername string
+	Reason         string
+}
+
+// EventsService is the part of the events service used to log the erased approval list entries
+type EventsService interface {
+	LogEvent(args *events.LogEventArgs)
+}
+
+// Service interface defines the data subject request functions
+type Service interface {
+	CreateRequest(ctx context.Context, input *CreateRequestInput, requestedBy string) (*Request, error)
+	GetRequest(ctx context.Context, requestID string) (*Request, error)
+	GetRequests(ctx context.Context, status Status) ([]*Request, error)
+	ApproveRequest(ctx context.Context, requestID, reviewer, note string) (*Request, error)
+	RejectRequest(ctx context.Context, requestID, reviewer, note string) (*Request, error)
+	GetExportURL(ctx context.Context, requestID, actor string) (string, error)
+	ProcessApprovedRequests(ctx context.Context) (*ProcessReport, error)
+}
+
+type service struct {
+	repo          Repository
+	storage       Storage
+	eventsService EventsService
+}
+
+// NewService creates a new data subject request service
+func NewService(repo Repository, storage Storage, eventsService EventsService) Service {
+	return &service{
+		repo:          repo,
+		storage:       storage,
+		eventsService: eventsService,
+	}
+}
+
+// CreateRequest records a new export or erasure request, nothing is exported or erased before another admin
+// approves the request
+func (s *service) CreateRequest(ctx context.Context, input *CreateRequestInput, requestedBy string) (*Request, error) {
+	if input.RequestType != RequestTypeExport && input.RequestType != RequestTypeErasure {
+		return nil, ErrInvalidRequestType
+	}
+	userID := strings.TrimSpace(input.UserID)
+	email := strings.TrimSpace(input.Email)
+	githubUsername := strings.TrimSpace(input.GithubUsername)
+	if userID == "" && email == "" && githubUsername == "" {
+		return nil, ErrNoSubjectIdentifier
+	}
+
+	requestID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	_, now := utils.CurrentTime()
+	request := &Request{
+		RequestID:      requestID.String(),
+		RequestType:    input.RequestType,
+		Status:         StatusPending,
+		UserID:         userID,
+		Email:          email,
+		GithubUsername: githubUsername,
+		Reason:         input.Reason,
+		RequestedBy:    requestedBy,
+		DateCreated:    now,
+		DateModified:   now,
+	}
+	addAuditEntry(request, requestedBy, AuditActionCreated, input.Reason)
+
+	err = s.repo.CreateRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// GetRequest returns the data subject request
+func (s *service) GetRequest(ctx context.Context, requestID string) (*Request, error) {
+	return s.repo.GetRequest(ctx, requestID)
+}
+
+// GetRequests returns the data subject requests with the status, the newest first
+func (s *service) GetRequests(ctx context.Context, status Status) ([]*Request, error) {
+	requests, err := s.repo.GetRequests(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].DateCreated > requests[j].DateCreated
+	})
+	return requests, nil
+}
+
+// RejectRequest closes the request without exporting or erasing anything
+func (s *service) RejectRequest(ctx context.Context, requestID, reviewer, note string) (*Request, error) {
+	request, err := s.reviewableRequest(ctx, requestID, reviewer)
+	if err != nil {
+		return nil, err
+	}
+	request.Status = StatusRejected
+	request.ReviewedBy = reviewer
+	addAuditEntry(request, reviewer, AuditActionRejected, note)
+	err = s.repo.UpdateRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ApproveRequest queues the export or the erasure of the request, the data subject job processes the approved
+// requests. A failed request can be approved again, the erasure skips the records it already pseudonymized.
+func (s *service) ApproveRequest(ctx context.Context, requestID, reviewer, note string) (*Request, error) {
+	request, err := s.reviewableRequest(ctx, requestID, reviewer)
+	if err != nil {
+		return nil, err
+	}
+	request.Status = StatusApproved
+	request.ReviewedBy = reviewer
+	request.Error = ""
+	addAuditEntry(request, reviewer, AuditActionApproved, note)
+	err = s.repo.UpdateRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ProcessApprovedRequests runs the export or the erasure of the approved requests, a failed request is recorded
+// and the next request is processed
+func (s *service) ProcessApprovedRequests(ctx context.Context) (*ProcessReport, error) {
+	f := logrus.Fields{
+		"functionName":   "ProcessApprovedRequests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	requests, err := s.GetRequests(ctx, StatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ProcessReport{}
+	// The oldest requests first
+	for i := len(requests) - 1; i >= 0; i-- {
+		err = s.processRequest(ctx, requests[i])
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem processing the data subject request %s", requests[i].RequestID)
+			report.Failed++
+			continue
+		}
+		report.Completed++
+	}
+	return report, nil
+}
+
+// processRequest runs the export or the erasure of the approved request and records the result
+func (s *service) processRequest(ctx context.Context, request *Request) error {
+	var summary *Summary
+	var err error
+	switch request.RequestType {
+	case RequestTypeExport:
+		summary, err = s.export(ctx, request)
+	case RequestTypeErasure:
+		summary, err = s.erase(ctx, request)
+	default:
+		err = ErrInvalidRequestType
+	}
+
+	_, now := utils.CurrentTime()
+	if err != nil {
+		request.Status = StatusFailed
+		request.Error = err.Error()
+		addAuditEntry(request, jobActor, AuditActionFailed, err.Error())
+	} else {
+		request.Status = StatusCompleted
+		request.Error = ""
+		request.Summary = summary
+		request.DateCompleted = now
+		addAuditEntry(request, jobActor, AuditActionCompleted, "")
+		if request.RequestType == RequestTypeErasure {
+			// The request must not keep the identifiers it erased
+			request.Email = ""
+			request.GithubUsername = ""
+		}
+	}
+	updateErr := s.repo.UpdateRequest(ctx, request)
+	if updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+// GetExportURL returns the download link of the export, each download is added to the audit trail
+func (s *service) GetExportURL(ctx context.Context, requestID, actor string) (string, error) {
+	request, err := s.repo.GetRequest(ctx, requestID)
+	if err != nil {
+		return "", err
+	}
+	if request.RequestType != RequestTypeExport || request.Status != StatusCompleted || request.ExportKey == "" {
+		return "", ErrExportNotAvailable
+	}
+	url, err := s.storage.GetPresignedURL(request.ExportKey)
+	if err != nil {
+		return "", err
+	}
+	addAuditEntry(request, actor, AuditActionExported, "")
+	err = s.repo.UpdateRequest(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	return url, nil
+}
+
+// reviewableRequest returns the request if it's waiting for a review by the reviewer
+func (s *service) reviewableRequest(ctx context.Context, requestID, reviewer string) (*Request, error) {
+	request, err := s.repo.GetRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != StatusPending && request.Status != StatusFailed {
+		return nil, ErrRequestNotPending
+	}
+	if strings.EqualFold(request.RequestedBy, reviewer) {
+		return nil, ErrSelfReview
+	}
+	return request, nil
+}
+
+// subjectRecords holds the records of the data subject
+type subjectRecords struct {
+	subject              *Subject
+	users                []Record
+	signatures           []Record
+	events               []Record
+	approvalLists        []*ApprovalList
+	approvalListRequests []Record
+}
+
+// loadSubject resolves the users of the request and loads the records tied to the users
+func (s *service) loadSubject(ctx context.Context, request *Request) (*subjectRecords, error) {
+	users, err := s.repo.GetUsers(ctx, request.UserID, request.Email, request.GithubUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := &Subject{}
+	if request.Email != "" {
+		subject.Emails = append(subject.Emails, request.Email)
+	}
+	if request.GithubUsername != "" {
+		subject.GithubUsernames = append(subject.GithubUsernames, request.GithubUsername)
+	}
+	for _, user := range users {
+		subject.UserIDs = appendMissing(subject.UserIDs, []string{stringValue(user, "user_id")})
+		subject.Names = appendMissing(subject.Names, []string{stringValue(user, "user_name")})
+		subject.LFUsernames = appendMissing(subject.LFUsernames, []string{stringValue(user, "lf_username")})
+		subject.Emails = appendMissing(subject.Emails, []string{stringValue(user, "lf_email")})
+		subject.Emails = appendMissing(subject.Emails, stringList(user, "user_emails"))
+		subject.GithubUsernames = appendMissing(subject.GithubUsernames, []string{stringValue(user, "user_github_username")})
+		subject.GithubIDs = appendMissing(subject.GithubIDs, []string{stringValue(user, "user_github_id")})
+	}
+
+	records := &subjectRecords{
+		subject: subject,
+		users:   users,
+	}
+	for _, userID := range subject.UserIDs {
+		signatures, err := s.repo.GetUserSignatures(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		records.signatures = append(records.signatures, signatures...)
+	}
+	events, err := s.repo.GetEvents(ctx, subject.UserIDs, subject.LFUsernames, subject.terms())
+	if err != nil {
+		return nil, err
+	}
+	// The scan matches a term anywhere in the text, only the events of the subject and the events mentioning an
+	// identifier of the subject as a whole word are kept
+	matcher := newTermReplacer(subject.terms(), "")
+	for _, event := range events {
+		if isSubjectEvent(subject, event) || matcher.matches(stringValue(event, "event_data")) || matcher.matches(stringValue(event, "event_summary")) {
+			records.events = append(records.events, event)
+		}
+	}
+	records.approvalLists, err = s.repo.GetApprovalLists(ctx, subject.Emails, subject.GithubUsernames)
+	if err != nil {
+		return nil, err
+	}
+	records.approvalListRequests, err = s.repo.GetApprovalListRequests(ctx, subject.UserIDs, subject.Emails)
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// export builds a zip archive of the records and the signed documents of the subject
+func (s *service) export(ctx context.Context, request *Request) (*Summary, error) {
+	f := logrus.Fields{
+		"functionName":   "export",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"requestID":      request.RequestID,
+	}
+
+	records, err := s.loadSubject(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the approval list entries of the subject are exported, the other entries belong to other people
+	var approvalLists []*ApprovalList
+	for _, approvalList := range records.approvalLists {
+		approvalLists = append(approvalLists, &ApprovalList{
+			SignatureID:        approvalList.SignatureID,
+			ProjectID:          approvalList.ProjectID,
+			CompanyID:          approvalList.CompanyID,
+			EmailApprovalList:  matching(approvalList.EmailApprovalList, records.subject.Emails),
+			GithubApprovalList: matching(approvalList.GithubApprovalList, records.subject.GithubUsernames),
+		})
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+	for name, content := range map[string]interface{}{
+		"subject.json":                records.subject,
+		"users.json":                  records.users,
+		"signatures.json":             records.signatures,
+		"events.json":                 records.events,
+		"approval_lists.json":         approvalLists,
+		"approval_list_requests.json": records.approvalListRequests,
+	} {
+		err = writeJSON(writer, name, content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	documents := 0
+	for _, signature := range records.signatures {
+		// Only the individual signatures have a signed document, the employee acknowledgements don't
+		if stringValue(signature, "signature_type") != "cla" || stringValue(signature, "signature_user_ccla_company_id") != "" {
+			continue
+		}
+		signatureID := stringValue(signature, "signature_id")
+		key := utils.SignedCLAFilename(stringValue(signature, "signature_project_id"), utils.ClaTypeICLA,
+			stringValue(signature, "signature_reference_id"), signatureID)
+		content, downloadErr := s.storage.Download(key)
+		if downloadErr != nil {
+			log.WithFields(f).WithError(downloadErr).Warnf("unable to download the signed document %s, skipping", key)
+			continue
+		}
+		fileWriter, createErr := writer.Create(fmt.Sprintf("documents/%s.pdf", signatureID))
+		if createErr != nil {
+			return nil, createErr
+		}
+		if _, err = fileWriter.Write(content); err != nil {
+			return nil, err
+		}
+		documents++
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	request.ExportKey = fmt.Sprintf("data-subject-requests/%s.zip", request.RequestID)
+	err = s.storage.Upload(request.ExportKey, buffer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Summary{
+		Users:                len(records.users),
+		Signatures:           len(records.signatures),
+		SignedDocuments:      documents,
+		Events:               len(records.events),
+		ApprovalLists:        len(approvalLists),
+		ApprovalListRequests: len(records.approvalListRequests),
+	}, nil
+}
+
+// erase pseudonymizes the users, the events, the approval list entries and the approval list requests of the
+// subject. The signatures and the signed documents are legal records and are kept, they keep referencing the
+// pseudonymized user ID.
+func (s *service) erase(ctx context.Context, request *Request) (*Summary, error) {
+	records, err := s.loadSubject(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if request.Pseudonym == "" {
+		request.Pseudonym = pseudonym(request.RequestID)
+	}
+	summary := &Summary{
+		Signatures: len(records.signatures),
+	}
+
+	replacer := newTermReplacer(records.subject.terms(), request.Pseudonym)
+	nameReplacer := newTermReplacer(records.subject.Names, request.Pseudonym)
+	for _, event := range records.events {
+		values := make(map[string]string)
+		subjectEvent := isSubjectEvent(records.subject, event)
+		if subjectEvent {
+			for _, column := range eventUserColumns {
+				if stringValue(event, column) != "" {
+					values[column] = request.Pseudonym
+				}
+			}
+		}
+		for _, column := range eventTextColumns {
+			text := stringValue(event, column)
+			replaced := replacer.replace(text)
+			if subjectEvent {
+				// The names of the subject are only replaced in the events of the subject, the same name in
+				// another event may be someone else's
+				replaced = nameReplacer.replace(replaced)
+			}
+			if replaced != text {
+				values[column] = replaced
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		err = s.repo.UpdateEvent(ctx, stringValue(event, "event_id"), values)
+		if err != nil {
+			return nil, err
+		}
+		summary.Events++
+	}
+
+	for _, approvalList := range records.approvalLists {
+		erased, eraseErr := s.eraseApprovalList(ctx, request, records.subject, approvalList)
+		if eraseErr != nil {
+			return nil, eraseErr
+		}
+		if erased {
+			summary.ApprovalLists++
+		}
+	}
+
+	for _, approvalListRequest := range records.approvalListRequests {
+		err = s.repo.PseudonymizeApprovalListRequest(ctx, stringValue(approvalListRequest, "request_id"), request.Pseudonym)
+		if err != nil {
+			return nil, err
+		}
+		summary.ApprovalListRequests++
+	}
+
+	// The users are pseudonymized last - the identifiers of the subject are resolved from the users, a failed
+	// erasure approved again still finds the records not pseudonymized yet
+	for _, user := range records.users {
+		err = s.repo.PseudonymizeUser(ctx, stringValue(user, "user_id"), request.Pseudonym)
+		if err != nil {
+			return nil, err
+		}
+		summary.Users++
+	}
+
+	return summary, nil
+}
+
+// eraseApprovalList replaces the emails and the GitHub usernames of the subject on the approval lists with the
+// pseudonym, returns true if the approval lists changed. The approval lists are only updated if they were not changed
+// since they were read, the approval lists are read again and pseudonymized again otherwise.
+func (s *service) eraseApprovalList(ctx context.Context, request *Request, subject *Subject, approvalList *ApprovalList) (bool, error) {
+	f := logrus.Fields{
+		"functionName":   "eraseApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"requestID":      request.RequestID,
+		"signatureID":    approvalList.SignatureID,
+	}
+
+	current := approvalList
+	for attempt := 1; ; attempt++ {
+		emails, emailsErased := pseudonymize(current.EmailApprovalList, subject.Emails, request.Pseudonym+"@"+erasedEmailDomain)
+		githubUsernames, githubErased := pseudonymize(current.GithubApprovalList, subject.GithubUsernames, request.Pseudonym)
+		if emailsErased == 0 && githubErased == 0 {
+			return false, nil
+		}
+		updated := *current
+		updated.EmailApprovalList = emails
+		updated.GithubApprovalList = githubUsernames
+		err := s.repo.UpdateApprovalList(ctx, current, &updated)
+		if err == nil {
+			s.eventsService.LogEvent(&events.LogEventArgs{
+				EventType: events.ClaApprovalListUpdated,
+				ProjectID: current.ProjectID,
+				CompanyID: current.CompanyID,
+				EventData: &events.CLAApprovalListEntriesErasedData{
+					RequestID:       request.RequestID,
+					Pseudonym:       request.Pseudonym,
+					Emails:          emailsErased,
+					GithubUsernames: githubErased,
+				},
+			})
+			return true, nil
+		}
+		if err != ErrApprovalListChanged || attempt == updateAttempts {
+			return false, err
+		}
+		log.WithFields(f).Debugf("the approval list was changed during the erasure, attempt %d of %d", attempt, updateAttempts)
+		current, err = s.repo.GetApprovalList(ctx, approvalList.SignatureID)
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// pseudonym returns the pseudonym of the subject of the request - the underscore keeps it from being a valid GitHub
+// username
+func pseudonym(requestID string) string {
+	sum := sha256.Sum256([]byte(requestID))
+	return "erased_" + hex.EncodeToString(sum[:])[:12]
+}
+
+// pseudonymize replaces the values of the list found in the identifiers with the pseudonym, returns the number of
+// values replaced
+func pseudonymize(list, identifiers []string, pseudonym string) ([]string, int) {
+	var result []string
+	replaced := 0
+	for _, value := range list {
+		if containsFold(identifiers, value) {
+			value = pseudonym
+			replaced++
+		}
+		if !containsString(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result, replaced
+}
+
+// matching returns the values of the list found in the identifiers
+func matching(list, identifiers []string) []string {
+	var result []string
+	for _, value := range list {
+		if containsFold(identifiers, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// termReplacer replaces the identifiers of the subject in free text, ignoring the case
+type termReplacer struct {
+	patterns    []*regexp.Regexp
+	replacement string
+}
+
+func newTermReplacer(terms []string, replacement string) *termReplacer {
+	// The longest terms first, an email address is replaced before the username it contains
+	sorted := append([]string(nil), terms...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+	replacer := &termReplacer{replacement: replacement}
+	for _, term := range sorted {
+		pattern := regexp.QuoteMeta(term)
+		// Only match whole words so a short name doesn't replace a part of another word
+		if isWordByte(term[0]) {
+			pattern = `\b` + pattern
+		}
+		if isWordByte(term[len(term)-1]) {
+			pattern = pattern + `\b`
+		}
+		replacer.patterns = append(replacer.patterns, regexp.MustCompile("(?i)"+pattern))
+	}
+	return replacer
+}
+
+// matches returns true if the text contains one of the terms
+func (r *termReplacer) matches(text string) bool {
+	for _, pattern := range r.patterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *termReplacer) replace(text string) string {
+	for _, pattern := range r.patterns {
+		text = pattern.ReplaceAllLiteralString(text, r.replacement)
+	}
+	return text
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+// isSubjectEvent returns true if the event was created by one of the users of the subject
+func isSubjectEvent(subject *Subject, event Record) bool {
+	return containsString(subject.UserIDs, stringValue(event, "event_user_id")) ||
+		containsFold(subject.LFUsernames, stringValue(event, "event_lf_username"))
+}
+
+// addAuditEntry appends a step to the audit trail of the request
+func addAuditEntry(request *Request, actor, action, note string) {
+	_, now := utils.CurrentTime()
+	request.DateModified = now
+	request.AuditTrail = append(request.AuditTrail, AuditEntry{
+		Date:   now,
+		Actor:  actor,
+		Action: action,
+		Note:   note,
+	})
+}
+
+// writeJSON adds the content as an indented JSON file to the archive
+func writeJSON(writer *zip.Writer, name string, content interface{}) error {
+	data, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return err
+	}
+	fileWriter, err := writer.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = fileWriter.Write(data)
+	return err
+}
+
+// stringValue returns the string value of the record column
+func stringValue(record Record, column string) string {
+	value, _ := record[column].(string)
+	return value
+}
+
+// stringList returns the values of the string set or list column of the record
+func stringList(record Record, column string) []string {
+	switch value := record[column].(type) {
+	case []string:
+		return value
+	case []interface{}:
+		var list []string
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// appendMissing appends the non empty values not in the list yet, ignoring the case
+func appendMissing(list, values []string) []string {
+	for _, value := range values {
+		if value != "" && !containsFold(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
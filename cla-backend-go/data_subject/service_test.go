@@ -0,0 +1,329 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package data_subject_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"sort"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/data_subject"
+	"github.com/communitybridge/easycla/cla-backend-go/data_subject/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// subjectFixtures sets up a user with two signatures, the events, the approval list and the approval list request of
+// the user and the signed document of the ICLA
+func subjectFixtures(repo *mock.MockRepository, storage *mock.MockStorage) {
+	repo.EXPECT().GetUsers(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]data_subject.Record{
+		{"user_id": "user-1", "user_name": "Jane Doe", "lf_username": "jdoe", "lf_email": "jane@example.com",
+			"user_emails": []string{"jane@users.noreply.github.com"}, "user_github_username": "janedoe", "user_github_id": "1234"},
+	}, nil).AnyTimes()
+	repo.EXPECT().GetUserSignatures(gomock.Any(), "user-1").Return([]data_subject.Record{
+		{"signature_id": "icla-1", "signature_type": "cla", "signature_project_id": "cla-group-1", "signature_reference_id": "user-1"},
+		{"signature_id": "ecla-1", "signature_type": "cla", "signature_project_id": "cla-group-1", "signature_reference_id": "user-1",
+			"signature_user_ccla_company_id": "company-1"},
+	}, nil).AnyTimes()
+	// only the user IDs, the LF usernames and the unique identifiers of the subject are searched, never the names
+	repo.EXPECT().GetEvents(gomock.Any(), []string{"user-1"}, []string{"jdoe"},
+		[]string{"jdoe", "jane@example.com", "jane@users.noreply.github.com", "janedoe", "1234"}).Return([]data_subject.Record{
+		{"event_id": "event-1", "event_user_id": "user-1", "event_user_name": "Jane Doe", "event_user_name_lower": "jane doe",
+			"event_data": "user [Jane Doe] signed the ICLA", "event_summary": "Jane Doe signed"},
+		{"event_id": "event-2", "event_user_id": "manager-1", "event_user_name": "Manager",
+			"event_data": "user [Manager] added jane@example.com and JaneDoe to the approval list", "event_summary": "Manager added Jane Doe"},
+		{"event_id": "event-3", "event_user_id": "manager-1", "event_user_name": "Manager",
+			"event_data": "user [Manager] added janedoes to the approval list", "event_summary": "approval list updated"},
+	}, nil).AnyTimes()
+	repo.EXPECT().GetApprovalLists(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*data_subject.ApprovalList{
+		{SignatureID: "ccla-1", ProjectID: "cla-group-1", CompanyID: "company-1",
+			EmailApprovalList: []string{"Jane@Example.com", "other@example.com"}, GithubApprovalList: []string{"janedoe", "other"}},
+	}, nil).AnyTimes()
+	repo.EXPECT().GetApprovalListRequests(gomock.Any(), gomock.Any(), gomock.Any()).Return([]data_subject.Record{
+		{"request_id": "approval-request-1", "user_id": "user-1", "user_name": "Jane Doe"},
+	}, nil).AnyTimes()
+	storage.EXPECT().Download("contract-group/cla-group-1/icla/user-1/icla-1.pdf").Return([]byte("signed document"), nil).AnyTimes()
+}
+
+// expectRequestUpdates stores the updated requests in the map
+func expectRequestUpdates(repo *mock.MockRepository, requests map[string]*data_subject.Request) {
+	repo.EXPECT().UpdateRequest(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, request *data_subject.Request) error {
+		copied := *request
+		requests[request.RequestID] = &copied
+		return nil
+	}).AnyTimes()
+}
+
+func TestCreateAndReviewRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	service := data_subject.NewService(repo, mock.NewMockStorage(ctrl), mock.NewMockEventsService(ctrl))
+
+	_, err := service.CreateRequest(context.Background(), &data_subject.CreateRequestInput{RequestType: "delete", UserID: "user-1"}, "admin-1")
+	assert.Equal(t, data_subject.ErrInvalidRequestType, err)
+	_, err = service.CreateRequest(context.Background(), &data_subject.CreateRequestInput{RequestType: data_subject.RequestTypeExport}, "admin-1")
+	assert.Equal(t, data_subject.ErrNoSubjectIdentifier, err)
+
+	repo.EXPECT().CreateRequest(gomock.Any(), gomock.Any()).Return(nil)
+	request, err := service.CreateRequest(context.Background(), &data_subject.CreateRequestInput{RequestType: data_subject.RequestTypeErasure, UserID: "user-1"}, "admin-1")
+	assert.Nil(t, err)
+	assert.Equal(t, data_subject.StatusPending, request.Status)
+
+	requests := map[string]*data_subject.Request{request.RequestID: request}
+	repo.EXPECT().GetRequest(gomock.Any(), request.RequestID).DoAndReturn(func(ctx context.Context, requestID string) (*data_subject.Request, error) {
+		copied := *requests[requestID]
+		return &copied, nil
+	}).AnyTimes()
+	expectRequestUpdates(repo, requests)
+
+	// the requester can't approve their own request
+	_, err = service.ApproveRequest(context.Background(), request.RequestID, "admin-1", "")
+	assert.Equal(t, data_subject.ErrSelfReview, err)
+
+	rejected, err := service.RejectRequest(context.Background(), request.RequestID, "admin-2", "not verified")
+	assert.Nil(t, err)
+	assert.Equal(t, data_subject.StatusRejected, rejected.Status)
+	assert.Equal(t, "admin-2", rejected.ReviewedBy)
+	assert.Equal(t, []string{data_subject.AuditActionCreated, data_subject.AuditActionRejected}, auditActions(rejected))
+
+	_, err = service.ApproveRequest(context.Background(), request.RequestID, "admin-2", "")
+	assert.Equal(t, data_subject.ErrRequestNotPending, err)
+}
+
+func TestApproveRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	// the approval only queues the request - loading or changing any record is an unexpected call
+	repo.EXPECT().GetRequest(gomock.Any(), "request-1").Return(&data_subject.Request{
+		RequestID: "request-1", RequestType: data_subject.RequestTypeErasure, Status: data_subject.StatusFailed, Error: "timeout", RequestedBy: "admin-1",
+	}, nil)
+	requests := make(map[string]*data_subject.Request)
+	expectRequestUpdates(repo, requests)
+
+	approved, err := data_subject.NewService(repo, mock.NewMockStorage(ctrl), mock.NewMockEventsService(ctrl)).ApproveRequest(context.Background(), "request-1", "admin-2", "retry")
+	assert.Nil(t, err)
+	assert.Equal(t, data_subject.StatusApproved, approved.Status)
+	assert.Equal(t, "admin-2", approved.ReviewedBy)
+	assert.Empty(t, approved.Error)
+	assert.Equal(t, []string{data_subject.AuditActionApproved}, auditActions(requests["request-1"]))
+}
+
+func TestProcessExportRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	storage := mock.NewMockStorage(ctrl)
+	subjectFixtures(repo, storage)
+
+	repo.EXPECT().GetRequests(gomock.Any(), data_subject.StatusApproved).Return([]*data_subject.Request{
+		{RequestID: "request-1", RequestType: data_subject.RequestTypeExport, Status: data_subject.StatusApproved, Email: "jane@example.com", RequestedBy: "admin-1", ReviewedBy: "admin-2"},
+	}, nil)
+	requests := make(map[string]*data_subject.Request)
+	expectRequestUpdates(repo, requests)
+	var archiveContent []byte
+	storage.EXPECT().Upload("data-subject-requests/request-1.zip", gomock.Any()).DoAndReturn(func(key string, content []byte) error {
+		archiveContent = content
+		return nil
+	})
+
+	service := data_subject.NewService(repo, storage, mock.NewMockEventsService(ctrl))
+	report, err := service.ProcessApprovedRequests(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &data_subject.ProcessReport{Completed: 1}, report)
+
+	completed := requests["request-1"]
+	assert.Equal(t, data_subject.StatusCompleted, completed.Status)
+	// the event mentioning another GitHub user whose name starts with the username of the subject isn't exported
+	assert.Equal(t, &data_subject.Summary{Users: 1, Signatures: 2, SignedDocuments: 1, Events: 2, ApprovalLists: 1, ApprovalListRequests: 1}, completed.Summary)
+
+	archive, err := zip.NewReader(bytes.NewReader(archiveContent), int64(len(archiveContent)))
+	assert.Nil(t, err)
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		reader, openErr := file.Open()
+		assert.Nil(t, openErr)
+		content, readErr := ioutil.ReadAll(reader)
+		assert.Nil(t, readErr)
+		files[file.Name] = string(content)
+	}
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"approval_list_requests.json", "approval_lists.json", "documents/icla-1.pdf", "events.json",
+		"signatures.json", "subject.json", "users.json"}, names)
+	assert.Equal(t, "signed document", files["documents/icla-1.pdf"])
+	// the entries of the other people on the approval list are not exported
+	assert.Contains(t, files["approval_lists.json"], "Jane@Example.com")
+	assert.NotContains(t, files["approval_lists.json"], "other@example.com")
+	assert.NotContains(t, files["events.json"], "janedoes")
+
+	repo.EXPECT().GetRequest(gomock.Any(), "request-1").Return(completed, nil)
+	storage.EXPECT().GetPresignedURL("data-subject-requests/request-1.zip").Return("https://example.com/data-subject-requests/request-1.zip", nil)
+	url, err := service.GetExportURL(context.Background(), "request-1", "admin-2")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://example.com/data-subject-requests/request-1.zip", url)
+	assert.Equal(t, []string{data_subject.AuditActionCompleted, data_subject.AuditActionExported}, auditActions(requests["request-1"]))
+}
+
+func TestProcessErasureRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	storage := mock.NewMockStorage(ctrl)
+	subjectFixtures(repo, storage)
+
+	repo.EXPECT().GetRequests(gomock.Any(), data_subject.StatusApproved).Return([]*data_subject.Request{
+		{RequestID: "request-1", RequestType: data_subject.RequestTypeErasure, Status: data_subject.StatusApproved, GithubUsername: "janedoe", RequestedBy: "admin-1", ReviewedBy: "admin-2"},
+	}, nil)
+	requests := make(map[string]*data_subject.Request)
+	expectRequestUpdates(repo, requests)
+
+	updatedEvents := make(map[string]map[string]string)
+	repo.EXPECT().UpdateEvent(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, eventID string, values map[string]string) error {
+		updatedEvents[eventID] = values
+		return nil
+	}).AnyTimes()
+	var approvalList *data_subject.ApprovalList
+	repo.EXPECT().UpdateApprovalList(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, current, updated *data_subject.ApprovalList) error {
+		// the update is conditional on the approval lists read
+		assert.Equal(t, []string{"Jane@Example.com", "other@example.com"}, current.EmailApprovalList)
+		approvalList = updated
+		return nil
+	})
+	erased := data_subject.Pseudonym("request-1")
+	eventsService := mock.NewMockEventsService(ctrl)
+	eventsService.EXPECT().LogEvent(&events.LogEventArgs{
+		EventType: events.ClaApprovalListUpdated,
+		ProjectID: "cla-group-1",
+		CompanyID: "company-1",
+		EventData: &events.CLAApprovalListEntriesErasedData{RequestID: "request-1", Pseudonym: erased, Emails: 1, GithubUsernames: 1},
+	})
+	// the users are pseudonymized last so a failed erasure approved again still resolves the identifiers of the subject
+	pseudonymizedUsers := make(map[string]string)
+	gomock.InOrder(
+		repo.EXPECT().PseudonymizeApprovalListRequest(gomock.Any(), "approval-request-1", erased).Return(nil),
+		repo.EXPECT().PseudonymizeUser(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, userID, pseudonym string) error {
+			pseudonymizedUsers[userID] = pseudonym
+			return nil
+		}),
+	)
+
+	report, err := data_subject.NewService(repo, storage, eventsService).ProcessApprovedRequests(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &data_subject.ProcessReport{Completed: 1}, report)
+
+	completed := requests["request-1"]
+	assert.Equal(t, data_subject.StatusCompleted, completed.Status)
+	assert.Empty(t, completed.GithubUsername)
+	assert.Equal(t, erased, completed.Pseudonym)
+	assert.Regexp(t, "^erased_[0-9a-f]{12}$", erased)
+	assert.Equal(t, map[string]string{"user-1": erased}, pseudonymizedUsers)
+	assert.Equal(t, map[string]string{
+		"event_user_name":       erased,
+		"event_user_name_lower": erased,
+		"event_data":            "user [" + erased + "] signed the ICLA",
+		"event_summary":         erased + " signed",
+	}, updatedEvents["event-1"])
+	// the name of the subject is only replaced in the events of the subject
+	assert.Equal(t, map[string]string{
+		"event_data": "user [Manager] added " + erased + " and " + erased + " to the approval list",
+	}, updatedEvents["event-2"])
+	// another GitHub user whose name starts with the username of the subject is left alone
+	assert.NotContains(t, updatedEvents, "event-3")
+
+	if assert.NotNil(t, approvalList) {
+		assert.Equal(t, []string{erased + "@erased.invalid", "other@example.com"}, approvalList.EmailApprovalList)
+		assert.Equal(t, []string{erased, "other"}, approvalList.GithubApprovalList)
+	}
+	assert.Equal(t, &data_subject.Summary{Users: 1, Signatures: 2, Events: 2, ApprovalLists: 1, ApprovalListRequests: 1}, completed.Summary)
+}
+
+func TestEraseChangedApprovalList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	storage := mock.NewMockStorage(ctrl)
+	subjectFixtures(repo, storage)
+
+	repo.EXPECT().GetRequests(gomock.Any(), data_subject.StatusApproved).Return([]*data_subject.Request{
+		{RequestID: "request-1", RequestType: data_subject.RequestTypeErasure, Status: data_subject.StatusApproved, UserID: "user-1", RequestedBy: "admin-1", ReviewedBy: "admin-2"},
+	}, nil)
+	requests := make(map[string]*data_subject.Request)
+	expectRequestUpdates(repo, requests)
+	repo.EXPECT().UpdateEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	repo.EXPECT().PseudonymizeApprovalListRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().PseudonymizeUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	eventsService := mock.NewMockEventsService(ctrl)
+	eventsService.EXPECT().LogEvent(gomock.Any())
+
+	// a CLA manager approves another email while the approval list is pseudonymized
+	erased := data_subject.Pseudonym("request-1")
+	var approvalList *data_subject.ApprovalList
+	gomock.InOrder(
+		repo.EXPECT().UpdateApprovalList(gomock.Any(), gomock.Any(), gomock.Any()).Return(data_subject.ErrApprovalListChanged),
+		repo.EXPECT().GetApprovalList(gomock.Any(), "ccla-1").Return(&data_subject.ApprovalList{
+			SignatureID: "ccla-1", ProjectID: "cla-group-1", CompanyID: "company-1",
+			EmailApprovalList: []string{"Jane@Example.com", "other@example.com", "new@example.com"}, GithubApprovalList: []string{"janedoe", "other"},
+		}, nil),
+		repo.EXPECT().UpdateApprovalList(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, current, updated *data_subject.ApprovalList) error {
+			approvalList = updated
+			return nil
+		}),
+	)
+
+	report, err := data_subject.NewService(repo, storage, eventsService).ProcessApprovedRequests(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &data_subject.ProcessReport{Completed: 1}, report)
+	if assert.NotNil(t, approvalList) {
+		assert.Equal(t, []string{erased + "@erased.invalid", "other@example.com", "new@example.com"}, approvalList.EmailApprovalList)
+	}
+}
+
+func TestProcessApprovedRequestsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	storage := mock.NewMockStorage(ctrl)
+
+	repo.EXPECT().GetRequests(gomock.Any(), data_subject.StatusApproved).Return([]*data_subject.Request{
+		{RequestID: "request-2", RequestType: data_subject.RequestTypeExport, Status: data_subject.StatusApproved, UserID: "user-2", DateCreated: "2020-02-01T00:00:00Z"},
+		{RequestID: "request-1", RequestType: data_subject.RequestTypeExport, Status: data_subject.StatusApproved, UserID: "user-1", DateCreated: "2020-01-01T00:00:00Z"},
+	}, nil)
+	requests := make(map[string]*data_subject.Request)
+	expectRequestUpdates(repo, requests)
+	gomock.InOrder(
+		repo.EXPECT().GetUsers(gomock.Any(), "user-1", "", "").Return(nil, errors.New("timeout")),
+		repo.EXPECT().GetUsers(gomock.Any(), "user-2", "", "").Return(nil, nil),
+	)
+	repo.EXPECT().GetEvents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().GetApprovalLists(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().GetApprovalListRequests(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	storage.EXPECT().Upload("data-subject-requests/request-2.zip", gomock.Any()).Return(nil)
+
+	// the failed request is recorded and the next request is processed
+	report, err := data_subject.NewService(repo, storage, mock.NewMockEventsService(ctrl)).ProcessApprovedRequests(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &data_subject.ProcessReport{Completed: 1, Failed: 1}, report)
+	assert.Equal(t, data_subject.StatusFailed, requests["request-1"].Status)
+	assert.Equal(t, "timeout", requests["request-1"].Error)
+	assert.Equal(t, []string{data_subject.AuditActionFailed}, auditActions(requests["request-1"]))
+	assert.Equal(t, data_subject.StatusCompleted, requests["request-2"].Status)
+}
+
+func auditActions(request *data_subject.Request) []string {
+	var actions []string
+	for _, entry := range request.AuditTrail {
+		actions = append(actions, entry.Action)
+	}
+	return actions
+}
@@ -0,0 +1,68 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package data_subject
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Storage reads the signed documents and stores the exports of the data subject requests
+type Storage interface {
+	Download(key string) ([]byte, error)
+	Upload(key string, content []byte) error
+	GetPresignedURL(key string) (string, error)
+}
+
+type s3Storage struct {
+	s3         *s3.S3
+	bucketName string
+}
+
+// NewStorage returns the storage backed by the signature files bucket
+func NewStorage(awsSession *session.Session, bucketName string) Storage {
+	return &s3Storage{
+		s3:         s3.New(awsSession),
+		bucketName: bucketName,
+	}
+}
+
+// Download returns the content of the file
+func (s *s3Storage) Download(key string) ([]byte, error) {
+	output, err := s.s3.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+	return ioutil.ReadAll(output.Body)
+}
+
+// Upload stores the file, the exports are encrypted at rest
+func (s *s3Storage) Upload(key string, content []byte) error {
+	_, err := s.s3.PutObject(&s3.PutObjectInput{
+		Bucket:               aws.String(s.bucketName),
+		Key:                  aws.String(key),
+		Body:                 bytes.NewReader(content),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAes256),
+	})
+	return err
+}
+
+// GetPresignedURL returns a short lived download link of the file
+func (s *s3Storage) GetPresignedURL(key string) (string, error) {
+	req, _ := s.s3.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	return req.Presign(utils.PresignedURLValidity)
+}
@@ -0,0 +1,26 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package domain_verification
+
+import (
+	"errors"
+)
+
+// HashCode exposes hashCode to the tests
+var HashCode = hashCode
+
+// NewTestService creates the service with the TXT records of the test, gmail.com is denied
+func NewTestService(repo Repository, txtRecords map[string][]string) Service {
+	return &service{
+		repo:          repo,
+		deniedDomains: func() []string { return []string{"gmail.com"} },
+		lookupTXT: func(name string) ([]string, error) {
+			records, ok := txtRecords[name]
+			if !ok {
+				return nil, errors.New("no such host")
+			}
+			return records, nil
+		},
+	}
+}
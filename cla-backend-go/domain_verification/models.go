@@ -0,0 +1,68 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package domain_verification
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// Method is how the company proves it owns the domain
+type Method string
+
+// verification methods
+const (
+	// MethodDNS expects the challenge token in a TXT record of the domain
+	MethodDNS Method = "dns"
+	// MethodEmail sends the challenge token to the admin address of the domain
+	MethodEmail Method = "email"
+)
+
+// Status is the state of a domain verification
+type Status string
+
+// verification status values
+const (
+	StatusPending  Status = "pending"
+	StatusVerified Status = "verified"
+)
+
+// DomainVerification is the database model for the company domain verifications table
+type DomainVerification struct {
+	CompanyID    string `dynamodbav:"company_id" json:"company_id"`
+	Domain       string `dynamodbav:"domain" json:"domain"`
+	Method       Method `dynamodbav:"method" json:"method"`
+	Status       Status `dynamodbav:"status" json:"status"`
+	Token        string `dynamodbav:"token" json:"token"`
+	RequestedBy  string `dynamodbav:"requested_by" json:"requested_by"`
+	DateCreated  string `dynamodbav:"date_created" json:"date_created"`
+	DateModified string `dynamodbav:"date_modified" json:"date_modified"`
+	DateVerified string `dynamodbav:"date_verified,omitempty" json:"date_verified,omitempty"`
+	// Expires is the epoch when a pending challenge is dropped by the table TTL - verified records don't expire
+	Expires int64 `dynamodbav:"expires,omitempty" json:"expires,omitempty"`
+}
+
+// isVerified returns true if the company proved it owns the domain
+func (v *DomainVerification) isVerified() bool {
+	return v.Status == StatusVerified
+}
+
+// ToModel converts the database model to the API model - the token is only returned for DNS challenges, the email
+// challenge token must come from the mailbox of the domain
+func (v *DomainVerification) ToModel() *models.DomainVerification {
+	verification := &models.DomainVerification{
+		CompanyID:    v.CompanyID,
+		Domain:       v.Domain,
+		Method:       string(v.Method),
+		Status:       string(v.Status),
+		RequestedBy:  v.RequestedBy,
+		DateCreated:  v.DateCreated,
+		DateModified: v.DateModified,
+		DateVerified: v.DateVerified,
+	}
+	if v.Method == MethodDNS && v.Status == StatusPending {
+		verification.DNSRecordName = dnsRecordName(v.Domain)
+		verification.DNSRecordValue = dnsRecordValue(v.Token)
+	}
+	return verification
+}
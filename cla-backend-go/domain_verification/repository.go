@@ -0,0 +1,189 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package domain_verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// errors
+var (
+	ErrDomainVerificationNotFound = errors.New("domain verification not found")
+)
+
+// Repository interface defines the functions for the company domain verifications data model
+type Repository interface {
+	GetDomainVerification(ctx context.Context, companyID, domain string) (*DomainVerification, error)
+	GetDomainVerifications(ctx context.Context, companyID string) ([]*DomainVerification, error)
+	PutDomainVerification(ctx context.Context, verification *DomainVerification) error
+	DeleteDomainVerification(ctx context.Context, companyID, domain string) error
+}
+
+type repository struct {
+	stage          string
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewRepository creates a new instance of the company domain verifications repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:          stage,
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-company-domain-verifications", stage),
+	}
+}
+
+// GetDomainVerification returns the verification of the company domain, returns ErrDomainVerificationNotFound if
+// the company never started a verification of the domain or the challenge expired
+func (repo *repository) GetDomainVerification(ctx context.Context, companyID, domain string) (*DomainVerification, error) {
+	f := logrus.Fields{
+		"functionName":   "GetDomainVerification",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"companyID":      companyID,
+		"domain":         domain,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+			"domain": {
+				S: aws.String(domain),
+			},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load domain verification, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrDomainVerificationNotFound
+	}
+
+	var verification DomainVerification
+	err = dynamodbattribute.UnmarshalMap(result.Item, &verification)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling domain verification table data, error: %v", err)
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// GetDomainVerifications returns the verifications of all the domains of the company
+func (repo *repository) GetDomainVerifications(ctx context.Context, companyID string) ([]*DomainVerification, error) {
+	f := logrus.Fields{
+		"functionName":   "GetDomainVerifications",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"companyID":      companyID,
+	}
+
+	condition := expression.Key("company_id").Equal(expression.Value(companyID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for domain verifications query, error: %v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.tableName),
+	}
+	var resultList []map[string]*dynamodb.AttributeValue
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).Warnf("error retrieving domain verifications, error: %v", err)
+			return nil, err
+		}
+		resultList = append(resultList, results.Items...)
+		if len(results.LastEvaluatedKey) != 0 {
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+
+	var verifications []*DomainVerification
+	err = dynamodbattribute.UnmarshalListOfMaps(resultList, &verifications)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling domain verifications from database, error: %v", err)
+		return nil, err
+	}
+	return verifications, nil
+}
+
+// PutDomainVerification creates or replaces the domain verification
+func (repo *repository) PutDomainVerification(ctx context.Context, verification *DomainVerification) error {
+	f := logrus.Fields{
+		"functionName":   "PutDomainVerification",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"companyID":      verification.CompanyID,
+		"domain":         verification.Domain,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(verification)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal domain verification, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store domain verification, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteDomainVerification removes the domain verification
+func (repo *repository) DeleteDomainVerification(ctx context.Context, companyID, domain string) error {
+	f := logrus.Fields{
+		"functionName":   "DeleteDomainVerification",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"companyID":      companyID,
+		"domain":         domain,
+	}
+
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+			"domain": {
+				S: aws.String(domain),
+			},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to delete domain verification, error: %+v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,369 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package domain_verification
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+const (
+	// challengeTTL is how long a company has to complete a verification before the challenge expires
+	challengeTTL = 7 * 24 * time.Hour
+
+	dnsRecordPrefix      = "_easycla-challenge."
+	dnsRecordValuePrefix = "easycla-domain-verification="
+	adminMailbox         = "admin"
+)
+
+// errors
+var (
+	ErrInvalidDomain        = errors.New("invalid domain")
+	ErrDomainDenied         = errors.New("domain is a public email domain and can't be verified")
+	ErrDomainNotVerified    = errors.New("domain has not been verified by the company")
+	ErrUnknownMethod        = errors.New("unknown domain verification method")
+	ErrChallengeFailed      = errors.New("domain verification challenge failed")
+	ErrNoPendingChallenge   = errors.New("no pending domain verification challenge")
+	ErrChallengeEmailFailed = errors.New("unable to send the domain verification email")
+)
+
+// Service interface defines the company domain verification service methods
+type Service interface {
+	StartVerification(ctx context.Context, companyModel *models.Company, domain string, method Method, requestedBy string) (*DomainVerification, error)
+	VerifyDomain(ctx context.Context, companyID, domain, code string) (*DomainVerification, error)
+	GetDomainVerifications(ctx context.Context, companyID string) ([]*DomainVerification, error)
+	DeleteDomainVerification(ctx context.Context, companyID, domain string) error
+	CheckApprovalListDomains(ctx context.Context, companyID string, domains []string) error
+}
+
+type service struct {
+	repo          Repository
+	deniedDomains func() []string
+	lookupTXT     func(name string) ([]string, error)
+}
+
+// NewService creates a new company domain verification service - the denied domains are read from the current
+// configuration so changes are applied when the configuration is reloaded
+func NewService(repo Repository) Service {
+	return &service{
+		repo:          repo,
+		deniedDomains: func() []string { return config.GetConfig().DeniedDomains },
+		lookupTXT:     net.LookupTXT,
+	}
+}
+
+// StartVerification creates a new challenge for the company domain. A DNS challenge returns the TXT record the
+// company must publish, an email challenge sends a code to the admin mailbox of the domain. Starting a new challenge
+// replaces any pending challenge, a verified domain is returned as is.
+func (s *service) StartVerification(ctx context.Context, companyModel *models.Company, domain string, method Method, requestedBy string) (*DomainVerification, error) {
+	f := logrus.Fields{
+		"functionName":   "StartVerification",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyModel.CompanyID,
+		"domain":         domain,
+		"method":         method,
+		"requestedBy":    requestedBy,
+	}
+
+	domain, err := s.validateDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+	if method != MethodDNS && method != MethodEmail {
+		return nil, ErrUnknownMethod
+	}
+
+	existing, err := s.repo.GetDomainVerification(ctx, companyModel.CompanyID, domain)
+	if err != nil && !errors.Is(err, ErrDomainVerificationNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.isVerified() {
+		log.WithFields(f).Debug("domain is already verified")
+		return existing, nil
+	}
+
+	now, nowStr := utils.CurrentTime()
+	verification := &DomainVerification{
+		CompanyID:    companyModel.CompanyID,
+		Domain:       domain,
+		Method:       method,
+		Status:       StatusPending,
+		RequestedBy:  requestedBy,
+		DateCreated:  nowStr,
+		DateModified: nowStr,
+		Expires:      now.Add(challengeTTL).Unix(),
+	}
+
+	var code string
+	if method == MethodDNS {
+		verification.Token, err = generateToken()
+	} else {
+		// Only the hash of the emailed code is stored, the code itself is only known to the domain admin
+		code, err = generateCode()
+		verification.Token = hashCode(code)
+	}
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate the domain verification token")
+		return nil, err
+	}
+
+	if err = s.repo.PutDomainVerification(ctx, verification); err != nil {
+		return nil, err
+	}
+
+	if method == MethodEmail {
+		if err = sendChallengeEmail(companyModel, domain, code); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to send the domain verification email")
+			return nil, ErrChallengeEmailFailed
+		}
+	}
+
+	log.WithFields(f).Debug("started domain verification")
+	return verification, nil
+}
+
+// VerifyDomain completes the pending challenge of the company domain - a DNS challenge looks up the TXT record, an
+// email challenge compares the code sent to the admin mailbox
+func (s *service) VerifyDomain(ctx context.Context, companyID, domain, code string) (*DomainVerification, error) {
+	f := logrus.Fields{
+		"functionName":   "VerifyDomain",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+		"domain":         domain,
+	}
+
+	domain, err := s.validateDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	verification, err := s.repo.GetDomainVerification(ctx, companyID, domain)
+	if err != nil {
+		if errors.Is(err, ErrDomainVerificationNotFound) {
+			return nil, ErrNoPendingChallenge
+		}
+		return nil, err
+	}
+	if verification.isVerified() {
+		return verification, nil
+	}
+	// The table TTL removes expired challenges eventually, not immediately
+	if verification.Expires != 0 && time.Now().Unix() > verification.Expires {
+		return nil, ErrNoPendingChallenge
+	}
+
+	switch verification.Method {
+	case MethodDNS:
+		if !s.hasChallengeRecord(domain, verification.Token) {
+			log.WithFields(f).Debugf("TXT record %s does not contain the challenge token", dnsRecordName(domain))
+			return nil, ErrChallengeFailed
+		}
+	case MethodEmail:
+		if subtle.ConstantTimeCompare([]byte(hashCode(code)), []byte(verification.Token)) != 1 {
+			log.WithFields(f).Debug("email challenge code does not match")
+			return nil, ErrChallengeFailed
+		}
+	default:
+		return nil, ErrUnknownMethod
+	}
+
+	_, nowStr := utils.CurrentTime()
+	verification.Status = StatusVerified
+	verification.Token = ""
+	verification.DateModified = nowStr
+	verification.DateVerified = nowStr
+	verification.Expires = 0
+	if err = s.repo.PutDomainVerification(ctx, verification); err != nil {
+		return nil, err
+	}
+
+	log.WithFields(f).Debug("verified domain")
+	return verification, nil
+}
+
+// GetDomainVerifications returns the pending and verified domains of the company
+func (s *service) GetDomainVerifications(ctx context.Context, companyID string) ([]*DomainVerification, error) {
+	verifications, err := s.repo.GetDomainVerifications(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	var current []*DomainVerification
+	for _, verification := range verifications {
+		if verification.Expires != 0 && now > verification.Expires {
+			continue
+		}
+		current = append(current, verification)
+	}
+	return current, nil
+}
+
+// DeleteDomainVerification removes the pending or verified domain of the company - domains already on an approval
+// list are kept, only new approval list entries require the verification
+func (s *service) DeleteDomainVerification(ctx context.Context, companyID, domain string) error {
+	domain = normalizeDomain(domain)
+	if _, err := s.repo.GetDomainVerification(ctx, companyID, domain); err != nil {
+		return err
+	}
+	return s.repo.DeleteDomainVerification(ctx, companyID, domain)
+}
+
+// CheckApprovalListDomains confirms the company may add the domains to an approval list - a domain is rejected if
+// it is on the denied domains list, or neither the domain nor one of its parent domains has been verified by the
+// company. A wildcard entry, e.g. *.example.com, requires example.com to be verified.
+func (s *service) CheckApprovalListDomains(ctx context.Context, companyID string, domains []string) error {
+	if len(domains) == 0 {
+		return nil
+	}
+
+	verifications, err := s.repo.GetDomainVerifications(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	verified := make(map[string]bool, len(verifications))
+	for _, verification := range verifications {
+		if verification.isVerified() {
+			verified[verification.Domain] = true
+		}
+	}
+
+	for _, entry := range domains {
+		domain, err := s.validateDomain(entry)
+		if err != nil {
+			return fmt.Errorf("%s: %w", entry, err)
+		}
+		if !isCovered(domain, verified) {
+			return fmt.Errorf("%s: %w", entry, ErrDomainNotVerified)
+		}
+	}
+	return nil
+}
+
+// validateDomain returns the normalized domain, or an error if the domain is malformed or denied
+func (s *service) validateDomain(domain string) (string, error) {
+	domain = normalizeDomain(domain)
+	if msg, ok := utils.ValidDomain(domain); !ok || !strings.Contains(domain, ".") {
+		return "", fmt.Errorf("%w: %s %s", ErrInvalidDomain, domain, msg)
+	}
+
+	denied := make(map[string]bool)
+	for _, deniedDomain := range s.deniedDomains() {
+		denied[deniedDomain] = true
+	}
+	if isCovered(domain, denied) {
+		return "", ErrDomainDenied
+	}
+	return domain, nil
+}
+
+// hasChallengeRecord returns true if the challenge TXT record of the domain contains the token
+func (s *service) hasChallengeRecord(domain, token string) bool {
+	records, err := s.lookupTXT(dnsRecordName(domain))
+	if err != nil {
+		return false
+	}
+	expected := dnsRecordValue(token)
+	for _, record := range records {
+		if strings.TrimSpace(record) == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// isCovered returns true if the domain, or one of its parent domains, is in the set
+func isCovered(domain string, domains map[string]bool) bool {
+	for {
+		if domains[domain] {
+			return true
+		}
+		index := strings.Index(domain, ".")
+		if index < 0 {
+			return false
+		}
+		domain = domain[index+1:]
+		// Stop at the top level domain
+		if !strings.Contains(domain, ".") {
+			return false
+		}
+	}
+}
+
+// normalizeDomain lower cases the domain and removes the wildcard prefix of approval list entries
+func normalizeDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	domain = strings.TrimPrefix(domain, "*.")
+	return strings.TrimSuffix(domain, ".")
+}
+
+// dnsRecordName returns the name of the TXT record holding the challenge token
+func dnsRecordName(domain string) string {
+	return dnsRecordPrefix + domain
+}
+
+// dnsRecordValue returns the value of the TXT record holding the challenge token
+func dnsRecordValue(token string) string {
+	return dnsRecordValuePrefix + token
+}
+
+// generateToken returns a new random DNS challenge token
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// generateCode returns a new random email challenge code - short enough to be typed in
+func generateCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.EncodeToString(b), nil
+}
+
+// hashCode returns the hash stored for the email challenge code
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToUpper(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
+
+// sendChallengeEmail sends the challenge code to the admin mailbox of the domain
+func sendChallengeEmail(companyModel *models.Company, domain, code string) error {
+	subject := fmt.Sprintf("EasyCLA: Domain Verification for %s", domain)
+	recipients := []string{fmt.Sprintf("%s@%s", adminMailbox, domain)}
+	body := fmt.Sprintf(`
+<p>Hello %s Administrator,</p>
+<p>This is a notification email from EasyCLA regarding the domain %s.</p>
+<p>A CLA Manager of %s requested to approve all the contributors with an email address of %s. To confirm %s owns
+this domain, please share the following verification code with the CLA Manager:</p>
+<p><b>%s</b></p>
+<p>The code expires in %d days. If you do not recognize this request, you can ignore this email.</p>
+%s
+%s`,
+		domain, domain, companyModel.CompanyName, domain, companyModel.CompanyName, code, int(challengeTTL.Hours()/24),
+		utils.GetEmailHelpContent(true), utils.GetEmailSignOffContent())
+
+	return utils.SendEmail(subject, body, recipients)
+}
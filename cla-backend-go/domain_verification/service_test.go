@@ -0,0 +1,168 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package domain_verification_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/domain_verification"
+	"github.com/communitybridge/easycla/cla-backend-go/domain_verification/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeEmailSender struct {
+	body       string
+	recipients []string
+}
+
+func (s *fakeEmailSender) SendEmail(subject string, body string, recipients []string) error {
+	s.body = body
+	s.recipients = recipients
+	return nil
+}
+
+// expectStored returns the verification of acme.com stored for company-1 by the service
+func expectStored(repo *mock.MockRepository) func() *domain_verification.DomainVerification {
+	var stored *domain_verification.DomainVerification
+	repo.EXPECT().PutDomainVerification(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, verification *domain_verification.DomainVerification) error {
+		copied := *verification
+		stored = &copied
+		return nil
+	}).AnyTimes()
+	repo.EXPECT().GetDomainVerification(gomock.Any(), "company-1", "acme.com").DoAndReturn(func(ctx context.Context, companyID, domain string) (*domain_verification.DomainVerification, error) {
+		if stored == nil {
+			return nil, domain_verification.ErrDomainVerificationNotFound
+		}
+		copied := *stored
+		return &copied, nil
+	}).AnyTimes()
+	return func() *domain_verification.DomainVerification { return stored }
+}
+
+func TestDNSVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	repo := mock.NewMockRepository(ctrl)
+	stored := expectStored(repo)
+	txtRecords := map[string][]string{}
+	service := domain_verification.NewTestService(repo, txtRecords)
+	company := &models.Company{CompanyID: "company-1", CompanyName: "Acme"}
+
+	verification, err := service.StartVerification(ctx, company, " *.Acme.com ", domain_verification.MethodDNS, "manager")
+	assert.Nil(t, err)
+	assert.Equal(t, "acme.com", verification.Domain)
+	assert.Equal(t, domain_verification.StatusPending, verification.Status)
+	assert.Equal(t, verification, stored())
+	model := verification.ToModel()
+	assert.Equal(t, "_easycla-challenge.acme.com", model.DNSRecordName)
+
+	// the record has not been published yet
+	_, err = service.VerifyDomain(ctx, "company-1", "acme.com", "")
+	assert.Equal(t, domain_verification.ErrChallengeFailed, err)
+	assert.Equal(t, domain_verification.StatusPending, stored().Status)
+
+	txtRecords[model.DNSRecordName] = []string{"v=spf1 -all", model.DNSRecordValue}
+	verification, err = service.VerifyDomain(ctx, "company-1", "acme.com", "")
+	assert.Nil(t, err)
+	assert.Equal(t, domain_verification.StatusVerified, verification.Status)
+	assert.Empty(t, verification.Token)
+	assert.Zero(t, verification.Expires)
+	assert.Equal(t, verification, stored())
+}
+
+func TestCheckApprovalListDomains(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetDomainVerifications(gomock.Any(), "company-1").Return([]*domain_verification.DomainVerification{
+		{CompanyID: "company-1", Domain: "acme.com", Status: domain_verification.StatusVerified},
+		{CompanyID: "company-1", Domain: "acme.org", Status: domain_verification.StatusPending},
+	}, nil).AnyTimes()
+	repo.EXPECT().GetDomainVerifications(gomock.Any(), "company-2").Return(nil, nil)
+	service := domain_verification.NewTestService(repo, nil)
+
+	// sub domains and wildcards are covered by the verified domain, pending domains and other companies are not
+	assert.Nil(t, service.CheckApprovalListDomains(ctx, "company-1", []string{"acme.com", "*.acme.com", "eu.acme.com"}))
+	assert.Equal(t, domain_verification.ErrDomainNotVerified, errors.Unwrap(service.CheckApprovalListDomains(ctx, "company-1", []string{"acme.com", "acme.org"})))
+	assert.NotNil(t, service.CheckApprovalListDomains(ctx, "company-2", []string{"acme.com"}))
+}
+
+func TestEmailVerification(t *testing.T) {
+	ctx := context.Background()
+	sender := &fakeEmailSender{}
+	previous := utils.GetEmailSender()
+	utils.SetEmailSender(sender)
+	defer utils.SetEmailSender(previous)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	expectStored(repo)
+	service := domain_verification.NewTestService(repo, nil)
+	company := &models.Company{CompanyID: "company-1", CompanyName: "Acme"}
+
+	verification, err := service.StartVerification(ctx, company, "acme.com", domain_verification.MethodEmail, "manager")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"admin@acme.com"}, sender.recipients)
+	assert.Empty(t, verification.ToModel().DNSRecordValue)
+	code := regexp.MustCompile(`<b>([A-Z2-7]+)</b>`).FindStringSubmatch(sender.body)[1]
+	assert.NotContains(t, verification.Token, code)
+
+	_, err = service.VerifyDomain(ctx, "company-1", "acme.com", "WRONGCODE")
+	assert.Equal(t, domain_verification.ErrChallengeFailed, err)
+
+	verification, err = service.VerifyDomain(ctx, "company-1", "acme.com", code)
+	assert.Nil(t, err)
+	assert.Equal(t, domain_verification.StatusVerified, verification.Status)
+}
+
+func TestDeniedDomains(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	// denied and invalid domains are rejected before a verification is stored
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetDomainVerifications(gomock.Any(), "company-1").Return(nil, nil)
+	service := domain_verification.NewTestService(repo, nil)
+	company := &models.Company{CompanyID: "company-1"}
+
+	_, err := service.StartVerification(ctx, company, "GMail.com", domain_verification.MethodDNS, "manager")
+	assert.Equal(t, domain_verification.ErrDomainDenied, err)
+	_, err = service.StartVerification(ctx, company, "mail.gmail.com", domain_verification.MethodDNS, "manager")
+	assert.Equal(t, domain_verification.ErrDomainDenied, err)
+	_, err = service.StartVerification(ctx, company, "com", domain_verification.MethodDNS, "manager")
+	assert.True(t, errors.Is(err, domain_verification.ErrInvalidDomain))
+	_, err = service.StartVerification(ctx, company, "acme.com", domain_verification.Method("phone"), "manager")
+	assert.Equal(t, domain_verification.ErrUnknownMethod, err)
+
+	assert.True(t, errors.Is(service.CheckApprovalListDomains(ctx, "company-1", []string{"gmail.com"}), domain_verification.ErrDomainDenied))
+	assert.Nil(t, service.CheckApprovalListDomains(ctx, "company-1", nil))
+}
+
+func TestVerifyExpiredChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	expired := &domain_verification.DomainVerification{
+		CompanyID: "company-1", Domain: "acme.com", Method: domain_verification.MethodEmail, Status: domain_verification.StatusPending, Token: domain_verification.HashCode("CODE"), Expires: 1,
+	}
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetDomainVerification(gomock.Any(), "company-1", "acme.com").Return(expired, nil)
+	repo.EXPECT().GetDomainVerifications(gomock.Any(), "company-1").Return([]*domain_verification.DomainVerification{expired}, nil)
+	service := domain_verification.NewTestService(repo, nil)
+
+	_, err := service.VerifyDomain(ctx, "company-1", "acme.com", "code")
+	assert.Equal(t, domain_verification.ErrNoPendingChallenge, err)
+	verifications, err := service.GetDomainVerifications(ctx, "company-1")
+	assert.Nil(t, err)
+	assert.Empty(t, verifications)
+}
@@ -5,6 +5,7 @@ package events
 
 import (
 	"fmt"
+	"strings"
 )
 
 // EventData returns event data string which is used for event logging and containsPII field
@@ -324,6 +325,278 @@ type ClaManagerRoleDeletedData struct {
 	UserEmail string
 }
 
+// FeatureFlagUpdatedEventData . . .
+type FeatureFlagUpdatedEventData struct {
+	FlagName        string
+	Enabled         bool
+	Stages          []string
+	FoundationSFIDs []string
+	ClaGroupIDs     []string
+	CompanyIDs      []string
+}
+
+// FeatureFlagDeletedEventData . . .
+type FeatureFlagDeletedEventData struct {
+	FlagName string
+}
+
+// APIKeyCreatedEventData . . .
+type APIKeyCreatedEventData struct {
+	APIKeyID       string
+	Name           string
+	FoundationSFID string
+	AccessLevel    string
+	ClaGroupIDs    []string
+}
+
+// APIKeyRevokedEventData . . .
+type APIKeyRevokedEventData struct {
+	APIKeyID       string
+	Name           string
+	FoundationSFID string
+}
+
+// APIKeyUsedEventData . . .
+type APIKeyUsedEventData struct {
+	APIKeyID string
+	Name     string
+	Method   string
+	Path     string
+}
+
+// WebhookSubscriptionCreatedEventData . . .
+type WebhookSubscriptionCreatedEventData struct {
+	SubscriptionID string
+	FoundationSFID string
+	URL            string
+}
+
+// WebhookSubscriptionUpdatedEventData . . .
+type WebhookSubscriptionUpdatedEventData struct {
+	SubscriptionID string
+	FoundationSFID string
+	URL            string
+	Disabled       bool
+}
+
+// WebhookSubscriptionDeletedEventData . . .
+type WebhookSubscriptionDeletedEventData struct {
+	SubscriptionID string
+	FoundationSFID string
+	URL            string
+}
+
+// RepositoryRenamedEventData . . .
+type RepositoryRenamedEventData struct {
+	OldRepositoryName string
+	RepositoryName    string
+}
+
+// RepositoryTransferredEventData . . .
+type RepositoryTransferredEventData struct {
+	OldRepositoryName string
+	RepositoryName    string
+	OldClaGroupID     string
+	NewClaGroupID     string
+	Disabled          bool
+}
+
+// RepositoryArchivedEventData . . .
+type RepositoryArchivedEventData struct {
+	RepositoryName string
+	Archived       bool
+}
+
+// RepositoryVisibilityChangedEventData . . .
+type RepositoryVisibilityChangedEventData struct {
+	RepositoryName string
+	Private        bool
+}
+
+// GithubAppInstallationEventData . . .
+type GithubAppInstallationEventData struct {
+	OrganizationName   string
+	InstallationStatus string
+	RepositoryNames    []string
+	Enabled            bool
+}
+
+// BranchProtectionPolicyUpdatedEventData . . .
+type BranchProtectionPolicyUpdatedEventData struct {
+	PolicyID       string
+	BranchPatterns []string
+	RequiredChecks []string
+}
+
+// BranchProtectionPolicyDeletedEventData . . .
+type BranchProtectionPolicyDeletedEventData struct {
+	PolicyID string
+}
+
+// CompanyMergedEventData . . .
+type CompanyMergedEventData struct {
+	DuplicateCompanyID        string
+	DuplicateCompanyName      string
+	SignaturesMoved           int
+	SignaturesMerged          int
+	ApprovalListRequestsMoved int
+	ClaManagerRequestsMoved   int
+	InvitesMoved              int
+}
+
+// CompanyDomainVerifiedEventData . . .
+type CompanyDomainVerifiedEventData struct {
+	Domain string
+	Method string
+}
+
+// UserMergedEventData . . .
+type UserMergedEventData struct {
+	MergedIntoUserID      string
+	MergedIntoUserName    string
+	DuplicateUserID       string
+	DuplicateUserName     string
+	IdentitiesLinked      int
+	SignaturesMoved       int
+	SignaturesInvalidated int
+}
+
+// DataSubjectRequestEventData . . . - the identifiers of the data subject are left out of the event
+type DataSubjectRequestEventData struct {
+	RequestID   string
+	RequestType string
+	Status      string
+}
+
+// CLAApprovalListEntryActivatedData event data model for a time-bound approval list entry which became valid
+type CLAApprovalListEntryActivatedData struct {
+	ListType  string
+	Value     string
+	ValidFrom string
+}
+
+// CLAApprovalListEntryExpiredData event data model for a time-bound approval list entry which expired
+type CLAApprovalListEntryExpiredData struct {
+	ListType   string
+	Value      string
+	ValidUntil string
+}
+
+// CLAApprovalListEntryMergedData event data model for an approval list entry of a duplicate company added to the
+// approval list of the surviving company
+type CLAApprovalListEntryMergedData struct {
+	ListType             string
+	Value                string
+	DuplicateCompanyID   string
+	DuplicateCompanyName string
+}
+
+// CLAApprovalListImportedData event data model for a bulk approval list import
+type CLAApprovalListImportedData struct {
+	Format    string
+	Added     int
+	Removed   int
+	Unchanged int
+	Invalid   int
+}
+
+// CLAApprovalListEntriesErasedData event data model for the approval list entries of a data subject replaced with
+// the pseudonym of an erasure request
+type CLAApprovalListEntriesErasedData struct {
+	RequestID       string
+	Pseudonym       string
+	Emails          int
+	GithubUsernames int
+}
+
+// ScimTokenCreatedEventData event data model for a SCIM token created by a CLA manager
+type ScimTokenCreatedEventData struct {
+	TokenID     string
+	TokenPrefix string
+}
+
+// ScimTokenRevokedEventData event data model for a revoked SCIM token
+type ScimTokenRevokedEventData struct {
+	TokenID     string
+	TokenPrefix string
+}
+
+// CLAManagerRequestApprovalRecordedEventData event data model for an approval step of a CLA manager request which
+// still needs more approvals
+type CLAManagerRequestApprovalRecordedEventData struct {
+	RequestID         string
+	CompanyName       string
+	ProjectName       string
+	UserName          string
+	UserEmail         string
+	ManagerName       string
+	ManagerEmail      string
+	Approvals         int
+	RequiredApprovals int
+}
+
+// CLAManagerRequestExpiredEventData event data model for a CLA manager request expired by the request job
+type CLAManagerRequestExpiredEventData struct {
+	RequestID   string
+	CompanyName string
+	ProjectName string
+	UserName    string
+	UserEmail   string
+}
+
+// CLAManagerApprovalPolicyUpdatedEventData event data model for the updated CLA manager approval policy of a company
+type CLAManagerApprovalPolicyUpdatedEventData struct {
+	RequiredApprovals int
+	ApproverRole      string
+	Approvers         []string
+	ExpiryDays        int
+	ReminderDays      int
+}
+
+// CLAManagerDelegateAddedEventData event data model for a CLA manager delegation granted or updated by a CLA manager
+type CLAManagerDelegateAddedEventData struct {
+	CompanyName string
+	ProjectName string
+	UserName    string
+	UserLFID    string
+	Scope       string
+	DateExpires string
+}
+
+// CLAManagerDelegateRemovedEventData event data model for a CLA manager delegation revoked by a CLA manager
+type CLAManagerDelegateRemovedEventData struct {
+	CompanyName string
+	ProjectName string
+	UserName    string
+	UserLFID    string
+}
+
+// CLAManagerOrphanedCCLADetectedEventData event data model for a CCLA signature without an active CLA manager
+type CLAManagerOrphanedCCLADetectedEventData struct {
+	CompanyName    string
+	ProjectName    string
+	Managers       []string
+	AdminsNotified int
+}
+
+// CLAManagerSuccessorAppointedEventData event data model for a CLA manager appointed by a company admin to an orphaned CCLA signature
+type CLAManagerSuccessorAppointedEventData struct {
+	CompanyName     string
+	ProjectName     string
+	UserName        string
+	UserLFID        string
+	RemovedManagers []string
+}
+
+// ContributorAffiliationChangedEventData event data model for a contributor who changed their company affiliation
+type ContributorAffiliationChangedEventData struct {
+	PreviousCompanyName   string
+	CompanyName           string
+	ProjectName           string
+	ApprovalListRequestID string
+	RemovalRequests       int
+}
+
 // GetEventDetailsString . . .
 func (ed *RepositoryAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("user [%s] added github repository [%s] to project [%s]", args.userName, ed.RepositoryName, args.projectName)
@@ -669,6 +942,261 @@ func (ed *ClaManagerRoleDeletedData) GetEventDetailsString(args *LogEventArgs) (
 
 // Event Summary started
 
+// GetEventDetailsString . . .
+func (ed *FeatureFlagUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] updated feature flag [%s] with enabled: %t, stages: %v, foundations: %v, CLA groups: %v, companies: %v",
+		args.userName, ed.FlagName, ed.Enabled, ed.Stages, ed.FoundationSFIDs, ed.ClaGroupIDs, ed.CompanyIDs)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *FeatureFlagDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] deleted feature flag [%s]", args.userName, ed.FlagName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *APIKeyCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] created %s API key [%s] with ID [%s] for foundation [%s]",
+		args.userName, ed.AccessLevel, ed.Name, ed.APIKeyID, ed.FoundationSFID)
+	if len(ed.ClaGroupIDs) > 0 {
+		data = data + fmt.Sprintf(" limited to CLA groups: %v", ed.ClaGroupIDs)
+	}
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *APIKeyRevokedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] revoked API key [%s] with ID [%s] for foundation [%s]",
+		args.userName, ed.Name, ed.APIKeyID, ed.FoundationSFID)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *APIKeyUsedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("API key [%s] with ID [%s] created by user [%s] was used for request: %s %s",
+		ed.Name, ed.APIKeyID, args.userName, ed.Method, ed.Path)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *WebhookSubscriptionCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] created webhook subscription with ID [%s] for URL [%s] for foundation [%s]",
+		args.userName, ed.SubscriptionID, ed.URL, ed.FoundationSFID)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *WebhookSubscriptionUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] updated webhook subscription with ID [%s] for URL [%s] for foundation [%s], disabled: %t",
+		args.userName, ed.SubscriptionID, ed.URL, ed.FoundationSFID, ed.Disabled)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *WebhookSubscriptionDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] deleted webhook subscription with ID [%s] for URL [%s] for foundation [%s]",
+		args.userName, ed.SubscriptionID, ed.URL, ed.FoundationSFID)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *RepositoryRenamedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] renamed github repository [%s] to [%s] for project [%s]", args.userName, ed.OldRepositoryName, ed.RepositoryName, args.projectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *RepositoryTransferredEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] transferred github repository [%s] to [%s], CLA group changed from [%s] to [%s]",
+		args.userName, ed.OldRepositoryName, ed.RepositoryName, ed.OldClaGroupID, ed.NewClaGroupID)
+	if ed.Disabled {
+		data = data + ", the repository was disabled as the new organization is not associated with a CLA group"
+	}
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *RepositoryArchivedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	action := "unarchived"
+	if ed.Archived {
+		action = "archived"
+	}
+	data := fmt.Sprintf("user [%s] %s github repository [%s] for project [%s]", args.userName, action, ed.RepositoryName, args.projectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *RepositoryVisibilityChangedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	visibility := "public"
+	if ed.Private {
+		visibility = "private"
+	}
+	data := fmt.Sprintf("user [%s] made github repository [%s] %s for project [%s]", args.userName, ed.RepositoryName, visibility, args.projectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *GithubAppInstallationEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] changed the github app installation of github organization [%s] to [%s]", args.userName, ed.OrganizationName, ed.InstallationStatus)
+	if len(ed.RepositoryNames) > 0 {
+		action := "disabled"
+		if ed.Enabled {
+			action = "enabled"
+		}
+		data = data + fmt.Sprintf(", %s github repositories [%s] for project [%s]", action, strings.Join(ed.RepositoryNames, ","), args.projectName)
+	}
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *BranchProtectionPolicyUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] updated branch protection policy [%s] with branch patterns [%s] and required checks [%s]",
+		args.userName, ed.PolicyID, strings.Join(ed.BranchPatterns, ","), strings.Join(ed.RequiredChecks, ","))
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *BranchProtectionPolicyDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] deleted branch protection policy [%s]", args.userName, ed.PolicyID)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CompanyMergedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] merged company [%s] with ID [%s] into company [%s] with ID [%s], signatures moved: %d, signatures merged: %d, approval list requests moved: %d, cla manager requests moved: %d, invites moved: %d",
+		args.userName, ed.DuplicateCompanyName, ed.DuplicateCompanyID, args.companyName, args.CompanyID,
+		ed.SignaturesMoved, ed.SignaturesMerged, ed.ApprovalListRequestsMoved, ed.ClaManagerRequestsMoved, ed.InvitesMoved)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CompanyDomainVerifiedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] verified domain [%s] using method [%s] for company [%s] with ID [%s]",
+		args.userName, ed.Domain, ed.Method, args.companyName, args.CompanyID)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *UserMergedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] merged user [%s] with ID [%s] into user [%s] with ID [%s], identities linked: %d, signatures moved: %d, signatures invalidated: %d",
+		args.userName, ed.DuplicateUserName, ed.DuplicateUserID, ed.MergedIntoUserName, ed.MergedIntoUserID,
+		ed.IdentitiesLinked, ed.SignaturesMoved, ed.SignaturesInvalidated)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *DataSubjectRequestEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] updated the data subject %s request [%s], status: %s",
+		args.userName, ed.RequestType, ed.RequestID, ed.Status)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAApprovalListEntryActivatedData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("EasyCLA added %s %s valid from %s to the approval list for Company: %s, Project: %s",
+		ed.ListType, ed.Value, ed.ValidFrom, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAApprovalListEntryExpiredData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("EasyCLA removed %s %s which expired on %s from the approval list for Company: %s, Project: %s",
+		ed.ListType, ed.Value, ed.ValidUntil, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAApprovalListEntryMergedData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("EasyCLA added %s %s merged from company %s (%s) to the approval list for Company: %s, Project: %s, merge run by user [%s]",
+		ed.ListType, ed.Value, ed.DuplicateCompanyName, ed.DuplicateCompanyID, args.companyName, args.projectName, args.userName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAApprovalListImportedData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager %s imported a %s approval list for Company: %s, Project: %s - added: %d, removed: %d, unchanged: %d, invalid: %d",
+		args.userName, ed.Format, args.companyName, args.projectName, ed.Added, ed.Removed, ed.Unchanged, ed.Invalid)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAApprovalListEntriesErasedData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("EasyCLA replaced %d email and %d GitHub username entries of the approval list for Company: %s, Project: %s with the pseudonym %s of the data subject request %s",
+		ed.Emails, ed.GithubUsernames, args.companyName, args.projectName, ed.Pseudonym, ed.RequestID)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *ScimTokenCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] created SCIM token [%s] with ID [%s] for Company: %s, Project: %s",
+		args.userName, ed.TokenPrefix, ed.TokenID, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *ScimTokenRevokedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] revoked SCIM token [%s] with ID [%s] for Company: %s, Project: %s",
+		args.userName, ed.TokenPrefix, ed.TokenID, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAManagerRequestApprovalRecordedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager Request [%s] for user [%s / %s] was approved by [%s / %s] for Company: %s, Project: %s - %d of %d approvals",
+		ed.RequestID, ed.UserName, ed.UserEmail, ed.ManagerName, ed.ManagerEmail, ed.CompanyName, ed.ProjectName, ed.Approvals, ed.RequiredApprovals)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAManagerRequestExpiredEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager Request [%s] for user [%s / %s] expired for Company: %s, Project: %s",
+		ed.RequestID, ed.UserName, ed.UserEmail, ed.CompanyName, ed.ProjectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAManagerApprovalPolicyUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] updated the CLA Manager approval policy for Company: %s - required approvals: %d, approver role: %s, approvers: %s, expiry days: %d, reminder days: %d",
+		args.userName, args.companyName, ed.RequiredApprovals, ed.ApproverRole, strings.Join(ed.Approvers, ","), ed.ExpiryDays, ed.ReminderDays)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAManagerDelegateAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s / %s] was added as CLA Manager delegate with scope: %s until: %s by [%s] for Company: %s, Project: %s",
+		ed.UserLFID, ed.UserName, ed.Scope, ed.DateExpires, args.userName, ed.CompanyName, ed.ProjectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAManagerDelegateRemovedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s / %s] was removed as CLA Manager delegate by [%s] for Company: %s, Project: %s",
+		ed.UserLFID, ed.UserName, args.userName, ed.CompanyName, ed.ProjectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAManagerOrphanedCCLADetectedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CCLA signature has no active CLA Manager - managers: [%s] for Company: %s, Project: %s, %d company admins notified",
+		strings.Join(ed.Managers, ","), ed.CompanyName, ed.ProjectName, ed.AdminsNotified)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAManagerSuccessorAppointedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s / %s] was appointed as successor CLA Manager by company admin [%s] for Company: %s, Project: %s, removed managers: [%s]",
+		ed.UserLFID, ed.UserName, args.userName, ed.CompanyName, ed.ProjectName, strings.Join(ed.RemovedManagers, ","))
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *ContributorAffiliationChangedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] changed the company affiliation from Company: %s to Company: %s, approval list request: %s created for Project: %s, %d approval list removals requested",
+		args.userName, ed.PreviousCompanyName, ed.CompanyName, ed.ApprovalListRequestID, ed.ProjectName, ed.RemovalRequests)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *RepositoryAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("user %s added github repository %s to project %s", args.userName, ed.RepositoryName, args.projectName)
@@ -1007,3 +1535,240 @@ func (ed *ClaManagerRoleDeletedData) GetEventSummaryString(args *LogEventArgs) (
 	data := fmt.Sprintf("user %s removed user %s from role: %s", args.userName, ed.UserName, ed.Role)
 	return data, false
 }
+
+// GetEventSummaryString . . .
+func (ed *FeatureFlagUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s updated feature flag %s with enabled: %t", args.userName, ed.FlagName, ed.Enabled)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *FeatureFlagDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s deleted feature flag %s", args.userName, ed.FlagName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *APIKeyCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s created %s API key %s for foundation %s", args.userName, ed.AccessLevel, ed.Name, ed.FoundationSFID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *APIKeyRevokedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s revoked API key %s for foundation %s", args.userName, ed.Name, ed.FoundationSFID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *APIKeyUsedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("API key %s was used for request: %s %s", ed.Name, ed.Method, ed.Path)
+	return data, false
+}
+
+// GetEventSummaryString . . .
+func (ed *WebhookSubscriptionCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s created a webhook subscription for %s for foundation %s", args.userName, ed.URL, ed.FoundationSFID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *WebhookSubscriptionUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s updated the webhook subscription for %s for foundation %s", args.userName, ed.URL, ed.FoundationSFID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *WebhookSubscriptionDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s deleted the webhook subscription for %s for foundation %s", args.userName, ed.URL, ed.FoundationSFID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *RepositoryRenamedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s renamed github repository %s to %s for project %s", args.userName, ed.OldRepositoryName, ed.RepositoryName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *RepositoryTransferredEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s transferred github repository %s to %s", args.userName, ed.OldRepositoryName, ed.RepositoryName)
+	if ed.Disabled {
+		data = data + ", the repository was disabled"
+	}
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *RepositoryArchivedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	action := "unarchived"
+	if ed.Archived {
+		action = "archived"
+	}
+	data := fmt.Sprintf("user %s %s github repository %s for project %s", args.userName, action, ed.RepositoryName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *RepositoryVisibilityChangedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	visibility := "public"
+	if ed.Private {
+		visibility = "private"
+	}
+	data := fmt.Sprintf("user %s made github repository %s %s for project %s", args.userName, ed.RepositoryName, visibility, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *GithubAppInstallationEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("the github app installation of github organization %s is %s", ed.OrganizationName, ed.InstallationStatus)
+	if len(ed.RepositoryNames) > 0 {
+		action := "disabled"
+		if ed.Enabled {
+			action = "enabled"
+		}
+		data = data + fmt.Sprintf(", %d github repositories were %s for project %s", len(ed.RepositoryNames), action, args.projectName)
+	}
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *BranchProtectionPolicyUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s updated branch protection policy %s", args.userName, ed.PolicyID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *BranchProtectionPolicyDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s deleted branch protection policy %s", args.userName, ed.PolicyID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CompanyMergedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s merged company %s into company %s", args.userName, ed.DuplicateCompanyName, args.companyName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CompanyDomainVerifiedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s verified the domain %s for company %s", args.userName, ed.Domain, args.companyName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *UserMergedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s merged user %s into user %s", args.userName, ed.DuplicateUserName, ed.MergedIntoUserName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *DataSubjectRequestEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s updated the data subject %s request %s, status: %s", args.userName, ed.RequestType, ed.RequestID, ed.Status)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAApprovalListEntryActivatedData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s %s became valid and was added to the approval list for Company: %s, Project: %s",
+		ed.ListType, ed.Value, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAApprovalListEntryExpiredData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s %s expired and was removed from the approval list for Company: %s, Project: %s",
+		ed.ListType, ed.Value, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAApprovalListEntryMergedData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s %s of company %s was added to the approval list for Company: %s, Project: %s when the companies were merged",
+		ed.ListType, ed.Value, ed.DuplicateCompanyName, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAApprovalListImportedData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager %s added %d and removed %d entries of the approval list for Company: %s, Project: %s with an import",
+		args.userName, ed.Added, ed.Removed, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAApprovalListEntriesErasedData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("entries of the approval list for Company: %s, Project: %s were erased for a data subject request",
+		args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *ScimTokenCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s created a SCIM token to sync the approval list for Company: %s, Project: %s",
+		args.userName, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *ScimTokenRevokedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s revoked a SCIM token of Company: %s, Project: %s",
+		args.userName, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAManagerRequestApprovalRecordedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s approved the CLA Manager request of %s for %s and %s - %d of %d approvals",
+		ed.ManagerName, ed.UserName, ed.CompanyName, ed.ProjectName, ed.Approvals, ed.RequiredApprovals)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAManagerRequestExpiredEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("the CLA Manager request of %s for %s and %s expired",
+		ed.UserName, ed.CompanyName, ed.ProjectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAManagerApprovalPolicyUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s updated the CLA Manager approval policy of %s",
+		args.userName, args.companyName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAManagerDelegateAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s was added as CLA Manager delegate with scope %s until %s for Company: %s, Project: %s",
+		ed.UserName, ed.Scope, ed.DateExpires, ed.CompanyName, ed.ProjectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAManagerDelegateRemovedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s was removed as CLA Manager delegate for Company: %s, Project: %s",
+		ed.UserName, ed.CompanyName, ed.ProjectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAManagerOrphanedCCLADetectedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("the CCLA signature has no active CLA Manager for Company: %s, Project: %s",
+		ed.CompanyName, ed.ProjectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAManagerSuccessorAppointedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user %s was appointed as successor CLA Manager for Company: %s, Project: %s",
+		ed.UserName, ed.CompanyName, ed.ProjectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *ContributorAffiliationChangedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("%s changed the company affiliation from %s to %s and requested to be added to the approval list for Project: %s",
+		args.userName, ed.PreviousCompanyName, ed.CompanyName, ed.ProjectName)
+	return data, true
+}
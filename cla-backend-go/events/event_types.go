@@ -36,8 +36,14 @@ const (
 	UserUpdated        = "user.updated"
 	UserDeleted        = "user.deleted"
 
-	RepositoryAdded    = "repository.added"
-	RepositoryDisabled = "repository.disabled"
+	RepositoryAdded       = "repository.added"
+	RepositoryDisabled    = "repository.disabled"
+	RepositoryRenamed     = "repository.renamed"
+	RepositoryTransferred = "repository.transferred"
+	RepositoryArchived    = "repository.archived"
+	RepositoryUnarchived  = "repository.unarchived"
+	RepositoryPrivatized  = "repository.privatized"
+	RepositoryPublicized  = "repository.publicized"
 
 	GerritRepositoryAdded   = "gerrit_repository.added"
 	GerritRepositoryDeleted = "gerrit_repository.deleted"
@@ -46,6 +52,11 @@ const (
 	GithubOrganizationDeleted = "github_organization.deleted"
 	GithubOrganizationUpdated = "github_organization.updated"
 
+	GithubAppInstalled   = "github_app.installed"
+	GithubAppUninstalled = "github_app.uninstalled"
+	GithubAppSuspended   = "github_app.suspended"
+	GithubAppUnsuspended = "github_app.unsuspended"
+
 	CompanyACLUserAdded       = "company_acl.user_added"
 	CompanyACLRequestAdded    = "company_acl.request_added"
 	CompanyACLRequestApproved = "company_acl.request_approved"
@@ -84,4 +95,45 @@ const (
 
 	ProjectServiceCLAEnabled  = "project.service.cla.enabled"
 	ProjectServiceCLADisabled = "project.service.cla.disabled"
+
+	FeatureFlagUpdated = "feature_flag.updated"
+	FeatureFlagDeleted = "feature_flag.deleted"
+
+	APIKeyCreated = "api_key.created"
+	APIKeyRevoked = "api_key.revoked"
+	APIKeyUsed    = "api_key.used"
+
+	WebhookSubscriptionCreated = "webhook_subscription.created"
+	WebhookSubscriptionUpdated = "webhook_subscription.updated"
+	WebhookSubscriptionDeleted = "webhook_subscription.deleted"
+
+	BranchProtectionPolicyUpdated = "branch_protection_policy.updated"
+	BranchProtectionPolicyDeleted = "branch_protection_policy.deleted"
+
+	CompanyMerged = "company.merged"
+
+	CompanyDomainVerified = "company.domain.verified"
+
+	UserMerged = "user.merged"
+
+	DataSubjectRequestCreated  = "data_subject_request.created"
+	DataSubjectRequestApproved = "data_subject_request.approved"
+	DataSubjectRequestRejected = "data_subject_request.rejected"
+
+	ClaApprovalListImported = "cla_manager.approval_list_imported"
+
+	ScimTokenCreated = "scim_token.created"
+	ScimTokenRevoked = "scim_token.revoked"
+
+	ClaManagerAccessRequestApprovalRecorded = "cla_manager.access_request_approval_recorded"
+	ClaManagerAccessRequestExpired          = "cla_manager.access_request_expired"
+	ClaManagerApprovalPolicyUpdated         = "cla_manager.approval_policy_updated"
+
+	ClaManagerDelegateAdded   = "cla_manager.delegate_added"
+	ClaManagerDelegateRemoved = "cla_manager.delegate_removed"
+
+	ClaManagerOrphanedCCLADetected = "cla_manager.orphaned_ccla_detected"
+	ClaManagerSuccessorAppointed   = "cla_manager.successor_appointed"
+
+	ContributorAffiliationChanged = "contributor.affiliation_changed"
 )
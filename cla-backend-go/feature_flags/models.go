@@ -0,0 +1,48 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package feature_flags
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// FeatureFlag is the database model for the feature flags table
+type FeatureFlag struct {
+	FlagName        string   `dynamodbav:"flag_name" json:"flag_name"`
+	Description     string   `dynamodbav:"description" json:"description"`
+	Enabled         bool     `dynamodbav:"enabled" json:"enabled"`
+	Stages          []string `dynamodbav:"stages" json:"stages"`
+	FoundationSFIDs []string `dynamodbav:"foundation_sfids" json:"foundation_sfids"`
+	ClaGroupIDs     []string `dynamodbav:"cla_group_ids" json:"cla_group_ids"`
+	CompanyIDs      []string `dynamodbav:"company_ids" json:"company_ids"`
+	DateCreated     string   `dynamodbav:"date_created" json:"date_created"`
+	DateModified    string   `dynamodbav:"date_modified" json:"date_modified"`
+	UpdatedBy       string   `dynamodbav:"updated_by" json:"updated_by"`
+	Version         string   `dynamodbav:"version" json:"version"`
+}
+
+// Scope identifies who a feature flag is being evaluated for - any of the values may be empty
+type Scope struct {
+	FoundationSFID string
+	ClaGroupID     string
+	CompanyID      string
+	CompanySFID    string
+}
+
+// ToModel converts the database model to the API model
+func (f *FeatureFlag) ToModel() *models.FeatureFlag {
+	return &models.FeatureFlag{
+		FlagName:           f.FlagName,
+		Description:        f.Description,
+		Enabled:            f.Enabled,
+		StageList:          f.Stages,
+		FoundationSfidList: f.FoundationSFIDs,
+		ClaGroupIDList:     f.ClaGroupIDs,
+		CompanyIDList:      f.CompanyIDs,
+		DateCreated:        f.DateCreated,
+		DateModified:       f.DateModified,
+		UpdatedBy:          f.UpdatedBy,
+		Version:            f.Version,
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package feature_flags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// errors
+var (
+	ErrFeatureFlagNotFound = errors.New("feature flag not found")
+)
+
+// Repository interface defines the functions for the feature flags data model
+type Repository interface {
+	GetFeatureFlag(ctx context.Context, flagName string) (*FeatureFlag, error)
+	GetFeatureFlags(ctx context.Context) ([]*FeatureFlag, error)
+	PutFeatureFlag(ctx context.Context, flag *FeatureFlag) error
+	DeleteFeatureFlag(ctx context.Context, flagName string) error
+}
+
+type repository struct {
+	stage          string
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewRepository creates a new instance of the feature flags repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:          stage,
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-feature-flags", stage),
+	}
+}
+
+// GetFeatureFlag returns the feature flag by name, returns ErrFeatureFlagNotFound if the flag has not been stored
+func (repo *repository) GetFeatureFlag(ctx context.Context, flagName string) (*FeatureFlag, error) {
+	f := logrus.Fields{
+		"functionName":   "GetFeatureFlag",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"flagName":       flagName,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"flag_name": {
+				S: aws.String(flagName),
+			},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load feature flag, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrFeatureFlagNotFound
+	}
+
+	var flag FeatureFlag
+	err = dynamodbattribute.UnmarshalMap(result.Item, &flag)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling feature flag table data, error: %v", err)
+		return nil, err
+	}
+	return &flag, nil
+}
+
+// GetFeatureFlags returns all the stored feature flags
+func (repo *repository) GetFeatureFlags(ctx context.Context) ([]*FeatureFlag, error) {
+	f := logrus.Fields{
+		"functionName":   "GetFeatureFlags",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		TableName: aws.String(repo.tableName),
+	}
+	var resultList []map[string]*dynamodb.AttributeValue
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput) //nolint
+		if err != nil {
+			log.WithFields(f).Warnf("error retrieving feature flags, error: %v", err)
+			return nil, err
+		}
+		resultList = append(resultList, results.Items...)
+		if len(results.LastEvaluatedKey) != 0 {
+			scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+
+	var flags []*FeatureFlag
+	err := dynamodbattribute.UnmarshalListOfMaps(resultList, &flags)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling feature flags from database, error: %v", err)
+		return nil, err
+	}
+	return flags, nil
+}
+
+// PutFeatureFlag creates or replaces the feature flag
+func (repo *repository) PutFeatureFlag(ctx context.Context, flag *FeatureFlag) error {
+	f := logrus.Fields{
+		"functionName":   "PutFeatureFlag",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"flagName":       flag.FlagName,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(flag)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal feature flag, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store feature flag, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteFeatureFlag removes the feature flag
+func (repo *repository) DeleteFeatureFlag(ctx context.Context, flagName string) error {
+	f := logrus.Fields{
+		"functionName":   "DeleteFeatureFlag",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"flagName":       flagName,
+	}
+
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"flag_name": {
+				S: aws.String(flagName),
+			},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to delete feature flag, error: %+v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package feature_flags
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// feature flag names
+const (
+	// LFStyleTemplate shows the LF Style Template in the list of available CLA templates
+	LFStyleTemplate = "lf-style-template"
+	// GithubOrgValidation verifies the authenticated GitHub user belongs to a GitHub organization before it is added to
+	// or removed from an approval list - disable to run functional tests locally
+	GithubOrgValidation = "github-org-validation"
+)
+
+// DefaultCacheTTL is how long the stored flags are cached before they are loaded again
+const DefaultCacheTTL = time.Minute
+
+// errors
+var (
+	ErrUnknownFeatureFlag = errors.New("unknown feature flag")
+)
+
+// Evaluator answers whether a feature flag is on for a given scope
+type Evaluator interface {
+	IsEnabled(ctx context.Context, flagName string, scope Scope) bool
+}
+
+// Service interface defines the feature flag service methods
+type Service interface {
+	Evaluator
+	GetFeatureFlags(ctx context.Context) ([]*FeatureFlag, error)
+	GetFeatureFlag(ctx context.Context, flagName string) (*FeatureFlag, error)
+	UpdateFeatureFlag(ctx context.Context, flagName string, input *models.FeatureFlagInput, updatedBy string) (*FeatureFlag, error)
+	DeleteFeatureFlag(ctx context.Context, flagName string) error
+}
+
+type service struct {
+	repo     Repository
+	stage    string
+	defaults map[string]bool
+	cacheTTL time.Duration
+
+	lock     sync.RWMutex
+	flags    map[string]*FeatureFlag
+	loadedAt time.Time
+}
+
+// NewService creates a new feature flag service. The defaults map contains every known flag and the value used when
+// the flag has not been stored.
+func NewService(repo Repository, stage string, defaults map[string]bool) Service {
+	return &service{
+		repo:     repo,
+		stage:    strings.ToLower(stage),
+		defaults: defaults,
+		cacheTTL: DefaultCacheTTL,
+	}
+}
+
+// IsEnabled returns true if the flag is on for the current stage and the specified scope - if the flag has not been
+// stored, or the flags cannot be loaded, the default value is returned
+func (s *service) IsEnabled(ctx context.Context, flagName string, scope Scope) bool {
+	flag, ok := s.cachedFlags(ctx)[flagName]
+	if !ok {
+		return s.defaults[flagName]
+	}
+	return flag.isEnabled(s.stage, scope)
+}
+
+// isEnabled evaluates the flag - the stage list limits where the flag applies, then the flag is either on for
+// everyone or only for the listed foundations, CLA groups and companies
+func (f *FeatureFlag) isEnabled(stage string, scope Scope) bool {
+	if len(f.Stages) > 0 && !containsFold(f.Stages, stage) {
+		return false
+	}
+	if f.Enabled {
+		return true
+	}
+	return (scope.FoundationSFID != "" && contains(f.FoundationSFIDs, scope.FoundationSFID)) ||
+		(scope.ClaGroupID != "" && contains(f.ClaGroupIDs, scope.ClaGroupID)) ||
+		(scope.CompanyID != "" && contains(f.CompanyIDs, scope.CompanyID)) ||
+		(scope.CompanySFID != "" && contains(f.CompanyIDs, scope.CompanySFID))
+}
+
+// cachedFlags returns the stored flags, loading them when the cache has expired
+func (s *service) cachedFlags(ctx context.Context) map[string]*FeatureFlag {
+	s.lock.RLock()
+	if s.flags != nil && time.Since(s.loadedAt) < s.cacheTTL {
+		defer s.lock.RUnlock()
+		return s.flags
+	}
+	s.lock.RUnlock()
+
+	f := logrus.Fields{
+		"functionName":   "cachedFlags",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	// Another caller may have refreshed the cache while we waited for the lock
+	if s.flags != nil && time.Since(s.loadedAt) < s.cacheTTL {
+		return s.flags
+	}
+
+	flags, err := s.repo.GetFeatureFlags(ctx)
+	if err != nil {
+		// Keep the previous values (or the defaults) rather than failing the caller, try again once the TTL expires
+		log.WithFields(f).WithError(err).Warn("unable to load feature flags - using the previously loaded values or the defaults")
+		if s.flags == nil {
+			s.flags = map[string]*FeatureFlag{}
+		}
+		s.loadedAt = time.Now()
+		return s.flags
+	}
+
+	s.flags = make(map[string]*FeatureFlag, len(flags))
+	for _, flag := range flags {
+		s.flags[flag.FlagName] = flag
+	}
+	s.loadedAt = time.Now()
+	return s.flags
+}
+
+// invalidate drops the cached flags so the next evaluation reloads them
+func (s *service) invalidate() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.flags = nil
+}
+
+// defaultFlag returns the built-in state of a known flag which has not been stored
+func (s *service) defaultFlag(flagName string) *FeatureFlag {
+	return &FeatureFlag{
+		FlagName:    flagName,
+		Description: "built-in default",
+		Enabled:     s.defaults[flagName],
+	}
+}
+
+// GetFeatureFlags returns all the known flags - flags which have not been stored are returned with their default value
+func (s *service) GetFeatureFlags(ctx context.Context) ([]*FeatureFlag, error) {
+	flags, err := s.repo.GetFeatureFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make(map[string]bool, len(flags))
+	for _, flag := range flags {
+		stored[flag.FlagName] = true
+	}
+	for flagName := range s.defaults {
+		if !stored[flagName] {
+			flags = append(flags, s.defaultFlag(flagName))
+		}
+	}
+
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].FlagName < flags[j].FlagName
+	})
+	return flags, nil
+}
+
+// GetFeatureFlag returns the flag by name - a known flag which has not been stored is returned with its default value
+func (s *service) GetFeatureFlag(ctx context.Context, flagName string) (*FeatureFlag, error) {
+	flag, err := s.repo.GetFeatureFlag(ctx, flagName)
+	if err == ErrFeatureFlagNotFound {
+		if _, ok := s.defaults[flagName]; ok {
+			return s.defaultFlag(flagName), nil
+		}
+	}
+	return flag, err
+}
+
+// UpdateFeatureFlag creates or updates the flag - only known flags may be stored
+func (s *service) UpdateFeatureFlag(ctx context.Context, flagName string, input *models.FeatureFlagInput, updatedBy string) (*FeatureFlag, error) {
+	f := logrus.Fields{
+		"functionName":   "UpdateFeatureFlag",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"flagName":       flagName,
+		"updatedBy":      updatedBy,
+	}
+
+	if _, ok := s.defaults[flagName]; !ok {
+		return nil, ErrUnknownFeatureFlag
+	}
+
+	_, now := utils.CurrentTime()
+	dateCreated := now
+	existing, err := s.repo.GetFeatureFlag(ctx, flagName)
+	if err != nil && err != ErrFeatureFlagNotFound {
+		return nil, err
+	}
+	if existing != nil {
+		dateCreated = existing.DateCreated
+	}
+
+	flag := &FeatureFlag{
+		FlagName:        flagName,
+		Description:     input.Description,
+		Enabled:         input.Enabled,
+		Stages:          input.StageList,
+		FoundationSFIDs: input.FoundationSfidList,
+		ClaGroupIDs:     input.ClaGroupIDList,
+		CompanyIDs:      input.CompanyIDList,
+		DateCreated:     dateCreated,
+		DateModified:    now,
+		UpdatedBy:       updatedBy,
+		Version:         "v1",
+	}
+
+	log.WithFields(f).Debugf("storing feature flag: %+v", flag)
+	if err := s.repo.PutFeatureFlag(ctx, flag); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	return flag, nil
+}
+
+// DeleteFeatureFlag removes the stored flag - the flag reverts to its default value
+func (s *service) DeleteFeatureFlag(ctx context.Context, flagName string) error {
+	if _, err := s.repo.GetFeatureFlag(ctx, flagName); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteFeatureFlag(ctx, flagName); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package feature_flags_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/communitybridge/easycla/cla-backend-go/feature_flags"
+	"github.com/communitybridge/easycla/cla-backend-go/feature_flags/mock"
+)
+
+func TestFeatureFlagIsEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	flag := &feature_flags.FeatureFlag{
+		FlagName:        "new-signing-flow",
+		Stages:          []string{"dev", "staging"},
+		FoundationSFIDs: []string{"foundation-1"},
+		ClaGroupIDs:     []string{"cla-group-1"},
+		CompanyIDs:      []string{"company-1"},
+	}
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetFeatureFlags(gomock.Any()).Return([]*feature_flags.FeatureFlag{flag}, nil).AnyTimes()
+	defaults := map[string]bool{"new-signing-flow": false}
+	dev := feature_flags.NewService(repo, "dev", defaults)
+	staging := feature_flags.NewService(repo, "staging", defaults)
+	prod := feature_flags.NewService(repo, "prod", defaults)
+
+	assert.True(t, dev.IsEnabled(ctx, flag.FlagName, feature_flags.Scope{FoundationSFID: "foundation-1"}))
+	assert.True(t, staging.IsEnabled(ctx, flag.FlagName, feature_flags.Scope{ClaGroupID: "cla-group-1"}))
+	assert.True(t, dev.IsEnabled(ctx, flag.FlagName, feature_flags.Scope{CompanySFID: "company-1"}))
+	assert.False(t, dev.IsEnabled(ctx, flag.FlagName, feature_flags.Scope{FoundationSFID: "foundation-2"}))
+	assert.False(t, dev.IsEnabled(ctx, flag.FlagName, feature_flags.Scope{}))
+	assert.False(t, prod.IsEnabled(ctx, flag.FlagName, feature_flags.Scope{FoundationSFID: "foundation-1"}))
+
+	flag.Enabled = true
+	assert.True(t, dev.IsEnabled(ctx, flag.FlagName, feature_flags.Scope{}))
+	assert.False(t, prod.IsEnabled(ctx, flag.FlagName, feature_flags.Scope{}))
+
+	flag.Stages = nil
+	assert.True(t, prod.IsEnabled(ctx, flag.FlagName, feature_flags.Scope{}))
+}
+
+func TestServiceIsEnabledDefaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	repo := mock.NewMockRepository(ctrl)
+	// the flags are cached between evaluations
+	repo.EXPECT().GetFeatureFlags(gomock.Any()).Return([]*feature_flags.FeatureFlag{{FlagName: feature_flags.LFStyleTemplate, Enabled: true}}, nil)
+	service := feature_flags.NewService(repo, "prod", map[string]bool{feature_flags.LFStyleTemplate: false, feature_flags.GithubOrgValidation: true})
+
+	assert.True(t, service.IsEnabled(ctx, feature_flags.LFStyleTemplate, feature_flags.Scope{}))
+	assert.True(t, service.IsEnabled(ctx, feature_flags.GithubOrgValidation, feature_flags.Scope{}))
+	assert.False(t, service.IsEnabled(ctx, "unknown-flag", feature_flags.Scope{}))
+
+	// Fall back to the defaults when the flags cannot be loaded
+	failing := mock.NewMockRepository(ctrl)
+	failing.EXPECT().GetFeatureFlags(gomock.Any()).Return(nil, errors.New("table not found"))
+	service = feature_flags.NewService(failing, "prod", map[string]bool{feature_flags.LFStyleTemplate: false})
+	assert.False(t, service.IsEnabled(ctx, feature_flags.LFStyleTemplate, feature_flags.Scope{}))
+}
+
+func TestServiceGetFeatureFlagsIncludesDefaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetFeatureFlags(gomock.Any()).Return([]*feature_flags.FeatureFlag{{FlagName: feature_flags.LFStyleTemplate, Enabled: true}}, nil)
+	service := feature_flags.NewService(repo, "dev", map[string]bool{feature_flags.LFStyleTemplate: false, feature_flags.GithubOrgValidation: true})
+
+	flags, err := service.GetFeatureFlags(context.Background())
+	assert.Nil(t, err)
+	if assert.Len(t, flags, 2) {
+		assert.Equal(t, feature_flags.GithubOrgValidation, flags[0].FlagName)
+		assert.True(t, flags[0].Enabled)
+		assert.Equal(t, feature_flags.LFStyleTemplate, flags[1].FlagName)
+	}
+
+	_, err = service.UpdateFeatureFlag(context.Background(), "unknown-flag", nil, "admin")
+	assert.Equal(t, feature_flags.ErrUnknownFeatureFlag, err)
+}
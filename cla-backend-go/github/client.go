@@ -74,7 +74,9 @@ func NewGithubAppClient(installationID int64) (*github.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return github.NewClient(&http.Client{Transport: itr}), nil
+	// the rate limit transport wraps the installation transport so only the installation calls are recorded and not
+	// the app calls creating the installation tokens
+	return github.NewClient(&http.Client{Transport: &rateLimitTransport{installationID: installationID, next: itr}}), nil
 }
 
 // NewGithubOauthClient creates github client from global accessToken
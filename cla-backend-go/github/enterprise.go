@@ -0,0 +1,317 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bradleyfalzon/ghinstallation"
+	"github.com/google/go-github/v32/github"
+	"golang.org/x/oauth2"
+)
+
+// EnterpriseHostHeader is the webhook header naming the GitHub Enterprise Server host which sent the event, github.com
+// doesn't send it
+const EnterpriseHostHeader = "X-GitHub-Enterprise-Host"
+
+// errors
+var (
+	ErrInvalidEnterpriseURL        = errors.New("invalid github enterprise server url")
+	ErrEnterpriseHostNotConfigured = errors.New("github enterprise server host is not configured")
+)
+
+// Installation identifies a GitHub App installation - the enterprise fields are empty for the installations on github.com
+type Installation struct {
+	EnterpriseBaseURL string
+	EnterpriseAppID   int64
+	InstallationID    int64
+}
+
+// IsEnterprise returns true if the installation is on a GitHub Enterprise Server host
+func (i Installation) IsEnterprise() bool {
+	return i.EnterpriseBaseURL != ""
+}
+
+// EnterpriseCredentials are the secrets of the EasyCLA GitHub App registered on a GitHub Enterprise Server host
+type EnterpriseCredentials struct {
+	AppPrivateKey     string
+	AccessToken       string
+	WebhookSecret     string
+	OAuthClientID     string
+	OAuthClientSecret string
+}
+
+// EnterpriseCredentialsLoader loads the credentials of the GitHub Enterprise Server host
+type EnterpriseCredentialsLoader func(host string) (*EnterpriseCredentials, error)
+
+// EnterpriseHostsLoader returns the GitHub Enterprise Server hosts of the GitHub organizations registered in EasyCLA
+type EnterpriseHostsLoader func() ([]string, error)
+
+const (
+	// enterpriseFailureTTL is how long a failed credentials load is cached, so a misconfigured host doesn't load its
+	// credentials on every request
+	enterpriseFailureTTL = 5 * time.Minute
+	// enterpriseHostsTTL is how long the registered GitHub Enterprise Server hosts are cached
+	enterpriseHostsTTL = time.Minute
+)
+
+// enterpriseCredentialsEntry is the cached credentials load of a host, ready is closed once the load is done
+type enterpriseCredentialsEntry struct {
+	ready       chan struct{}
+	credentials *EnterpriseCredentials
+	err         error
+	expires     time.Time
+}
+
+// expired returns true if the load failed and the failure is no longer cached, a load in progress isn't expired
+func (e *enterpriseCredentialsEntry) expired(now time.Time) bool {
+	select {
+	case <-e.ready:
+		return e.err != nil && !now.Before(e.expires)
+	default:
+		return false
+	}
+}
+
+var (
+	enterpriseLock        sync.Mutex
+	enterpriseLoader      EnterpriseCredentialsLoader
+	enterpriseCredentials = make(map[string]*enterpriseCredentialsEntry)
+
+	enterpriseHostsLoader  EnterpriseHostsLoader
+	enterpriseHosts        map[string]bool
+	enterpriseHostsExpires time.Time
+	enterpriseHostsLoading bool
+
+	enterpriseNow = time.Now
+)
+
+// InitEnterprise sets the function loading the credentials of the GitHub Enterprise Server hosts
+func InitEnterprise(loader EnterpriseCredentialsLoader) {
+	enterpriseLock.Lock()
+	defer enterpriseLock.Unlock()
+	enterpriseLoader = loader
+	enterpriseCredentials = make(map[string]*enterpriseCredentialsEntry)
+}
+
+// InitEnterpriseHosts sets the function loading the GitHub Enterprise Server hosts the webhook events are accepted
+// from, no enterprise host is accepted until it is set
+func InitEnterpriseHosts(loader EnterpriseHostsLoader) {
+	enterpriseLock.Lock()
+	defer enterpriseLock.Unlock()
+	enterpriseHostsLoader = loader
+	enterpriseHosts = nil
+	enterpriseHostsExpires = time.Time{}
+}
+
+// getEnterpriseCredentials returns the credentials of the host, they are loaded once per host and failed loads are
+// retried after enterpriseFailureTTL - the lock isn't held while loading, concurrent callers wait for the same load
+func getEnterpriseCredentials(host string) (*EnterpriseCredentials, error) {
+	enterpriseLock.Lock()
+	entry, ok := enterpriseCredentials[host]
+	if !ok || entry.expired(enterpriseNow()) {
+		if enterpriseLoader == nil {
+			enterpriseLock.Unlock()
+			return nil, ErrEnterpriseHostNotConfigured
+		}
+		entry = &enterpriseCredentialsEntry{ready: make(chan struct{})}
+		enterpriseCredentials[host] = entry
+		loader := enterpriseLoader
+		enterpriseLock.Unlock()
+
+		entry.credentials, entry.err = loadEnterpriseCredentials(loader, host)
+		if entry.err != nil {
+			entry.credentials = nil
+			entry.expires = enterpriseNow().Add(enterpriseFailureTTL)
+		}
+		close(entry.ready)
+		return entry.credentials, entry.err
+	}
+	enterpriseLock.Unlock()
+
+	<-entry.ready
+	return entry.credentials, entry.err
+}
+
+func loadEnterpriseCredentials(loader EnterpriseCredentialsLoader, host string) (*EnterpriseCredentials, error) {
+	credentials, err := loader(host)
+	if err != nil {
+		return nil, err
+	}
+	if credentials == nil || credentials.AppPrivateKey == "" {
+		return nil, fmt.Errorf("%s : %w", host, ErrEnterpriseHostNotConfigured)
+	}
+	return credentials, nil
+}
+
+// isRegisteredEnterpriseHost returns true if a GitHub organization registered in EasyCLA is on the host - the hosts
+// are cached for enterpriseHostsTTL, also when loading them fails, and the stale hosts are used while they reload
+func isRegisteredEnterpriseHost(host string) (bool, error) {
+	now := enterpriseNow()
+	enterpriseLock.Lock()
+	if enterpriseHostsLoader == nil {
+		enterpriseLock.Unlock()
+		return false, nil
+	}
+	if now.Before(enterpriseHostsExpires) || (enterpriseHostsLoading && enterpriseHosts != nil) {
+		registered := enterpriseHosts[host]
+		enterpriseLock.Unlock()
+		return registered, nil
+	}
+	enterpriseHostsLoading = true
+	loader := enterpriseHostsLoader
+	enterpriseLock.Unlock()
+
+	hosts, err := loader()
+
+	enterpriseLock.Lock()
+	defer enterpriseLock.Unlock()
+	enterpriseHostsLoading = false
+	enterpriseHostsExpires = now.Add(enterpriseHostsTTL)
+	if err != nil {
+		return enterpriseHosts[host], err
+	}
+	enterpriseHosts = make(map[string]bool)
+	for _, registeredHost := range hosts {
+		enterpriseHosts[strings.ToLower(registeredHost)] = true
+	}
+	return enterpriseHosts[host], nil
+}
+
+// NormalizeEnterpriseURL validates the base url of a GitHub Enterprise Server host and returns it as https://host
+func NormalizeEnterpriseURL(baseURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", ErrInvalidEnterpriseURL
+	}
+	if strings.Trim(u.Path, "/") != "" {
+		return "", ErrInvalidEnterpriseURL
+	}
+	host := strings.ToLower(u.Host)
+	if host == "github.com" || strings.HasSuffix(host, ".github.com") {
+		return "", ErrInvalidEnterpriseURL
+	}
+	return "https://" + host, nil
+}
+
+// EnterpriseHost returns the host of the GitHub Enterprise Server base url, as sent in the webhook headers
+func EnterpriseHost(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Host)
+}
+
+// enterpriseCredentialsForURL validates the base url and loads the credentials of its host
+func enterpriseCredentialsForURL(baseURL string) (string, *EnterpriseCredentials, error) {
+	normalizedURL, err := NormalizeEnterpriseURL(baseURL)
+	if err != nil {
+		return "", nil, err
+	}
+	credentials, err := getEnterpriseCredentials(EnterpriseHost(normalizedURL))
+	if err != nil {
+		return "", nil, err
+	}
+	return normalizedURL, credentials, nil
+}
+
+// newEnterpriseClient creates a github client for the REST API of the GitHub Enterprise Server
+func newEnterpriseClient(baseURL string, httpClient *http.Client) (*github.Client, error) {
+	return github.NewEnterpriseClient(baseURL+"/api/v3/", baseURL+"/api/uploads/", httpClient)
+}
+
+// NewGithubAppClientForInstallation creates a new github client for the installation on github.com or on the GitHub
+// Enterprise Server host of the installation
+func NewGithubAppClientForInstallation(installation Installation) (*github.Client, error) {
+	if !installation.IsEnterprise() {
+		return NewGithubAppClient(installation.InstallationID)
+	}
+	baseURL, credentials, err := enterpriseCredentialsForURL(installation.EnterpriseBaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if installation.EnterpriseAppID == 0 {
+		return nil, fmt.Errorf("missing github app id for %s : %w", baseURL, ErrEnterpriseHostNotConfigured)
+	}
+	itr, err := ghinstallation.New(http.DefaultTransport, installation.EnterpriseAppID, installation.InstallationID, []byte(credentials.AppPrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	itr.BaseURL = baseURL + "/api/v3"
+	return newEnterpriseClient(baseURL, &http.Client{Transport: &rateLimitTransport{installationID: installation.InstallationID, next: itr}})
+}
+
+// NewGithubOauthClientForHost creates a github client from the access token of the GitHub Enterprise Server host, an
+// empty base url creates the client for github.com
+func NewGithubOauthClientForHost(baseURL string) (*github.Client, error) {
+	if baseURL == "" {
+		return NewGithubOauthClient(), nil
+	}
+	baseURL, credentials, err := enterpriseCredentialsForURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if credentials.AccessToken == "" {
+		return nil, fmt.Errorf("missing access token for %s : %w", baseURL, ErrEnterpriseHostNotConfigured)
+	}
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: credentials.AccessToken})
+	return newEnterpriseClient(baseURL, oauth2.NewClient(context.TODO(), ts))
+}
+
+// OAuthConfigForHost returns the OAuth configuration of the GitHub Enterprise Server host - the default configuration
+// is returned for github.com
+func OAuthConfigForHost(baseURL string, defaultConfig *oauth2.Config) (*oauth2.Config, error) {
+	if baseURL == "" {
+		return defaultConfig, nil
+	}
+	baseURL, credentials, err := enterpriseCredentialsForURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if credentials.OAuthClientID == "" || credentials.OAuthClientSecret == "" {
+		return nil, fmt.Errorf("missing oauth client for %s : %w", baseURL, ErrEnterpriseHostNotConfigured)
+	}
+	return &oauth2.Config{
+		ClientID:     credentials.OAuthClientID,
+		ClientSecret: credentials.OAuthClientSecret,
+		Scopes:       defaultConfig.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  baseURL + "/login/oauth/authorize",
+			TokenURL: baseURL + "/login/oauth/access_token",
+		},
+	}, nil
+}
+
+// WebhookSecret returns the secret validating the webhook events of the GitHub Enterprise Server host, the events of
+// github.com have no secret configured - the enterprise hosts always require a secret, and only the hosts of the
+// registered GitHub organizations are accepted since the host is sent by the caller
+func WebhookSecret(host string) ([]byte, error) {
+	if host == "" {
+		return nil, nil
+	}
+	host = strings.ToLower(host)
+	registered, err := isRegisteredEnterpriseHost(host)
+	if err != nil {
+		return nil, err
+	}
+	if !registered {
+		return nil, fmt.Errorf("%s : %w", host, ErrEnterpriseHostNotConfigured)
+	}
+	credentials, err := getEnterpriseCredentials(host)
+	if err != nil {
+		return nil, err
+	}
+	if credentials.WebhookSecret == "" {
+		return nil, fmt.Errorf("missing webhook secret for %s : %w", host, ErrEnterpriseHostNotConfigured)
+	}
+	return []byte(credentials.WebhookSecret), nil
+}
@@ -0,0 +1,116 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bmizerany/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestNormalizeEnterpriseURL(t *testing.T) {
+	for input, expected := range map[string]string{
+		"https://github.example.com":      "https://github.example.com",
+		"https://GitHub.Example.com/":     "https://github.example.com",
+		"https://github.example.com:8443": "https://github.example.com:8443",
+		"http://github.example.com":       "",
+		"https://github.com":              "",
+		"https://api.github.com":          "",
+		"https://github.example.com/x":    "",
+		"https://user@github.example.com": "",
+		"github.example.com":              "",
+		"":                                "",
+	} {
+		normalized, err := NormalizeEnterpriseURL(input)
+		if expected == "" {
+			assert.Equal(t, ErrInvalidEnterpriseURL, err)
+			continue
+		}
+		assert.Equal(t, nil, err)
+		assert.Equal(t, expected, normalized)
+	}
+}
+
+func TestEnterpriseCredentials(t *testing.T) {
+	loads := 0
+	InitEnterprise(func(host string) (*EnterpriseCredentials, error) {
+		loads++
+		if host != "github.example.com" {
+			return &EnterpriseCredentials{}, nil
+		}
+		return &EnterpriseCredentials{
+			AppPrivateKey:     "key",
+			WebhookSecret:     "secret",
+			OAuthClientID:     "client-id",
+			OAuthClientSecret: "client-secret",
+		}, nil
+	})
+	defer InitEnterprise(nil)
+	hostLoads := 0
+	InitEnterpriseHosts(func() ([]string, error) {
+		hostLoads++
+		return []string{"GitHub.example.com", "github.other.com"}, nil
+	})
+	defer InitEnterpriseHosts(nil)
+
+	secret, err := WebhookSecret("")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(secret))
+
+	secret, err = WebhookSecret("GitHub.Example.com")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "secret", string(secret))
+
+	_, err = WebhookSecret("github.other.com")
+	assert.T(t, errors.Is(err, ErrEnterpriseHostNotConfigured))
+	_, err = WebhookSecret("github.other.com")
+	assert.T(t, errors.Is(err, ErrEnterpriseHostNotConfigured))
+
+	// the credentials of the hosts without a registered organization are not loaded
+	_, err = WebhookSecret("github.unknown.com")
+	assert.T(t, errors.Is(err, ErrEnterpriseHostNotConfigured))
+
+	defaultConfig := &oauth2.Config{ClientID: "default", Scopes: []string{"user:email"}}
+	config, err := OAuthConfigForHost("", defaultConfig)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, defaultConfig, config)
+
+	config, err = OAuthConfigForHost("https://github.example.com", defaultConfig)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "client-id", config.ClientID)
+	assert.Equal(t, "https://github.example.com/login/oauth/authorize", config.Endpoint.AuthURL)
+	assert.Equal(t, "https://github.example.com/login/oauth/access_token", config.Endpoint.TokenURL)
+	assert.Equal(t, defaultConfig.Scopes, config.Scopes)
+
+	// the credentials are loaded once per host, also when the load fails, and the hosts once
+	assert.Equal(t, 2, loads)
+	assert.Equal(t, 1, hostLoads)
+}
+
+func TestEnterpriseCredentialsFailureExpires(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	enterpriseNow = func() time.Time { return now }
+	defer func() { enterpriseNow = time.Now }()
+
+	loads := 0
+	InitEnterprise(func(host string) (*EnterpriseCredentials, error) {
+		loads++
+		return nil, errors.New("parameter not found")
+	})
+	defer InitEnterprise(nil)
+
+	_, err := NewGithubOauthClientForHost("https://github.example.com")
+	assert.NotEqual(t, nil, err)
+	_, err = NewGithubOauthClientForHost("https://github.example.com")
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 1, loads)
+
+	now = now.Add(enterpriseFailureTTL)
+	_, err = NewGithubOauthClientForHost("https://github.example.com")
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 2, loads)
+}
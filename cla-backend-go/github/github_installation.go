@@ -15,15 +15,30 @@ import (
 
 // GetInstallationRepositories returns list of repositories for github app installation
 func GetInstallationRepositories(installationID int64) ([]*github.Repository, error) {
-	client, err := NewGithubAppClient(installationID)
+	return GetRepositoriesForInstallation(Installation{InstallationID: installationID})
+}
+
+// GetRepositoriesForInstallation returns list of repositories for the github app installation on github.com or on a
+// GitHub Enterprise Server host
+func GetRepositoriesForInstallation(installation Installation) ([]*github.Repository, error) {
+	installationID := installation.InstallationID
+	client, err := NewGithubAppClientForInstallation(installation)
 	if err != nil {
 		return nil, errors.New("cannot create github client")
 	}
-	repos, _, err := client.Apps.ListRepos(context.TODO(), nil)
-	if err != nil {
-		logging.Error("error while getting installation repositories", err)
-		err = fmt.Errorf("unable to get repositories for installation id : %d", installationID)
-		return nil, err
+	var repos []*github.Repository
+	listOpt := &github.ListOptions{PerPage: 100}
+	for {
+		pageRepos, resp, err := client.Apps.ListRepos(context.TODO(), listOpt)
+		if err != nil {
+			logging.Error("error while getting installation repositories", err)
+			err = fmt.Errorf("unable to get repositories for installation id : %d", installationID)
+			return nil, err
+		}
+		repos = append(repos, pageRepos...)
+		if resp.NextPage == 0 {
+			return repos, nil
+		}
+		listOpt.Page = resp.NextPage
 	}
-	return repos, nil
 }
@@ -22,13 +22,24 @@ var (
 
 // GetOrganization gets github organization
 func GetOrganization(ctx context.Context, organizationName string) (*github.Organization, error) {
+	return GetOrganizationForHost(ctx, "", organizationName)
+}
+
+// GetOrganizationForHost gets github organization from github.com or from the GitHub Enterprise Server with the given
+// base url
+func GetOrganizationForHost(ctx context.Context, enterpriseBaseURL, organizationName string) (*github.Organization, error) {
 	f := logrus.Fields{
-		"functionName":     "GetOrganization",
-		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
-		"organizationName": organizationName,
+		"functionName":      "GetOrganizationForHost",
+		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
+		"enterpriseBaseURL": enterpriseBaseURL,
+		"organizationName":  organizationName,
 	}
 
-	client := NewGithubOauthClient()
+	client, err := NewGithubOauthClientForHost(enterpriseBaseURL)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to create github client, error = %s", err.Error())
+		return nil, err
+	}
 	org, resp, err := client.Organizations.Get(ctx, organizationName)
 	if err != nil {
 		log.WithFields(f).Warnf("GetOrganization %s failed. error = %s", organizationName, err.Error())
@@ -44,3 +55,60 @@ func GetOrganization(ctx context.Context, organizationName string) (*github.Orga
 	}
 	return org, nil
 }
+
+// IsOrganizationMember returns true if the github user is a member of the github organization
+func IsOrganizationMember(ctx context.Context, organizationName, userName string) (bool, error) {
+	f := logrus.Fields{
+		"functionName":     "IsOrganizationMember",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": organizationName,
+		"userName":         userName,
+	}
+
+	client := NewGithubOauthClient()
+	isMember, _, err := client.Organizations.IsMember(ctx, organizationName, userName)
+	if err != nil {
+		log.WithFields(f).Warnf("IsOrganizationMember %s failed. error = %s", organizationName, err.Error())
+		return false, err
+	}
+	return isMember, nil
+}
+
+// ListOrganizationMembersForInstallation returns the logins of all the members of the github organization, including
+// the private members, using the github app installation of the organization
+func ListOrganizationMembersForInstallation(ctx context.Context, installation Installation, organizationName string) ([]string, error) {
+	f := logrus.Fields{
+		"functionName":     "ListOrganizationMembersForInstallation",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": organizationName,
+		"installationID":   installation.InstallationID,
+	}
+
+	client, err := NewGithubAppClientForInstallation(installation)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to create github app client, error = %s", err.Error())
+		return nil, err
+	}
+
+	var logins []string
+	listOpt := &github.ListMembersOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		members, resp, err := client.Organizations.ListMembers(ctx, organizationName, listOpt)
+		if err != nil {
+			_, err = checkAndWrapForKnownErrors(resp, err)
+			log.WithFields(f).Warnf("ListMembers %s failed. error = %s", organizationName, err.Error())
+			return nil, err
+		}
+		for _, member := range members {
+			if member.GetLogin() != "" {
+				logins = append(logins, member.GetLogin())
+			}
+		}
+		if resp.NextPage == 0 {
+			return logins, nil
+		}
+		listOpt.Page = resp.NextPage
+	}
+}
@@ -23,14 +23,20 @@ var (
 
 // GetRepositoryByExternalID finds github repository by github repository id
 func GetRepositoryByExternalID(ctx context.Context, installationID, id int64) (*github.Repository, error) {
-	client, err := NewGithubAppClient(installationID)
+	return GetRepositoryByExternalIDForInstallation(ctx, Installation{InstallationID: installationID}, id)
+}
+
+// GetRepositoryByExternalIDForInstallation finds github repository by github repository id using the installation on
+// github.com or on a GitHub Enterprise Server host
+func GetRepositoryByExternalIDForInstallation(ctx context.Context, installation Installation, id int64) (*github.Repository, error) {
+	client, err := NewGithubAppClientForInstallation(installation)
 	if err != nil {
 		return nil, err
 	}
 	org, resp, err := client.Repositories.GetByID(ctx, id)
 	if err != nil {
 		logging.Warnf("GetRepository %v failed. error = %s", id, err.Error())
-		if resp.StatusCode == 404 {
+		if resp != nil && resp.StatusCode == 404 {
 			return nil, ErrGithubRepositoryNotFound
 		}
 		return nil, err
@@ -40,14 +46,25 @@ func GetRepositoryByExternalID(ctx context.Context, installationID, id int64) (*
 
 // GetRepositories gets github repositories by organization
 func GetRepositories(ctx context.Context, organizationName string) ([]*github.Repository, error) {
+	return GetRepositoriesForHost(ctx, "", organizationName)
+}
+
+// GetRepositoriesForHost gets github repositories by organization on github.com or on the GitHub Enterprise Server
+// with the given base url
+func GetRepositoriesForHost(ctx context.Context, enterpriseBaseURL, organizationName string) ([]*github.Repository, error) {
 	f := logrus.Fields{
-		"functionName":     "GetRepositories",
-		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
-		"organizationName": organizationName,
+		"functionName":      "GetRepositoriesForHost",
+		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
+		"enterpriseBaseURL": enterpriseBaseURL,
+		"organizationName":  organizationName,
 	}
 
 	// Get the client with token
-	client := NewGithubOauthClient()
+	client, err := NewGithubOauthClientForHost(enterpriseBaseURL)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create github client")
+		return nil, err
+	}
 
 	// API https://docs.github.com/en/free-pro-team@latest/rest/reference/repos
 	repoList, resp, err := client.Repositories.ListByOrg(ctx, organizationName, &github.RepositoryListByOrgOptions{
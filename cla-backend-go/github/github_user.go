@@ -14,7 +14,17 @@ import (
 
 // GetUserDetails return github users details
 func GetUserDetails(user string) (*github.User, error) {
-	client := NewGithubOauthClient()
+	return GetUserDetailsForHost("", user)
+}
+
+// GetUserDetailsForHost return github users details from github.com or from the GitHub Enterprise Server with the
+// given base url
+func GetUserDetailsForHost(enterpriseBaseURL, user string) (*github.User, error) {
+	client, err := NewGithubOauthClientForHost(enterpriseBaseURL)
+	if err != nil {
+		logging.Warnf("GetUserDetails failed for user : %s, error = %s\n", user, err.Error())
+		return nil, fmt.Errorf("unable to get github info of %s", user)
+	}
 	userResp, _, err := client.Users.Get(context.TODO(), user)
 	if err != nil {
 		logging.Warnf("GetUserDetails failed for user : %s, error = %s\n", user, err.Error())
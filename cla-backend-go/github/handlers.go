@@ -109,6 +109,23 @@ func Configure(api *operations.ClaAPI, clientID, clientSecret, accessToken strin
 				session.Values["callback"] = params.Callback
 				//session.Values[""] = params.
 
+				// The GitHub Enterprise Server hosts have their own OAuth application
+				enterpriseBaseURL := ""
+				if params.EnterpriseBaseURL != nil && *params.EnterpriseBaseURL != "" {
+					enterpriseBaseURL, err = NormalizeEnterpriseURL(*params.EnterpriseBaseURL)
+					if err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+				}
+				hostOAuthConfig, err := OAuthConfigForHost(enterpriseBaseURL, oauthConfig)
+				if err != nil {
+					log.Warnf("Error loading the oauth configuration of github host: %s, error: %v", enterpriseBaseURL, err)
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				session.Values["enterprise_base_url"] = enterpriseBaseURL
+
 				// Generate a csrf token to send
 				state, err := uuid.NewV4()
 				if err != nil {
@@ -127,8 +144,8 @@ func Configure(api *operations.ClaAPI, clientID, clientSecret, accessToken strin
 				}
 
 				log.Debugf("GH Login handler saved the http session: %v", session)
-				log.Debugf("redirecting flow to %s", oauthConfig.AuthCodeURL(state.String()))
-				http.Redirect(w, params.HTTPRequest, oauthConfig.AuthCodeURL(state.String()), http.StatusFound)
+				log.Debugf("redirecting flow to %s", hostOAuthConfig.AuthCodeURL(state.String()))
+				http.Redirect(w, params.HTTPRequest, hostOAuthConfig.AuthCodeURL(state.String()), http.StatusFound)
 			})
 	})
 
@@ -158,8 +175,17 @@ func Configure(api *operations.ClaAPI, clientID, clientSecret, accessToken strin
 					return
 				}
 
+				// sessions created before the enterprise hosts were supported have no base url, they are github.com
+				enterpriseBaseURL, _ := session.Values["enterprise_base_url"].(string) // nolint
+				hostOAuthConfig, err := OAuthConfigForHost(enterpriseBaseURL, oauthConfig)
+				if err != nil {
+					log.Warnf("Error loading the oauth configuration of github host: %s, error: %v", enterpriseBaseURL, err)
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
 				// trade temporary code for access token
-				token, err := oauthConfig.Exchange(context.TODO(), params.Code)
+				token, err := hostOAuthConfig.Exchange(context.TODO(), params.Code)
 				if err != nil {
 					log.Warnf("unable to exchange oath code, error: %v", err)
 					http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -3,6 +3,11 @@
 
 package github
 
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+)
+
 var githubAppPrivateKey string
 var githubAppID int
 var secretAccessToken string
@@ -14,6 +19,23 @@ func Init(ghAppID int, ghAppPrivateKey string, secAccessToken string) {
 	secretAccessToken = secAccessToken
 }
 
+// InitEnterpriseFromSSM loads the credentials of the GitHub Enterprise Server hosts from the SSM parameters of the stage
+func InitEnterpriseFromSSM(awsSession *session.Session, stage string) {
+	InitEnterprise(func(host string) (*EnterpriseCredentials, error) {
+		githubEnterprise, err := config.LoadGithubEnterprise(awsSession, stage, host)
+		if err != nil {
+			return nil, err
+		}
+		return &EnterpriseCredentials{
+			AppPrivateKey:     githubEnterprise.AppPrivateKey,
+			AccessToken:       githubEnterprise.AccessToken,
+			WebhookSecret:     githubEnterprise.WebhookSecret,
+			OAuthClientID:     githubEnterprise.ClientID,
+			OAuthClientSecret: githubEnterprise.ClientSecret,
+		}, nil
+	})
+}
+
 func getGithubAppPrivateKey() string {
 	return githubAppPrivateKey
 }
@@ -101,3 +101,19 @@ func (mr *MockRepositoriesMockRecorder) UpdateBranchProtection(ctx, owner, repo,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBranchProtection", reflect.TypeOf((*MockRepositories)(nil).UpdateBranchProtection), ctx, owner, repo, branch, preq)
 }
+
+// ListBranches mocks base method
+func (m *MockRepositories) ListBranches(ctx context.Context, owner, repo string, opts *github.BranchListOptions) ([]*github.Branch, *github.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBranches", ctx, owner, repo, opts)
+	ret0, _ := ret[0].([]*github.Branch)
+	ret1, _ := ret[1].(*github.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListBranches indicates an expected call of ListBranches
+func (mr *MockRepositoriesMockRecorder) ListBranches(ctx, owner, repo, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranches", reflect.TypeOf((*MockRepositories)(nil).ListBranches), ctx, owner, repo, opts)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/go-openapi/swag"
@@ -45,6 +46,7 @@ type Repositories interface {
 	Get(ctx context.Context, owner, repo string) (*githubpkg.Repository, *githubpkg.Response, error)
 	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*githubpkg.Protection, *githubpkg.Response, error)
 	UpdateBranchProtection(ctx context.Context, owner, repo, branch string, preq *githubpkg.ProtectionRequest) (*githubpkg.Protection, *githubpkg.Response, error)
+	ListBranches(ctx context.Context, owner string, repo string, opts *githubpkg.BranchListOptions) ([]*githubpkg.Branch, *githubpkg.Response, error)
 }
 
 type blockingRateLimitRepositories struct {
@@ -77,6 +79,11 @@ func (b blockingRateLimitRepositories) UpdateBranchProtection(ctx context.Contex
 	return b.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, preq)
 }
 
+func (b blockingRateLimitRepositories) ListBranches(ctx context.Context, owner string, repo string, opts *githubpkg.BranchListOptions) ([]*githubpkg.Branch, *githubpkg.Response, error) {
+	blockingRateLimit.Take()
+	return b.Repositories.ListBranches(ctx, owner, repo, opts)
+}
+
 type nonBlockingRateLimitRepositories struct {
 	Repositories
 }
@@ -114,6 +121,13 @@ func (nb nonBlockingRateLimitRepositories) UpdateBranchProtection(ctx context.Co
 	return nil, nil, fmt.Errorf("too many requests : %w", ErrRateLimited)
 }
 
+func (nb nonBlockingRateLimitRepositories) ListBranches(ctx context.Context, owner string, repo string, opts *githubpkg.BranchListOptions) ([]*githubpkg.Branch, *githubpkg.Response, error) {
+	if nonBlockingRateLimit.Allow() {
+		return nb.Repositories.ListBranches(ctx, owner, repo, opts)
+	}
+	return nil, nil, fmt.Errorf("too many requests : %w", ErrRateLimited)
+}
+
 type branchProtectionRepositoryConfig struct {
 	enableBlockingLimiter    bool
 	enableNonBlockingLimiter bool
@@ -245,9 +259,63 @@ func (bp *BranchProtectionRepository) GetProtectedBranch(ctx context.Context, ow
 	return protection, err
 }
 
+// ListBranchNames returns the names of all the branches of the given repo
+func (bp *BranchProtectionRepository) ListBranchNames(ctx context.Context, owner, repoName string) ([]string, error) {
+	repoName = CleanGithubRepoName(repoName)
+	listOpt := &githubpkg.BranchListOptions{
+		ListOptions: githubpkg.ListOptions{
+			PerPage: 100,
+		},
+	}
+	var branchNames []string
+	for {
+		branches, resp, err := bp.githubRepo.ListBranches(ctx, owner, repoName, listOpt)
+		if err != nil {
+			if ok, wErr := checkAndWrapForKnownErrors(resp, err); ok {
+				return nil, wErr
+			}
+			return nil, fmt.Errorf("listing branches for repo : %s : %w", repoName, err)
+		}
+
+		for _, branch := range branches {
+			branchNames = append(branchNames, branch.GetName())
+		}
+
+		if resp == nil || resp.NextPage == 0 {
+			return branchNames, nil
+		}
+		listOpt.Page = resp.NextPage
+	}
+}
+
+// MatchBranchPattern checks if the branch name matches the branch pattern, the pattern follows the fnmatch syntax
+// github uses for the branch protection rules, e.g. release/*
+func MatchBranchPattern(pattern, branchName string) bool {
+	matched, err := path.Match(pattern, branchName)
+	if err != nil {
+		log.Warnf("MatchBranchPattern : invalid branch pattern : %s : %v", pattern, err)
+		return false
+	}
+	return matched
+}
+
+// BranchProtectionOptions are the optional protection rules which are enforced in addition to the status checks
+type BranchProtectionOptions struct {
+	// RequiredApprovingReviewCount when greater than zero requires pull request reviews with at least this many approvals
+	RequiredApprovingReviewCount int
+	// RequireLinearHistory prevents merge commits from being pushed to the branch
+	RequireLinearHistory bool
+}
+
 //EnableBranchProtection enables branch protection if not enabled and makes sure passed arguments such as enforceAdmin
 //statusChecks are applied. The operation makes sure it doesn't override the existing checks.
 func (bp *BranchProtectionRepository) EnableBranchProtection(ctx context.Context, owner, repoName, branchName string, enforceAdmin bool, enableStatusChecks, disableStatusChecks []string) error {
+	return bp.EnableBranchProtectionWithOptions(ctx, owner, repoName, branchName, enforceAdmin, enableStatusChecks, disableStatusChecks, nil)
+}
+
+// EnableBranchProtectionWithOptions works the same as EnableBranchProtection and additionally enforces the given
+// options, the existing review and linear history rules are only ever tightened
+func (bp *BranchProtectionRepository) EnableBranchProtectionWithOptions(ctx context.Context, owner, repoName, branchName string, enforceAdmin bool, enableStatusChecks, disableStatusChecks []string, opts *BranchProtectionOptions) error {
 	repoName = CleanGithubRepoName(repoName)
 	protectedBranch, err := bp.GetProtectedBranch(ctx, owner, repoName, branchName)
 	if err != nil && !errors.Is(err, ErrBranchNotProtected) {
@@ -258,6 +326,7 @@ func (bp *BranchProtectionRepository) EnableBranchProtection(ctx context.Context
 	if err != nil {
 		return fmt.Errorf("creating branch protection request failed : %v", err)
 	}
+	applyBranchProtectionOptions(branchProtectionRequest, opts)
 
 	_, resp, err := bp.githubRepo.UpdateBranchProtection(ctx, owner, repoName, branchName, branchProtectionRequest)
 
@@ -267,6 +336,26 @@ func (bp *BranchProtectionRepository) EnableBranchProtection(ctx context.Context
 	return err
 }
 
+// applyBranchProtectionOptions adds the optional protection rules to the request
+func applyBranchProtectionOptions(request *githubpkg.ProtectionRequest, opts *BranchProtectionOptions) {
+	if opts == nil {
+		return
+	}
+
+	if opts.RequiredApprovingReviewCount > 0 {
+		if request.RequiredPullRequestReviews == nil {
+			request.RequiredPullRequestReviews = &githubpkg.PullRequestReviewsEnforcementRequest{}
+		}
+		if request.RequiredPullRequestReviews.RequiredApprovingReviewCount < opts.RequiredApprovingReviewCount {
+			request.RequiredPullRequestReviews.RequiredApprovingReviewCount = opts.RequiredApprovingReviewCount
+		}
+	}
+
+	if opts.RequireLinearHistory {
+		request.RequireLinearHistory = swag.Bool(true)
+	}
+}
+
 // createBranchProtectionRequest creates a branch protection request from existing protection
 func createBranchProtectionRequest(protection *githubpkg.Protection, enableStatusChecks, disableStatusChecks []string, enforceAdmin bool) (*githubpkg.ProtectionRequest, error) {
 	var currentChecks *githubpkg.RequiredStatusChecks
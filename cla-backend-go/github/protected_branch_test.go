@@ -349,3 +349,26 @@ func TestBlockingRateLimitRepositories_GetBranchProtection(t *testing.T) {
 
 	})
 }
+
+func TestMatchBranchPattern(t *testing.T) {
+	assert.Equal(t, true, MatchBranchPattern("release/*", "release/1.0"))
+	assert.Equal(t, false, MatchBranchPattern("release/*", "release/1.0/hotfix"))
+	assert.Equal(t, false, MatchBranchPattern("release/*", "main"))
+	assert.Equal(t, true, MatchBranchPattern("v[0-9]*", "v2"))
+	assert.Equal(t, false, MatchBranchPattern("release/[", "release/["))
+}
+
+func TestApplyBranchProtectionOptions(t *testing.T) {
+	request := &githubsdk.ProtectionRequest{}
+	applyBranchProtectionOptions(request, nil)
+	assert.Equal(t, &githubsdk.ProtectionRequest{}, request)
+
+	applyBranchProtectionOptions(request, &BranchProtectionOptions{RequiredApprovingReviewCount: 2, RequireLinearHistory: true})
+	assert.Equal(t, 2, request.RequiredPullRequestReviews.RequiredApprovingReviewCount)
+	assert.Equal(t, swag.Bool(true), request.RequireLinearHistory)
+
+	// the existing reviews are never loosened
+	request.RequiredPullRequestReviews.RequiredApprovingReviewCount = 3
+	applyBranchProtectionOptions(request, &BranchProtectionOptions{RequiredApprovingReviewCount: 1})
+	assert.Equal(t, 3, request.RequiredPullRequestReviews.RequiredApprovingReviewCount)
+}
@@ -0,0 +1,83 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit is the latest rate limit reported by github for an installation
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+var (
+	rateLimitsLock sync.RWMutex
+	rateLimits     = make(map[int64]RateLimit)
+)
+
+// GetRateLimit returns the latest rate limit github reported for the installation, returns false when no call was
+// made with the installation yet
+func GetRateLimit(installationID int64) (RateLimit, bool) {
+	rateLimitsLock.RLock()
+	defer rateLimitsLock.RUnlock()
+	rateLimit, ok := rateLimits[installationID]
+	return rateLimit, ok
+}
+
+func setRateLimit(installationID int64, rateLimit RateLimit) {
+	rateLimitsLock.Lock()
+	defer rateLimitsLock.Unlock()
+	rateLimits[installationID] = rateLimit
+}
+
+// rateLimitTransport records the rate limit headers of the github responses per installation
+type rateLimitTransport struct {
+	installationID int64
+	next           http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if rateLimit, ok := parseRateLimit(resp.Header, time.Now()); ok {
+		setRateLimit(t.installationID, rateLimit)
+	}
+	return resp, nil
+}
+
+// parseRateLimit reads the X-RateLimit headers, the Retry-After header of the secondary rate limits means nothing is
+// remaining until the given number of seconds passed
+func parseRateLimit(header http.Header, now time.Time) (RateLimit, bool) {
+	if retryAfter, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return RateLimit{
+			Remaining: 0,
+			Reset:     now.Add(time.Duration(retryAfter) * time.Second),
+		}, true
+	}
+
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return RateLimit{}, false
+	}
+	// the limit is informational only
+	limit, _ := strconv.Atoi(header.Get("X-RateLimit-Limit")) // nolint
+
+	return RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+	}, true
+}
@@ -0,0 +1,33 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	header := http.Header{}
+	_, ok := parseRateLimit(header, now)
+	assert.Equal(t, false, ok)
+
+	header.Set("X-RateLimit-Limit", "5000")
+	header.Set("X-RateLimit-Remaining", "42")
+	header.Set("X-RateLimit-Reset", "1600000600")
+	rateLimit, ok := parseRateLimit(header, now)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, RateLimit{Limit: 5000, Remaining: 42, Reset: time.Unix(1600000600, 0)}, rateLimit)
+
+	header.Set("Retry-After", "60")
+	rateLimit, ok = parseRateLimit(header, now)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 0, rateLimit.Remaining)
+	assert.Equal(t, now.Add(time.Minute), rateLimit.Reset)
+}
@@ -0,0 +1,33 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_jobs
+
+import (
+	"time"
+
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+)
+
+// expose the job run limits to the tests
+const (
+	RateLimitReserve = rateLimitReserve
+	RateLimitBackoff = rateLimitBackoff
+	JobLease         = jobLease
+	JobRetention     = jobRetention
+)
+
+// NewTestService creates the service with the clock and installation rate limit of the test
+func NewTestService(repo Repository, now func() time.Time, rateLimit *githubutils.RateLimit) Service {
+	return &service{
+		repo:     repo,
+		handlers: make(map[string]ItemHandler),
+		now:      now,
+		rateLimit: func(installationID int64) (githubutils.RateLimit, bool) {
+			if rateLimit == nil {
+				return githubutils.RateLimit{}, false
+			}
+			return *rateLimit, true
+		},
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_jobs
+
+import (
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// job statuses
+const (
+	JobStatusPending   = "pending"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+
+	// JobStatusRunning is reported for a pending job which is claimed by a job run, it is never stored
+	JobStatusRunning = "running"
+	// JobStatusRateLimited is reported for a pending job which waits for the rate limit reset, it is never stored
+	JobStatusRateLimited = "rate_limited"
+)
+
+// job types
+const (
+	// JobTypeBranchProtection enables the branch protection policy on the repositories, the job items are the EasyCLA
+	// repository IDs
+	JobTypeBranchProtection = "branch_protection"
+)
+
+// Job is the database model for the github jobs table - a job is a bulk GitHub operation over a list of items which
+// is processed in the background, pausing whenever the GitHub App installation is about to run out of rate limit
+type Job struct {
+	JobID                 string   `dynamodbav:"job_id" json:"job_id"`
+	JobType               string   `dynamodbav:"job_type" json:"job_type"`
+	InstallationID        int64    `dynamodbav:"installation_id" json:"installation_id"`
+	OrganizationName      string   `dynamodbav:"organization_name" json:"organization_name"`
+	ProjectSFID           string   `dynamodbav:"project_sfid" json:"project_sfid"`
+	JobStatus             string   `dynamodbav:"job_status" json:"job_status"`
+	PendingItems          []string `dynamodbav:"pending_items" json:"pending_items"`
+	TotalItems            int64    `dynamodbav:"total_items" json:"total_items"`
+	ProcessedItems        int64    `dynamodbav:"processed_items" json:"processed_items"`
+	FailedItems           int64    `dynamodbav:"failed_items" json:"failed_items"`
+	ItemErrors            []string `dynamodbav:"item_errors" json:"item_errors"`
+	NextRunEpoch          int64    `dynamodbav:"next_run_epoch" json:"next_run_epoch"`
+	RateLimitedUntilEpoch int64    `dynamodbav:"rate_limited_until_epoch" json:"rate_limited_until_epoch"`
+	LastError             string   `dynamodbav:"last_error" json:"last_error"`
+	CreatedBy             string   `dynamodbav:"created_by" json:"created_by"`
+	DateCreated           string   `dynamodbav:"date_created" json:"date_created"`
+	DateModified          string   `dynamodbav:"date_modified" json:"date_modified"`
+	DateCompleted         string   `dynamodbav:"date_completed" json:"date_completed"`
+	Expires               int64    `dynamodbav:"expires" json:"expires"`
+	Version               string   `dynamodbav:"version" json:"version"`
+}
+
+// Status returns the status of the job as reported to the API - a pending job is either running, waiting for the
+// rate limit reset or waiting for the next job run
+func (j *Job) Status(now time.Time) string {
+	if j.JobStatus != JobStatusPending {
+		return j.JobStatus
+	}
+	if j.RateLimitedUntilEpoch > now.Unix() {
+		return JobStatusRateLimited
+	}
+	if j.NextRunEpoch > now.Unix() {
+		return JobStatusRunning
+	}
+	return JobStatusPending
+}
+
+// ToModel converts the database model to the API model
+func (j *Job) ToModel() *models.GithubJob {
+	now := time.Now()
+	job := &models.GithubJob{
+		JobID:            j.JobID,
+		JobType:          j.JobType,
+		InstallationID:   j.InstallationID,
+		OrganizationName: j.OrganizationName,
+		ProjectSfid:      j.ProjectSFID,
+		Status:           j.Status(now),
+		TotalItems:       j.TotalItems,
+		ProcessedItems:   j.ProcessedItems,
+		FailedItems:      j.FailedItems,
+		ItemErrors:       j.ItemErrors,
+		LastError:        j.LastError,
+		CreatedBy:        j.CreatedBy,
+		DateCreated:      j.DateCreated,
+		DateModified:     j.DateModified,
+		DateCompleted:    j.DateCompleted,
+	}
+	if j.TotalItems > 0 {
+		job.PercentComplete = j.ProcessedItems * 100 / j.TotalItems
+	}
+	if job.Status == JobStatusRateLimited {
+		job.RateLimitedUntil = utils.TimeToString(time.Unix(j.RateLimitedUntilEpoch, 0))
+	}
+	return job
+}
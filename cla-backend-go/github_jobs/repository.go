@@ -0,0 +1,279 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// indexes
+const (
+	JobStatusIndex        = "job-status-index"
+	OrganizationNameIndex = "organization-name-index"
+	InstallationIDIndex   = "installation-id-index"
+)
+
+// errors
+var (
+	ErrJobNotFound = errors.New("github job not found")
+)
+
+// Repository interface defines the functions for the github jobs data model
+type Repository interface {
+	CreateJob(ctx context.Context, job *Job) error
+	UpdateJob(ctx context.Context, job *Job) error
+	AddJobItems(ctx context.Context, job *Job) (bool, error)
+	ClaimJob(ctx context.Context, jobID string, nextRunEpoch, leaseUntilEpoch int64) (*Job, error)
+	GetJob(ctx context.Context, jobID string) (*Job, error)
+	GetDueJobs(ctx context.Context, nowEpoch int64) ([]*Job, error)
+	GetJobsByOrganization(ctx context.Context, organizationName string, limit int64) ([]*Job, error)
+	GetJobsByInstallation(ctx context.Context, installationID int64) ([]*Job, error)
+}
+
+type repository struct {
+	stage          string
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewRepository creates a new instance of the github jobs repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:          stage,
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-github-jobs", stage),
+	}
+}
+
+// CreateJob stores the new github job
+func (repo *repository) CreateJob(ctx context.Context, job *Job) error {
+	return repo.putItem(ctx, "CreateJob", job, "attribute_not_exists(job_id)", nil)
+}
+
+// UpdateJob stores the progress of the github job
+func (repo *repository) UpdateJob(ctx context.Context, job *Job) error {
+	return repo.putItem(ctx, "UpdateJob", job, "attribute_exists(job_id)", nil)
+}
+
+// AddJobItems stores the items added to a pending job - returns false if the job was claimed by a job run or is no
+// longer pending since it was loaded, the items must then be added to a new job
+func (repo *repository) AddJobItems(ctx context.Context, job *Job) (bool, error) {
+	err := repo.putItem(ctx, "AddJobItems", job, "job_status = :s AND next_run_epoch = :n", map[string]*dynamodb.AttributeValue{
+		":s": {
+			S: aws.String(JobStatusPending),
+		},
+		":n": {
+			N: aws.String(strconv.FormatInt(job.NextRunEpoch, 10)),
+		},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ClaimJob moves the next run of a pending job to the end of the lease so concurrent job runs do not process it at
+// the same time and returns the claimed job as stored - returns nil if the job was already claimed or is no longer
+// pending
+func (repo *repository) ClaimJob(ctx context.Context, jobID string, nextRunEpoch, leaseUntilEpoch int64) (*Job, error) {
+	f := logrus.Fields{
+		"functionName":   "ClaimJob",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"jobID":          jobID,
+	}
+
+	result, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"job_id": {
+				S: aws.String(jobID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#S": aws.String("job_status"),
+			"#N": aws.String("next_run_epoch"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":s": {
+				S: aws.String(JobStatusPending),
+			},
+			":n": {
+				N: aws.String(strconv.FormatInt(nextRunEpoch, 10)),
+			},
+			":l": {
+				N: aws.String(strconv.FormatInt(leaseUntilEpoch, 10)),
+			},
+		},
+		ConditionExpression: aws.String("#S = :s AND #N = :n"),
+		UpdateExpression:    aws.String("SET #N = :l"),
+		ReturnValues:        aws.String(dynamodb.ReturnValueAllNew),
+		TableName:           aws.String(repo.tableName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return nil, nil
+		}
+		log.WithFields(f).Warnf("unable to claim github job, error: %+v", err)
+		return nil, err
+	}
+
+	var job Job
+	err = dynamodbattribute.UnmarshalMap(result.Attributes, &job)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling the claimed github job, error: %+v", err)
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetJob returns the github job
+func (repo *repository) GetJob(ctx context.Context, jobID string) (*Job, error) {
+	f := logrus.Fields{
+		"functionName":   "GetJob",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"jobID":          jobID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"job_id": {
+				S: aws.String(jobID),
+			},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load github job, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrJobNotFound
+	}
+
+	var job Job
+	err = dynamodbattribute.UnmarshalMap(result.Item, &job)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to unmarshal github job, error: %+v", err)
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetDueJobs returns the pending jobs which are due for the next run, oldest first
+func (repo *repository) GetDueJobs(ctx context.Context, nowEpoch int64) ([]*Job, error) {
+	keyCondition := expression.Key("job_status").Equal(expression.Value(JobStatusPending)).
+		And(expression.Key("next_run_epoch").LessThanEqual(expression.Value(nowEpoch)))
+	return repo.queryJobs(ctx, keyCondition, JobStatusIndex, true, 0)
+}
+
+// GetJobsByOrganization returns the most recent jobs of the GitHub organization
+func (repo *repository) GetJobsByOrganization(ctx context.Context, organizationName string, limit int64) ([]*Job, error) {
+	keyCondition := expression.Key("organization_name").Equal(expression.Value(organizationName))
+	return repo.queryJobs(ctx, keyCondition, OrganizationNameIndex, false, limit)
+}
+
+// GetJobsByInstallation returns all the jobs of the GitHub App installation, newest first
+func (repo *repository) GetJobsByInstallation(ctx context.Context, installationID int64) ([]*Job, error) {
+	keyCondition := expression.Key("installation_id").Equal(expression.Value(installationID))
+	return repo.queryJobs(ctx, keyCondition, InstallationIDIndex, false, 0)
+}
+
+// queryJobs returns the jobs matching the key condition - a limit of 0 returns all the matching jobs
+func (repo *repository) queryJobs(ctx context.Context, keyCondition expression.KeyConditionBuilder, indexName string, ascending bool, limit int64) ([]*Job, error) {
+	f := logrus.Fields{
+		"functionName":   "queryJobs",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"indexName":      indexName,
+	}
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for github job query, error: %v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.tableName),
+		IndexName:                 aws.String(indexName),
+		ScanIndexForward:          aws.Bool(ascending),
+	}
+	if limit > 0 {
+		queryInput.Limit = aws.Int64(limit)
+	}
+
+	var jobs []*Job
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.WithFields(f).Warnf("error retrieving github jobs, error: %v", errQuery)
+			return nil, errQuery
+		}
+
+		var jobsTmp []*Job
+		err := dynamodbattribute.UnmarshalListOfMaps(results.Items, &jobsTmp)
+		if err != nil {
+			log.WithFields(f).Warnf("error unmarshalling github jobs from database, error: %v", err)
+			return nil, err
+		}
+		jobs = append(jobs, jobsTmp...)
+
+		if len(results.LastEvaluatedKey) != 0 && (limit == 0 || int64(len(jobs)) < limit) {
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+
+	return jobs, nil
+}
+
+func (repo *repository) putItem(ctx context.Context, functionName string, job *Job, condition string, values map[string]*dynamodb.AttributeValue) error {
+	f := logrus.Fields{
+		"functionName":   functionName,
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tableName,
+		"jobID":          job.JobID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(job)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal github job, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                      av,
+		TableName:                 aws.String(repo.tableName),
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeValues: values,
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store github job, error: %+v", err)
+		return err
+	}
+	return nil
+}
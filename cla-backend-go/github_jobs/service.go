@@ -0,0 +1,334 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+const (
+	// rateLimitReserve is the number of requests kept for the realtime GitHub calls of the installation - a job
+	// pauses until the rate limit resets when the installation has fewer requests remaining
+	rateLimitReserve = 100
+	// rateLimitBackoff is how long a job pauses when it hits the rate limit and github didn't report the reset time
+	rateLimitBackoff = 5 * time.Minute
+	// jobLease is how long a job run may process a job before another job run may pick the job up again
+	jobLease = 15 * time.Minute
+	// jobRetention is how long finished jobs are kept for inspection
+	jobRetention = 30 * 24 * time.Hour
+	// progressInterval is the number of items processed between the progress updates of a job
+	progressInterval = 10
+	// maxItemErrors limits the number of item errors stored with a job
+	maxItemErrors = 50
+	// maxErrorLength limits the size of a single item error
+	maxErrorLength = 500
+	// jobListLimit is the number of the most recent jobs returned for a GitHub organization
+	jobListLimit = 50
+)
+
+// errors
+var (
+	ErrNoJobItems = errors.New("github job has no items")
+)
+
+// ItemHandler processes a single item of a job - returning an error wrapping github.ErrRateLimited pauses the job
+// until the rate limit resets and the item is processed again
+type ItemHandler func(ctx context.Context, job *Job, item string) error
+
+// Service queues the bulk GitHub operations and processes them within the rate limits of the GitHub App installations
+type Service interface {
+	RegisterHandler(jobType string, handler ItemHandler)
+	CreateJob(ctx context.Context, jobType string, installationID int64, organizationName, projectSFID string, items []string, createdBy string) (*Job, error)
+	GetJob(ctx context.Context, projectSFID, organizationName, jobID string) (*Job, error)
+	GetJobsByOrganization(ctx context.Context, projectSFID, organizationName string) ([]*Job, error)
+	ProcessJobs(ctx context.Context, deadline time.Time) (int, error)
+}
+
+type service struct {
+	repo          Repository
+	githubOrgRepo github_organizations.Repository
+	handlers      map[string]ItemHandler
+
+	now       func() time.Time
+	rateLimit func(installationID int64) (githubutils.RateLimit, bool)
+}
+
+// NewService creates a new github jobs service
+func NewService(repo Repository, githubOrgRepo github_organizations.Repository) Service {
+	return &service{
+		repo:          repo,
+		githubOrgRepo: githubOrgRepo,
+		handlers:      make(map[string]ItemHandler),
+		now:           time.Now,
+		rateLimit:     githubutils.GetRateLimit,
+	}
+}
+
+// RegisterHandler sets the function processing the items of the given job type
+func (s *service) RegisterHandler(jobType string, handler ItemHandler) {
+	s.handlers[jobType] = handler
+}
+
+// CreateJob queues a new job - when a job of the same type is already waiting for the GitHub organization the new
+// items are added to that job instead
+func (s *service) CreateJob(ctx context.Context, jobType string, installationID int64, organizationName, projectSFID string, items []string, createdBy string) (*Job, error) {
+	f := logrus.Fields{
+		"functionName":     "CreateJob",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"jobType":          jobType,
+		"installationID":   installationID,
+		"organizationName": organizationName,
+	}
+
+	var uniqueItems []string
+	for _, item := range items {
+		if item != "" && !utils.StringInSlice(item, uniqueItems) {
+			uniqueItems = append(uniqueItems, item)
+		}
+	}
+	if len(uniqueItems) == 0 {
+		return nil, ErrNoJobItems
+	}
+
+	now := s.now()
+	existingJobs, err := s.repo.GetJobsByInstallation(ctx, installationID)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range existingJobs {
+		// a running job stores its progress while it runs, so it can't be extended safely
+		if job.JobType != jobType || job.OrganizationName != organizationName || job.Status(now) == JobStatusRunning || job.JobStatus != JobStatusPending {
+			continue
+		}
+		added := 0
+		for _, item := range uniqueItems {
+			if !utils.StringInSlice(item, job.PendingItems) {
+				job.PendingItems = append(job.PendingItems, item)
+				added++
+			}
+		}
+		job.TotalItems += int64(added)
+		job.DateModified = utils.TimeToString(now)
+		stored, err := s.repo.AddJobItems(ctx, job)
+		if err != nil {
+			return nil, err
+		}
+		if !stored {
+			log.WithFields(f).Debugf("github job %s was claimed by a job run, creating a new job", job.JobID)
+			break
+		}
+		log.WithFields(f).Debugf("added %d items to the pending github job %s", added, job.JobID)
+		return job, nil
+	}
+
+	jobID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	job := &Job{
+		JobID:            jobID.String(),
+		JobType:          jobType,
+		InstallationID:   installationID,
+		OrganizationName: organizationName,
+		ProjectSFID:      projectSFID,
+		JobStatus:        JobStatusPending,
+		PendingItems:     uniqueItems,
+		TotalItems:       int64(len(uniqueItems)),
+		NextRunEpoch:     now.Unix(),
+		CreatedBy:        createdBy,
+		DateCreated:      utils.TimeToString(now),
+		DateModified:     utils.TimeToString(now),
+		Version:          "v1",
+	}
+	if err := s.repo.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+	log.WithFields(f).Debugf("created github job %s with %d items", job.JobID, job.TotalItems)
+	return job, nil
+}
+
+// checkOrganization makes sure the GitHub organization belongs to the project
+func (s *service) checkOrganization(ctx context.Context, projectSFID, organizationName string) error {
+	_, err := s.githubOrgRepo.GetGithubOrganizationForProject(ctx, projectSFID, organizationName)
+	return err
+}
+
+// GetJob returns the job of the GitHub organization
+func (s *service) GetJob(ctx context.Context, projectSFID, organizationName, jobID string) (*Job, error) {
+	if err := s.checkOrganization(ctx, projectSFID, organizationName); err != nil {
+		return nil, err
+	}
+	job, err := s.repo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.OrganizationName != organizationName {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// GetJobsByOrganization returns the most recent jobs of the GitHub organization
+func (s *service) GetJobsByOrganization(ctx context.Context, projectSFID, organizationName string) ([]*Job, error) {
+	if err := s.checkOrganization(ctx, projectSFID, organizationName); err != nil {
+		return nil, err
+	}
+	return s.repo.GetJobsByOrganization(ctx, organizationName, jobListLimit)
+}
+
+// ProcessJobs processes the due jobs until the deadline and returns the number of jobs which were run - a job which is
+// not finished by the deadline or which runs out of rate limit is resumed by a later job run
+func (s *service) ProcessJobs(ctx context.Context, deadline time.Time) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "ProcessJobs",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	jobs, err := s.repo.GetDueJobs(ctx, s.now().Unix())
+	if err != nil {
+		return 0, err
+	}
+
+	runs := 0
+	// installations which hit the rate limit without github reporting the reset time
+	pausedUntil := make(map[int64]int64)
+	for _, job := range jobs {
+		if s.now().After(deadline) {
+			break
+		}
+
+		// the claimed job is used as it may have been extended with new items since the due jobs were loaded
+		leaseUntil := s.now().Add(jobLease).Unix()
+		claimed, claimErr := s.repo.ClaimJob(ctx, job.JobID, job.NextRunEpoch, leaseUntil)
+		if claimErr != nil {
+			return runs, claimErr
+		}
+		if claimed == nil {
+			continue
+		}
+		job = claimed
+
+		s.run(ctx, job, deadline, pausedUntil)
+		log.WithFields(f).Debugf("github job %s is %s with %d of %d items processed", job.JobID, job.Status(s.now()), job.ProcessedItems, job.TotalItems)
+		runs++
+	}
+
+	return runs, nil
+}
+
+// rateLimitedUntil returns the epoch the installation may be used again when it's below the rate limit reserve
+func (s *service) rateLimitedUntil(installationID int64, pausedUntil map[int64]int64) (int64, bool) {
+	now := s.now()
+	if until, ok := pausedUntil[installationID]; ok && until > now.Unix() {
+		return until, true
+	}
+	rateLimit, ok := s.rateLimit(installationID)
+	if ok && rateLimit.Remaining < rateLimitReserve && rateLimit.Reset.After(now) {
+		return rateLimit.Reset.Unix() + 1, true
+	}
+	return 0, false
+}
+
+// run processes the items of the claimed job and stores the progress
+func (s *service) run(ctx context.Context, job *Job, deadline time.Time, pausedUntil map[int64]int64) {
+	handler, ok := s.handlers[job.JobType]
+	if !ok {
+		job.JobStatus = JobStatusFailed
+		job.LastError = fmt.Sprintf("unsupported job type %s", job.JobType)
+		s.finish(ctx, job)
+		return
+	}
+
+	job.RateLimitedUntilEpoch = 0
+	sinceUpdate := 0
+	for len(job.PendingItems) > 0 {
+		if s.now().After(deadline) {
+			job.NextRunEpoch = s.now().Unix()
+			s.update(ctx, job)
+			return
+		}
+
+		if until, limited := s.rateLimitedUntil(job.InstallationID, pausedUntil); limited {
+			s.pause(ctx, job, until)
+			return
+		}
+
+		item := job.PendingItems[0]
+		err := handler(ctx, job, item)
+		if errors.Is(err, githubutils.ErrRateLimited) {
+			until, limited := s.rateLimitedUntil(job.InstallationID, pausedUntil)
+			if !limited {
+				until = s.now().Add(rateLimitBackoff).Unix()
+				pausedUntil[job.InstallationID] = until
+			}
+			job.LastError = truncate(err.Error())
+			s.pause(ctx, job, until)
+			return
+		}
+
+		job.PendingItems = job.PendingItems[1:]
+		job.ProcessedItems++
+		if err != nil {
+			job.FailedItems++
+			job.LastError = truncate(err.Error())
+			if len(job.ItemErrors) < maxItemErrors {
+				job.ItemErrors = append(job.ItemErrors, truncate(fmt.Sprintf("%s : %s", item, err.Error())))
+			}
+		}
+
+		sinceUpdate++
+		if sinceUpdate >= progressInterval && len(job.PendingItems) > 0 {
+			s.update(ctx, job)
+			sinceUpdate = 0
+		}
+	}
+
+	job.JobStatus = JobStatusCompleted
+	s.finish(ctx, job)
+}
+
+// pause stores the job to be resumed once the rate limit resets
+func (s *service) pause(ctx context.Context, job *Job, until int64) {
+	job.NextRunEpoch = until
+	job.RateLimitedUntilEpoch = until
+	s.update(ctx, job)
+}
+
+// finish stores the finished job which expires after the retention period
+func (s *service) finish(ctx context.Context, job *Job) {
+	now := s.now()
+	job.NextRunEpoch = now.Unix()
+	job.DateCompleted = utils.TimeToString(now)
+	job.Expires = now.Add(jobRetention).Unix()
+	s.update(ctx, job)
+}
+
+func (s *service) update(ctx context.Context, job *Job) {
+	job.DateModified = utils.TimeToString(s.now())
+	if err := s.repo.UpdateJob(ctx, job); err != nil {
+		log.WithFields(logrus.Fields{
+			"functionName":   "update",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"jobID":          job.JobID,
+		}).WithError(err).Warn("unable to store the progress of the github job")
+	}
+}
+
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+	return s
+}
@@ -0,0 +1,252 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_jobs_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_jobs"
+	"github.com/communitybridge/easycla/cla-backend-go/github_jobs/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestService(ctrl *gomock.Controller, now time.Time, rateLimit *githubutils.RateLimit) (github_jobs.Service, *mock.MockRepository) {
+	repo := mock.NewMockRepository(ctrl)
+	return github_jobs.NewTestService(repo, func() time.Time { return now }, rateLimit), repo
+}
+
+// pendingJob returns a branch protection job of the org github organization which is due at now
+func pendingJob(now time.Time, items ...string) *github_jobs.Job {
+	return &github_jobs.Job{
+		JobID:            "job-1",
+		JobType:          github_jobs.JobTypeBranchProtection,
+		InstallationID:   1,
+		OrganizationName: "org",
+		ProjectSFID:      "project-sfid",
+		JobStatus:        github_jobs.JobStatusPending,
+		PendingItems:     items,
+		TotalItems:       int64(len(items)),
+		NextRunEpoch:     now.Unix(),
+	}
+}
+
+// expectRun loads the due job and claims it as claimed, it returns the job as it was last stored by the job run
+func expectRun(repo *mock.MockRepository, now time.Time, due, claimed *github_jobs.Job) func() *github_jobs.Job {
+	repo.EXPECT().GetDueJobs(gomock.Any(), now.Unix()).Return([]*github_jobs.Job{due}, nil)
+	repo.EXPECT().ClaimJob(gomock.Any(), due.JobID, due.NextRunEpoch, now.Add(github_jobs.JobLease).Unix()).Return(claimed, nil)
+	var stored *github_jobs.Job
+	repo.EXPECT().UpdateJob(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, job *github_jobs.Job) error {
+		copied := *job
+		stored = &copied
+		return nil
+	}).MinTimes(1)
+	return func() *github_jobs.Job { return stored }
+}
+
+func TestCreateJobMergesPendingJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Unix(1600000000, 0)
+	s, repo := newTestService(ctrl, now, nil)
+	var created []*github_jobs.Job
+	repo.EXPECT().CreateJob(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, job *github_jobs.Job) error {
+		copied := *job
+		created = append(created, &copied)
+		return nil
+	}).Times(2)
+
+	_, err := s.CreateJob(context.Background(), github_jobs.JobTypeBranchProtection, 1, "org", "project-sfid", nil, "user")
+	assert.Equal(t, github_jobs.ErrNoJobItems, err)
+
+	repo.EXPECT().GetJobsByInstallation(gomock.Any(), int64(1)).Return(nil, nil)
+	job, err := s.CreateJob(context.Background(), github_jobs.JobTypeBranchProtection, 1, "org", "project-sfid", []string{"a", "b", "a", ""}, "user")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"a", "b"}, job.PendingItems)
+	assert.Equal(t, int64(2), job.TotalItems)
+	assert.Equal(t, github_jobs.JobStatusPending, job.Status(now))
+
+	repo.EXPECT().GetJobsByInstallation(gomock.Any(), int64(1)).Return([]*github_jobs.Job{created[0]}, nil)
+	repo.EXPECT().AddJobItems(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, job *github_jobs.Job) (bool, error) {
+		assert.Equal(t, created[0].NextRunEpoch, job.NextRunEpoch)
+		return true, nil
+	})
+	merged, err := s.CreateJob(context.Background(), github_jobs.JobTypeBranchProtection, 1, "org", "project-sfid", []string{"b", "c"}, "user")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, job.JobID, merged.JobID)
+	assert.Equal(t, []string{"a", "b", "c"}, merged.PendingItems)
+	assert.Equal(t, int64(3), merged.TotalItems)
+
+	repo.EXPECT().GetJobsByInstallation(gomock.Any(), int64(1)).Return([]*github_jobs.Job{merged}, nil)
+	other, err := s.CreateJob(context.Background(), github_jobs.JobTypeBranchProtection, 1, "other-org", "project-sfid", []string{"a"}, "user")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotEqual(t, job.JobID, other.JobID)
+	assert.Len(t, created, 2)
+}
+
+func TestCreateJobAfterJobClaimed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Unix(1600000000, 0)
+	s, repo := newTestService(ctrl, now, nil)
+
+	// the pending job is claimed by a job run between loading and extending it
+	repo.EXPECT().GetJobsByInstallation(gomock.Any(), int64(1)).Return([]*github_jobs.Job{pendingJob(now, "a")}, nil)
+	repo.EXPECT().AddJobItems(gomock.Any(), gomock.Any()).Return(false, nil)
+	repo.EXPECT().CreateJob(gomock.Any(), gomock.Any()).Return(nil)
+
+	created, err := s.CreateJob(context.Background(), github_jobs.JobTypeBranchProtection, 1, "org", "project-sfid", []string{"b"}, "user")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotEqual(t, "job-1", created.JobID)
+	assert.Equal(t, []string{"b"}, created.PendingItems)
+	assert.Equal(t, int64(1), created.TotalItems)
+}
+
+func TestProcessJobsRunsItemsAddedBeforeClaim(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Unix(1600000000, 0)
+	s, repo := newTestService(ctrl, now, nil)
+	var processed []string
+	s.RegisterHandler(github_jobs.JobTypeBranchProtection, func(ctx context.Context, job *github_jobs.Job, item string) error {
+		processed = append(processed, item)
+		return nil
+	})
+	// the job was extended by a concurrent job creation after the due jobs were loaded
+	stored := expectRun(repo, now, pendingJob(now, "a"), pendingJob(now, "a", "b"))
+
+	_, err := s.ProcessJobs(context.Background(), now.Add(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, processed)
+	assert.Equal(t, github_jobs.JobStatusCompleted, stored().Status(now))
+}
+
+func TestProcessJobsCompletesJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Unix(1600000000, 0)
+	s, repo := newTestService(ctrl, now, &githubutils.RateLimit{Remaining: 4000, Reset: now.Add(time.Hour)})
+	var processed []string
+	s.RegisterHandler(github_jobs.JobTypeBranchProtection, func(ctx context.Context, job *github_jobs.Job, item string) error {
+		processed = append(processed, item)
+		if item == "bad" {
+			return errors.New("repository not found")
+		}
+		return nil
+	})
+	stored := expectRun(repo, now, pendingJob(now, "a", "bad", "c"), pendingJob(now, "a", "bad", "c"))
+
+	runs, err := s.ProcessJobs(context.Background(), now.Add(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, runs)
+	assert.Equal(t, []string{"a", "bad", "c"}, processed)
+
+	job := stored()
+	assert.Equal(t, github_jobs.JobStatusCompleted, job.Status(now))
+	assert.Empty(t, job.PendingItems)
+	assert.Equal(t, int64(3), job.ProcessedItems)
+	assert.Equal(t, int64(1), job.FailedItems)
+	assert.Equal(t, []string{"bad : repository not found"}, job.ItemErrors)
+	assert.Equal(t, now.Add(github_jobs.JobRetention).Unix(), job.Expires)
+	assert.Equal(t, int64(100), job.ToModel().PercentComplete)
+}
+
+func TestProcessJobsSkipsClaimedJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Unix(1600000000, 0)
+	s, repo := newTestService(ctrl, now, nil)
+	s.RegisterHandler(github_jobs.JobTypeBranchProtection, func(ctx context.Context, job *github_jobs.Job, item string) error {
+		t.Fatalf("item %s of a job claimed by another job run processed", item)
+		return nil
+	})
+	job := pendingJob(now, "a")
+	repo.EXPECT().GetDueJobs(gomock.Any(), now.Unix()).Return([]*github_jobs.Job{job}, nil)
+	repo.EXPECT().ClaimJob(gomock.Any(), job.JobID, job.NextRunEpoch, now.Add(github_jobs.JobLease).Unix()).Return(nil, nil)
+
+	runs, err := s.ProcessJobs(context.Background(), now.Add(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, runs)
+}
+
+func TestProcessJobsPausesBelowRateLimitReserve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Unix(1600000000, 0)
+	reset := now.Add(20 * time.Minute)
+	s, repo := newTestService(ctrl, now, &githubutils.RateLimit{Remaining: github_jobs.RateLimitReserve - 1, Reset: reset})
+	s.RegisterHandler(github_jobs.JobTypeBranchProtection, func(ctx context.Context, job *github_jobs.Job, item string) error {
+		t.Fatalf("item %s processed while rate limited", item)
+		return nil
+	})
+	stored := expectRun(repo, now, pendingJob(now, "a"), pendingJob(now, "a"))
+
+	_, err := s.ProcessJobs(context.Background(), now.Add(time.Minute))
+	assert.Nil(t, err)
+
+	job := stored()
+	assert.Equal(t, github_jobs.JobStatusRateLimited, job.Status(now))
+	assert.Equal(t, reset.Unix()+1, job.NextRunEpoch)
+	assert.Equal(t, []string{"a"}, job.PendingItems)
+}
+
+func TestProcessJobsPausesOnRateLimitError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Unix(1600000000, 0)
+	s, repo := newTestService(ctrl, now, nil)
+	s.RegisterHandler(github_jobs.JobTypeBranchProtection, func(ctx context.Context, job *github_jobs.Job, item string) error {
+		if item == "b" {
+			return fmt.Errorf("unable to enable branch protection : %w", githubutils.ErrRateLimited)
+		}
+		return nil
+	})
+	stored := expectRun(repo, now, pendingJob(now, "a", "b", "c"), pendingJob(now, "a", "b", "c"))
+
+	_, err := s.ProcessJobs(context.Background(), now.Add(time.Minute))
+	assert.Nil(t, err)
+
+	job := stored()
+	assert.Equal(t, github_jobs.JobStatusRateLimited, job.Status(now))
+	assert.Equal(t, now.Add(github_jobs.RateLimitBackoff).Unix(), job.NextRunEpoch)
+	assert.Equal(t, []string{"b", "c"}, job.PendingItems)
+	assert.Equal(t, int64(1), job.ProcessedItems)
+	assert.Equal(t, int64(0), job.FailedItems)
+}
+
+func TestProcessJobsStopsAtDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	start := time.Unix(1600000000, 0)
+	now := start
+	repo := mock.NewMockRepository(ctrl)
+	s := github_jobs.NewTestService(repo, func() time.Time { return now }, nil)
+	s.RegisterHandler(github_jobs.JobTypeBranchProtection, func(ctx context.Context, job *github_jobs.Job, item string) error {
+		now = now.Add(time.Minute)
+		return nil
+	})
+	stored := expectRun(repo, start, pendingJob(start, "a", "b", "c"), pendingJob(start, "a", "b", "c"))
+
+	_, err := s.ProcessJobs(context.Background(), start.Add(90*time.Second))
+	assert.Nil(t, err)
+
+	job := stored()
+	assert.Equal(t, github_jobs.JobStatusPending, job.Status(now))
+	assert.Equal(t, []string{"c"}, job.PendingItems)
+	assert.Equal(t, int64(2), job.ProcessedItems)
+	assert.Equal(t, now.Unix(), job.NextRunEpoch)
+}
@@ -0,0 +1,26 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_org_members
+
+import (
+	"context"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+)
+
+// NewTestService creates the service with the github calls and clock of the test
+func NewTestService(repo Repository, githubOrgRepo GithubOrganizationRepository, companyRepo CompanyRepository, signatureRepo SignatureRepository,
+	listMembers func(ctx context.Context, installation github.Installation, organizationName string) ([]string, error),
+	isOrganizationMember func(ctx context.Context, organizationName, userName string) (bool, error), now func() time.Time) Service {
+	return &service{
+		repo:                 repo,
+		githubOrgRepo:        githubOrgRepo,
+		companyRepo:          companyRepo,
+		signatureRepo:        signatureRepo,
+		listMembers:          listMembers,
+		isOrganizationMember: isOrganizationMember,
+		now:                  now,
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_org_members
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// CacheStatus is the state of the cached members of a github organization
+type CacheStatus string
+
+// cache status values
+const (
+	// CacheStatusCurrent organizations were refreshed successfully within the maximum cache age
+	CacheStatusCurrent CacheStatus = "current"
+	// CacheStatusStale organizations were refreshed once but not within the maximum cache age
+	CacheStatusStale CacheStatus = "stale"
+	// CacheStatusNotCached organizations never installed the github app or were never refreshed
+	CacheStatusNotCached CacheStatus = "not_cached"
+)
+
+// Member is the database model for the github organization members table, one record per member of the organization
+type Member struct {
+	OrganizationName string `dynamodbav:"organization_name" json:"organization_name"`
+	GithubUsername   string `dynamodbav:"github_username" json:"github_username"`
+	GithubLogin      string `dynamodbav:"github_login" json:"github_login"`
+	DateCreated      string `dynamodbav:"date_created" json:"date_created"`
+	DateModified     string `dynamodbav:"date_modified" json:"date_modified"`
+}
+
+// Organization is the database model for the github organization member caches table, it tracks the refresh of the
+// members of each organization - the names are stored in lower case
+type Organization struct {
+	OrganizationName string `dynamodbav:"organization_name" json:"organization_name"`
+	InstallationID   int64  `dynamodbav:"installation_id" json:"installation_id"`
+	MemberCount      int    `dynamodbav:"member_count" json:"member_count"`
+	DateRefreshed    string `dynamodbav:"date_refreshed,omitempty" json:"date_refreshed,omitempty"`
+	LastError        string `dynamodbav:"last_error,omitempty" json:"last_error,omitempty"`
+	DateLastError    string `dynamodbav:"date_last_error,omitempty" json:"date_last_error,omitempty"`
+	DateCreated      string `dynamodbav:"date_created" json:"date_created"`
+	DateModified     string `dynamodbav:"date_modified" json:"date_modified"`
+}
+
+// toModel converts the cache of an approved organization to the API model
+func (o *Organization) toModel(organizationName string, status CacheStatus, members []*Member) *models.GithubOrgMemberCoverage {
+	out := &models.GithubOrgMemberCoverage{
+		OrganizationName: organizationName,
+		CacheStatus:      string(status),
+		Members:          []string{},
+	}
+	if o != nil {
+		out.DateRefreshed = o.DateRefreshed
+		out.LastError = o.LastError
+	}
+	for _, member := range members {
+		out.Members = append(out.Members, member.GithubLogin)
+	}
+	out.MemberCount = int64(len(out.Members))
+	return out
+}
+
+// Report is the outcome of a run of the refresh job
+type Report struct {
+	Refreshed int
+	Skipped   int
+	Failed    int
+}
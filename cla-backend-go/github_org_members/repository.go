@@ -0,0 +1,224 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_org_members
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// Repository interface defines the functions for the github organization members cache data model
+type Repository interface {
+	GetOrganization(ctx context.Context, organizationName string) (*Organization, error)
+	PutOrganization(ctx context.Context, organization *Organization) error
+	GetMember(ctx context.Context, organizationName, githubUsername string) (*Member, error)
+	GetMembers(ctx context.Context, organizationName string) ([]*Member, error)
+	PutMember(ctx context.Context, member *Member) error
+	DeleteMember(ctx context.Context, organizationName, githubUsername string) error
+}
+
+type repository struct {
+	stage                  string
+	dynamoDBClient         *dynamodb.DynamoDB
+	membersTableName       string
+	organizationsTableName string
+}
+
+// NewRepository creates a new instance of the github organization members repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:                  stage,
+		dynamoDBClient:         dynamodb.New(awsSession),
+		membersTableName:       fmt.Sprintf("cla-%s-github-org-members", stage),
+		organizationsTableName: fmt.Sprintf("cla-%s-github-org-member-caches", stage),
+	}
+}
+
+// GetOrganization returns the cache record of the organization or nil if the organization was never refreshed
+func (repo *repository) GetOrganization(ctx context.Context, organizationName string) (*Organization, error) {
+	f := logrus.Fields{
+		"functionName":     "GetOrganization",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"tableName":        repo.organizationsTableName,
+		"organizationName": organizationName,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_name": {S: aws.String(organizationName)},
+		},
+		TableName: aws.String(repo.organizationsTableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load github organization member cache, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, nil
+	}
+
+	var organization Organization
+	err = dynamodbattribute.UnmarshalMap(result.Item, &organization)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to unmarshal github organization member cache, error: %+v", err)
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// PutOrganization creates or replaces the cache record of the organization
+func (repo *repository) PutOrganization(ctx context.Context, organization *Organization) error {
+	f := logrus.Fields{
+		"functionName":     "PutOrganization",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"tableName":        repo.organizationsTableName,
+		"organizationName": organization.OrganizationName,
+	}
+	return repo.put(f, repo.organizationsTableName, organization)
+}
+
+// GetMember returns the cached member of the organization or nil if the user is not a member
+func (repo *repository) GetMember(ctx context.Context, organizationName, githubUsername string) (*Member, error) {
+	f := logrus.Fields{
+		"functionName":     "GetMember",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"tableName":        repo.membersTableName,
+		"organizationName": organizationName,
+		"githubUsername":   githubUsername,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_name": {S: aws.String(organizationName)},
+			"github_username":   {S: aws.String(githubUsername)},
+		},
+		TableName: aws.String(repo.membersTableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load github organization member, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, nil
+	}
+
+	var member Member
+	err = dynamodbattribute.UnmarshalMap(result.Item, &member)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to unmarshal github organization member, error: %+v", err)
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetMembers returns the cached members of the organization
+func (repo *repository) GetMembers(ctx context.Context, organizationName string) ([]*Member, error) {
+	f := logrus.Fields{
+		"functionName":     "GetMembers",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"tableName":        repo.membersTableName,
+		"organizationName": organizationName,
+	}
+
+	condition := expression.Key("organization_name").Equal(expression.Value(organizationName))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for github organization members query, error: %v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.membersTableName),
+	}
+	var resultList []map[string]*dynamodb.AttributeValue
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).Warnf("error retrieving github organization members, error: %v", err)
+			return nil, err
+		}
+		resultList = append(resultList, results.Items...)
+		if len(results.LastEvaluatedKey) != 0 {
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+
+	var members []*Member
+	err = dynamodbattribute.UnmarshalListOfMaps(resultList, &members)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling github organization members from database, error: %v", err)
+		return nil, err
+	}
+	return members, nil
+}
+
+// PutMember creates or replaces the cached member
+func (repo *repository) PutMember(ctx context.Context, member *Member) error {
+	f := logrus.Fields{
+		"functionName":     "PutMember",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"tableName":        repo.membersTableName,
+		"organizationName": member.OrganizationName,
+		"githubUsername":   member.GithubUsername,
+	}
+	return repo.put(f, repo.membersTableName, member)
+}
+
+// DeleteMember removes the member from the cache of the organization
+func (repo *repository) DeleteMember(ctx context.Context, organizationName, githubUsername string) error {
+	f := logrus.Fields{
+		"functionName":     "DeleteMember",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"tableName":        repo.membersTableName,
+		"organizationName": organizationName,
+		"githubUsername":   githubUsername,
+	}
+
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_name": {S: aws.String(organizationName)},
+			"github_username":   {S: aws.String(githubUsername)},
+		},
+		TableName: aws.String(repo.membersTableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to delete github organization member, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+func (repo *repository) put(f logrus.Fields, tableName string, in interface{}) error {
+	av, err := dynamodbattribute.MarshalMap(in)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal record, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store record, error: %+v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,359 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_org_members
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+const (
+	// RefreshInterval is the time after which the members of an organization are loaded again from github
+	RefreshInterval = 6 * time.Hour
+	// MaxCacheAge is the time after which the cached members are no longer trusted and the membership is checked
+	// with github directly
+	MaxCacheAge = 24 * time.Hour
+)
+
+// errors
+var (
+	ErrCompanyNotFound   = errors.New("company not found")
+	ErrSignatureNotFound = errors.New("company has no approved CCLA signature for the CLA group")
+)
+
+// GithubOrganizationRepository is the part of the github organizations repository used to find the app installations
+type GithubOrganizationRepository interface {
+	GetAllGithubOrganizations(ctx context.Context) (*v1Models.GithubOrganizations, error)
+}
+
+// CompanyRepository is the part of the company repository used to load the company of the CCLA signature
+type CompanyRepository interface {
+	GetCompanyByExternalID(ctx context.Context, companySFID string) (*v1Models.Company, error)
+}
+
+// SignatureRepository is the part of the signatures repository used to load the CCLA signature
+type SignatureRepository interface {
+	GetProjectCompanySignature(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*v1Models.Signature, error)
+}
+
+// Service interface defines the github organization members cache service methods
+type Service interface {
+	IsMember(ctx context.Context, organizationName, githubUsername string) (bool, error)
+	GetMemberOrganizations(ctx context.Context, githubUsername string) ([]string, error)
+	RefreshOrganizations(ctx context.Context, deadline time.Time) (*Report, error)
+	GetCoveredMembers(ctx context.Context, claGroupID, companySFID string) (*models.GithubOrgMemberCoverageList, error)
+}
+
+type service struct {
+	repo                 Repository
+	githubOrgRepo        GithubOrganizationRepository
+	companyRepo          CompanyRepository
+	signatureRepo        SignatureRepository
+	listMembers          func(ctx context.Context, installation github.Installation, organizationName string) ([]string, error)
+	isOrganizationMember func(ctx context.Context, organizationName, userName string) (bool, error)
+	now                  func() time.Time
+}
+
+// NewService creates a new github organization members cache service
+func NewService(repo Repository, githubOrgRepo GithubOrganizationRepository, companyRepo CompanyRepository, signatureRepo SignatureRepository) Service {
+	return &service{
+		repo:                 repo,
+		githubOrgRepo:        githubOrgRepo,
+		companyRepo:          companyRepo,
+		signatureRepo:        signatureRepo,
+		listMembers:          github.ListOrganizationMembersForInstallation,
+		isOrganizationMember: github.IsOrganizationMember,
+		now:                  time.Now,
+	}
+}
+
+// IsMember returns true if the github user is a member of the github organization. The cached members are used when
+// the organization installed the github app and was refreshed within the maximum cache age, which includes the private
+// members, otherwise github is asked directly which only sees the public members.
+func (s *service) IsMember(ctx context.Context, organizationName, githubUsername string) (bool, error) {
+	f := logrus.Fields{
+		"functionName":     "IsMember",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": organizationName,
+		"githubUsername":   githubUsername,
+	}
+
+	organizationKey := strings.ToLower(strings.TrimSpace(organizationName))
+	organization, err := s.repo.GetOrganization(ctx, organizationKey)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the github organization member cache - checking github")
+	} else if s.cacheStatus(organization) == CacheStatusCurrent {
+		member, err := s.repo.GetMember(ctx, organizationKey, strings.ToLower(strings.TrimSpace(githubUsername)))
+		if err == nil {
+			return member != nil, nil
+		}
+		log.WithFields(f).WithError(err).Warn("unable to load the github organization member - checking github")
+	}
+
+	return s.isOrganizationMember(ctx, organizationName, githubUsername)
+}
+
+// GetMemberOrganizations returns the names of the github organizations with a current cache which have the github user
+// as a member, which includes the organizations where the membership of the user is private
+func (s *service) GetMemberOrganizations(ctx context.Context, githubUsername string) ([]string, error) {
+	f := logrus.Fields{
+		"functionName":   "GetMemberOrganizations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"githubUsername": githubUsername,
+	}
+
+	githubOrgs, err := s.githubOrgRepo.GetAllGithubOrganizations(ctx)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the github organizations")
+		return nil, err
+	}
+
+	username := strings.ToLower(strings.TrimSpace(githubUsername))
+	var organizationNames []string
+	for _, githubOrg := range githubOrgs.List {
+		if githubOrg.OrganizationInstallationID == 0 {
+			continue
+		}
+		organizationKey := strings.ToLower(githubOrg.OrganizationName)
+		organization, err := s.repo.GetOrganization(ctx, organizationKey)
+		if err != nil {
+			return nil, err
+		}
+		if s.cacheStatus(organization) != CacheStatusCurrent {
+			continue
+		}
+		member, err := s.repo.GetMember(ctx, organizationKey, username)
+		if err != nil {
+			return nil, err
+		}
+		if member != nil {
+			organizationNames = append(organizationNames, githubOrg.OrganizationName)
+		}
+	}
+	return organizationNames, nil
+}
+
+// RefreshOrganizations loads the members of the github organizations with an active github app installation which
+// were not refreshed within the refresh interval, the least recently refreshed first, until the deadline
+func (s *service) RefreshOrganizations(ctx context.Context, deadline time.Time) (*Report, error) {
+	f := logrus.Fields{
+		"functionName":   "RefreshOrganizations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	githubOrgs, err := s.githubOrgRepo.GetAllGithubOrganizations(ctx)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the github organizations")
+		return nil, err
+	}
+
+	type candidate struct {
+		githubOrg    *v1Models.GithubOrganization
+		organization *Organization
+		lastRefresh  time.Time
+	}
+	var candidates []candidate
+	report := &Report{}
+	for _, githubOrg := range githubOrgs.List {
+		if githubOrg.InstallationStatus != github_organizations.InstallationStatusActive || githubOrg.OrganizationInstallationID == 0 {
+			continue
+		}
+		organization, err := s.repo.GetOrganization(ctx, strings.ToLower(githubOrg.OrganizationName))
+		if err != nil {
+			report.Failed++
+			continue
+		}
+		var lastRefresh time.Time
+		if organization != nil {
+			lastRefresh = lastAttempt(organization)
+		}
+		if s.now().Sub(lastRefresh) < RefreshInterval {
+			report.Skipped++
+			continue
+		}
+		candidates = append(candidates, candidate{githubOrg: githubOrg, organization: organization, lastRefresh: lastRefresh})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].lastRefresh.Before(candidates[j].lastRefresh)
+	})
+
+	for i, c := range candidates {
+		if s.now().After(deadline) {
+			log.WithFields(f).Infof("deadline reached - %d github organizations left for the next run", len(candidates)-i)
+			break
+		}
+		if err := s.refreshOrganization(ctx, c.githubOrg, c.organization); err != nil {
+			report.Failed++
+			continue
+		}
+		report.Refreshed++
+	}
+	return report, nil
+}
+
+// refreshOrganization replaces the cached members of the organization with the members listed by the github app
+// installation. A failed refresh is recorded on the cache record and keeps the previous members.
+func (s *service) refreshOrganization(ctx context.Context, githubOrg *v1Models.GithubOrganization, organization *Organization) error {
+	f := logrus.Fields{
+		"functionName":     "refreshOrganization",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": githubOrg.OrganizationName,
+		"installationID":   githubOrg.OrganizationInstallationID,
+	}
+
+	now := utils.TimeToString(s.now())
+	organizationKey := strings.ToLower(githubOrg.OrganizationName)
+	if organization == nil {
+		organization = &Organization{
+			OrganizationName: organizationKey,
+			DateCreated:      now,
+		}
+	}
+	organization.InstallationID = githubOrg.OrganizationInstallationID
+	organization.DateModified = now
+
+	logins, err := s.listMembers(ctx, github_organizations.GithubInstallation(githubOrg), githubOrg.OrganizationName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to list the github organization members")
+		organization.LastError = err.Error()
+		organization.DateLastError = now
+		if putErr := s.repo.PutOrganization(ctx, organization); putErr != nil {
+			log.WithFields(f).WithError(putErr).Warn("unable to record the failed refresh")
+		}
+		return err
+	}
+
+	cached, err := s.repo.GetMembers(ctx, organizationKey)
+	if err != nil {
+		return err
+	}
+	stale := make(map[string]bool, len(cached))
+	for _, member := range cached {
+		stale[member.GithubUsername] = true
+	}
+
+	for _, login := range logins {
+		githubUsername := strings.ToLower(login)
+		if stale[githubUsername] {
+			delete(stale, githubUsername)
+			continue
+		}
+		err = s.repo.PutMember(ctx, &Member{
+			OrganizationName: organizationKey,
+			GithubUsername:   githubUsername,
+			GithubLogin:      login,
+			DateCreated:      now,
+			DateModified:     now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for githubUsername := range stale {
+		if err = s.repo.DeleteMember(ctx, organizationKey, githubUsername); err != nil {
+			return err
+		}
+	}
+
+	organization.MemberCount = len(logins)
+	organization.DateRefreshed = now
+	organization.LastError = ""
+	organization.DateLastError = ""
+	if err = s.repo.PutOrganization(ctx, organization); err != nil {
+		return err
+	}
+	log.WithFields(f).Debugf("refreshed %d github organization members", len(logins))
+	return nil
+}
+
+// GetCoveredMembers returns the cached members of each github organization on the approval list of the CCLA signature
+// of the company for the CLA group, the members of organizations without a current cache are not listed
+func (s *service) GetCoveredMembers(ctx context.Context, claGroupID, companySFID string) (*models.GithubOrgMemberCoverageList, error) {
+	f := logrus.Fields{
+		"functionName":   "GetCoveredMembers",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"companySFID":    companySFID,
+	}
+
+	companyModel, err := s.companyRepo.GetCompanyByExternalID(ctx, companySFID)
+	if err != nil || companyModel == nil {
+		return nil, ErrCompanyNotFound
+	}
+	signed, approved := true, true
+	pageSize := int64(1)
+	signature, err := s.signatureRepo.GetProjectCompanySignature(ctx, companyModel.CompanyID, claGroupID, &signed, &approved, nil, &pageSize)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CCLA signature")
+		return nil, err
+	}
+	if signature == nil {
+		return nil, ErrSignatureNotFound
+	}
+
+	response := &models.GithubOrgMemberCoverageList{
+		ClaGroupID:    claGroupID,
+		CompanyID:     companyModel.CompanyID,
+		SignatureID:   signature.SignatureID.String(),
+		Organizations: []*models.GithubOrgMemberCoverage{},
+	}
+	for _, organizationName := range signature.GithubOrgApprovalList {
+		organizationKey := strings.ToLower(strings.TrimSpace(organizationName))
+		organization, err := s.repo.GetOrganization(ctx, organizationKey)
+		if err != nil {
+			return nil, err
+		}
+		status := s.cacheStatus(organization)
+		var members []*Member
+		if status == CacheStatusCurrent {
+			members, err = s.repo.GetMembers(ctx, organizationKey)
+			if err != nil {
+				return nil, err
+			}
+			sort.Slice(members, func(i, j int) bool {
+				return members[i].GithubUsername < members[j].GithubUsername
+			})
+		}
+		response.Organizations = append(response.Organizations, organization.toModel(organizationName, status, members))
+	}
+	return response, nil
+}
+
+// cacheStatus returns the state of the cached members of the organization
+func (s *service) cacheStatus(organization *Organization) CacheStatus {
+	if organization == nil || organization.DateRefreshed == "" {
+		return CacheStatusNotCached
+	}
+	refreshed, err := utils.ParseDateTime(organization.DateRefreshed)
+	if err != nil || s.now().Sub(refreshed) > MaxCacheAge {
+		return CacheStatusStale
+	}
+	return CacheStatusCurrent
+}
+
+// lastAttempt returns the time of the last successful or failed refresh of the organization
+func lastAttempt(organization *Organization) time.Time {
+	var last time.Time
+	for _, value := range []string{organization.DateRefreshed, organization.DateLastError} {
+		if value == "" {
+			continue
+		}
+		if t, err := utils.ParseDateTime(value); err == nil && t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
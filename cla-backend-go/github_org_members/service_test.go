@@ -0,0 +1,222 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_org_members_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_org_members"
+	"github.com/communitybridge/easycla/cla-backend-go/github_org_members/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestService returns the service for the company-sfid company with the CCLA signature of cla-group-id
+func newTestService(ctrl *gomock.Controller, repo github_org_members.Repository, listMembers func(ctx context.Context, installation github.Installation, organizationName string) ([]string, error), githubOrgs ...*v1Models.GithubOrganization) github_org_members.Service {
+	githubOrgRepo := mock.NewMockGithubOrganizationRepository(ctrl)
+	githubOrgRepo.EXPECT().GetAllGithubOrganizations(gomock.Any()).Return(&v1Models.GithubOrganizations{List: githubOrgs}, nil).AnyTimes()
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	companyRepo.EXPECT().GetCompanyByExternalID(gomock.Any(), "company-sfid").
+		Return(&v1Models.Company{CompanyID: "company-id", CompanyExternalID: "company-sfid"}, nil).AnyTimes()
+	companyRepo.EXPECT().GetCompanyByExternalID(gomock.Any(), gomock.Not("company-sfid")).Return(nil, errors.New("not found")).AnyTimes()
+	signatureRepo := mock.NewMockSignatureRepository(ctrl)
+	signatureRepo.EXPECT().GetProjectCompanySignature(gomock.Any(), "company-id", "cla-group-id", gomock.Any(), gomock.Any(), nil, gomock.Any()).
+		Return(&v1Models.Signature{
+			SignatureID:           "signature-id",
+			GithubOrgApprovalList: []string{"Acme", "public-org"},
+		}, nil).AnyTimes()
+	signatureRepo.EXPECT().GetProjectCompanySignature(gomock.Any(), "company-id", gomock.Not("cla-group-id"), gomock.Any(), gomock.Any(), nil, gomock.Any()).
+		Return(nil, nil).AnyTimes()
+
+	isOrganizationMember := func(ctx context.Context, organizationName, userName string) (bool, error) {
+		return organizationName == "public-org" && userName == "public-user", nil
+	}
+	return github_org_members.NewTestService(repo, githubOrgRepo, companyRepo, signatureRepo, listMembers, isOrganizationMember,
+		func() time.Time { return testNow })
+}
+
+func installedOrg(name string, installationID int64) *v1Models.GithubOrganization {
+	return &v1Models.GithubOrganization{
+		OrganizationName:           name,
+		OrganizationInstallationID: installationID,
+		InstallationStatus:         github_organizations.InstallationStatusActive,
+	}
+}
+
+func TestRefreshOrganizations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := utils.TimeToString(testNow)
+	recent := &github_org_members.Organization{
+		OrganizationName: "recent-org",
+		DateRefreshed:    utils.TimeToString(testNow.Add(-time.Hour)),
+	}
+	broken := &github_org_members.Organization{
+		OrganizationName: "broken-org",
+		DateRefreshed:    utils.TimeToString(testNow.Add(-48 * time.Hour)),
+	}
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetOrganization(gomock.Any(), "acme").Return(nil, nil)
+	repo.EXPECT().GetOrganization(gomock.Any(), "recent-org").Return(recent, nil)
+	repo.EXPECT().GetOrganization(gomock.Any(), "broken-org").Return(broken, nil)
+	repo.EXPECT().GetMembers(gomock.Any(), "acme").Return([]*github_org_members.Member{
+		{OrganizationName: "acme", GithubUsername: "former", GithubLogin: "Former"},
+	}, nil)
+	repo.EXPECT().PutMember(gomock.Any(), &github_org_members.Member{
+		OrganizationName: "acme", GithubUsername: "alice", GithubLogin: "Alice", DateCreated: now, DateModified: now,
+	}).Return(nil)
+	repo.EXPECT().PutMember(gomock.Any(), &github_org_members.Member{
+		OrganizationName: "acme", GithubUsername: "bob", GithubLogin: "bob", DateCreated: now, DateModified: now,
+	}).Return(nil)
+	repo.EXPECT().DeleteMember(gomock.Any(), "acme", "former").Return(nil)
+	saved := map[string]*github_org_members.Organization{}
+	repo.EXPECT().PutOrganization(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, organization *github_org_members.Organization) error {
+		copied := *organization
+		saved[organization.OrganizationName] = &copied
+		return nil
+	}).Times(2)
+
+	uninstalled := installedOrg("uninstalled-org", 3)
+	uninstalled.InstallationStatus = github_organizations.InstallationStatusUninstalled
+	var listed []string
+	listMembers := func(ctx context.Context, installation github.Installation, organizationName string) ([]string, error) {
+		listed = append(listed, organizationName)
+		if organizationName == "broken-org" {
+			return nil, github.ErrRateLimited
+		}
+		return []string{"Alice", "bob"}, nil
+	}
+	s := newTestService(ctrl, repo, listMembers, installedOrg("Acme", 1), installedOrg("recent-org", 2), installedOrg("broken-org", 4), uninstalled)
+
+	report, err := s.RefreshOrganizations(context.Background(), testNow.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, &github_org_members.Report{Refreshed: 1, Skipped: 1, Failed: 1}, report)
+	// the least recently refreshed organization goes first
+	assert.Equal(t, []string{"Acme", "broken-org"}, listed)
+	assert.Equal(t, &github_org_members.Organization{
+		OrganizationName: "acme",
+		InstallationID:   1,
+		MemberCount:      2,
+		DateRefreshed:    now,
+		DateCreated:      now,
+		DateModified:     now,
+	}, saved["acme"])
+
+	// a failed refresh keeps the previous refresh date and records the error
+	assert.Equal(t, utils.TimeToString(testNow.Add(-48*time.Hour)), saved["broken-org"].DateRefreshed)
+	assert.Equal(t, github.ErrRateLimited.Error(), saved["broken-org"].LastError)
+	assert.Equal(t, now, saved["broken-org"].DateLastError)
+
+	// nothing is due within the refresh interval, including the failed organization
+	repo.EXPECT().GetOrganization(gomock.Any(), "acme").Return(saved["acme"], nil)
+	repo.EXPECT().GetOrganization(gomock.Any(), "recent-org").Return(recent, nil)
+	repo.EXPECT().GetOrganization(gomock.Any(), "broken-org").Return(saved["broken-org"], nil)
+	listed = nil
+	report, err = s.RefreshOrganizations(context.Background(), testNow.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, &github_org_members.Report{Skipped: 3}, report)
+	assert.Empty(t, listed)
+}
+
+func TestIsMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetOrganization(gomock.Any(), "acme").Return(&github_org_members.Organization{
+		OrganizationName: "acme",
+		DateRefreshed:    utils.TimeToString(testNow.Add(-time.Hour)),
+	}, nil).Times(2)
+	repo.EXPECT().GetMember(gomock.Any(), "acme", "private-user").
+		Return(&github_org_members.Member{OrganizationName: "acme", GithubUsername: "private-user", GithubLogin: "Private-User"}, nil)
+	repo.EXPECT().GetMember(gomock.Any(), "acme", "public-user").Return(nil, nil)
+	repo.EXPECT().GetOrganization(gomock.Any(), "public-org").Return(&github_org_members.Organization{
+		OrganizationName: "public-org",
+		DateRefreshed:    utils.TimeToString(testNow.Add(-github_org_members.MaxCacheAge - time.Hour)),
+	}, nil)
+	s := newTestService(ctrl, repo, nil)
+
+	isMember, err := s.IsMember(context.Background(), "ACME", "private-USER")
+	assert.Nil(t, err)
+	assert.True(t, isMember)
+
+	// a current cache is trusted over github
+	isMember, err = s.IsMember(context.Background(), "acme", "public-user")
+	assert.Nil(t, err)
+	assert.False(t, isMember)
+
+	// a stale cache falls back to github
+	isMember, err = s.IsMember(context.Background(), "public-org", "public-user")
+	assert.Nil(t, err)
+	assert.True(t, isMember)
+}
+
+func TestGetMemberOrganizations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetOrganization(gomock.Any(), "acme").Return(&github_org_members.Organization{
+		OrganizationName: "acme",
+		DateRefreshed:    utils.TimeToString(testNow.Add(-time.Hour)),
+	}, nil)
+	repo.EXPECT().GetMember(gomock.Any(), "acme", "private-user").
+		Return(&github_org_members.Member{OrganizationName: "acme", GithubUsername: "private-user", GithubLogin: "Private-User"}, nil)
+	repo.EXPECT().GetOrganization(gomock.Any(), "other-org").Return(&github_org_members.Organization{
+		OrganizationName: "other-org",
+		DateRefreshed:    utils.TimeToString(testNow.Add(-time.Hour)),
+	}, nil)
+	repo.EXPECT().GetMember(gomock.Any(), "other-org", "private-user").Return(nil, nil)
+	repo.EXPECT().GetOrganization(gomock.Any(), "stale-org").Return(&github_org_members.Organization{
+		OrganizationName: "stale-org",
+		DateRefreshed:    utils.TimeToString(testNow.Add(-github_org_members.MaxCacheAge - time.Hour)),
+	}, nil)
+	// the organizations without the github app are not cached
+	s := newTestService(ctrl, repo, nil, installedOrg("Acme", 1), installedOrg("other-org", 2), installedOrg("stale-org", 3),
+		&v1Models.GithubOrganization{OrganizationName: "not-installed"})
+
+	organizationNames, err := s.GetMemberOrganizations(context.Background(), "Private-User")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Acme"}, organizationNames)
+}
+
+func TestGetCoveredMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetOrganization(gomock.Any(), "acme").Return(&github_org_members.Organization{
+		OrganizationName: "acme",
+		DateRefreshed:    utils.TimeToString(testNow.Add(-time.Hour)),
+	}, nil)
+	repo.EXPECT().GetMembers(gomock.Any(), "acme").Return([]*github_org_members.Member{
+		{OrganizationName: "acme", GithubUsername: "bob", GithubLogin: "bob"},
+		{OrganizationName: "acme", GithubUsername: "alice", GithubLogin: "Alice"},
+	}, nil)
+	repo.EXPECT().GetOrganization(gomock.Any(), "public-org").Return(nil, nil)
+	s := newTestService(ctrl, repo, nil)
+
+	result, err := s.GetCoveredMembers(context.Background(), "cla-group-id", "company-sfid")
+	assert.Nil(t, err)
+	assert.Equal(t, "signature-id", result.SignatureID)
+	assert.Equal(t, "company-id", result.CompanyID)
+	assert.Len(t, result.Organizations, 2)
+	assert.Equal(t, "Acme", result.Organizations[0].OrganizationName)
+	assert.Equal(t, string(github_org_members.CacheStatusCurrent), result.Organizations[0].CacheStatus)
+	assert.Equal(t, []string{"Alice", "bob"}, result.Organizations[0].Members)
+	assert.Equal(t, int64(2), result.Organizations[0].MemberCount)
+	assert.Equal(t, string(github_org_members.CacheStatusNotCached), result.Organizations[1].CacheStatus)
+	assert.Empty(t, result.Organizations[1].Members)
+
+	_, err = s.GetCoveredMembers(context.Background(), "cla-group-id", "other-sfid")
+	assert.Equal(t, github_org_members.ErrCompanyNotFound, err)
+	_, err = s.GetCoveredMembers(context.Background(), "other-cla-group", "company-sfid")
+	assert.Equal(t, github_org_members.ErrSignatureNotFound, err)
+}
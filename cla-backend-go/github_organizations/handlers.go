@@ -60,10 +60,14 @@ func Configure(api *operations.ClaAPI, service Service, eventService events.Serv
 				})
 			}
 
-			_, err := github.GetOrganization(ctx, *params.Body.OrganizationName)
+			enterpriseBaseURL, err := ValidateGithubOrganizationHost(ctx, *params.Body.OrganizationName, params.Body.EnterpriseBaseURL, params.Body.EnterpriseAppID)
 			if err != nil {
+				if errors.Is(err, github.ErrInvalidEnterpriseURL) || errors.Is(err, github.ErrEnterpriseHostNotConfigured) || errors.Is(err, ErrMissingEnterpriseAppID) {
+					return github_organizations.NewAddProjectGithubOrganizationBadRequest().WithPayload(errorResponse(err))
+				}
 				return github_organizations.NewAddProjectGithubOrganizationNotFound().WithPayload(errorResponse(err))
 			}
+			params.Body.EnterpriseBaseURL = enterpriseBaseURL
 
 			result, err := service.AddGithubOrganization(ctx, params.ProjectSFID, params.Body)
 			if err != nil {
@@ -109,7 +113,12 @@ func Configure(api *operations.ClaAPI, service Service, eventService events.Serv
 			reqID := utils.GetRequestID(params.XREQUESTID)
 			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
 
-			_, err := github.GetOrganization(ctx, params.OrgName)
+			// the organization is looked up on the GitHub Enterprise Server it was added with
+			enterpriseBaseURL := ""
+			if org, orgErr := service.GetGithubOrganizationByName(ctx, params.OrgName); orgErr == nil && org != nil {
+				enterpriseBaseURL = org.EnterpriseBaseURL
+			}
+			_, err := github.GetOrganizationForHost(ctx, enterpriseBaseURL, params.OrgName)
 			if err != nil {
 				return github_organizations.NewDeleteProjectGithubOrganizationNotFound().WithPayload(errorResponse(err))
 			}
@@ -5,6 +5,7 @@ package github_organizations
 
 import (
 	"context"
+	"errors"
 	"sync"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
@@ -15,6 +16,52 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// errors
+var (
+	ErrMissingEnterpriseAppID = errors.New("the github app id is required for a github enterprise server organization")
+)
+
+// ValidateGithubOrganizationHost validates the GitHub Enterprise Server of a new GitHub organization and makes sure the
+// organization exists on github.com or on the enterprise host - returns the normalized enterprise base url
+func ValidateGithubOrganizationHost(ctx context.Context, organizationName, enterpriseBaseURL string, enterpriseAppID int64) (string, error) {
+	if enterpriseBaseURL == "" {
+		if enterpriseAppID != 0 {
+			return "", github.ErrInvalidEnterpriseURL
+		}
+		_, err := github.GetOrganization(ctx, organizationName)
+		return "", err
+	}
+
+	normalizedURL, err := github.NormalizeEnterpriseURL(enterpriseBaseURL)
+	if err != nil {
+		return "", err
+	}
+	if enterpriseAppID <= 0 {
+		return "", ErrMissingEnterpriseAppID
+	}
+	if _, err := github.GetOrganizationForHost(ctx, normalizedURL, organizationName); err != nil {
+		return "", err
+	}
+	return normalizedURL, nil
+}
+
+// EnterpriseHostsLoader returns the loader of the GitHub Enterprise Server hosts of the registered GitHub organizations
+func EnterpriseHostsLoader(repo Repository) github.EnterpriseHostsLoader {
+	return func() ([]string, error) {
+		githubOrgs, err := repo.GetAllGithubOrganizations(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		var hosts []string
+		for _, githubOrg := range githubOrgs.List {
+			if githubOrg.EnterpriseBaseURL != "" {
+				hosts = append(hosts, github.EnterpriseHost(githubOrg.EnterpriseBaseURL))
+			}
+		}
+		return hosts, nil
+	}
+}
+
 func buildGithubOrganizationListModels(ctx context.Context, githubOrganizations []*GithubOrganization) []*models.GithubOrganization {
 	f := logrus.Fields{
 		"functionName":   "buildGithubOrganizationListModels",
@@ -29,7 +76,7 @@ func buildGithubOrganizationListModels(ctx context.Context, githubOrganizations
 				defer wg.Done()
 				ghorg.GithubInfo = &models.GithubOrganizationGithubInfo{}
 				log.WithFields(f).Debugf("Loading GitHub organization details: %s...", ghorg.OrganizationName)
-				user, err := github.GetUserDetails(ghorg.OrganizationName)
+				user, err := github.GetUserDetailsForHost(ghorg.EnterpriseBaseURL, ghorg.OrganizationName)
 				if err != nil {
 					ghorg.GithubInfo.Error = err.Error()
 				} else {
@@ -45,7 +92,7 @@ func buildGithubOrganizationListModels(ctx context.Context, githubOrganizations
 				}
 				if ghorg.OrganizationInstallationID != 0 {
 					log.WithFields(f).Debugf("Loading GitHub repository list based on installation id: %d...", ghorg.OrganizationInstallationID)
-					list, err := github.GetInstallationRepositories(ghorg.OrganizationInstallationID)
+					list, err := github.GetRepositoriesForInstallation(GithubInstallation(ghorg))
 					if err != nil {
 						log.WithFields(f).Warnf("unable to get repositories for installation id : %d", ghorg.OrganizationInstallationID)
 						ghorg.Repositories.Error = err.Error()
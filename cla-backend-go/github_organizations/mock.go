@@ -84,18 +84,33 @@ func (mr *MockRepositoryMockRecorder) GetGithubOrganizationsByParent(ctx, parent
 }
 
 // GetGithubOrganization mocks base method
-func (m *MockRepository) GetGithubOrganization(ctx context.Context, githubOrganizationName string) (*models.GithubOrganization, error) {
+func (m *MockRepository) GetGithubOrganization(ctx context.Context, organizationKey string) (*models.GithubOrganization, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGithubOrganization", ctx, githubOrganizationName)
+	ret := m.ctrl.Call(m, "GetGithubOrganization", ctx, organizationKey)
 	ret0, _ := ret[0].(*models.GithubOrganization)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetGithubOrganization indicates an expected call of GetGithubOrganization
-func (mr *MockRepositoryMockRecorder) GetGithubOrganization(ctx, githubOrganizationName interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetGithubOrganization(ctx, organizationKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGithubOrganization", reflect.TypeOf((*MockRepository)(nil).GetGithubOrganization), ctx, githubOrganizationName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGithubOrganization", reflect.TypeOf((*MockRepository)(nil).GetGithubOrganization), ctx, organizationKey)
+}
+
+// GetGithubOrganizationForProject mocks base method
+func (m *MockRepository) GetGithubOrganizationForProject(ctx context.Context, projectSFID, githubOrganizationName string) (*models.GithubOrganization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGithubOrganizationForProject", ctx, projectSFID, githubOrganizationName)
+	ret0, _ := ret[0].(*models.GithubOrganization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGithubOrganizationForProject indicates an expected call of GetGithubOrganizationForProject
+func (mr *MockRepositoryMockRecorder) GetGithubOrganizationForProject(ctx, projectSFID, githubOrganizationName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGithubOrganizationForProject", reflect.TypeOf((*MockRepository)(nil).GetGithubOrganizationForProject), ctx, projectSFID, githubOrganizationName)
 }
 
 // GetGithubOrganizationByName mocks base method
@@ -113,6 +128,21 @@ func (mr *MockRepositoryMockRecorder) GetGithubOrganizationByName(ctx, githubOrg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGithubOrganizationByName", reflect.TypeOf((*MockRepository)(nil).GetGithubOrganizationByName), ctx, githubOrganizationName)
 }
 
+// GetAllGithubOrganizations mocks base method
+func (m *MockRepository) GetAllGithubOrganizations(ctx context.Context) (*models.GithubOrganizations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllGithubOrganizations", ctx)
+	ret0, _ := ret[0].(*models.GithubOrganizations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllGithubOrganizations indicates an expected call of GetAllGithubOrganizations
+func (mr *MockRepositoryMockRecorder) GetAllGithubOrganizations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllGithubOrganizations", reflect.TypeOf((*MockRepository)(nil).GetAllGithubOrganizations), ctx)
+}
+
 // UpdateGithubOrganization mocks base method
 func (m *MockRepository) UpdateGithubOrganization(ctx context.Context, projectSFID, organizationName string, autoEnabled, branchProtectionEnabled bool) error {
 	m.ctrl.T.Helper()
@@ -127,6 +157,20 @@ func (mr *MockRepositoryMockRecorder) UpdateGithubOrganization(ctx, projectSFID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGithubOrganization", reflect.TypeOf((*MockRepository)(nil).UpdateGithubOrganization), ctx, projectSFID, organizationName, autoEnabled, branchProtectionEnabled)
 }
 
+// UpdateGithubOrganizationInstallation mocks base method
+func (m *MockRepository) UpdateGithubOrganizationInstallation(ctx context.Context, organizationKey string, installationID int64, installationStatus string, disabledRepositoryIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGithubOrganizationInstallation", ctx, organizationKey, installationID, installationStatus, disabledRepositoryIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGithubOrganizationInstallation indicates an expected call of UpdateGithubOrganizationInstallation
+func (mr *MockRepositoryMockRecorder) UpdateGithubOrganizationInstallation(ctx, organizationKey, installationID, installationStatus, disabledRepositoryIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGithubOrganizationInstallation", reflect.TypeOf((*MockRepository)(nil).UpdateGithubOrganizationInstallation), ctx, organizationKey, installationID, installationStatus, disabledRepositoryIDs)
+}
+
 // DeleteGithubOrganization mocks base method
 func (m *MockRepository) DeleteGithubOrganization(ctx context.Context, projectSFID, githubOrgName string) error {
 	m.ctrl.T.Helper()
@@ -3,9 +3,16 @@
 
 package github_organizations
 
-import "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+import (
+	"strings"
 
-// GithubOrganization is data model for github organizations
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+	gogithub "github.com/google/go-github/v32/github"
+)
+
+// GithubOrganization is data model for github organizations - the organization name is the key of the record, see
+// OrganizationKey
 type GithubOrganization struct {
 	DateCreated                string `json:"date_created,omitempty"`
 	DateModified               string `json:"date_modified,omitempty"`
@@ -18,6 +25,33 @@ type GithubOrganization struct {
 	BranchProtectionEnabled    bool   `json:"branch_protection_enabled"`
 	AutoEnabledClaGroupID      string `json:"auto_enabled_cla_group_id,omitempty"`
 	Version                    string `json:"version,omitempty"`
+
+	InstallationStatus                string   `json:"installation_status,omitempty"`
+	InstallationStatusDate            string   `json:"installation_status_date,omitempty"`
+	InstallationDisabledRepositoryIDs []string `json:"installation_disabled_repository_ids,omitempty"`
+
+	EnterpriseBaseURL string `json:"enterprise_base_url,omitempty"`
+	EnterpriseAppID   int64  `json:"enterprise_app_id,omitempty"`
+}
+
+// GitHub App installation status values
+const (
+	InstallationStatusActive       = "active"
+	InstallationStatusSuspended    = "suspended"
+	InstallationStatusUninstalled  = "uninstalled"
+	InstallationStatusNotInstalled = "not_installed"
+)
+
+// installationStatus returns the GitHub App installation status of the organization, records created before the
+// status was tracked are derived from the installation id
+func installationStatus(in *GithubOrganization) string {
+	if in.InstallationStatus != "" {
+		return in.InstallationStatus
+	}
+	if in.OrganizationInstallationID != 0 {
+		return InstallationStatusActive
+	}
+	return InstallationStatusNotInstalled
 }
 
 // ToModel converts to models.GithubOrganization
@@ -26,13 +60,65 @@ func ToModel(in *GithubOrganization) *models.GithubOrganization {
 		DateCreated:                in.DateCreated,
 		DateModified:               in.DateModified,
 		OrganizationInstallationID: in.OrganizationInstallationID,
-		OrganizationName:           in.OrganizationName,
+		OrganizationName:           organizationLogin(in),
 		OrganizationSfid:           in.OrganizationSFID,
 		Version:                    in.Version,
 		AutoEnabled:                in.AutoEnabled,
 		AutoEnabledClaGroupID:      in.AutoEnabledClaGroupID,
 		BranchProtectionEnabled:    in.BranchProtectionEnabled,
 		ProjectSFID:                in.ProjectSFID,
+
+		InstallationStatus:                installationStatus(in),
+		InstallationStatusDate:            in.InstallationStatusDate,
+		InstallationDisabledRepositoryIDs: in.InstallationDisabledRepositoryIDs,
+
+		EnterpriseBaseURL: in.EnterpriseBaseURL,
+		EnterpriseAppID:   in.EnterpriseAppID,
+	}
+}
+
+// OrganizationKey returns the key of the GitHub organization record - the organization names are only unique on their
+// GitHub host, so the organizations of the GitHub Enterprise Server hosts are keyed by host and name
+func OrganizationKey(host, organizationName string) string {
+	host = strings.ToLower(host)
+	if host == "" || host == "github.com" {
+		return organizationName
+	}
+	return host + "/" + organizationName
+}
+
+// GithubOrganizationKey returns the key of the record of the GitHub organization
+func GithubOrganizationKey(org *models.GithubOrganization) string {
+	return OrganizationKey(github.EnterpriseHost(org.EnterpriseBaseURL), org.OrganizationName)
+}
+
+// RepositoryOrganizationKey returns the key of the GitHub organization record of the repository, the GitHub host is
+// taken from the repository url
+func RepositoryOrganizationKey(repo *models.GithubRepository) string {
+	return OrganizationKey(github.EnterpriseHost(repo.RepositoryURL), repo.RepositoryOrganizationName)
+}
+
+// AccountOrganizationKey returns the key of the GitHub organization record of the organization account sent in the
+// GitHub events, the GitHub host is taken from the account url
+func AccountOrganizationKey(account *gogithub.User) string {
+	return OrganizationKey(github.EnterpriseHost(account.GetHTMLURL()), account.GetLogin())
+}
+
+// organizationLogin returns the name of the organization on its GitHub host
+func organizationLogin(in *GithubOrganization) string {
+	if in.EnterpriseBaseURL == "" {
+		return in.OrganizationName
+	}
+	return strings.TrimPrefix(in.OrganizationName, github.EnterpriseHost(in.EnterpriseBaseURL)+"/")
+}
+
+// GithubInstallation returns the GitHub App installation of the organization on github.com or on its GitHub Enterprise
+// Server host
+func GithubInstallation(org *models.GithubOrganization) github.Installation {
+	return github.Installation{
+		EnterpriseBaseURL: org.EnterpriseBaseURL,
+		EnterpriseAppID:   org.EnterpriseAppID,
+		InstallationID:    org.OrganizationInstallationID,
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -20,6 +21,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 )
 
@@ -40,9 +42,12 @@ type Repository interface {
 	AddGithubOrganization(ctx context.Context, parentProjectSFID string, projectSFID string, input *models.CreateGithubOrganization) (*models.GithubOrganization, error)
 	GetGithubOrganizations(ctx context.Context, projectSFID string) (*models.GithubOrganizations, error)
 	GetGithubOrganizationsByParent(ctx context.Context, parentProjectSFID string) (*models.GithubOrganizations, error)
-	GetGithubOrganization(ctx context.Context, githubOrganizationName string) (*models.GithubOrganization, error)
+	GetGithubOrganization(ctx context.Context, organizationKey string) (*models.GithubOrganization, error)
+	GetGithubOrganizationForProject(ctx context.Context, projectSFID string, githubOrganizationName string) (*models.GithubOrganization, error)
 	GetGithubOrganizationByName(ctx context.Context, githubOrganizationName string) (*models.GithubOrganizations, error)
+	GetAllGithubOrganizations(ctx context.Context) (*models.GithubOrganizations, error)
 	UpdateGithubOrganization(ctx context.Context, projectSFID string, organizationName string, autoEnabled bool, branchProtectionEnabled bool) error
+	UpdateGithubOrganizationInstallation(ctx context.Context, organizationKey string, installationID int64, installationStatus string, disabledRepositoryIDs []string) error
 	DeleteGithubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error
 	DeleteGithubOrganizationByParent(ctx context.Context, parentProjectSFID string, githubOrgName string) error
 }
@@ -77,13 +82,15 @@ func (repo repository) AddGithubOrganization(ctx context.Context, parentProjectS
 		DateCreated:                currentTime,
 		DateModified:               currentTime,
 		OrganizationInstallationID: 0,
-		OrganizationName:           *input.OrganizationName,
+		OrganizationName:           OrganizationKey(github.EnterpriseHost(input.EnterpriseBaseURL), *input.OrganizationName),
 		OrganizationNameLower:      strings.ToLower(*input.OrganizationName),
 		OrganizationSFID:           parentProjectSFID,
 		ProjectSFID:                projectSFID,
 		AutoEnabled:                aws.BoolValue(input.AutoEnabled),
 		AutoEnabledClaGroupID:      input.AutoEnabledClaGroupID,
 		BranchProtectionEnabled:    aws.BoolValue(input.BranchProtectionEnabled),
+		EnterpriseBaseURL:          input.EnterpriseBaseURL,
+		EnterpriseAppID:            input.EnterpriseAppID,
 		Version:                    "v1",
 	}
 
@@ -265,18 +272,55 @@ func (repo repository) GetGithubOrganizationByName(ctx context.Context, githubOr
 	return &models.GithubOrganizations{List: ghOrgList}, nil
 }
 
-func (repo repository) GetGithubOrganization(ctx context.Context, githubOrganizationName string) (*models.GithubOrganization, error) {
+// GetAllGithubOrganizations returns all the GitHub organizations registered in EasyCLA, the GitHub details are not
+// loaded for the returned organizations
+func (repo repository) GetAllGithubOrganizations(ctx context.Context) (*models.GithubOrganizations, error) {
 	f := logrus.Fields{
-		"functionName":           "GetGithubOrganization",
-		utils.XREQUESTID:         ctx.Value(utils.XREQUESTID),
-		"githubOrganizationName": githubOrganizationName,
+		"functionName":   "GetAllGithubOrganizations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.githubOrgTableName,
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		TableName: aws.String(repo.githubOrgTableName),
+	}
+	var resultList []map[string]*dynamodb.AttributeValue
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput) //nolint
+		if err != nil {
+			log.WithFields(f).Warnf("error retrieving github organizations, error: %+v", err)
+			return nil, err
+		}
+		resultList = append(resultList, results.Items...)
+		if len(results.LastEvaluatedKey) != 0 {
+			scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+
+	var resultOutput []*GithubOrganization
+	err := dynamodbattribute.UnmarshalListOfMaps(resultList, &resultOutput)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding database results, error: %+v", err)
+		return nil, err
+	}
+	return &models.GithubOrganizations{List: toModels(resultOutput)}, nil
+}
+
+// GetGithubOrganization returns the GitHub organization with the given record key, see OrganizationKey
+func (repo repository) GetGithubOrganization(ctx context.Context, organizationKey string) (*models.GithubOrganization, error) {
+	f := logrus.Fields{
+		"functionName":    "GetGithubOrganization",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"organizationKey": organizationKey,
 	}
 
 	log.WithFields(f).Debug("Querying for github organization by name...")
 	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
 		Key: map[string]*dynamodb.AttributeValue{
 			"organization_name": {
-				S: aws.String(githubOrganizationName),
+				S: aws.String(organizationKey),
 			},
 		},
 		TableName: aws.String(repo.githubOrgTableName),
@@ -298,6 +342,47 @@ func (repo repository) GetGithubOrganization(ctx context.Context, githubOrganiza
 	return ToModel(&org), nil
 }
 
+// GetGithubOrganizationForProject returns the GitHub organization of the project with the given name, on github.com
+// or on a GitHub Enterprise Server host - the GitHub details are not loaded for the returned organization
+func (repo repository) GetGithubOrganizationForProject(ctx context.Context, projectSFID string, githubOrganizationName string) (*models.GithubOrganization, error) {
+	f := logrus.Fields{
+		"functionName":           "GetGithubOrganizationForProject",
+		utils.XREQUESTID:         ctx.Value(utils.XREQUESTID),
+		"projectSFID":            projectSFID,
+		"githubOrganizationName": githubOrganizationName,
+	}
+
+	condition := expression.Key("organization_name_lower").Equal(expression.Value(strings.ToLower(githubOrganizationName)))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+	results, err := repo.dynamoDBClient.Query(&dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.githubOrgTableName),
+		IndexName:                 aws.String(GithubOrgLowerNameIndex),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("error retrieving github organizations by name, error: %+v", err)
+		return nil, err
+	}
+
+	var resultOutput []*GithubOrganization
+	err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &resultOutput)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding database results, error: %+v", err)
+		return nil, err
+	}
+	for _, githubOrg := range resultOutput {
+		if githubOrg.ProjectSFID == projectSFID || githubOrg.OrganizationSFID == projectSFID {
+			return ToModel(githubOrg), nil
+		}
+	}
+	return nil, ErrOrganizationDoesNotExist
+}
+
 // UpdateGithubOrganization updates the specified GitHub organization based on the update model provided
 func (repo repository) UpdateGithubOrganization(ctx context.Context, projectSFID string, organizationName string, autoEnabled bool, branchProtectionEnabled bool) error {
 	f := logrus.Fields{
@@ -311,7 +396,7 @@ func (repo repository) UpdateGithubOrganization(ctx context.Context, projectSFID
 	}
 
 	_, currentTime := utils.CurrentTime()
-	githubOrg, lookupErr := repo.GetGithubOrganization(ctx, organizationName)
+	githubOrg, lookupErr := repo.GetGithubOrganizationForProject(ctx, projectSFID, organizationName)
 	if lookupErr != nil {
 		log.WithFields(f).Warnf("error looking up github organization by name, error: %+v", lookupErr)
 		return lookupErr
@@ -325,7 +410,7 @@ func (repo repository) UpdateGithubOrganization(ctx context.Context, projectSFID
 	input := &dynamodb.UpdateItemInput{
 		Key: map[string]*dynamodb.AttributeValue{
 			"organization_name": {
-				S: aws.String(githubOrg.OrganizationName),
+				S: aws.String(GithubOrganizationKey(githubOrg)),
 			},
 		},
 		ExpressionAttributeNames: map[string]*string{
@@ -358,6 +443,72 @@ func (repo repository) UpdateGithubOrganization(ctx context.Context, projectSFID
 	return nil
 }
 
+// UpdateGithubOrganizationInstallation updates the GitHub App installation details of the GitHub organization with the
+// given record key, the disabled repository list holds the repositories disabled because of the installation status
+func (repo repository) UpdateGithubOrganizationInstallation(ctx context.Context, organizationKey string, installationID int64, installationStatus string, disabledRepositoryIDs []string) error {
+	f := logrus.Fields{
+		"functionName":          "UpdateGithubOrganizationInstallation",
+		utils.XREQUESTID:        ctx.Value(utils.XREQUESTID),
+		"organizationKey":       organizationKey,
+		"installationID":        installationID,
+		"installationStatus":    installationStatus,
+		"disabledRepositoryIDs": strings.Join(disabledRepositoryIDs, ","),
+		"tableName":             repo.githubOrgTableName,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_name": {
+				S: aws.String(organizationKey),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#I": aws.String("organization_installation_id"),
+			"#S": aws.String("installation_status"),
+			"#D": aws.String("installation_status_date"),
+			"#R": aws.String("installation_disabled_repository_ids"),
+			"#M": aws.String("date_modified"),
+			"#N": aws.String("organization_name"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":i": {
+				N: aws.String(strconv.FormatInt(installationID, 10)),
+			},
+			":s": {
+				S: aws.String(installationStatus),
+			},
+			":d": {
+				S: aws.String(currentTime),
+			},
+			":m": {
+				S: aws.String(currentTime),
+			},
+		},
+		UpdateExpression:    aws.String("SET #I = :i, #S = :s, #D = :d, #M = :m REMOVE #R"),
+		ConditionExpression: aws.String("attribute_exists(#N)"),
+		TableName:           aws.String(repo.githubOrgTableName),
+	}
+	if len(disabledRepositoryIDs) > 0 {
+		input.ExpressionAttributeValues[":r"] = &dynamodb.AttributeValue{
+			SS: aws.StringSlice(disabledRepositoryIDs),
+		}
+		input.UpdateExpression = aws.String("SET #I = :i, #S = :s, #D = :d, #M = :m, #R = :r")
+	}
+
+	log.WithFields(f).Debug("updating github organization installation...")
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		if aerr, ok := updateErr.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrOrganizationDoesNotExist
+		}
+		log.WithFields(f).Warnf("unable to update github organization installation, error: %+v", updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
 func (repo repository) DeleteGithubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error {
 	f := logrus.Fields{
 		"functionName":   "DeleteGithubOrganization",
@@ -376,7 +527,7 @@ func (repo repository) DeleteGithubOrganization(ctx context.Context, projectSFID
 
 	for _, githubOrg := range orgs.List {
 		if strings.EqualFold(githubOrg.OrganizationName, githubOrgName) {
-			githubOrganizationName = githubOrg.OrganizationName
+			githubOrganizationName = GithubOrganizationKey(githubOrg)
 		}
 	}
 
@@ -416,7 +567,7 @@ func (repo repository) DeleteGithubOrganizationByParent(ctx context.Context, par
 
 	for _, githubOrg := range orgs.List {
 		if strings.EqualFold(githubOrg.OrganizationName, githubOrgName) {
-			githubOrganizationName = githubOrg.OrganizationName
+			githubOrganizationName = GithubOrganizationKey(githubOrg)
 		}
 	}
 
@@ -5,6 +5,7 @@ package init
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/communitybridge/easycla/cla-backend-go/config"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
@@ -19,7 +20,6 @@ const (
 var (
 	stage      string
 	configFile = ""
-	configVars config.Config
 )
 
 // CommonInit initializes the common properties
@@ -49,15 +49,29 @@ func Init() {
 	AWSInit()
 }
 
+// SetConfigFile sets the local configuration file - when empty, the configuration is loaded from SSM
+func SetConfigFile(configFilePath string) {
+	configFile = configFilePath
+}
+
 // ConfigVariable loads all the SSM values based on stage.
 func ConfigVariable() {
-	var err error
-	configVars, err = config.LoadConfig(configFile, awsSession, stage)
+	_, err := config.LoadConfig(configFile, awsSession, stage)
 	if err != nil {
 		log.Panicf("Unable to load config - Error: %v", err)
 	}
 }
 
+// ResolveConfigVariable loads and validates the configuration without storing it - used to check the configuration
+func ResolveConfigVariable() (config.Config, error) {
+	return config.ResolveConfig(configFile, awsSession, stage)
+}
+
+// StartConfigReloader periodically refreshes the reloadable configuration values
+func StartConfigReloader(interval time.Duration) (stop func()) {
+	return config.StartReloader(configFile, awsSession, stage, interval)
+}
+
 // GetStage returns the deployment stage, e.g. dev, test, stage or prod
 func GetStage() string {
 	return stage
@@ -65,5 +79,5 @@ func GetStage() string {
 
 // GetConfig returns the configration SSM based on stage, e.g. dev, test, stage or prod
 func GetConfig() config.Config {
-	return configVars
+	return config.GetConfig()
 }
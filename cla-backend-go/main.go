@@ -5,8 +5,6 @@ package main
 
 import (
 	"github.com/communitybridge/easycla/cla-backend-go/cmd"
-	ini "github.com/communitybridge/easycla/cla-backend-go/init"
-	token "github.com/communitybridge/easycla/cla-backend-go/token"
 )
 
 var (
@@ -23,12 +21,6 @@ var (
 	buildDate string
 )
 
-func init() {
-	ini.ConfigVariable()
-	configFile := ini.GetConfig()
-	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
-}
-
 func main() {
 	cmd.Version = version
 	cmd.Commit = commit
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	repositories "github.com/communitybridge/easycla/cla-backend-go/repositories"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -68,6 +69,20 @@ func (mr *MockRepositoryMockRecorder) UpdateClaGroupID(ctx, repositoryID, claGro
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClaGroupID", reflect.TypeOf((*MockRepository)(nil).UpdateClaGroupID), ctx, repositoryID, claGroupID)
 }
 
+// UpdateGithubRepository mocks base method
+func (m *MockRepository) UpdateGithubRepository(ctx context.Context, repositoryID string, update *repositories.RepositoryUpdate, note string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGithubRepository", ctx, repositoryID, update, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGithubRepository indicates an expected call of UpdateGithubRepository
+func (mr *MockRepositoryMockRecorder) UpdateGithubRepository(ctx, repositoryID, update, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGithubRepository", reflect.TypeOf((*MockRepository)(nil).UpdateGithubRepository), ctx, repositoryID, update, note)
+}
+
 // EnableRepository mocks base method
 func (m *MockRepository) EnableRepository(ctx context.Context, repositoryID string) error {
 	m.ctrl.T.Helper()
@@ -139,6 +154,21 @@ func (mr *MockRepositoryMockRecorder) GetRepository(ctx, repositoryID interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepository", reflect.TypeOf((*MockRepository)(nil).GetRepository), ctx, repositoryID)
 }
 
+// GetRepositoryByName mocks base method
+func (m *MockRepository) GetRepositoryByName(ctx context.Context, repositoryName string) (*models.GithubRepository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepositoryByName", ctx, repositoryName)
+	ret0, _ := ret[0].(*models.GithubRepository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepositoryByName indicates an expected call of GetRepositoryByName
+func (mr *MockRepositoryMockRecorder) GetRepositoryByName(ctx, repositoryName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryByName", reflect.TypeOf((*MockRepository)(nil).GetRepositoryByName), ctx, repositoryName)
+}
+
 // GetRepositoryByGithubID mocks base method
 func (m *MockRepository) GetRepositoryByGithubID(ctx context.Context, externalID string, enabled bool) (*models.GithubRepository, error) {
 	m.ctrl.T.Helper()
@@ -19,6 +19,8 @@ type RepositoryDBModel struct {
 	RepositoryURL              string `dynamodbav:"repository_url" json:"repository_url,omitempty"`
 	ProjectSFID                string `dynamodbav:"project_sfid" json:"project_sfid,omitempty"`
 	Enabled                    bool   `dynamodbav:"enabled" json:"enabled"`
+	RepositoryArchived         bool   `dynamodbav:"repository_archived" json:"repository_archived"`
+	RepositoryPrivate          bool   `dynamodbav:"repository_private" json:"repository_private"`
 	Note                       string `dynamodbav:"note" json:"note,omitempty"`
 	Version                    string `dynamodbav:"version" json:"version,omitempty"`
 }
@@ -46,7 +48,21 @@ func (gr *RepositoryDBModel) toModel() *models.GithubRepository {
 		RepositoryURL:              gr.RepositoryURL,
 		ProjectSFID:                gr.ProjectSFID,
 		Enabled:                    gr.Enabled,
+		RepositoryArchived:         gr.RepositoryArchived,
+		RepositoryPrivate:          gr.RepositoryPrivate,
 		Note:                       gr.Note,
 		Version:                    gr.Version,
 	}
 }
+
+// RepositoryUpdate holds the repository attributes to update - nil fields are left unchanged
+type RepositoryUpdate struct {
+	RepositoryName             *string
+	RepositoryURL              *string
+	RepositoryOrganizationName *string
+	RepositoryProjectID        *string
+	ProjectSFID                *string
+	Enabled                    *bool
+	RepositoryArchived         *bool
+	RepositoryPrivate          *bool
+}
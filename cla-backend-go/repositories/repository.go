@@ -45,6 +45,7 @@ var (
 type Repository interface {
 	AddGithubRepository(ctx context.Context, externalProjectID string, projectSFID string, input *models.GithubRepositoryInput) (*models.GithubRepository, error)
 	UpdateClaGroupID(ctx context.Context, repositoryID, claGroupID string) error
+	UpdateGithubRepository(ctx context.Context, repositoryID string, update *RepositoryUpdate, note string) error
 	EnableRepository(ctx context.Context, repositoryID string) error
 	DisableRepository(ctx context.Context, repositoryID string) error
 	DisableRepositoriesByProjectID(ctx context.Context, projectID string) error
@@ -143,6 +144,76 @@ func (r *repo) UpdateClaGroupID(ctx context.Context, repositoryID, claGroupID st
 	return r.setClaGroupIDGithubRepository(ctx, repositoryID, claGroupID)
 }
 
+// UpdateGithubRepository updates the repository attributes set on the update model, the note is appended to the
+// existing repository note
+func (r *repo) UpdateGithubRepository(ctx context.Context, repositoryID string, update *RepositoryUpdate, note string) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateGithubRepository",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"repositoryID":   repositoryID,
+	}
+
+	existingModel, getErr := r.GetRepository(ctx, repositoryID)
+	if getErr != nil {
+		log.WithFields(f).WithError(getErr).Warn("unable to load repository by repository id")
+		return getErr
+	}
+	if existingModel == nil {
+		return fmt.Errorf("unable to locate existing repository entry by ID: %s", repositoryID)
+	}
+
+	_, now := utils.CurrentTime()
+	updateExpression := expression.Set(expression.Name("date_modified"), expression.Value(now))
+	setString := func(name string, value *string) {
+		if value != nil {
+			updateExpression = updateExpression.Set(expression.Name(name), expression.Value(*value))
+		}
+	}
+	setBool := func(name string, value *bool) {
+		if value != nil {
+			updateExpression = updateExpression.Set(expression.Name(name), expression.Value(*value))
+		}
+	}
+	setString("repository_name", update.RepositoryName)
+	setString("repository_url", update.RepositoryURL)
+	setString("repository_organization_name", update.RepositoryOrganizationName)
+	setString("repository_project_id", update.RepositoryProjectID)
+	setString("project_sfid", update.ProjectSFID)
+	setBool("enabled", update.Enabled)
+	setBool("repository_archived", update.RepositoryArchived)
+	setBool("repository_private", update.RepositoryPrivate)
+	if note != "" {
+		// Add to the existing note, if set
+		if existingModel.Note != "" {
+			note = existingModel.Note + ". " + note
+		}
+		updateExpression = updateExpression.Set(expression.Name("note"), expression.Value(fmt.Sprintf("%s on %s", note, now)))
+	}
+
+	expr, err := expression.NewBuilder().WithUpdate(updateExpression).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to build the update expression")
+		return err
+	}
+
+	log.WithFields(f).Debug("updating repository record")
+	_, err = r.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"repository_id": {S: aws.String(repositoryID)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		TableName:                 aws.String(r.repositoryTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error updating github repository")
+		return err
+	}
+
+	return nil
+}
+
 // EnableRepository enables the repository entry
 func (r *repo) EnableRepository(ctx context.Context, repositoryID string) error {
 	return r.enableGithubRepository(ctx, repositoryID)
@@ -100,7 +100,11 @@ func (s *service) AddGithubRepository(ctx context.Context, externalProjectID str
 		log.WithFields(f).WithError(err).Warnf("problem converting repository external ID - should be an integer value: %s", utils.StringValue(input.RepositoryExternalID))
 		return nil, err
 	}
-	ghRepo, err := github.GetRepositoryByExternalID(ctx, org.List[0].OrganizationInstallationID, repoGithubID)
+	ghRepo, err := github.GetRepositoryByExternalIDForInstallation(ctx, github.Installation{
+		EnterpriseBaseURL: org.List[0].EnterpriseBaseURL,
+		EnterpriseAppID:   org.List[0].EnterpriseAppID,
+		InstallationID:    org.List[0].OrganizationInstallationID,
+	}, repoGithubID)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem loading repository by organization installation ID: %d and repo github id: %d", org.List[0].OrganizationInstallationID, repoGithubID)
 		return nil, err
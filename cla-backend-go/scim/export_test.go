@@ -0,0 +1,23 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+// exposes the internals used by the tests
+const (
+	TokenValuePrefix = tokenValuePrefix
+	TokenRevoker     = tokenRevoker
+	ContentType      = contentType
+)
+
+// HashToken exposes hashToken to the tests
+var HashToken = hashToken
+
+// ValidateResource exposes validateResource to the tests
+var ValidateResource = validateResource
+
+// SCIMResource exposes the SCIM resource representation to the tests
+type SCIMResource = scimResource
+
+// SCIMListResponse exposes the SCIM list response to the tests
+type SCIMListResponse = scimListResponse
@@ -0,0 +1,502 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// BasePath is the path of the SCIM endpoints below the v2 API base path
+const BasePath = "/scim/v2"
+
+// SCIM schemas
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+const (
+	contentType = "application/scim+json"
+	// maxBodySize limits the size of the request body
+	maxBodySize = 1 << 20
+	// maxResults is the maximum number of resources returned by a list request
+	maxResults = 1000
+)
+
+// filterRegex matches the attribute eq "value" filters sent by the identity providers to look up a resource
+var filterRegex = regexp.MustCompile(`^\s*(\w+)\s+eq\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// memberFilterRegex matches the members[value eq "id"] path used to remove a group member
+var memberFilterRegex = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+"([^"]*)"\s*\]$`)
+
+type scimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+type scimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type scimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// scimResource is the SCIM representation of a user or group
+type scimResource struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	UserName    string       `json:"userName,omitempty"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Active      *bool        `json:"active,omitempty"`
+	Emails      []scimEmail  `json:"emails,omitempty"`
+	Members     []scimMember `json:"members,omitempty"`
+	Meta        *scimMeta    `json:"meta,omitempty"`
+}
+
+type scimListResponse struct {
+	Schemas      []string        `json:"schemas"`
+	TotalResults int             `json:"totalResults"`
+	StartIndex   int             `json:"startIndex"`
+	ItemsPerPage int             `json:"itemsPerPage"`
+	Resources    []*scimResource `json:"Resources"`
+}
+
+type scimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+type scimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []scimPatchOperation `json:"Operations"`
+}
+
+type scimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+type handler struct {
+	service Service
+}
+
+// NewHandler returns the handler of the SCIM protocol requests - the paths are relative to the SCIM base path and the
+// requests are authenticated with a SCIM token sent as a bearer token
+func NewHandler(service Service) http.Handler {
+	return &handler{service: service}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(context.Background(), utils.XREQUESTID, r.Header.Get("x-request-id")) // nolint
+	f := logrus.Fields{
+		"functionName":   "scim.ServeHTTP",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"method":         r.Method,
+		"path":           r.URL.Path,
+	}
+
+	tokenValue := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	token, err := h.service.Authenticate(ctx, tokenValue)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("scim token authentication failed")
+		if err == ErrInvalidToken {
+			writeError(w, http.StatusUnauthorized, "", "invalid SCIM token")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "", "unable to authenticate the SCIM token")
+		return
+	}
+	f["tokenID"] = token.TokenID
+	f["signatureID"] = token.SignatureID
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) == 1 && parts[0] == "ServiceProviderConfig" && r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, serviceProviderConfig())
+		return
+	}
+
+	var resourceType string
+	switch parts[0] {
+	case "Users":
+		resourceType = ResourceTypeUser
+	case "Groups":
+		resourceType = ResourceTypeGroup
+	}
+	if resourceType == "" || len(parts) > 2 {
+		writeError(w, http.StatusNotFound, "", fmt.Sprintf("unknown SCIM endpoint %s", r.URL.Path))
+		return
+	}
+
+	var resource *Resource
+	status := http.StatusOK
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		h.list(ctx, w, r, token, resourceType)
+		return
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		resource, err = readResource(r, resourceType)
+		if err == nil {
+			resource, err = h.service.CreateResource(ctx, token, resource)
+			status = http.StatusCreated
+		}
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		resource, err = h.service.GetResource(ctx, token, resourceType, parts[1])
+	case len(parts) == 2 && r.Method == http.MethodPut:
+		resource, err = readResource(r, resourceType)
+		if err == nil {
+			resource.ResourceID = parts[1]
+			resource, err = h.service.ReplaceResource(ctx, token, resource)
+		}
+	case len(parts) == 2 && r.Method == http.MethodPatch:
+		resource, err = h.service.GetResource(ctx, token, resourceType, parts[1])
+		if err == nil {
+			err = readPatch(r, resource)
+		}
+		if err == nil {
+			resource, err = h.service.ReplaceResource(ctx, token, resource)
+		}
+	case len(parts) == 2 && r.Method == http.MethodDelete:
+		err = h.service.DeleteResource(ctx, token, resourceType, parts[1])
+		if err == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("method %s is not supported for %s", r.Method, r.URL.Path))
+		return
+	}
+
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("scim request failed")
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, status, toSCIM(resource))
+}
+
+// list returns the users or groups matching the optional filter
+func (h *handler) list(ctx context.Context, w http.ResponseWriter, r *http.Request, token *Token, resourceType string) {
+	var attribute, value string
+	if filter := r.URL.Query().Get("filter"); filter != "" {
+		match := filterRegex.FindStringSubmatch(filter)
+		if match == nil {
+			writeError(w, http.StatusBadRequest, "invalidFilter", fmt.Sprintf("unsupported filter %s", filter))
+			return
+		}
+		attribute, value = strings.ToLower(match[1]), strings.ReplaceAll(match[2], `\"`, `"`)
+	}
+
+	startIndex, count := 1, maxResults
+	if v, err := strconv.Atoi(r.URL.Query().Get("startIndex")); err == nil && v > 1 {
+		startIndex = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("count")); err == nil && v >= 0 && v < maxResults {
+		count = v
+	}
+
+	resources, err := h.service.GetResources(ctx, token, resourceType)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	response := &scimListResponse{
+		Schemas:    []string{SchemaListResponse},
+		StartIndex: startIndex,
+		Resources:  []*scimResource{},
+	}
+	for _, resource := range resources {
+		if attribute != "" && !matchesFilter(resource, attribute, value) {
+			continue
+		}
+		response.TotalResults++
+		if response.TotalResults >= startIndex && len(response.Resources) < count {
+			response.Resources = append(response.Resources, toSCIM(resource))
+		}
+	}
+	response.ItemsPerPage = len(response.Resources)
+	writeJSON(w, http.StatusOK, response)
+}
+
+// matchesFilter returns true if the attribute of the resource matches the value - string attributes are compared case
+// insensitive as the identity providers do not agree on the case of the user names
+func matchesFilter(resource *Resource, attribute, value string) bool {
+	switch attribute {
+	case "username":
+		return strings.EqualFold(resource.UserName, value)
+	case "displayname":
+		return strings.EqualFold(resource.DisplayName, value)
+	case "externalid":
+		return resource.ExternalID == value
+	case "id":
+		return resource.ResourceID == value
+	}
+	return false
+}
+
+// readResource reads the user or group from the request body
+func readResource(r *http.Request, resourceType string) (*Resource, error) {
+	var input scimResource
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodySize)).Decode(&input); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResource, err)
+	}
+	resource := &Resource{
+		ResourceType: resourceType,
+		ExternalID:   input.ExternalID,
+		UserName:     input.UserName,
+		DisplayName:  input.DisplayName,
+		Active:       input.Active == nil || *input.Active,
+	}
+	if resourceType == ResourceTypeUser {
+		resource.Email = primaryEmail(input.Emails, input.UserName)
+	}
+	for _, member := range input.Members {
+		resource.Members = append(resource.Members, member.Value)
+	}
+	return resource, nil
+}
+
+// primaryEmail returns the primary email of the user - the user name is used when it is an email and the user has no
+// email
+func primaryEmail(emails []scimEmail, userName string) string {
+	for _, email := range emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(emails) > 0 {
+		return emails[0].Value
+	}
+	if utils.ValidEmail(userName) {
+		return userName
+	}
+	return ""
+}
+
+// readPatch applies the PatchOp operations of the request body to the resource
+func readPatch(r *http.Request, resource *Resource) error {
+	var patch scimPatchRequest
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodySize)).Decode(&patch); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidResource, err)
+	}
+	for _, operation := range patch.Operations {
+		if err := applyPatch(resource, operation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyPatch applies a single patch operation - the operations without a path carry the attributes in the value
+func applyPatch(resource *Resource, operation scimPatchOperation) error {
+	op := strings.ToLower(operation.Op)
+	if op != "add" && op != "replace" && op != "remove" {
+		return fmt.Errorf("%w: unsupported patch operation %s", ErrInvalidResource, operation.Op)
+	}
+
+	if operation.Path == "" {
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(operation.Value, &values); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidResource, err)
+		}
+		for path, value := range values {
+			if err := applyPatch(resource, scimPatchOperation{Op: op, Path: path, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if match := memberFilterRegex.FindStringSubmatch(operation.Path); match != nil && op == "remove" {
+		resource.Members = utils.RemoveItemsFromList(resource.Members, []string{match[1]})
+		return nil
+	}
+
+	path := strings.ToLower(operation.Path)
+	switch {
+	case path == "active":
+		if op == "remove" {
+			resource.Active = false
+			return nil
+		}
+		return unmarshalValue(operation.Value, &resource.Active)
+	case path == "username":
+		return unmarshalValue(operation.Value, &resource.UserName)
+	case path == "displayname":
+		return unmarshalValue(operation.Value, &resource.DisplayName)
+	case path == "externalid":
+		return unmarshalValue(operation.Value, &resource.ExternalID)
+	case path == "emails" || strings.HasPrefix(path, "emails["):
+		if op == "remove" {
+			resource.Email = ""
+			return nil
+		}
+		var emails []scimEmail
+		if err := json.Unmarshal(operation.Value, &emails); err != nil {
+			// emails[type eq "work"].value is set with a plain string
+			var email string
+			if err := unmarshalValue(operation.Value, &email); err != nil {
+				return err
+			}
+			emails = []scimEmail{{Value: email}}
+		}
+		resource.Email = primaryEmail(emails, "")
+	case path == "members":
+		var members []scimMember
+		if len(operation.Value) > 0 {
+			if err := unmarshalValue(operation.Value, &members); err != nil {
+				return err
+			}
+		}
+		var ids []string
+		for _, member := range members {
+			ids = append(ids, member.Value)
+		}
+		switch {
+		case op == "add":
+			resource.Members = utils.RemoveDuplicates(append(resource.Members, ids...))
+		case op == "replace":
+			resource.Members = ids
+		case len(ids) == 0:
+			resource.Members = nil
+		default:
+			resource.Members = utils.RemoveItemsFromList(resource.Members, ids)
+		}
+	default:
+		// the other attributes are not stored
+		return nil
+	}
+	return nil
+}
+
+func unmarshalValue(value json.RawMessage, out interface{}) error {
+	if err := json.Unmarshal(value, out); err != nil {
+		// some identity providers send the booleans as strings
+		var s string
+		if json.Unmarshal(value, &s) != nil || json.Unmarshal([]byte(strings.ToLower(s)), out) != nil {
+			return fmt.Errorf("%w: invalid patch value %s", ErrInvalidResource, string(value))
+		}
+	}
+	return nil
+}
+
+// toSCIM returns the SCIM representation of the resource
+func toSCIM(resource *Resource) *scimResource {
+	result := &scimResource{
+		ID:          resource.ResourceID,
+		ExternalID:  resource.ExternalID,
+		DisplayName: resource.DisplayName,
+		Meta: &scimMeta{
+			ResourceType: resource.ResourceType,
+			Created:      resource.DateCreated,
+			LastModified: resource.DateModified,
+		},
+	}
+	if resource.ResourceType == ResourceTypeGroup {
+		result.Schemas = []string{SchemaGroup}
+		result.Members = []scimMember{}
+		for _, member := range resource.Members {
+			result.Members = append(result.Members, scimMember{Value: member})
+		}
+		return result
+	}
+
+	active := resource.Active
+	result.Schemas = []string{SchemaUser}
+	result.UserName = resource.UserName
+	result.Active = &active
+	if resource.Email != "" {
+		result.Emails = []scimEmail{{Value: resource.Email, Type: "work", Primary: true}}
+	}
+	return result
+}
+
+func serviceProviderConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"schemas":        []string{SchemaServiceProviderConfig},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": maxResults},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]interface{}{
+			{
+				"type":        "oauthbearertoken",
+				"name":        "OAuth Bearer Token",
+				"description": "Authentication with a SCIM token created by a CLA manager",
+				"primary":     true,
+			},
+		},
+	}
+}
+
+// writeServiceError writes the SCIM error response for the service error
+func writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case err == ErrResourceNotFound:
+		writeError(w, http.StatusNotFound, "", err.Error())
+	case errors.Is(err, ErrUniqueness):
+		writeError(w, http.StatusConflict, "uniqueness", err.Error())
+	case errors.Is(err, ErrInvalidResource):
+		writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+	case err == ErrCompanyNotFound || err == ErrCLAGroupNotFound || err == ErrSignatureNotFound:
+		writeError(w, http.StatusForbidden, "", err.Error())
+	case err == ErrInvalidToken:
+		writeError(w, http.StatusUnauthorized, "", "invalid SCIM token")
+	default:
+		// the approval list update of the signatures service
+		switch err.(type) {
+		case *signatures.ForbiddenError:
+			writeError(w, http.StatusForbidden, "", err.Error())
+		case *signatures.BadRequestError:
+			writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "", "unable to process the SCIM request")
+		}
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, scimType, detail string) {
+	writeJSON(w, status, &scimError{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		log.Warnf("unable to write scim response, error: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// resource types
+const (
+	ResourceTypeUser  = "User"
+	ResourceTypeGroup = "Group"
+)
+
+// Token is the database model for the SCIM tokens table - a token is scoped to the CCLA signature of one company
+// for a CLA group and only the hash of the token value is stored
+type Token struct {
+	TokenID      string `dynamodbav:"token_id" json:"token_id"`
+	TokenHash    string `dynamodbav:"token_hash" json:"token_hash"`
+	TokenPrefix  string `dynamodbav:"token_prefix" json:"token_prefix"`
+	SignatureID  string `dynamodbav:"signature_id" json:"signature_id"`
+	ClaGroupID   string `dynamodbav:"cla_group_id" json:"cla_group_id"`
+	CompanyID    string `dynamodbav:"company_id" json:"company_id"`
+	CompanySFID  string `dynamodbav:"company_sfid" json:"company_sfid"`
+	LastUsed     string `dynamodbav:"last_used" json:"last_used"`
+	Revoked      bool   `dynamodbav:"revoked" json:"revoked"`
+	DateRevoked  string `dynamodbav:"date_revoked" json:"date_revoked"`
+	RevokedBy    string `dynamodbav:"revoked_by" json:"revoked_by"`
+	CreatedBy    string `dynamodbav:"created_by" json:"created_by"`
+	DateCreated  string `dynamodbav:"date_created" json:"date_created"`
+	DateModified string `dynamodbav:"date_modified" json:"date_modified"`
+}
+
+// ToModel converts the database model to the API model
+func (t *Token) ToModel() *models.ScimToken {
+	return &models.ScimToken{
+		TokenID:      t.TokenID,
+		TokenPrefix:  t.TokenPrefix,
+		SignatureID:  t.SignatureID,
+		ClaGroupID:   t.ClaGroupID,
+		CompanyID:    t.CompanyID,
+		CompanySfid:  t.CompanySFID,
+		LastUsed:     t.LastUsed,
+		Revoked:      t.Revoked,
+		DateRevoked:  t.DateRevoked,
+		RevokedBy:    t.RevokedBy,
+		CreatedBy:    t.CreatedBy,
+		DateCreated:  t.DateCreated,
+		DateModified: t.DateModified,
+	}
+}
+
+// Resource is the database model for the SCIM resources table, it holds the users and groups provisioned by the
+// identity provider for a CCLA signature
+type Resource struct {
+	SignatureID  string   `dynamodbav:"signature_id" json:"signature_id"`
+	ResourceID   string   `dynamodbav:"resource_id" json:"resource_id"`
+	ResourceType string   `dynamodbav:"resource_type" json:"resource_type"`
+	ExternalID   string   `dynamodbav:"external_id,omitempty" json:"external_id,omitempty"`
+	UserName     string   `dynamodbav:"user_name,omitempty" json:"user_name,omitempty"`
+	Email        string   `dynamodbav:"email,omitempty" json:"email,omitempty"`
+	Active       bool     `dynamodbav:"active" json:"active"`
+	DisplayName  string   `dynamodbav:"display_name,omitempty" json:"display_name,omitempty"`
+	Members      []string `dynamodbav:"members,omitempty" json:"members,omitempty"`
+	SyncedEmail  string   `dynamodbav:"synced_email,omitempty" json:"synced_email,omitempty"`
+	DateCreated  string   `dynamodbav:"date_created" json:"date_created"`
+	DateModified string   `dynamodbav:"date_modified" json:"date_modified"`
+}
+
+// hasMember returns true if the user is a member of the group
+func (r *Resource) hasMember(userID string) bool {
+	for _, member := range r.Members {
+		if member == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// emailKey is used to compare the email addresses - the approval list values are compared case insensitive
+func emailKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,387 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// indexes
+const (
+	TokenHashIndex   = "token-hash-index"
+	SignatureIDIndex = "signature-id-index"
+)
+
+// errors
+var (
+	ErrTokenNotFound    = errors.New("scim token not found")
+	ErrResourceNotFound = errors.New("scim resource not found")
+)
+
+// Repository interface defines the functions for the SCIM tokens and resources data model
+type Repository interface {
+	CreateToken(ctx context.Context, token *Token) error
+	GetToken(ctx context.Context, tokenID string) (*Token, error)
+	GetTokenByHash(ctx context.Context, tokenHash string) (*Token, error)
+	GetTokensBySignature(ctx context.Context, signatureID string) ([]*Token, error)
+	UpdateTokenLastUsed(ctx context.Context, tokenID string, lastUsed string) error
+	RevokeToken(ctx context.Context, tokenID string, revokedBy string) error
+
+	GetResources(ctx context.Context, signatureID string) ([]*Resource, error)
+	GetResource(ctx context.Context, signatureID, resourceID string) (*Resource, error)
+	PutResource(ctx context.Context, resource *Resource) error
+	DeleteResource(ctx context.Context, signatureID, resourceID string) error
+}
+
+type repository struct {
+	stage              string
+	dynamoDBClient     *dynamodb.DynamoDB
+	tokensTableName    string
+	resourcesTableName string
+}
+
+// NewRepository creates a new instance of the SCIM repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:              stage,
+		dynamoDBClient:     dynamodb.New(awsSession),
+		tokensTableName:    fmt.Sprintf("cla-%s-scim-tokens", stage),
+		resourcesTableName: fmt.Sprintf("cla-%s-scim-resources", stage),
+	}
+}
+
+// CreateToken stores the new token
+func (repo *repository) CreateToken(ctx context.Context, token *Token) error {
+	f := logrus.Fields{
+		"functionName":   "CreateToken",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tokensTableName,
+		"tokenID":        token.TokenID,
+		"signatureID":    token.SignatureID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(token)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal scim token, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.tokensTableName),
+		ConditionExpression: aws.String("attribute_not_exists(token_id)"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store scim token, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// GetToken returns the token by ID
+func (repo *repository) GetToken(ctx context.Context, tokenID string) (*Token, error) {
+	f := logrus.Fields{
+		"functionName":   "GetToken",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tokensTableName,
+		"tokenID":        tokenID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"token_id": {
+				S: aws.String(tokenID),
+			},
+		},
+		TableName: aws.String(repo.tokensTableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load scim token, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrTokenNotFound
+	}
+
+	var token Token
+	err = dynamodbattribute.UnmarshalMap(result.Item, &token)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling scim token table data, error: %v", err)
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetTokenByHash returns the token matching the hash of the token value
+func (repo *repository) GetTokenByHash(ctx context.Context, tokenHash string) (*Token, error) {
+	f := logrus.Fields{
+		"functionName":   "GetTokenByHash",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tokensTableName,
+	}
+
+	var tokens []*Token
+	condition := expression.Key("token_hash").Equal(expression.Value(tokenHash))
+	if err := repo.query(f, repo.tokensTableName, condition, aws.String(TokenHashIndex), &tokens); err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, ErrTokenNotFound
+	}
+	return tokens[0], nil
+}
+
+// GetTokensBySignature returns the tokens of the signature, including the revoked ones
+func (repo *repository) GetTokensBySignature(ctx context.Context, signatureID string) ([]*Token, error) {
+	f := logrus.Fields{
+		"functionName":   "GetTokensBySignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tokensTableName,
+		"signatureID":    signatureID,
+	}
+
+	var tokens []*Token
+	condition := expression.Key("signature_id").Equal(expression.Value(signatureID))
+	if err := repo.query(f, repo.tokensTableName, condition, aws.String(SignatureIDIndex), &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// UpdateTokenLastUsed records when the token was last used
+func (repo *repository) UpdateTokenLastUsed(ctx context.Context, tokenID string, lastUsed string) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateTokenLastUsed",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tokensTableName,
+		"tokenID":        tokenID,
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"token_id": {
+				S: aws.String(tokenID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#L": aws.String("last_used"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":l": {
+				S: aws.String(lastUsed),
+			},
+		},
+		UpdateExpression: aws.String("SET #L = :l"),
+		TableName:        aws.String(repo.tokensTableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to update scim token last used, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// RevokeToken marks the token as revoked - the record is kept for auditing
+func (repo *repository) RevokeToken(ctx context.Context, tokenID string, revokedBy string) error {
+	f := logrus.Fields{
+		"functionName":   "RevokeToken",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.tokensTableName,
+		"tokenID":        tokenID,
+		"revokedBy":      revokedBy,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"token_id": {
+				S: aws.String(tokenID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#R": aws.String("revoked"),
+			"#D": aws.String("date_revoked"),
+			"#B": aws.String("revoked_by"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":r": {
+				BOOL: aws.Bool(true),
+			},
+			":d": {
+				S: aws.String(currentTime),
+			},
+			":b": {
+				S: aws.String(revokedBy),
+			},
+			":m": {
+				S: aws.String(currentTime),
+			},
+		},
+		UpdateExpression: aws.String("SET #R = :r, #D = :d, #B = :b, #M = :m"),
+		TableName:        aws.String(repo.tokensTableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to revoke scim token, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// GetResources returns the users and groups provisioned for the signature
+func (repo *repository) GetResources(ctx context.Context, signatureID string) ([]*Resource, error) {
+	f := logrus.Fields{
+		"functionName":   "GetResources",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.resourcesTableName,
+		"signatureID":    signatureID,
+	}
+
+	var resources []*Resource
+	condition := expression.Key("signature_id").Equal(expression.Value(signatureID))
+	if err := repo.query(f, repo.resourcesTableName, condition, nil, &resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// GetResource returns the user or group by ID
+func (repo *repository) GetResource(ctx context.Context, signatureID, resourceID string) (*Resource, error) {
+	f := logrus.Fields{
+		"functionName":   "GetResource",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.resourcesTableName,
+		"signatureID":    signatureID,
+		"resourceID":     resourceID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key:       resourceKey(signatureID, resourceID),
+		TableName: aws.String(repo.resourcesTableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load scim resource, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrResourceNotFound
+	}
+
+	var resource Resource
+	err = dynamodbattribute.UnmarshalMap(result.Item, &resource)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling scim resource table data, error: %v", err)
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// PutResource creates or replaces the user or group
+func (repo *repository) PutResource(ctx context.Context, resource *Resource) error {
+	f := logrus.Fields{
+		"functionName":   "PutResource",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.resourcesTableName,
+		"signatureID":    resource.SignatureID,
+		"resourceID":     resource.ResourceID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(resource)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal scim resource, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.resourcesTableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store scim resource, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteResource deletes the user or group
+func (repo *repository) DeleteResource(ctx context.Context, signatureID, resourceID string) error {
+	f := logrus.Fields{
+		"functionName":   "DeleteResource",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.resourcesTableName,
+		"signatureID":    signatureID,
+		"resourceID":     resourceID,
+	}
+
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key:       resourceKey(signatureID, resourceID),
+		TableName: aws.String(repo.resourcesTableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to delete scim resource, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+func (repo *repository) query(f logrus.Fields, tableName string, condition expression.KeyConditionBuilder, indexName *string, out interface{}) error {
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for scim query, error: %v", err)
+		return err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(tableName),
+		IndexName:                 indexName,
+	}
+	var resultList []map[string]*dynamodb.AttributeValue
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).Warnf("error retrieving scim records, error: %v", err)
+			return err
+		}
+		resultList = append(resultList, results.Items...)
+		if len(results.LastEvaluatedKey) != 0 {
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+
+	err = dynamodbattribute.UnmarshalListOfMaps(resultList, out)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling scim records from database, error: %v", err)
+		return err
+	}
+	return nil
+}
+
+func resourceKey(signatureID, resourceID string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"signature_id": {
+			S: aws.String(signatureID),
+		},
+		"resource_id": {
+			S: aws.String(resourceID),
+		},
+	}
+}
@@ -0,0 +1,561 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+const (
+	// tokenValuePrefix makes EasyCLA SCIM tokens easy to recognize, e.g. by secret scanners
+	tokenValuePrefix = "ecla_scim_"
+	// tokenPrefixLength is the number of characters of the token value stored to help identify the token
+	tokenPrefixLength = 16
+	// lastUsedInterval limits how often the last used date of a token is stored
+	lastUsedInterval = 5 * time.Minute
+	// tokenRevoker is recorded as the revoker of the tokens whose creator is no longer a CLA manager
+	tokenRevoker = "EasyCLA"
+)
+
+// errors
+var (
+	ErrInvalidToken      = errors.New("invalid scim token")
+	ErrInvalidResource   = errors.New("invalid scim resource")
+	ErrUniqueness        = errors.New("scim resource already exists")
+	ErrCompanyNotFound   = errors.New("company not found")
+	ErrCLAGroupNotFound  = errors.New("CLA group not found")
+	ErrSignatureNotFound = errors.New("signed and approved CCLA signature not found")
+)
+
+// CompanyService is the part of the company service used to resolve the company of a token
+type CompanyService interface {
+	GetCompanyByExternalID(ctx context.Context, companySFID string) (*v1Models.Company, error)
+}
+
+// ClaGroupService is the part of the project service used to resolve the CLA group of a token
+type ClaGroupService interface {
+	GetCLAGroupByID(ctx context.Context, claGroupID string) (*v1Models.ClaGroup, error)
+}
+
+// SignatureRepository is the part of the signatures repository used to load the CCLA signature and its CLA managers
+type SignatureRepository interface {
+	GetProjectCompanySignature(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*v1Models.Signature, error)
+	GetSignatureACL(ctx context.Context, signatureID string) ([]string, error)
+}
+
+// SignatureService is the part of the signatures service used to update the approval list for the CLA manager who
+// created the token
+type SignatureService interface {
+	ImportApprovalList(ctx context.Context, authUser *auth.User, claGroupModel *v1Models.ClaGroup, companyModel *v1Models.Company, claGroupID string, params *v1Models.ApprovalList) (*v1Models.Signature, error)
+}
+
+// EventsService is the part of the events service used to log the revoked tokens
+type EventsService interface {
+	LogEvent(args *events.LogEventArgs)
+}
+
+// Service interface defines the SCIM service methods
+type Service interface {
+	CreateToken(ctx context.Context, authUser *auth.User, claGroupID, companySFID string) (*Token, string, error)
+	GetTokens(ctx context.Context, claGroupID, companySFID string) ([]*Token, error)
+	RevokeToken(ctx context.Context, claGroupID, companySFID, tokenID, revokedBy string) (*Token, error)
+	Authenticate(ctx context.Context, tokenValue string) (*Token, error)
+
+	GetResources(ctx context.Context, token *Token, resourceType string) ([]*Resource, error)
+	GetResource(ctx context.Context, token *Token, resourceType, resourceID string) (*Resource, error)
+	CreateResource(ctx context.Context, token *Token, resource *Resource) (*Resource, error)
+	ReplaceResource(ctx context.Context, token *Token, resource *Resource) (*Resource, error)
+	DeleteResource(ctx context.Context, token *Token, resourceType, resourceID string) error
+}
+
+type service struct {
+	repo             Repository
+	companyService   CompanyService
+	projectService   ClaGroupService
+	signatureRepo    SignatureRepository
+	signatureService SignatureService
+	eventsService    EventsService
+}
+
+// NewService creates a new SCIM service
+func NewService(repo Repository, companyService CompanyService, projectService ClaGroupService, signatureRepo SignatureRepository, signatureService SignatureService, eventsService EventsService) Service {
+	return &service{
+		repo:             repo,
+		companyService:   companyService,
+		projectService:   projectService,
+		signatureRepo:    signatureRepo,
+		signatureService: signatureService,
+		eventsService:    eventsService,
+	}
+}
+
+// hashToken returns the hash stored for the token value - the token values are random so a plain SHA-256 is sufficient
+func hashToken(tokenValue string) string {
+	sum := sha256.Sum256([]byte(tokenValue))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateToken returns a new random token value
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return tokenValuePrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CreateToken creates a new token for the company CCLA signature and returns the token value - only the hash of the
+// value is stored so it cannot be retrieved again. The identity provider acts for the CLA manager creating the token,
+// the token is revoked once the CLA manager is removed from the signature.
+func (s *service) CreateToken(ctx context.Context, authUser *auth.User, claGroupID, companySFID string) (*Token, string, error) {
+	f := logrus.Fields{
+		"functionName":   "CreateToken",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"companySFID":    companySFID,
+		"authUserName":   authUser.UserName,
+	}
+
+	companyModel, _, signature, err := s.loadSignature(ctx, claGroupID, companySFID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !isCLAManager(authUser.UserName, signature.SignatureACL) {
+		msg := fmt.Sprintf("CLA Manager %s is not authorized to create a SCIM token for company ID: %s, CLA Group ID: %s",
+			authUser.UserName, companyModel.CompanyID, claGroupID)
+		log.WithFields(f).Warn(msg)
+		return nil, "", signatures.NewForbiddenError(msg)
+	}
+
+	tokenValue, err := generateToken()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate scim token")
+		return nil, "", err
+	}
+	tokenID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate a UUID for the scim token")
+		return nil, "", err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	token := &Token{
+		TokenID:      tokenID.String(),
+		TokenHash:    hashToken(tokenValue),
+		TokenPrefix:  tokenValue[:tokenPrefixLength],
+		SignatureID:  signature.SignatureID.String(),
+		ClaGroupID:   claGroupID,
+		CompanyID:    companyModel.CompanyID,
+		CompanySFID:  companySFID,
+		CreatedBy:    authUser.UserName,
+		DateCreated:  currentTime,
+		DateModified: currentTime,
+	}
+	if err := s.repo.CreateToken(ctx, token); err != nil {
+		return nil, "", err
+	}
+	return token, tokenValue, nil
+}
+
+// GetTokens returns the tokens of the company CCLA signature
+func (s *service) GetTokens(ctx context.Context, claGroupID, companySFID string) ([]*Token, error) {
+	_, _, signature, err := s.loadSignature(ctx, claGroupID, companySFID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetTokensBySignature(ctx, signature.SignatureID.String())
+}
+
+// RevokeToken revokes the token - the token must belong to the company and CLA group
+func (s *service) RevokeToken(ctx context.Context, claGroupID, companySFID, tokenID, revokedBy string) (*Token, error) {
+	token, err := s.repo.GetToken(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if token.ClaGroupID != claGroupID || token.CompanySFID != companySFID {
+		return nil, ErrTokenNotFound
+	}
+	if err := s.repo.RevokeToken(ctx, tokenID, revokedBy); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Authenticate returns the token for the token value if it is valid and not revoked. A token whose creator is no
+// longer a CLA manager of the signature is revoked.
+func (s *service) Authenticate(ctx context.Context, tokenValue string) (*Token, error) {
+	f := logrus.Fields{
+		"functionName":   "Authenticate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	if !strings.HasPrefix(tokenValue, tokenValuePrefix) {
+		return nil, ErrInvalidToken
+	}
+	token, err := s.repo.GetTokenByHash(ctx, hashToken(tokenValue))
+	if err != nil {
+		if err == ErrTokenNotFound {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if token.Revoked {
+		return nil, ErrInvalidToken
+	}
+
+	claManagers, err := s.signatureRepo.GetSignatureACL(ctx, token.SignatureID)
+	if err != nil {
+		return nil, err
+	}
+	if !utils.StringInSlice(token.CreatedBy, claManagers) {
+		s.revokeOrphanedToken(ctx, token)
+		return nil, ErrInvalidToken
+	}
+
+	now, currentTime := utils.CurrentTime()
+	lastUsed, parseErr := utils.ParseDateTime(token.LastUsed)
+	if parseErr != nil || now.Sub(lastUsed) > lastUsedInterval {
+		if err := s.repo.UpdateTokenLastUsed(ctx, token.TokenID, currentTime); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to update the scim token last used date")
+		}
+	}
+	return token, nil
+}
+
+// GetResources returns the users or groups provisioned with the token
+func (s *service) GetResources(ctx context.Context, token *Token, resourceType string) ([]*Resource, error) {
+	resources, err := s.repo.GetResources(ctx, token.SignatureID)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []*Resource
+	for _, resource := range resources {
+		if resource.ResourceType == resourceType {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered, nil
+}
+
+// GetResource returns the user or group by ID
+func (s *service) GetResource(ctx context.Context, token *Token, resourceType, resourceID string) (*Resource, error) {
+	resource, err := s.repo.GetResource(ctx, token.SignatureID, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if resource.ResourceType != resourceType {
+		return nil, ErrResourceNotFound
+	}
+	return resource, nil
+}
+
+// CreateResource stores the new user or group and syncs the approval list
+func (s *service) CreateResource(ctx context.Context, token *Token, resource *Resource) (*Resource, error) {
+	resourceID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	_, currentTime := utils.CurrentTime()
+	resource.SignatureID = token.SignatureID
+	resource.ResourceID = resourceID.String()
+	resource.SyncedEmail = ""
+	resource.DateCreated = currentTime
+	resource.DateModified = currentTime
+	return s.store(ctx, token, resource)
+}
+
+// ReplaceResource replaces the user or group and syncs the approval list
+func (s *service) ReplaceResource(ctx context.Context, token *Token, resource *Resource) (*Resource, error) {
+	existing, err := s.GetResource(ctx, token, resource.ResourceType, resource.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	_, currentTime := utils.CurrentTime()
+	resource.SignatureID = token.SignatureID
+	resource.SyncedEmail = existing.SyncedEmail
+	resource.DateCreated = existing.DateCreated
+	resource.DateModified = currentTime
+	return s.store(ctx, token, resource)
+}
+
+// DeleteResource deletes the user or group - a deleted user is removed from the approval list first
+func (s *service) DeleteResource(ctx context.Context, token *Token, resourceType, resourceID string) error {
+	resource, err := s.GetResource(ctx, token, resourceType, resourceID)
+	if err != nil {
+		return err
+	}
+
+	resources, err := s.repo.GetResources(ctx, token.SignatureID)
+	if err != nil {
+		return err
+	}
+	var remaining []*Resource
+	for _, r := range resources {
+		if r.ResourceID != resource.ResourceID {
+			remaining = append(remaining, r)
+		}
+	}
+	if resource.ResourceType == ResourceTypeUser {
+		resource.Active = false
+		remaining = append(remaining, resource)
+	}
+	if err := s.sync(ctx, token, remaining); err != nil {
+		return err
+	}
+	return s.repo.DeleteResource(ctx, token.SignatureID, resource.ResourceID)
+}
+
+// store validates and saves the resource, then syncs the approval list
+func (s *service) store(ctx context.Context, token *Token, resource *Resource) (*Resource, error) {
+	resources, err := s.repo.GetResources(ctx, token.SignatureID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateResource(resource, resources); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.PutResource(ctx, resource); err != nil {
+		return nil, err
+	}
+
+	updated := []*Resource{resource}
+	for _, r := range resources {
+		if r.ResourceID != resource.ResourceID {
+			updated = append(updated, r)
+		}
+	}
+	if err := s.sync(ctx, token, updated); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// validateResource checks the required attributes and the uniqueness of the user name or group display name
+func validateResource(resource *Resource, resources []*Resource) error {
+	resource.UserName = strings.TrimSpace(resource.UserName)
+	resource.Email = strings.TrimSpace(resource.Email)
+	resource.DisplayName = strings.TrimSpace(resource.DisplayName)
+
+	switch resource.ResourceType {
+	case ResourceTypeUser:
+		if resource.UserName == "" {
+			return fmt.Errorf("%w: userName is required", ErrInvalidResource)
+		}
+		if resource.Email != "" && !utils.ValidEmail(resource.Email) {
+			return fmt.Errorf("%w: invalid email %s", ErrInvalidResource, resource.Email)
+		}
+		resource.Members = nil
+	case ResourceTypeGroup:
+		if resource.DisplayName == "" {
+			return fmt.Errorf("%w: displayName is required", ErrInvalidResource)
+		}
+		resource.Members = utils.RemoveDuplicates(resource.Members)
+	default:
+		return fmt.Errorf("%w: unsupported resource type %s", ErrInvalidResource, resource.ResourceType)
+	}
+
+	for _, r := range resources {
+		if r.ResourceID == resource.ResourceID || r.ResourceType != resource.ResourceType {
+			continue
+		}
+		if resource.ResourceType == ResourceTypeUser && strings.EqualFold(r.UserName, resource.UserName) {
+			return fmt.Errorf("%w: userName %s", ErrUniqueness, resource.UserName)
+		}
+		if resource.ResourceType == ResourceTypeGroup && strings.EqualFold(r.DisplayName, resource.DisplayName) {
+			return fmt.Errorf("%w: displayName %s", ErrUniqueness, resource.DisplayName)
+		}
+	}
+	return nil
+}
+
+// sync updates the email approval list from the resources - the emails of the active users who are a member of a
+// group are approved, the emails previously approved by SCIM for the other users are removed. Entries added by the
+// CLA managers for other emails are not changed. The changes are applied by the signatures service for the CLA
+// manager who created the token, the same as an approval list import by the CLA manager, and only while the creator
+// is still a CLA manager of the signature.
+func (s *service) sync(ctx context.Context, token *Token, resources []*Resource) error {
+	f := logrus.Fields{
+		"functionName":   "sync",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tokenID":        token.TokenID,
+		"signatureID":    token.SignatureID,
+	}
+
+	companyModel, claGroupModel, signature, err := s.loadSignature(ctx, token.ClaGroupID, token.CompanySFID)
+	if err != nil {
+		return err
+	}
+	if signature.SignatureID.String() != token.SignatureID {
+		log.WithFields(f).Warnf("the scim token signature is no longer the signed and approved CCLA %s", signature.SignatureID)
+		return ErrSignatureNotFound
+	}
+	if !isCLAManager(token.CreatedBy, signature.SignatureACL) {
+		s.revokeOrphanedToken(ctx, token)
+		return ErrInvalidToken
+	}
+
+	params, synced := approvalListChanges(signature.EmailApprovalList, resources)
+	if len(params.AddEmailApprovalList) > 0 || len(params.RemoveEmailApprovalList) > 0 {
+		log.WithFields(f).Debugf("syncing the email approval list - add: %v, remove: %v",
+			params.AddEmailApprovalList, params.RemoveEmailApprovalList)
+		_, err = s.signatureService.ImportApprovalList(ctx, &auth.User{UserName: token.CreatedBy}, claGroupModel, companyModel, token.ClaGroupID, params)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to sync the email approval list")
+			return err
+		}
+	}
+
+	_, currentTime := utils.CurrentTime()
+	for _, resource := range resources {
+		email, ok := synced[resource.ResourceID]
+		if !ok {
+			continue
+		}
+		resource.SyncedEmail = email
+		resource.DateModified = currentTime
+		if err := s.repo.PutResource(ctx, resource); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revokeOrphanedToken revokes the token of a creator who is no longer a CLA manager of the signature
+func (s *service) revokeOrphanedToken(ctx context.Context, token *Token) {
+	f := logrus.Fields{
+		"functionName":   "revokeOrphanedToken",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tokenID":        token.TokenID,
+		"signatureID":    token.SignatureID,
+		"createdBy":      token.CreatedBy,
+	}
+
+	log.WithFields(f).Warn("the creator of the scim token is no longer a CLA manager, revoking the token")
+	if err := s.repo.RevokeToken(ctx, token.TokenID, tokenRevoker); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to revoke the scim token")
+		return
+	}
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		LfUsername: tokenRevoker,
+		EventType:  events.ScimTokenRevoked,
+		ProjectID:  token.ClaGroupID,
+		CompanyID:  token.CompanyID,
+		EventData: &events.ScimTokenRevokedEventData{
+			TokenID:     token.TokenID,
+			TokenPrefix: token.TokenPrefix,
+		},
+	})
+}
+
+// isCLAManager returns true if the user is in the signature ACL
+func isCLAManager(userName string, claManagers []v1Models.User) bool {
+	for _, claManager := range claManagers {
+		if claManager.LfUsername == userName {
+			return true
+		}
+	}
+	return false
+}
+
+// approvalListChanges returns the approval list update for the resources and the new synced email of the users
+// whose synced email changed, by resource ID
+func approvalListChanges(emailApprovalList []string, resources []*Resource) (*v1Models.ApprovalList, map[string]string) {
+	// the approval list values by email key, the removed values must match the list
+	current := make(map[string]string)
+	for _, email := range emailApprovalList {
+		current[emailKey(email)] = email
+	}
+	members := make(map[string]bool)
+	for _, resource := range resources {
+		if resource.ResourceType != ResourceTypeGroup {
+			continue
+		}
+		for _, member := range resource.Members {
+			members[member] = true
+		}
+	}
+
+	// the approved emails - several users may share an email
+	desired := make(map[string]bool)
+	for _, resource := range resources {
+		if isApproved(resource, members) {
+			desired[emailKey(resource.Email)] = true
+		}
+	}
+
+	params := &v1Models.ApprovalList{}
+	added := make(map[string]bool)
+	removed := make(map[string]bool)
+	changed := make(map[string]string)
+	for _, resource := range resources {
+		if resource.ResourceType != ResourceTypeUser {
+			continue
+		}
+		synced := ""
+		if isApproved(resource, members) {
+			synced = resource.Email
+		}
+		// an approved email removed from the approval list by hand is added again on the next sync
+		if key := emailKey(synced); key != "" && current[key] == "" && !added[key] {
+			added[key] = true
+			params.AddEmailApprovalList = append(params.AddEmailApprovalList, synced)
+		}
+		if emailKey(synced) == emailKey(resource.SyncedEmail) {
+			continue
+		}
+
+		if previous := emailKey(resource.SyncedEmail); previous != "" && !desired[previous] && current[previous] != "" && !removed[previous] {
+			removed[previous] = true
+			params.RemoveEmailApprovalList = append(params.RemoveEmailApprovalList, current[previous])
+		}
+		changed[resource.ResourceID] = synced
+	}
+	return params, changed
+}
+
+// isApproved returns true if the email of the user belongs on the approval list
+func isApproved(resource *Resource, members map[string]bool) bool {
+	return resource.ResourceType == ResourceTypeUser && resource.Active && resource.Email != "" && members[resource.ResourceID]
+}
+
+func (s *service) loadSignature(ctx context.Context, claGroupID, companySFID string) (*v1Models.Company, *v1Models.ClaGroup, *v1Models.Signature, error) {
+	companyModel, err := s.companyService.GetCompanyByExternalID(ctx, companySFID)
+	if err != nil || companyModel == nil {
+		return nil, nil, nil, ErrCompanyNotFound
+	}
+	claGroupModel, err := s.projectService.GetCLAGroupByID(ctx, claGroupID)
+	if err != nil || claGroupModel == nil {
+		return nil, nil, nil, ErrCLAGroupNotFound
+	}
+
+	signed, approved := true, true
+	pageSize := int64(1)
+	signature, err := s.signatureRepo.GetProjectCompanySignature(ctx, companyModel.CompanyID, claGroupID, &signed, &approved, nil, &pageSize)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if signature == nil {
+		return nil, nil, nil, ErrSignatureNotFound
+	}
+	return companyModel, claGroupModel, signature, nil
+}
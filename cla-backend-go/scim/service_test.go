@@ -0,0 +1,326 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/scim"
+	"github.com/communitybridge/easycla/cla-backend-go/scim/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSignatureID = "4a4d5a8c-4d2b-4c43-9f36-0c6a3f8f1e21"
+
+var testToken = &scim.Token{
+	TokenID:     "token-1",
+	TokenPrefix: "ecla_scim_abcdef",
+	SignatureID: testSignatureID,
+	ClaGroupID:  "cla-group-1",
+	CompanyID:   "company-1",
+	CompanySFID: "company-sfid",
+	CreatedBy:   "manager",
+}
+
+type testMocks struct {
+	repo             *mock.MockRepository
+	signatureRepo    *mock.MockSignatureRepository
+	signatureService *mock.MockSignatureService
+	eventsService    *mock.MockEventsService
+}
+
+// newTestService returns the service for the company-sfid company and the cla-group-1 CLA group
+func newTestService(ctrl *gomock.Controller) (scim.Service, *testMocks) {
+	mocks := &testMocks{
+		repo:             mock.NewMockRepository(ctrl),
+		signatureRepo:    mock.NewMockSignatureRepository(ctrl),
+		signatureService: mock.NewMockSignatureService(ctrl),
+		eventsService:    mock.NewMockEventsService(ctrl),
+	}
+	companyService := mock.NewMockCompanyService(ctrl)
+	companyService.EXPECT().GetCompanyByExternalID(gomock.Any(), "company-sfid").
+		Return(&models.Company{CompanyID: "company-1", CompanyExternalID: "company-sfid"}, nil).AnyTimes()
+	projectService := mock.NewMockClaGroupService(ctrl)
+	projectService.EXPECT().GetCLAGroupByID(gomock.Any(), "cla-group-1").Return(&models.ClaGroup{ProjectID: "cla-group-1"}, nil).AnyTimes()
+	return scim.NewService(mocks.repo, companyService, projectService, mocks.signatureRepo, mocks.signatureService, mocks.eventsService), mocks
+}
+
+// expectSignature returns the signature managed by the CLA manager who created the test token
+func (m *testMocks) expectSignature(emailApprovalList ...string) *gomock.Call {
+	return m.signatureRepo.EXPECT().GetProjectCompanySignature(gomock.Any(), "company-1", "cla-group-1", gomock.Any(), gomock.Any(), nil, gomock.Any()).
+		Return(&models.Signature{
+			SignatureID:       testSignatureID,
+			SignatureACL:      []models.User{{LfUsername: "manager"}},
+			EmailApprovalList: emailApprovalList,
+		}, nil)
+}
+
+func TestTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mocks := newTestService(ctrl)
+	ctx := context.Background()
+
+	mocks.expectSignature().Times(2)
+	_, _, err := service.CreateToken(ctx, &auth.User{UserName: "someone"}, "cla-group-1", "company-sfid")
+	_, ok := err.(*signatures.ForbiddenError)
+	assert.True(t, ok, "only the CLA managers of the signature can create a token")
+
+	var stored *scim.Token
+	mocks.repo.EXPECT().CreateToken(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, token *scim.Token) error {
+		stored = token
+		return nil
+	})
+	token, tokenValue, err := service.CreateToken(ctx, &auth.User{UserName: "manager"}, "cla-group-1", "company-sfid")
+	assert.Nil(t, err)
+	assert.Equal(t, stored, token)
+	assert.True(t, strings.HasPrefix(tokenValue, scim.TokenValuePrefix))
+	assert.Equal(t, testSignatureID, token.SignatureID)
+	assert.Equal(t, "manager", token.CreatedBy)
+	assert.Equal(t, scim.HashToken(tokenValue), token.TokenHash)
+
+	mocks.repo.EXPECT().GetTokenByHash(gomock.Any(), token.TokenHash).Return(token, nil)
+	mocks.signatureRepo.EXPECT().GetSignatureACL(gomock.Any(), testSignatureID).Return([]string{"manager"}, nil)
+	mocks.repo.EXPECT().UpdateTokenLastUsed(gomock.Any(), token.TokenID, gomock.Any()).Return(nil)
+	authenticated, err := service.Authenticate(ctx, tokenValue)
+	assert.Nil(t, err)
+	assert.Equal(t, token.TokenID, authenticated.TokenID)
+
+	mocks.repo.EXPECT().GetTokenByHash(gomock.Any(), scim.HashToken(scim.TokenValuePrefix+"unknown")).Return(nil, scim.ErrTokenNotFound)
+	_, err = service.Authenticate(ctx, scim.TokenValuePrefix+"unknown")
+	assert.Equal(t, scim.ErrInvalidToken, err)
+	_, err = service.Authenticate(ctx, "")
+	assert.Equal(t, scim.ErrInvalidToken, err)
+
+	mocks.repo.EXPECT().GetToken(gomock.Any(), token.TokenID).Return(token, nil).Times(2)
+	_, err = service.RevokeToken(ctx, "cla-group-2", "company-sfid", token.TokenID, "manager")
+	assert.Equal(t, scim.ErrTokenNotFound, err, "the token must belong to the CLA group")
+	mocks.repo.EXPECT().RevokeToken(gomock.Any(), token.TokenID, "manager").Return(nil)
+	_, err = service.RevokeToken(ctx, "cla-group-1", "company-sfid", token.TokenID, "manager")
+	assert.Nil(t, err)
+
+	mocks.repo.EXPECT().GetTokenByHash(gomock.Any(), token.TokenHash).Return(&scim.Token{TokenID: token.TokenID, Revoked: true}, nil)
+	_, err = service.Authenticate(ctx, tokenValue)
+	assert.Equal(t, scim.ErrInvalidToken, err)
+}
+
+func TestAuthenticateRevokesOrphanedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mocks := newTestService(ctrl)
+
+	// the CLA manager who created the token is no longer in the signature ACL
+	tokenValue := scim.TokenValuePrefix + "orphaned"
+	mocks.repo.EXPECT().GetTokenByHash(gomock.Any(), scim.HashToken(tokenValue)).Return(testToken, nil)
+	mocks.signatureRepo.EXPECT().GetSignatureACL(gomock.Any(), testSignatureID).Return([]string{"other"}, nil)
+	mocks.repo.EXPECT().RevokeToken(gomock.Any(), testToken.TokenID, scim.TokenRevoker).Return(nil)
+	mocks.eventsService.EXPECT().LogEvent(gomock.Any()).Do(func(args *events.LogEventArgs) {
+		assert.Equal(t, events.ScimTokenRevoked, args.EventType)
+		assert.Equal(t, &events.ScimTokenRevokedEventData{TokenID: testToken.TokenID, TokenPrefix: testToken.TokenPrefix}, args.EventData)
+	})
+
+	_, err := service.Authenticate(context.Background(), tokenValue)
+	assert.Equal(t, scim.ErrInvalidToken, err)
+}
+
+func TestSync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mocks := newTestService(ctrl)
+	ctx := context.Background()
+
+	john := &scim.Resource{SignatureID: testSignatureID, ResourceID: "john", ResourceType: scim.ResourceTypeUser, UserName: "john", Email: "john@example.com", Active: true}
+	jane := &scim.Resource{SignatureID: testSignatureID, ResourceID: "jane", ResourceType: scim.ResourceTypeUser, UserName: "jane", Email: "Manual@example.com", Active: true}
+
+	// the emails of the group members are approved, emails already on the list are not added again
+	mocks.repo.EXPECT().GetResources(gomock.Any(), testSignatureID).Return([]*scim.Resource{john, jane}, nil)
+	mocks.expectSignature("manual@example.com")
+	mocks.signatureService.EXPECT().ImportApprovalList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "cla-group-1", &models.ApprovalList{
+		AddEmailApprovalList: []string{"john@example.com"},
+	}).DoAndReturn(func(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error) {
+		assert.Equal(t, "manager", authUser.UserName, "the sync acts for the CLA manager who created the token")
+		assert.Equal(t, "company-1", companyModel.CompanyID)
+		return &models.Signature{}, nil
+	})
+	var synced []string
+	mocks.repo.EXPECT().PutResource(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, resource *scim.Resource) error {
+		synced = append(synced, resource.ResourceID+"="+resource.SyncedEmail)
+		return nil
+	}).Times(3)
+
+	group, err := service.CreateResource(ctx, testToken, &scim.Resource{ResourceType: scim.ResourceTypeGroup, DisplayName: "Engineering", Members: []string{"john", "jane"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{group.ResourceID + "=", "john=john@example.com", "jane=Manual@example.com"}, synced)
+
+	// deleting the group removes the emails of the members, including the adopted entry
+	mocks.repo.EXPECT().GetResource(gomock.Any(), testSignatureID, group.ResourceID).Return(group, nil)
+	mocks.repo.EXPECT().GetResources(gomock.Any(), testSignatureID).Return([]*scim.Resource{group, john, jane}, nil)
+	mocks.expectSignature("manual@example.com", "john@example.com")
+	mocks.signatureService.EXPECT().ImportApprovalList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "cla-group-1", &models.ApprovalList{
+		RemoveEmailApprovalList: []string{"john@example.com", "manual@example.com"},
+	}).Return(&models.Signature{}, nil)
+	mocks.repo.EXPECT().PutResource(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mocks.repo.EXPECT().DeleteResource(gomock.Any(), testSignatureID, group.ResourceID).Return(nil)
+
+	err = service.DeleteResource(ctx, testToken, scim.ResourceTypeGroup, group.ResourceID)
+	assert.Nil(t, err)
+	assert.Equal(t, "", john.SyncedEmail)
+	assert.Equal(t, "", jane.SyncedEmail)
+}
+
+func TestSyncUpdateFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mocks := newTestService(ctrl)
+
+	// the resource is stored, but not marked as synced when the approval list was not updated
+	john := &scim.Resource{ResourceType: scim.ResourceTypeUser, UserName: "john", Email: "john@example.com", Active: true}
+	group := &scim.Resource{SignatureID: testSignatureID, ResourceID: "group", ResourceType: scim.ResourceTypeGroup, DisplayName: "Engineering"}
+	mocks.repo.EXPECT().GetResources(gomock.Any(), testSignatureID).Return([]*scim.Resource{group}, nil)
+	mocks.repo.EXPECT().PutResource(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, resource *scim.Resource) error {
+		group.Members = []string{resource.ResourceID}
+		return nil
+	})
+	mocks.expectSignature()
+	updateErr := signatures.NewBadRequestError("unable to add domain to the approval list")
+	mocks.signatureService.EXPECT().ImportApprovalList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "cla-group-1", gomock.Any()).Return(nil, updateErr)
+
+	_, err := service.CreateResource(context.Background(), testToken, john)
+	assert.Equal(t, updateErr, err)
+	assert.Equal(t, "", john.SyncedEmail)
+}
+
+func TestSyncRevokesOrphanedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mocks := newTestService(ctrl)
+
+	// the CLA manager who created the token was removed from the signature ACL after the token was authenticated
+	mocks.repo.EXPECT().GetResources(gomock.Any(), testSignatureID).Return(nil, nil)
+	mocks.repo.EXPECT().PutResource(gomock.Any(), gomock.Any()).Return(nil)
+	mocks.signatureRepo.EXPECT().GetProjectCompanySignature(gomock.Any(), "company-1", "cla-group-1", gomock.Any(), gomock.Any(), nil, gomock.Any()).
+		Return(&models.Signature{SignatureID: testSignatureID, SignatureACL: []models.User{{LfUsername: "other"}}}, nil)
+	mocks.repo.EXPECT().RevokeToken(gomock.Any(), testToken.TokenID, scim.TokenRevoker).Return(nil)
+	mocks.eventsService.EXPECT().LogEvent(gomock.Any())
+
+	_, err := service.CreateResource(context.Background(), testToken, &scim.Resource{ResourceType: scim.ResourceTypeUser, UserName: "john", Email: "john@example.com", Active: true})
+	assert.Equal(t, scim.ErrInvalidToken, err)
+}
+
+func TestValidateResource(t *testing.T) {
+	existing := []*scim.Resource{
+		{ResourceID: "1", ResourceType: scim.ResourceTypeUser, UserName: "john"},
+		{ResourceID: "2", ResourceType: scim.ResourceTypeGroup, DisplayName: "Engineering"},
+	}
+
+	err := scim.ValidateResource(&scim.Resource{ResourceID: "3", ResourceType: scim.ResourceTypeUser, UserName: "John"}, existing)
+	assert.True(t, errors.Is(err, scim.ErrUniqueness))
+	err = scim.ValidateResource(&scim.Resource{ResourceID: "1", ResourceType: scim.ResourceTypeUser, UserName: "john"}, existing)
+	assert.Nil(t, err, "a user may be replaced with the same user name")
+	err = scim.ValidateResource(&scim.Resource{ResourceID: "3", ResourceType: scim.ResourceTypeGroup, DisplayName: "engineering"}, existing)
+	assert.True(t, errors.Is(err, scim.ErrUniqueness))
+	err = scim.ValidateResource(&scim.Resource{ResourceID: "3", ResourceType: scim.ResourceTypeUser}, existing)
+	assert.True(t, errors.Is(err, scim.ErrInvalidResource))
+	err = scim.ValidateResource(&scim.Resource{ResourceID: "3", ResourceType: scim.ResourceTypeUser, UserName: "jane", Email: "not-an-email"}, existing)
+	assert.True(t, errors.Is(err, scim.ErrInvalidResource))
+}
+
+func TestHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := mock.NewMockService(ctrl)
+	handler := scim.NewHandler(service)
+	tokenValue := scim.TokenValuePrefix + "valid"
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer "+tokenValue)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	service.EXPECT().Authenticate(gomock.Any(), "").Return(nil, scim.ErrInvalidToken)
+	unauthorized := httptest.NewRecorder()
+	handler.ServeHTTP(unauthorized, httptest.NewRequest(http.MethodGet, "/Users", nil))
+	assert.Equal(t, http.StatusUnauthorized, unauthorized.Code)
+
+	service.EXPECT().Authenticate(gomock.Any(), tokenValue).Return(testToken, nil).AnyTimes()
+	john := &scim.Resource{SignatureID: testSignatureID, ResourceID: "john", ResourceType: scim.ResourceTypeUser, UserName: "john@example.com", Email: "john@example.com", Active: true}
+	group := &scim.Resource{SignatureID: testSignatureID, ResourceID: "group", ResourceType: scim.ResourceTypeGroup, DisplayName: "Engineering"}
+
+	service.EXPECT().GetResources(gomock.Any(), testToken, scim.ResourceTypeUser).Return([]*scim.Resource{john}, nil).Times(2)
+	w := request(http.MethodGet, "/Users", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, scim.ContentType, w.Header().Get("Content-Type"))
+
+	service.EXPECT().CreateResource(gomock.Any(), testToken, gomock.Any()).DoAndReturn(func(ctx context.Context, token *scim.Token, resource *scim.Resource) (*scim.Resource, error) {
+		assert.Equal(t, "john@example.com", resource.Email)
+		assert.True(t, resource.Active, "users are active unless specified otherwise")
+		return john, nil
+	})
+	w = request(http.MethodPost, "/Users", `{"schemas":["`+scim.SchemaUser+`"],"userName":"john@example.com","emails":[{"value":"john@example.com","primary":true}]}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var user scim.SCIMResource
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &user))
+	assert.Equal(t, "john", user.ID)
+
+	service.EXPECT().CreateResource(gomock.Any(), testToken, gomock.Any()).Return(nil, fmt.Errorf("%w: userName JOHN@example.com", scim.ErrUniqueness))
+	w = request(http.MethodPost, "/Users", `{"userName":"JOHN@example.com"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = request(http.MethodGet, `/Users?filter=userName%20eq%20%22JOHN@example.com%22`, "")
+	var list scim.SCIMListResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, 1, list.TotalResults)
+	w = request(http.MethodGet, `/Users?filter=userName%20co%20%22john%22`, "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	service.EXPECT().GetResource(gomock.Any(), testToken, scim.ResourceTypeGroup, "group").Return(group, nil).Times(2)
+	service.EXPECT().ReplaceResource(gomock.Any(), testToken, gomock.Any()).DoAndReturn(func(ctx context.Context, token *scim.Token, resource *scim.Resource) (*scim.Resource, error) {
+		assert.Equal(t, []string{"john"}, resource.Members)
+		return resource, nil
+	})
+	w = request(http.MethodPatch, "/Groups/group", `{"schemas":["`+scim.SchemaPatchOp+`"],"Operations":[{"op":"add","path":"members","value":[{"value":"john"}]}]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	service.EXPECT().ReplaceResource(gomock.Any(), testToken, gomock.Any()).DoAndReturn(func(ctx context.Context, token *scim.Token, resource *scim.Resource) (*scim.Resource, error) {
+		assert.Empty(t, resource.Members)
+		return resource, nil
+	})
+	w = request(http.MethodPatch, "/Groups/group", `{"Operations":[{"op":"remove","path":"members[value eq \"john\"]"}]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	service.EXPECT().GetResource(gomock.Any(), testToken, scim.ResourceTypeUser, "john").Return(john, nil)
+	service.EXPECT().ReplaceResource(gomock.Any(), testToken, gomock.Any()).DoAndReturn(func(ctx context.Context, token *scim.Token, resource *scim.Resource) (*scim.Resource, error) {
+		assert.False(t, resource.Active)
+		return nil, signatures.NewForbiddenError("not authorized to update the approval list")
+	})
+	w = request(http.MethodPatch, "/Users/john", `{"Operations":[{"op":"Replace","value":{"active":"False"}}]}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// the token is revoked when the sync finds its creator is no longer a CLA manager
+	service.EXPECT().DeleteResource(gomock.Any(), testToken, scim.ResourceTypeUser, "john").Return(scim.ErrInvalidToken)
+	w = request(http.MethodDelete, "/Users/john", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	service.EXPECT().DeleteResource(gomock.Any(), testToken, scim.ResourceTypeUser, "john").Return(nil)
+	w = request(http.MethodDelete, "/Users/john", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	service.EXPECT().GetResource(gomock.Any(), testToken, scim.ResourceTypeUser, "john").Return(nil, scim.ErrResourceNotFound)
+	w = request(http.MethodGet, "/Users/john", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
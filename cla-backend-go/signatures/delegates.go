@@ -0,0 +1,98 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"time"
+
+	v2Models "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// delegate scopes
+const (
+	// DelegateScopeApprovalList allows the delegate to view and update the approval list
+	DelegateScopeApprovalList = "approval_list"
+	// DelegateScopeViewOnly allows the delegate to view the approval list
+	DelegateScopeViewOnly = "view_only"
+)
+
+// Delegate is a user a CLA manager delegated approval list permissions to, the delegates are stored alongside the
+// signature ACL of the CCLA signature
+type Delegate struct {
+	LfUsername  string `json:"lf_username"`
+	Name        string `json:"name,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Scope       string `json:"scope"`
+	DateExpires string `json:"date_expires"`
+	GrantedBy   string `json:"granted_by"`
+	DateCreated string `json:"date_created"`
+}
+
+// DBDelegatesModel is a database model for only the delegates column
+type DBDelegatesModel struct {
+	SignatureID string     `json:"signature_id"`
+	Delegates   []Delegate `json:"signature_acl_delegates"`
+}
+
+// IsValidDelegateScope returns true if the scope is one of the delegate scopes
+func IsValidDelegateScope(scope string) bool {
+	return scope == DelegateScopeApprovalList || scope == DelegateScopeViewOnly
+}
+
+// IsActive returns true if the delegation did not expire yet, a delegation with an invalid expiry is not active
+func (d Delegate) IsActive(now time.Time) bool {
+	expires, err := time.Parse(time.RFC3339, d.DateExpires)
+	if err != nil {
+		return false
+	}
+	return now.Before(expires)
+}
+
+// Allows returns true if the delegation is active and grants one of the scopes
+func (d Delegate) Allows(now time.Time, scopes ...string) bool {
+	if !d.IsActive(now) {
+		return false
+	}
+	for _, scope := range scopes {
+		if d.Scope == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ToModel converts the delegate to the v2 response model
+func (d Delegate) ToModel(now time.Time) *v2Models.ClaManagerDelegate {
+	return &v2Models.ClaManagerDelegate{
+		LfUsername:  d.LfUsername,
+		Name:        d.Name,
+		Email:       d.Email,
+		Scope:       d.Scope,
+		DateExpires: d.DateExpires,
+		Active:      d.IsActive(now),
+		GrantedBy:   d.GrantedBy,
+		DateCreated: d.DateCreated,
+	}
+}
+
+// FindDelegate returns the delegation of the user, nil if the user is not a delegate
+func FindDelegate(delegates []Delegate, lfUsername string) *Delegate {
+	for i := range delegates {
+		if delegates[i].LfUsername == lfUsername {
+			return &delegates[i]
+		}
+	}
+	return nil
+}
+
+// ActiveDelegates returns the delegations which did not expire yet
+func ActiveDelegates(delegates []Delegate, now time.Time) []Delegate {
+	active := make([]Delegate, 0, len(delegates))
+	for _, delegate := range delegates {
+		if delegate.IsActive(now) {
+			active = append(active, delegate)
+		}
+	}
+	return active
+}
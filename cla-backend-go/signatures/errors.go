@@ -32,3 +32,6 @@ type ForbiddenError struct {
 func (e ForbiddenError) Error() string {
 	return e.s
 }
+
+// ErrLastCLAManager is returned when removing the only CLA manager of a signature, a successor must be added first
+var ErrLastCLAManager = NewBadRequestError("unable to remove the last CLA manager of the signature - add another CLA manager first")
@@ -152,6 +152,20 @@ func Configure(api *operations.ClaAPI, service SignatureService, sessionStore *d
 	api.SignaturesGetGitHubOrgWhitelistHandler = signatures.GetGitHubOrgWhitelistHandlerFunc(func(params signatures.GetGitHubOrgWhitelistParams, claUser *user.CLAUser) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
 		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		allowed, aclErr := isSignatureManagerOrDelegate(ctx, service, params.SignatureID, claUser, DelegateScopeViewOnly, DelegateScopeApprovalList)
+		if !allowed {
+			msg := fmt.Sprintf("EasyCLA - 403 Forbidden - user %s / %s is not a CLA Manager or approval list delegate of signature ID: %s",
+				claUser.LFUsername, claUser.LFEmail, params.SignatureID)
+			if aclErr != nil {
+				msg = fmt.Sprintf("%s - %v", msg, aclErr)
+			}
+			log.Warn(msg)
+			return signatures.NewGetGitHubOrgWhitelistForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: msg,
+			})
+		}
+
 		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
 		if err != nil {
 			log.Warnf("error retrieving session from the session store, error: %+v", err)
@@ -178,6 +192,20 @@ func Configure(api *operations.ClaAPI, service SignatureService, sessionStore *d
 	api.SignaturesAddGitHubOrgWhitelistHandler = signatures.AddGitHubOrgWhitelistHandlerFunc(func(params signatures.AddGitHubOrgWhitelistParams, claUser *user.CLAUser) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
 		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		allowed, aclErr := isSignatureManagerOrDelegate(ctx, service, params.SignatureID, claUser, DelegateScopeApprovalList)
+		if !allowed {
+			msg := fmt.Sprintf("EasyCLA - 403 Forbidden - user %s / %s is not a CLA Manager or approval list delegate of signature ID: %s",
+				claUser.LFUsername, claUser.LFEmail, params.SignatureID)
+			if aclErr != nil {
+				msg = fmt.Sprintf("%s - %v", msg, aclErr)
+			}
+			log.Warn(msg)
+			return signatures.NewAddGitHubOrgWhitelistForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: msg,
+			})
+		}
+
 		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
 		if err != nil {
 			log.Warnf("error retrieving session from the session store, error: %+v", err)
@@ -227,6 +255,20 @@ func Configure(api *operations.ClaAPI, service SignatureService, sessionStore *d
 		reqID := utils.GetRequestID(params.XREQUESTID)
 		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
 
+		allowed, aclErr := isSignatureManagerOrDelegate(ctx, service, params.SignatureID, claUser, DelegateScopeApprovalList)
+		if !allowed {
+			msg := fmt.Sprintf("EasyCLA - 403 Forbidden - user %s / %s is not a CLA Manager or approval list delegate of signature ID: %s",
+				claUser.LFUsername, claUser.LFEmail, params.SignatureID)
+			if aclErr != nil {
+				msg = fmt.Sprintf("%s - %v", msg, aclErr)
+			}
+			log.Warn(msg)
+			return signatures.NewDeleteGitHubOrgWhitelistForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: msg,
+			})
+		}
+
 		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
 		if err != nil {
 			log.Warnf("error retrieving session from the session store, error: %+v", err)
@@ -353,6 +395,23 @@ func Configure(api *operations.ClaAPI, service SignatureService, sessionStore *d
 	})
 }
 
+// isSignatureManagerOrDelegate returns true if the user is a CLA manager of the signature or holds an active
+// delegation with one of the scopes on it
+func isSignatureManagerOrDelegate(ctx context.Context, service SignatureService, signatureID string, claUser *user.CLAUser, scopes ...string) (bool, error) {
+	sigModel, err := service.GetSignature(ctx, signatureID)
+	if err != nil || sigModel == nil {
+		return false, err
+	}
+
+	for _, manager := range sigModel.SignatureACL {
+		if manager.UserID == claUser.UserID {
+			return true, nil
+		}
+	}
+
+	return service.IsAuthorizedDelegate(ctx, signatureID, claUser.LFUsername, scopes...)
+}
+
 type codedResponse interface {
 	Code() string
 }
@@ -46,6 +46,15 @@ func buildSignatureACLProjection() expression.ProjectionBuilder {
 	)
 }
 
+// buildSignatureDelegatesProjection is a helper function to build a signature delegates response/projection
+func buildSignatureDelegatesProjection() expression.ProjectionBuilder {
+	// These are the columns we want returned
+	return expression.NamesList(
+		expression.Name("signature_id"),
+		expression.Name("signature_acl_delegates"),
+	)
+}
+
 // buildCompanyIDProjection is a helper function to build a simple projection with the signature id and the company id
 func buildCompanyIDProjection() expression.ProjectionBuilder {
 	// These are the columns we want returned
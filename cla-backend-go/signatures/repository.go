@@ -29,6 +29,7 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
@@ -59,6 +60,7 @@ type SignatureRepository interface {
 	GetSignature(ctx context.Context, signatureID string) (*models.Signature, error)
 	GetIndividualSignature(ctx context.Context, claGroupID, userID string) (*models.Signature, error)
 	GetCorporateSignature(ctx context.Context, claGroupID, companyID string) (*models.Signature, error)
+	GetEmployeeSignature(ctx context.Context, claGroupID, companyID, userID string) (*models.Signature, error)
 	GetSignatureACL(ctx context.Context, signatureID string) ([]string, error)
 	GetProjectSignatures(ctx context.Context, params signatures.GetProjectSignaturesParams, pageSize int64) (*models.Signatures, error)
 	GetProjectCompanySignature(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*models.Signature, error)
@@ -72,6 +74,8 @@ type SignatureRepository interface {
 
 	AddCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error)
 	RemoveCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error)
+	GetSignatureDelegates(ctx context.Context, signatureID string) ([]Delegate, error)
+	UpdateSignatureDelegates(ctx context.Context, signatureID string, delegates []Delegate) error
 
 	removeColumn(ctx context.Context, signatureID, columnName string) (*models.Signature, error)
 
@@ -601,6 +605,67 @@ func (repo repository) GetCorporateSignature(ctx context.Context, claGroupID, co
 	return sigs[0], nil
 }
 
+// GetEmployeeSignature returns the employee acknowledgement signature record for the specified CLA Group, Company and User ID
+func (repo repository) GetEmployeeSignature(ctx context.Context, claGroupID, companyID, userID string) (*models.Signature, error) {
+	f := logrus.Fields{
+		"functionName":      "GetEmployeeSignature",
+		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
+		"tableName":         repo.signatureTableName,
+		"claGroupID":        claGroupID,
+		"companyID":         companyID,
+		"userID":            userID,
+		"signatureApproved": "true",
+		"signatureSigned":   "true",
+	}
+
+	// These are the keys we want to match for an employee signature with a given CLA Group and User ID
+	condition := expression.Key("signature_project_id").Equal(expression.Value(claGroupID)).
+		And(expression.Key("signature_reference_id").Equal(expression.Value(userID)))
+	filter := expression.Name("signature_user_ccla_company_id").Equal(expression.Value(companyID)).
+		And(expression.Name("signature_approved").Equal(expression.Value(aws.Bool(true)))).
+		And(expression.Name("signature_signed").Equal(expression.Value(aws.Bool(true))))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(filter).WithProjection(buildProjection()).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for employee signature query, error: %v", err)
+		return nil, err
+	}
+
+	// Assemble the query input parameters
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.signatureTableName),
+		IndexName:                 aws.String(SignatureProjectReferenceIndex),
+	}
+
+	// Loop until we find a match or run out of records
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.WithFields(f).Warnf("error retrieving employee signature, error: %v", errQuery)
+			return nil, errQuery
+		}
+
+		signatureList, modelErr := repo.buildProjectSignatureModels(ctx, results, claGroupID, DontLoadACLDetails)
+		if modelErr != nil {
+			log.WithFields(f).Warnf("error converting DB model to response model for signatures, error: %v", modelErr)
+			return nil, modelErr
+		}
+		if len(signatureList) > 0 {
+			return signatureList[0], nil
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			return nil, nil
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+}
+
 // GetSignatureACL returns the signature ACL for the specified signature id
 func (repo repository) GetSignatureACL(ctx context.Context, signatureID string) ([]string, error) {
 	f := logrus.Fields{
@@ -1660,23 +1725,18 @@ func (repo repository) RemoveCLAManager(ctx context.Context, signatureID, claMan
 		return nil, nil
 	}
 
-	// A bit of logic to determine if the manager is listed and to build the new list without the specified manager
-	found := false
-	var updateEntries []string
-	for _, manager := range aclEntries {
-		if claManagerID == manager {
-			found = true
-		} else {
-			updateEntries = append(updateEntries, manager)
-		}
-	}
-
-	if !found {
+	if !utils.StringInSlice(claManagerID, aclEntries) {
 		return nil, fmt.Errorf("manager ID: %s not found in signature ACL", claManagerID)
 	}
+	if len(aclEntries) == 1 {
+		log.WithFields(f).Warn("unable to remove the last CLA manager of the signature")
+		return nil, ErrLastCLAManager
+	}
 
 	_, now := utils.CurrentTime()
 
+	// The removal is conditional on the manager still being listed with at least one other manager, so concurrent
+	// removals can't leave the signature without a CLA manager
 	input := &dynamodb.UpdateItemInput{
 		Key: map[string]*dynamodb.AttributeValue{
 			"signature_id": {
@@ -1689,17 +1749,33 @@ func (repo repository) RemoveCLAManager(ctx context.Context, signatureID, claMan
 		},
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":a": {
-				SS: aws.StringSlice(updateEntries),
+				SS: aws.StringSlice([]string{claManagerID}),
+			},
+			":c": {
+				S: aws.String(claManagerID),
+			},
+			":one": {
+				N: aws.String("1"),
 			},
 			":m": {
 				S: aws.String(now),
 			},
 		},
-		UpdateExpression: aws.String("SET #A = :a, #M = :m"),
-		TableName:        aws.String(fmt.Sprintf("cla-%s-signatures", repo.stage)),
+		UpdateExpression:    aws.String("DELETE #A :a SET #M = :m"),
+		ConditionExpression: aws.String("contains(#A, :c) AND size(#A) > :one"),
+		TableName:           aws.String(fmt.Sprintf("cla-%s-signatures", repo.stage)),
 	}
 
 	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if aerr, ok := updateErr.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		// the ACL changed since it was loaded
+		aclEntries, err = repo.GetSignatureACL(ctx, signatureID)
+		if err == nil && !utils.StringInSlice(claManagerID, aclEntries) {
+			return nil, fmt.Errorf("manager ID: %s not found in signature ACL", claManagerID)
+		}
+		log.WithFields(f).Warn("unable to remove the last CLA manager of the signature")
+		return nil, ErrLastCLAManager
+	}
 	if updateErr != nil {
 		log.WithFields(f).Warnf("remove CLA manager - unable to remove ACL entry of '%s' for signature ID: %s, error: %v",
 			claManagerID, signatureID, updateErr)
@@ -1716,6 +1792,97 @@ func (repo repository) RemoveCLAManager(ctx context.Context, signatureID, claMan
 	return sigModel, nil
 }
 
+// GetSignatureDelegates returns the CLA manager delegates of the specified signature
+func (repo repository) GetSignatureDelegates(ctx context.Context, signatureID string) ([]Delegate, error) {
+	f := logrus.Fields{
+		"functionName":   "GetSignatureDelegates",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+	expr, err := expression.NewBuilder().
+		WithProjection(buildSignatureDelegatesProjection()).
+		Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for signature ID query, signatureID: %s, error: %v",
+			signatureID, err)
+		return nil, err
+	}
+
+	result, queryErr := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {S: aws.String(signatureID)},
+		},
+		ExpressionAttributeNames: expr.Names(),
+		ProjectionExpression:     expr.Projection(),
+		TableName:                aws.String(repo.signatureTableName),
+	})
+	if queryErr != nil {
+		log.WithFields(f).Warnf("error retrieving signature ID: %s, error: %v", signatureID, queryErr)
+		return nil, queryErr
+	}
+
+	// No match, didn't find it
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var dbModel DBDelegatesModel
+	unmarshallErr := dynamodbattribute.UnmarshalMap(result.Item, &dbModel)
+	if unmarshallErr != nil {
+		log.WithFields(f).Warnf("error converting DB model signature delegates using signature ID: %s, error: %v",
+			signatureID, unmarshallErr)
+		return nil, unmarshallErr
+	}
+
+	return dbModel.Delegates, nil
+}
+
+// UpdateSignatureDelegates replaces the CLA manager delegates of the specified signature
+func (repo repository) UpdateSignatureDelegates(ctx context.Context, signatureID string, delegates []Delegate) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateSignatureDelegates",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+	if delegates == nil {
+		delegates = []Delegate{}
+	}
+	delegatesValue, marshalErr := dynamodbattribute.Marshal(delegates)
+	if marshalErr != nil {
+		log.WithFields(f).Warnf("unable to marshal the delegates of signature ID: %s, error: %v", signatureID, marshalErr)
+		return marshalErr
+	}
+
+	_, now := utils.CurrentTime()
+	_, updateErr := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#D": aws.String("signature_acl_delegates"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":d": delegatesValue,
+			":m": {
+				S: aws.String(now),
+			},
+		},
+		// The signature must exist, we don't want to create a partial record
+		ConditionExpression: aws.String("attribute_exists(signature_id)"),
+		UpdateExpression:    aws.String("SET #D = :d, #M = :m"),
+		TableName:           aws.String(repo.signatureTableName),
+	})
+	if updateErr != nil {
+		log.WithFields(f).Warnf("unable to update the delegates of signature ID: %s, error: %v", signatureID, updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
 // UpdateApprovalList updates the specified project/company signature with the updated approval list information
 func (repo repository) UpdateApprovalList(ctx context.Context, projectID, companyID string, params *models.ApprovalList) (*models.Signature, error) { // nolint
 	f := logrus.Fields{
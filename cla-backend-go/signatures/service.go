@@ -7,7 +7,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 
@@ -18,7 +20,10 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/users"
 
 	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
 	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/domain_verification"
+	"github.com/communitybridge/easycla/cla-backend-go/feature_flags"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/signatures"
@@ -48,31 +53,48 @@ type SignatureService interface {
 	AddGithubOrganizationToWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken string) ([]models.GithubOrg, error)
 	DeleteGithubOrganizationFromWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken string) ([]models.GithubOrg, error)
 	UpdateApprovalList(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error)
+	ImportApprovalList(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error)
 
 	AddCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error)
 	RemoveCLAManager(ctx context.Context, ignatureID, claManagerID string) (*models.Signature, error)
+	GetSignatureDelegates(ctx context.Context, signatureID string) ([]Delegate, error)
+	UpdateSignatureDelegates(ctx context.Context, signatureID string, delegates []Delegate) error
+	IsAuthorizedDelegate(ctx context.Context, signatureID, lfUsername string, scopes ...string) (bool, error)
+	IsCompanyDelegate(ctx context.Context, companySFID, claGroupID, lfUsername string, scopes ...string) (bool, error)
 
 	GetClaGroupICLASignatures(ctx context.Context, claGroupID string, searchTerm *string) (*models.IclaSignatures, error)
 	GetClaGroupCCLASignatures(ctx context.Context, claGroupID string) (*models.Signatures, error)
 	GetClaGroupCorporateContributors(ctx context.Context, claGroupID string, companyID *string, searchTerm *string) (*models.CorporateContributorList, error)
 }
 
+// GithubOrgMembersService is the part of the github organization members cache service used to find the organizations
+// of the github user, including the private memberships
+type GithubOrgMembersService interface {
+	GetMemberOrganizations(ctx context.Context, githubUsername string) ([]string, error)
+}
+
 type service struct {
-	repo                SignatureRepository
-	companyService      company.IService
-	usersService        users.Service
-	eventsService       events.Service
-	githubOrgValidation bool
+	repo               SignatureRepository
+	companyService     company.IService
+	usersService       users.Service
+	eventsService      events.Service
+	featureFlags       feature_flags.Evaluator
+	domainVerification domain_verification.Service
+	approvalListExpiry approval_list_expiry.Service
+	githubOrgMembers   GithubOrgMembersService
 }
 
 // NewService creates a new whitelist service
-func NewService(repo SignatureRepository, companyService company.IService, usersService users.Service, eventsService events.Service, githubOrgValidation bool) SignatureService {
+func NewService(repo SignatureRepository, companyService company.IService, usersService users.Service, eventsService events.Service, featureFlags feature_flags.Evaluator, domainVerification domain_verification.Service, approvalListExpiry approval_list_expiry.Service, githubOrgMembers GithubOrgMembersService) SignatureService {
 	return service{
 		repo,
 		companyService,
 		usersService,
 		eventsService,
-		githubOrgValidation,
+		featureFlags,
+		domainVerification,
+		approvalListExpiry,
+		githubOrgMembers,
 	}
 }
 
@@ -211,7 +233,7 @@ func (s service) GetGithubOrganizationsFromWhitelist(ctx context.Context, signat
 
 		selectedOrgs := make(map[string]struct{}, len(orgIds))
 		for _, selectedOrg := range orgIds {
-			selectedOrgs[*selectedOrg.ID] = struct{}{}
+			selectedOrgs[strings.ToLower(*selectedOrg.ID)] = struct{}{}
 		}
 
 		// Since we're logged into github, lets get the list of organization we can add.
@@ -231,18 +253,58 @@ func (s service) GetGithubOrganizationsFromWhitelist(ctx context.Context, signat
 		}
 
 		for _, org := range orgs {
-			_, ok := selectedOrgs[*org.Login]
+			_, ok := selectedOrgs[strings.ToLower(*org.Login)]
 			if ok {
 				continue
 			}
 
+			selectedOrgs[strings.ToLower(*org.Login)] = struct{}{}
 			orgIds = append(orgIds, models.GithubOrg{ID: org.Login})
 		}
+
+		// The organizations listed with the user's token miss the private memberships of organizations which restrict
+		// the access of the OAuth app - the members cached with the github app cover them
+		githubUser, _, err := client.Users.Get(utils.NewContext(), "")
+		if err != nil {
+			return nil, err
+		}
+		memberOrgs, err := s.githubOrgMembers.GetMemberOrganizations(ctx, githubUser.GetLogin())
+		if err != nil {
+			log.Warnf("unable to load the cached github organizations of user: %s, error: %+v", githubUser.GetLogin(), err)
+			return orgIds, nil
+		}
+		for _, memberOrg := range memberOrgs {
+			if _, ok := selectedOrgs[strings.ToLower(memberOrg)]; ok {
+				continue
+			}
+
+			selectedOrgs[strings.ToLower(memberOrg)] = struct{}{}
+			orgIds = append(orgIds, models.GithubOrg{ID: aws.String(memberOrg)})
+		}
 	}
 
 	return orgIds, nil
 }
 
+// featureFlagScope returns the company and CLA group of the signature so the feature flags are evaluated for them -
+// if the signature can't be loaded the flags are evaluated without a scope
+func (s service) featureFlagScope(ctx context.Context, signatureID string) feature_flags.Scope {
+	f := logrus.Fields{
+		"functionName":   "featureFlagScope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+	sig, err := s.repo.GetSignature(ctx, signatureID)
+	if err != nil || sig == nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the signature - evaluating the feature flags without a scope")
+		return feature_flags.Scope{}
+	}
+	return feature_flags.Scope{
+		CompanyID:  sig.SignatureReferenceID.String(),
+		ClaGroupID: sig.ProjectID,
+	}
+}
+
 // AddGithubOrganizationToWhitelist adds the GH organization to the whitelist
 func (s service) AddGithubOrganizationToWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken string) ([]models.GithubOrg, error) {
 	organizationID := whiteListParams.OrganizationID
@@ -259,10 +321,10 @@ func (s service) AddGithubOrganizationToWhitelist(ctx context.Context, signature
 		return nil, errors.New(msg)
 	}
 
-	// GH_ORG_VALIDATION environment - set to false to test locally which will by-pass the GH auth checks and
-	// allow functional tests (e.g. with curl or postmon) - default is enabled
+	// github-org-validation feature flag - turn off to test locally which will by-pass the GH auth checks and
+	// allow functional tests (e.g. with curl or postmon) - default is enabled, see GH_ORG_VALIDATION
 
-	if s.githubOrgValidation {
+	if s.featureFlags.IsEnabled(ctx, feature_flags.GithubOrgValidation, s.featureFlagScope(ctx, signatureID)) {
 		// Verify the authenticated github user has access to the github organization being added.
 		if githubAccessToken == "" {
 			msg := fmt.Sprintf("unable to add github organization, not logged in using "+
@@ -331,10 +393,10 @@ func (s service) DeleteGithubOrganizationFromWhitelist(ctx context.Context, sign
 		return nil, errors.New(msg)
 	}
 
-	// GH_ORG_VALIDATION environment - set to false to test locally which will by-pass the GH auth checks and
-	// allow functional tests (e.g. with curl or postmon) - default is enabled
+	// github-org-validation feature flag - turn off to test locally which will by-pass the GH auth checks and
+	// allow functional tests (e.g. with curl or postmon) - default is enabled, see GH_ORG_VALIDATION
 
-	if s.githubOrgValidation {
+	if s.featureFlags.IsEnabled(ctx, feature_flags.GithubOrgValidation, s.featureFlagScope(ctx, signatureID)) {
 		// Verify the authenticated github user has access to the github organization being added.
 		if githubAccessToken == "" {
 			msg := fmt.Sprintf("unable to delete github organization, not logged in using "+
@@ -385,6 +447,16 @@ func (s service) DeleteGithubOrganizationFromWhitelist(ctx context.Context, sign
 
 // UpdateApprovalList service method
 func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error) {
+	return s.updateApprovalList(ctx, authUser, claGroupModel, companyModel, claGroupID, params, true)
+}
+
+// ImportApprovalList applies a batch of a bulk approval list import - same as UpdateApprovalList, but without an event
+// or an email per entry as the caller logs one summary event and notifies the CLA managers once for the whole import
+func (s service) ImportApprovalList(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error) {
+	return s.updateApprovalList(ctx, authUser, claGroupModel, companyModel, claGroupID, params, false)
+}
+
+func (s service) updateApprovalList(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, params *models.ApprovalList, notify bool) (*models.Signature, error) {
 	pageSize := int64(1)
 	signed, approved := true, true
 	sigModel, sigErr := s.GetProjectCompanySignature(ctx, companyModel.CompanyID, claGroupID, &signed, &approved, nil, &pageSize)
@@ -401,14 +473,21 @@ func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, cl
 		return nil, NewBadRequestError(msg)
 	}
 
-	// Ensure current user is in the Signature ACL
+	// Ensure current user is in the Signature ACL or is a delegate allowed to update the approval list
 	claManagers := sigModel.SignatureACL
 	if !utils.CurrentUserInACL(authUser, claManagers) {
-		msg := fmt.Sprintf("EasyCLA - 403 Forbidden - CLA Manager %s / %s is not authorized to approve request for company ID: %s / %s / %s, project ID: %s / %s / %s",
-			authUser.UserName, authUser.Email,
-			companyModel.CompanyName, companyModel.CompanyExternalID, companyModel.CompanyID,
-			claGroupModel.ProjectName, claGroupModel.ProjectExternalID, claGroupModel.ProjectID)
-		return nil, NewForbiddenError(msg)
+		isDelegate, delegateErr := s.IsAuthorizedDelegate(ctx, sigModel.SignatureID.String(), authUser.UserName, DelegateScopeApprovalList)
+		if delegateErr != nil {
+			log.Warnf("unable to load the delegates of signature: %s, error: %+v", sigModel.SignatureID, delegateErr)
+			return nil, delegateErr
+		}
+		if !isDelegate {
+			msg := fmt.Sprintf("EasyCLA - 403 Forbidden - CLA Manager %s / %s is not authorized to approve request for company ID: %s / %s / %s, project ID: %s / %s / %s",
+				authUser.UserName, authUser.Email,
+				companyModel.CompanyName, companyModel.CompanyExternalID, companyModel.CompanyID,
+				claGroupModel.ProjectName, claGroupModel.ProjectExternalID, claGroupModel.ProjectID)
+			return nil, NewForbiddenError(msg)
+		}
 	}
 
 	// Lookup the user making the request
@@ -417,13 +496,37 @@ func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, cl
 		return nil, userErr
 	}
 
-	updatedSig, err := s.repo.UpdateApprovalList(ctx, claGroupModel.ProjectID, companyModel.CompanyID, params)
+	// Domains only take effect once the company proved it owns them
+	if domainErr := s.domainVerification.CheckApprovalListDomains(ctx, companyModel.CompanyID, params.AddDomainApprovalList); domainErr != nil {
+		msg := fmt.Sprintf("unable to add domain to the approval list of company ID: %s - %v", companyModel.CompanyID, domainErr)
+		log.Warn(msg)
+		return nil, NewBadRequestError(msg)
+	}
+
+	// Entries which are not valid yet are added by the approval list expiry job
+	validity, validityErr := s.approvalListExpiry.ParseValidity(params.ValidFrom, params.ValidUntil)
+	if validityErr != nil {
+		return nil, NewBadRequestError(validityErr.Error())
+	}
+	activeParams := s.approvalListExpiry.ActiveApprovalList(params, validity)
+
+	updatedSig, err := s.repo.UpdateApprovalList(ctx, claGroupModel.ProjectID, companyModel.CompanyID, activeParams)
 	if err != nil {
 		return updatedSig, err
 	}
 
+	err = s.approvalListExpiry.RecordEntries(ctx, sigModel.SignatureID.String(), claGroupModel.ProjectID, companyModel.CompanyID, params, validity, authUser.UserName)
+	if err != nil {
+		log.Warnf("unable to record the validity of the approval list entries of signature: %s, error: %+v", sigModel.SignatureID, err)
+		return updatedSig, err
+	}
+
+	if !notify {
+		return updatedSig, nil
+	}
+
 	// Log Events
-	s.createEventLogEntries(companyModel, claGroupModel, userModel, params)
+	s.createEventLogEntries(companyModel, claGroupModel, userModel, activeParams)
 
 	// Send an email to the CLA Managers
 	for _, claManager := range claManagers {
@@ -432,7 +535,7 @@ func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, cl
 	}
 
 	// Send emails to contributors if email or GH username as added/removed
-	s.sendRequestAccessEmailToContributors(authUser, companyModel, claGroupModel, params)
+	s.sendRequestAccessEmailToContributors(authUser, companyModel, claGroupModel, activeParams)
 
 	return updatedSig, nil
 }
@@ -479,11 +582,52 @@ func (s service) AddCLAManager(ctx context.Context, signatureID, claManagerID st
 	return s.repo.AddCLAManager(ctx, signatureID, claManagerID)
 }
 
-// RemoveCLAManager removes the specified manager from the signature ACL list
+// RemoveCLAManager removes the specified manager from the signature ACL list, the last CLA manager can not be removed
 func (s service) RemoveCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error) {
 	return s.repo.RemoveCLAManager(ctx, signatureID, claManagerID)
 }
 
+// GetSignatureDelegates returns the CLA manager delegates of the signature
+func (s service) GetSignatureDelegates(ctx context.Context, signatureID string) ([]Delegate, error) {
+	return s.repo.GetSignatureDelegates(ctx, signatureID)
+}
+
+// UpdateSignatureDelegates replaces the CLA manager delegates of the signature
+func (s service) UpdateSignatureDelegates(ctx context.Context, signatureID string, delegates []Delegate) error {
+	return s.repo.UpdateSignatureDelegates(ctx, signatureID, delegates)
+}
+
+// IsAuthorizedDelegate returns true if the user holds an active delegation on the signature with one of the scopes
+func (s service) IsAuthorizedDelegate(ctx context.Context, signatureID, lfUsername string, scopes ...string) (bool, error) {
+	if lfUsername == "" {
+		return false, nil
+	}
+	delegates, err := s.repo.GetSignatureDelegates(ctx, signatureID)
+	if err != nil {
+		return false, err
+	}
+	delegate := FindDelegate(delegates, lfUsername)
+	return delegate != nil && delegate.Allows(time.Now().UTC(), scopes...), nil
+}
+
+// IsCompanyDelegate returns true if the user holds an active delegation with one of the scopes on the CCLA signature
+// of the company for the CLA group - used by the handlers for users without the Project|Organization scope
+func (s service) IsCompanyDelegate(ctx context.Context, companySFID, claGroupID, lfUsername string, scopes ...string) (bool, error) {
+	companyModel, err := s.companyService.GetCompanyByExternalID(ctx, companySFID)
+	if err != nil || companyModel == nil {
+		return false, err
+	}
+
+	signed, approved := true, true
+	pageSize := int64(1)
+	sigModel, err := s.repo.GetProjectCompanySignature(ctx, companyModel.CompanyID, claGroupID, &signed, &approved, nil, &pageSize)
+	if err != nil || sigModel == nil {
+		return false, err
+	}
+
+	return s.IsAuthorizedDelegate(ctx, sigModel.SignatureID.String(), lfUsername, scopes...)
+}
+
 // appendList is a helper function to generate the email content of the Approval List changes
 func appendList(approvalList []string, message string) string {
 	approvalListSummary := ""
@@ -509,6 +653,12 @@ func buildApprovalListSummary(approvalListChanges *models.ApprovalList) string {
 	approvalListSummary += appendList(approvalListChanges.AddGithubOrgApprovalList, "Added GithHub Organization:")
 	approvalListSummary += appendList(approvalListChanges.RemoveGithubOrgApprovalList, "Removed GitHub Organization:")
 	approvalListSummary += "</ul>"
+	if approvalListChanges.ValidFrom != "" {
+		approvalListSummary += fmt.Sprintf("<p>The added entries are valid from %s.</p>", approvalListChanges.ValidFrom)
+	}
+	if approvalListChanges.ValidUntil != "" {
+		approvalListSummary += fmt.Sprintf("<p>The added entries expire on %s.</p>", approvalListChanges.ValidUntil)
+	}
 	return approvalListSummary
 }
 
@@ -9,6 +9,7 @@ type DBProjectModel struct {
 	DateModified                     string                   `dynamodbav:"date_modified"`
 	ProjectExternalID                string                   `dynamodbav:"project_external_id"`
 	ProjectID                        string                   `dynamodbav:"project_id"`
+	FoundationSFID                   string                   `dynamodbav:"foundation_sfid"`
 	ProjectName                      string                   `dynamodbav:"project_name"`
 	Version                          string                   `dynamodbav:"version"`
 	ProjectCclaEnabled               bool                     `dynamodbav:"project_ccla_enabled"`
@@ -7,7 +7,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
@@ -25,6 +24,8 @@ import (
 var (
 	// ErrTemplateNotFound error
 	ErrTemplateNotFound = errors.New("template not found")
+	// ErrTemplateNotAvailable error
+	ErrTemplateNotAvailable = errors.New("template not available for the CLA group")
 )
 
 var (
@@ -106,13 +107,7 @@ func NewRepository(awsSession *session.Session, stage string) repository {
 func (r repository) GetTemplates() ([]models.Template, error) {
 	var templates []models.Template
 	for _, template := range templateMap {
-		// DEBUG
-		// Only show the LF style template in dev for now
-		if template.Name == "LF Style Template" && strings.ToLower(r.stage) != "dev" {
-			log.Debugf("Skipping '%s' template since we are in stage: %s - only shown in 'dev'", template.Name, r.stage)
-		} else {
-			templates = append(templates, template)
-		}
+		templates = append(templates, template)
 	}
 
 	return templates, nil
@@ -218,6 +213,7 @@ func (r repository) buildProjectModel(dbModel DBProjectModel) *models.ClaGroup {
 	return &models.ClaGroup{
 		ProjectID:               dbModel.ProjectID,
 		ProjectExternalID:       dbModel.ProjectExternalID,
+		FoundationSFID:          dbModel.FoundationSFID,
 		ProjectName:             dbModel.ProjectName,
 		ProjectACL:              dbModel.ProjectACL,
 		ProjectCCLAEnabled:      dbModel.ProjectCclaEnabled,
@@ -18,6 +18,7 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/communitybridge/easycla/cla-backend-go/docraptor"
+	"github.com/communitybridge/easycla/cla-backend-go/feature_flags"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -29,11 +30,14 @@ import (
 const (
 	claTypeICLA = "icla"
 	claTypeCCLA = "ccla"
+
+	// lfStyleTemplateName is only listed when the lf-style-template feature flag is on
+	lfStyleTemplateName = "LF Style Template"
 )
 
 // Service interface
 type Service interface {
-	GetTemplates(ctx context.Context) ([]models.Template, error)
+	GetTemplates(ctx context.Context, foundationSFID string) ([]models.Template, error)
 	CreateCLAGroupTemplate(ctx context.Context, claGroupID string, claGroupFields *models.CreateClaGroupTemplate) (models.TemplatePdfs, error)
 	CreateTemplatePreview(claGroupFields *models.CreateClaGroupTemplate, templateFor string) ([]byte, error)
 	GetCLATemplatePreview(ctx context.Context, claGroupID, claType string, watermark bool) ([]byte, error)
@@ -44,23 +48,26 @@ type service struct {
 	templateRepo    Repository
 	docraptorClient docraptor.Client
 	s3Client        *s3manager.Uploader
+	featureFlags    feature_flags.Evaluator
 }
 
 // NewService API call
-func NewService(stage string, templateRepo Repository, docraptorClient docraptor.Client, awsSession *session.Session) service {
+func NewService(stage string, templateRepo Repository, docraptorClient docraptor.Client, awsSession *session.Session, featureFlags feature_flags.Evaluator) service {
 	return service{
 		stage:           stage,
 		templateRepo:    templateRepo,
 		docraptorClient: docraptorClient,
 		s3Client:        s3manager.NewUploader(awsSession),
+		featureFlags:    featureFlags,
 	}
 }
 
-// GetTemplates API call
-func (s service) GetTemplates(ctx context.Context) ([]models.Template, error) {
+// GetTemplates API call - the foundation SFID, when provided, is used to evaluate the template feature flags
+func (s service) GetTemplates(ctx context.Context, foundationSFID string) ([]models.Template, error) {
 	f := logrus.Fields{
 		"functionName":   "GetTemplates",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"foundationSFID": foundationSFID,
 	}
 	log.WithFields(f).Debug("loading templates...")
 	templates, err := s.templateRepo.GetTemplates()
@@ -69,14 +76,21 @@ func (s service) GetTemplates(ctx context.Context) ([]models.Template, error) {
 		return nil, err
 	}
 
+	showLFStyleTemplate := s.featureFlags.IsEnabled(ctx, feature_flags.LFStyleTemplate, feature_flags.Scope{FoundationSFID: foundationSFID})
+
 	// Remove HTML from template
-	for i, template := range templates {
+	var response []models.Template
+	for _, template := range templates {
+		if template.Name == lfStyleTemplateName && !showLFStyleTemplate {
+			log.WithFields(f).Debugf("skipping '%s' template - the %s feature flag is off", template.Name, feature_flags.LFStyleTemplate)
+			continue
+		}
 		template.IclaHTMLBody = ""
 		template.CclaHTMLBody = ""
-		templates[i] = template
+		response = append(response, template)
 	}
 
-	return templates, nil
+	return response, nil
 }
 
 func (s service) CreateTemplatePreview(claGroupFields *models.CreateClaGroupTemplate, templateFor string) ([]byte, error) {
@@ -160,6 +174,12 @@ func (s service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		return models.TemplatePdfs{}, err
 	}
 
+	if template.Name == lfStyleTemplateName && !s.featureFlags.IsEnabled(ctx, feature_flags.LFStyleTemplate, feature_flags.Scope{FoundationSFID: claGroup.FoundationSFID, ClaGroupID: claGroupID}) {
+		log.WithFields(f).Warnf("the '%s' template is not available - the %s feature flag is off for foundation: %s",
+			template.Name, feature_flags.LFStyleTemplate, claGroup.FoundationSFID)
+		return models.TemplatePdfs{}, ErrTemplateNotAvailable
+	}
+
 	// Apply template fields
 	iclaTemplateHTML, cclaTemplateHTML, err := s.InjectProjectInformationIntoTemplate(template, claGroupFields.MetaFields)
 	if err != nil {
@@ -0,0 +1,54 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/communitybridge/easycla/cla-backend-go/docraptor"
+	"github.com/communitybridge/easycla/cla-backend-go/feature_flags"
+	featureFlagsMock "github.com/communitybridge/easycla/cla-backend-go/feature_flags/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/template"
+	"github.com/communitybridge/easycla/cla-backend-go/template/mock"
+)
+
+func TestLFStyleTemplateFoundationOverride(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	// The flag is off by default and only turned on for one foundation
+	flagsRepo := featureFlagsMock.NewMockRepository(ctrl)
+	flagsRepo.EXPECT().GetFeatureFlags(gomock.Any()).Return([]*feature_flags.FeatureFlag{
+		{FlagName: feature_flags.LFStyleTemplate, FoundationSFIDs: []string{"foundation-1"}},
+	}, nil)
+	featureFlags := feature_flags.NewService(flagsRepo, "dev", map[string]bool{feature_flags.LFStyleTemplate: false})
+
+	templateRepo := mock.NewMockRepository(ctrl)
+	templateRepo.EXPECT().GetTemplates().Return([]models.Template{
+		{ID: "apache", Name: "Apache Style"},
+		{ID: "lf-style", Name: "LF Style Template"},
+	}, nil).Times(2)
+	templateRepo.EXPECT().GetCLAGroup("cla-group-2").Return(&models.ClaGroup{ProjectID: "cla-group-2", FoundationSFID: "foundation-2"}, nil)
+	templateRepo.EXPECT().GetTemplate("lf-style").Return(models.Template{ID: "lf-style", Name: "LF Style Template"}, nil)
+
+	service := template.NewService("dev", templateRepo, docraptor.Client{}, session.Must(session.NewSession()), featureFlags)
+
+	templates, err := service.GetTemplates(ctx, "")
+	assert.Nil(t, err)
+	assert.Len(t, templates, 1)
+
+	templates, err = service.GetTemplates(ctx, "foundation-1")
+	assert.Nil(t, err)
+	assert.Len(t, templates, 2)
+
+	_, err = service.CreateCLAGroupTemplate(ctx, "cla-group-2", &models.CreateClaGroupTemplate{TemplateID: "lf-style"})
+	assert.Equal(t, template.ErrTemplateNotAvailable, err)
+}
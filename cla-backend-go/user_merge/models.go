@@ -0,0 +1,142 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package user_merge
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// User is the part of the user record holding the identities linked to the user
+type User struct {
+	UserID           string   `dynamodbav:"user_id" json:"user_id"`
+	UserName         string   `dynamodbav:"user_name" json:"user_name"`
+	UserExternalID   string   `dynamodbav:"user_external_id" json:"user_external_id"`
+	LFUsername       string   `dynamodbav:"lf_username" json:"lf_username"`
+	LFEmail          string   `dynamodbav:"lf_email" json:"lf_email"`
+	UserEmails       []string `dynamodbav:"user_emails" json:"user_emails"`
+	GithubID         string   `dynamodbav:"user_github_id" json:"user_github_id"`
+	GithubUsername   string   `dynamodbav:"user_github_username" json:"user_github_username"`
+	UserCompanyID    string   `dynamodbav:"user_company_id" json:"user_company_id"`
+	DateCreated      string   `dynamodbav:"date_created" json:"date_created"`
+	MergedIntoUserID string   `dynamodbav:"merged_into_user_id" json:"merged_into_user_id"`
+}
+
+// emails returns the LF email and the other email addresses of the user
+func (u *User) emails() []string {
+	var emails []string
+	if u.LFEmail != "" {
+		emails = append(emails, u.LFEmail)
+	}
+	return utils.AppendMissing(emails, u.UserEmails)
+}
+
+// Signature is the part of the individual and employee signature records used when merging users
+type Signature struct {
+	SignatureID            string `dynamodbav:"signature_id" json:"signature_id"`
+	SignatureProjectID     string `dynamodbav:"signature_project_id" json:"signature_project_id"`
+	SignatureReferenceID   string `dynamodbav:"signature_reference_id" json:"signature_reference_id"`
+	SignatureReferenceType string `dynamodbav:"signature_reference_type" json:"signature_reference_type"`
+	SignatureUserCompanyID string `dynamodbav:"signature_user_ccla_company_id" json:"signature_user_ccla_company_id"`
+	SignatureSigned        bool   `dynamodbav:"signature_signed" json:"signature_signed"`
+	SignatureApproved      bool   `dynamodbav:"signature_approved" json:"signature_approved"`
+}
+
+// isActive returns true if the signature is signed and approved
+func (s *Signature) isActive() bool {
+	return s.SignatureSigned && s.SignatureApproved
+}
+
+// key identifies what the signature covers - the ICLA of a CLA group, or the employee acknowledgement of a company
+// for a CLA group
+func (s *Signature) key() string {
+	return s.SignatureProjectID + "/" + s.SignatureUserCompanyID
+}
+
+// Requester is the user asking for the merge - an admin can merge any users, a contributor can only merge the
+// duplicate records of their own LF login
+type Requester struct {
+	LFUsername string
+	Email      string
+	Admin      bool
+}
+
+// DuplicateMergeReport lists the identities and the records moved from one duplicate user to the canonical user
+type DuplicateMergeReport struct {
+	UserID                string
+	UserName              string
+	IdentitiesLinked      []string
+	SignaturesMoved       []string
+	SignaturesInvalidated []string
+	CompanyACLUpdates     []string
+	SignatureACLUpdates   []string
+}
+
+// MergeReport is the result of merging the duplicate users into the canonical user, the changes are only planned
+// for a dry run
+type MergeReport struct {
+	UserID     string
+	UserName   string
+	LFUsername string
+	DryRun     bool
+	Duplicates []*DuplicateMergeReport
+}
+
+// DuplicateCandidate is a group of user records which likely belong to the same person
+type DuplicateCandidate struct {
+	SuggestedUserID string
+	Users           []DuplicateUser
+	Reasons         []string
+}
+
+// DuplicateUser is a user of the duplicate candidates
+type DuplicateUser struct {
+	UserID         string
+	UserName       string
+	LFUsername     string
+	GithubUsername string
+	Emails         []string
+}
+
+// ToModel converts the merge report to the API model
+func (r *MergeReport) ToModel() *models.UserMergeReport {
+	duplicates := make([]*models.UserMergeDuplicate, 0, len(r.Duplicates))
+	for _, duplicate := range r.Duplicates {
+		duplicates = append(duplicates, &models.UserMergeDuplicate{
+			UserID:                duplicate.UserID,
+			UserName:              duplicate.UserName,
+			IdentitiesLinked:      duplicate.IdentitiesLinked,
+			SignaturesMoved:       duplicate.SignaturesMoved,
+			SignaturesInvalidated: duplicate.SignaturesInvalidated,
+			CompanyACLUpdates:     duplicate.CompanyACLUpdates,
+			SignatureACLUpdates:   duplicate.SignatureACLUpdates,
+		})
+	}
+	return &models.UserMergeReport{
+		UserID:     r.UserID,
+		UserName:   r.UserName,
+		LfUsername: r.LFUsername,
+		DryRun:     r.DryRun,
+		Duplicates: duplicates,
+	}
+}
+
+// ToModel converts the duplicate candidate to the API model
+func (c *DuplicateCandidate) ToModel() *models.UserDuplicateCandidate {
+	users := make([]*models.UserDuplicate, 0, len(c.Users))
+	for _, user := range c.Users {
+		users = append(users, &models.UserDuplicate{
+			UserID:         user.UserID,
+			UserName:       user.UserName,
+			LfUsername:     user.LFUsername,
+			GithubUsername: user.GithubUsername,
+			Emails:         user.Emails,
+		})
+	}
+	return &models.UserDuplicateCandidate{
+		SuggestedUserID: c.SuggestedUserID,
+		Users:           users,
+		Reasons:         c.Reasons,
+	}
+}
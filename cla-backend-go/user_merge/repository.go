@@ -0,0 +1,489 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package user_merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// errors
+var (
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// ACLType identifies the table of the ACLs referencing a user by LF username
+type ACLType string
+
+// ACL types
+const (
+	ACLTypeCompany   ACLType = "company"
+	ACLTypeSignature ACLType = "signature"
+)
+
+// Repository interface defines the functions linking the identities of a user and moving the records of a user to
+// another user
+type Repository interface {
+	GetUser(ctx context.Context, userID string) (*User, error)
+	GetUsers(ctx context.Context) ([]*User, error)
+	UpdateUserIdentities(ctx context.Context, user *User) error
+	MarkUserMerged(ctx context.Context, userID, mergedIntoUserID string) error
+
+	GetUserSignatures(ctx context.Context, userID string) ([]*Signature, error)
+	UpdateSignatureReference(ctx context.Context, signatureID string, user *User) error
+	InvalidateSignature(ctx context.Context, signatureID, note string) error
+
+	GetACLsContaining(ctx context.Context, aclType ACLType, lfUsername string) (map[string][]string, error)
+	UpdateACL(ctx context.Context, aclType ACLType, id string, acl []string) error
+}
+
+// aclTable describes the table of an ACL type
+type aclTable struct {
+	tableName string
+	keyColumn string
+	aclColumn string
+}
+
+type repository struct {
+	stage              string
+	dynamoDBClient     *dynamodb.DynamoDB
+	userTableName      string
+	signatureTableName string
+	aclTables          map[ACLType]aclTable
+}
+
+// NewRepository creates a new instance of the user merge repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:              stage,
+		dynamoDBClient:     dynamodb.New(awsSession),
+		userTableName:      fmt.Sprintf("cla-%s-users", stage),
+		signatureTableName: fmt.Sprintf("cla-%s-signatures", stage),
+		aclTables: map[ACLType]aclTable{
+			ACLTypeCompany: {
+				tableName: fmt.Sprintf("cla-%s-companies", stage),
+				keyColumn: "company_id",
+				aclColumn: "company_acl",
+			},
+			ACLTypeSignature: {
+				tableName: fmt.Sprintf("cla-%s-signatures", stage),
+				keyColumn: "signature_id",
+				aclColumn: "signature_acl",
+			},
+		},
+	}
+}
+
+// GetUser returns the user record, merged users are returned as is
+func (repo *repository) GetUser(ctx context.Context, userID string) (*User, error) {
+	f := logrus.Fields{
+		"functionName":   "GetUser",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"userID":         userID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"user_id": {
+				S: aws.String(userID),
+			},
+		},
+		TableName: aws.String(repo.userTableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load user, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrUserNotFound
+	}
+
+	var user User
+	err = dynamodbattribute.UnmarshalMap(result.Item, &user)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling user table data, error: %v", err)
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUsers returns the users which have not been merged into another user
+func (repo *repository) GetUsers(ctx context.Context) ([]*User, error) {
+	f := logrus.Fields{
+		"functionName":   "GetUsers",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	filter := expression.AttributeNotExists(expression.Name("merged_into_user_id"))
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("problem building the users scan expression, error: %+v", err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.userTableName),
+	}
+
+	var users []*User
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.WithFields(f).Warnf("problem scanning the users, error: %+v", err)
+			return nil, err
+		}
+
+		var page []*User
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).Warnf("error unmarshalling users from database, error: %v", err)
+			return nil, err
+		}
+		users = append(users, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return users, nil
+}
+
+// UpdateUserIdentities stores the identities linked to the user - empty identities are left unchanged
+func (repo *repository) UpdateUserIdentities(ctx context.Context, user *User) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateUserIdentities",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"userID":         user.UserID,
+	}
+
+	_, now := utils.CurrentTime()
+	expressionAttributeNames := map[string]*string{
+		"#M": aws.String("date_modified"),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":m": {S: aws.String(now)},
+	}
+	updateExpression := "SET #M = :m"
+
+	for placeholder, column := range map[string]struct {
+		name  string
+		value string
+	}{
+		"N":  {"user_name", user.UserName},
+		"X":  {"user_external_id", user.UserExternalID},
+		"U":  {"lf_username", user.LFUsername},
+		"E":  {"lf_email", user.LFEmail},
+		"G":  {"user_github_id", user.GithubID},
+		"GU": {"user_github_username", user.GithubUsername},
+		"C":  {"user_company_id", user.UserCompanyID},
+	} {
+		if column.value == "" {
+			continue
+		}
+		expressionAttributeNames["#"+placeholder] = aws.String(column.name)
+		expressionAttributeValues[":"+strings.ToLower(placeholder)] = &dynamodb.AttributeValue{S: aws.String(column.value)}
+		updateExpression = fmt.Sprintf("%s, #%s = :%s", updateExpression, placeholder, strings.ToLower(placeholder))
+	}
+	// The emails are a string set which can't be empty
+	if len(user.UserEmails) > 0 {
+		expressionAttributeNames["#ES"] = aws.String("user_emails")
+		expressionAttributeValues[":es"] = &dynamodb.AttributeValue{SS: aws.StringSlice(user.UserEmails)}
+		updateExpression = updateExpression + ", #ES = :es"
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.userTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"user_id": {
+				S: aws.String(user.UserID),
+			},
+		},
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		UpdateExpression:          aws.String(updateExpression),
+		ConditionExpression:       aws.String("attribute_exists(user_id)"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem updating the identities of the user, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// MarkUserMerged turns the user into a tombstone redirecting to the canonical user - the identities are removed from
+// the tombstone so the lookups by LF username, GitHub ID, email and external ID only find the canonical user
+func (repo *repository) MarkUserMerged(ctx context.Context, userID, mergedIntoUserID string) error {
+	f := logrus.Fields{
+		"functionName":     "MarkUserMerged",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"userID":           userID,
+		"mergedIntoUserID": mergedIntoUserID,
+	}
+
+	_, now := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.userTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"user_id": {
+				S: aws.String(userID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#I":  aws.String("merged_into_user_id"),
+			"#D":  aws.String("date_merged"),
+			"#M":  aws.String("date_modified"),
+			"#X":  aws.String("user_external_id"),
+			"#U":  aws.String("lf_username"),
+			"#E":  aws.String("lf_email"),
+			"#ES": aws.String("user_emails"),
+			"#G":  aws.String("user_github_id"),
+			"#GU": aws.String("user_github_username"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":i": {S: aws.String(mergedIntoUserID)},
+			":d": {S: aws.String(now)},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression:    aws.String("SET #I = :i, #D = :d, #M = :m REMOVE #X, #U, #E, #ES, #G, #GU"),
+		ConditionExpression: aws.String("attribute_exists(user_id)"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem marking the user as merged, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// GetUserSignatures returns the individual and employee signatures of the user
+func (repo *repository) GetUserSignatures(ctx context.Context, userID string) ([]*Signature, error) {
+	f := logrus.Fields{
+		"functionName":   "GetUserSignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"userID":         userID,
+	}
+
+	condition := expression.Key("signature_reference_id").Equal(expression.Value(userID))
+	filter := expression.Name("signature_reference_type").Equal(expression.Value("user"))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(filter).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("problem building the signatures query expression, error: %+v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.signatureTableName),
+		IndexName:                 aws.String("reference-signature-index"),
+	}
+
+	var signatures []*Signature
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.WithFields(f).Warnf("problem loading the signatures of the user, error: %+v", err)
+			return nil, err
+		}
+
+		var page []*Signature
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).Warnf("error unmarshalling signatures from database, error: %v", err)
+			return nil, err
+		}
+		signatures = append(signatures, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return signatures, nil
+}
+
+// UpdateSignatureReference points the signature to the user
+func (repo *repository) UpdateSignatureReference(ctx context.Context, signatureID string, user *User) error {
+	f := logrus.Fields{
+		"functionName":   "UpdateSignatureReference",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+		"userID":         user.UserID,
+	}
+
+	_, now := utils.CurrentTime()
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#R": aws.String("signature_reference_id"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":r": {S: aws.String(user.UserID)},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression: aws.String("SET #R = :r, #M = :m"),
+	}
+	if user.UserName != "" {
+		input.ExpressionAttributeNames["#N"] = aws.String("signature_reference_name")
+		input.ExpressionAttributeNames["#L"] = aws.String("signature_reference_name_lower")
+		input.ExpressionAttributeValues[":n"] = &dynamodb.AttributeValue{S: aws.String(user.UserName)}
+		input.ExpressionAttributeValues[":l"] = &dynamodb.AttributeValue{S: aws.String(strings.ToLower(user.UserName))}
+		input.UpdateExpression = aws.String("SET #R = :r, #N = :n, #L = :l, #M = :m")
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.WithFields(f).Warnf("problem updating the reference of the signature, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// InvalidateSignature clears the approved flag of the signature and records the reason in the signature note
+func (repo *repository) InvalidateSignature(ctx context.Context, signatureID, note string) error {
+	f := logrus.Fields{
+		"functionName":   "InvalidateSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	_, now := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#A": aws.String("signature_approved"),
+			"#N": aws.String("note"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":a": {BOOL: aws.Bool(false)},
+			":n": {S: aws.String(note)},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression: aws.String("SET #A = :a, #N = :n, #M = :m"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem invalidating the signature, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// GetACLsContaining returns the ACLs which contain the LF username by record ID
+func (repo *repository) GetACLsContaining(ctx context.Context, aclType ACLType, lfUsername string) (map[string][]string, error) {
+	table := repo.aclTables[aclType]
+	f := logrus.Fields{
+		"functionName":   "GetACLsContaining",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      table.tableName,
+		"lfUsername":     lfUsername,
+	}
+
+	filter := expression.Name(table.aclColumn).Contains(lfUsername)
+	projection := expression.NamesList(expression.Name(table.keyColumn), expression.Name(table.aclColumn))
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(projection).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("problem building the ACL scan expression, error: %+v", err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(table.tableName),
+	}
+
+	acls := make(map[string][]string)
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.WithFields(f).Warnf("problem scanning the ACLs, error: %+v", err)
+			return nil, err
+		}
+
+		for _, item := range results.Items {
+			var id string
+			var acl []string
+			if err = dynamodbattribute.Unmarshal(item[table.keyColumn], &id); err != nil {
+				return nil, err
+			}
+			if err = dynamodbattribute.Unmarshal(item[table.aclColumn], &acl); err != nil {
+				return nil, err
+			}
+			acls[id] = acl
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return acls, nil
+}
+
+// UpdateACL stores the ACL of the company or signature
+func (repo *repository) UpdateACL(ctx context.Context, aclType ACLType, id string, acl []string) error {
+	table := repo.aclTables[aclType]
+	f := logrus.Fields{
+		"functionName":   "UpdateACL",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      table.tableName,
+		"id":             id,
+	}
+
+	_, now := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(table.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			table.keyColumn: {
+				S: aws.String(id),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#A": aws.String(table.aclColumn),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":a": {SS: aws.StringSlice(acl)},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression: aws.String("SET #A = :a, #M = :m"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem updating the ACL, error: %+v", err)
+		return err
+	}
+	return nil
+}
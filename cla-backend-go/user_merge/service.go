@@ -0,0 +1,368 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package user_merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// errors
+var (
+	ErrNoDuplicateUsers      = errors.New("no duplicate users to merge")
+	ErrUserAlreadyMerged     = errors.New("user was already merged into another user")
+	ErrConflictingIdentities = errors.New("users are linked to different GitHub accounts")
+	ErrMergeNotAllowed       = errors.New("only the owner of the user records or an admin can merge the users")
+)
+
+// Service interface defines the user merge functions
+type Service interface {
+	MergeUsers(ctx context.Context, userID string, duplicateUserIDs []string, requester Requester, dryRun bool) (*MergeReport, error)
+	GetDuplicateCandidates(ctx context.Context) ([]*DuplicateCandidate, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+// NewService creates a new user merge service
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+// MergeUsers links the identities of the duplicate users to the canonical user and points the signatures and the
+// ACLs of the duplicates to the canonical user, then turns the duplicates into tombstones redirecting to the canonical
+// user. An active signature of a duplicate is invalidated if the canonical user already has an active signature for
+// the same CLA group and company. Nothing is changed for a dry run. A contributor can only merge the records without
+// an LF login which share their LF email address into the record of their LF login.
+func (s *service) MergeUsers(ctx context.Context, userID string, duplicateUserIDs []string, requester Requester, dryRun bool) (*MergeReport, error) {
+	f := logrus.Fields{
+		"functionName":     "MergeUsers",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"userID":           userID,
+		"duplicateUserIDs": strings.Join(duplicateUserIDs, ","),
+		"requester":        requester.LFUsername,
+		"dryRun":           dryRun,
+	}
+
+	canonical, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var duplicates []*User
+	for _, duplicateUserID := range utils.RemoveDuplicates(duplicateUserIDs) {
+		if duplicateUserID == userID {
+			continue
+		}
+		duplicate, loadErr := s.loadUser(ctx, duplicateUserID)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+	if len(duplicates) == 0 {
+		return nil, ErrNoDuplicateUsers
+	}
+
+	for _, duplicate := range duplicates {
+		if !requester.Admin && !isOwnDuplicate(requester, canonical, duplicate) {
+			return nil, ErrMergeNotAllowed
+		}
+		if canonical.GithubID != "" && duplicate.GithubID != "" && canonical.GithubID != duplicate.GithubID {
+			return nil, fmt.Errorf("%s : %w", duplicate.UserID, ErrConflictingIdentities)
+		}
+	}
+
+	// The active signatures of the canonical user, the signatures of the duplicates covering the same CLA group and
+	// company are invalidated
+	canonicalSignatures, err := s.repo.GetUserSignatures(ctx, canonical.UserID)
+	if err != nil {
+		return nil, err
+	}
+	activeSignatures := make(map[string]*Signature)
+	for _, signature := range canonicalSignatures {
+		if signature.isActive() {
+			activeSignatures[signature.key()] = signature
+		}
+	}
+
+	report := &MergeReport{
+		UserID: canonical.UserID,
+		DryRun: dryRun,
+	}
+	for _, duplicate := range duplicates {
+		log.WithFields(f).Debugf("merging user %s (%s) into user %s (%s)", duplicate.UserName, duplicate.UserID, canonical.UserName, canonical.UserID)
+		duplicateReport := &DuplicateMergeReport{
+			UserID:   duplicate.UserID,
+			UserName: duplicate.UserName,
+		}
+		report.Duplicates = append(report.Duplicates, duplicateReport)
+
+		duplicateReport.IdentitiesLinked = linkIdentities(canonical, duplicate)
+
+		err = s.moveSignatures(ctx, duplicate, canonical, activeSignatures, duplicateReport, dryRun)
+		if err != nil {
+			return nil, err
+		}
+
+		// The ACLs hold LF usernames - only a duplicate with its own LF login is referenced by an ACL
+		if duplicate.LFUsername != "" && duplicate.LFUsername != canonical.LFUsername {
+			duplicateReport.CompanyACLUpdates, err = s.replaceACLUser(ctx, ACLTypeCompany, duplicate.LFUsername, canonical.LFUsername, dryRun)
+			if err != nil {
+				return nil, err
+			}
+			duplicateReport.SignatureACLUpdates, err = s.replaceACLUser(ctx, ACLTypeSignature, duplicate.LFUsername, canonical.LFUsername, dryRun)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !dryRun {
+			// Link the identities first, a failure before the tombstone is written leaves both records usable and
+			// the merge can be run again
+			err = s.repo.UpdateUserIdentities(ctx, canonical)
+			if err != nil {
+				return nil, err
+			}
+			err = s.repo.MarkUserMerged(ctx, duplicate.UserID, canonical.UserID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	report.UserName = canonical.UserName
+	report.LFUsername = canonical.LFUsername
+	return report, nil
+}
+
+// loadUser returns the user, an error if the user was already merged into another user
+func (s *service) loadUser(ctx context.Context, userID string) (*User, error) {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MergedIntoUserID != "" {
+		return nil, fmt.Errorf("%s : %w", userID, ErrUserAlreadyMerged)
+	}
+	return user, nil
+}
+
+// isOwnDuplicate returns true if the requester owns both user records - the canonical user is the record of the LF
+// login of the requester and the duplicate has no LF login but one of its emails is the LF email of the requester
+func isOwnDuplicate(requester Requester, canonical, duplicate *User) bool {
+	if requester.LFUsername == "" || requester.Email == "" || canonical.LFUsername != requester.LFUsername || duplicate.LFUsername != "" {
+		return false
+	}
+	for _, email := range duplicate.emails() {
+		if strings.EqualFold(email, requester.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+// linkIdentities copies the identities of the duplicate the canonical user doesn't have yet, returns the linked
+// identities
+func linkIdentities(canonical, duplicate *User) []string {
+	var linked []string
+	for _, identity := range []struct {
+		name      string
+		canonical *string
+		duplicate string
+	}{
+		{"lf_username", &canonical.LFUsername, duplicate.LFUsername},
+		{"lf_email", &canonical.LFEmail, duplicate.LFEmail},
+		{"github_id", &canonical.GithubID, duplicate.GithubID},
+		{"github_username", &canonical.GithubUsername, duplicate.GithubUsername},
+		{"external_id", &canonical.UserExternalID, duplicate.UserExternalID},
+		{"user_name", &canonical.UserName, duplicate.UserName},
+		{"company_id", &canonical.UserCompanyID, duplicate.UserCompanyID},
+	} {
+		if *identity.canonical == "" && identity.duplicate != "" {
+			*identity.canonical = identity.duplicate
+			linked = append(linked, fmt.Sprintf("%s:%s", identity.name, identity.duplicate))
+		}
+	}
+
+	known := canonical.emails()
+	for _, email := range duplicate.emails() {
+		if !containsFold(known, email) {
+			known = append(known, email)
+			canonical.UserEmails = append(canonical.UserEmails, email)
+			linked = append(linked, "email:"+email)
+		}
+	}
+	return linked
+}
+
+// moveSignatures points the signatures of the duplicate user to the canonical user - an active signature is
+// invalidated if the canonical user already has an active signature for the same CLA group and company
+func (s *service) moveSignatures(ctx context.Context, duplicate, canonical *User, activeSignatures map[string]*Signature, report *DuplicateMergeReport, dryRun bool) error {
+	signatures, err := s.repo.GetUserSignatures(ctx, duplicate.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, signature := range signatures {
+		if existing, ok := activeSignatures[signature.key()]; ok && signature.isActive() {
+			if !dryRun {
+				err = s.repo.InvalidateSignature(ctx, signature.SignatureID, fmt.Sprintf("user %s merged into user %s which has signature %s", duplicate.UserID, canonical.UserID, existing.SignatureID))
+				if err != nil {
+					return err
+				}
+			}
+			report.SignaturesInvalidated = append(report.SignaturesInvalidated, signature.SignatureID)
+		}
+
+		// Invalidated signatures are moved too, the history of the person is kept on one user
+		if !dryRun {
+			err = s.repo.UpdateSignatureReference(ctx, signature.SignatureID, canonical)
+			if err != nil {
+				return err
+			}
+		}
+		if _, ok := activeSignatures[signature.key()]; !ok && signature.isActive() {
+			activeSignatures[signature.key()] = signature
+		}
+		report.SignaturesMoved = append(report.SignaturesMoved, signature.SignatureID)
+	}
+	return nil
+}
+
+// replaceACLUser replaces the LF username of the duplicate with the LF username of the canonical user in the ACLs of
+// the type, returns the IDs of the updated records
+func (s *service) replaceACLUser(ctx context.Context, aclType ACLType, duplicateUsername, canonicalUsername string, dryRun bool) ([]string, error) {
+	acls, err := s.repo.GetACLsContaining(ctx, aclType, duplicateUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []string
+	for id, acl := range acls {
+		var replaced []string
+		for _, username := range acl {
+			if username != duplicateUsername {
+				replaced = append(replaced, username)
+			}
+		}
+		replaced = utils.AppendMissing(replaced, []string{canonicalUsername})
+		if !dryRun {
+			err = s.repo.UpdateACL(ctx, aclType, id, replaced)
+			if err != nil {
+				return nil, err
+			}
+		}
+		updated = append(updated, id)
+	}
+	sort.Strings(updated)
+	return updated, nil
+}
+
+// containsFold returns true if the list contains the value ignoring case
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDuplicateCandidates returns the groups of users sharing an email address, a GitHub account or an LF login
+func (s *service) GetDuplicateCandidates(ctx context.Context) ([]*DuplicateCandidate, error) {
+	f := logrus.Fields{
+		"functionName":   "GetDuplicateCandidates",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	users, err := s.repo.GetUsers(ctx)
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the users, error: %+v", err)
+		return nil, err
+	}
+
+	// Group the users sharing an LF login, a GitHub account or an email address
+	duplicateGroups := utils.NewDuplicateGroups()
+	userByID := make(map[string]*User)
+	for _, user := range users {
+		userByID[user.UserID] = user
+		var keys []string
+		if user.LFUsername != "" {
+			keys = append(keys, "lf_username:"+user.LFUsername)
+		}
+		if user.GithubID != "" {
+			keys = append(keys, "github_id:"+user.GithubID)
+		}
+		if user.GithubUsername != "" {
+			keys = append(keys, "github_username:"+strings.ToLower(user.GithubUsername))
+		}
+		for _, email := range user.emails() {
+			keys = append(keys, "email:"+strings.ToLower(strings.TrimSpace(email)))
+		}
+		duplicateGroups.Add(user.UserID, keys...)
+	}
+	userGroups, reasons := duplicateGroups.Groups()
+
+	var candidates []*DuplicateCandidate
+	for index, userIDs := range userGroups {
+		group := make([]*User, 0, len(userIDs))
+		for _, userID := range userIDs {
+			group = append(group, userByID[userID])
+		}
+		sort.Slice(group, func(i, j int) bool {
+			return isPreferredCanonical(group[i], group[j])
+		})
+		candidate := &DuplicateCandidate{
+			SuggestedUserID: group[0].UserID,
+			Reasons:         reasons[index],
+		}
+		for _, user := range group {
+			candidate.Users = append(candidate.Users, DuplicateUser{
+				UserID:         user.UserID,
+				UserName:       user.UserName,
+				LFUsername:     user.LFUsername,
+				GithubUsername: user.GithubUsername,
+				Emails:         user.emails(),
+			})
+		}
+		sort.Strings(candidate.Reasons)
+		candidates = append(candidates, candidate)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].SuggestedUserID < candidates[j].SuggestedUserID
+	})
+	return candidates, nil
+}
+
+// isPreferredCanonical returns true if the first user should be the canonical user rather than the second one - the
+// users with an LF login first, then the users with a GitHub account, then the oldest user
+func isPreferredCanonical(first, second *User) bool {
+	if (first.LFUsername != "") != (second.LFUsername != "") {
+		return first.LFUsername != ""
+	}
+	if (first.GithubID != "") != (second.GithubID != "") {
+		return first.GithubID != ""
+	}
+	// The records created before the dates were tracked have no date
+	if (first.DateCreated != "") != (second.DateCreated != "") {
+		return first.DateCreated != ""
+	}
+	if first.DateCreated != second.DateCreated {
+		return first.DateCreated < second.DateCreated
+	}
+	return first.UserID < second.UserID
+}
@@ -0,0 +1,195 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package user_merge_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/user_merge"
+	"github.com/communitybridge/easycla/cla-backend-go/user_merge/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectUsers sets up the user lookups, each user is returned as a copy
+func expectUsers(repo *mock.MockRepository, users ...*user_merge.User) {
+	for _, user := range users {
+		copied := *user
+		repo.EXPECT().GetUser(gomock.Any(), user.UserID).Return(&copied, nil).AnyTimes()
+	}
+}
+
+// mergeFixtures sets up a user with an LF login and an ICLA and a user with a GitHub account, two ICLAs and an
+// employee acknowledgement
+func mergeFixtures(repo *mock.MockRepository) {
+	expectUsers(repo,
+		&user_merge.User{UserID: "lf-user", UserName: "Jane Doe", LFUsername: "jdoe", LFEmail: "jane@example.com"},
+		&user_merge.User{UserID: "github-user", GithubID: "1234", GithubUsername: "janedoe", UserEmails: []string{"Jane@example.com", "jane@users.noreply.github.com"}},
+	)
+	repo.EXPECT().GetUserSignatures(gomock.Any(), "lf-user").Return([]*user_merge.Signature{
+		{SignatureID: "lf-icla", SignatureProjectID: "cla-group-1", SignatureSigned: true, SignatureApproved: true},
+	}, nil)
+	repo.EXPECT().GetUserSignatures(gomock.Any(), "github-user").Return([]*user_merge.Signature{
+		{SignatureID: "github-icla-1", SignatureProjectID: "cla-group-1", SignatureSigned: true, SignatureApproved: true},
+		{SignatureID: "github-icla-2", SignatureProjectID: "cla-group-2", SignatureSigned: true, SignatureApproved: true},
+		{SignatureID: "github-ecla", SignatureProjectID: "cla-group-1", SignatureUserCompanyID: "company-1", SignatureSigned: true, SignatureApproved: true},
+	}, nil)
+}
+
+func TestMergeUsersDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	// nothing is changed for a dry run - any update is an unexpected call
+	mergeFixtures(repo)
+
+	report, err := user_merge.NewService(repo).MergeUsers(context.Background(), "lf-user", []string{"github-user"}, user_merge.Requester{Admin: true}, true)
+	assert.Nil(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, "jdoe", report.LFUsername)
+	assert.Len(t, report.Duplicates, 1)
+	duplicate := report.Duplicates[0]
+	assert.Equal(t, []string{"github_id:1234", "github_username:janedoe", "email:jane@users.noreply.github.com"}, duplicate.IdentitiesLinked)
+	assert.Equal(t, []string{"github-icla-1", "github-icla-2", "github-ecla"}, duplicate.SignaturesMoved)
+	assert.Equal(t, []string{"github-icla-1"}, duplicate.SignaturesInvalidated)
+}
+
+func TestMergeUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	mergeFixtures(repo)
+
+	var canonical *user_merge.User
+	repo.EXPECT().InvalidateSignature(gomock.Any(), "github-icla-1", gomock.Any()).Return(nil)
+	for _, signatureID := range []string{"github-icla-1", "github-icla-2", "github-ecla"} {
+		repo.EXPECT().UpdateSignatureReference(gomock.Any(), signatureID, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, signatureID string, user *user_merge.User) error {
+				assert.Equal(t, "lf-user", user.UserID)
+				return nil
+			})
+	}
+	gomock.InOrder(
+		repo.EXPECT().UpdateUserIdentities(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, user *user_merge.User) error {
+				copied := *user
+				canonical = &copied
+				return nil
+			}),
+		repo.EXPECT().MarkUserMerged(gomock.Any(), "github-user", "lf-user").Return(nil),
+	)
+
+	requester := user_merge.Requester{LFUsername: "jdoe", Email: "jane@example.com"}
+	_, err := user_merge.NewService(repo).MergeUsers(context.Background(), "lf-user", []string{"github-user"}, requester, false)
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, canonical) {
+		assert.Equal(t, "lf-user", canonical.UserID)
+		assert.Equal(t, "jdoe", canonical.LFUsername)
+		assert.Equal(t, "1234", canonical.GithubID)
+		assert.Equal(t, "janedoe", canonical.GithubUsername)
+		assert.Equal(t, []string{"jane@users.noreply.github.com"}, canonical.UserEmails)
+	}
+}
+
+func TestMergeUsersAlreadyMerged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	expectUsers(repo,
+		&user_merge.User{UserID: "lf-user", LFUsername: "jdoe"},
+		&user_merge.User{UserID: "github-user", GithubID: "1234", MergedIntoUserID: "lf-user"},
+	)
+
+	_, err := user_merge.NewService(repo).MergeUsers(context.Background(), "lf-user", []string{"github-user"}, user_merge.Requester{Admin: true}, false)
+	assert.True(t, errors.Is(err, user_merge.ErrUserAlreadyMerged))
+}
+
+func TestMergeUsersNotAllowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	expectUsers(repo,
+		&user_merge.User{UserID: "lf-user", UserName: "Jane Doe", LFUsername: "jdoe", LFEmail: "jane@example.com"},
+		&user_merge.User{UserID: "github-user", GithubID: "1234", GithubUsername: "janedoe", UserEmails: []string{"Jane@example.com"}},
+	)
+	service := user_merge.NewService(repo)
+
+	// the requester doesn't own the canonical user
+	_, err := service.MergeUsers(context.Background(), "lf-user", []string{"github-user"}, user_merge.Requester{LFUsername: "other", Email: "jane@example.com"}, false)
+	assert.Equal(t, user_merge.ErrMergeNotAllowed, err)
+
+	// the duplicate doesn't share the LF email of the requester
+	_, err = service.MergeUsers(context.Background(), "lf-user", []string{"github-user"}, user_merge.Requester{LFUsername: "jdoe", Email: "other@example.com"}, false)
+	assert.Equal(t, user_merge.ErrMergeNotAllowed, err)
+
+	_, err = service.MergeUsers(context.Background(), "lf-user", []string{"lf-user"}, user_merge.Requester{Admin: true}, false)
+	assert.Equal(t, user_merge.ErrNoDuplicateUsers, err)
+}
+
+func TestMergeUsersWithConflictingGitHubAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	expectUsers(repo,
+		&user_merge.User{UserID: "user-1", LFUsername: "jdoe", GithubID: "1"},
+		&user_merge.User{UserID: "user-2", LFUsername: "jdoe2", GithubID: "2"},
+	)
+
+	_, err := user_merge.NewService(repo).MergeUsers(context.Background(), "user-1", []string{"user-2"}, user_merge.Requester{Admin: true}, false)
+	assert.True(t, errors.Is(err, user_merge.ErrConflictingIdentities))
+}
+
+func TestMergeUsersReplacesACLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	expectUsers(repo,
+		&user_merge.User{UserID: "user-1", LFUsername: "jdoe"},
+		&user_merge.User{UserID: "user-2", LFUsername: "jdoe2"},
+	)
+	repo.EXPECT().GetUserSignatures(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	repo.EXPECT().GetACLsContaining(gomock.Any(), user_merge.ACLTypeCompany, "jdoe2").Return(map[string][]string{
+		"company-1": {"jdoe2", "other"},
+		"company-2": {"jdoe", "jdoe2"},
+	}, nil)
+	repo.EXPECT().GetACLsContaining(gomock.Any(), user_merge.ACLTypeSignature, "jdoe2").Return(map[string][]string{
+		"signature-1": {"jdoe2"},
+	}, nil)
+	repo.EXPECT().UpdateACL(gomock.Any(), user_merge.ACLTypeCompany, "company-1", []string{"other", "jdoe"}).Return(nil)
+	repo.EXPECT().UpdateACL(gomock.Any(), user_merge.ACLTypeCompany, "company-2", []string{"jdoe"}).Return(nil)
+	repo.EXPECT().UpdateACL(gomock.Any(), user_merge.ACLTypeSignature, "signature-1", []string{"jdoe"}).Return(nil)
+	repo.EXPECT().UpdateUserIdentities(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().MarkUserMerged(gomock.Any(), "user-2", "user-1").Return(nil)
+
+	report, err := user_merge.NewService(repo).MergeUsers(context.Background(), "user-1", []string{"user-2"}, user_merge.Requester{Admin: true}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"company-1", "company-2"}, report.Duplicates[0].CompanyACLUpdates)
+	assert.Equal(t, []string{"signature-1"}, report.Duplicates[0].SignatureACLUpdates)
+}
+
+func TestGetDuplicateCandidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().GetUsers(gomock.Any()).Return([]*user_merge.User{
+		{UserID: "github-user", GithubID: "1234", UserEmails: []string{"Jane@Example.com"}, DateCreated: "2019-01-01T00:00:00Z"},
+		{UserID: "lf-user", LFUsername: "jdoe", LFEmail: "jane@example.com", DateCreated: "2020-01-01T00:00:00Z"},
+		{UserID: "gerrit-user", LFEmail: "jane@example.com", GithubUsername: "other"},
+		{UserID: "other-user", LFUsername: "other", GithubUsername: "Other", DateCreated: "2021-01-01T00:00:00Z"},
+		{UserID: "unrelated-user", LFUsername: "unrelated"},
+	}, nil)
+
+	candidates, err := user_merge.NewService(repo).GetDuplicateCandidates(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, "lf-user", candidates[0].SuggestedUserID)
+	assert.Len(t, candidates[0].Users, 4)
+	assert.Equal(t, "other-user", candidates[0].Users[1].UserID)
+	assert.Equal(t, "github-user", candidates[0].Users[2].UserID)
+	assert.Equal(t, []string{"email:jane@example.com", "github_username:other"}, candidates[0].Reasons)
+}
@@ -19,4 +19,5 @@ type DBUser struct {
 	UserCompanyID      string   `json:"user_company_id"`
 	UserGithubUsername string   `json:"user_github_username"`
 	Note               string   `json:"note"`
+	MergedIntoUserID   string   `json:"merged_into_user_id"`
 }
@@ -27,10 +27,14 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 )
 
+// maxMergedUserRedirects limits the merged user tombstones followed by a single lookup
+const maxMergedUserRedirects = 5
+
 // UserRepository interface defines the functions for the users repository
 type UserRepository interface {
 	CreateUser(user *models.User) (*models.User, error)
 	Save(user *models.UserUpdate) (*models.User, error)
+	RemoveCompany(userID string) error
 	Delete(userID string) error
 	GetUser(userID string) (*models.User, error)
 	GetUserByLFUserName(lfUserName string) (*models.User, error)
@@ -345,6 +349,37 @@ func (repo repository) Save(user *models.UserUpdate) (*models.User, error) {
 	return newUserModel, err
 }
 
+// RemoveCompany removes the company of the specified user, the Save function only sets a new company
+func (repo repository) RemoveCompany(userID string) error {
+	f := logrus.Fields{
+		"functionName": "RemoveCompany",
+		"user_id":      userID,
+		"tableName":    repo.tableName,
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"user_id": {S: aws.String(userID)},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#C": aws.String("user_company_id"),
+			"#D": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":d": {S: aws.String(time.Now().UTC().Format(time.RFC3339))},
+		},
+		UpdateExpression:    aws.String("SET #D = :d REMOVE #C"),
+		ConditionExpression: aws.String("attribute_exists(user_id)"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to remove the company of the user, error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
 // Delete deletes the specified user
 func (repo repository) Delete(userID string) error {
 	// The table we're interested in
@@ -366,8 +401,30 @@ func (repo repository) Delete(userID string) error {
 	return nil
 }
 
-// GetUser retrieves the specified user using the user id
+// GetUser retrieves the specified user using the user id, a user merged into another user resolves to the user it
+// was merged into
 func (repo repository) GetUser(userID string) (*models.User, error) {
+	dbUserModel, err := repo.getDBUser(userID)
+	if err != nil || dbUserModel == nil {
+		return nil, err
+	}
+	for redirects := 0; dbUserModel.MergedIntoUserID != ""; redirects++ {
+		if redirects == maxMergedUserRedirects {
+			log.Warnf("too many merged user redirects, last user: %s", dbUserModel.UserID)
+			return nil, nil
+		}
+		log.Debugf("user %s was merged into user %s", dbUserModel.UserID, dbUserModel.MergedIntoUserID)
+		dbUserModel, err = repo.getDBUser(dbUserModel.MergedIntoUserID)
+		if err != nil || dbUserModel == nil {
+			return nil, err
+		}
+	}
+
+	return convertDBUserModel(*dbUserModel), nil
+}
+
+// getDBUser returns the user record based on the user id, merged users are not resolved
+func (repo repository) getDBUser(userID string) (*DBUser, error) {
 
 	// This is the key we want to match
 	condition := expression.Key("user_id").Equal(expression.Value(userID))
@@ -413,7 +470,7 @@ func (repo repository) GetUser(userID string) (*models.User, error) {
 		log.Warnf("retrieved %d results for the getUser(id) query when we should return 0 or 1", len(dbUserModels))
 	}
 
-	return convertDBUserModel(dbUserModels[0]), nil
+	return &dbUserModels[0], nil
 }
 
 // GetuserByLFUserName returns the user record associated with the LF Username value
@@ -846,6 +903,7 @@ func buildUserProjection() expression.ProjectionBuilder {
 		expression.Name("date_modified"),
 		expression.Name("version"),
 		expression.Name("note"),
+		expression.Name("merged_into_user_id"),
 	)
 }
 
@@ -865,6 +923,10 @@ func buildDBUserModels(results *dynamodb.ScanOutput) ([]models.User, error) {
 
 	// Covert the database models to a list of API response models
 	for _, dbUser := range dbUsers {
+		// Skip the tombstones of the users merged into another user
+		if dbUser.MergedIntoUserID != "" {
+			continue
+		}
 		users = append(users, *convertDBUserModel(dbUser))
 	}
 
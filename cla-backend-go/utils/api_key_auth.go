@@ -0,0 +1,47 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+)
+
+// APIKeyUserNamePrefix identifies users authenticated using an API key - LF usernames cannot contain a colon so
+// the prefix cannot clash with a real user
+const APIKeyUserNamePrefix = "api-key:"
+
+// APIKeyAuthorizer checks the scope of an API key
+type APIKeyAuthorizer interface {
+	IsAPIKeyAuthorizedForProject(apiKeyID, projectSFID string) bool
+}
+
+var apiKeyAuthorizer APIKeyAuthorizer
+
+// SetAPIKeyAuthorizer sets the authorizer used to check the scope of requests authenticated using an API key
+func SetAPIKeyAuthorizer(authorizer APIKeyAuthorizer) {
+	apiKeyAuthorizer = authorizer
+}
+
+// APIKeyUserName returns the user name of a user authenticated using the API key
+func APIKeyUserName(apiKeyID string) string {
+	return APIKeyUserNamePrefix + apiKeyID
+}
+
+// GetAPIKeyID returns the API key ID if the user was authenticated using an API key
+func GetAPIKeyID(user *auth.User) (string, bool) {
+	if user == nil || !strings.HasPrefix(user.UserName, APIKeyUserNamePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(user.UserName, APIKeyUserNamePrefix), true
+}
+
+// isAPIKeyAuthorizedForProject returns true if the API key scope includes the project
+func isAPIKeyAuthorizedForProject(apiKeyID, projectSFID string) bool {
+	if apiKeyAuthorizer == nil {
+		return false
+	}
+	return apiKeyAuthorizer.IsAPIKeyAuthorizedForProject(apiKeyID, projectSFID)
+}
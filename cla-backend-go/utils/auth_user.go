@@ -4,14 +4,20 @@
 package utils
 
 import (
+	"strings"
+
 	"github.com/LF-Engineering/lfx-kit/auth"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 )
 
 // SetAuthUserProperties adds username and email to auth user
 func SetAuthUserProperties(authUser *auth.User, xUserName *string, xEmail *string) {
+	// Users authenticated using an API key keep the key identity, and the identity cannot be claimed using the header
+	if _, ok := GetAPIKeyID(authUser); ok {
+		return
+	}
 
-	if xUserName != nil {
+	if xUserName != nil && !strings.HasPrefix(*xUserName, APIKeyUserNamePrefix) {
 		authUser.UserName = *xUserName
 	}
 	if xEmail != nil {
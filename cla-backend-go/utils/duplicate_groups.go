@@ -0,0 +1,89 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+// DuplicateGroups groups the records sharing a key - the keys are the normalized names, emails, domains or
+// identities of the records, two records sharing any key end up in the same group
+type DuplicateGroups struct {
+	parent map[string]string
+	ids    []string
+	byKey  map[string][]string
+	keys   []string
+}
+
+// NewDuplicateGroups returns an empty set of duplicate groups
+func NewDuplicateGroups() *DuplicateGroups {
+	return &DuplicateGroups{
+		parent: make(map[string]string),
+		byKey:  make(map[string][]string),
+	}
+}
+
+// Add adds the record with its keys, the empty keys are ignored
+func (g *DuplicateGroups) Add(id string, keys ...string) {
+	if _, ok := g.parent[id]; !ok {
+		g.parent[id] = id
+		g.ids = append(g.ids, id)
+	}
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if _, ok := g.byKey[key]; !ok {
+			g.keys = append(g.keys, key)
+		}
+		if !StringInSlice(id, g.byKey[key]) {
+			g.byKey[key] = append(g.byKey[key], id)
+		}
+	}
+}
+
+// find returns the root of the group of the record
+func (g *DuplicateGroups) find(id string) string {
+	if g.parent[id] == id {
+		return id
+	}
+	g.parent[id] = g.find(g.parent[id])
+	return g.parent[id]
+}
+
+// Groups returns the groups of two or more records in the order the records were added, with the keys shared by the
+// records of each group
+func (g *DuplicateGroups) Groups() (groups [][]string, reasons [][]string) {
+	for _, key := range g.keys {
+		ids := g.byKey[key]
+		for _, id := range ids[1:] {
+			g.parent[g.find(id)] = g.find(ids[0])
+		}
+	}
+
+	index := make(map[string]int)
+	var all [][]string
+	var allReasons [][]string
+	for _, id := range g.ids {
+		root := g.find(id)
+		i, ok := index[root]
+		if !ok {
+			i = len(all)
+			index[root] = i
+			all = append(all, nil)
+			allReasons = append(allReasons, nil)
+		}
+		all[i] = append(all[i], id)
+	}
+	for _, key := range g.keys {
+		if ids := g.byKey[key]; len(ids) > 1 {
+			i := index[g.find(ids[0])]
+			allReasons[i] = append(allReasons[i], key)
+		}
+	}
+
+	for i, group := range all {
+		if len(group) > 1 {
+			groups = append(groups, group)
+			reasons = append(reasons, allReasons[i])
+		}
+	}
+	return groups, reasons
+}
@@ -148,6 +148,16 @@ func RemoveDuplicates(list []string) []string {
 	return newList
 }
 
+// AppendMissing appends the values which are not in the list yet
+func AppendMissing(list, values []string) []string {
+	for _, value := range values {
+		if !StringInSlice(value, list) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
 // HostInSlice returns true if the specified host value exists in the slice, otherwise returns false
 func HostInSlice(a string, list []string) bool {
 	if list == nil {
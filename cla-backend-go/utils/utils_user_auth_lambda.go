@@ -23,6 +23,11 @@ func IsUserAuthorizedForOrganization(user *auth.User, companySFID string) bool {
 
 // IsUserAuthorizedForProjectTree helper function for determining if the user is authorized for this project hierarchy/tree
 func IsUserAuthorizedForProjectTree(user *auth.User, projectSFID string) bool {
+	// API keys are scoped to a foundation and, optionally, some of its CLA groups
+	if apiKeyID, ok := GetAPIKeyID(user); ok {
+		return isAPIKeyAuthorizedForProject(apiKeyID, projectSFID)
+	}
+
 	// Previously, we checked for user.Admin - admins should be in a separate role
 	// Previously, we checked for user.Allowed, which is currently not used (future flag that is currently not implemented)
 	return user.IsUserAuthorized(auth.Project, projectSFID, true)
@@ -30,6 +35,11 @@ func IsUserAuthorizedForProjectTree(user *auth.User, projectSFID string) bool {
 
 // IsUserAuthorizedForProject helper function for determining if the user is authorized for this project
 func IsUserAuthorizedForProject(user *auth.User, projectSFID string) bool {
+	// API keys are scoped to a foundation and, optionally, some of its CLA groups
+	if apiKeyID, ok := GetAPIKeyID(user); ok {
+		return isAPIKeyAuthorizedForProject(apiKeyID, projectSFID)
+	}
+
 	// Previously, we checked for user.Admin - admins should be in a separate role
 	// Previously, we checked for user.Allowed, which is currently not used (future flag that is currently not implemented)
 	return user.IsUserAuthorizedForProjectScope(projectSFID)
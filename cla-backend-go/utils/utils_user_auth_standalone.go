@@ -53,6 +53,11 @@ func IsUserAuthorizedForProjectTree(user *auth.User, projectSFID string) bool {
 		return true
 	}
 
+	// API keys are scoped to a foundation and, optionally, some of its CLA groups
+	if apiKeyID, ok := GetAPIKeyID(user); ok {
+		return isAPIKeyAuthorizedForProject(apiKeyID, projectSFID)
+	}
+
 	// Previously, we checked for user.Admin - admins should be in a separate role
 	// Previously, we checked for user.Allowed, which is currently not used (future flag that is currently not implemented)
 	return user.IsUserAuthorized(auth.Project, projectSFID, true)
@@ -65,6 +70,11 @@ func IsUserAuthorizedForProject(user *auth.User, projectSFID string) bool {
 		return true
 	}
 
+	// API keys are scoped to a foundation and, optionally, some of its CLA groups
+	if apiKeyID, ok := GetAPIKeyID(user); ok {
+		return isAPIKeyAuthorizedForProject(apiKeyID, projectSFID)
+	}
+
 	// Previously, we checked for user.Admin - admins should be in a separate role
 	// Previously, we checked for user.Allowed, which is currently not used (future flag that is currently not implemented)
 	return user.IsUserAuthorizedForProjectScope(projectSFID)
@@ -0,0 +1,122 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package affiliation_change
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1AffiliationChange "github.com/communitybridge/easycla/cla-backend-go/affiliation_change"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/users"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1AffiliationChange.Service) {
+	api.UsersGetUserAffiliationsHandler = users.GetUserAffiliationsHandlerFunc(
+		func(params users.GetUserAffiliationsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "UsersGetUserAffiliationsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"userID":         params.UserID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			affiliations, err := service.GetAffiliations(ctx, params.UserID, requester(authUser))
+			if err != nil {
+				if errors.Is(err, v1AffiliationChange.ErrUserNotFound) {
+					msg := fmt.Sprintf("user not found, error: %v", err)
+					return users.NewGetUserAffiliationsNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				if errors.Is(err, v1AffiliationChange.ErrNotAllowed) {
+					msg := fmt.Sprintf("user %s does not have access to Get the Affiliations of user %s", authUser.UserName, params.UserID)
+					log.WithFields(f).Warn(msg)
+					return users.NewGetUserAffiliationsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+				}
+				msg := "problem loading the user affiliations"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return users.NewGetUserAffiliationsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.UserAffiliationList{
+				List: []*models.UserAffiliation{},
+			}
+			for _, affiliation := range affiliations {
+				response.List = append(response.List, affiliation.ToModel())
+			}
+			return users.NewGetUserAffiliationsOK().WithXRequestID(reqID).WithPayload(response)
+		})
+
+	api.UsersChangeUserAffiliationHandler = users.ChangeUserAffiliationHandlerFunc(
+		func(params users.ChangeUserAffiliationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "UsersChangeUserAffiliationHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"userID":         params.UserID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if params.Body == nil || params.Body.CompanySFID == nil || *params.Body.CompanySFID == "" ||
+				params.Body.ClaGroupID == nil || *params.Body.ClaGroupID == "" {
+				msg := "the company SFID and the CLA group ID are required"
+				log.WithFields(f).Warn(msg)
+				return users.NewChangeUserAffiliationBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+			}
+			f["companySFID"] = *params.Body.CompanySFID
+			f["claGroupID"] = *params.Body.ClaGroupID
+
+			change, err := service.ChangeAffiliation(ctx, params.UserID, v1AffiliationChange.ChangeInput{
+				CompanySFID: *params.Body.CompanySFID,
+				ClaGroupID:  *params.Body.ClaGroupID,
+				Message:     params.Body.Message,
+			}, requester(authUser))
+			if err != nil {
+				msg := "problem changing the user affiliation"
+				log.WithFields(f).WithError(err).Warn(msg)
+				switch {
+				case errors.Is(err, v1AffiliationChange.ErrUserNotFound), errors.Is(err, v1AffiliationChange.ErrCompanyNotFound):
+					return users.NewChangeUserAffiliationNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				case errors.Is(err, v1AffiliationChange.ErrNotAllowed):
+					msg = fmt.Sprintf("user %s does not have access to Change the Affiliation of user %s", authUser.UserName, params.UserID)
+					return users.NewChangeUserAffiliationForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+				case errors.Is(err, v1AffiliationChange.ErrSameCompany), errors.Is(err, v1AffiliationChange.ErrCCLANotSigned):
+					return users.NewChangeUserAffiliationBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				case errors.Is(err, approval_list.ErrCclaApprovalRequestAlreadyExists):
+					return users.NewChangeUserAffiliationConflict().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code:       utils.String409,
+						Message:    fmt.Sprintf("%s - error: %+v", msg, err),
+						XRequestID: reqID,
+					})
+				}
+				return users.NewChangeUserAffiliationInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return users.NewChangeUserAffiliationOK().WithXRequestID(reqID).WithPayload(change.ToModel())
+		})
+}
+
+// requester returns the affiliation change requester of the authenticated user
+func requester(authUser *auth.User) v1AffiliationChange.Requester {
+	return v1AffiliationChange.Requester{
+		LFUsername: authUser.UserName,
+		Admin:      utils.IsUserAdmin(authUser),
+	}
+}
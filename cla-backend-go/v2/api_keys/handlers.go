@@ -0,0 +1,161 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package api_keys
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1APIKeys "github.com/communitybridge/easycla/cla-backend-go/api_keys"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/api_keys"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// isAuthorized returns true if the user may manage the API keys of the foundation - API keys cannot be used to
+// manage API keys
+func isAuthorized(authUser *auth.User, foundationSFID string) bool {
+	if _, ok := utils.GetAPIKeyID(authUser); ok {
+		return false
+	}
+	return utils.IsUserAuthorizedForProjectTree(authUser, foundationSFID)
+}
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1APIKeys.Service, eventService events.Service) {
+	api.APIKeysListAPIKeysHandler = api_keys.ListAPIKeysHandlerFunc(
+		func(params api_keys.ListAPIKeysParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "APIKeysListAPIKeysHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"foundationSFID": params.FoundationSFID,
+			}
+
+			if !isAuthorized(authUser, params.FoundationSFID) {
+				msg := fmt.Sprintf("user %s does not have access to List API Keys with Project scope of %s", authUser.UserName, params.FoundationSFID)
+				log.WithFields(f).Warn(msg)
+				return api_keys.NewListAPIKeysForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			apiKeys, err := service.GetAPIKeys(ctx, params.FoundationSFID)
+			if err != nil {
+				msg := "problem loading API keys"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return api_keys.NewListAPIKeysInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.APIKeyList{
+				List: []*models.APIKey{},
+			}
+			for _, apiKey := range apiKeys {
+				response.List = append(response.List, apiKey.ToModel())
+			}
+			return api_keys.NewListAPIKeysOK().WithXRequestID(reqID).WithPayload(response)
+		})
+
+	api.APIKeysCreateAPIKeyHandler = api_keys.CreateAPIKeyHandlerFunc(
+		func(params api_keys.CreateAPIKeyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "APIKeysCreateAPIKeyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"foundationSFID": params.FoundationSFID,
+			}
+
+			if !isAuthorized(authUser, params.FoundationSFID) {
+				msg := fmt.Sprintf("user %s does not have access to Create API Key with Project scope of %s", authUser.UserName, params.FoundationSFID)
+				log.WithFields(f).Warn(msg)
+				return api_keys.NewCreateAPIKeyForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			apiKey, keyValue, err := service.CreateAPIKey(ctx, params.FoundationSFID, params.Body, authUser.UserName)
+			if err != nil {
+				if err == v1APIKeys.ErrInvalidAccessLevel || err == v1APIKeys.ErrCLAGroupNotInProject {
+					return api_keys.NewCreateAPIKeyBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "invalid API key input", err))
+				}
+				msg := "problem creating API key"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return api_keys.NewCreateAPIKeyInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername:        authUser.UserName,
+				EventType:         events.APIKeyCreated,
+				ExternalProjectID: params.FoundationSFID,
+				EventData: &events.APIKeyCreatedEventData{
+					APIKeyID:       apiKey.APIKeyID,
+					Name:           apiKey.Name,
+					FoundationSFID: apiKey.FoundationSFID,
+					AccessLevel:    apiKey.AccessLevel,
+					ClaGroupIDs:    apiKey.ClaGroupIDs,
+				},
+			})
+
+			return api_keys.NewCreateAPIKeyOK().WithXRequestID(reqID).WithPayload(&models.APIKeyCreated{
+				APIKey: apiKey.ToModel(),
+				Key:    keyValue,
+			})
+		})
+
+	api.APIKeysRevokeAPIKeyHandler = api_keys.RevokeAPIKeyHandlerFunc(
+		func(params api_keys.RevokeAPIKeyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "APIKeysRevokeAPIKeyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"foundationSFID": params.FoundationSFID,
+				"apiKeyID":       params.APIKeyID,
+			}
+
+			if !isAuthorized(authUser, params.FoundationSFID) {
+				msg := fmt.Sprintf("user %s does not have access to Revoke API Key with Project scope of %s", authUser.UserName, params.FoundationSFID)
+				log.WithFields(f).Warn(msg)
+				return api_keys.NewRevokeAPIKeyForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			apiKey, err := service.RevokeAPIKey(ctx, params.FoundationSFID, params.APIKeyID, authUser.UserName)
+			if err != nil {
+				if err == v1APIKeys.ErrAPIKeyNotFound {
+					msg := fmt.Sprintf("API key %s not found", params.APIKeyID)
+					return api_keys.NewRevokeAPIKeyNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem revoking API key %s", params.APIKeyID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return api_keys.NewRevokeAPIKeyInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername:        authUser.UserName,
+				EventType:         events.APIKeyRevoked,
+				ExternalProjectID: params.FoundationSFID,
+				EventData: &events.APIKeyRevokedEventData{
+					APIKeyID:       apiKey.APIKeyID,
+					Name:           apiKey.Name,
+					FoundationSFID: apiKey.FoundationSFID,
+				},
+			})
+
+			return api_keys.NewRevokeAPIKeyNoContent().WithXRequestID(reqID)
+		})
+}
@@ -0,0 +1,87 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list_expiry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1ApprovalListExpiry "github.com/communitybridge/easycla/cla-backend-go/approval_list_expiry"
+	v1Company "github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/signatures"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Signatures "github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1ApprovalListExpiry.Service, companyService v1Company.IService, signatureService v1Signatures.SignatureService) {
+	api.SignaturesGetApprovalListEntriesHandler = signatures.GetApprovalListEntriesHandlerFunc(
+		func(params signatures.GetApprovalListEntriesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "SignaturesGetApprovalListEntriesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			// CLA manager delegates of the company may view the entries with either scope
+			if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, params.CompanySFID) {
+				isDelegate, delegateErr := signatureService.IsCompanyDelegate(ctx, params.CompanySFID, params.ClaGroupID, authUser.UserName,
+					v1Signatures.DelegateScopeApprovalList, v1Signatures.DelegateScopeViewOnly)
+				if delegateErr != nil {
+					log.WithFields(f).WithError(delegateErr).Warn("unable to check the CLA manager delegates of the company")
+				}
+				if !isDelegate {
+					msg := fmt.Sprintf("user %s does not have access to get the Approval List entries with Project|Organization scope of %s | %s",
+						authUser.UserName, params.ProjectSFID, params.CompanySFID)
+					log.WithFields(f).Warn(msg)
+					return signatures.NewGetApprovalListEntriesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+				}
+			}
+
+			companyModel, err := companyService.GetCompanyByExternalID(ctx, params.CompanySFID)
+			if err != nil || companyModel == nil {
+				msg := fmt.Sprintf("unable to locate company by external company ID: %s", params.CompanySFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return signatures.NewGetApprovalListEntriesNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+
+			signed, approved := true, true
+			pageSize := int64(1)
+			sigModel, err := signatureService.GetProjectCompanySignature(ctx, companyModel.CompanyID, params.ClaGroupID, &signed, &approved, nil, &pageSize)
+			if err != nil || sigModel == nil {
+				msg := fmt.Sprintf("unable to locate the CCLA signature of company ID: %s CLA Group ID: %s", companyModel.CompanyID, params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return signatures.NewGetApprovalListEntriesNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+
+			entries, err := service.GetEntries(ctx, sigModel.SignatureID.String())
+			if err != nil {
+				msg := "problem loading the approval list entries"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return signatures.NewGetApprovalListEntriesInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.ApprovalListEntryList{
+				List: []*models.ApprovalListEntry{},
+			}
+			for _, entry := range entries {
+				response.List = append(response.List, entry.ToModel())
+			}
+			return signatures.NewGetApprovalListEntriesOK().WithXRequestID(reqID).WithPayload(response)
+		})
+}
@@ -0,0 +1,132 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list_import
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1ApprovalListImport "github.com/communitybridge/easycla/cla-backend-go/approval_list_import"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/signatures"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Signatures "github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1ApprovalListImport.Service) {
+	api.SignaturesExportApprovalListHandler = signatures.ExportApprovalListHandlerFunc(
+		func(params signatures.ExportApprovalListParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "SignaturesExportApprovalListHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to export the Approval List with Project|Organization scope of %s | %s",
+					authUser.UserName, params.ProjectSFID, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return signatures.NewExportApprovalListForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			format := v1ApprovalListImport.FormatCSV
+			if params.Format != nil {
+				format = v1ApprovalListImport.Format(*params.Format)
+			}
+			content, err := service.Export(ctx, params.ClaGroupID, params.CompanySFID, format)
+			if err != nil {
+				msg := "problem exporting the approval list"
+				log.WithFields(f).WithError(err).Warn(msg)
+				if isNotFound(err) {
+					return signatures.NewExportApprovalListNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				if errors.Is(err, v1ApprovalListImport.ErrInvalidImport) {
+					return signatures.NewExportApprovalListBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				}
+				return signatures.NewExportApprovalListInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			contentType := "text/csv"
+			if format == v1ApprovalListImport.FormatJSON {
+				contentType = "application/json"
+			}
+			return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+				rw.Header().Set("Content-Type", contentType)
+				rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=approval-list-%s.%s", params.CompanySFID, format))
+				rw.Header().Set(utils.XREQUESTID, reqID)
+				rw.WriteHeader(http.StatusOK)
+				_, writeErr := rw.Write(content)
+				if writeErr != nil {
+					log.WithFields(f).WithError(writeErr).Warn("error writing the approval list export")
+				}
+			})
+		})
+
+	api.SignaturesImportApprovalListHandler = signatures.ImportApprovalListHandlerFunc(
+		func(params signatures.ImportApprovalListParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "SignaturesImportApprovalListHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			// ACL is checked against the signature when the import is applied
+			if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to import the Approval List with Project|Organization scope of %s | %s",
+					authUser.UserName, params.ProjectSFID, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return signatures.NewImportApprovalListForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.Import(ctx, authUser, params.ClaGroupID, params.CompanySFID, &v1ApprovalListImport.Input{
+				Format:  v1ApprovalListImport.Format(utils.StringValue(params.Body.Format)),
+				Content: utils.StringValue(params.Body.Content),
+				DryRun:  params.Body.DryRun,
+			})
+			if err != nil {
+				msg := "problem importing the approval list"
+				log.WithFields(f).WithError(err).Warn(msg)
+				if isNotFound(err) {
+					return signatures.NewImportApprovalListNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				if _, ok := err.(*v1Signatures.ForbiddenError); ok {
+					return signatures.NewImportApprovalListForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
+				}
+				if _, ok := err.(*v1Signatures.BadRequestError); ok || errors.Is(err, v1ApprovalListImport.ErrInvalidImport) {
+					return signatures.NewImportApprovalListBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				}
+				return signatures.NewImportApprovalListInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return signatures.NewImportApprovalListOK().WithXRequestID(reqID).WithPayload(result.ToModel())
+		})
+}
+
+func isNotFound(err error) bool {
+	return err == v1ApprovalListImport.ErrCompanyNotFound || err == v1ApprovalListImport.ErrCLAGroupNotFound ||
+		err == v1ApprovalListImport.ErrSignatureNotFound
+}
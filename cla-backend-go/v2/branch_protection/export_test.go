@@ -0,0 +1,22 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+)
+
+// NewTestService creates the service with the branch protection client of the test
+func NewTestService(repo Repository, githubRepo repositories.Repository, githubOrgRepo github_organizations.Repository, branchProtection githubutils.Repositories) Service {
+	return &service{
+		repo:          repo,
+		githubRepo:    githubRepo,
+		githubOrgRepo: githubOrgRepo,
+		branchProtectionRepository: func(installation githubutils.Installation) (*githubutils.BranchProtectionRepository, error) {
+			return githubutils.NewBranchProtectionRepository(branchProtection), nil
+		},
+	}
+}
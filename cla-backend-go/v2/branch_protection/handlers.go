@@ -0,0 +1,332 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/branch_protection"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// isNotFound returns true if the GitHub organization, the CLA Group or the policy doesn't exist
+func isNotFound(err error) bool {
+	return errors.Is(err, github_organizations.ErrOrganizationDoesNotExist) ||
+		errors.Is(err, ErrClaGroupNotFound) || errors.Is(err, ErrPolicyNotFound)
+}
+
+// isUserAuthorizedForClaGroup checks if the user has access to the foundation or to one of the projects of the CLA Group
+func isUserAuthorizedForClaGroup(authUser *auth.User, claGroupID string, projectsClaGroupRepo projects_cla_groups.Repository) bool {
+	projectClaGroups, err := projectsClaGroupRepo.GetProjectsIdsForClaGroup(claGroupID)
+	if err != nil || len(projectClaGroups) == 0 {
+		return false
+	}
+	if utils.IsUserAuthorizedForProjectTree(authUser, projectClaGroups[0].FoundationSFID) {
+		return true
+	}
+	for _, projectClaGroup := range projectClaGroups {
+		if utils.IsUserAuthorizedForProject(authUser, projectClaGroup.ProjectSFID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service, projectsClaGroupRepo projects_cla_groups.Repository, eventService events.Service) {
+	api.BranchProtectionGetGithubOrganizationBranchProtectionPolicyHandler = branch_protection.GetGithubOrganizationBranchProtectionPolicyHandlerFunc(
+		func(params branch_protection.GetGithubOrganizationBranchProtectionPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "BranchProtectionGetGithubOrganizationBranchProtectionPolicyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"orgName":        params.OrgName,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.ProjectSFID) {
+				msg := fmt.Sprintf("user %s does not have access to Get GitHub Organization Branch Protection Policy with Project scope of %s", authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Warn(msg)
+				return branch_protection.NewGetGithubOrganizationBranchProtectionPolicyForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			policy, err := service.GetGithubOrganizationPolicy(ctx, params.ProjectSFID, params.OrgName)
+			if err != nil {
+				if isNotFound(err) {
+					msg := fmt.Sprintf("branch protection policy for github organization %s not found", params.OrgName)
+					return branch_protection.NewGetGithubOrganizationBranchProtectionPolicyNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem loading the branch protection policy for github organization %s", params.OrgName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return branch_protection.NewGetGithubOrganizationBranchProtectionPolicyInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return branch_protection.NewGetGithubOrganizationBranchProtectionPolicyOK().WithXRequestID(reqID).WithPayload(policy.ToModel())
+		})
+
+	api.BranchProtectionUpdateGithubOrganizationBranchProtectionPolicyHandler = branch_protection.UpdateGithubOrganizationBranchProtectionPolicyHandlerFunc(
+		func(params branch_protection.UpdateGithubOrganizationBranchProtectionPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "BranchProtectionUpdateGithubOrganizationBranchProtectionPolicyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"orgName":        params.OrgName,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.ProjectSFID) {
+				msg := fmt.Sprintf("user %s does not have access to Update GitHub Organization Branch Protection Policy with Project scope of %s", authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Warn(msg)
+				return branch_protection.NewUpdateGithubOrganizationBranchProtectionPolicyForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			policy, err := service.UpdateGithubOrganizationPolicy(ctx, params.ProjectSFID, params.OrgName, params.Body, authUser.UserName)
+			if err != nil {
+				if isNotFound(err) {
+					msg := fmt.Sprintf("github organization %s not found", params.OrgName)
+					return branch_protection.NewUpdateGithubOrganizationBranchProtectionPolicyNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				if errors.Is(err, ErrInvalidPolicy) {
+					return branch_protection.NewUpdateGithubOrganizationBranchProtectionPolicyBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "invalid branch protection policy", err))
+				}
+				msg := fmt.Sprintf("problem updating the branch protection policy for github organization %s", params.OrgName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return branch_protection.NewUpdateGithubOrganizationBranchProtectionPolicyInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername:        authUser.UserName,
+				EventType:         events.BranchProtectionPolicyUpdated,
+				ExternalProjectID: params.ProjectSFID,
+				EventData: &events.BranchProtectionPolicyUpdatedEventData{
+					PolicyID:       policy.PolicyID,
+					BranchPatterns: policy.BranchPatterns,
+					RequiredChecks: policy.RequiredChecks,
+				},
+			})
+
+			return branch_protection.NewUpdateGithubOrganizationBranchProtectionPolicyOK().WithXRequestID(reqID).WithPayload(policy.ToModel())
+		})
+
+	api.BranchProtectionDeleteGithubOrganizationBranchProtectionPolicyHandler = branch_protection.DeleteGithubOrganizationBranchProtectionPolicyHandlerFunc(
+		func(params branch_protection.DeleteGithubOrganizationBranchProtectionPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "BranchProtectionDeleteGithubOrganizationBranchProtectionPolicyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"orgName":        params.OrgName,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.ProjectSFID) {
+				msg := fmt.Sprintf("user %s does not have access to Delete GitHub Organization Branch Protection Policy with Project scope of %s", authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Warn(msg)
+				return branch_protection.NewDeleteGithubOrganizationBranchProtectionPolicyForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			err := service.DeleteGithubOrganizationPolicy(ctx, params.ProjectSFID, params.OrgName)
+			if err != nil {
+				if isNotFound(err) {
+					msg := fmt.Sprintf("branch protection policy for github organization %s not found", params.OrgName)
+					return branch_protection.NewDeleteGithubOrganizationBranchProtectionPolicyNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem deleting the branch protection policy for github organization %s", params.OrgName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return branch_protection.NewDeleteGithubOrganizationBranchProtectionPolicyInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername:        authUser.UserName,
+				EventType:         events.BranchProtectionPolicyDeleted,
+				ExternalProjectID: params.ProjectSFID,
+				EventData: &events.BranchProtectionPolicyDeletedEventData{
+					PolicyID: PolicyID(ScopeGithubOrganization, params.OrgName),
+				},
+			})
+
+			return branch_protection.NewDeleteGithubOrganizationBranchProtectionPolicyNoContent().WithXRequestID(reqID)
+		})
+
+	api.BranchProtectionGetGithubOrganizationBranchProtectionComplianceHandler = branch_protection.GetGithubOrganizationBranchProtectionComplianceHandlerFunc(
+		func(params branch_protection.GetGithubOrganizationBranchProtectionComplianceParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "BranchProtectionGetGithubOrganizationBranchProtectionComplianceHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"orgName":        params.OrgName,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.ProjectSFID) {
+				msg := fmt.Sprintf("user %s does not have access to Get GitHub Organization Branch Protection Compliance with Project scope of %s", authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Warn(msg)
+				return branch_protection.NewGetGithubOrganizationBranchProtectionComplianceForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			report, err := service.GetComplianceReport(ctx, params.ProjectSFID, params.OrgName)
+			if err != nil {
+				if isNotFound(err) {
+					msg := fmt.Sprintf("github organization %s not found", params.OrgName)
+					return branch_protection.NewGetGithubOrganizationBranchProtectionComplianceNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				if errors.Is(err, ErrOrganizationNotInstalled) {
+					return branch_protection.NewGetGithubOrganizationBranchProtectionComplianceBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "unable to check the branch protection compliance", err))
+				}
+				msg := fmt.Sprintf("problem checking the branch protection compliance for github organization %s", params.OrgName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return branch_protection.NewGetGithubOrganizationBranchProtectionComplianceInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return branch_protection.NewGetGithubOrganizationBranchProtectionComplianceOK().WithXRequestID(reqID).WithPayload(report.ToModel())
+		})
+
+	api.BranchProtectionGetClaGroupBranchProtectionPolicyHandler = branch_protection.GetClaGroupBranchProtectionPolicyHandlerFunc(
+		func(params branch_protection.GetClaGroupBranchProtectionPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "BranchProtectionGetClaGroupBranchProtectionPolicyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"claGroupID":     params.ClaGroupID,
+			}
+
+			if !isUserAuthorizedForClaGroup(authUser, params.ClaGroupID, projectsClaGroupRepo) {
+				msg := fmt.Sprintf("user %s does not have access to Get CLA Group Branch Protection Policy for CLA Group %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return branch_protection.NewGetClaGroupBranchProtectionPolicyForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			policy, err := service.GetClaGroupPolicy(ctx, params.ClaGroupID)
+			if err != nil {
+				if isNotFound(err) {
+					msg := fmt.Sprintf("branch protection policy for CLA Group %s not found", params.ClaGroupID)
+					return branch_protection.NewGetClaGroupBranchProtectionPolicyNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem loading the branch protection policy for CLA Group %s", params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return branch_protection.NewGetClaGroupBranchProtectionPolicyInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return branch_protection.NewGetClaGroupBranchProtectionPolicyOK().WithXRequestID(reqID).WithPayload(policy.ToModel())
+		})
+
+	api.BranchProtectionUpdateClaGroupBranchProtectionPolicyHandler = branch_protection.UpdateClaGroupBranchProtectionPolicyHandlerFunc(
+		func(params branch_protection.UpdateClaGroupBranchProtectionPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "BranchProtectionUpdateClaGroupBranchProtectionPolicyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"claGroupID":     params.ClaGroupID,
+			}
+
+			if !isUserAuthorizedForClaGroup(authUser, params.ClaGroupID, projectsClaGroupRepo) {
+				msg := fmt.Sprintf("user %s does not have access to Update CLA Group Branch Protection Policy for CLA Group %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return branch_protection.NewUpdateClaGroupBranchProtectionPolicyForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			policy, err := service.UpdateClaGroupPolicy(ctx, params.ClaGroupID, params.Body, authUser.UserName)
+			if err != nil {
+				if isNotFound(err) {
+					msg := fmt.Sprintf("CLA Group %s not found", params.ClaGroupID)
+					return branch_protection.NewUpdateClaGroupBranchProtectionPolicyNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				if errors.Is(err, ErrInvalidPolicy) {
+					return branch_protection.NewUpdateClaGroupBranchProtectionPolicyBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "invalid branch protection policy", err))
+				}
+				msg := fmt.Sprintf("problem updating the branch protection policy for CLA Group %s", params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return branch_protection.NewUpdateClaGroupBranchProtectionPolicyInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername: authUser.UserName,
+				EventType:  events.BranchProtectionPolicyUpdated,
+				ProjectID:  params.ClaGroupID,
+				EventData: &events.BranchProtectionPolicyUpdatedEventData{
+					PolicyID:       policy.PolicyID,
+					BranchPatterns: policy.BranchPatterns,
+					RequiredChecks: policy.RequiredChecks,
+				},
+			})
+
+			return branch_protection.NewUpdateClaGroupBranchProtectionPolicyOK().WithXRequestID(reqID).WithPayload(policy.ToModel())
+		})
+
+	api.BranchProtectionDeleteClaGroupBranchProtectionPolicyHandler = branch_protection.DeleteClaGroupBranchProtectionPolicyHandlerFunc(
+		func(params branch_protection.DeleteClaGroupBranchProtectionPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "BranchProtectionDeleteClaGroupBranchProtectionPolicyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"claGroupID":     params.ClaGroupID,
+			}
+
+			if !isUserAuthorizedForClaGroup(authUser, params.ClaGroupID, projectsClaGroupRepo) {
+				msg := fmt.Sprintf("user %s does not have access to Delete CLA Group Branch Protection Policy for CLA Group %s", authUser.UserName, params.ClaGroupID)
+				log.WithFields(f).Warn(msg)
+				return branch_protection.NewDeleteClaGroupBranchProtectionPolicyForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			err := service.DeleteClaGroupPolicy(ctx, params.ClaGroupID)
+			if err != nil {
+				if isNotFound(err) {
+					msg := fmt.Sprintf("branch protection policy for CLA Group %s not found", params.ClaGroupID)
+					return branch_protection.NewDeleteClaGroupBranchProtectionPolicyNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem deleting the branch protection policy for CLA Group %s", params.ClaGroupID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return branch_protection.NewDeleteClaGroupBranchProtectionPolicyInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername: authUser.UserName,
+				EventType:  events.BranchProtectionPolicyDeleted,
+				ProjectID:  params.ClaGroupID,
+				EventData: &events.BranchProtectionPolicyDeletedEventData{
+					PolicyID: PolicyID(ScopeClaGroup, params.ClaGroupID),
+				},
+			})
+
+			return branch_protection.NewDeleteClaGroupBranchProtectionPolicyNoContent().WithXRequestID(reqID)
+		})
+}
@@ -0,0 +1,131 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"fmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// policy scopes
+const (
+	ScopeGithubOrganization = "github-org"
+	ScopeClaGroup           = "cla-group"
+)
+
+// violation reasons
+const (
+	// ReasonCheckFailed means the branch protection of the branch could not be loaded from GitHub
+	ReasonCheckFailed = "check_failed"
+	// ReasonBranchNotProtected means the branch has no branch protection at all
+	ReasonBranchNotProtected = "branch_not_protected"
+	// ReasonMissingRequiredCheck means one of the required status checks of the policy is not required on the branch
+	ReasonMissingRequiredCheck = "missing_required_check"
+	// ReasonAdminsNotEnforced means the policy enforces the protection for admins but the branch doesn't
+	ReasonAdminsNotEnforced = "admins_not_enforced"
+	// ReasonInsufficientReviews means the branch requires less approving reviews than the policy
+	ReasonInsufficientReviews = "insufficient_reviews"
+	// ReasonLinearHistoryNotRequired means the policy requires linear history but the branch doesn't
+	ReasonLinearHistoryNotRequired = "linear_history_not_required"
+)
+
+// Policy is the database model for the branch protection policies table, a policy is either defined for a GitHub
+// organization or for a CLA Group
+type Policy struct {
+	PolicyID                     string   `dynamodbav:"policy_id" json:"policy_id"`
+	Scope                        string   `dynamodbav:"scope" json:"scope"`
+	ScopeID                      string   `dynamodbav:"scope_id" json:"scope_id"`
+	ProjectSFID                  string   `dynamodbav:"project_sfid" json:"project_sfid"`
+	BranchPatterns               []string `dynamodbav:"branch_patterns" json:"branch_patterns"`
+	IncludeDefaultBranch         bool     `dynamodbav:"include_default_branch" json:"include_default_branch"`
+	RequiredChecks               []string `dynamodbav:"required_checks" json:"required_checks"`
+	EnforceAdmins                bool     `dynamodbav:"enforce_admins" json:"enforce_admins"`
+	RequiredApprovingReviewCount int      `dynamodbav:"required_approving_review_count" json:"required_approving_review_count"`
+	RequireLinearHistory         bool     `dynamodbav:"require_linear_history" json:"require_linear_history"`
+	DateCreated                  string   `dynamodbav:"date_created" json:"date_created"`
+	DateModified                 string   `dynamodbav:"date_modified" json:"date_modified"`
+	ModifiedBy                   string   `dynamodbav:"modified_by" json:"modified_by"`
+	Version                      string   `dynamodbav:"version" json:"version"`
+}
+
+// PolicyID returns the policy id of the given scope
+func PolicyID(scope, scopeID string) string {
+	return fmt.Sprintf("%s:%s", scope, scopeID)
+}
+
+// DefaultPolicy is used when neither the GitHub organization nor the CLA Group has a policy, it matches the
+// protection EasyCLA has always applied - the EasyCLA check on the default branch enforced for admins
+func DefaultPolicy() *Policy {
+	return &Policy{
+		IncludeDefaultBranch: true,
+		RequiredChecks:       []string{utils.GitHubBotName},
+		EnforceAdmins:        true,
+	}
+}
+
+// ToModel converts the database model to the API model
+func (p *Policy) ToModel() *models.BranchProtectionPolicy {
+	return &models.BranchProtectionPolicy{
+		PolicyID:                     p.PolicyID,
+		Scope:                        p.Scope,
+		ScopeID:                      p.ScopeID,
+		ProjectSfid:                  p.ProjectSFID,
+		BranchPatterns:               p.BranchPatterns,
+		IncludeDefaultBranch:         p.IncludeDefaultBranch,
+		RequiredChecks:               p.RequiredChecks,
+		EnforceAdmins:                p.EnforceAdmins,
+		RequiredApprovingReviewCount: int64(p.RequiredApprovingReviewCount),
+		RequireLinearHistory:         p.RequireLinearHistory,
+		DateCreated:                  p.DateCreated,
+		DateModified:                 p.DateModified,
+		ModifiedBy:                   p.ModifiedBy,
+		Version:                      p.Version,
+	}
+}
+
+// ComplianceReport lists the repositories and branches which do not conform to their branch protection policy
+type ComplianceReport struct {
+	OrganizationName    string
+	ProjectSFID         string
+	DateChecked         string
+	RepositoriesChecked int
+	Violations          []*Violation
+}
+
+// Violation is a single branch which does not conform to its branch protection policy
+type Violation struct {
+	RepositoryName string
+	RepositoryID   string
+	ClaGroupID     string
+	BranchName     string
+	PolicyID       string
+	Reason         string
+	Detail         string
+}
+
+// ToModel converts the compliance report to the API model
+func (r *ComplianceReport) ToModel() *models.BranchProtectionComplianceReport {
+	violations := make([]*models.BranchProtectionViolation, 0, len(r.Violations))
+	for _, violation := range r.Violations {
+		violations = append(violations, &models.BranchProtectionViolation{
+			RepositoryName: violation.RepositoryName,
+			RepositoryID:   violation.RepositoryID,
+			ClaGroupID:     violation.ClaGroupID,
+			BranchName:     violation.BranchName,
+			PolicyID:       violation.PolicyID,
+			Reason:         violation.Reason,
+			Detail:         violation.Detail,
+		})
+	}
+	return &models.BranchProtectionComplianceReport{
+		OrganizationName:    r.OrganizationName,
+		ProjectSfid:         r.ProjectSFID,
+		DateChecked:         r.DateChecked,
+		RepositoriesChecked: int64(r.RepositoriesChecked),
+		Compliant:           len(r.Violations) == 0,
+		Violations:          violations,
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// errors
+var (
+	ErrPolicyNotFound = errors.New("branch protection policy not found")
+)
+
+// Repository interface defines the functions for the branch protection policies data model
+type Repository interface {
+	PutPolicy(ctx context.Context, policy *Policy) error
+	GetPolicy(ctx context.Context, policyID string) (*Policy, error)
+	DeletePolicy(ctx context.Context, policyID string) error
+}
+
+type repository struct {
+	stage          string
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewRepository creates a new instance of the branch protection policies repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:          stage,
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-branch-protection-policies", stage),
+	}
+}
+
+// PutPolicy stores the branch protection policy, replacing the previous policy of the same scope
+func (repo *repository) PutPolicy(ctx context.Context, policy *Policy) error {
+	f := logrus.Fields{
+		"functionName":   "PutPolicy",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"policyID":       policy.PolicyID,
+		"tableName":      repo.tableName,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(policy)
+	if err != nil {
+		log.WithFields(f).Warnf("problem encoding the branch protection policy, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem storing the branch protection policy, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// GetPolicy returns the branch protection policy with the given policy id
+func (repo *repository) GetPolicy(ctx context.Context, policyID string) (*Policy, error) {
+	f := logrus.Fields{
+		"functionName":   "GetPolicy",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"policyID":       policyID,
+		"tableName":      repo.tableName,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"policy_id": {
+				S: aws.String(policyID),
+			},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the branch protection policy, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrPolicyNotFound
+	}
+
+	var policy Policy
+	err = dynamodbattribute.UnmarshalMap(result.Item, &policy)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding the branch protection policy, error: %+v", err)
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// DeletePolicy deletes the branch protection policy with the given policy id
+func (repo *repository) DeletePolicy(ctx context.Context, policyID string) error {
+	f := logrus.Fields{
+		"functionName":   "DeletePolicy",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"policyID":       policyID,
+		"tableName":      repo.tableName,
+	}
+
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"policy_id": {
+				S: aws.String(policyID),
+			},
+		},
+		ConditionExpression: aws.String("attribute_exists(policy_id)"),
+		TableName:           aws.String(repo.tableName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrPolicyNotFound
+		}
+		log.WithFields(f).Warnf("problem deleting the branch protection policy, error: %+v", err)
+		return err
+	}
+	return nil
+}
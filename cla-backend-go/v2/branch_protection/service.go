@@ -0,0 +1,484 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_jobs"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/google/go-github/v32/github"
+	"github.com/sirupsen/logrus"
+)
+
+// maxRequiredApprovingReviewCount is the maximum number of approving reviews github accepts for a branch protection
+const maxRequiredApprovingReviewCount = 6
+
+// errors
+var (
+	ErrOrganizationNotInstalled = errors.New("github app is not installed for the github organization")
+	ErrClaGroupNotFound         = errors.New("cla group not found")
+	ErrInvalidPolicy            = errors.New("invalid branch protection policy")
+)
+
+// Service manages the branch protection policies of the GitHub organizations and CLA Groups
+type Service interface {
+	GetGithubOrganizationPolicy(ctx context.Context, projectSFID, organizationName string) (*Policy, error)
+	UpdateGithubOrganizationPolicy(ctx context.Context, projectSFID, organizationName string, input *models.BranchProtectionPolicyInput, modifiedBy string) (*Policy, error)
+	DeleteGithubOrganizationPolicy(ctx context.Context, projectSFID, organizationName string) error
+	GetClaGroupPolicy(ctx context.Context, claGroupID string) (*Policy, error)
+	UpdateClaGroupPolicy(ctx context.Context, claGroupID string, input *models.BranchProtectionPolicyInput, modifiedBy string) (*Policy, error)
+	DeleteClaGroupPolicy(ctx context.Context, claGroupID string) error
+
+	ResolvePolicy(ctx context.Context, organizationName, claGroupID string) (*Policy, error)
+	ApplyPolicy(ctx context.Context, branchProtectionRepo *githubutils.BranchProtectionRepository, owner, repositoryName string, policy *Policy) error
+	GetComplianceReport(ctx context.Context, projectSFID, organizationName string) (*ComplianceReport, error)
+	JobHandler() github_jobs.ItemHandler
+}
+
+type service struct {
+	repo                 Repository
+	githubRepo           repositories.Repository
+	githubOrgRepo        github_organizations.Repository
+	projectsClaGroupRepo projects_cla_groups.Repository
+
+	branchProtectionRepository func(installation githubutils.Installation) (*githubutils.BranchProtectionRepository, error)
+}
+
+// NewService creates a new branch protection policy service
+func NewService(repo Repository, githubRepo repositories.Repository, githubOrgRepo github_organizations.Repository, projectsClaGroupRepo projects_cla_groups.Repository) Service {
+	return &service{
+		repo:                 repo,
+		githubRepo:           githubRepo,
+		githubOrgRepo:        githubOrgRepo,
+		projectsClaGroupRepo: projectsClaGroupRepo,
+		branchProtectionRepository: func(installation githubutils.Installation) (*githubutils.BranchProtectionRepository, error) {
+			client, err := githubutils.NewGithubAppClientForInstallation(installation)
+			if err != nil {
+				return nil, err
+			}
+			return githubutils.NewBranchProtectionRepository(client.Repositories, githubutils.EnableBlockingLimiter()), nil
+		},
+	}
+}
+
+// getOrganization loads the GitHub organization and makes sure it belongs to the project
+func (s *service) getOrganization(ctx context.Context, projectSFID, organizationName string) (*v1Models.GithubOrganization, error) {
+	return s.githubOrgRepo.GetGithubOrganizationForProject(ctx, projectSFID, organizationName)
+}
+
+// getClaGroupFoundationSFID returns the foundation of the CLA Group, which also makes sure the CLA Group exists
+func (s *service) getClaGroupFoundationSFID(claGroupID string) (string, error) {
+	projectClaGroups, err := s.projectsClaGroupRepo.GetProjectsIdsForClaGroup(claGroupID)
+	if err != nil {
+		return "", err
+	}
+	if len(projectClaGroups) == 0 {
+		return "", ErrClaGroupNotFound
+	}
+	return projectClaGroups[0].FoundationSFID, nil
+}
+
+// GetGithubOrganizationPolicy returns the branch protection policy of the GitHub organization
+func (s *service) GetGithubOrganizationPolicy(ctx context.Context, projectSFID, organizationName string) (*Policy, error) {
+	if _, err := s.getOrganization(ctx, projectSFID, organizationName); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPolicy(ctx, PolicyID(ScopeGithubOrganization, organizationName))
+}
+
+// UpdateGithubOrganizationPolicy creates or replaces the branch protection policy of the GitHub organization
+func (s *service) UpdateGithubOrganizationPolicy(ctx context.Context, projectSFID, organizationName string, input *models.BranchProtectionPolicyInput, modifiedBy string) (*Policy, error) {
+	if _, err := s.getOrganization(ctx, projectSFID, organizationName); err != nil {
+		return nil, err
+	}
+	return s.putPolicy(ctx, ScopeGithubOrganization, organizationName, projectSFID, input, modifiedBy)
+}
+
+// DeleteGithubOrganizationPolicy deletes the branch protection policy of the GitHub organization
+func (s *service) DeleteGithubOrganizationPolicy(ctx context.Context, projectSFID, organizationName string) error {
+	if _, err := s.getOrganization(ctx, projectSFID, organizationName); err != nil {
+		return err
+	}
+	return s.repo.DeletePolicy(ctx, PolicyID(ScopeGithubOrganization, organizationName))
+}
+
+// GetClaGroupPolicy returns the branch protection policy of the CLA Group
+func (s *service) GetClaGroupPolicy(ctx context.Context, claGroupID string) (*Policy, error) {
+	if _, err := s.getClaGroupFoundationSFID(claGroupID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPolicy(ctx, PolicyID(ScopeClaGroup, claGroupID))
+}
+
+// UpdateClaGroupPolicy creates or replaces the branch protection policy of the CLA Group
+func (s *service) UpdateClaGroupPolicy(ctx context.Context, claGroupID string, input *models.BranchProtectionPolicyInput, modifiedBy string) (*Policy, error) {
+	foundationSFID, err := s.getClaGroupFoundationSFID(claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return s.putPolicy(ctx, ScopeClaGroup, claGroupID, foundationSFID, input, modifiedBy)
+}
+
+// DeleteClaGroupPolicy deletes the branch protection policy of the CLA Group
+func (s *service) DeleteClaGroupPolicy(ctx context.Context, claGroupID string) error {
+	if _, err := s.getClaGroupFoundationSFID(claGroupID); err != nil {
+		return err
+	}
+	return s.repo.DeletePolicy(ctx, PolicyID(ScopeClaGroup, claGroupID))
+}
+
+// putPolicy validates the input and stores it as the policy of the given scope, keeping the creation date of the
+// policy it replaces
+func (s *service) putPolicy(ctx context.Context, scope, scopeID, projectSFID string, input *models.BranchProtectionPolicyInput, modifiedBy string) (*Policy, error) {
+	policy, err := newPolicy(input)
+	if err != nil {
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	policy.PolicyID = PolicyID(scope, scopeID)
+	policy.Scope = scope
+	policy.ScopeID = scopeID
+	policy.ProjectSFID = projectSFID
+	policy.DateCreated = currentTime
+	policy.DateModified = currentTime
+	policy.ModifiedBy = modifiedBy
+	policy.Version = "v1"
+
+	existing, err := s.repo.GetPolicy(ctx, policy.PolicyID)
+	if err != nil && !errors.Is(err, ErrPolicyNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		policy.DateCreated = existing.DateCreated
+	}
+
+	if err := s.repo.PutPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// newPolicy validates the input and converts it to a policy, the EasyCLA check is always required
+func newPolicy(input *models.BranchProtectionPolicyInput) (*Policy, error) {
+	policy := &Policy{
+		IncludeDefaultBranch:         true,
+		EnforceAdmins:                true,
+		RequiredApprovingReviewCount: int(input.RequiredApprovingReviewCount),
+		RequireLinearHistory:         input.RequireLinearHistory,
+	}
+	if input.IncludeDefaultBranch != nil {
+		policy.IncludeDefaultBranch = *input.IncludeDefaultBranch
+	}
+	if input.EnforceAdmins != nil {
+		policy.EnforceAdmins = *input.EnforceAdmins
+	}
+
+	if policy.RequiredApprovingReviewCount < 0 || policy.RequiredApprovingReviewCount > maxRequiredApprovingReviewCount {
+		return nil, fmt.Errorf("%w : the required approving review count must be between 0 and %d", ErrInvalidPolicy, maxRequiredApprovingReviewCount)
+	}
+
+	for _, pattern := range input.BranchPatterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" || utils.StringInSlice(pattern, policy.BranchPatterns) {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w : invalid branch pattern %s", ErrInvalidPolicy, pattern)
+		}
+		policy.BranchPatterns = append(policy.BranchPatterns, pattern)
+	}
+	if !policy.IncludeDefaultBranch && len(policy.BranchPatterns) == 0 {
+		return nil, fmt.Errorf("%w : the policy must protect the default branch or at least one branch pattern", ErrInvalidPolicy)
+	}
+
+	policy.RequiredChecks = []string{utils.GitHubBotName}
+	for _, check := range input.RequiredChecks {
+		check = strings.TrimSpace(check)
+		if check == "" || utils.StringInSlice(check, policy.RequiredChecks) {
+			continue
+		}
+		policy.RequiredChecks = append(policy.RequiredChecks, check)
+	}
+
+	return policy, nil
+}
+
+// ResolvePolicy returns the branch protection policy of a repository - the policy of the GitHub organization takes
+// precedence over the policy of the CLA Group the repository belongs to, and when neither is defined the default
+// policy is returned
+func (s *service) ResolvePolicy(ctx context.Context, organizationName, claGroupID string) (*Policy, error) {
+	policy, err := s.repo.GetPolicy(ctx, PolicyID(ScopeGithubOrganization, organizationName))
+	if err == nil {
+		return policy, nil
+	}
+	if !errors.Is(err, ErrPolicyNotFound) {
+		return nil, err
+	}
+
+	if claGroupID != "" {
+		policy, err = s.repo.GetPolicy(ctx, PolicyID(ScopeClaGroup, claGroupID))
+		if err == nil {
+			return policy, nil
+		}
+		if !errors.Is(err, ErrPolicyNotFound) {
+			return nil, err
+		}
+	}
+
+	return DefaultPolicy(), nil
+}
+
+// targetBranches returns the branches of the repository the policy applies to
+func targetBranches(ctx context.Context, branchProtectionRepo *githubutils.BranchProtectionRepository, owner, repositoryName string, policy *Policy) ([]string, error) {
+	var branches []string
+	if policy.IncludeDefaultBranch {
+		defaultBranch, err := branchProtectionRepo.GetDefaultBranchForRepo(ctx, owner, repositoryName)
+		if err != nil {
+			return nil, err
+		}
+		branches = append(branches, defaultBranch)
+	}
+
+	if len(policy.BranchPatterns) == 0 {
+		return branches, nil
+	}
+
+	branchNames, err := branchProtectionRepo.ListBranchNames(ctx, owner, repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	for _, branchName := range branchNames {
+		if utils.StringInSlice(branchName, branches) {
+			continue
+		}
+		for _, pattern := range policy.BranchPatterns {
+			if githubutils.MatchBranchPattern(pattern, branchName) {
+				branches = append(branches, branchName)
+				break
+			}
+		}
+	}
+	return branches, nil
+}
+
+// ApplyPolicy enables the branch protection of the policy on all the branches of the repository the policy applies to
+func (s *service) ApplyPolicy(ctx context.Context, branchProtectionRepo *githubutils.BranchProtectionRepository, owner, repositoryName string, policy *Policy) error {
+	f := logrus.Fields{
+		"functionName":   "ApplyPolicy",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"owner":          owner,
+		"repositoryName": repositoryName,
+		"policyID":       policy.PolicyID,
+	}
+
+	branches, err := targetBranches(ctx, branchProtectionRepo, owner, repositoryName, policy)
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the branches of the repository, error: %+v", err)
+		return err
+	}
+
+	opts := &githubutils.BranchProtectionOptions{
+		RequiredApprovingReviewCount: policy.RequiredApprovingReviewCount,
+		RequireLinearHistory:         policy.RequireLinearHistory,
+	}
+	var applyErr error
+	for _, branch := range branches {
+		log.WithFields(f).Debugf("enabling branch protection on branch : %s", branch)
+		err := branchProtectionRepo.EnableBranchProtectionWithOptions(ctx, owner, repositoryName, branch,
+			policy.EnforceAdmins, policy.RequiredChecks, []string{}, opts)
+		if err != nil {
+			log.WithFields(f).Warnf("problem enabling branch protection on branch : %s, error: %+v", branch, err)
+			applyErr = fmt.Errorf("enabling branch protection on branch : %s : %w", branch, err)
+		}
+	}
+	return applyErr
+}
+
+// JobHandler returns the github job handler applying the branch protection policy to a repository, the job items are
+// the EasyCLA repository IDs
+func (s *service) JobHandler() github_jobs.ItemHandler {
+	// the branch protection repositories are kept per organization for the whole job run
+	branchProtectionRepos := make(map[string]*githubutils.BranchProtectionRepository)
+	return func(ctx context.Context, job *github_jobs.Job, repositoryID string) error {
+		repo, err := s.githubRepo.GetRepository(ctx, repositoryID)
+		if err != nil {
+			return err
+		}
+
+		branchProtectionRepo, ok := branchProtectionRepos[job.OrganizationName]
+		if !ok {
+			// the organization knows the GitHub Enterprise Server host of the installation
+			org, orgErr := s.githubOrgRepo.GetGithubOrganizationForProject(ctx, job.ProjectSFID, job.OrganizationName)
+			if orgErr != nil {
+				return orgErr
+			}
+			installation := github_organizations.GithubInstallation(org)
+			installation.InstallationID = job.InstallationID
+			branchProtectionRepo, err = s.branchProtectionRepository(installation)
+			if err != nil {
+				return err
+			}
+			branchProtectionRepos[job.OrganizationName] = branchProtectionRepo
+		}
+
+		policy, err := s.ResolvePolicy(ctx, job.OrganizationName, repo.RepositoryProjectID)
+		if err != nil {
+			return err
+		}
+		return s.ApplyPolicy(ctx, branchProtectionRepo, job.OrganizationName, repo.RepositoryName, policy)
+	}
+}
+
+// GetComplianceReport checks the branches of all the enabled repositories of the GitHub organization against their
+// branch protection policy
+func (s *service) GetComplianceReport(ctx context.Context, projectSFID, organizationName string) (*ComplianceReport, error) {
+	f := logrus.Fields{
+		"functionName":     "GetComplianceReport",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"projectSFID":      projectSFID,
+		"organizationName": organizationName,
+	}
+
+	org, err := s.getOrganization(ctx, projectSFID, organizationName)
+	if err != nil {
+		return nil, err
+	}
+	if org.OrganizationInstallationID == 0 {
+		return nil, ErrOrganizationNotInstalled
+	}
+
+	repos, err := s.githubRepo.GetRepositoriesByOrganizationName(ctx, organizationName)
+	if err != nil {
+		return nil, err
+	}
+
+	branchProtectionRepo, err := s.branchProtectionRepository(github_organizations.GithubInstallation(org))
+	if err != nil {
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	report := &ComplianceReport{
+		OrganizationName: organizationName,
+		ProjectSFID:      projectSFID,
+		DateChecked:      currentTime,
+	}
+	for _, repo := range repos {
+		if !repo.Enabled {
+			continue
+		}
+		report.RepositoriesChecked++
+
+		violation := func(branchName, policyID, reason, detail string) *Violation {
+			return &Violation{
+				RepositoryName: repo.RepositoryName,
+				RepositoryID:   repo.RepositoryID,
+				ClaGroupID:     repo.RepositoryProjectID,
+				BranchName:     branchName,
+				PolicyID:       policyID,
+				Reason:         reason,
+				Detail:         detail,
+			}
+		}
+
+		policy, err := s.ResolvePolicy(ctx, organizationName, repo.RepositoryProjectID)
+		if err != nil {
+			log.WithFields(f).Warnf("problem resolving the branch protection policy of repository : %s, error: %+v", repo.RepositoryName, err)
+			report.Violations = append(report.Violations, violation("", "", ReasonCheckFailed, err.Error()))
+			continue
+		}
+
+		branches, err := targetBranches(ctx, branchProtectionRepo, organizationName, repo.RepositoryName, policy)
+		if err != nil {
+			log.WithFields(f).Warnf("problem loading the branches of repository : %s, error: %+v", repo.RepositoryName, err)
+			report.Violations = append(report.Violations, violation("", policy.PolicyID, ReasonCheckFailed, err.Error()))
+			continue
+		}
+
+		for _, branch := range branches {
+			protection, err := branchProtectionRepo.GetProtectedBranch(ctx, organizationName, repo.RepositoryName, branch)
+			if err != nil {
+				if errors.Is(err, githubutils.ErrBranchNotProtected) {
+					report.Violations = append(report.Violations, violation(branch, policy.PolicyID, ReasonBranchNotProtected, "the branch is not protected"))
+					continue
+				}
+				log.WithFields(f).Warnf("problem loading the branch protection of repository : %s, branch : %s, error: %+v", repo.RepositoryName, branch, err)
+				report.Violations = append(report.Violations, violation(branch, policy.PolicyID, ReasonCheckFailed, err.Error()))
+				continue
+			}
+
+			for _, v := range checkProtection(protection, policy) {
+				report.Violations = append(report.Violations, violation(branch, policy.PolicyID, v.Reason, v.Detail))
+			}
+		}
+	}
+
+	log.WithFields(f).Debugf("checked %d repositories, found %d violations", report.RepositoriesChecked, len(report.Violations))
+	return report, nil
+}
+
+// checkProtection compares the branch protection with the policy, the returned violations only have the reason and
+// the detail set
+func checkProtection(protection *github.Protection, policy *Policy) []*Violation {
+	var violations []*Violation
+
+	var contexts []string
+	if protection.RequiredStatusChecks != nil {
+		contexts = protection.RequiredStatusChecks.Contexts
+	}
+	var missing []string
+	for _, check := range policy.RequiredChecks {
+		if !utils.StringInSlice(check, contexts) {
+			missing = append(missing, check)
+		}
+	}
+	if len(missing) > 0 {
+		violations = append(violations, &Violation{
+			Reason: ReasonMissingRequiredCheck,
+			Detail: fmt.Sprintf("the status checks %s are not required", strings.Join(missing, ",")),
+		})
+	}
+
+	if policy.EnforceAdmins && !githubutils.IsEnforceAdminEnabled(protection) {
+		violations = append(violations, &Violation{
+			Reason: ReasonAdminsNotEnforced,
+			Detail: "the branch protection is not enforced for administrators",
+		})
+	}
+
+	if policy.RequiredApprovingReviewCount > 0 {
+		reviewCount := 0
+		if protection.RequiredPullRequestReviews != nil {
+			reviewCount = protection.RequiredPullRequestReviews.RequiredApprovingReviewCount
+		}
+		if reviewCount < policy.RequiredApprovingReviewCount {
+			violations = append(violations, &Violation{
+				Reason: ReasonInsufficientReviews,
+				Detail: fmt.Sprintf("the branch requires %d approving reviews instead of %d", reviewCount, policy.RequiredApprovingReviewCount),
+			})
+		}
+	}
+
+	if policy.RequireLinearHistory && (protection.RequireLinearHistory == nil || !protection.RequireLinearHistory.Enabled) {
+		violations = append(violations, &Violation{
+			Reason: ReasonLinearHistoryNotRequired,
+			Detail: "the branch does not require linear history",
+		})
+	}
+
+	return violations
+}
@@ -0,0 +1,194 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	repositoriesmock "github.com/communitybridge/easycla/cla-backend-go/repositories/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/branch_protection"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/branch_protection/mock"
+	"github.com/go-openapi/swag"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-github/v32/github"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectPolicies expects any number of policy lookups, returning the given policies
+func expectPolicies(repo *mock.MockRepository, policies ...*branch_protection.Policy) {
+	repo.EXPECT().GetPolicy(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, policyID string) (*branch_protection.Policy, error) {
+		for _, policy := range policies {
+			if policy.PolicyID == policyID {
+				return policy, nil
+			}
+		}
+		return nil, branch_protection.ErrPolicyNotFound
+	}).AnyTimes()
+}
+
+func TestResolvePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orgPolicy := &branch_protection.Policy{PolicyID: branch_protection.PolicyID(branch_protection.ScopeGithubOrganization, "org")}
+	claGroupPolicy := &branch_protection.Policy{PolicyID: branch_protection.PolicyID(branch_protection.ScopeClaGroup, "cla-group-id")}
+	repo := mock.NewMockRepository(ctrl)
+	expectPolicies(repo, orgPolicy, claGroupPolicy)
+	s := branch_protection.NewTestService(repo, nil, nil, nil)
+
+	policy, err := s.ResolvePolicy(context.Background(), "org", "cla-group-id")
+	assert.Nil(t, err)
+	assert.Equal(t, orgPolicy, policy)
+
+	policy, err = s.ResolvePolicy(context.Background(), "other-org", "cla-group-id")
+	assert.Nil(t, err)
+	assert.Equal(t, claGroupPolicy, policy)
+
+	policy, err = s.ResolvePolicy(context.Background(), "other-org", "other-cla-group-id")
+	assert.Nil(t, err)
+	assert.Equal(t, branch_protection.DefaultPolicy(), policy)
+}
+
+func TestUpdateGithubOrganizationPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubOrgRepo := github_organizations.NewMockRepository(ctrl)
+	githubOrgRepo.EXPECT().GetGithubOrganizationForProject(gomock.Any(), "project-sfid", "org").Return(&v1Models.GithubOrganization{
+		OrganizationName: "org",
+		ProjectSFID:      "project-sfid",
+	}, nil).AnyTimes()
+	githubOrgRepo.EXPECT().GetGithubOrganizationForProject(gomock.Any(), "other-project-sfid", "org").Return(nil, github_organizations.ErrOrganizationDoesNotExist)
+	// only the valid input is stored
+	repo := mock.NewMockRepository(ctrl)
+	expectPolicies(repo)
+	repo.EXPECT().PutPolicy(gomock.Any(), gomock.Any()).Return(nil)
+	s := branch_protection.NewTestService(repo, nil, githubOrgRepo, nil)
+
+	policy, err := s.UpdateGithubOrganizationPolicy(context.Background(), "project-sfid", "org", &models.BranchProtectionPolicyInput{
+		BranchPatterns:               []string{"release/*", " release/* ", ""},
+		RequiredChecks:               []string{"ci", "EasyCLA"},
+		RequiredApprovingReviewCount: 2,
+	}, "user")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "github-org:org", policy.PolicyID)
+	assert.Equal(t, "project-sfid", policy.ProjectSFID)
+	assert.Equal(t, []string{"release/*"}, policy.BranchPatterns)
+	assert.Equal(t, []string{"EasyCLA", "ci"}, policy.RequiredChecks)
+	assert.True(t, policy.IncludeDefaultBranch)
+	assert.True(t, policy.EnforceAdmins)
+	assert.Equal(t, 2, policy.RequiredApprovingReviewCount)
+
+	_, err = s.UpdateGithubOrganizationPolicy(context.Background(), "project-sfid", "org", &models.BranchProtectionPolicyInput{
+		BranchPatterns: []string{"release/["},
+	}, "user")
+	assert.True(t, errors.Is(err, branch_protection.ErrInvalidPolicy))
+
+	_, err = s.UpdateGithubOrganizationPolicy(context.Background(), "project-sfid", "org", &models.BranchProtectionPolicyInput{
+		IncludeDefaultBranch: swag.Bool(false),
+	}, "user")
+	assert.True(t, errors.Is(err, branch_protection.ErrInvalidPolicy))
+
+	_, err = s.UpdateGithubOrganizationPolicy(context.Background(), "other-project-sfid", "org", &models.BranchProtectionPolicyInput{}, "user")
+	assert.Equal(t, github_organizations.ErrOrganizationDoesNotExist, err)
+}
+
+func TestApplyPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	branchProtection := githubutils.NewMockRepositories(ctrl)
+	branchProtection.EXPECT().Get(gomock.Any(), "org", "repo").Return(&github.Repository{DefaultBranch: github.String("main")}, nil, nil)
+	branchProtection.EXPECT().ListBranches(gomock.Any(), "org", "repo", gomock.Any()).Return([]*github.Branch{
+		{Name: github.String("main")},
+		{Name: github.String("release/1.0")},
+		{Name: github.String("feature/x")},
+	}, &github.Response{}, nil)
+	for _, branch := range []string{"main", "release/1.0"} {
+		branchProtection.EXPECT().GetBranchProtection(gomock.Any(), "org", "repo", branch).Return(&github.Protection{}, nil, nil)
+		branchProtection.EXPECT().UpdateBranchProtection(gomock.Any(), "org", "repo", branch, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error) {
+				assert.Equal(t, []string{"EasyCLA"}, preq.RequiredStatusChecks.Contexts)
+				assert.Equal(t, 1, preq.RequiredPullRequestReviews.RequiredApprovingReviewCount)
+				assert.True(t, preq.EnforceAdmins)
+				return nil, nil, nil
+			})
+	}
+
+	s := branch_protection.NewTestService(mock.NewMockRepository(ctrl), nil, nil, nil)
+	policy := &branch_protection.Policy{
+		BranchPatterns:               []string{"release/*"},
+		IncludeDefaultBranch:         true,
+		RequiredChecks:               []string{"EasyCLA"},
+		EnforceAdmins:                true,
+		RequiredApprovingReviewCount: 1,
+	}
+	err := s.ApplyPolicy(context.Background(), githubutils.NewBranchProtectionRepository(branchProtection), "org", "repo", policy)
+	assert.Nil(t, err)
+}
+
+func TestGetComplianceReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubOrgRepo := github_organizations.NewMockRepository(ctrl)
+	githubOrgRepo.EXPECT().GetGithubOrganizationForProject(gomock.Any(), "project-sfid", "org").Return(&v1Models.GithubOrganization{
+		OrganizationName:           "org",
+		ProjectSFID:                "project-sfid",
+		OrganizationInstallationID: 1,
+	}, nil)
+
+	githubRepo := repositoriesmock.NewMockRepository(ctrl)
+	githubRepo.EXPECT().GetRepositoriesByOrganizationName(gomock.Any(), "org").Return([]*v1Models.GithubRepository{
+		{RepositoryID: "compliant", RepositoryName: "org/compliant", RepositoryProjectID: "cla-group-id", Enabled: true},
+		{RepositoryID: "non-compliant", RepositoryName: "org/non-compliant", RepositoryProjectID: "cla-group-id", Enabled: true},
+		{RepositoryID: "disabled", RepositoryName: "org/disabled", RepositoryProjectID: "cla-group-id", Enabled: false},
+	}, nil)
+
+	branchProtection := githubutils.NewMockRepositories(ctrl)
+	branchProtection.EXPECT().Get(gomock.Any(), "org", "compliant").Return(&github.Repository{DefaultBranch: github.String("main")}, nil, nil)
+	branchProtection.EXPECT().GetBranchProtection(gomock.Any(), "org", "compliant", "main").Return(&github.Protection{
+		RequiredStatusChecks:       &github.RequiredStatusChecks{Contexts: []string{"EasyCLA"}},
+		EnforceAdmins:              &github.AdminEnforcement{Enabled: true},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 2},
+	}, nil, nil)
+	branchProtection.EXPECT().Get(gomock.Any(), "org", "non-compliant").Return(&github.Repository{DefaultBranch: github.String("main")}, nil, nil)
+	branchProtection.EXPECT().GetBranchProtection(gomock.Any(), "org", "non-compliant", "main").Return(&github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"ci"}},
+		EnforceAdmins:        &github.AdminEnforcement{Enabled: true},
+	}, nil, nil)
+
+	claGroupPolicy := &branch_protection.Policy{
+		PolicyID:                     branch_protection.PolicyID(branch_protection.ScopeClaGroup, "cla-group-id"),
+		IncludeDefaultBranch:         true,
+		RequiredChecks:               []string{"EasyCLA"},
+		EnforceAdmins:                true,
+		RequiredApprovingReviewCount: 1,
+	}
+	repo := mock.NewMockRepository(ctrl)
+	expectPolicies(repo, claGroupPolicy)
+	s := branch_protection.NewTestService(repo, githubRepo, githubOrgRepo, branchProtection)
+
+	report, err := s.GetComplianceReport(context.Background(), "project-sfid", "org")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, 2, report.RepositoriesChecked)
+	if assert.Len(t, report.Violations, 2) {
+		assert.Equal(t, branch_protection.ReasonMissingRequiredCheck, report.Violations[0].Reason)
+		assert.Equal(t, "non-compliant", report.Violations[0].RepositoryID)
+		assert.Equal(t, "main", report.Violations[0].BranchName)
+		assert.Equal(t, "cla-group:cla-group-id", report.Violations[0].PolicyID)
+		assert.Equal(t, branch_protection.ReasonInsufficientReviews, report.Violations[1].Reason)
+	}
+	assert.False(t, report.ToModel().Compliant)
+}
@@ -0,0 +1,245 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// maxDelegationPeriod is the longest a CLA manager delegation may last
+const maxDelegationPeriod = 365 * 24 * time.Hour
+
+// CreateCLAManagerDelegate delegates the approval list of the company for the CLA group to a user until the expiry
+// date, granting the delegation again updates its scope and expiry
+func (s *service) CreateCLAManagerDelegate(ctx context.Context, companySFID, claGroupID string, input *models.ClaManagerDelegateInput, authUser *auth.User) (*models.ClaManagerDelegate, error) {
+	f := logrus.Fields{
+		"functionName":   "CreateCLAManagerDelegate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companySFID":    companySFID,
+		"claGroupID":     claGroupID,
+		"authUsername":   authUser.UserName,
+	}
+
+	if input == nil || input.UserLFID == nil || *input.UserLFID == "" {
+		return nil, fmt.Errorf("%w: the user LFID is required", ErrInvalidDelegate)
+	}
+	if input.Scope == nil || !signatures.IsValidDelegateScope(*input.Scope) {
+		return nil, fmt.Errorf("%w: the scope must be one of %s or %s", ErrInvalidDelegate, signatures.DelegateScopeApprovalList, signatures.DelegateScopeViewOnly)
+	}
+	if input.DateExpires == nil {
+		return nil, fmt.Errorf("%w: the expiry date is required", ErrInvalidDelegate)
+	}
+	now := s.now().UTC()
+	expires, parseErr := time.Parse(time.RFC3339, *input.DateExpires)
+	if parseErr != nil {
+		return nil, fmt.Errorf("%w: the expiry date must be in RFC3339 format - %v", ErrInvalidDelegate, parseErr)
+	}
+	if !expires.After(now) || expires.Sub(now) > maxDelegationPeriod {
+		return nil, fmt.Errorf("%w: the expiry date must be in the future and at most one year from now", ErrInvalidDelegate)
+	}
+	userLFID := *input.UserLFID
+	f["userLFID"] = userLFID
+
+	companyModel, claGroupModel, sigModel, err := s.loadDelegationSignature(ctx, companySFID, claGroupID, authUser)
+	if err != nil {
+		return nil, err
+	}
+	for _, manager := range sigModel.SignatureACL {
+		if manager.LfUsername == userLFID {
+			log.WithFields(f).Warnf("user %s is already a CLA manager", userLFID)
+			return nil, ErrDelegateIsCLAManager
+		}
+	}
+
+	userModel, userErr := s.easyCLAUserService.GetUserByLFUserName(userLFID)
+	if userErr != nil || userModel == nil {
+		log.WithFields(f).Warnf("unable to load user by LFID: %s, error: %+v", userLFID, userErr)
+		return nil, ErrCLAUserNotFound
+	}
+
+	delegates, err := s.signatureService.GetSignatureDelegates(ctx, sigModel.SignatureID.String())
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the delegates of signature: %s, error: %+v", sigModel.SignatureID, err)
+		return nil, err
+	}
+	delegate := signatures.Delegate{
+		LfUsername:  userLFID,
+		Name:        userModel.Username,
+		Email:       userModel.LfEmail,
+		Scope:       *input.Scope,
+		DateExpires: expires.UTC().Format(time.RFC3339),
+		GrantedBy:   authUser.UserName,
+		DateCreated: now.Format(time.RFC3339),
+	}
+	if existing := signatures.FindDelegate(delegates, userLFID); existing != nil {
+		*existing = delegate
+	} else {
+		delegates = append(delegates, delegate)
+	}
+	// Drop the expired delegations while we are at it
+	delegates = signatures.ActiveDelegates(delegates, now)
+
+	log.WithFields(f).Debugf("delegating %s scope until %s", delegate.Scope, delegate.DateExpires)
+	if err := s.signatureService.UpdateSignatureDelegates(ctx, sigModel.SignatureID.String(), delegates); err != nil {
+		log.WithFields(f).Warnf("unable to update the delegates of signature: %s, error: %+v", sigModel.SignatureID, err)
+		return nil, err
+	}
+
+	s.eventService.LogEvent(&events.LogEventArgs{
+		EventType:     events.ClaManagerDelegateAdded,
+		ProjectID:     claGroupModel.ProjectID,
+		ClaGroupModel: claGroupModel,
+		CompanyID:     companyModel.CompanyID,
+		CompanyModel:  companyModel,
+		LfUsername:    authUser.UserName,
+		EventData: &events.CLAManagerDelegateAddedEventData{
+			CompanyName: companyModel.CompanyName,
+			ProjectName: claGroupModel.ProjectName,
+			UserName:    delegate.Name,
+			UserLFID:    delegate.LfUsername,
+			Scope:       delegate.Scope,
+			DateExpires: delegate.DateExpires,
+		},
+	})
+
+	return delegate.ToModel(now), nil
+}
+
+// ListCLAManagerDelegates returns the delegates of the company for the CLA group, including the expired ones
+func (s *service) ListCLAManagerDelegates(ctx context.Context, companySFID, claGroupID string) (*models.ClaManagerDelegateList, error) {
+	f := logrus.Fields{
+		"functionName":   "ListCLAManagerDelegates",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companySFID":    companySFID,
+		"claGroupID":     claGroupID,
+	}
+
+	_, _, sigModel, err := s.loadDelegationSignature(ctx, companySFID, claGroupID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	delegates, err := s.signatureService.GetSignatureDelegates(ctx, sigModel.SignatureID.String())
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the delegates of signature: %s, error: %+v", sigModel.SignatureID, err)
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	response := &models.ClaManagerDelegateList{List: []*models.ClaManagerDelegate{}}
+	for _, delegate := range delegates {
+		response.List = append(response.List, delegate.ToModel(now))
+	}
+	return response, nil
+}
+
+// DeleteCLAManagerDelegate revokes the delegation of the user before it expires
+func (s *service) DeleteCLAManagerDelegate(ctx context.Context, companySFID, claGroupID, userLFID string, authUser *auth.User) error {
+	f := logrus.Fields{
+		"functionName":   "DeleteCLAManagerDelegate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companySFID":    companySFID,
+		"claGroupID":     claGroupID,
+		"userLFID":       userLFID,
+		"authUsername":   authUser.UserName,
+	}
+
+	companyModel, claGroupModel, sigModel, err := s.loadDelegationSignature(ctx, companySFID, claGroupID, authUser)
+	if err != nil {
+		return err
+	}
+
+	delegates, err := s.signatureService.GetSignatureDelegates(ctx, sigModel.SignatureID.String())
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the delegates of signature: %s, error: %+v", sigModel.SignatureID, err)
+		return err
+	}
+	delegate := signatures.FindDelegate(delegates, userLFID)
+	if delegate == nil {
+		return ErrDelegateNotFound
+	}
+	removed := *delegate
+
+	remaining := make([]signatures.Delegate, 0, len(delegates))
+	for _, d := range delegates {
+		if d.LfUsername != userLFID {
+			remaining = append(remaining, d)
+		}
+	}
+	if err := s.signatureService.UpdateSignatureDelegates(ctx, sigModel.SignatureID.String(), remaining); err != nil {
+		log.WithFields(f).Warnf("unable to update the delegates of signature: %s, error: %+v", sigModel.SignatureID, err)
+		return err
+	}
+
+	s.eventService.LogEvent(&events.LogEventArgs{
+		EventType:     events.ClaManagerDelegateRemoved,
+		ProjectID:     claGroupModel.ProjectID,
+		ClaGroupModel: claGroupModel,
+		CompanyID:     companyModel.CompanyID,
+		CompanyModel:  companyModel,
+		LfUsername:    authUser.UserName,
+		EventData: &events.CLAManagerDelegateRemovedEventData{
+			CompanyName: companyModel.CompanyName,
+			ProjectName: claGroupModel.ProjectName,
+			UserName:    removed.Name,
+			UserLFID:    removed.LfUsername,
+		},
+	})
+
+	return nil
+}
+
+// loadDelegationSignature loads the company, the CLA group and the signed CCLA signature the delegates are stored on,
+// when provided the user it must be one of the CLA managers of the signature
+func (s *service) loadDelegationSignature(ctx context.Context, companySFID, claGroupID string, authUser *auth.User) (*v1Models.Company, *v1Models.ClaGroup, *v1Models.Signature, error) {
+	f := logrus.Fields{
+		"functionName":   "loadDelegationSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companySFID":    companySFID,
+		"claGroupID":     claGroupID,
+	}
+
+	companyModel, err := s.companyService.GetCompanyByExternalID(ctx, companySFID)
+	if err != nil || companyModel == nil {
+		log.WithFields(f).Warnf("unable to load company by SFID: %s, error: %+v", companySFID, err)
+		return nil, nil, nil, ErrCLACompanyNotFound
+	}
+
+	claGroupModel, err := s.projectService.GetCLAGroupByID(ctx, claGroupID)
+	if err != nil || claGroupModel == nil {
+		log.WithFields(f).Warnf("unable to load CLA group by ID: %s, error: %+v", claGroupID, err)
+		return nil, nil, nil, ErrClaGroupNotFound
+	}
+
+	signed, approved := true, true
+	pageSize := int64(1)
+	sigModel, err := s.signatureService.GetProjectCompanySignature(ctx, companyModel.CompanyID, claGroupID, &signed, &approved, nil, &pageSize)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the CCLA signature of company: %s, error: %+v", companyModel.CompanyID, err)
+		return nil, nil, nil, err
+	}
+	if sigModel == nil {
+		return nil, nil, nil, ErrCCLANotSigned
+	}
+
+	// Only the CLA managers may delegate, a delegate can not delegate further
+	if authUser != nil && !utils.CurrentUserInACL(authUser, sigModel.SignatureACL) {
+		log.WithFields(f).Warnf("user %s is not a CLA manager of the signature: %s", authUser.UserName, sigModel.SignatureID)
+		return nil, nil, nil, ErrNotCLAManager
+	}
+
+	return companyModel, claGroupModel, sigModel, nil
+}
@@ -0,0 +1,170 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	companyMock "github.com/communitybridge/easycla/cla-backend-go/company/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	eventsMock "github.com/communitybridge/easycla/cla-backend-go/events/mock"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	projectMock "github.com/communitybridge/easycla/cla-backend-go/project/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	signaturesMock "github.com/communitybridge/easycla/cla-backend-go/signatures/mock"
+	usersMock "github.com/communitybridge/easycla/cla-backend-go/users/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_manager"
+)
+
+var delegateTestNow = time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+const delegateTestSignatureID = "d2b3d3ea-5d3a-4f1b-9d2c-7a1e5b7f3c11"
+
+// delegateTestMocks holds the mocks of the delegate tests, the signature service keeps the delegates in delegates
+type delegateTestMocks struct {
+	service    cla_manager.Service
+	users      *usersMock.MockService
+	events     *eventsMock.MockService
+	delegates  []signatures.Delegate
+	eventTypes []string
+}
+
+func newDelegateTestService(ctrl *gomock.Controller) *delegateTestMocks {
+	m := &delegateTestMocks{}
+
+	companyService := companyMock.NewMockIService(ctrl)
+	companyService.EXPECT().GetCompanyByExternalID(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, companySFID string) (*v1Models.Company, error) {
+		return &v1Models.Company{CompanyID: "company-1", CompanyExternalID: companySFID, CompanyName: "Acme"}, nil
+	}).AnyTimes()
+
+	projectService := projectMock.NewMockService(ctrl)
+	projectService.EXPECT().GetCLAGroupByID(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, claGroupID string) (*v1Models.ClaGroup, error) {
+		return &v1Models.ClaGroup{ProjectID: claGroupID, ProjectName: "Project"}, nil
+	}).AnyTimes()
+
+	sigService := signaturesMock.NewMockSignatureService(ctrl)
+	sigService.EXPECT().GetProjectCompanySignature(gomock.Any(), "company-1", "cla-group", gomock.Any(), gomock.Any(), nil, gomock.Any()).Return(&v1Models.Signature{
+		SignatureID:  strfmt.UUID(delegateTestSignatureID),
+		SignatureACL: []v1Models.User{{LfUsername: "manager"}},
+	}, nil).AnyTimes()
+	sigService.EXPECT().GetSignatureDelegates(gomock.Any(), delegateTestSignatureID).DoAndReturn(func(ctx context.Context, signatureID string) ([]signatures.Delegate, error) {
+		return append([]signatures.Delegate{}, m.delegates...), nil
+	}).AnyTimes()
+	sigService.EXPECT().UpdateSignatureDelegates(gomock.Any(), delegateTestSignatureID, gomock.Any()).DoAndReturn(func(ctx context.Context, signatureID string, delegates []signatures.Delegate) error {
+		m.delegates = delegates
+		return nil
+	}).AnyTimes()
+
+	m.users = usersMock.NewMockService(ctrl)
+	m.events = eventsMock.NewMockService(ctrl)
+	m.events.EXPECT().LogEvent(gomock.Any()).Do(func(args *events.LogEventArgs) {
+		m.eventTypes = append(m.eventTypes, args.EventType)
+	}).AnyTimes()
+
+	m.service = cla_manager.NewTestService(companyService, projectService, m.users, m.events, sigService, func() time.Time { return delegateTestNow })
+	return m
+}
+
+func (m *delegateTestMocks) expectUser(lfUsername string) {
+	m.users.EXPECT().GetUserByLFUserName(lfUsername).Return(&v1Models.User{LfUsername: lfUsername, Username: "Team Lead", LfEmail: lfUsername + "@example.com"}, nil)
+}
+
+func delegateInput(userLFID, scope string, expires time.Time) *models.ClaManagerDelegateInput {
+	return &models.ClaManagerDelegateInput{
+		UserLFID:    aws.String(userLFID),
+		Scope:       aws.String(scope),
+		DateExpires: aws.String(expires.Format(time.RFC3339)),
+	}
+}
+
+func TestCreateCLAManagerDelegate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newDelegateTestService(ctrl)
+	manager := &auth.User{UserName: "manager"}
+	expires := delegateTestNow.Add(30 * 24 * time.Hour)
+
+	m.expectUser("lead")
+	delegate, err := m.service.CreateCLAManagerDelegate(context.Background(), "sfid", "cla-group", delegateInput("lead", signatures.DelegateScopeApprovalList, expires), manager)
+	assert.Nil(t, err)
+	assert.Equal(t, "lead", delegate.LfUsername)
+	assert.Equal(t, "Team Lead", delegate.Name)
+	assert.True(t, delegate.Active)
+	assert.Equal(t, []string{events.ClaManagerDelegateAdded}, m.eventTypes)
+
+	// Granting the delegation again updates it
+	m.expectUser("lead")
+	_, err = m.service.CreateCLAManagerDelegate(context.Background(), "sfid", "cla-group", delegateInput("lead", signatures.DelegateScopeViewOnly, expires), manager)
+	assert.Nil(t, err)
+	assert.Len(t, m.delegates, 1)
+	assert.Equal(t, signatures.DelegateScopeViewOnly, m.delegates[0].Scope)
+	assert.Equal(t, "manager", m.delegates[0].GrantedBy)
+}
+
+func TestCreateCLAManagerDelegateValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newDelegateTestService(ctrl)
+	manager := &auth.User{UserName: "manager"}
+	expires := delegateTestNow.Add(24 * time.Hour)
+
+	_, err := m.service.CreateCLAManagerDelegate(context.Background(), "sfid", "cla-group", delegateInput("lead", "admin", expires), manager)
+	assert.True(t, errors.Is(err, cla_manager.ErrInvalidDelegate))
+
+	_, err = m.service.CreateCLAManagerDelegate(context.Background(), "sfid", "cla-group", delegateInput("lead", signatures.DelegateScopeViewOnly, delegateTestNow.Add(-time.Hour)), manager)
+	assert.True(t, errors.Is(err, cla_manager.ErrInvalidDelegate))
+
+	_, err = m.service.CreateCLAManagerDelegate(context.Background(), "sfid", "cla-group", delegateInput("lead", signatures.DelegateScopeViewOnly, delegateTestNow.Add(400*24*time.Hour)), manager)
+	assert.True(t, errors.Is(err, cla_manager.ErrInvalidDelegate))
+
+	_, err = m.service.CreateCLAManagerDelegate(context.Background(), "sfid", "cla-group", delegateInput("manager", signatures.DelegateScopeViewOnly, expires), manager)
+	assert.True(t, errors.Is(err, cla_manager.ErrDelegateIsCLAManager))
+
+	// Only the CLA managers may delegate, including the delegates themselves
+	m.delegates = []signatures.Delegate{{LfUsername: "lead", Scope: signatures.DelegateScopeApprovalList, DateExpires: expires.Format(time.RFC3339)}}
+	_, err = m.service.CreateCLAManagerDelegate(context.Background(), "sfid", "cla-group", delegateInput("other", signatures.DelegateScopeViewOnly, expires), &auth.User{UserName: "lead"})
+	assert.True(t, errors.Is(err, cla_manager.ErrNotCLAManager))
+	assert.Len(t, m.delegates, 1)
+	assert.Empty(t, m.eventTypes)
+}
+
+func TestDeleteCLAManagerDelegate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newDelegateTestService(ctrl)
+	expires := delegateTestNow.Add(24 * time.Hour).Format(time.RFC3339)
+	m.delegates = []signatures.Delegate{
+		{LfUsername: "lead", Scope: signatures.DelegateScopeApprovalList, DateExpires: expires},
+		{LfUsername: "viewer", Scope: signatures.DelegateScopeViewOnly, DateExpires: expires},
+	}
+
+	err := m.service.DeleteCLAManagerDelegate(context.Background(), "sfid", "cla-group", "lead", &auth.User{UserName: "manager"})
+	assert.Nil(t, err)
+	assert.Len(t, m.delegates, 1)
+	assert.Equal(t, "viewer", m.delegates[0].LfUsername)
+	assert.Equal(t, []string{events.ClaManagerDelegateRemoved}, m.eventTypes)
+
+	err = m.service.DeleteCLAManagerDelegate(context.Background(), "sfid", "cla-group", "lead", &auth.User{UserName: "manager"})
+	assert.True(t, errors.Is(err, cla_manager.ErrDelegateNotFound))
+}
+
+func TestDelegateAllows(t *testing.T) {
+	delegate := signatures.Delegate{LfUsername: "lead", Scope: signatures.DelegateScopeViewOnly, DateExpires: delegateTestNow.Add(time.Hour).Format(time.RFC3339)}
+	assert.True(t, delegate.Allows(delegateTestNow, signatures.DelegateScopeApprovalList, signatures.DelegateScopeViewOnly))
+	assert.False(t, delegate.Allows(delegateTestNow, signatures.DelegateScopeApprovalList))
+	assert.False(t, delegate.Allows(delegateTestNow.Add(2*time.Hour), signatures.DelegateScopeViewOnly))
+}
@@ -0,0 +1,27 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager
+
+import (
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	easyCLAUser "github.com/communitybridge/easycla/cla-backend-go/users"
+)
+
+// NewTestService creates the service with the clock of the test
+func NewTestService(companyService company.IService, projectService project.Service, claUserService easyCLAUser.Service,
+	eventService events.Service, sigService signatures.SignatureService, now func() time.Time) Service {
+	return &service{
+		companyService:     companyService,
+		projectService:     projectService,
+		easyCLAUserService: claUserService,
+		eventService:       eventService,
+		signatureService:   sigService,
+		now:                now,
+	}
+}
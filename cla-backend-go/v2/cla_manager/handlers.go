@@ -5,6 +5,7 @@ package cla_manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/sirupsen/logrus"
@@ -33,6 +34,8 @@ const (
 	NotFound = "404"
 	//Accepted Response code
 	Accepted = "202"
+	// Forbidden error Response code
+	Forbidden = "403"
 )
 
 // Configure is the API handler routine for CLA Manager routes
@@ -358,6 +361,122 @@ func Configure(api *operations.EasyclaAPI, service Service, LfxPortalURL string,
 			return cla_manager.NewNotifyCLAManagersNoContent().WithXRequestID(reqID)
 		})
 
+	api.ClaManagerListCLAManagerDelegatesHandler = cla_manager.ListCLAManagerDelegatesHandlerFunc(
+		func(params cla_manager.ListCLAManagerDelegatesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ClaManagerListCLAManagerDelegatesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"authUserName":   authUser.UserName,
+			}
+
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to list the CLA Manager delegates with Organization scope of %s",
+					authUser.UserName, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return cla_manager.NewListCLAManagerDelegatesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			delegates, err := service.ListCLAManagerDelegates(ctx, params.CompanySFID, params.ClaGroupID)
+			if err != nil {
+				msg := "problem loading the CLA Manager delegates"
+				log.WithFields(f).WithError(err).Warn(msg)
+				if buildDelegateErrorStatusCode(err) == NotFound {
+					return cla_manager.NewListCLAManagerDelegatesNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				return cla_manager.NewListCLAManagerDelegatesInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return cla_manager.NewListCLAManagerDelegatesOK().WithXRequestID(reqID).WithPayload(delegates)
+		})
+
+	api.ClaManagerCreateCLAManagerDelegateHandler = cla_manager.CreateCLAManagerDelegateHandlerFunc(
+		func(params cla_manager.CreateCLAManagerDelegateParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ClaManagerCreateCLAManagerDelegateHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"authUserName":   authUser.UserName,
+			}
+
+			// The service checks the user is one of the CLA managers of the company for the CLA group
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to add a CLA Manager delegate with Organization scope of %s",
+					authUser.UserName, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return cla_manager.NewCreateCLAManagerDelegateForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			delegate, err := service.CreateCLAManagerDelegate(ctx, params.CompanySFID, params.ClaGroupID, params.Body, authUser)
+			if err != nil {
+				msg := "problem adding the CLA Manager delegate"
+				log.WithFields(f).WithError(err).Warn(msg)
+				switch buildDelegateErrorStatusCode(err) {
+				case BadRequest:
+					return cla_manager.NewCreateCLAManagerDelegateBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				case Forbidden:
+					return cla_manager.NewCreateCLAManagerDelegateForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
+				case NotFound:
+					return cla_manager.NewCreateCLAManagerDelegateNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				case Conflict:
+					return cla_manager.NewCreateCLAManagerDelegateConflict().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code:       Conflict,
+						Message:    fmt.Sprintf("EasyCLA - 409 Conflict - %s - %v", msg, err),
+						XRequestID: reqID,
+					})
+				}
+				return cla_manager.NewCreateCLAManagerDelegateInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return cla_manager.NewCreateCLAManagerDelegateOK().WithXRequestID(reqID).WithPayload(delegate)
+		})
+
+	api.ClaManagerDeleteCLAManagerDelegateHandler = cla_manager.DeleteCLAManagerDelegateHandlerFunc(
+		func(params cla_manager.DeleteCLAManagerDelegateParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ClaManagerDeleteCLAManagerDelegateHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"userLFID":       params.UserLFID,
+				"authUserName":   authUser.UserName,
+			}
+
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to remove a CLA Manager delegate with Organization scope of %s",
+					authUser.UserName, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return cla_manager.NewDeleteCLAManagerDelegateForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			err := service.DeleteCLAManagerDelegate(ctx, params.CompanySFID, params.ClaGroupID, params.UserLFID, authUser)
+			if err != nil {
+				msg := "problem removing the CLA Manager delegate"
+				log.WithFields(f).WithError(err).Warn(msg)
+				switch buildDelegateErrorStatusCode(err) {
+				case BadRequest:
+					return cla_manager.NewDeleteCLAManagerDelegateBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				case Forbidden:
+					return cla_manager.NewDeleteCLAManagerDelegateForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
+				case NotFound:
+					return cla_manager.NewDeleteCLAManagerDelegateNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				return cla_manager.NewDeleteCLAManagerDelegateInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return cla_manager.NewDeleteCLAManagerDelegateNoContent().WithXRequestID(reqID)
+		})
 }
 
 // buildErrorMessageCreate helper function to build an error message
@@ -388,3 +507,19 @@ func buildErrorStatusCode(err error) string {
 	// Return Bad Request
 	return BadRequest
 }
+
+// buildDelegateErrorStatusCode helper function to build the error status code of the CLA manager delegate routes
+func buildDelegateErrorStatusCode(err error) string {
+	switch {
+	case errors.Is(err, ErrInvalidDelegate):
+		return BadRequest
+	case errors.Is(err, ErrNotCLAManager):
+		return Forbidden
+	case errors.Is(err, ErrCLACompanyNotFound), errors.Is(err, ErrClaGroupNotFound), errors.Is(err, ErrCLAUserNotFound),
+		errors.Is(err, ErrCCLANotSigned), errors.Is(err, ErrDelegateNotFound):
+		return NotFound
+	case errors.Is(err, ErrDelegateIsCLAManager):
+		return Conflict
+	}
+	return ""
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/project"
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/organization-service/client/organizations"
 	"golang.org/x/sync/errgroup"
 
@@ -72,6 +73,16 @@ var (
 	ErrClaGroupNotFound = errors.New("cla group not found")
 	//ErrClaGroupBadRequest returns error if cla group bad request
 	ErrClaGroupBadRequest = errors.New("cla group bad request")
+	//ErrNotCLAManager returns error if the user is not a CLA manager of the company for the CLA group
+	ErrNotCLAManager = errors.New("user is not a cla manager")
+	//ErrCCLANotSigned returns error if the company has no signed CCLA for the CLA group
+	ErrCCLANotSigned = errors.New("company has no signed ccla")
+	//ErrInvalidDelegate returns error if the delegate input is not valid
+	ErrInvalidDelegate = errors.New("invalid cla manager delegate")
+	//ErrDelegateIsCLAManager returns error if the delegate is already a CLA manager
+	ErrDelegateIsCLAManager = errors.New("user is already cla-manager")
+	//ErrDelegateNotFound returns error if the user is not a delegate
+	ErrDelegateNotFound = errors.New("cla manager delegate not found")
 )
 
 const (
@@ -88,6 +99,8 @@ type service struct {
 	v2CompanyService    v2Company.Service
 	eventService        events.Service
 	projectCGRepo       projects_cla_groups.Repository
+	signatureService    signatures.SignatureService
+	now                 func() time.Time
 }
 
 // Service interface
@@ -99,12 +112,15 @@ type Service interface {
 	CreateCLAManagerRequest(ctx context.Context, contactAdmin bool, companyID string, projectID string, userEmail string, fullName string, authUser *auth.User, LfxPortalURL string) (*models.ClaManagerDesignee, error)
 	NotifyCLAManagers(ctx context.Context, otifyCLAManagers *models.NotifyClaManagerList) error
 	CreateCLAManagerDesigneeByGroup(ctx context.Context, params cla_manager.CreateCLAManagerDesigneeByGroupParams, projectCLAGroups []*projects_cla_groups.ProjectClaGroup, f logrus.Fields) ([]*models.ClaManagerDesignee, string, error)
+	CreateCLAManagerDelegate(ctx context.Context, companySFID, claGroupID string, input *models.ClaManagerDelegateInput, authUser *auth.User) (*models.ClaManagerDelegate, error)
+	ListCLAManagerDelegates(ctx context.Context, companySFID, claGroupID string) (*models.ClaManagerDelegateList, error)
+	DeleteCLAManagerDelegate(ctx context.Context, companySFID, claGroupID, userLFID string, authUser *auth.User) error
 }
 
 // NewService returns instance of CLA Manager service
 func NewService(compService company.IService, projService project.Service, mgrService v1ClaManager.IService, claUserService easyCLAUser.Service,
 	repoService repositories.Service, v2CompService v2Company.Service,
-	evService events.Service, projectCGroupRepo projects_cla_groups.Repository) Service {
+	evService events.Service, projectCGroupRepo projects_cla_groups.Repository, sigService signatures.SignatureService) Service {
 	return &service{
 		companyService:      compService,
 		projectService:      projService,
@@ -114,6 +130,8 @@ func NewService(compService company.IService, projService project.Service, mgrSe
 		v2CompanyService:    v2CompService,
 		eventService:        evService,
 		projectCGRepo:       projectCGroupRepo,
+		signatureService:    sigService,
+		now:                 time.Now,
 	}
 }
 
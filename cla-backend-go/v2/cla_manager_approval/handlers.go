@@ -0,0 +1,292 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager_approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1ClaManager "github.com/communitybridge/easycla/cla-backend-go/cla_manager"
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/cla_manager_approval"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1ClaManager.IService, companyService company.IService, signatureService signatures.SignatureService, eventsService events.Service) { // nolint
+	api.ClaManagerApprovalGetClaManagerApprovalPolicyHandler = cla_manager_approval.GetClaManagerApprovalPolicyHandlerFunc(
+		func(params cla_manager_approval.GetClaManagerApprovalPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ClaManagerApprovalGetClaManagerApprovalPolicyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to get the CLA Manager Approval Policy with Organization scope of %s",
+					authUser.UserName, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return cla_manager_approval.NewGetClaManagerApprovalPolicyForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			companyModel, err := companyService.GetCompanyByExternalID(ctx, params.CompanySFID)
+			if err != nil || companyModel == nil {
+				msg := fmt.Sprintf("unable to locate company by external company ID: %s", params.CompanySFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_manager_approval.NewGetClaManagerApprovalPolicyNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+
+			policy, err := service.GetApprovalPolicy(ctx, companyModel.CompanyID)
+			if err != nil {
+				msg := "problem loading the CLA manager approval policy"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_manager_approval.NewGetClaManagerApprovalPolicyInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return cla_manager_approval.NewGetClaManagerApprovalPolicyOK().WithXRequestID(reqID).WithPayload(policy.ToModel())
+		})
+
+	api.ClaManagerApprovalUpdateClaManagerApprovalPolicyHandler = cla_manager_approval.UpdateClaManagerApprovalPolicyHandlerFunc(
+		func(params cla_manager_approval.UpdateClaManagerApprovalPolicyParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ClaManagerApprovalUpdateClaManagerApprovalPolicyHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			// Only the company admins may change how the CLA manager requests are approved
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to update the CLA Manager Approval Policy with Organization scope of %s",
+					authUser.UserName, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return cla_manager_approval.NewUpdateClaManagerApprovalPolicyForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			companyModel, err := companyService.GetCompanyByExternalID(ctx, params.CompanySFID)
+			if err != nil || companyModel == nil {
+				msg := fmt.Sprintf("unable to locate company by external company ID: %s", params.CompanySFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_manager_approval.NewUpdateClaManagerApprovalPolicyNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+
+			policy := &v1ClaManager.ApprovalPolicy{
+				CompanyID:         companyModel.CompanyID,
+				RequiredApprovals: int(utils.Int64Value(params.Body.RequiredApprovals)),
+				ApproverRole:      utils.StringValue(params.Body.ApproverRole),
+				Approvers:         params.Body.Approvers,
+				ExpiryDays:        int(params.Body.ExpiryDays),
+				ReminderDays:      int(params.Body.ReminderDays),
+			}
+			policy, err = service.UpdateApprovalPolicy(ctx, policy, authUser.UserName)
+			if err != nil {
+				msg := "problem updating the CLA manager approval policy"
+				log.WithFields(f).WithError(err).Warn(msg)
+				if errors.Is(err, v1ClaManager.ErrInvalidApprovalPolicy) {
+					return cla_manager_approval.NewUpdateClaManagerApprovalPolicyBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				}
+				return cla_manager_approval.NewUpdateClaManagerApprovalPolicyInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventsService.LogEvent(&events.LogEventArgs{
+				EventType:    events.ClaManagerApprovalPolicyUpdated,
+				CompanyID:    companyModel.CompanyID,
+				CompanyModel: companyModel,
+				LfUsername:   authUser.UserName,
+				EventData: &events.CLAManagerApprovalPolicyUpdatedEventData{
+					RequiredApprovals: policy.RequiredApprovals,
+					ApproverRole:      policy.ApproverRole,
+					Approvers:         policy.Approvers,
+					ExpiryDays:        policy.ExpiryDays,
+					ReminderDays:      policy.ReminderDays,
+				},
+			})
+
+			return cla_manager_approval.NewUpdateClaManagerApprovalPolicyOK().WithXRequestID(reqID).WithPayload(policy.ToModel())
+		})
+
+	api.ClaManagerApprovalListClaManagerApprovalRequestsHandler = cla_manager_approval.ListClaManagerApprovalRequestsHandlerFunc(
+		func(params cla_manager_approval.ListClaManagerApprovalRequestsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ClaManagerApprovalListClaManagerApprovalRequestsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to list the CLA Manager Requests with Organization scope of %s",
+					authUser.UserName, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return cla_manager_approval.NewListClaManagerApprovalRequestsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			companyModel, err := companyService.GetCompanyByExternalID(ctx, params.CompanySFID)
+			if err != nil || companyModel == nil {
+				msg := fmt.Sprintf("unable to locate company by external company ID: %s", params.CompanySFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_manager_approval.NewListClaManagerApprovalRequestsNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+
+			requests, err := service.GetRequestsWithHistory(ctx, companyModel.CompanyID, params.ClaGroupID)
+			if err != nil {
+				msg := "problem loading the CLA manager requests"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_manager_approval.NewListClaManagerApprovalRequestsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.ClaManagerApprovalRequestList{
+				List: []*models.ClaManagerApprovalRequest{},
+			}
+			for i := range requests {
+				response.List = append(response.List, requests[i].ToApprovalModel())
+			}
+			return cla_manager_approval.NewListClaManagerApprovalRequestsOK().WithXRequestID(reqID).WithPayload(response)
+		})
+
+	api.ClaManagerApprovalApproveClaManagerRequestHandler = cla_manager_approval.ApproveClaManagerRequestHandlerFunc(
+		func(params cla_manager_approval.ApproveClaManagerRequestParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ClaManagerApprovalApproveClaManagerRequestHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"requestID":      params.RequestID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			companyModel, approver, errResponse := loadApprover(ctx, companyService, signatureService, authUser, params.CompanySFID, params.ClaGroupID, reqID)
+			if errResponse != nil {
+				log.WithFields(f).Warn(errResponse.Message)
+				return cla_manager_approval.NewApproveClaManagerRequestNotFound().WithXRequestID(reqID).WithPayload(errResponse)
+			}
+
+			var note string
+			if params.Body != nil {
+				note = params.Body.Note
+			}
+			request, err := service.ApproveRequest(ctx, companyModel.CompanyID, params.ClaGroupID, params.RequestID, approver, note)
+			if err != nil {
+				msg := "problem approving the CLA manager request"
+				log.WithFields(f).WithError(err).Warn(msg)
+				switch {
+				case errors.Is(err, v1ClaManager.ErrRequestNotFound):
+					return cla_manager_approval.NewApproveClaManagerRequestNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				case errors.Is(err, v1ClaManager.ErrNotApprover):
+					return cla_manager_approval.NewApproveClaManagerRequestForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
+				case errors.Is(err, v1ClaManager.ErrAlreadyApproved), errors.Is(err, v1ClaManager.ErrRequestNotPending),
+					errors.Is(err, v1ClaManager.ErrRequestExpired), errors.Is(err, v1ClaManager.ErrRequestModified):
+					return cla_manager_approval.NewApproveClaManagerRequestConflict().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code:       utils.String409,
+						Message:    fmt.Sprintf("%s - error: %+v", msg, err),
+						XRequestID: reqID,
+					})
+				}
+				return cla_manager_approval.NewApproveClaManagerRequestInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return cla_manager_approval.NewApproveClaManagerRequestOK().WithXRequestID(reqID).WithPayload(request.ToApprovalModel())
+		})
+
+	api.ClaManagerApprovalDenyClaManagerRequestHandler = cla_manager_approval.DenyClaManagerRequestHandlerFunc(
+		func(params cla_manager_approval.DenyClaManagerRequestParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ClaManagerApprovalDenyClaManagerRequestHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"requestID":      params.RequestID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			companyModel, approver, errResponse := loadApprover(ctx, companyService, signatureService, authUser, params.CompanySFID, params.ClaGroupID, reqID)
+			if errResponse != nil {
+				log.WithFields(f).Warn(errResponse.Message)
+				return cla_manager_approval.NewDenyClaManagerRequestNotFound().WithXRequestID(reqID).WithPayload(errResponse)
+			}
+
+			var note string
+			if params.Body != nil {
+				note = params.Body.Note
+			}
+			request, err := service.DenyRequest(ctx, companyModel.CompanyID, params.ClaGroupID, params.RequestID, approver, note)
+			if err != nil {
+				msg := "problem denying the CLA manager request"
+				log.WithFields(f).WithError(err).Warn(msg)
+				switch {
+				case errors.Is(err, v1ClaManager.ErrRequestNotFound):
+					return cla_manager_approval.NewDenyClaManagerRequestNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				case errors.Is(err, v1ClaManager.ErrNotApprover):
+					return cla_manager_approval.NewDenyClaManagerRequestForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
+				case errors.Is(err, v1ClaManager.ErrRequestNotPending), errors.Is(err, v1ClaManager.ErrRequestExpired),
+					errors.Is(err, v1ClaManager.ErrRequestModified):
+					return cla_manager_approval.NewDenyClaManagerRequestConflict().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code:       utils.String409,
+						Message:    fmt.Sprintf("%s - error: %+v", msg, err),
+						XRequestID: reqID,
+					})
+				}
+				return cla_manager_approval.NewDenyClaManagerRequestInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return cla_manager_approval.NewDenyClaManagerRequestOK().WithXRequestID(reqID).WithPayload(request.ToApprovalModel())
+		})
+}
+
+// loadApprover returns the company and the approver roles of the user - a CLA manager is on the ACL of the company
+// CCLA signature and a company admin has the organization scope
+func loadApprover(ctx context.Context, companyService company.IService, signatureService signatures.SignatureService, authUser *auth.User, companySFID, claGroupID, reqID string) (*v1Models.Company, *v1ClaManager.Approver, *models.ErrorResponse) {
+	companyModel, err := companyService.GetCompanyByExternalID(ctx, companySFID)
+	if err != nil || companyModel == nil {
+		return nil, nil, utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate company by external company ID: %s", companySFID))
+	}
+
+	signed, approved := true, true
+	pageSize := int64(1)
+	sigModel, err := signatureService.GetProjectCompanySignature(ctx, companyModel.CompanyID, claGroupID, &signed, &approved, nil, &pageSize)
+	if err != nil || sigModel == nil {
+		return nil, nil, utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate the CCLA signature of company ID: %s CLA Group ID: %s", companyModel.CompanyID, claGroupID))
+	}
+
+	return companyModel, &v1ClaManager.Approver{
+		LFUsername:     authUser.UserName,
+		Name:           authUser.UserName,
+		Email:          authUser.Email,
+		IsCLAManager:   utils.CurrentUserInACL(authUser, sigModel.SignatureACL),
+		IsCompanyAdmin: utils.IsUserAuthorizedForOrganization(authUser, companySFID),
+	}, nil
+}
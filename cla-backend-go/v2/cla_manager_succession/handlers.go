@@ -0,0 +1,112 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager_succession
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1ClaManagerSuccession "github.com/communitybridge/easycla/cla-backend-go/cla_manager_succession"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/cla_manager"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1ClaManagerSuccession.Service) {
+	api.ClaManagerListOrphanedCCLAsHandler = cla_manager.ListOrphanedCCLAsHandlerFunc(
+		func(params cla_manager.ListOrphanedCCLAsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ClaManagerListOrphanedCCLAsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to list the orphaned CCLAs with Organization scope of %s",
+					authUser.UserName, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return cla_manager.NewListOrphanedCCLAsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			orphans, err := service.GetOrphanedSignatures(ctx, params.CompanySFID)
+			if err != nil {
+				msg := "problem loading the orphaned CCLAs"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_manager.NewListOrphanedCCLAsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.OrphanedCclaList{
+				List: []*models.OrphanedCcla{},
+			}
+			for _, orphan := range orphans {
+				response.List = append(response.List, orphan.ToModel())
+			}
+			return cla_manager.NewListOrphanedCCLAsOK().WithXRequestID(reqID).WithPayload(response)
+		})
+
+	api.ClaManagerAppointCLAManagerSuccessorHandler = cla_manager.AppointCLAManagerSuccessorHandlerFunc(
+		func(params cla_manager.AppointCLAManagerSuccessorParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ClaManagerAppointCLAManagerSuccessorHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			// Only the company admins may appoint a successor, the CLA managers of an orphaned signature are gone
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to appoint a successor CLA Manager with Organization scope of %s",
+					authUser.UserName, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return cla_manager.NewAppointCLAManagerSuccessorForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			if params.Body == nil || params.Body.SuccessorLFID == nil || *params.Body.SuccessorLFID == "" {
+				msg := "the successor LFID is required"
+				log.WithFields(f).Warn(msg)
+				return cla_manager.NewAppointCLAManagerSuccessorBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+			}
+			f["successorLFID"] = *params.Body.SuccessorLFID
+
+			orphan, err := service.AppointSuccessor(ctx, params.CompanySFID, params.ClaGroupID, *params.Body.SuccessorLFID, params.Body.RemoveInactiveManagers, authUser)
+			if err != nil {
+				msg := "problem appointing the successor CLA Manager"
+				log.WithFields(f).WithError(err).Warn(msg)
+				switch {
+				case errors.Is(err, v1ClaManagerSuccession.ErrCompanyNotFound), errors.Is(err, v1ClaManagerSuccession.ErrCCLANotSigned),
+					errors.Is(err, v1ClaManagerSuccession.ErrSuccessorNotFound):
+					return cla_manager.NewAppointCLAManagerSuccessorNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				case errors.Is(err, v1ClaManagerSuccession.ErrInvalidSuccessor):
+					return cla_manager.NewAppointCLAManagerSuccessorBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				case errors.Is(err, v1ClaManagerSuccession.ErrNotOrphaned):
+					return cla_manager.NewAppointCLAManagerSuccessorConflict().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+						Code:       utils.String409,
+						Message:    fmt.Sprintf("%s - error: %+v", msg, err),
+						XRequestID: reqID,
+					})
+				}
+				return cla_manager.NewAppointCLAManagerSuccessorInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return cla_manager.NewAppointCLAManagerSuccessorOK().WithXRequestID(reqID).WithPayload(orphan.ToModel())
+		})
+}
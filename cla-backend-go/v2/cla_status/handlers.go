@@ -0,0 +1,159 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_status
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1ClaStatus "github.com/communitybridge/easycla/cla-backend-go/cla_status"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/cla_status"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service - the status check is public, callers are identified by their API
+// key, if any, or their IP address for rate limiting. The status history is limited to the CLA group project scope.
+func Configure(api *operations.EasyclaAPI, service v1ClaStatus.Service, rateLimiter v1ClaStatus.RateLimiter) {
+	api.ClaStatusCheckClaStatusHandler = cla_status.CheckClaStatusHandlerFunc(
+		func(params cla_status.CheckClaStatusParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ClaStatusCheckClaStatusHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			}
+
+			clientKey, isAPIKey := utils.GetAPIKeyID(authUser)
+			if !isAPIKey {
+				clientKey = clientIP(params.HTTPRequest)
+			}
+			f["clientKey"] = clientKey
+			if !rateLimiter.Allow(ctx, clientKey, isAPIKey) {
+				msg := "rate limit exceeded - please retry later or use an API key"
+				log.WithFields(f).Debug(msg)
+				return cla_status.NewCheckClaStatusTooManyRequests().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code:       "429",
+					Message:    "EasyCLA - 429 Too Many Requests - " + msg,
+					XRequestID: reqID,
+				})
+			}
+
+			var authors []v1ClaStatus.Author
+			for _, author := range params.Body.Authors {
+				if author == nil {
+					authors = append(authors, v1ClaStatus.Author{})
+					continue
+				}
+				authors = append(authors, v1ClaStatus.Author{
+					Email:       strings.TrimSpace(author.Email),
+					GithubLogin: strings.TrimSpace(author.GithubLogin),
+				})
+			}
+
+			result, err := service.CheckAuthors(ctx, params.Body.ProjectSfid, params.Body.Repository, authors)
+			if err != nil {
+				if err == v1ClaStatus.ErrInvalidInput {
+					return cla_status.NewCheckClaStatusBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "invalid CLA status check input", err))
+				}
+				if err == v1ClaStatus.ErrProjectNotFound {
+					return cla_status.NewCheckClaStatusNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, err.Error()))
+				}
+				msg := "problem checking CLA status"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_status.NewCheckClaStatusInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return cla_status.NewCheckClaStatusOK().WithXRequestID(reqID).WithPayload(result.ToModel())
+		})
+
+	api.ClaStatusGetClaStatusHistoryHandler = cla_status.GetClaStatusHistoryHandlerFunc(
+		func(params cla_status.GetClaStatusHistoryParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ClaStatusGetClaStatusHistoryHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if params.Body == nil || params.Body.ClaGroupID == nil || *params.Body.ClaGroupID == "" ||
+				params.Body.Timestamp == nil || *params.Body.Timestamp == "" {
+				msg := "the CLA group ID and the timestamp are required"
+				log.WithFields(f).Warn(msg)
+				return cla_status.NewGetClaStatusHistoryBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+			}
+			claGroupID := *params.Body.ClaGroupID
+			f["claGroupID"] = claGroupID
+
+			at, err := utils.ParseDateTime(*params.Body.Timestamp)
+			if err != nil {
+				return cla_status.NewGetClaStatusHistoryBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "invalid timestamp", err))
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				projectSFIDs, projectErr := service.GetClaGroupProjectSFIDs(claGroupID)
+				if projectErr != nil {
+					msg := "problem loading the projects of the CLA group"
+					log.WithFields(f).WithError(projectErr).Warn(msg)
+					return cla_status.NewGetClaStatusHistoryInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, projectErr))
+				}
+				if len(projectSFIDs) == 0 {
+					msg := fmt.Sprintf("CLA group %s is not associated with any project", claGroupID)
+					return cla_status.NewGetClaStatusHistoryNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				if !utils.IsUserAuthorizedForAnyProjects(authUser, projectSFIDs) {
+					msg := fmt.Sprintf("user %s does not have access to the CLA status history of CLA group %s", authUser.UserName, claGroupID)
+					log.WithFields(f).Warn(msg)
+					return cla_status.NewGetClaStatusHistoryForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+				}
+			}
+
+			author := v1ClaStatus.Author{
+				Email:       strings.TrimSpace(params.Body.Email),
+				GithubLogin: strings.TrimSpace(params.Body.GithubLogin),
+			}
+			result, err := service.GetHistoricalCoverage(ctx, claGroupID, author, at)
+			if err != nil {
+				if err == v1ClaStatus.ErrInvalidHistoryInput {
+					return cla_status.NewGetClaStatusHistoryBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "invalid CLA status history input", err))
+				}
+				msg := "problem reconstructing the CLA status history"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return cla_status.NewGetClaStatusHistoryInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return cla_status.NewGetClaStatusHistoryOK().WithXRequestID(reqID).WithPayload(result.ToModel())
+		})
+}
+
+// clientIP returns the IP address of the caller - the API Gateway appends the source IP to the X-Forwarded-For
+// header, the entries before it are set by the client and can not be trusted
+func clientIP(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	if forwardedFor := r.Header["X-Forwarded-For"]; len(forwardedFor) > 0 {
+		hops := strings.Split(forwardedFor[len(forwardedFor)-1], ",")
+		if lastHop := strings.TrimSpace(hops[len(hops)-1]); lastHop != "" {
+			return lastHop
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -822,7 +822,19 @@ func (s *service) GetCompanyCLAGroupManagers(ctx context.Context, companyID, cla
 		})
 	}
 
-	return &models.CompanyClaManagers{List: claManagers}, nil
+	// The delegates are shown alongside the CLA managers, the expired ones no longer have any permissions
+	delegates, delegatesErr := s.signatureRepo.GetSignatureDelegates(ctx, sigModel.SignatureID.String())
+	if delegatesErr != nil {
+		log.WithFields(f).Warnf("unable to query the delegates of signature ID: %s, error: %+v", sigModel.SignatureID, delegatesErr)
+		return nil, delegatesErr
+	}
+	now := time.Now().UTC()
+	claManagerDelegates := make([]*models.ClaManagerDelegate, 0)
+	for _, delegate := range signatures.ActiveDelegates(delegates, now) {
+		claManagerDelegates = append(claManagerDelegates, delegate.ToModel(now))
+	}
+
+	return &models.CompanyClaManagers{List: claManagers, Delegates: claManagerDelegates}, nil
 }
 
 func (s *service) AssignCompanyOwner(ctx context.Context, companySFID string, userEmail string, LFXPortalURL string) (*models.CompanyOwner, error) {
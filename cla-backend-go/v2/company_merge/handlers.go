@@ -0,0 +1,130 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company_merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1Company "github.com/communitybridge/easycla/cla-backend-go/company"
+	v1CompanyMerge "github.com/communitybridge/easycla/cla-backend-go/company_merge"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/company"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1CompanyMerge.Service, eventService events.Service) {
+	api.CompanyGetCompanyDuplicateCandidatesHandler = company.GetCompanyDuplicateCandidatesHandlerFunc(
+		func(params company.GetCompanyDuplicateCandidatesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "CompanyGetCompanyDuplicateCandidatesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to Get Company Duplicate Candidates - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return company.NewGetCompanyDuplicateCandidatesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			candidates, err := service.GetDuplicateCandidates(ctx)
+			if err != nil {
+				msg := "problem loading the duplicate company candidates"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return company.NewGetCompanyDuplicateCandidatesInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.CompanyDuplicateCandidateList{
+				List: []*models.CompanyDuplicateCandidate{},
+			}
+			for _, candidate := range candidates {
+				response.List = append(response.List, candidate.ToModel())
+			}
+			return company.NewGetCompanyDuplicateCandidatesOK().WithXRequestID(reqID).WithPayload(response)
+		})
+
+	api.CompanyMergeCompaniesHandler = company.MergeCompaniesHandlerFunc(
+		func(params company.MergeCompaniesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "CompanyMergeCompaniesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"companyID":      params.CompanyID,
+				"dryRun":         params.Body.DryRun,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to Merge Companies - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return company.NewMergeCompaniesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			report, err := service.MergeCompanies(ctx, params.CompanyID, params.Body.DuplicateCompanyIDList, params.Body.DryRun)
+			if err != nil {
+				if errors.Is(err, v1Company.ErrCompanyDoesNotExist) {
+					msg := fmt.Sprintf("company not found, error: %v", err)
+					return company.NewMergeCompaniesNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				if errors.Is(err, v1CompanyMerge.ErrCompanyAlreadyMerged) || errors.Is(err, v1CompanyMerge.ErrNoDuplicateCompanies) {
+					return company.NewMergeCompaniesBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "unable to merge the companies", err))
+				}
+				msg := fmt.Sprintf("problem merging the companies into company %s", params.CompanyID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return company.NewMergeCompaniesInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			if !report.DryRun {
+				for _, duplicate := range report.Duplicates {
+					eventService.LogEvent(&events.LogEventArgs{
+						LfUsername: authUser.UserName,
+						EventType:  events.CompanyMerged,
+						CompanyID:  report.CompanyID,
+						EventData: &events.CompanyMergedEventData{
+							DuplicateCompanyID:        duplicate.CompanyID,
+							DuplicateCompanyName:      duplicate.CompanyName,
+							SignaturesMoved:           len(duplicate.SignaturesMoved),
+							SignaturesMerged:          len(duplicate.SignaturesMerged),
+							ApprovalListRequestsMoved: len(duplicate.ApprovalListRequestsMoved),
+							ClaManagerRequestsMoved:   len(duplicate.ClaManagerRequestsMoved),
+							InvitesMoved:              len(duplicate.InvitesMoved),
+						},
+					})
+					for _, entry := range duplicate.ApprovalListEntriesAdded {
+						eventService.LogEvent(&events.LogEventArgs{
+							LfUsername: authUser.UserName,
+							EventType:  events.ClaApprovalListUpdated,
+							ProjectID:  entry.ClaGroupID,
+							CompanyID:  report.CompanyID,
+							EventData: &events.CLAApprovalListEntryMergedData{
+								ListType:             string(entry.ListType),
+								Value:                entry.Value,
+								DuplicateCompanyID:   duplicate.CompanyID,
+								DuplicateCompanyName: duplicate.CompanyName,
+							},
+						})
+					}
+				}
+			}
+
+			return company.NewMergeCompaniesOK().WithXRequestID(reqID).WithPayload(report.ToModel())
+		})
+}
@@ -0,0 +1,250 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package data_subject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1DataSubject "github.com/communitybridge/easycla/cla-backend-go/data_subject"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/data_subject_requests"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1DataSubject.Service, eventService events.Service) {
+	api.DataSubjectRequestsListDataSubjectRequestsHandler = data_subject_requests.ListDataSubjectRequestsHandlerFunc(
+		func(params data_subject_requests.ListDataSubjectRequestsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "DataSubjectRequestsListDataSubjectRequestsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to List Data Subject Requests - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return data_subject_requests.NewListDataSubjectRequestsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			requests, err := service.GetRequests(ctx, v1DataSubject.Status(utils.StringValue(params.Status)))
+			if err != nil {
+				msg := "problem loading the data subject requests"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return data_subject_requests.NewListDataSubjectRequestsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.DataSubjectRequestList{
+				List: []*models.DataSubjectRequest{},
+			}
+			for _, request := range requests {
+				response.List = append(response.List, request.ToModel())
+			}
+			return data_subject_requests.NewListDataSubjectRequestsOK().WithXRequestID(reqID).WithPayload(response)
+		})
+
+	api.DataSubjectRequestsCreateDataSubjectRequestHandler = data_subject_requests.CreateDataSubjectRequestHandlerFunc(
+		func(params data_subject_requests.CreateDataSubjectRequestParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "DataSubjectRequestsCreateDataSubjectRequestHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"requestType":    utils.StringValue(params.Body.RequestType),
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to Create Data Subject Request - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return data_subject_requests.NewCreateDataSubjectRequestForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			request, err := service.CreateRequest(ctx, &v1DataSubject.CreateRequestInput{
+				RequestType:    v1DataSubject.RequestType(utils.StringValue(params.Body.RequestType)),
+				UserID:         params.Body.UserID,
+				Email:          params.Body.Email,
+				GithubUsername: params.Body.GithubUsername,
+				Reason:         params.Body.Reason,
+			}, authUser.UserName)
+			if err != nil {
+				if errors.Is(err, v1DataSubject.ErrInvalidRequestType) || errors.Is(err, v1DataSubject.ErrNoSubjectIdentifier) {
+					return data_subject_requests.NewCreateDataSubjectRequestBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "unable to create the data subject request", err))
+				}
+				msg := "problem creating the data subject request"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return data_subject_requests.NewCreateDataSubjectRequestInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			logDataSubjectRequestEvent(eventService, authUser, events.DataSubjectRequestCreated, request)
+			return data_subject_requests.NewCreateDataSubjectRequestOK().WithXRequestID(reqID).WithPayload(request.ToModel())
+		})
+
+	api.DataSubjectRequestsGetDataSubjectRequestHandler = data_subject_requests.GetDataSubjectRequestHandlerFunc(
+		func(params data_subject_requests.GetDataSubjectRequestParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "DataSubjectRequestsGetDataSubjectRequestHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"requestID":      params.RequestID,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to Get Data Subject Request - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return data_subject_requests.NewGetDataSubjectRequestForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			request, err := service.GetRequest(ctx, params.RequestID)
+			if err != nil {
+				if errors.Is(err, v1DataSubject.ErrRequestNotFound) {
+					return data_subject_requests.NewGetDataSubjectRequestNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, err.Error()))
+				}
+				msg := fmt.Sprintf("problem loading the data subject request %s", params.RequestID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return data_subject_requests.NewGetDataSubjectRequestInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return data_subject_requests.NewGetDataSubjectRequestOK().WithXRequestID(reqID).WithPayload(request.ToModel())
+		})
+
+	api.DataSubjectRequestsApproveDataSubjectRequestHandler = data_subject_requests.ApproveDataSubjectRequestHandlerFunc(
+		func(params data_subject_requests.ApproveDataSubjectRequestParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "DataSubjectRequestsApproveDataSubjectRequestHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"requestID":      params.RequestID,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to Approve Data Subject Request - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return data_subject_requests.NewApproveDataSubjectRequestForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			request, err := service.ApproveRequest(ctx, params.RequestID, authUser.UserName, params.Body.Note)
+			if err != nil {
+				if errors.Is(err, v1DataSubject.ErrRequestNotFound) {
+					return data_subject_requests.NewApproveDataSubjectRequestNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, err.Error()))
+				}
+				if errors.Is(err, v1DataSubject.ErrRequestNotPending) || errors.Is(err, v1DataSubject.ErrSelfReview) {
+					return data_subject_requests.NewApproveDataSubjectRequestBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "unable to approve the data subject request", err))
+				}
+				msg := fmt.Sprintf("problem approving the data subject request %s", params.RequestID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return data_subject_requests.NewApproveDataSubjectRequestInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			logDataSubjectRequestEvent(eventService, authUser, events.DataSubjectRequestApproved, request)
+			return data_subject_requests.NewApproveDataSubjectRequestOK().WithXRequestID(reqID).WithPayload(request.ToModel())
+		})
+
+	api.DataSubjectRequestsRejectDataSubjectRequestHandler = data_subject_requests.RejectDataSubjectRequestHandlerFunc(
+		func(params data_subject_requests.RejectDataSubjectRequestParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "DataSubjectRequestsRejectDataSubjectRequestHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"requestID":      params.RequestID,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to Reject Data Subject Request - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return data_subject_requests.NewRejectDataSubjectRequestForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			request, err := service.RejectRequest(ctx, params.RequestID, authUser.UserName, params.Body.Note)
+			if err != nil {
+				if errors.Is(err, v1DataSubject.ErrRequestNotFound) {
+					return data_subject_requests.NewRejectDataSubjectRequestNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, err.Error()))
+				}
+				if errors.Is(err, v1DataSubject.ErrRequestNotPending) || errors.Is(err, v1DataSubject.ErrSelfReview) {
+					return data_subject_requests.NewRejectDataSubjectRequestBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "unable to reject the data subject request", err))
+				}
+				msg := fmt.Sprintf("problem rejecting the data subject request %s", params.RequestID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return data_subject_requests.NewRejectDataSubjectRequestInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			logDataSubjectRequestEvent(eventService, authUser, events.DataSubjectRequestRejected, request)
+			return data_subject_requests.NewRejectDataSubjectRequestOK().WithXRequestID(reqID).WithPayload(request.ToModel())
+		})
+
+	api.DataSubjectRequestsGetDataSubjectRequestExportHandler = data_subject_requests.GetDataSubjectRequestExportHandlerFunc(
+		func(params data_subject_requests.GetDataSubjectRequestExportParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "DataSubjectRequestsGetDataSubjectRequestExportHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"requestID":      params.RequestID,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to Get Data Subject Request Export - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return data_subject_requests.NewGetDataSubjectRequestExportForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			url, err := service.GetExportURL(ctx, params.RequestID, authUser.UserName)
+			if err != nil {
+				if errors.Is(err, v1DataSubject.ErrRequestNotFound) || errors.Is(err, v1DataSubject.ErrExportNotAvailable) {
+					return data_subject_requests.NewGetDataSubjectRequestExportNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, err.Error()))
+				}
+				msg := fmt.Sprintf("problem loading the export of the data subject request %s", params.RequestID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return data_subject_requests.NewGetDataSubjectRequestExportInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return data_subject_requests.NewGetDataSubjectRequestExportOK().WithXRequestID(reqID).WithPayload(&models.DataSubjectRequestExport{
+				URL: url,
+			})
+		})
+}
+
+// logDataSubjectRequestEvent logs the event of the data subject request, the identifiers of the subject are left
+// out of the event
+func logDataSubjectRequestEvent(eventService events.Service, authUser *auth.User, eventType string, request *v1DataSubject.Request) {
+	eventService.LogEvent(&events.LogEventArgs{
+		LfUsername: authUser.UserName,
+		EventType:  eventType,
+		EventData: &events.DataSubjectRequestEventData{
+			RequestID:   request.RequestID,
+			RequestType: string(request.RequestType),
+			Status:      string(request.Status),
+		},
+	})
+}
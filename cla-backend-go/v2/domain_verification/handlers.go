@@ -0,0 +1,216 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package domain_verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1Company "github.com/communitybridge/easycla/cla-backend-go/company"
+	v1DomainVerification "github.com/communitybridge/easycla/cla-backend-go/domain_verification"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/company"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1DomainVerification.Service, companyService v1Company.IService, eventService events.Service) {
+	api.CompanyListCompanyDomainVerificationsHandler = company.ListCompanyDomainVerificationsHandlerFunc(
+		func(params company.ListCompanyDomainVerificationsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "CompanyListCompanyDomainVerificationsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to List Company Domain Verifications with Organization scope of %s", authUser.UserName, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return company.NewListCompanyDomainVerificationsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			companyModel, err := companyService.GetCompanyByExternalID(ctx, params.CompanySFID)
+			if err != nil || companyModel == nil {
+				msg := fmt.Sprintf("unable to locate company by external company ID: %s", params.CompanySFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return company.NewListCompanyDomainVerificationsNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+
+			verifications, err := service.GetDomainVerifications(ctx, companyModel.CompanyID)
+			if err != nil {
+				msg := "problem loading the company domain verifications"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return company.NewListCompanyDomainVerificationsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.DomainVerificationList{
+				List: []*models.DomainVerification{},
+			}
+			for _, verification := range verifications {
+				response.List = append(response.List, verification.ToModel())
+			}
+			return company.NewListCompanyDomainVerificationsOK().WithXRequestID(reqID).WithPayload(response)
+		})
+
+	api.CompanyStartCompanyDomainVerificationHandler = company.StartCompanyDomainVerificationHandlerFunc(
+		func(params company.StartCompanyDomainVerificationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "CompanyStartCompanyDomainVerificationHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"domain":         utils.StringValue(params.Body.Domain),
+				"method":         utils.StringValue(params.Body.Method),
+			}
+
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to Start Company Domain Verification with Organization scope of %s", authUser.UserName, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return company.NewStartCompanyDomainVerificationForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			companyModel, err := companyService.GetCompanyByExternalID(ctx, params.CompanySFID)
+			if err != nil || companyModel == nil {
+				msg := fmt.Sprintf("unable to locate company by external company ID: %s", params.CompanySFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return company.NewStartCompanyDomainVerificationNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+
+			verification, err := service.StartVerification(ctx, companyModel, utils.StringValue(params.Body.Domain),
+				v1DomainVerification.Method(utils.StringValue(params.Body.Method)), authUser.UserName)
+			if err != nil {
+				if isBadRequest(err) {
+					return company.NewStartCompanyDomainVerificationBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "unable to start the domain verification", err))
+				}
+				msg := "problem starting the domain verification"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return company.NewStartCompanyDomainVerificationInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return company.NewStartCompanyDomainVerificationOK().WithXRequestID(reqID).WithPayload(verification.ToModel())
+		})
+
+	api.CompanyVerifyCompanyDomainHandler = company.VerifyCompanyDomainHandlerFunc(
+		func(params company.VerifyCompanyDomainParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "CompanyVerifyCompanyDomainHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"domain":         utils.StringValue(params.Body.Domain),
+			}
+
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to Verify Company Domain with Organization scope of %s", authUser.UserName, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return company.NewVerifyCompanyDomainForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			companyModel, err := companyService.GetCompanyByExternalID(ctx, params.CompanySFID)
+			if err != nil || companyModel == nil {
+				msg := fmt.Sprintf("unable to locate company by external company ID: %s", params.CompanySFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return company.NewVerifyCompanyDomainNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+
+			verification, err := service.VerifyDomain(ctx, companyModel.CompanyID, utils.StringValue(params.Body.Domain), params.Body.Code)
+			if err != nil {
+				if errors.Is(err, v1DomainVerification.ErrNoPendingChallenge) {
+					return company.NewVerifyCompanyDomainNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, err.Error()))
+				}
+				if isBadRequest(err) {
+					return company.NewVerifyCompanyDomainBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "unable to verify the domain", err))
+				}
+				msg := "problem verifying the domain"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return company.NewVerifyCompanyDomainInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			logDomainVerifiedEvent(eventService, authUser, companyModel, verification)
+			return company.NewVerifyCompanyDomainOK().WithXRequestID(reqID).WithPayload(verification.ToModel())
+		})
+
+	api.CompanyDeleteCompanyDomainVerificationHandler = company.DeleteCompanyDomainVerificationHandlerFunc(
+		func(params company.DeleteCompanyDomainVerificationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "CompanyDeleteCompanyDomainVerificationHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companySFID":    params.CompanySFID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"domain":         params.Domain,
+			}
+
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to Delete Company Domain Verification with Organization scope of %s", authUser.UserName, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return company.NewDeleteCompanyDomainVerificationForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			companyModel, err := companyService.GetCompanyByExternalID(ctx, params.CompanySFID)
+			if err != nil || companyModel == nil {
+				msg := fmt.Sprintf("unable to locate company by external company ID: %s", params.CompanySFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return company.NewDeleteCompanyDomainVerificationNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+
+			err = service.DeleteDomainVerification(ctx, companyModel.CompanyID, params.Domain)
+			if err != nil {
+				if errors.Is(err, v1DomainVerification.ErrDomainVerificationNotFound) {
+					return company.NewDeleteCompanyDomainVerificationNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, err.Error()))
+				}
+				msg := "problem deleting the domain verification"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return company.NewDeleteCompanyDomainVerificationInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return company.NewDeleteCompanyDomainVerificationNoContent().WithXRequestID(reqID)
+		})
+}
+
+// isBadRequest returns true for the errors caused by the request input
+func isBadRequest(err error) bool {
+	return errors.Is(err, v1DomainVerification.ErrInvalidDomain) ||
+		errors.Is(err, v1DomainVerification.ErrDomainDenied) ||
+		errors.Is(err, v1DomainVerification.ErrUnknownMethod) ||
+		errors.Is(err, v1DomainVerification.ErrChallengeFailed)
+}
+
+// logDomainVerifiedEvent logs the event of the verified domain
+func logDomainVerifiedEvent(eventService events.Service, authUser *auth.User, companyModel *v1Models.Company, verification *v1DomainVerification.DomainVerification) {
+	eventService.LogEvent(&events.LogEventArgs{
+		LfUsername:   authUser.UserName,
+		EventType:    events.CompanyDomainVerified,
+		CompanyModel: companyModel,
+		EventData: &events.CompanyDomainVerifiedEventData{
+			Domain: verification.Domain,
+			Method: string(verification.Method),
+		},
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/project"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
 	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
@@ -75,7 +76,9 @@ func (a *autoEnableServiceProvider) CreateAutoEnabledRepository(repo *github.Rep
 
 	organizationName := strings.Split(repositoryFullName, "/")[0]
 	ctx := context.Background()
-	orgModel, err := a.githubOrgRepo.GetGithubOrganization(ctx, organizationName)
+	// the organization is looked up on the GitHub host of the repository
+	organizationKey := github_organizations.OrganizationKey(githubutils.EnterpriseHost(repo.GetHTMLURL()), organizationName)
+	orgModel, err := a.githubOrgRepo.GetGithubOrganization(ctx, organizationKey)
 	if err != nil {
 		log.Warnf("fetching github org failed : %v", err)
 		return nil, err
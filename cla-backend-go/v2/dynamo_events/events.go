@@ -8,14 +8,20 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	v2ProjectService "github.com/communitybridge/easycla/cla-backend-go/v2/project-service"
+	"github.com/communitybridge/easycla/cla-backend-go/webhooks"
 	"github.com/sirupsen/logrus"
 )
 
 // Event data model
 type Event struct {
-	EventID        string `json:"event_id"`
-	EventProjectID string `json:"event_project_id"`
-	EventCompanyID string `json:"event_company_id"`
+	EventID             string `json:"event_id"`
+	EventType           string `json:"event_type"`
+	EventTime           string `json:"event_time"`
+	EventProjectID      string `json:"event_project_id"`
+	EventCompanyID      string `json:"event_company_id"`
+	EventData           string `json:"event_data"`
+	EventSummary        string `json:"event_summary"`
+	EventFoundationSFID string `json:"event_foundation_sfid"`
 }
 
 // should be called when we insert Event
@@ -58,5 +64,28 @@ func (s *service) EventAddedEvent(event events.DynamoDBEventRecord) error {
 	if err != nil {
 		return err
 	}
+
+	if s.webhooksService != nil {
+		if foundationSFID == "" {
+			foundationSFID = newEvent.EventFoundationSFID
+		}
+		// the deliveries are sent by the webhook retry lambda, only queueing them must succeed - a queueing error
+		// fails the stream batch so the record is processed again
+		err = s.webhooksService.Dispatch(ctx, &webhooks.Event{
+			EventID:        newEvent.EventID,
+			EventType:      newEvent.EventType,
+			EventTime:      newEvent.EventTime,
+			FoundationSFID: foundationSFID,
+			ProjectSFID:    projectSFID,
+			ClaGroupID:     newEvent.EventProjectID,
+			CompanySFID:    companySFID,
+			Summary:        newEvent.EventSummary,
+			Data:           newEvent.EventData,
+		})
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to dispatch the event to the webhook subscriptions")
+			return &retryableError{err: err}
+		}
+	}
 	return nil
 }
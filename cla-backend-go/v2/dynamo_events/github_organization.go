@@ -5,14 +5,14 @@ package dynamo_events
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aws/aws-lambda-go/events"
-	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_jobs"
 	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/sync/errgroup"
 )
 
 // GitHubOrgAddedEvent github repository added event
@@ -118,6 +118,8 @@ func (s *service) GitHubOrgDeletedEvent(event events.DynamoDBEventRecord) error
 	return nil
 }
 
+// enableBranchProtectionForGithubOrg queues a github job applying the branch protection policy to all the repositories
+// of the organization, so large organizations are processed within the rate limit of the GitHub App installation
 func (s *service) enableBranchProtectionForGithubOrg(f logrus.Fields, newGitHubOrg github_organizations.GithubOrganization) error {
 	// Locate the repositories already saved under this organization
 	log.WithFields(f).Debugf("loading repositories under the organization : %s", newGitHubOrg.OrganizationName)
@@ -127,50 +129,29 @@ func (s *service) enableBranchProtectionForGithubOrg(f logrus.Fields, newGitHubO
 		return err
 	}
 
-	ctx := context.Background()
-	log.WithFields(f).Debugf("creating a new GitHub client object for org: %s...", newGitHubOrg.OrganizationName)
-	gitHubClient, clientErr := githubutils.NewGithubAppClient(newGitHubOrg.OrganizationInstallationID)
-	if clientErr != nil {
-		return clientErr
+	if newGitHubOrg.OrganizationInstallationID == 0 {
+		log.WithFields(f).Warnf("missing installation id for : %s", newGitHubOrg.OrganizationName)
+		return fmt.Errorf("missing installation id")
 	}
 
-	branchProtectionRepo := githubutils.NewBranchProtectionRepository(gitHubClient.Repositories, githubutils.EnableBlockingLimiter())
+	if len(repos) == 0 {
+		log.WithFields(f).Debug("no repositories found for organization - no branch protection job required")
+		return nil
+	}
 
-	var eg errgroup.Group
-	// a pool of 5 concurrent workers
-	var workerTokens = make(chan struct{}, 5)
+	repositoryIDs := make([]string, 0, len(repos))
 	for _, repo := range repos {
-		// this is for goroutine local variables
-		repo := repo
-		// acquire a worker token to create a new goroutine
-		workerTokens <- struct{}{}
-		// Update the branch protection in a go routine...
-		eg.Go(func() error {
-			defer func() {
-				<-workerTokens // release the workerToken
-			}()
-			log.WithFields(f).Debugf("enabling branch protection for repository: %s", repo.RepositoryName)
-
-			log.WithFields(f).Debugf("looking up the default branch for the GitHub repository: %s...", repo.RepositoryName)
-			defaultBranch, branchErr := branchProtectionRepo.GetDefaultBranchForRepo(ctx, newGitHubOrg.OrganizationName, repo.RepositoryName)
-			if branchErr != nil {
-				return branchErr
-			}
-
-			log.WithFields(f).Debugf("enabling branch protection on the default branch %s for the GitHub repository: %s...",
-				defaultBranch, repo.RepositoryName)
-			return branchProtectionRepo.EnableBranchProtection(ctx, newGitHubOrg.OrganizationName, repo.RepositoryName,
-				defaultBranch, true, []string{utils.GitHubBotName}, []string{})
-		})
-	}
-
-	// Wait for the go routines to finish
-	log.WithFields(f).Debugf("waiting for %d repositories to complete...", len(repos))
-	var branchProtectionErr error
-	if loadErr := eg.Wait(); loadErr != nil {
-		log.WithFields(f).Warnf("encountered branch protection setup error: %+v", loadErr)
-		branchProtectionErr = loadErr
-	}
-
-	return branchProtectionErr
+		repositoryIDs = append(repositoryIDs, repo.RepositoryID)
+	}
+
+	log.WithFields(f).Debugf("queueing the branch protection job for %d repositories...", len(repositoryIDs))
+	job, err := s.githubJobsService.CreateJob(context.Background(), github_jobs.JobTypeBranchProtection, newGitHubOrg.OrganizationInstallationID,
+		newGitHubOrg.OrganizationName, newGitHubOrg.ProjectSFID, repositoryIDs, utils.GitHubBotName)
+	if err != nil {
+		log.WithFields(f).Warnf("problem queueing the branch protection job, error: %+v", err)
+		return err
+	}
+
+	log.WithFields(f).Debugf("queued branch protection job %s", job.JobID)
+	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 	"github.com/communitybridge/easycla/cla-backend-go/repositories"
 	"github.com/sirupsen/logrus"
 
@@ -128,24 +129,22 @@ func (s *service) EnableBranchProtectionServiceHandler(event events.DynamoDBEven
 
 			ctx := context.Background()
 			log.WithFields(f).Debug("creating a new GitHub client object...")
-			gitHubClient, clientErr := github.NewGithubAppClient(gitHubOrg.OrganizationInstallationID)
+			gitHubClient, clientErr := github.NewGithubAppClientForInstallation(github_organizations.GithubInstallation(gitHubOrg))
 			if clientErr != nil {
 				return clientErr
 			}
 
 			branchProtectionRepository := github.NewBranchProtectionRepository(gitHubClient.Repositories, github.EnableBlockingLimiter())
 
-			log.WithFields(f).Debug("looking up the default branch for the GitHub repository...")
-			defaultBranch, branchErr := branchProtectionRepository.GetDefaultBranchForRepo(ctx, gitHubOrg.OrganizationName, newRepoModel.RepositoryName)
-			if branchErr != nil {
-				return branchErr
+			log.WithFields(f).Debug("resolving the branch protection policy for the GitHub repository...")
+			policy, policyErr := s.branchProtectionService.ResolvePolicy(ctx, parentOrgName, newRepoModel.RepositoryProjectID)
+			if policyErr != nil {
+				return policyErr
 			}
 
-			log.WithFields(f).Debugf("enabling branch protection on th default branch %s for the GitHub repository: %s...",
-				defaultBranch, newRepoModel.RepositoryName)
-			return branchProtectionRepository.EnableBranchProtection(ctx,
-				parentOrgName, newRepoModel.RepositoryName,
-				defaultBranch, true, []string{utils.GitHubBotName}, []string{})
+			log.WithFields(f).Debugf("enabling branch protection policy %s for the GitHub repository: %s...",
+				policy.PolicyID, newRepoModel.RepositoryName)
+			return s.branchProtectionService.ApplyPolicy(ctx, branchProtectionRepository, parentOrgName, newRepoModel.RepositoryName, policy)
 		}
 
 		log.WithFields(f).Debug("github organization branch protection is not enabled - no action required")
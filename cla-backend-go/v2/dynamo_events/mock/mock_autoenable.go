@@ -0,0 +1,85 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+//
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: v2/dynamo_events/autoenable.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	github_organizations "github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	gomock "github.com/golang/mock/gomock"
+	github "github.com/google/go-github/v32/github"
+	logrus "github.com/sirupsen/logrus"
+)
+
+// MockAutoEnableService is a mock of AutoEnableService interface
+type MockAutoEnableService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAutoEnableServiceMockRecorder
+}
+
+// MockAutoEnableServiceMockRecorder is the mock recorder for MockAutoEnableService
+type MockAutoEnableServiceMockRecorder struct {
+	mock *MockAutoEnableService
+}
+
+// NewMockAutoEnableService creates a new mock instance
+func NewMockAutoEnableService(ctrl *gomock.Controller) *MockAutoEnableService {
+	mock := &MockAutoEnableService{ctrl: ctrl}
+	mock.recorder = &MockAutoEnableServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAutoEnableService) EXPECT() *MockAutoEnableServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateAutoEnabledRepository mocks base method
+func (m *MockAutoEnableService) CreateAutoEnabledRepository(repo *github.Repository) (*models.GithubRepository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAutoEnabledRepository", repo)
+	ret0, _ := ret[0].(*models.GithubRepository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAutoEnabledRepository indicates an expected call of CreateAutoEnabledRepository
+func (mr *MockAutoEnableServiceMockRecorder) CreateAutoEnabledRepository(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAutoEnabledRepository", reflect.TypeOf((*MockAutoEnableService)(nil).CreateAutoEnabledRepository), repo)
+}
+
+// AutoEnabledForGithubOrg mocks base method
+func (m *MockAutoEnableService) AutoEnabledForGithubOrg(f logrus.Fields, gitHubOrg github_organizations.GithubOrganization, notify bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoEnabledForGithubOrg", f, gitHubOrg, notify)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AutoEnabledForGithubOrg indicates an expected call of AutoEnabledForGithubOrg
+func (mr *MockAutoEnableServiceMockRecorder) AutoEnabledForGithubOrg(f, gitHubOrg, notify interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoEnabledForGithubOrg", reflect.TypeOf((*MockAutoEnableService)(nil).AutoEnabledForGithubOrg), f, gitHubOrg, notify)
+}
+
+// NotifyCLAManagerForRepos mocks base method
+func (m *MockAutoEnableService) NotifyCLAManagerForRepos(claGroupID string, repos []*models.GithubRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyCLAManagerForRepos", claGroupID, repos)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyCLAManagerForRepos indicates an expected call of NotifyCLAManagerForRepos
+func (mr *MockAutoEnableServiceMockRecorder) NotifyCLAManagerForRepos(claGroupID, repos interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyCLAManagerForRepos", reflect.TypeOf((*MockAutoEnableService)(nil).NotifyCLAManagerForRepos), claGroupID, repos)
+}
@@ -5,12 +5,14 @@ package dynamo_events
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 
 	"github.com/communitybridge/easycla/cla-backend-go/repositories"
 
+	"github.com/communitybridge/easycla/cla-backend-go/github_jobs"
 	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 
 	"github.com/communitybridge/easycla/cla-backend-go/approval_list"
@@ -21,9 +23,11 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 
 	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/branch_protection"
 	v2Company "github.com/communitybridge/easycla/cla-backend-go/v2/company"
 
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/webhooks"
 
 	"github.com/sirupsen/logrus"
 
@@ -59,11 +63,28 @@ type service struct {
 	autoEnableService        *autoEnableServiceProvider
 	claManagerRequestsRepo   cla_manager.IRepository
 	approvalListRequestsRepo approval_list.IRepository
+	webhooksService          webhooks.Service
+	branchProtectionService  branch_protection.Service
+	githubJobsService        github_jobs.Service
 }
 
 // Service implements DynamoDB stream event handler service
 type Service interface {
-	ProcessEvents(event events.DynamoDBEvent)
+	ProcessEvents(event events.DynamoDBEvent) error
+}
+
+// retryableError is returned by the event handlers which must be run again when they fail - the error fails the
+// stream batch so the records are delivered again, the handlers must be idempotent
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
 }
 
 // NewService creates DynamoDB stream event handler service
@@ -78,7 +99,10 @@ func NewService(stage string,
 	githubOrgService github_organizations.Service,
 	repositoryService repositories.Service,
 	claManagerRequestsRepo cla_manager.IRepository,
-	approvalListRequestsRepo approval_list.IRepository) Service {
+	approvalListRequestsRepo approval_list.IRepository,
+	webhooksService webhooks.Service,
+	branchProtectionService branch_protection.Service,
+	githubJobsService github_jobs.Service) Service {
 
 	signaturesTable := fmt.Sprintf("cla-%s-signatures", stage)
 	eventsTable := fmt.Sprintf("cla-%s-events", stage)
@@ -101,6 +125,9 @@ func NewService(stage string,
 		autoEnableService:        &autoEnableServiceProvider{repositoryService: repositoryService},
 		claManagerRequestsRepo:   claManagerRequestsRepo,
 		approvalListRequestsRepo: approvalListRequestsRepo,
+		webhooksService:          webhooksService,
+		branchProtectionService:  branchProtectionService,
+		githubJobsService:        githubJobsService,
 	}
 
 	s.registerCallback(signaturesTable, Modify, s.SignatureSignedEvent)
@@ -143,7 +170,9 @@ func (s *service) registerCallback(tableName, eventName string, callbackFunction
 	s.functions[key] = funcArr
 }
 
-func (s *service) ProcessEvents(events events.DynamoDBEvent) {
+func (s *service) ProcessEvents(events events.DynamoDBEvent) error {
+	var retryErr error
+	var retryErrLock sync.Mutex
 	for _, event := range events.Records {
 		tableName := strings.Split(event.EventSourceArn, "/")[1]
 		fields := logrus.Fields{
@@ -179,6 +208,12 @@ func (s *service) ProcessEvents(events events.DynamoDBEvent) {
 					err := f(event)
 					if err != nil {
 						log.WithFields(fields).WithError(err).WithField("event", event).Error("unable to process event", err)
+						var retryable *retryableError
+						if errors.As(err, &retryable) {
+							retryErrLock.Lock()
+							retryErr = err
+							retryErrLock.Unlock()
+						}
 					}
 				}(eventHandlerFunction)
 			}
@@ -189,6 +224,7 @@ func (s *service) ProcessEvents(events events.DynamoDBEvent) {
 			log.WithFields(fields).Debugf("%d event handler functions to completed", len(s.functions[key]))
 		}
 	}
+	return retryErr
 }
 
 // UnmarshalStreamImage converts events.DynamoDBAttributeValue to struct
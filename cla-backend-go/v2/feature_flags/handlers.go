@@ -0,0 +1,178 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package feature_flags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1FeatureFlags "github.com/communitybridge/easycla/cla-backend-go/feature_flags"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/feature_flags"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1FeatureFlags.Service, eventService events.Service) {
+	api.FeatureFlagsGetFeatureFlagsHandler = feature_flags.GetFeatureFlagsHandlerFunc(
+		func(params feature_flags.GetFeatureFlagsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "FeatureFlagsGetFeatureFlagsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to Get Feature Flags - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return feature_flags.NewGetFeatureFlagsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			flags, err := service.GetFeatureFlags(ctx)
+			if err != nil {
+				msg := "problem loading feature flags"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return feature_flags.NewGetFeatureFlagsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.FeatureFlagList{
+				List: []*models.FeatureFlag{},
+			}
+			for _, flag := range flags {
+				response.List = append(response.List, flag.ToModel())
+			}
+			return feature_flags.NewGetFeatureFlagsOK().WithXRequestID(reqID).WithPayload(response)
+		})
+
+	api.FeatureFlagsGetFeatureFlagHandler = feature_flags.GetFeatureFlagHandlerFunc(
+		func(params feature_flags.GetFeatureFlagParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "FeatureFlagsGetFeatureFlagHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"flagName":       params.FlagName,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to Get Feature Flag - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return feature_flags.NewGetFeatureFlagForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			flag, err := service.GetFeatureFlag(ctx, params.FlagName)
+			if err != nil {
+				if err == v1FeatureFlags.ErrFeatureFlagNotFound {
+					msg := fmt.Sprintf("feature flag %s not found", params.FlagName)
+					return feature_flags.NewGetFeatureFlagNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem loading feature flag %s", params.FlagName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return feature_flags.NewGetFeatureFlagInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return feature_flags.NewGetFeatureFlagOK().WithXRequestID(reqID).WithPayload(flag.ToModel())
+		})
+
+	api.FeatureFlagsUpdateFeatureFlagHandler = feature_flags.UpdateFeatureFlagHandlerFunc(
+		func(params feature_flags.UpdateFeatureFlagParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "FeatureFlagsUpdateFeatureFlagHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"flagName":       params.FlagName,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to Update Feature Flag - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return feature_flags.NewUpdateFeatureFlagForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			flag, err := service.UpdateFeatureFlag(ctx, params.FlagName, params.Body, authUser.UserName)
+			if err != nil {
+				if err == v1FeatureFlags.ErrUnknownFeatureFlag {
+					msg := fmt.Sprintf("unknown feature flag %s", params.FlagName)
+					return feature_flags.NewUpdateFeatureFlagBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem updating feature flag %s", params.FlagName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return feature_flags.NewUpdateFeatureFlagInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername: authUser.UserName,
+				EventType:  events.FeatureFlagUpdated,
+				EventData: &events.FeatureFlagUpdatedEventData{
+					FlagName:        flag.FlagName,
+					Enabled:         flag.Enabled,
+					Stages:          flag.Stages,
+					FoundationSFIDs: flag.FoundationSFIDs,
+					ClaGroupIDs:     flag.ClaGroupIDs,
+					CompanyIDs:      flag.CompanyIDs,
+				},
+			})
+
+			return feature_flags.NewUpdateFeatureFlagOK().WithXRequestID(reqID).WithPayload(flag.ToModel())
+		})
+
+	api.FeatureFlagsDeleteFeatureFlagHandler = feature_flags.DeleteFeatureFlagHandlerFunc(
+		func(params feature_flags.DeleteFeatureFlagParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "FeatureFlagsDeleteFeatureFlagHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"flagName":       params.FlagName,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to Delete Feature Flag - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return feature_flags.NewDeleteFeatureFlagForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			err := service.DeleteFeatureFlag(ctx, params.FlagName)
+			if err != nil {
+				if err == v1FeatureFlags.ErrFeatureFlagNotFound {
+					msg := fmt.Sprintf("feature flag %s not found", params.FlagName)
+					return feature_flags.NewDeleteFeatureFlagNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem deleting feature flag %s", params.FlagName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return feature_flags.NewDeleteFeatureFlagInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername: authUser.UserName,
+				EventType:  events.FeatureFlagDeleted,
+				EventData: &events.FeatureFlagDeletedEventData{
+					FlagName: params.FlagName,
+				},
+			})
+
+			return feature_flags.NewDeleteFeatureFlagNoContent().WithXRequestID(reqID)
+		})
+}
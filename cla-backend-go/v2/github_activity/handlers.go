@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/google/go-github/v32/github"
@@ -22,10 +23,18 @@ import (
 )
 
 // signatureCheckMiddleware is used to get access to raw http request so can do the
-// signature validation properly
+// signature validation properly - the events of the GitHub Enterprise Server hosts are validated with the webhook
+// secret of the host
 func signatureCheckMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		payload, err := github.ValidatePayload(r, nil)
+		host := r.Header.Get(githubutils.EnterpriseHostHeader)
+		secret, err := githubutils.WebhookSecret(host)
+		if err != nil {
+			log.Warnf("unable to load the webhook secret of github enterprise host : %s, error: %v", host, err)
+			http.Error(w, "signature check failure", 401)
+			return
+		}
+		payload, err := github.ValidatePayload(r, secret)
 		if err != nil {
 			http.Error(w, "signature check failure", 401)
 			return
@@ -66,12 +75,20 @@ func Configure(api *operations.EasyclaAPI, service Service) {
 				})
 			}
 
+			host := params.HTTPRequest.Header.Get(githubutils.EnterpriseHostHeader)
+			if err := service.CheckEventHost(host, eventAccount(event)); err != nil {
+				log.Warnf("ignoring event : %s, error: %v", githubEvent, err)
+				return github_activity.NewGithubActivityOK()
+			}
+
 			var processError error
 			switch event := event.(type) {
 			case *github.InstallationRepositoriesEvent:
 				processError = service.ProcessInstallationRepositoriesEvent(event)
 			case *github.RepositoryEvent:
 				processError = service.ProcessRepositoryEvent(event)
+			case *github.InstallationEvent:
+				processError = service.ProcessInstallationEvent(event)
 			default:
 				log.Warnf("unsupported event sent : %s", githubEvent)
 			}
@@ -85,6 +102,19 @@ func Configure(api *operations.EasyclaAPI, service Service) {
 	api.AddMiddlewareFor("POST", "/github/activity", signatureCheckMiddleware)
 }
 
+// eventAccount returns the account of the GitHub organization the event belongs to
+func eventAccount(event interface{}) *github.User {
+	switch event := event.(type) {
+	case *github.InstallationRepositoriesEvent:
+		return event.GetInstallation().GetAccount()
+	case *github.InstallationEvent:
+		return event.GetInstallation().GetAccount()
+	case *github.RepositoryEvent:
+		return event.GetRepo().GetOwner()
+	}
+	return nil
+}
+
 type codedResponse interface {
 	Code() string
 }
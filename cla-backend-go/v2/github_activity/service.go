@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 
@@ -15,32 +16,50 @@ import (
 
 	"github.com/communitybridge/easycla/cla-backend-go/events"
 
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
 	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
+	"github.com/aws/aws-sdk-go/aws"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/google/go-github/v32/github"
+	"github.com/sirupsen/logrus"
 )
 
 // Service is responsible for handling the github activity events
 type Service interface {
 	ProcessInstallationRepositoriesEvent(event *github.InstallationRepositoriesEvent) error
 	ProcessRepositoryEvent(*github.RepositoryEvent) error
+	ProcessInstallationEvent(*github.InstallationEvent) error
+	CheckEventHost(host string, account *github.User) error
 }
 
+// ErrEventHostMismatch is returned when an event is sent by a different GitHub host than the one of the organization
+// account in the event
+var ErrEventHostMismatch = errors.New("github event host does not match the github organization host")
+
 type eventHandlerService struct {
 	githubRepo        repositories.Repository
+	githubOrgRepo     github_organizations.Repository
 	eventService      events.Service
 	autoEnableService dynamo_events.AutoEnableService
+	claService        project.Service
 }
 
 // NewService creates a new instance of the Event Handler Service
 func NewService(githubRepo repositories.Repository,
+	githubOrgRepo github_organizations.Repository,
 	eventService events.Service,
-	autoEnableService dynamo_events.AutoEnableService) Service {
+	autoEnableService dynamo_events.AutoEnableService,
+	claService project.Service) Service {
 	return &eventHandlerService{
 		githubRepo:        githubRepo,
+		githubOrgRepo:     githubOrgRepo,
 		eventService:      eventService,
 		autoEnableService: autoEnableService,
+		claService:        claService,
 	}
 }
 
@@ -54,6 +73,14 @@ func (s *eventHandlerService) ProcessRepositoryEvent(event *github.RepositoryEve
 		return s.handleRepositoryAddedAction(event.Sender, event.Repo)
 	case "deleted":
 		return s.handleRepositoryRemovedAction(event.Sender, event.Repo)
+	case "renamed":
+		return s.handleRepositoryRenamedAction(event.Sender, event.Repo)
+	case "transferred":
+		return s.handleRepositoryTransferredAction(event.Sender, event.Repo)
+	case "archived", "unarchived":
+		return s.handleRepositoryArchivedAction(event.Sender, event.Repo, *event.Action == "archived")
+	case "privatized", "publicized":
+		return s.handleRepositoryVisibilityAction(event.Sender, event.Repo, *event.Action == "privatized")
 	default:
 		log.Warnf("ProcessRepositoryEvent no handler for action : %s", *event.Action)
 	}
@@ -138,6 +165,256 @@ func (s *eventHandlerService) handleRepositoryRemovedAction(sender *github.User,
 	return nil
 }
 
+// getLocalRepository loads the repository record by its GitHub ID, disabled repositories are returned as well so
+// their details stay up to date
+func (s *eventHandlerService) getLocalRepository(repo *github.Repository) (*models.GithubRepository, error) {
+	if repo == nil || repo.ID == nil || *repo.ID == 0 {
+		return nil, fmt.Errorf("missing repo id")
+	}
+	if repo.FullName == nil || *repo.FullName == "" {
+		return nil, fmt.Errorf("repo full name missing")
+	}
+	repositoryExternalID := strconv.FormatInt(*repo.ID, 10)
+	repoModel, err := s.githubRepo.GetRepositoryByGithubID(context.Background(), repositoryExternalID, true)
+	if errors.Is(err, repositories.ErrGithubRepositoryNotFound) {
+		repoModel, err = s.githubRepo.GetRepositoryByGithubID(context.Background(), repositoryExternalID, false)
+	}
+	if err != nil {
+		if !errors.Is(err, repositories.ErrGithubRepositoryNotFound) {
+			err = fmt.Errorf("fetching the repo : %s by external id : %s failed : %w", *repo.FullName, repositoryExternalID, err)
+		}
+		return nil, err
+	}
+	return repoModel, nil
+}
+
+// senderLogin returns the login of the user who triggered the event, if available
+func senderLogin(sender *github.User) string {
+	if sender == nil || sender.Login == nil {
+		return ""
+	}
+	return *sender.Login
+}
+
+func (s *eventHandlerService) handleRepositoryRenamedAction(sender *github.User, repo *github.Repository) error {
+	repoModel, err := s.getLocalRepository(repo)
+	if err != nil {
+		if errors.Is(err, repositories.ErrGithubRepositoryNotFound) {
+			log.Warnf("rename event for non existing local repo : %s, nothing to do", repo.GetFullName())
+			return nil
+		}
+		return err
+	}
+
+	oldRepositoryName := repoModel.RepositoryName
+	if oldRepositoryName == repo.GetFullName() {
+		log.Debugf("repo : %s name is up to date, nothing to do", oldRepositoryName)
+		return nil
+	}
+
+	err = s.githubRepo.UpdateGithubRepository(context.Background(), repoModel.RepositoryID, &repositories.RepositoryUpdate{
+		RepositoryName: aws.String(repo.GetFullName()),
+		RepositoryURL:  aws.String(repositoryURL(repo)),
+	}, fmt.Sprintf("renamed from %s", oldRepositoryName))
+	if err != nil {
+		log.Warnf("renaming repo : %s to %s failed : %v", oldRepositoryName, repo.GetFullName(), err)
+		return err
+	}
+
+	s.eventService.LogEvent(&events.LogEventArgs{
+		EventType: events.RepositoryRenamed,
+		ProjectID: repoModel.RepositoryProjectID,
+		UserID:    senderLogin(sender),
+		EventData: &events.RepositoryRenamedEventData{
+			OldRepositoryName: oldRepositoryName,
+			RepositoryName:    repo.GetFullName(),
+		},
+	})
+
+	return nil
+}
+
+// handleRepositoryTransferredAction updates the repository record when the repository is moved to another GitHub
+// organization - the CLA group is re-evaluated for the new organization, if the new organization is not registered or
+// its CLA group can't be determined the repository is disabled
+func (s *eventHandlerService) handleRepositoryTransferredAction(sender *github.User, repo *github.Repository) error {
+	repoModel, err := s.getLocalRepository(repo)
+	if err != nil {
+		if errors.Is(err, repositories.ErrGithubRepositoryNotFound) {
+			log.Warnf("transfer event for non existing local repo : %s, nothing to do", repo.GetFullName())
+			return nil
+		}
+		return err
+	}
+
+	f := logrus.Fields{
+		"functionName":       "handleRepositoryTransferredAction",
+		"repositoryID":       repoModel.RepositoryID,
+		"repositoryFullName": repo.GetFullName(),
+	}
+	organizationName := strings.Split(repo.GetFullName(), "/")[0]
+	update := &repositories.RepositoryUpdate{
+		RepositoryName:             aws.String(repo.GetFullName()),
+		RepositoryURL:              aws.String(repositoryURL(repo)),
+		RepositoryOrganizationName: aws.String(organizationName),
+	}
+
+	organizationKey := github_organizations.OrganizationKey(githubutils.EnterpriseHost(repositoryURL(repo)), organizationName)
+	claGroupID, projectSFID, err := s.determineClaGroupForOrganization(f, organizationKey, organizationName, repoModel.RepositoryID)
+	if err != nil {
+		if !errors.Is(err, github_organizations.ErrOrganizationDoesNotExist) && !errors.Is(err, dynamo_events.ErrCantDetermineAutoEnableClaGroup) {
+			return err
+		}
+		log.WithFields(f).Warnf("unable to determine the cla group for the new organization : %s, disabling the repository : %v", organizationName, err)
+		update.Enabled = aws.Bool(false)
+	} else {
+		update.RepositoryProjectID = aws.String(claGroupID)
+		update.ProjectSFID = aws.String(projectSFID)
+	}
+
+	err = s.githubRepo.UpdateGithubRepository(context.Background(), repoModel.RepositoryID, update,
+		fmt.Sprintf("transferred from %s", repoModel.RepositoryName))
+	if err != nil {
+		log.WithFields(f).Warnf("updating the transferred repo failed : %v", err)
+		return err
+	}
+
+	eventClaGroupID := claGroupID
+	if eventClaGroupID == "" {
+		eventClaGroupID = repoModel.RepositoryProjectID
+	}
+	s.eventService.LogEvent(&events.LogEventArgs{
+		EventType: events.RepositoryTransferred,
+		ProjectID: eventClaGroupID,
+		UserID:    senderLogin(sender),
+		EventData: &events.RepositoryTransferredEventData{
+			OldRepositoryName: repoModel.RepositoryName,
+			RepositoryName:    repo.GetFullName(),
+			OldClaGroupID:     repoModel.RepositoryProjectID,
+			NewClaGroupID:     claGroupID,
+			Disabled:          update.Enabled != nil && repoModel.Enabled,
+		},
+	})
+
+	return nil
+}
+
+// determineClaGroupForOrganization returns the CLA group and project SFID for the repositories of the GitHub
+// organization, the repository being moved is ignored
+func (s *eventHandlerService) determineClaGroupForOrganization(f logrus.Fields, organizationKey, organizationName, repositoryID string) (string, string, error) {
+	ctx := context.Background()
+	orgModel, err := s.githubOrgRepo.GetGithubOrganization(ctx, organizationKey)
+	if err != nil {
+		return "", "", err
+	}
+	if orgModel == nil {
+		return "", "", github_organizations.ErrOrganizationDoesNotExist
+	}
+
+	orgRepositories, err := s.githubRepo.GetRepositoriesByOrganizationName(ctx, organizationName)
+	if err != nil && !errors.Is(err, repositories.ErrGithubRepositoryNotFound) {
+		return "", "", err
+	}
+	repos := &models.ListGithubRepositories{}
+	for _, orgRepository := range orgRepositories {
+		if orgRepository.RepositoryID != repositoryID && orgRepository.Enabled {
+			repos.List = append(repos.List, orgRepository)
+		}
+	}
+
+	claGroupID, err := dynamo_events.DetermineClaGroupID(f, orgModel, repos)
+	if err != nil {
+		return "", "", err
+	}
+
+	projectSFID := orgModel.ProjectSFID
+	for _, orgRepository := range repos.List {
+		if orgRepository.RepositoryProjectID == claGroupID && orgRepository.ProjectSFID != "" {
+			projectSFID = orgRepository.ProjectSFID
+			break
+		}
+	}
+	return claGroupID, projectSFID, nil
+}
+
+func (s *eventHandlerService) handleRepositoryArchivedAction(sender *github.User, repo *github.Repository, archived bool) error {
+	repoModel, err := s.getLocalRepository(repo)
+	if err != nil {
+		if errors.Is(err, repositories.ErrGithubRepositoryNotFound) {
+			log.Warnf("archive event for non existing local repo : %s, nothing to do", repo.GetFullName())
+			return nil
+		}
+		return err
+	}
+
+	eventType, note := events.RepositoryUnarchived, "unarchived"
+	if archived {
+		eventType, note = events.RepositoryArchived, "archived"
+	}
+	err = s.githubRepo.UpdateGithubRepository(context.Background(), repoModel.RepositoryID, &repositories.RepositoryUpdate{
+		RepositoryArchived: aws.Bool(archived),
+	}, note)
+	if err != nil {
+		log.Warnf("updating the archived flag of repo : %s failed : %v", repo.GetFullName(), err)
+		return err
+	}
+
+	s.eventService.LogEvent(&events.LogEventArgs{
+		EventType: eventType,
+		ProjectID: repoModel.RepositoryProjectID,
+		UserID:    senderLogin(sender),
+		EventData: &events.RepositoryArchivedEventData{
+			RepositoryName: repo.GetFullName(),
+			Archived:       archived,
+		},
+	})
+
+	return nil
+}
+
+func (s *eventHandlerService) handleRepositoryVisibilityAction(sender *github.User, repo *github.Repository, private bool) error {
+	repoModel, err := s.getLocalRepository(repo)
+	if err != nil {
+		if errors.Is(err, repositories.ErrGithubRepositoryNotFound) {
+			log.Warnf("visibility event for non existing local repo : %s, nothing to do", repo.GetFullName())
+			return nil
+		}
+		return err
+	}
+
+	eventType, note := events.RepositoryPublicized, "made public"
+	if private {
+		eventType, note = events.RepositoryPrivatized, "made private"
+	}
+	err = s.githubRepo.UpdateGithubRepository(context.Background(), repoModel.RepositoryID, &repositories.RepositoryUpdate{
+		RepositoryPrivate: aws.Bool(private),
+	}, note)
+	if err != nil {
+		log.Warnf("updating the private flag of repo : %s failed : %v", repo.GetFullName(), err)
+		return err
+	}
+
+	s.eventService.LogEvent(&events.LogEventArgs{
+		EventType: eventType,
+		ProjectID: repoModel.RepositoryProjectID,
+		UserID:    senderLogin(sender),
+		EventData: &events.RepositoryVisibilityChangedEventData{
+			RepositoryName: repo.GetFullName(),
+			Private:        private,
+		},
+	})
+
+	return nil
+}
+
+// repositoryURL returns the repository html url from the event, falling back to the github.com url of the full name
+func repositoryURL(repo *github.Repository) string {
+	if repo.GetHTMLURL() != "" {
+		return repo.GetHTMLURL()
+	}
+	return "https://github.com/" + repo.GetFullName()
+}
+
 func (s *eventHandlerService) ProcessInstallationRepositoriesEvent(event *github.InstallationRepositoriesEvent) error {
 	log.Debugf("ProcessInstallationRepositoriesEvent called for action : %s", *event.Action)
 	if event.Action == nil {
@@ -172,3 +449,285 @@ func (s *eventHandlerService) ProcessInstallationRepositoriesEvent(event *github
 
 	return nil
 }
+
+// ProcessInstallationEvent keeps the GitHub organization in sync with the EasyCLA GitHub App installation - when the
+// app is uninstalled or suspended the enabled repositories of the organization are disabled, since the CLA checks can't
+// run anymore, and they are enabled again once the app is installed again or unsuspended
+func (s *eventHandlerService) ProcessInstallationEvent(event *github.InstallationEvent) error {
+	if event.Action == nil {
+		return fmt.Errorf("no action found in event payload")
+	}
+	log.Debugf("ProcessInstallationEvent called for action : %s", *event.Action)
+	if event.Installation == nil || event.Installation.Account == nil || event.Installation.Account.GetLogin() == "" {
+		return fmt.Errorf("installation account is missing")
+	}
+
+	switch *event.Action {
+	case "created", "unsuspend":
+		status, eventType := github_organizations.InstallationStatusActive, events.GithubAppUnsuspended
+		if *event.Action == "created" {
+			eventType = events.GithubAppInstalled
+		}
+		return s.handleInstallationRestored(event.Sender, event.Installation, status, eventType)
+	case "deleted":
+		return s.handleInstallationRevoked(event.Sender, event.Installation, github_organizations.InstallationStatusUninstalled, events.GithubAppUninstalled)
+	case "suspend":
+		return s.handleInstallationRevoked(event.Sender, event.Installation, github_organizations.InstallationStatusSuspended, events.GithubAppSuspended)
+	default:
+		log.Warnf("ProcessInstallationEvent no handler for action : %s", *event.Action)
+	}
+
+	return nil
+}
+
+// CheckEventHost makes sure the event was sent by the GitHub host of the organization account in the event, the
+// organization records are looked up by the host of the account - an empty host is github.com
+func (s *eventHandlerService) CheckEventHost(host string, account *github.User) error {
+	if account == nil {
+		return nil
+	}
+	accountHost := githubutils.EnterpriseHost(account.GetHTMLURL())
+	if accountHost == "github.com" {
+		accountHost = ""
+	}
+	if !strings.EqualFold(accountHost, host) {
+		return fmt.Errorf("organization : %s, host : %s : %w", account.GetLogin(), host, ErrEventHostMismatch)
+	}
+	return nil
+}
+
+// getInstallationOrganization loads the GitHub organization record of the installation, returns nil if the
+// organization isn't registered in EasyCLA
+func (s *eventHandlerService) getInstallationOrganization(installation *github.Installation) (*models.GithubOrganization, error) {
+	organizationName := installation.Account.GetLogin()
+	orgModel, err := s.githubOrgRepo.GetGithubOrganization(context.Background(), github_organizations.AccountOrganizationKey(installation.Account))
+	if err != nil {
+		if errors.Is(err, github_organizations.ErrOrganizationDoesNotExist) {
+			log.Warnf("installation event for non existing github organization : %s, nothing to do", organizationName)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fetching the github organization : %s failed : %w", organizationName, err)
+	}
+	return orgModel, nil
+}
+
+// getOrganizationRepositories returns the repositories of the GitHub organization
+func (s *eventHandlerService) getOrganizationRepositories(organizationName string) ([]*models.GithubRepository, error) {
+	orgRepositories, err := s.githubRepo.GetRepositoriesByOrganizationName(context.Background(), organizationName)
+	if err != nil && !errors.Is(err, repositories.ErrGithubRepositoryNotFound) {
+		return nil, fmt.Errorf("fetching the repositories of github organization : %s failed : %w", organizationName, err)
+	}
+	return orgRepositories, nil
+}
+
+// handleInstallationRevoked disables the enabled repositories of the organization and records them on the
+// organization so they can be enabled again when the installation is restored
+func (s *eventHandlerService) handleInstallationRevoked(sender *github.User, installation *github.Installation, status, eventType string) error {
+	orgModel, err := s.getInstallationOrganization(installation)
+	if err != nil || orgModel == nil {
+		return err
+	}
+	organizationName := orgModel.OrganizationName
+	orgRepositories, err := s.getOrganizationRepositories(organizationName)
+	if err != nil {
+		return err
+	}
+
+	// repositories disabled by a previous suspend are kept, so they are restored once the app is installed again
+	disabledRepositoryIDs := append([]string{}, orgModel.InstallationDisabledRepositoryIDs...)
+	var disabledRepos []*models.GithubRepository
+	note := fmt.Sprintf("disabled as the GitHub App is %s", status)
+	for _, repoModel := range orgRepositories {
+		if !repoModel.Enabled {
+			continue
+		}
+		err = s.githubRepo.UpdateGithubRepository(context.Background(), repoModel.RepositoryID, &repositories.RepositoryUpdate{
+			Enabled: aws.Bool(false),
+		}, note)
+		if err != nil {
+			log.Warnf("disabling repo : %s failed : %v", repoModel.RepositoryName, err)
+			continue
+		}
+		disabledRepositoryIDs = append(disabledRepositoryIDs, repoModel.RepositoryID)
+		disabledRepos = append(disabledRepos, repoModel)
+	}
+
+	installationID := orgModel.OrganizationInstallationID
+	if status == github_organizations.InstallationStatusUninstalled {
+		installationID = 0
+	}
+	err = s.githubOrgRepo.UpdateGithubOrganizationInstallation(context.Background(), github_organizations.GithubOrganizationKey(orgModel), installationID, status, disabledRepositoryIDs)
+	if err != nil {
+		log.Warnf("updating the installation of github organization : %s failed : %v", organizationName, err)
+		return err
+	}
+
+	s.logInstallationEvents(sender, orgModel, status, eventType, disabledRepos, false)
+	return nil
+}
+
+// handleInstallationRestored enables the repositories disabled when the installation was revoked
+func (s *eventHandlerService) handleInstallationRestored(sender *github.User, installation *github.Installation, status, eventType string) error {
+	orgModel, err := s.getInstallationOrganization(installation)
+	if err != nil || orgModel == nil {
+		return err
+	}
+	organizationName := orgModel.OrganizationName
+
+	var enabledRepos []*models.GithubRepository
+	var failedRepositoryIDs []string
+	if len(orgModel.InstallationDisabledRepositoryIDs) > 0 {
+		orgRepositories, err := s.getOrganizationRepositories(organizationName)
+		if err != nil {
+			return err
+		}
+		disabledRepositoryIDs := make(map[string]bool)
+		for _, repositoryID := range orgModel.InstallationDisabledRepositoryIDs {
+			disabledRepositoryIDs[repositoryID] = true
+		}
+		note := fmt.Sprintf("enabled as the GitHub App is %s", status)
+		for _, repoModel := range orgRepositories {
+			// repositories transferred out of the organization meanwhile are not enabled again
+			if !disabledRepositoryIDs[repoModel.RepositoryID] || repoModel.Enabled {
+				continue
+			}
+			err = s.githubRepo.UpdateGithubRepository(context.Background(), repoModel.RepositoryID, &repositories.RepositoryUpdate{
+				Enabled: aws.Bool(true),
+			}, note)
+			if err != nil {
+				log.Warnf("enabling repo : %s failed : %v", repoModel.RepositoryName, err)
+				failedRepositoryIDs = append(failedRepositoryIDs, repoModel.RepositoryID)
+				continue
+			}
+			enabledRepos = append(enabledRepos, repoModel)
+		}
+	}
+
+	err = s.githubOrgRepo.UpdateGithubOrganizationInstallation(context.Background(), github_organizations.GithubOrganizationKey(orgModel), installation.GetID(), status, failedRepositoryIDs)
+	if err != nil {
+		log.Warnf("updating the installation of github organization : %s failed : %v", organizationName, err)
+		return err
+	}
+
+	s.logInstallationEvents(sender, orgModel, status, eventType, enabledRepos, true)
+	return nil
+}
+
+// logInstallationEvents logs an event for each CLA group with affected repositories and notifies its CLA managers,
+// a single event is logged for the organization when no repositories were affected
+func (s *eventHandlerService) logInstallationEvents(sender *github.User, orgModel *models.GithubOrganization, status, eventType string, repos []*models.GithubRepository, enabled bool) {
+	claGroupRepos := make(map[string][]*models.GithubRepository)
+	var claGroupIDs []string
+	for _, repoModel := range repos {
+		if _, ok := claGroupRepos[repoModel.RepositoryProjectID]; !ok {
+			claGroupIDs = append(claGroupIDs, repoModel.RepositoryProjectID)
+		}
+		claGroupRepos[repoModel.RepositoryProjectID] = append(claGroupRepos[repoModel.RepositoryProjectID], repoModel)
+	}
+
+	if len(claGroupIDs) == 0 {
+		s.eventService.LogEvent(&events.LogEventArgs{
+			EventType:         eventType,
+			ExternalProjectID: orgModel.ProjectSFID,
+			UserID:            senderLogin(sender),
+			EventData: &events.GithubAppInstallationEventData{
+				OrganizationName:   orgModel.OrganizationName,
+				InstallationStatus: status,
+				Enabled:            enabled,
+			},
+		})
+		return
+	}
+
+	for _, claGroupID := range claGroupIDs {
+		var repositoryNames []string
+		for _, repoModel := range claGroupRepos[claGroupID] {
+			repositoryNames = append(repositoryNames, repoModel.RepositoryName)
+		}
+		s.eventService.LogEvent(&events.LogEventArgs{
+			EventType: eventType,
+			ProjectID: claGroupID,
+			UserID:    senderLogin(sender),
+			EventData: &events.GithubAppInstallationEventData{
+				OrganizationName:   orgModel.OrganizationName,
+				InstallationStatus: status,
+				RepositoryNames:    repositoryNames,
+				Enabled:            enabled,
+			},
+		})
+
+		if err := s.notifyCLAManagersForInstallation(claGroupID, orgModel.OrganizationName, status, claGroupRepos[claGroupID], enabled); err != nil {
+			log.Warnf("notifying the cla managers of claGroup : %s about the installation of github organization : %s failed : %v", claGroupID, orgModel.OrganizationName, err)
+		}
+	}
+}
+
+// notifyCLAManagersForInstallation emails the CLA managers of the CLA group about the repositories which were
+// disabled or enabled because of the GitHub App installation status
+func (s *eventHandlerService) notifyCLAManagersForInstallation(claGroupID, orgName, status string, repos []*models.GithubRepository, enabled bool) error {
+	claManagers, err := s.claService.GetCLAManagers(context.Background(), claGroupID)
+	if err != nil {
+		return err
+	}
+	if len(claManagers) == 0 {
+		log.Warnf("no cla managers registered for the claGroup : %s, none to notify", claGroupID)
+		return nil
+	}
+
+	claGroupModel, err := s.claService.GetCLAGroupByID(context.Background(), claGroupID)
+	if err != nil {
+		return err
+	}
+
+	subject, body, recipients := installationEmailContent(claGroupModel, orgName, status, claManagers, repos, enabled)
+	if len(recipients) == 0 {
+		log.Warnf("no cla manager emails for claGroup : %s registered, can't notify the cla managers ", claGroupModel.ProjectName)
+		return nil
+	}
+
+	log.Debugf("sending email with subject : %s for claGroup : %s for recipients : %+v", subject, claGroupModel.ProjectName, recipients)
+	return utils.SendEmail(subject, body, recipients)
+}
+
+// installationEmailContent prepares the email for the repositories affected by the GitHub App installation status
+func installationEmailContent(claGroupModel *models.ClaGroup, orgName, status string, managers []*models.ClaManagerUser, repos []*models.GithubRepository, enabled bool) (string, string, []string) {
+	claGroupName := claGroupModel.ProjectName
+	subject := fmt.Sprintf("EasyCLA: GitHub App %s for GitHub Organization: %s", strings.Title(status), orgName)
+
+	repoContent := "<ul>"
+	for _, repo := range repos {
+		repoContent += "<li>" + repo.RepositoryName + "</li>"
+	}
+	repoContent += "</ul>"
+
+	action := `The repositories below were disabled and will not enforce CLA checks until the EasyCLA GitHub App is
+	installed again or unsuspended for the GitHub Organization.`
+	if enabled {
+		action = `The repositories below, disabled while the EasyCLA GitHub App was unavailable, were enabled again and
+	will start enforcing CLA checks.`
+	}
+
+	body := `
+	<p>Hello Project Manager,</p>
+	<p>This is a notification email from EasyCLA regarding the CLA Group %s.</p>
+	<p>EasyCLA was notified that the EasyCLA GitHub App installation for the %s GitHub Organization is now %s.
+	%s</p>
+	<p>Repositories:</p>
+	%s
+	%s
+	%s
+	`
+
+	body = fmt.Sprintf(
+		body, claGroupName, orgName, status, action, repoContent,
+		utils.GetEmailHelpContent(claGroupModel.Version == utils.V2), utils.GetEmailSignOffContent())
+	var recipients []string
+	for _, m := range managers {
+		if m.UserEmail == "" {
+			continue
+		}
+		recipients = append(recipients, m.UserEmail)
+	}
+
+	return subject, body, recipients
+}
@@ -0,0 +1,286 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_activity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	eventsMock "github.com/communitybridge/easycla/cla-backend-go/events/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	projectMock "github.com/communitybridge/easycla/cla-backend-go/project/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	repositoriesmock "github.com/communitybridge/easycla/cla-backend-go/repositories/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-github/v32/github"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectEvent expects a single logged event and returns its arguments once logged
+func expectEvent(eventService *eventsMock.MockService) *events.LogEventArgs {
+	logged := &events.LogEventArgs{}
+	eventService.EXPECT().LogEvent(gomock.Any()).Do(func(args *events.LogEventArgs) {
+		*logged = *args
+	})
+	return logged
+}
+
+const (
+	testRepositoryID = "d5e1f7a2-3b4c-4d5e-8f9a-0b1c2d3e4f5a"
+	oldClaGroupID    = "old-cla-group"
+	newClaGroupID    = "new-cla-group"
+)
+
+func repositoryEvent(action, fullName string) *github.RepositoryEvent {
+	return &github.RepositoryEvent{
+		Action: aws.String(action),
+		Repo: &github.Repository{
+			ID:       github.Int64(1234),
+			FullName: aws.String(fullName),
+			HTMLURL:  aws.String("https://github.com/" + fullName),
+		},
+		Sender: &github.User{Login: aws.String("octocat")},
+	}
+}
+
+func localRepository() *models.GithubRepository {
+	return &models.GithubRepository{
+		RepositoryID:               testRepositoryID,
+		RepositoryName:             "old-org/repo",
+		RepositoryOrganizationName: "old-org",
+		RepositoryProjectID:        oldClaGroupID,
+		Enabled:                    true,
+	}
+}
+
+func TestProcessRepositoryEventRenamed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubRepo := repositoriesmock.NewMockRepository(ctrl)
+	githubRepo.EXPECT().GetRepositoryByGithubID(gomock.Any(), "1234", true).Return(localRepository(), nil)
+	githubRepo.EXPECT().UpdateGithubRepository(gomock.Any(), testRepositoryID, &repositories.RepositoryUpdate{
+		RepositoryName: aws.String("old-org/new-name"),
+		RepositoryURL:  aws.String("https://github.com/old-org/new-name"),
+	}, "renamed from old-org/repo").Return(nil)
+
+	eventService := eventsMock.NewMockService(ctrl)
+	logged := expectEvent(eventService)
+	s := NewService(githubRepo, github_organizations.NewMockRepository(ctrl), eventService, nil, nil)
+
+	assert.Nil(t, s.ProcessRepositoryEvent(repositoryEvent("renamed", "old-org/new-name")))
+	assert.Equal(t, events.RepositoryRenamed, logged.EventType)
+	assert.Equal(t, oldClaGroupID, logged.ProjectID)
+}
+
+func TestProcessRepositoryEventTransferred(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubRepo := repositoriesmock.NewMockRepository(ctrl)
+	githubRepo.EXPECT().GetRepositoryByGithubID(gomock.Any(), "1234", true).Return(localRepository(), nil)
+	githubRepo.EXPECT().GetRepositoriesByOrganizationName(gomock.Any(), "new-org").Return([]*models.GithubRepository{
+		{RepositoryID: "other", RepositoryProjectID: newClaGroupID, ProjectSFID: "new-project-sfid", Enabled: true},
+	}, nil)
+	githubRepo.EXPECT().UpdateGithubRepository(gomock.Any(), testRepositoryID, &repositories.RepositoryUpdate{
+		RepositoryName:             aws.String("new-org/repo"),
+		RepositoryURL:              aws.String("https://github.com/new-org/repo"),
+		RepositoryOrganizationName: aws.String("new-org"),
+		RepositoryProjectID:        aws.String(newClaGroupID),
+		ProjectSFID:                aws.String("new-project-sfid"),
+	}, "transferred from old-org/repo").Return(nil)
+
+	githubOrgRepo := github_organizations.NewMockRepository(ctrl)
+	githubOrgRepo.EXPECT().GetGithubOrganization(gomock.Any(), "new-org").Return(&models.GithubOrganization{
+		OrganizationName: "new-org",
+		ProjectSFID:      "new-org-project-sfid",
+	}, nil)
+
+	eventService := eventsMock.NewMockService(ctrl)
+	logged := expectEvent(eventService)
+	s := NewService(githubRepo, githubOrgRepo, eventService, nil, nil)
+
+	assert.Nil(t, s.ProcessRepositoryEvent(repositoryEvent("transferred", "new-org/repo")))
+	assert.Equal(t, events.RepositoryTransferred, logged.EventType)
+	assert.Equal(t, newClaGroupID, logged.ProjectID)
+	assert.False(t, logged.EventData.(*events.RepositoryTransferredEventData).Disabled)
+}
+
+func TestProcessRepositoryEventTransferredToUnknownOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubRepo := repositoriesmock.NewMockRepository(ctrl)
+	githubRepo.EXPECT().GetRepositoryByGithubID(gomock.Any(), "1234", true).Return(localRepository(), nil)
+	githubRepo.EXPECT().UpdateGithubRepository(gomock.Any(), testRepositoryID, &repositories.RepositoryUpdate{
+		RepositoryName:             aws.String("unknown-org/repo"),
+		RepositoryURL:              aws.String("https://github.com/unknown-org/repo"),
+		RepositoryOrganizationName: aws.String("unknown-org"),
+		Enabled:                    aws.Bool(false),
+	}, "transferred from old-org/repo").Return(nil)
+
+	githubOrgRepo := github_organizations.NewMockRepository(ctrl)
+	githubOrgRepo.EXPECT().GetGithubOrganization(gomock.Any(), "unknown-org").Return(nil, github_organizations.ErrOrganizationDoesNotExist)
+
+	eventService := eventsMock.NewMockService(ctrl)
+	logged := expectEvent(eventService)
+	s := NewService(githubRepo, githubOrgRepo, eventService, nil, nil)
+
+	assert.Nil(t, s.ProcessRepositoryEvent(repositoryEvent("transferred", "unknown-org/repo")))
+	assert.Equal(t, oldClaGroupID, logged.ProjectID)
+	assert.True(t, logged.EventData.(*events.RepositoryTransferredEventData).Disabled)
+}
+
+func TestProcessRepositoryEventArchived(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubRepo := repositoriesmock.NewMockRepository(ctrl)
+	githubRepo.EXPECT().GetRepositoryByGithubID(gomock.Any(), "1234", true).Return(nil, repositories.ErrGithubRepositoryNotFound)
+	githubRepo.EXPECT().GetRepositoryByGithubID(gomock.Any(), "1234", false).Return(localRepository(), nil)
+	githubRepo.EXPECT().UpdateGithubRepository(gomock.Any(), testRepositoryID, &repositories.RepositoryUpdate{
+		RepositoryArchived: aws.Bool(true),
+	}, "archived").Return(nil)
+
+	eventService := eventsMock.NewMockService(ctrl)
+	logged := expectEvent(eventService)
+	s := NewService(githubRepo, github_organizations.NewMockRepository(ctrl), eventService, nil, nil)
+
+	assert.Nil(t, s.ProcessRepositoryEvent(repositoryEvent("archived", "old-org/repo")))
+	assert.Equal(t, events.RepositoryArchived, logged.EventType)
+}
+
+func TestProcessRepositoryEventUnknownRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubRepo := repositoriesmock.NewMockRepository(ctrl)
+	githubRepo.EXPECT().GetRepositoryByGithubID(gomock.Any(), "1234", true).Return(nil, repositories.ErrGithubRepositoryNotFound)
+	githubRepo.EXPECT().GetRepositoryByGithubID(gomock.Any(), "1234", false).Return(nil, repositories.ErrGithubRepositoryNotFound)
+
+	// no event is expected for a repository which isn't registered
+	s := NewService(githubRepo, github_organizations.NewMockRepository(ctrl), eventsMock.NewMockService(ctrl), nil, nil)
+	assert.Nil(t, s.ProcessRepositoryEvent(repositoryEvent("privatized", "old-org/repo")))
+}
+
+func installationEvent(action string) *github.InstallationEvent {
+	return &github.InstallationEvent{
+		Action: aws.String(action),
+		Installation: &github.Installation{
+			ID:      github.Int64(5678),
+			Account: &github.User{Login: aws.String("old-org")},
+		},
+		Sender: &github.User{Login: aws.String("octocat")},
+	}
+}
+
+func TestProcessInstallationEventDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubRepo := repositoriesmock.NewMockRepository(ctrl)
+	githubRepo.EXPECT().GetRepositoriesByOrganizationName(gomock.Any(), "old-org").Return([]*models.GithubRepository{
+		localRepository(),
+		{RepositoryID: "disabled", RepositoryProjectID: oldClaGroupID, Enabled: false},
+	}, nil)
+	githubRepo.EXPECT().UpdateGithubRepository(gomock.Any(), testRepositoryID, &repositories.RepositoryUpdate{
+		Enabled: aws.Bool(false),
+	}, "disabled as the GitHub App is uninstalled").Return(nil)
+
+	githubOrgRepo := github_organizations.NewMockRepository(ctrl)
+	githubOrgRepo.EXPECT().GetGithubOrganization(gomock.Any(), "old-org").Return(&models.GithubOrganization{
+		OrganizationName:           "old-org",
+		OrganizationInstallationID: 5678,
+	}, nil)
+	githubOrgRepo.EXPECT().UpdateGithubOrganizationInstallation(gomock.Any(), "old-org", int64(0),
+		github_organizations.InstallationStatusUninstalled, []string{testRepositoryID}).Return(nil)
+
+	eventService := eventsMock.NewMockService(ctrl)
+	logged := expectEvent(eventService)
+	claService := projectMock.NewMockService(ctrl)
+	claService.EXPECT().GetCLAManagers(gomock.Any(), oldClaGroupID).Return(nil, nil)
+	s := NewService(githubRepo, githubOrgRepo, eventService, nil, claService)
+
+	assert.Nil(t, s.ProcessInstallationEvent(installationEvent("deleted")))
+	assert.Equal(t, events.GithubAppUninstalled, logged.EventType)
+	assert.Equal(t, oldClaGroupID, logged.ProjectID)
+	assert.Equal(t, []string{"old-org/repo"}, logged.EventData.(*events.GithubAppInstallationEventData).RepositoryNames)
+}
+
+func TestProcessInstallationEventUnsuspend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	disabledRepository := localRepository()
+	disabledRepository.Enabled = false
+	githubRepo := repositoriesmock.NewMockRepository(ctrl)
+	githubRepo.EXPECT().GetRepositoriesByOrganizationName(gomock.Any(), "old-org").Return([]*models.GithubRepository{
+		disabledRepository,
+		{RepositoryID: "disabled-by-user", RepositoryProjectID: oldClaGroupID, Enabled: false},
+	}, nil)
+	githubRepo.EXPECT().UpdateGithubRepository(gomock.Any(), testRepositoryID, &repositories.RepositoryUpdate{
+		Enabled: aws.Bool(true),
+	}, "enabled as the GitHub App is active").Return(nil)
+
+	githubOrgRepo := github_organizations.NewMockRepository(ctrl)
+	githubOrgRepo.EXPECT().GetGithubOrganization(gomock.Any(), "old-org").Return(&models.GithubOrganization{
+		OrganizationName:                  "old-org",
+		OrganizationInstallationID:        5678,
+		InstallationStatus:                github_organizations.InstallationStatusSuspended,
+		InstallationDisabledRepositoryIDs: []string{testRepositoryID},
+	}, nil)
+	githubOrgRepo.EXPECT().UpdateGithubOrganizationInstallation(gomock.Any(), "old-org", int64(5678),
+		github_organizations.InstallationStatusActive, gomock.Nil()).Return(nil)
+
+	eventService := eventsMock.NewMockService(ctrl)
+	logged := expectEvent(eventService)
+	claService := projectMock.NewMockService(ctrl)
+	claService.EXPECT().GetCLAManagers(gomock.Any(), oldClaGroupID).Return(nil, nil)
+	s := NewService(githubRepo, githubOrgRepo, eventService, nil, claService)
+
+	assert.Nil(t, s.ProcessInstallationEvent(installationEvent("unsuspend")))
+	assert.Equal(t, events.GithubAppUnsuspended, logged.EventType)
+	assert.True(t, logged.EventData.(*events.GithubAppInstallationEventData).Enabled)
+}
+
+func TestProcessInstallationEventUnknownOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubOrgRepo := github_organizations.NewMockRepository(ctrl)
+	githubOrgRepo.EXPECT().GetGithubOrganization(gomock.Any(), "old-org").Return(nil, github_organizations.ErrOrganizationDoesNotExist)
+
+	// no event is expected for an organization which isn't registered
+	s := NewService(repositoriesmock.NewMockRepository(ctrl), githubOrgRepo, eventsMock.NewMockService(ctrl), nil, projectMock.NewMockService(ctrl))
+	assert.Nil(t, s.ProcessInstallationEvent(installationEvent("suspend")))
+}
+
+func TestProcessInstallationEventEnterpriseOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the organizations of the GitHub Enterprise Server hosts are keyed by host and name
+	githubOrgRepo := github_organizations.NewMockRepository(ctrl)
+	githubOrgRepo.EXPECT().GetGithubOrganization(gomock.Any(), "github.example.com/old-org").Return(nil, github_organizations.ErrOrganizationDoesNotExist)
+
+	event := installationEvent("suspend")
+	event.Installation.Account.HTMLURL = aws.String("https://github.example.com/old-org")
+	s := NewService(repositoriesmock.NewMockRepository(ctrl), githubOrgRepo, eventsMock.NewMockService(ctrl), nil, projectMock.NewMockService(ctrl))
+	assert.Nil(t, s.ProcessInstallationEvent(event))
+}
+
+func TestCheckEventHost(t *testing.T) {
+	publicAccount := &github.User{Login: aws.String("public-org"), HTMLURL: aws.String("https://github.com/public-org")}
+	enterpriseAccount := &github.User{Login: aws.String("enterprise-org"), HTMLURL: aws.String("https://github.example.com/enterprise-org")}
+
+	s := NewService(nil, nil, nil, nil, nil)
+	assert.Nil(t, s.CheckEventHost("", publicAccount))
+	assert.Nil(t, s.CheckEventHost("GitHub.example.com", enterpriseAccount))
+	assert.True(t, errors.Is(s.CheckEventHost("github.example.com", publicAccount), ErrEventHostMismatch))
+	assert.True(t, errors.Is(s.CheckEventHost("", enterpriseAccount), ErrEventHostMismatch))
+	assert.Nil(t, s.CheckEventHost("", nil))
+}
@@ -0,0 +1,29 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_drift
+
+import (
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/dynamo_events"
+	"github.com/google/go-github/v32/github"
+)
+
+// NewTestService creates the service with the installation repositories and branch protection client of the test
+func NewTestService(repo Repository, githubRepo repositories.Repository, githubOrgRepo github_organizations.Repository, autoEnableService dynamo_events.AutoEnableService,
+	githubRepos []*github.Repository, branchProtection githubutils.Repositories) Service {
+	return &service{
+		repo:              repo,
+		githubRepo:        githubRepo,
+		githubOrgRepo:     githubOrgRepo,
+		autoEnableService: autoEnableService,
+		listRepositories: func(installation githubutils.Installation) ([]*github.Repository, error) {
+			return githubRepos, nil
+		},
+		branchProtectionRepository: func(installation githubutils.Installation) (*githubutils.BranchProtectionRepository, error) {
+			return githubutils.NewBranchProtectionRepository(branchProtection), nil
+		},
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/github_drift"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// isNotFound returns true if the GitHub organization or its drift report doesn't exist for the project
+func isNotFound(err error) bool {
+	return errors.Is(err, github_organizations.ErrOrganizationDoesNotExist) || errors.Is(err, ErrReportNotFound)
+}
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service) {
+	api.GithubDriftGetGithubOrganizationDriftReportHandler = github_drift.GetGithubOrganizationDriftReportHandlerFunc(
+		func(params github_drift.GetGithubOrganizationDriftReportParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "GithubDriftGetGithubOrganizationDriftReportHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"orgName":        params.OrgName,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.ProjectSFID) {
+				msg := fmt.Sprintf("user %s does not have access to Get GitHub Organization Drift Report with Project scope of %s", authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Warn(msg)
+				return github_drift.NewGetGithubOrganizationDriftReportForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			report, err := service.GetReport(ctx, params.ProjectSFID, params.OrgName)
+			if err != nil {
+				if isNotFound(err) {
+					msg := fmt.Sprintf("drift report for github organization %s not found", params.OrgName)
+					return github_drift.NewGetGithubOrganizationDriftReportNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem loading the drift report for github organization %s", params.OrgName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return github_drift.NewGetGithubOrganizationDriftReportInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return github_drift.NewGetGithubOrganizationDriftReportOK().WithXRequestID(reqID).WithPayload(report.ToModel())
+		})
+
+	api.GithubDriftScanGithubOrganizationDriftHandler = github_drift.ScanGithubOrganizationDriftHandlerFunc(
+		func(params github_drift.ScanGithubOrganizationDriftParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			autoFix := params.AutoFix != nil && *params.AutoFix
+			f := logrus.Fields{
+				"functionName":   "GithubDriftScanGithubOrganizationDriftHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"orgName":        params.OrgName,
+				"autoFix":        autoFix,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.ProjectSFID) {
+				msg := fmt.Sprintf("user %s does not have access to Scan GitHub Organization Drift with Project scope of %s", authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Warn(msg)
+				return github_drift.NewScanGithubOrganizationDriftForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			report, err := service.ScanOrganization(ctx, params.ProjectSFID, params.OrgName, autoFix)
+			if err != nil {
+				if isNotFound(err) {
+					msg := fmt.Sprintf("github organization %s not found", params.OrgName)
+					return github_drift.NewScanGithubOrganizationDriftNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem scanning github organization %s", params.OrgName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return github_drift.NewScanGithubOrganizationDriftInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return github_drift.NewScanGithubOrganizationDriftOK().WithXRequestID(reqID).WithPayload(report.ToModel())
+		})
+}
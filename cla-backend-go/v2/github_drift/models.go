@@ -0,0 +1,77 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_drift
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// scan statuses
+const (
+	ScanStatusCompleted = "completed"
+	ScanStatusSkipped   = "skipped"
+	ScanStatusFailed    = "failed"
+)
+
+// drift types
+const (
+	// DriftUnknownRepository is a repository of the GitHub App installation which isn't registered in EasyCLA
+	DriftUnknownRepository = "unknown_repository"
+	// DriftDeletedRepository is an enabled repository which is no longer available on GitHub
+	DriftDeletedRepository = "deleted_repository"
+	// DriftMissingBranchProtection is an enabled repository whose default branch doesn't require the EasyCLA check
+	DriftMissingBranchProtection = "missing_branch_protection"
+)
+
+// Report is the database model for the github drift reports table, one report is kept per GitHub organization
+type Report struct {
+	OrganizationName string     `dynamodbav:"organization_name" json:"organization_name"`
+	ProjectSFID      string     `dynamodbav:"project_sfid" json:"project_sfid"`
+	InstallationID   int64      `dynamodbav:"installation_id" json:"installation_id"`
+	ScanStatus       string     `dynamodbav:"scan_status" json:"scan_status"`
+	ScanError        string     `dynamodbav:"scan_error" json:"scan_error"`
+	AutoFix          bool       `dynamodbav:"auto_fix" json:"auto_fix"`
+	DateScanned      string     `dynamodbav:"date_scanned" json:"date_scanned"`
+	Findings         []*Finding `dynamodbav:"findings" json:"findings"`
+	Version          string     `dynamodbav:"version" json:"version"`
+}
+
+// Finding is a single difference between GitHub and the EasyCLA records
+type Finding struct {
+	DriftType          string `dynamodbav:"drift_type" json:"drift_type"`
+	RepositoryName     string `dynamodbav:"repository_name" json:"repository_name"`
+	RepositoryGithubID int64  `dynamodbav:"repository_github_id" json:"repository_github_id"`
+	RepositoryID       string `dynamodbav:"repository_id" json:"repository_id"`
+	ClaGroupID         string `dynamodbav:"cla_group_id" json:"cla_group_id"`
+	Detail             string `dynamodbav:"detail" json:"detail"`
+	Fixed              bool   `dynamodbav:"fixed" json:"fixed"`
+	FixError           string `dynamodbav:"fix_error" json:"fix_error"`
+}
+
+// ToModel converts the database model to the API model
+func (r *Report) ToModel() *models.GithubDriftReport {
+	findings := make([]*models.GithubDriftFinding, 0, len(r.Findings))
+	for _, finding := range r.Findings {
+		findings = append(findings, &models.GithubDriftFinding{
+			DriftType:          finding.DriftType,
+			RepositoryName:     finding.RepositoryName,
+			RepositoryGithubID: finding.RepositoryGithubID,
+			RepositoryID:       finding.RepositoryID,
+			ClaGroupID:         finding.ClaGroupID,
+			Detail:             finding.Detail,
+			Fixed:              finding.Fixed,
+			FixError:           finding.FixError,
+		})
+	}
+	return &models.GithubDriftReport{
+		OrganizationName: r.OrganizationName,
+		ProjectSfid:      r.ProjectSFID,
+		InstallationID:   r.InstallationID,
+		ScanStatus:       r.ScanStatus,
+		ScanError:        r.ScanError,
+		AutoFix:          r.AutoFix,
+		DateScanned:      r.DateScanned,
+		Findings:         findings,
+	}
+}
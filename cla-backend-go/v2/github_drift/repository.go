@@ -0,0 +1,106 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// errors
+var (
+	ErrReportNotFound = errors.New("github drift report not found")
+)
+
+// Repository interface defines the functions for the github drift reports data model
+type Repository interface {
+	PutReport(ctx context.Context, report *Report) error
+	GetReport(ctx context.Context, organizationName string) (*Report, error)
+}
+
+type repository struct {
+	stage          string
+	dynamoDBClient *dynamodb.DynamoDB
+	tableName      string
+}
+
+// NewRepository creates a new instance of the github drift reports repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:          stage,
+		dynamoDBClient: dynamodb.New(awsSession),
+		tableName:      fmt.Sprintf("cla-%s-github-drift-reports", stage),
+	}
+}
+
+// PutReport stores the drift report, replacing the previous report of the GitHub organization
+func (repo *repository) PutReport(ctx context.Context, report *Report) error {
+	f := logrus.Fields{
+		"functionName":     "PutReport",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": report.OrganizationName,
+		"tableName":        repo.tableName,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(report)
+	if err != nil {
+		log.WithFields(f).Warnf("problem encoding the github drift report, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem storing the github drift report, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// GetReport returns the latest drift report of the GitHub organization
+func (repo *repository) GetReport(ctx context.Context, organizationName string) (*Report, error) {
+	f := logrus.Fields{
+		"functionName":     "GetReport",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": organizationName,
+		"tableName":        repo.tableName,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_name": {
+				S: aws.String(organizationName),
+			},
+		},
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("problem loading the github drift report, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrReportNotFound
+	}
+
+	var report Report
+	err = dynamodbattribute.UnmarshalMap(result.Item, &report)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding the github drift report, error: %+v", err)
+		return nil, err
+	}
+	return &report, nil
+}
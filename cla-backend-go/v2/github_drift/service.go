@@ -0,0 +1,319 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/dynamo_events"
+	"github.com/google/go-github/v32/github"
+	"github.com/sirupsen/logrus"
+)
+
+// Service scans the GitHub organizations for differences between the GitHub App installation and the EasyCLA records
+type Service interface {
+	GetReport(ctx context.Context, projectSFID, organizationName string) (*Report, error)
+	ScanOrganization(ctx context.Context, projectSFID, organizationName string, autoFix bool) (*Report, error)
+	ScanAll(ctx context.Context, autoFix bool) (int, error)
+}
+
+type service struct {
+	repo              Repository
+	githubRepo        repositories.Repository
+	githubOrgRepo     github_organizations.Repository
+	autoEnableService dynamo_events.AutoEnableService
+
+	listRepositories           func(installation githubutils.Installation) ([]*github.Repository, error)
+	branchProtectionRepository func(installation githubutils.Installation) (*githubutils.BranchProtectionRepository, error)
+}
+
+// NewService creates a new github drift service
+func NewService(repo Repository, githubRepo repositories.Repository, githubOrgRepo github_organizations.Repository, autoEnableService dynamo_events.AutoEnableService) Service {
+	return &service{
+		repo:              repo,
+		githubRepo:        githubRepo,
+		githubOrgRepo:     githubOrgRepo,
+		autoEnableService: autoEnableService,
+		listRepositories:  githubutils.GetRepositoriesForInstallation,
+		branchProtectionRepository: func(installation githubutils.Installation) (*githubutils.BranchProtectionRepository, error) {
+			client, err := githubutils.NewGithubAppClientForInstallation(installation)
+			if err != nil {
+				return nil, err
+			}
+			return githubutils.NewBranchProtectionRepository(client.Repositories, githubutils.EnableBlockingLimiter()), nil
+		},
+	}
+}
+
+// getOrganization loads the GitHub organization and makes sure it belongs to the project
+func (s *service) getOrganization(ctx context.Context, projectSFID, organizationName string) (*v1Models.GithubOrganization, error) {
+	return s.githubOrgRepo.GetGithubOrganizationForProject(ctx, projectSFID, organizationName)
+}
+
+// GetReport returns the latest drift report of the GitHub organization
+func (s *service) GetReport(ctx context.Context, projectSFID, organizationName string) (*Report, error) {
+	if _, err := s.getOrganization(ctx, projectSFID, organizationName); err != nil {
+		return nil, err
+	}
+	return s.repo.GetReport(ctx, organizationName)
+}
+
+// ScanOrganization scans the GitHub organization right away and stores the new drift report
+func (s *service) ScanOrganization(ctx context.Context, projectSFID, organizationName string, autoFix bool) (*Report, error) {
+	org, err := s.getOrganization(ctx, projectSFID, organizationName)
+	if err != nil {
+		return nil, err
+	}
+	report := s.scan(ctx, org, autoFix)
+	if err := s.repo.PutReport(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ScanAll scans all the GitHub organizations and returns the number of stored drift reports
+func (s *service) ScanAll(ctx context.Context, autoFix bool) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "ScanAll",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"autoFix":        autoFix,
+	}
+
+	orgs, err := s.githubOrgRepo.GetAllGithubOrganizations(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	scanned := 0
+	for _, org := range orgs.List {
+		report := s.scan(ctx, org, autoFix)
+		if err := s.repo.PutReport(ctx, report); err != nil {
+			log.WithFields(f).Warnf("unable to store the drift report of github organization : %s, error: %+v", org.OrganizationName, err)
+			continue
+		}
+		log.WithFields(f).Debugf("github organization : %s scan %s with %d findings", org.OrganizationName, report.ScanStatus, len(report.Findings))
+		scanned++
+	}
+	return scanned, nil
+}
+
+// scan compares the repositories of the GitHub App installation with the repositories of the organization - the
+// drift is only fixed when the organization has auto-enable turned on, and branch protection is only fixed when the
+// organization has branch protection turned on
+func (s *service) scan(ctx context.Context, org *v1Models.GithubOrganization, autoFix bool) *Report {
+	f := logrus.Fields{
+		"functionName":     "scan",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": org.OrganizationName,
+		"installationID":   org.OrganizationInstallationID,
+		"autoFix":          autoFix,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	report := &Report{
+		OrganizationName: org.OrganizationName,
+		ProjectSFID:      org.ProjectSFID,
+		InstallationID:   org.OrganizationInstallationID,
+		AutoFix:          autoFix,
+		DateScanned:      currentTime,
+		Findings:         []*Finding{},
+		Version:          "v1",
+	}
+
+	if org.OrganizationInstallationID == 0 || (org.InstallationStatus != "" && org.InstallationStatus != github_organizations.InstallationStatusActive) {
+		report.ScanStatus = ScanStatusSkipped
+		report.ScanError = "the GitHub App is not installed for the organization"
+		return report
+	}
+
+	githubRepos, err := s.listRepositories(github_organizations.GithubInstallation(org))
+	if err != nil {
+		log.WithFields(f).Warnf("unable to list the installation repositories, error: %+v", err)
+		report.ScanStatus = ScanStatusFailed
+		report.ScanError = err.Error()
+		return report
+	}
+	localRepos, err := s.githubRepo.GetRepositoriesByOrganizationName(ctx, org.OrganizationName)
+	if err != nil && !errors.Is(err, repositories.ErrGithubRepositoryNotFound) {
+		log.WithFields(f).Warnf("unable to load the organization repositories, error: %+v", err)
+		report.ScanStatus = ScanStatusFailed
+		report.ScanError = err.Error()
+		return report
+	}
+
+	localByExternalID := make(map[string]*v1Models.GithubRepository)
+	for _, localRepo := range localRepos {
+		localByExternalID[localRepo.RepositoryExternalID] = localRepo
+	}
+	githubByExternalID := make(map[string]*github.Repository)
+	for _, githubRepo := range githubRepos {
+		externalID := strconv.FormatInt(githubRepo.GetID(), 10)
+		githubByExternalID[externalID] = githubRepo
+		if _, ok := localByExternalID[externalID]; !ok {
+			report.Findings = append(report.Findings, s.unknownRepository(f, org, githubRepo, autoFix))
+		}
+	}
+
+	var branchProtectionRepo *githubutils.BranchProtectionRepository
+	var protectionErrors int
+	for _, localRepo := range localRepos {
+		if !localRepo.Enabled {
+			continue
+		}
+		githubRepo, ok := githubByExternalID[localRepo.RepositoryExternalID]
+		if !ok {
+			report.Findings = append(report.Findings, s.deletedRepository(ctx, f, org, localRepo, autoFix))
+			continue
+		}
+		// archived repositories are read only, their branch protection can't change
+		if githubRepo.GetArchived() {
+			continue
+		}
+
+		if branchProtectionRepo == nil {
+			branchProtectionRepo, err = s.branchProtectionRepository(github_organizations.GithubInstallation(org))
+			if err != nil {
+				log.WithFields(f).Warnf("unable to create the github client, error: %+v", err)
+				report.ScanError = fmt.Sprintf("unable to check the branch protection : %v", err)
+				break
+			}
+		}
+		finding, err := s.checkBranchProtection(ctx, org, branchProtectionRepo, localRepo, githubRepo, autoFix)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to check the branch protection of repository : %s, error: %+v", localRepo.RepositoryName, err)
+			protectionErrors++
+			continue
+		}
+		if finding != nil {
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+	if protectionErrors > 0 {
+		report.ScanError = fmt.Sprintf("unable to check the branch protection of %d repositories", protectionErrors)
+	}
+
+	report.ScanStatus = ScanStatusCompleted
+	return report
+}
+
+// unknownRepository reports a repository of the installation which isn't registered, the repository is added when
+// the organization has auto-enable turned on
+func (s *service) unknownRepository(f logrus.Fields, org *v1Models.GithubOrganization, githubRepo *github.Repository, autoFix bool) *Finding {
+	finding := &Finding{
+		DriftType:          DriftUnknownRepository,
+		RepositoryName:     githubRepo.GetFullName(),
+		RepositoryGithubID: githubRepo.GetID(),
+		Detail:             "the repository is available to the GitHub App but not registered in EasyCLA",
+	}
+	if !autoFix || !org.AutoEnabled {
+		return finding
+	}
+
+	repoModel, err := s.autoEnableService.CreateAutoEnabledRepository(githubRepo)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to auto-enable repository : %s, error: %+v", githubRepo.GetFullName(), err)
+		finding.FixError = err.Error()
+		return finding
+	}
+	finding.Fixed = true
+	finding.RepositoryID = repoModel.RepositoryID
+	finding.ClaGroupID = repoModel.RepositoryProjectID
+	return finding
+}
+
+// deletedRepository reports an enabled repository missing from the installation, the repository is disabled when
+// the organization has auto-enable turned on
+func (s *service) deletedRepository(ctx context.Context, f logrus.Fields, org *v1Models.GithubOrganization, localRepo *v1Models.GithubRepository, autoFix bool) *Finding {
+	finding := &Finding{
+		DriftType:      DriftDeletedRepository,
+		RepositoryName: localRepo.RepositoryName,
+		RepositoryID:   localRepo.RepositoryID,
+		ClaGroupID:     localRepo.RepositoryProjectID,
+		Detail:         "the repository was deleted on GitHub or is no longer available to the GitHub App",
+	}
+	finding.RepositoryGithubID, _ = strconv.ParseInt(localRepo.RepositoryExternalID, 10, 64)
+	if !autoFix || !org.AutoEnabled {
+		return finding
+	}
+
+	err := s.githubRepo.UpdateGithubRepository(ctx, localRepo.RepositoryID, &repositories.RepositoryUpdate{
+		Enabled: aws.Bool(false),
+	}, "disabled as the repository is no longer available on GitHub")
+	if err != nil {
+		log.WithFields(f).Warnf("unable to disable repository : %s, error: %+v", localRepo.RepositoryName, err)
+		finding.FixError = err.Error()
+		return finding
+	}
+	finding.Fixed = true
+	return finding
+}
+
+// checkBranchProtection reports an enabled repository whose default branch doesn't require the EasyCLA check, the
+// branch protection is enabled when the organization has branch protection turned on
+func (s *service) checkBranchProtection(ctx context.Context, org *v1Models.GithubOrganization, branchProtectionRepo *githubutils.BranchProtectionRepository,
+	localRepo *v1Models.GithubRepository, githubRepo *github.Repository, autoFix bool) (*Finding, error) {
+	owner := githubRepo.GetOwner().GetLogin()
+	if owner == "" {
+		owner = org.OrganizationName
+	}
+	branchName := githubRepo.GetDefaultBranch()
+	if branchName == "" {
+		var err error
+		branchName, err = branchProtectionRepo.GetDefaultBranchForRepo(ctx, owner, githubRepo.GetName())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	protection, err := branchProtectionRepo.GetProtectedBranch(ctx, owner, githubRepo.GetName(), branchName)
+	if err != nil && !errors.Is(err, githubutils.ErrBranchNotProtected) {
+		return nil, err
+	}
+	if hasRequiredCheck(protection) {
+		return nil, nil
+	}
+
+	finding := &Finding{
+		DriftType:          DriftMissingBranchProtection,
+		RepositoryName:     localRepo.RepositoryName,
+		RepositoryGithubID: githubRepo.GetID(),
+		RepositoryID:       localRepo.RepositoryID,
+		ClaGroupID:         localRepo.RepositoryProjectID,
+		Detail:             fmt.Sprintf("the branch %s does not require the %s status check", branchName, utils.GitHubBotName),
+	}
+	if !autoFix || !org.BranchProtectionEnabled {
+		return finding, nil
+	}
+
+	err = branchProtectionRepo.EnableBranchProtection(ctx, owner, githubRepo.GetName(), branchName, true, []string{utils.GitHubBotName}, []string{})
+	if err != nil {
+		finding.FixError = err.Error()
+		return finding, nil
+	}
+	finding.Fixed = true
+	return finding, nil
+}
+
+// hasRequiredCheck returns true if the branch protection requires the EasyCLA status check
+func hasRequiredCheck(protection *github.Protection) bool {
+	if protection == nil || protection.RequiredStatusChecks == nil {
+		return false
+	}
+	for _, check := range protection.RequiredStatusChecks.Contexts {
+		if check == utils.GitHubBotName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_drift_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	repositoriesmock "github.com/communitybridge/easycla/cla-backend-go/repositories/mock"
+	dynamoEventsMock "github.com/communitybridge/easycla/cla-backend-go/v2/dynamo_events/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/github_drift"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/github_drift/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-github/v32/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func githubRepository(id int64, name string) *github.Repository {
+	return &github.Repository{
+		ID:            github.Int64(id),
+		Name:          github.String(name),
+		FullName:      github.String("org/" + name),
+		DefaultBranch: github.String("main"),
+		Owner:         &github.User{Login: github.String("org")},
+	}
+}
+
+func TestScanOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubOrgRepo := github_organizations.NewMockRepository(ctrl)
+	githubOrgRepo.EXPECT().GetGithubOrganizationForProject(gomock.Any(), "project-sfid", "org").Return(&v1Models.GithubOrganization{
+		OrganizationName:           "org",
+		ProjectSFID:                "project-sfid",
+		OrganizationInstallationID: 1,
+	}, nil)
+
+	githubRepo := repositoriesmock.NewMockRepository(ctrl)
+	githubRepo.EXPECT().GetRepositoriesByOrganizationName(gomock.Any(), "org").Return([]*v1Models.GithubRepository{
+		{RepositoryID: "protected", RepositoryExternalID: "1", RepositoryName: "org/protected", Enabled: true},
+		{RepositoryID: "unprotected", RepositoryExternalID: "2", RepositoryName: "org/unprotected", Enabled: true},
+		{RepositoryID: "deleted", RepositoryExternalID: "3", RepositoryName: "org/deleted", Enabled: true},
+		{RepositoryID: "disabled", RepositoryExternalID: "4", RepositoryName: "org/disabled", Enabled: false},
+	}, nil)
+
+	branchProtection := githubutils.NewMockRepositories(ctrl)
+	branchProtection.EXPECT().GetBranchProtection(gomock.Any(), "org", "protected", "main").Return(&github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"EasyCLA"}},
+	}, nil, nil)
+	branchProtection.EXPECT().GetBranchProtection(gomock.Any(), "org", "unprotected", "main").Return(&github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"ci"}},
+	}, nil, nil)
+
+	githubRepos := []*github.Repository{
+		githubRepository(1, "protected"),
+		githubRepository(2, "unprotected"),
+		githubRepository(4, "disabled"),
+		githubRepository(5, "unknown"),
+	}
+	var stored *github_drift.Report
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().PutReport(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, report *github_drift.Report) {
+		stored = report
+	}).Return(nil)
+	// auto-enable and branch protection are turned off for the organization, the auto-enable service is never called
+	s := github_drift.NewTestService(repo, githubRepo, githubOrgRepo, dynamoEventsMock.NewMockAutoEnableService(ctrl), githubRepos, branchProtection)
+
+	report, err := s.ScanOrganization(context.Background(), "project-sfid", "org", true)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, github_drift.ScanStatusCompleted, report.ScanStatus)
+	assert.Equal(t, report, stored)
+	if assert.Len(t, report.Findings, 3) {
+		assert.Equal(t, github_drift.DriftUnknownRepository, report.Findings[0].DriftType)
+		assert.Equal(t, "org/unknown", report.Findings[0].RepositoryName)
+		assert.Equal(t, github_drift.DriftMissingBranchProtection, report.Findings[1].DriftType)
+		assert.Equal(t, "unprotected", report.Findings[1].RepositoryID)
+		assert.Equal(t, github_drift.DriftDeletedRepository, report.Findings[2].DriftType)
+		assert.Equal(t, "deleted", report.Findings[2].RepositoryID)
+		for _, finding := range report.Findings {
+			assert.False(t, finding.Fixed)
+		}
+	}
+}
+
+func TestScanOrganizationAutoFix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubOrgRepo := github_organizations.NewMockRepository(ctrl)
+	githubOrgRepo.EXPECT().GetGithubOrganizationForProject(gomock.Any(), "project-sfid", "org").Return(&v1Models.GithubOrganization{
+		OrganizationName:           "org",
+		ProjectSFID:                "project-sfid",
+		OrganizationInstallationID: 1,
+		AutoEnabled:                true,
+	}, nil)
+
+	githubRepo := repositoriesmock.NewMockRepository(ctrl)
+	githubRepo.EXPECT().GetRepositoriesByOrganizationName(gomock.Any(), "org").Return([]*v1Models.GithubRepository{
+		{RepositoryID: "deleted", RepositoryExternalID: "3", RepositoryName: "org/deleted", Enabled: true},
+	}, nil)
+	githubRepo.EXPECT().UpdateGithubRepository(gomock.Any(), "deleted", &repositories.RepositoryUpdate{
+		Enabled: aws.Bool(false),
+	}, gomock.Any()).Return(nil)
+
+	autoEnableService := dynamoEventsMock.NewMockAutoEnableService(ctrl)
+	autoEnableService.EXPECT().CreateAutoEnabledRepository(gomock.Any()).DoAndReturn(func(repo *github.Repository) (*v1Models.GithubRepository, error) {
+		assert.Equal(t, "org/unknown", repo.GetFullName())
+		return &v1Models.GithubRepository{RepositoryID: "new-repository-id", RepositoryProjectID: "cla-group-id"}, nil
+	})
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().PutReport(gomock.Any(), gomock.Any()).Return(nil)
+	s := github_drift.NewTestService(repo, githubRepo, githubOrgRepo, autoEnableService,
+		[]*github.Repository{githubRepository(5, "unknown")}, githubutils.NewMockRepositories(ctrl))
+
+	report, err := s.ScanOrganization(context.Background(), "project-sfid", "org", true)
+	if !assert.Nil(t, err) {
+		return
+	}
+	if assert.Len(t, report.Findings, 2) {
+		assert.True(t, report.Findings[0].Fixed)
+		assert.Equal(t, "new-repository-id", report.Findings[0].RepositoryID)
+		assert.True(t, report.Findings[1].Fixed)
+	}
+}
+
+func TestScanOrganizationNotInProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubOrgRepo := github_organizations.NewMockRepository(ctrl)
+	githubOrgRepo.EXPECT().GetGithubOrganizationForProject(gomock.Any(), "project-sfid", "org").Return(nil, github_organizations.ErrOrganizationDoesNotExist)
+
+	s := github_drift.NewTestService(mock.NewMockRepository(ctrl), repositoriesmock.NewMockRepository(ctrl), githubOrgRepo, dynamoEventsMock.NewMockAutoEnableService(ctrl), nil, nil)
+
+	_, err := s.ScanOrganization(context.Background(), "project-sfid", "org", false)
+	assert.Equal(t, github_organizations.ErrOrganizationDoesNotExist, err)
+}
+
+func TestScanAllSkipsUninstalledOrganizations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubOrgRepo := github_organizations.NewMockRepository(ctrl)
+	githubOrgRepo.EXPECT().GetAllGithubOrganizations(gomock.Any()).Return(&v1Models.GithubOrganizations{
+		List: []*v1Models.GithubOrganization{
+			{OrganizationName: "not-installed"},
+			{OrganizationName: "suspended", OrganizationInstallationID: 1, InstallationStatus: github_organizations.InstallationStatusSuspended},
+		},
+	}, nil)
+
+	scanStatus := make(map[string]string)
+	repo := mock.NewMockRepository(ctrl)
+	repo.EXPECT().PutReport(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, report *github_drift.Report) {
+		scanStatus[report.OrganizationName] = report.ScanStatus
+	}).Return(nil).Times(2)
+	s := github_drift.NewTestService(repo, repositoriesmock.NewMockRepository(ctrl), githubOrgRepo, dynamoEventsMock.NewMockAutoEnableService(ctrl), nil, nil)
+
+	scanned, err := s.ScanAll(context.Background(), true)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, scanned)
+	assert.Equal(t, map[string]string{
+		"not-installed": github_drift.ScanStatusSkipped,
+		"suspended":     github_drift.ScanStatusSkipped,
+	}, scanStatus)
+}
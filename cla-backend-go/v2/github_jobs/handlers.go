@@ -0,0 +1,104 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/github_jobs"
+	githubJobs "github.com/communitybridge/easycla/cla-backend-go/github_jobs"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// isNotFound returns true if the GitHub organization or the job doesn't exist
+func isNotFound(err error) bool {
+	return errors.Is(err, github_organizations.ErrOrganizationDoesNotExist) ||
+		errors.Is(err, githubJobs.ErrJobNotFound)
+}
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service githubJobs.Service) {
+	api.GithubJobsListGithubOrganizationJobsHandler = github_jobs.ListGithubOrganizationJobsHandlerFunc(
+		func(params github_jobs.ListGithubOrganizationJobsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "GithubJobsListGithubOrganizationJobsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"orgName":        params.OrgName,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.ProjectSFID) {
+				msg := fmt.Sprintf("user %s does not have access to List GitHub Organization Jobs with Project scope of %s", authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Warn(msg)
+				return github_jobs.NewListGithubOrganizationJobsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			jobs, err := service.GetJobsByOrganization(ctx, params.ProjectSFID, params.OrgName)
+			if err != nil {
+				if isNotFound(err) {
+					msg := fmt.Sprintf("github organization %s not found", params.OrgName)
+					return github_jobs.NewListGithubOrganizationJobsNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem loading the jobs of github organization %s", params.OrgName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return github_jobs.NewListGithubOrganizationJobsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			result := &models.GithubJobs{List: []*models.GithubJob{}}
+			for _, job := range jobs {
+				result.List = append(result.List, job.ToModel())
+			}
+			return github_jobs.NewListGithubOrganizationJobsOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.GithubJobsGetGithubOrganizationJobHandler = github_jobs.GetGithubOrganizationJobHandlerFunc(
+		func(params github_jobs.GetGithubOrganizationJobParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "GithubJobsGetGithubOrganizationJobHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"orgName":        params.OrgName,
+				"jobID":          params.JobID,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(authUser, params.ProjectSFID) {
+				msg := fmt.Sprintf("user %s does not have access to Get GitHub Organization Job with Project scope of %s", authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Warn(msg)
+				return github_jobs.NewGetGithubOrganizationJobForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			job, err := service.GetJob(ctx, params.ProjectSFID, params.OrgName, params.JobID)
+			if err != nil {
+				if isNotFound(err) {
+					msg := fmt.Sprintf("job %s of github organization %s not found", params.JobID, params.OrgName)
+					return github_jobs.NewGetGithubOrganizationJobNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem loading job %s of github organization %s", params.JobID, params.OrgName)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return github_jobs.NewGetGithubOrganizationJobInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return github_jobs.NewGetGithubOrganizationJobOK().WithXRequestID(reqID).WithPayload(job.ToModel())
+		})
+}
@@ -0,0 +1,57 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_org_members
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/github_org_members"
+	v1GithubOrgMembers "github.com/communitybridge/easycla/cla-backend-go/github_org_members"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1GithubOrgMembers.Service) {
+	api.GithubOrgMembersGetCoveredGithubOrgMembersHandler = github_org_members.GetCoveredGithubOrgMembersHandlerFunc(
+		func(params github_org_members.GetCoveredGithubOrgMembersParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "GithubOrgMembersGetCoveredGithubOrgMembersHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to Get Covered GitHub Organization Members with Project|Organization scope of %s | %s",
+					authUser.UserName, params.ProjectSFID, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return github_org_members.NewGetCoveredGithubOrgMembersForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetCoveredMembers(ctx, params.ClaGroupID, params.CompanySFID)
+			if err != nil {
+				msg := "problem loading the covered github organization members"
+				log.WithFields(f).WithError(err).Warn(msg)
+				if err == v1GithubOrgMembers.ErrCompanyNotFound || err == v1GithubOrgMembers.ErrSignatureNotFound {
+					return github_org_members.NewGetCoveredGithubOrgMembersNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				return github_org_members.NewGetCoveredGithubOrgMembersInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return github_org_members.NewGetCoveredGithubOrgMembersOK().WithXRequestID(reqID).WithPayload(result)
+		})
+}
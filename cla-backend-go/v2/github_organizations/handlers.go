@@ -13,7 +13,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/github_organizations"
-	"github.com/communitybridge/easycla/cla-backend-go/github"
+	v1GithubOrg "github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/go-openapi/runtime/middleware"
 )
@@ -73,10 +73,11 @@ func Configure(api *operations.EasyclaAPI, service Service, eventService events.
 				})
 			}
 
-			_, err := github.GetOrganization(ctx, *params.Body.OrganizationName)
+			enterpriseBaseURL, err := v1GithubOrg.ValidateGithubOrganizationHost(ctx, *params.Body.OrganizationName, params.Body.EnterpriseBaseURL, params.Body.EnterpriseAppID)
 			if err != nil {
 				return github_organizations.NewAddProjectGithubOrganizationBadRequest().WithPayload(errorResponse(reqID, err))
 			}
+			params.Body.EnterpriseBaseURL = enterpriseBaseURL
 
 			result, err := service.AddGithubOrganization(ctx, params.ProjectSFID, params.Body)
 			if err != nil {
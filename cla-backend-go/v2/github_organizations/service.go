@@ -122,12 +122,19 @@ func (s service) GetGithubOrganizations(ctx context.Context, projectSFID string)
 			ConnectionStatus:        "",
 			GithubOrganizationName:  org.OrganizationName,
 			Repositories:            make([]*models.ProjectGithubRepository, 0),
+
+			InstallationStatus:               org.InstallationStatus,
+			InstallationStatusDate:           org.InstallationStatusDate,
+			InstallationDisabledRepositories: int64(len(org.InstallationDisabledRepositoryIDs)),
+			EnterpriseBaseURL:                org.EnterpriseBaseURL,
 		}
 
 		orgmap[org.OrganizationName] = rorg
 		out.List = append(out.List, rorg)
 		if org.OrganizationInstallationID == 0 {
 			rorg.ConnectionStatus = NoConnection
+		} else if org.InstallationStatus == v1GithubOrg.InstallationStatusSuspended {
+			rorg.ConnectionStatus = ConnectionFailure
 		} else {
 			if org.Repositories.Error != "" {
 				rorg.ConnectionStatus = ConnectionFailure
@@ -19,6 +19,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
 	"github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 
 	"github.com/aws/aws-sdk-go/aws"
 	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
@@ -44,7 +45,7 @@ type Service interface {
 // GithubOrgRepo provide method to get github organization by name
 type GithubOrgRepo interface {
 	GetGithubOrganizationByName(ctx context.Context, githubOrganizationName string) (*v1Models.GithubOrganizations, error)
-	GetGithubOrganization(ctx context.Context, githubOrganizationName string) (*v1Models.GithubOrganization, error)
+	GetGithubOrganization(ctx context.Context, organizationKey string) (*v1Models.GithubOrganization, error)
 	GetGithubOrganizations(ctx context.Context, projectSFID string) (*v1Models.GithubOrganizations, error)
 }
 
@@ -106,7 +107,7 @@ func (s *service) AddGithubRepository(ctx context.Context, projectSFID string, i
 	if err != nil {
 		return nil, err
 	}
-	ghRepo, err := github.GetRepositoryByExternalID(ctx, org.List[0].OrganizationInstallationID, repoGithubID)
+	ghRepo, err := github.GetRepositoryByExternalIDForInstallation(ctx, githubInstallation(org.List[0]), repoGithubID)
 	if err != nil {
 		return nil, err
 	}
@@ -179,7 +180,7 @@ func (s *service) ListProjectRepositories(ctx context.Context, projectSFID strin
 	for _, gitHubOrg := range githubOrgList.List {
 		// Query GitHub for the list of public repositories...
 		log.WithFields(f).Debugf("querying github by organization: %s", gitHubOrg.OrganizationName)
-		ghRepoList, getRepoErr := github.GetRepositories(ctx, gitHubOrg.OrganizationName)
+		ghRepoList, getRepoErr := github.GetRepositoriesForHost(ctx, gitHubOrg.EnterpriseBaseURL, gitHubOrg.OrganizationName)
 		if getRepoErr != nil {
 			log.WithFields(f).WithError(getRepoErr).Warn("unable to lookup github organization details")
 			return response, getRepoErr
@@ -247,7 +248,7 @@ func (s *service) GetProtectedBranch(ctx context.Context, projectSFID, repositor
 	githubRepoName := githubRepository.RepositoryName
 	githubRepoName = github.CleanGithubRepoName(githubRepoName)
 
-	githubClient, err := s.getGithubClientForOrgName(ctx, githubOrgName)
+	githubClient, err := s.getGithubClientForRepository(ctx, githubRepository)
 	if err != nil {
 		return nil, err
 	}
@@ -294,7 +295,7 @@ func (s *service) UpdateProtectedBranch(ctx context.Context, projectSFID, reposi
 	githubRepoName := githubRepository.RepositoryName
 	githubRepoName = github.CleanGithubRepoName(githubRepoName)
 
-	githubClient, err := s.getGithubClientForOrgName(ctx, githubOrgName)
+	githubClient, err := s.getGithubClientForRepository(ctx, githubRepository)
 	if err != nil {
 		return nil, err
 	}
@@ -363,14 +364,27 @@ func (s *service) getGithubRepo(ctx context.Context, projectSFID, repositoryID s
 	return githubRepository, nil
 }
 
-func (s *service) getGithubClientForOrgName(ctx context.Context, githubOrgName string) (*githubsdk.Client, error) {
-	githubOrg, err := s.ghOrgRepo.GetGithubOrganization(ctx, githubOrgName)
+// githubInstallation returns the GitHub App installation of the organization on github.com or on its GitHub Enterprise
+// Server host
+func githubInstallation(githubOrg *v1Models.GithubOrganization) github.Installation {
+	return github.Installation{
+		EnterpriseBaseURL: githubOrg.EnterpriseBaseURL,
+		EnterpriseAppID:   githubOrg.EnterpriseAppID,
+		InstallationID:    githubOrg.OrganizationInstallationID,
+	}
+}
+
+// getGithubClientForRepository creates the github client for the installation of the GitHub organization of the
+// repository
+func (s *service) getGithubClientForRepository(ctx context.Context, githubRepository *v1Models.GithubRepository) (*githubsdk.Client, error) {
+	githubOrgName := githubRepository.RepositoryOrganizationName
+	githubOrg, err := s.ghOrgRepo.GetGithubOrganization(ctx, github_organizations.RepositoryOrganizationKey(githubRepository))
 	if err != nil {
 		log.Warnf("fetching githubOrg %s failed, error: %v", githubOrgName, err)
 		return nil, err
 	}
 
-	githubClient, err := github.NewGithubAppClient(githubOrg.OrganizationInstallationID)
+	githubClient, err := github.NewGithubAppClientForInstallation(githubInstallation(githubOrg))
 	if err != nil {
 		log.Warnf("creating the github client for installation id %d failed, error: %v", githubOrg.OrganizationInstallationID, err)
 		return nil, err
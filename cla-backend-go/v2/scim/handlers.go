@@ -0,0 +1,182 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/scim"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Scim "github.com/communitybridge/easycla/cla-backend-go/scim"
+	v1Signatures "github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// isAuthorized returns true if the user may manage the SCIM tokens of the company - API keys cannot be used to
+// manage SCIM tokens
+func isAuthorized(authUser *auth.User, projectSFID, companySFID string) bool {
+	if _, ok := utils.GetAPIKeyID(authUser); ok {
+		return false
+	}
+	return utils.IsUserAuthorizedForProjectOrganizationTree(authUser, projectSFID, companySFID)
+}
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1Scim.Service, eventService events.Service) {
+	api.ScimListScimTokensHandler = scim.ListScimTokensHandlerFunc(
+		func(params scim.ListScimTokensParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ScimListScimTokensHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !isAuthorized(authUser, params.ProjectSFID, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to List SCIM Tokens with Project|Organization scope of %s | %s",
+					authUser.UserName, params.ProjectSFID, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return scim.NewListScimTokensForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			tokens, err := service.GetTokens(ctx, params.ClaGroupID, params.CompanySFID)
+			if err != nil {
+				msg := "problem loading SCIM tokens"
+				log.WithFields(f).WithError(err).Warn(msg)
+				if isNotFound(err) {
+					return scim.NewListScimTokensNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				return scim.NewListScimTokensInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.ScimTokenList{
+				List: []*models.ScimToken{},
+			}
+			for _, token := range tokens {
+				response.List = append(response.List, token.ToModel())
+			}
+			return scim.NewListScimTokensOK().WithXRequestID(reqID).WithPayload(response)
+		})
+
+	api.ScimCreateScimTokenHandler = scim.CreateScimTokenHandlerFunc(
+		func(params scim.CreateScimTokenParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ScimCreateScimTokenHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			// ACL is checked against the signature by the service
+			if !isAuthorized(authUser, params.ProjectSFID, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to Create SCIM Token with Project|Organization scope of %s | %s",
+					authUser.UserName, params.ProjectSFID, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return scim.NewCreateScimTokenForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			token, tokenValue, err := service.CreateToken(ctx, authUser, params.ClaGroupID, params.CompanySFID)
+			if err != nil {
+				msg := "problem creating SCIM token"
+				log.WithFields(f).WithError(err).Warn(msg)
+				if isNotFound(err) {
+					return scim.NewCreateScimTokenNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				if _, ok := err.(*v1Signatures.ForbiddenError); ok {
+					return scim.NewCreateScimTokenForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
+				}
+				return scim.NewCreateScimTokenInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername:        authUser.UserName,
+				EventType:         events.ScimTokenCreated,
+				ProjectID:         token.ClaGroupID,
+				CompanyID:         token.CompanyID,
+				ExternalProjectID: params.ProjectSFID,
+				EventData: &events.ScimTokenCreatedEventData{
+					TokenID:     token.TokenID,
+					TokenPrefix: token.TokenPrefix,
+				},
+			})
+
+			return scim.NewCreateScimTokenOK().WithXRequestID(reqID).WithPayload(&models.ScimTokenCreated{
+				ScimToken: token.ToModel(),
+				Token:     tokenValue,
+			})
+		})
+
+	api.ScimRevokeScimTokenHandler = scim.RevokeScimTokenHandlerFunc(
+		func(params scim.RevokeScimTokenParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "ScimRevokeScimTokenHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"companySFID":    params.CompanySFID,
+				"claGroupID":     params.ClaGroupID,
+				"tokenID":        params.TokenID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !isAuthorized(authUser, params.ProjectSFID, params.CompanySFID) {
+				msg := fmt.Sprintf("user %s does not have access to Revoke SCIM Token with Project|Organization scope of %s | %s",
+					authUser.UserName, params.ProjectSFID, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return scim.NewRevokeScimTokenForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			token, err := service.RevokeToken(ctx, params.ClaGroupID, params.CompanySFID, params.TokenID, authUser.UserName)
+			if err != nil {
+				if err == v1Scim.ErrTokenNotFound {
+					msg := fmt.Sprintf("SCIM token %s not found", params.TokenID)
+					return scim.NewRevokeScimTokenNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem revoking SCIM token %s", params.TokenID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return scim.NewRevokeScimTokenInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername:        authUser.UserName,
+				EventType:         events.ScimTokenRevoked,
+				ProjectID:         token.ClaGroupID,
+				CompanyID:         token.CompanyID,
+				ExternalProjectID: params.ProjectSFID,
+				EventData: &events.ScimTokenRevokedEventData{
+					TokenID:     token.TokenID,
+					TokenPrefix: token.TokenPrefix,
+				},
+			})
+
+			return scim.NewRevokeScimTokenNoContent().WithXRequestID(reqID)
+		})
+}
+
+func isNotFound(err error) bool {
+	return err == v1Scim.ErrCompanyNotFound || err == v1Scim.ErrCLAGroupNotFound || err == v1Scim.ErrSignatureNotFound
+}
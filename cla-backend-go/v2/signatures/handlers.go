@@ -102,12 +102,19 @@ func Configure(api *operations.EasyclaAPI, projectService project.Service, proje
 			"companySFID":    params.CompanySFID,
 		}
 
-		// Must be in the Project|Organization Scope to see this - ACL is checked in the service when the signature is loaded
+		// Must be in the Project|Organization Scope or be an approval list delegate of the company to see this - ACL is
+		// checked in the service when the signature is loaded
 		if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, params.CompanySFID) {
-			msg := fmt.Sprintf("user %s does not have access to update Project Company Approval List with Project|Organization scope of %s | %s",
-				authUser.UserName, params.ProjectSFID, params.CompanySFID)
-			log.WithFields(f).Warn(msg)
-			return signatures.NewUpdateApprovalListForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			isDelegate, delegateErr := v1SignatureService.IsCompanyDelegate(ctx, params.CompanySFID, params.ClaGroupID, authUser.UserName, signatureService.DelegateScopeApprovalList)
+			if delegateErr != nil {
+				log.WithFields(f).WithError(delegateErr).Warn("unable to check the CLA manager delegates of the company")
+			}
+			if !isDelegate {
+				msg := fmt.Sprintf("user %s does not have access to update Project Company Approval List with Project|Organization scope of %s | %s",
+					authUser.UserName, params.ProjectSFID, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return signatures.NewUpdateApprovalListForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
 		}
 
 		// Valid the payload input - the validator will return a middleware.Responder response/error type
@@ -158,9 +165,13 @@ func Configure(api *operations.EasyclaAPI, projectService project.Service, proje
 		if updateErr != nil || updatedSig == nil {
 			msg := fmt.Sprintf("unable to update signature approval list using CLA Group ID: %s", params.ClaGroupID)
 			log.WithFields(f).Warn(msg)
-			if err, ok := err.(*signatureService.ForbiddenError); ok {
+			if err, ok := updateErr.(*signatureService.ForbiddenError); ok {
 				return signatures.NewUpdateApprovalListForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
 			}
+			// Return the reason, e.g. an unverified domain, so the CLA manager knows how to fix the request
+			if err, ok := updateErr.(*signatureService.BadRequestError); ok {
+				return signatures.NewUpdateApprovalListBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
 			return signatures.NewUpdateApprovalListBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
 		}
 
@@ -187,6 +198,19 @@ func Configure(api *operations.EasyclaAPI, projectService project.Service, proje
 			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
 			"signatureID":    params.SignatureID,
 		}
+
+		// Must have access to the signature or be a view only or approval list delegate of the CCLA signature
+		haveAccess, aclErr := isUserHaveAccessToSignatureApprovalList(ctx, authUser, params.SignatureID, v1SignatureService, companyService, projectClaGroupsRepo, signatureService.DelegateScopeViewOnly, signatureService.DelegateScopeApprovalList)
+		if aclErr != nil {
+			log.WithFields(f).WithError(aclErr).Warn("problem determining signature access")
+			return signatures.NewGetGitHubOrgWhitelistBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, aclErr))
+		}
+		if !haveAccess {
+			msg := fmt.Sprintf("user %s does not have access to the GitHub organization approval list of signature: %s", authUser.UserName, params.SignatureID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewGetGitHubOrgWhitelistForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
 		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
 		if err != nil {
 			log.WithFields(f).Warnf("error retrieving session from the session store, error: %+v", err)
@@ -226,6 +250,19 @@ func Configure(api *operations.EasyclaAPI, projectService project.Service, proje
 		}
 
 		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+		// Must have access to the signature or be an approval list delegate of the CCLA signature
+		haveAccess, aclErr := isUserHaveAccessToSignatureApprovalList(ctx, authUser, params.SignatureID, v1SignatureService, companyService, projectClaGroupsRepo, signatureService.DelegateScopeApprovalList)
+		if aclErr != nil {
+			log.WithFields(f).WithError(aclErr).Warn("problem determining signature access")
+			return signatures.NewAddGitHubOrgWhitelistBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, aclErr))
+		}
+		if !haveAccess {
+			msg := fmt.Sprintf("user %s does not have access to the GitHub organization approval list of signature: %s", authUser.UserName, params.SignatureID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewAddGitHubOrgWhitelistForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
 		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
 		if err != nil {
 			log.WithFields(f).Warnf("error retrieving session from the session store, error: %+v", err)
@@ -293,6 +330,19 @@ func Configure(api *operations.EasyclaAPI, projectService project.Service, proje
 			"signatureID":    params.SignatureID,
 		}
 		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+		// Must have access to the signature or be an approval list delegate of the CCLA signature
+		haveAccess, aclErr := isUserHaveAccessToSignatureApprovalList(ctx, authUser, params.SignatureID, v1SignatureService, companyService, projectClaGroupsRepo, signatureService.DelegateScopeApprovalList)
+		if aclErr != nil {
+			log.WithFields(f).WithError(aclErr).Warn("problem determining signature access")
+			return signatures.NewDeleteGitHubOrgWhitelistBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, aclErr))
+		}
+		if !haveAccess {
+			msg := fmt.Sprintf("user %s does not have access to the GitHub organization approval list of signature: %s", authUser.UserName, params.SignatureID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewDeleteGitHubOrgWhitelistForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
 		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
 		if err != nil {
 			log.WithFields(f).Warnf("error retrieving session from the session store, error: %+v", err)
@@ -442,12 +492,27 @@ func Configure(api *operations.EasyclaAPI, projectService project.Service, proje
 		// - if project scope (like a PM)
 		// - if project|organization scope (like CLA Manager, CLA Signatory)
 		// - if organization scope (like company admin)
+		// - if a view only or approval list delegate of the company CCLA signature
 		if !isUserHaveAccessToCLAProjectOrganization(ctx, authUser, params.ProjectSFID, params.CompanySFID, projectClaGroupsRepo) {
-			msg := fmt.Sprintf("user %s is not authorized to view project company signatures any scope of project: %s, organization %s",
-				authUser.UserName, params.ProjectSFID, params.CompanySFID)
-			log.WithFields(f).Warn(msg)
-			return signatures.NewGetProjectCompanySignaturesForbidden().WithXRequestID(reqID).WithPayload(
-				utils.ErrorResponseForbidden(reqID, msg))
+			isDelegate := false
+			projectCLAGroupModel, projectCLAGroupErr := projectClaGroupsRepo.GetClaGroupIDForProject(params.ProjectSFID)
+			if projectCLAGroupErr != nil || projectCLAGroupModel == nil {
+				log.WithFields(f).WithError(projectCLAGroupErr).Warn("unable to lookup the CLA group of the project")
+			} else {
+				var delegateErr error
+				isDelegate, delegateErr = v1SignatureService.IsCompanyDelegate(ctx, params.CompanySFID, projectCLAGroupModel.ClaGroupID, authUser.UserName,
+					signatureService.DelegateScopeViewOnly, signatureService.DelegateScopeApprovalList)
+				if delegateErr != nil {
+					log.WithFields(f).WithError(delegateErr).Warn("unable to check the CLA manager delegates of the company")
+				}
+			}
+			if !isDelegate {
+				msg := fmt.Sprintf("user %s is not authorized to view project company signatures any scope of project: %s, organization %s",
+					authUser.UserName, params.ProjectSFID, params.CompanySFID)
+				log.WithFields(f).Warn(msg)
+				return signatures.NewGetProjectCompanySignaturesForbidden().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
 		}
 
 		log.WithFields(f).Debug("loading project company signatures...")
@@ -1267,6 +1332,38 @@ func isUserHaveAccessOfSignedSignaturePDF(ctx context.Context, authUser *auth.Us
 	return false, nil
 }
 
+// isUserHaveAccessToSignatureApprovalList returns true if the specified user has access to the provided signature or
+// holds an active delegation with one of the scopes on it, false otherwise
+func isUserHaveAccessToSignatureApprovalList(ctx context.Context, authUser *auth.User, signatureID string, v1SignatureService signatureService.SignatureService, companyService company.IService, projectClaGroupRepo projects_cla_groups.Repository, scopes ...string) (bool, error) {
+	f := logrus.Fields{
+		"functionName":   "isUserHaveAccessToSignatureApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"authUserName":   authUser.UserName,
+		"signatureID":    signatureID,
+		"scopes":         strings.Join(scopes, ","),
+	}
+
+	signatureModel, err := v1SignatureService.GetSignature(ctx, signatureID)
+	if err != nil {
+		return false, err
+	}
+	if signatureModel == nil {
+		return false, errors.New("signature not found")
+	}
+
+	haveAccess, err := isUserHaveAccessOfSignedSignaturePDF(ctx, authUser, signatureModel, companyService, projectClaGroupRepo)
+	if err != nil {
+		// the delegates are stored on the signature itself, keep checking them
+		log.WithFields(f).WithError(err).Warn("problem determining signature access with project or project|org scope")
+	}
+	if haveAccess {
+		return true, nil
+	}
+
+	log.WithFields(f).Debug("checking the CLA manager delegates of the signature...")
+	return v1SignatureService.IsAuthorizedDelegate(ctx, signatureID, authUser.UserName, scopes...)
+}
+
 type codedResponse interface {
 	Code() string
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/communitybridge/easycla/cla-backend-go/events"
 	v1Events "github.com/communitybridge/easycla/cla-backend-go/events"
 	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
@@ -34,9 +35,10 @@ func Configure(api *operations.EasyclaAPI, service v1Template.Service, eventsSer
 		f := logrus.Fields{
 			"functionName":   "TemplateGetTemplatesHandler",
 			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"foundationSFID": aws.StringValue(params.FoundationSFID),
 		}
 
-		templates, err := service.GetTemplates(params.HTTPRequest.Context())
+		templates, err := service.GetTemplates(params.HTTPRequest.Context(), aws.StringValue(params.FoundationSFID))
 		if err != nil {
 			log.WithFields(f).WithError(err).Warn("problem loading templates")
 			return template.NewGetTemplatesBadRequest().WithPayload(errorResponse(reqID, err))
@@ -0,0 +1,122 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package user_merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/users"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1UserMerge "github.com/communitybridge/easycla/cla-backend-go/user_merge"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1UserMerge.Service, eventService events.Service) {
+	api.UsersGetUserDuplicateCandidatesHandler = users.GetUserDuplicateCandidatesHandlerFunc(
+		func(params users.GetUserDuplicateCandidatesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "UsersGetUserDuplicateCandidatesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !utils.IsUserAdmin(authUser) {
+				msg := fmt.Sprintf("user %s does not have access to Get User Duplicate Candidates - only Admins allowed", authUser.UserName)
+				log.WithFields(f).Warn(msg)
+				return users.NewGetUserDuplicateCandidatesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			candidates, err := service.GetDuplicateCandidates(ctx)
+			if err != nil {
+				msg := "problem loading the duplicate user candidates"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return users.NewGetUserDuplicateCandidatesInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.UserDuplicateCandidateList{
+				List: []*models.UserDuplicateCandidate{},
+			}
+			for _, candidate := range candidates {
+				response.List = append(response.List, candidate.ToModel())
+			}
+			return users.NewGetUserDuplicateCandidatesOK().WithXRequestID(reqID).WithPayload(response)
+		})
+
+	api.UsersMergeUsersHandler = users.MergeUsersHandlerFunc(
+		func(params users.MergeUsersParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "UsersMergeUsersHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"userID":         params.UserID,
+				"dryRun":         params.Body.DryRun,
+			}
+
+			// Contributors can merge their own duplicate records, the service checks the ownership
+			requester := v1UserMerge.Requester{
+				LFUsername: authUser.UserName,
+				Email:      authUser.Email,
+				Admin:      utils.IsUserAdmin(authUser),
+			}
+
+			report, err := service.MergeUsers(ctx, params.UserID, params.Body.DuplicateUserIDList, requester, params.Body.DryRun)
+			if err != nil {
+				if errors.Is(err, v1UserMerge.ErrUserNotFound) {
+					msg := fmt.Sprintf("user not found, error: %v", err)
+					return users.NewMergeUsersNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				if errors.Is(err, v1UserMerge.ErrMergeNotAllowed) {
+					msg := fmt.Sprintf("user %s does not have access to Merge Users into user %s", authUser.UserName, params.UserID)
+					log.WithFields(f).Warn(msg)
+					return users.NewMergeUsersForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+				}
+				if errors.Is(err, v1UserMerge.ErrUserAlreadyMerged) || errors.Is(err, v1UserMerge.ErrNoDuplicateUsers) ||
+					errors.Is(err, v1UserMerge.ErrConflictingIdentities) {
+					return users.NewMergeUsersBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "unable to merge the users", err))
+				}
+				msg := fmt.Sprintf("problem merging the users into user %s", params.UserID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return users.NewMergeUsersInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			if !report.DryRun {
+				for _, duplicate := range report.Duplicates {
+					eventService.LogEvent(&events.LogEventArgs{
+						LfUsername: authUser.UserName,
+						EventType:  events.UserMerged,
+						UserID:     report.UserID,
+						EventData: &events.UserMergedEventData{
+							MergedIntoUserID:      report.UserID,
+							MergedIntoUserName:    report.UserName,
+							DuplicateUserID:       duplicate.UserID,
+							DuplicateUserName:     duplicate.UserName,
+							IdentitiesLinked:      len(duplicate.IdentitiesLinked),
+							SignaturesMoved:       len(duplicate.SignaturesMoved),
+							SignaturesInvalidated: len(duplicate.SignaturesInvalidated),
+						},
+					})
+				}
+			}
+
+			return users.NewMergeUsersOK().WithXRequestID(reqID).WithPayload(report.ToModel())
+		})
+}
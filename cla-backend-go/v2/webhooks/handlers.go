@@ -0,0 +1,254 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/webhooks"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	v1Webhooks "github.com/communitybridge/easycla/cla-backend-go/webhooks"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// isAuthorized returns true if the user may manage the webhook subscriptions of the foundation - API keys cannot be
+// used to manage webhook subscriptions
+func isAuthorized(authUser *auth.User, foundationSFID string) bool {
+	if _, ok := utils.GetAPIKeyID(authUser); ok {
+		return false
+	}
+	return utils.IsUserAuthorizedForProjectTree(authUser, foundationSFID)
+}
+
+// isBadRequest returns true if the error is caused by the subscription input
+func isBadRequest(err error) bool {
+	return err == v1Webhooks.ErrInvalidURL || err == v1Webhooks.ErrInvalidEventType ||
+		err == v1Webhooks.ErrCLAGroupNotInFoundation || err == v1Webhooks.ErrTooManySubscriptions
+}
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1Webhooks.Service, eventService events.Service) {
+	api.WebhooksListWebhookSubscriptionsHandler = webhooks.ListWebhookSubscriptionsHandlerFunc(
+		func(params webhooks.ListWebhookSubscriptionsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "WebhooksListWebhookSubscriptionsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"foundationSFID": params.FoundationSFID,
+			}
+
+			if !isAuthorized(authUser, params.FoundationSFID) {
+				msg := fmt.Sprintf("user %s does not have access to List Webhook Subscriptions with Project scope of %s", authUser.UserName, params.FoundationSFID)
+				log.WithFields(f).Warn(msg)
+				return webhooks.NewListWebhookSubscriptionsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			subscriptions, err := service.GetSubscriptions(ctx, params.FoundationSFID)
+			if err != nil {
+				msg := "problem loading webhook subscriptions"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return webhooks.NewListWebhookSubscriptionsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.WebhookSubscriptionList{
+				List: []*models.WebhookSubscription{},
+			}
+			for _, subscription := range subscriptions {
+				response.List = append(response.List, subscription.ToModel())
+			}
+			return webhooks.NewListWebhookSubscriptionsOK().WithXRequestID(reqID).WithPayload(response)
+		})
+
+	api.WebhooksCreateWebhookSubscriptionHandler = webhooks.CreateWebhookSubscriptionHandlerFunc(
+		func(params webhooks.CreateWebhookSubscriptionParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "WebhooksCreateWebhookSubscriptionHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"foundationSFID": params.FoundationSFID,
+			}
+
+			if !isAuthorized(authUser, params.FoundationSFID) {
+				msg := fmt.Sprintf("user %s does not have access to Create Webhook Subscription with Project scope of %s", authUser.UserName, params.FoundationSFID)
+				log.WithFields(f).Warn(msg)
+				return webhooks.NewCreateWebhookSubscriptionForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			subscription, err := service.CreateSubscription(ctx, params.FoundationSFID, params.Body, authUser.UserName)
+			if err != nil {
+				if isBadRequest(err) {
+					return webhooks.NewCreateWebhookSubscriptionBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "invalid webhook subscription input", err))
+				}
+				msg := "problem creating webhook subscription"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return webhooks.NewCreateWebhookSubscriptionInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername:        authUser.UserName,
+				EventType:         events.WebhookSubscriptionCreated,
+				ExternalProjectID: params.FoundationSFID,
+				EventData: &events.WebhookSubscriptionCreatedEventData{
+					SubscriptionID: subscription.SubscriptionID,
+					FoundationSFID: subscription.FoundationSFID,
+					URL:            subscription.URL,
+				},
+			})
+
+			return webhooks.NewCreateWebhookSubscriptionOK().WithXRequestID(reqID).WithPayload(&models.WebhookSubscriptionCreated{
+				Subscription: subscription.ToModel(),
+				Secret:       subscription.Secret,
+			})
+		})
+
+	api.WebhooksUpdateWebhookSubscriptionHandler = webhooks.UpdateWebhookSubscriptionHandlerFunc(
+		func(params webhooks.UpdateWebhookSubscriptionParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "WebhooksUpdateWebhookSubscriptionHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"foundationSFID": params.FoundationSFID,
+				"subscriptionID": params.SubscriptionID,
+			}
+
+			if !isAuthorized(authUser, params.FoundationSFID) {
+				msg := fmt.Sprintf("user %s does not have access to Update Webhook Subscription with Project scope of %s", authUser.UserName, params.FoundationSFID)
+				log.WithFields(f).Warn(msg)
+				return webhooks.NewUpdateWebhookSubscriptionForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			subscription, err := service.UpdateSubscription(ctx, params.FoundationSFID, params.SubscriptionID, params.Body, authUser.UserName)
+			if err != nil {
+				if err == v1Webhooks.ErrSubscriptionNotFound {
+					msg := fmt.Sprintf("webhook subscription %s not found", params.SubscriptionID)
+					return webhooks.NewUpdateWebhookSubscriptionNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				if isBadRequest(err) {
+					return webhooks.NewUpdateWebhookSubscriptionBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, "invalid webhook subscription input", err))
+				}
+				msg := fmt.Sprintf("problem updating webhook subscription %s", params.SubscriptionID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return webhooks.NewUpdateWebhookSubscriptionInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername:        authUser.UserName,
+				EventType:         events.WebhookSubscriptionUpdated,
+				ExternalProjectID: params.FoundationSFID,
+				EventData: &events.WebhookSubscriptionUpdatedEventData{
+					SubscriptionID: subscription.SubscriptionID,
+					FoundationSFID: subscription.FoundationSFID,
+					URL:            subscription.URL,
+					Disabled:       subscription.Disabled,
+				},
+			})
+
+			return webhooks.NewUpdateWebhookSubscriptionOK().WithXRequestID(reqID).WithPayload(subscription.ToModel())
+		})
+
+	api.WebhooksDeleteWebhookSubscriptionHandler = webhooks.DeleteWebhookSubscriptionHandlerFunc(
+		func(params webhooks.DeleteWebhookSubscriptionParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "WebhooksDeleteWebhookSubscriptionHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"foundationSFID": params.FoundationSFID,
+				"subscriptionID": params.SubscriptionID,
+			}
+
+			if !isAuthorized(authUser, params.FoundationSFID) {
+				msg := fmt.Sprintf("user %s does not have access to Delete Webhook Subscription with Project scope of %s", authUser.UserName, params.FoundationSFID)
+				log.WithFields(f).Warn(msg)
+				return webhooks.NewDeleteWebhookSubscriptionForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			subscription, err := service.DeleteSubscription(ctx, params.FoundationSFID, params.SubscriptionID)
+			if err != nil {
+				if err == v1Webhooks.ErrSubscriptionNotFound {
+					msg := fmt.Sprintf("webhook subscription %s not found", params.SubscriptionID)
+					return webhooks.NewDeleteWebhookSubscriptionNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem deleting webhook subscription %s", params.SubscriptionID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return webhooks.NewDeleteWebhookSubscriptionInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername:        authUser.UserName,
+				EventType:         events.WebhookSubscriptionDeleted,
+				ExternalProjectID: params.FoundationSFID,
+				EventData: &events.WebhookSubscriptionDeletedEventData{
+					SubscriptionID: subscription.SubscriptionID,
+					FoundationSFID: subscription.FoundationSFID,
+					URL:            subscription.URL,
+				},
+			})
+
+			return webhooks.NewDeleteWebhookSubscriptionNoContent().WithXRequestID(reqID)
+		})
+
+	api.WebhooksListWebhookDeliveriesHandler = webhooks.ListWebhookDeliveriesHandlerFunc(
+		func(params webhooks.ListWebhookDeliveriesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "WebhooksListWebhookDeliveriesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+				"foundationSFID": params.FoundationSFID,
+				"subscriptionID": params.SubscriptionID,
+			}
+
+			if !isAuthorized(authUser, params.FoundationSFID) {
+				msg := fmt.Sprintf("user %s does not have access to List Webhook Deliveries with Project scope of %s", authUser.UserName, params.FoundationSFID)
+				log.WithFields(f).Warn(msg)
+				return webhooks.NewListWebhookDeliveriesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			deliveries, err := service.GetDeliveries(ctx, params.FoundationSFID, params.SubscriptionID)
+			if err != nil {
+				if err == v1Webhooks.ErrSubscriptionNotFound {
+					msg := fmt.Sprintf("webhook subscription %s not found", params.SubscriptionID)
+					return webhooks.NewListWebhookDeliveriesNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+				}
+				msg := fmt.Sprintf("problem loading the deliveries of webhook subscription %s", params.SubscriptionID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return webhooks.NewListWebhookDeliveriesInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			response := &models.WebhookDeliveryList{
+				List: []*models.WebhookDelivery{},
+			}
+			for _, delivery := range deliveries {
+				response.List = append(response.List, delivery.ToModel())
+			}
+			return webhooks.NewListWebhookDeliveriesOK().WithXRequestID(reqID).WithPayload(response)
+		})
+}
@@ -0,0 +1,27 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+)
+
+// RetryDelays exposes retryDelays to the tests
+var RetryDelays = retryDelays
+
+// IsPublicIP exposes isPublicIP to the tests
+var IsPublicIP = isPublicIP
+
+// NewTestService creates the service with the http client and clock of the test
+func NewTestService(repo Repository, projectsClaGroupsRepo projects_cla_groups.Repository, httpClient *http.Client, now func() time.Time) Service {
+	return &service{
+		repo:                  repo,
+		projectsClaGroupsRepo: projectsClaGroupsRepo,
+		httpClient:            httpClient,
+		now:                   now,
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// delivery statuses
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+// Subscription is the database model for the webhook subscriptions table
+type Subscription struct {
+	SubscriptionID string   `dynamodbav:"subscription_id" json:"subscription_id"`
+	FoundationSFID string   `dynamodbav:"foundation_sfid" json:"foundation_sfid"`
+	ClaGroupID     string   `dynamodbav:"cla_group_id" json:"cla_group_id"`
+	URL            string   `dynamodbav:"url" json:"url"`
+	Description    string   `dynamodbav:"description" json:"description"`
+	EventTypes     []string `dynamodbav:"event_types" json:"event_types"`
+	Secret         string   `dynamodbav:"secret" json:"-"`
+	Disabled       bool     `dynamodbav:"disabled" json:"disabled"`
+	CreatedBy      string   `dynamodbav:"created_by" json:"created_by"`
+	UpdatedBy      string   `dynamodbav:"updated_by" json:"updated_by"`
+	DateCreated    string   `dynamodbav:"date_created" json:"date_created"`
+	DateModified   string   `dynamodbav:"date_modified" json:"date_modified"`
+	Version        string   `dynamodbav:"version" json:"version"`
+}
+
+// Matches returns true if the event should be delivered to the subscription
+func (s *Subscription) Matches(event *Event) bool {
+	if s.Disabled || s.FoundationSFID != event.FoundationSFID {
+		return false
+	}
+	if s.ClaGroupID != "" && s.ClaGroupID != event.ClaGroupID {
+		return false
+	}
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, eventType := range s.EventTypes {
+		if eventType == event.EventType {
+			return true
+		}
+	}
+	return false
+}
+
+// ToModel converts the database model to the API model - the secret is never returned
+func (s *Subscription) ToModel() *models.WebhookSubscription {
+	return &models.WebhookSubscription{
+		SubscriptionID: s.SubscriptionID,
+		FoundationSfid: s.FoundationSFID,
+		ClaGroupID:     s.ClaGroupID,
+		URL:            s.URL,
+		Description:    s.Description,
+		EventTypeList:  s.EventTypes,
+		Disabled:       s.Disabled,
+		CreatedBy:      s.CreatedBy,
+		UpdatedBy:      s.UpdatedBy,
+		DateCreated:    s.DateCreated,
+		DateModified:   s.DateModified,
+		Version:        s.Version,
+	}
+}
+
+// Event is the payload delivered to the subscribers
+type Event struct {
+	EventID        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	EventTime      string `json:"event_time"`
+	FoundationSFID string `json:"foundation_sfid"`
+	ProjectSFID    string `json:"project_sfid,omitempty"`
+	ClaGroupID     string `json:"cla_group_id,omitempty"`
+	CompanySFID    string `json:"company_sfid,omitempty"`
+	Summary        string `json:"summary,omitempty"`
+	Data           string `json:"data,omitempty"`
+}
+
+// Delivery is the database model for the webhook deliveries table - one record per event and subscription
+type Delivery struct {
+	DeliveryID       string `dynamodbav:"delivery_id" json:"delivery_id"`
+	SubscriptionID   string `dynamodbav:"subscription_id" json:"subscription_id"`
+	FoundationSFID   string `dynamodbav:"foundation_sfid" json:"foundation_sfid"`
+	EventID          string `dynamodbav:"event_id" json:"event_id"`
+	EventType        string `dynamodbav:"event_type" json:"event_type"`
+	Payload          string `dynamodbav:"payload" json:"payload"`
+	DeliveryStatus   string `dynamodbav:"delivery_status" json:"delivery_status"`
+	Attempts         int64  `dynamodbav:"attempts" json:"attempts"`
+	NextAttemptEpoch int64  `dynamodbav:"next_attempt_epoch" json:"next_attempt_epoch"`
+	LastAttempt      string `dynamodbav:"last_attempt" json:"last_attempt"`
+	LastResponseCode int64  `dynamodbav:"last_response_code" json:"last_response_code"`
+	LastError        string `dynamodbav:"last_error" json:"last_error"`
+	DateCreated      string `dynamodbav:"date_created" json:"date_created"`
+	DateModified     string `dynamodbav:"date_modified" json:"date_modified"`
+	Expires          int64  `dynamodbav:"expires" json:"expires"`
+}
+
+// ToModel converts the database model to the API model
+func (d *Delivery) ToModel() *models.WebhookDelivery {
+	delivery := &models.WebhookDelivery{
+		DeliveryID:       d.DeliveryID,
+		SubscriptionID:   d.SubscriptionID,
+		EventID:          d.EventID,
+		EventType:        d.EventType,
+		Payload:          d.Payload,
+		Status:           d.DeliveryStatus,
+		Attempts:         d.Attempts,
+		LastAttempt:      d.LastAttempt,
+		LastResponseCode: d.LastResponseCode,
+		LastError:        d.LastError,
+		DateCreated:      d.DateCreated,
+	}
+	if d.DeliveryStatus == DeliveryStatusPending {
+		delivery.NextAttempt = utils.TimeToString(time.Unix(d.NextAttemptEpoch, 0))
+	}
+	return delivery
+}
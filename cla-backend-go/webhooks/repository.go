@@ -0,0 +1,337 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// indexes
+const (
+	FoundationSFIDIndex = "foundation-sfid-index"
+	SubscriptionIDIndex = "subscription-id-index"
+	DeliveryStatusIndex = "delivery-status-index"
+)
+
+// errors
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryExists       = errors.New("webhook delivery already exists")
+)
+
+// Repository interface defines the functions for the webhook subscriptions and deliveries data model
+type Repository interface {
+	CreateSubscription(ctx context.Context, subscription *Subscription) error
+	GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error)
+	GetSubscriptionsByFoundation(ctx context.Context, foundationSFID string) ([]*Subscription, error)
+	UpdateSubscription(ctx context.Context, subscription *Subscription) error
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+
+	CreateDelivery(ctx context.Context, delivery *Delivery) error
+	UpdateDelivery(ctx context.Context, delivery *Delivery) error
+	ClaimDelivery(ctx context.Context, deliveryID string, nextAttemptEpoch, leaseUntilEpoch int64) (bool, error)
+	GetDeliveriesBySubscription(ctx context.Context, subscriptionID string, limit int64) ([]*Delivery, error)
+	GetDueDeliveries(ctx context.Context, nowEpoch int64) ([]*Delivery, error)
+}
+
+type repository struct {
+	stage                  string
+	dynamoDBClient         *dynamodb.DynamoDB
+	subscriptionsTableName string
+	deliveriesTableName    string
+}
+
+// NewRepository creates a new instance of the webhooks repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repository{
+		stage:                  stage,
+		dynamoDBClient:         dynamodb.New(awsSession),
+		subscriptionsTableName: fmt.Sprintf("cla-%s-webhook-subscriptions", stage),
+		deliveriesTableName:    fmt.Sprintf("cla-%s-webhook-deliveries", stage),
+	}
+}
+
+// CreateSubscription stores the new webhook subscription
+func (repo *repository) CreateSubscription(ctx context.Context, subscription *Subscription) error {
+	return repo.putItem(ctx, "CreateSubscription", repo.subscriptionsTableName, subscription, "attribute_not_exists(subscription_id)")
+}
+
+// GetSubscription returns the webhook subscription by ID
+func (repo *repository) GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error) {
+	f := logrus.Fields{
+		"functionName":   "GetSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.subscriptionsTableName,
+		"subscriptionID": subscriptionID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"subscription_id": {
+				S: aws.String(subscriptionID),
+			},
+		},
+		TableName: aws.String(repo.subscriptionsTableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load webhook subscription, error: %+v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrSubscriptionNotFound
+	}
+
+	var subscription Subscription
+	err = dynamodbattribute.UnmarshalMap(result.Item, &subscription)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling webhook subscription table data, error: %v", err)
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetSubscriptionsByFoundation returns the webhook subscriptions of the foundation
+func (repo *repository) GetSubscriptionsByFoundation(ctx context.Context, foundationSFID string) ([]*Subscription, error) {
+	f := logrus.Fields{
+		"functionName":   "GetSubscriptionsByFoundation",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.subscriptionsTableName,
+		"foundationSFID": foundationSFID,
+	}
+
+	expr, err := expression.NewBuilder().WithKeyCondition(expression.Key("foundation_sfid").Equal(expression.Value(foundationSFID))).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for webhook subscription query, error: %v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.subscriptionsTableName),
+		IndexName:                 aws.String(FoundationSFIDIndex),
+	}
+
+	var subscriptions []*Subscription
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.WithFields(f).Warnf("error retrieving webhook subscriptions, error: %v", errQuery)
+			return nil, errQuery
+		}
+
+		var subscriptionsTmp []*Subscription
+		err := dynamodbattribute.UnmarshalListOfMaps(results.Items, &subscriptionsTmp)
+		if err != nil {
+			log.WithFields(f).Warnf("error unmarshalling webhook subscriptions from database, error: %v", err)
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscriptionsTmp...)
+
+		if len(results.LastEvaluatedKey) != 0 {
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+
+	return subscriptions, nil
+}
+
+// UpdateSubscription replaces the existing webhook subscription
+func (repo *repository) UpdateSubscription(ctx context.Context, subscription *Subscription) error {
+	return repo.putItem(ctx, "UpdateSubscription", repo.subscriptionsTableName, subscription, "attribute_exists(subscription_id)")
+}
+
+// DeleteSubscription removes the webhook subscription - the delivery records expire on their own
+func (repo *repository) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	f := logrus.Fields{
+		"functionName":   "DeleteSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.subscriptionsTableName,
+		"subscriptionID": subscriptionID,
+	}
+
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"subscription_id": {
+				S: aws.String(subscriptionID),
+			},
+		},
+		TableName: aws.String(repo.subscriptionsTableName),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to delete webhook subscription, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// CreateDelivery stores the new webhook delivery - returns ErrDeliveryExists if the event was already queued for
+// the subscription
+func (repo *repository) CreateDelivery(ctx context.Context, delivery *Delivery) error {
+	err := repo.putItem(ctx, "CreateDelivery", repo.deliveriesTableName, delivery, "attribute_not_exists(delivery_id)")
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		return ErrDeliveryExists
+	}
+	return err
+}
+
+// UpdateDelivery stores the outcome of a delivery attempt
+func (repo *repository) UpdateDelivery(ctx context.Context, delivery *Delivery) error {
+	return repo.putItem(ctx, "UpdateDelivery", repo.deliveriesTableName, delivery, "attribute_exists(delivery_id)")
+}
+
+// ClaimDelivery moves the next attempt of a pending delivery to the end of the lease so concurrent retry runs do not
+// attempt it at the same time - returns false if the delivery was already claimed or is no longer pending
+func (repo *repository) ClaimDelivery(ctx context.Context, deliveryID string, nextAttemptEpoch, leaseUntilEpoch int64) (bool, error) {
+	f := logrus.Fields{
+		"functionName":   "ClaimDelivery",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.deliveriesTableName,
+		"deliveryID":     deliveryID,
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"delivery_id": {
+				S: aws.String(deliveryID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#S": aws.String("delivery_status"),
+			"#N": aws.String("next_attempt_epoch"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":s": {
+				S: aws.String(DeliveryStatusPending),
+			},
+			":n": {
+				N: aws.String(strconv.FormatInt(nextAttemptEpoch, 10)),
+			},
+			":l": {
+				N: aws.String(strconv.FormatInt(leaseUntilEpoch, 10)),
+			},
+		},
+		ConditionExpression: aws.String("#S = :s AND #N = :n"),
+		UpdateExpression:    aws.String("SET #N = :l"),
+		TableName:           aws.String(repo.deliveriesTableName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return false, nil
+		}
+		log.WithFields(f).Warnf("unable to claim webhook delivery, error: %+v", err)
+		return false, err
+	}
+	return true, nil
+}
+
+// GetDeliveriesBySubscription returns the most recent deliveries of the subscription
+func (repo *repository) GetDeliveriesBySubscription(ctx context.Context, subscriptionID string, limit int64) ([]*Delivery, error) {
+	keyCondition := expression.Key("subscription_id").Equal(expression.Value(subscriptionID))
+	return repo.queryDeliveries(ctx, keyCondition, SubscriptionIDIndex, limit)
+}
+
+// GetDueDeliveries returns the pending deliveries which are due for another attempt
+func (repo *repository) GetDueDeliveries(ctx context.Context, nowEpoch int64) ([]*Delivery, error) {
+	keyCondition := expression.Key("delivery_status").Equal(expression.Value(DeliveryStatusPending)).
+		And(expression.Key("next_attempt_epoch").LessThanEqual(expression.Value(nowEpoch)))
+	return repo.queryDeliveries(ctx, keyCondition, DeliveryStatusIndex, 0)
+}
+
+// queryDeliveries returns the deliveries matching the key condition, newest first - a limit of 0 returns all the
+// matching deliveries
+func (repo *repository) queryDeliveries(ctx context.Context, keyCondition expression.KeyConditionBuilder, indexName string, limit int64) ([]*Delivery, error) {
+	f := logrus.Fields{
+		"functionName":   "queryDeliveries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      repo.deliveriesTableName,
+		"indexName":      indexName,
+	}
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for webhook delivery query, error: %v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.deliveriesTableName),
+		IndexName:                 aws.String(indexName),
+		ScanIndexForward:          aws.Bool(false),
+	}
+	if limit > 0 {
+		queryInput.Limit = aws.Int64(limit)
+	}
+
+	var deliveries []*Delivery
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.WithFields(f).Warnf("error retrieving webhook deliveries, error: %v", errQuery)
+			return nil, errQuery
+		}
+
+		var deliveriesTmp []*Delivery
+		err := dynamodbattribute.UnmarshalListOfMaps(results.Items, &deliveriesTmp)
+		if err != nil {
+			log.WithFields(f).Warnf("error unmarshalling webhook deliveries from database, error: %v", err)
+			return nil, err
+		}
+		deliveries = append(deliveries, deliveriesTmp...)
+
+		if len(results.LastEvaluatedKey) != 0 && (limit == 0 || int64(len(deliveries)) < limit) {
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			break
+		}
+	}
+
+	return deliveries, nil
+}
+
+func (repo *repository) putItem(ctx context.Context, functionName, tableName string, item interface{}, condition string) error {
+	f := logrus.Fields{
+		"functionName":   functionName,
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"tableName":      tableName,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(item)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal webhook record, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(tableName),
+		ConditionExpression: aws.String(condition),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to store webhook record, error: %+v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,465 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// delivery request headers - the signature is the hex encoded HMAC-SHA256 of "<timestamp>.<body>" using the
+// subscription secret, prefixed with "sha256="
+const (
+	EventTypeHeader  = "X-EasyCLA-Event"
+	DeliveryIDHeader = "X-EasyCLA-Delivery"
+	TimestampHeader  = "X-EasyCLA-Timestamp"
+	SignatureHeader  = "X-EasyCLA-Signature"
+)
+
+const (
+	// MaxSubscriptionsPerFoundation limits the number of webhook subscriptions of a foundation
+	MaxSubscriptionsPerFoundation = 25
+	// secretPrefix makes EasyCLA webhook secrets easy to recognize, e.g. by secret scanners
+	secretPrefix = "whsec_"
+	// deliveryLease is how long a delivery attempt may take before another retry run may pick the delivery up again
+	deliveryLease = 5 * time.Minute
+	// deliveryRetention is how long delivery records are kept for inspection
+	deliveryRetention = 30 * 24 * time.Hour
+	// deliveryLogLimit is the number of the most recent deliveries returned for a subscription
+	deliveryLogLimit = 100
+	// maxErrorLength limits the size of the error stored with a failed attempt
+	maxErrorLength = 500
+)
+
+// retryDelays is the backoff between failed delivery attempts - a delivery is marked as failed after the last one
+var retryDelays = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	6 * time.Hour,
+	12 * time.Hour,
+}
+
+// deliveryIDNamespace is used to derive the delivery ID from the event and subscription IDs, so an event which is
+// processed more than once is still only queued once per subscription
+var deliveryIDNamespace = uuid.Must(uuid.FromString("0b7f5e52-8a0c-4c43-9b3e-8f1d7b6f2a61"))
+
+// errors
+var (
+	ErrInvalidURL              = errors.New("webhook url must be an absolute https url")
+	ErrInvalidEventType        = errors.New("webhook event types must not be empty")
+	ErrCLAGroupNotInFoundation = errors.New("cla group is not associated with the foundation")
+	ErrTooManySubscriptions    = fmt.Errorf("a foundation may have at most %d webhook subscriptions", MaxSubscriptionsPerFoundation)
+)
+
+// Service interface defines the webhook service methods
+type Service interface {
+	CreateSubscription(ctx context.Context, foundationSFID string, input *models.WebhookSubscriptionInput, createdBy string) (*Subscription, error)
+	GetSubscriptions(ctx context.Context, foundationSFID string) ([]*Subscription, error)
+	UpdateSubscription(ctx context.Context, foundationSFID, subscriptionID string, input *models.WebhookSubscriptionInput, updatedBy string) (*Subscription, error)
+	DeleteSubscription(ctx context.Context, foundationSFID, subscriptionID string) (*Subscription, error)
+	GetDeliveries(ctx context.Context, foundationSFID, subscriptionID string) ([]*Delivery, error)
+
+	Dispatch(ctx context.Context, event *Event) error
+	SendDeliveries(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo                  Repository
+	projectsClaGroupsRepo projects_cla_groups.Repository
+	httpClient            *http.Client
+	now                   func() time.Time
+}
+
+// NewService creates a new webhook service
+func NewService(repo Repository, projectsClaGroupsRepo projects_cla_groups.Repository) Service {
+	return &service{
+		repo:                  repo,
+		projectsClaGroupsRepo: projectsClaGroupsRepo,
+		httpClient:            newHTTPClient(),
+		now:                   time.Now,
+	}
+}
+
+// CreateSubscription creates a webhook subscription for the foundation - the secret used to sign the deliveries is
+// only available on the returned subscription
+func (s *service) CreateSubscription(ctx context.Context, foundationSFID string, input *models.WebhookSubscriptionInput, createdBy string) (*Subscription, error) {
+	f := logrus.Fields{
+		"functionName":   "CreateSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"foundationSFID": foundationSFID,
+		"createdBy":      createdBy,
+	}
+
+	if err := s.validateInput(foundationSFID, input); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetSubscriptionsByFoundation(ctx, foundationSFID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxSubscriptionsPerFoundation {
+		return nil, ErrTooManySubscriptions
+	}
+
+	subscriptionID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).Warnf("unable to generate a UUID for the webhook subscription, error: %v", err)
+		return nil, err
+	}
+	secret, err := generateSecret()
+	if err != nil {
+		log.WithFields(f).Warnf("unable to generate the webhook secret, error: %v", err)
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	subscription := &Subscription{
+		SubscriptionID: subscriptionID.String(),
+		FoundationSFID: foundationSFID,
+		Secret:         secret,
+		CreatedBy:      createdBy,
+		UpdatedBy:      createdBy,
+		DateCreated:    currentTime,
+		DateModified:   currentTime,
+		Version:        "v1",
+	}
+	applyInput(subscription, input)
+
+	err = s.repo.CreateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// GetSubscriptions returns the webhook subscriptions of the foundation
+func (s *service) GetSubscriptions(ctx context.Context, foundationSFID string) ([]*Subscription, error) {
+	return s.repo.GetSubscriptionsByFoundation(ctx, foundationSFID)
+}
+
+// UpdateSubscription replaces the url, scope and event filter of the webhook subscription - the secret is unchanged
+func (s *service) UpdateSubscription(ctx context.Context, foundationSFID, subscriptionID string, input *models.WebhookSubscriptionInput, updatedBy string) (*Subscription, error) {
+	subscription, err := s.getSubscription(ctx, foundationSFID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.validateInput(foundationSFID, input); err != nil {
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	applyInput(subscription, input)
+	subscription.UpdatedBy = updatedBy
+	subscription.DateModified = currentTime
+
+	err = s.repo.UpdateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// DeleteSubscription deletes the webhook subscription - pending deliveries are marked as failed on their next attempt
+func (s *service) DeleteSubscription(ctx context.Context, foundationSFID, subscriptionID string) (*Subscription, error) {
+	subscription, err := s.getSubscription(ctx, foundationSFID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.repo.DeleteSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// GetDeliveries returns the most recent deliveries of the webhook subscription
+func (s *service) GetDeliveries(ctx context.Context, foundationSFID, subscriptionID string) ([]*Delivery, error) {
+	if _, err := s.getSubscription(ctx, foundationSFID, subscriptionID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDeliveriesBySubscription(ctx, subscriptionID, deliveryLogLimit)
+}
+
+// getSubscription returns the subscription if it belongs to the foundation
+func (s *service) getSubscription(ctx context.Context, foundationSFID, subscriptionID string) (*Subscription, error) {
+	subscription, err := s.repo.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.FoundationSFID != foundationSFID {
+		return nil, ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+func (s *service) validateInput(foundationSFID string, input *models.WebhookSubscriptionInput) error {
+	webhookURL, err := url.Parse(strings.TrimSpace(aws.StringValue(input.URL)))
+	if err != nil || webhookURL.Scheme != "https" || webhookURL.Hostname() == "" || webhookURL.User != nil {
+		return ErrInvalidURL
+	}
+
+	for _, eventType := range input.EventTypeList {
+		if strings.TrimSpace(eventType) == "" {
+			return ErrInvalidEventType
+		}
+	}
+
+	if input.ClaGroupID != "" {
+		pcgs, err := s.projectsClaGroupsRepo.GetProjectsIdsForFoundation(foundationSFID)
+		if err != nil {
+			return err
+		}
+		for _, pcg := range pcgs {
+			if pcg.ClaGroupID == input.ClaGroupID {
+				return nil
+			}
+		}
+		return ErrCLAGroupNotInFoundation
+	}
+	return nil
+}
+
+func applyInput(subscription *Subscription, input *models.WebhookSubscriptionInput) {
+	subscription.URL = strings.TrimSpace(aws.StringValue(input.URL))
+	subscription.Description = input.Description
+	subscription.ClaGroupID = input.ClaGroupID
+	subscription.Disabled = input.Disabled
+	subscription.EventTypes = nil
+	for _, eventType := range input.EventTypeList {
+		subscription.EventTypes = append(subscription.EventTypes, strings.TrimSpace(eventType))
+	}
+}
+
+// Dispatch queues the event for each matching subscription of the event's foundation - the deliveries are sent by
+// SendDeliveries. An error is returned when a delivery can not be queued so the event is processed again.
+func (s *service) Dispatch(ctx context.Context, event *Event) error {
+	f := logrus.Fields{
+		"functionName":   "Dispatch",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"eventID":        event.EventID,
+		"eventType":      event.EventType,
+		"foundationSFID": event.FoundationSFID,
+		"claGroupID":     event.ClaGroupID,
+	}
+
+	if event.FoundationSFID == "" {
+		return nil
+	}
+
+	subscriptions, err := s.repo.GetSubscriptionsByFoundation(ctx, event.FoundationSFID)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(event) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				return err
+			}
+		}
+
+		now := s.now()
+		delivery := &Delivery{
+			DeliveryID:       uuid.NewV5(deliveryIDNamespace, event.EventID+"#"+subscription.SubscriptionID).String(),
+			SubscriptionID:   subscription.SubscriptionID,
+			FoundationSFID:   subscription.FoundationSFID,
+			EventID:          event.EventID,
+			EventType:        event.EventType,
+			Payload:          string(payload),
+			DeliveryStatus:   DeliveryStatusPending,
+			NextAttemptEpoch: now.Unix(),
+			DateCreated:      utils.TimeToString(now),
+			DateModified:     utils.TimeToString(now),
+			Expires:          now.Add(deliveryRetention).Unix(),
+		}
+		err = s.repo.CreateDelivery(ctx, delivery)
+		if err == ErrDeliveryExists {
+			log.WithFields(f).Debugf("event already queued for webhook subscription %s", subscription.SubscriptionID)
+			continue
+		}
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to queue event for webhook subscription %s", subscription.SubscriptionID)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SendDeliveries attempts the pending deliveries which are due, the new ones and the failed ones waiting for a retry,
+// and returns the number of attempts made
+func (s *service) SendDeliveries(ctx context.Context) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "SendDeliveries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	deliveries, err := s.repo.GetDueDeliveries(ctx, s.now().Unix())
+	if err != nil {
+		return 0, err
+	}
+
+	attempts := 0
+	subscriptions := make(map[string]*Subscription)
+	for _, delivery := range deliveries {
+		leaseUntil := s.now().Add(deliveryLease).Unix()
+		claimed, claimErr := s.repo.ClaimDelivery(ctx, delivery.DeliveryID, delivery.NextAttemptEpoch, leaseUntil)
+		if claimErr != nil {
+			return attempts, claimErr
+		}
+		if !claimed {
+			continue
+		}
+		delivery.NextAttemptEpoch = leaseUntil
+
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = s.repo.GetSubscription(ctx, delivery.SubscriptionID)
+			if err != nil && err != ErrSubscriptionNotFound {
+				return attempts, err
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+		if subscription == nil || subscription.Disabled {
+			delivery.DeliveryStatus = DeliveryStatusFailed
+			delivery.LastError = "webhook subscription was deleted or disabled"
+			delivery.DateModified = utils.TimeToString(s.now())
+			if err = s.repo.UpdateDelivery(ctx, delivery); err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to update webhook delivery %s", delivery.DeliveryID)
+			}
+			continue
+		}
+
+		s.attempt(ctx, subscription, delivery)
+		attempts++
+	}
+
+	return attempts, nil
+}
+
+// attempt sends the delivery and stores the outcome - the delivery is retried with backoff until it succeeds or
+// runs out of attempts
+func (s *service) attempt(ctx context.Context, subscription *Subscription, delivery *Delivery) {
+	f := logrus.Fields{
+		"functionName":   "attempt",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscription.SubscriptionID,
+		"deliveryID":     delivery.DeliveryID,
+		"eventType":      delivery.EventType,
+	}
+
+	statusCode, err := s.send(ctx, subscription, delivery)
+
+	now := s.now()
+	delivery.Attempts++
+	delivery.LastAttempt = utils.TimeToString(now)
+	delivery.LastResponseCode = int64(statusCode)
+	delivery.DateModified = delivery.LastAttempt
+	delivery.LastError = ""
+	if err == nil && (statusCode < 200 || statusCode > 299) {
+		err = fmt.Errorf("webhook responded with status code %d", statusCode)
+	}
+
+	switch {
+	case err == nil:
+		delivery.DeliveryStatus = DeliveryStatusSucceeded
+	case int(delivery.Attempts) > len(retryDelays):
+		delivery.DeliveryStatus = DeliveryStatusFailed
+		delivery.LastError = truncate(err.Error(), maxErrorLength)
+	default:
+		delivery.DeliveryStatus = DeliveryStatusPending
+		delivery.LastError = truncate(err.Error(), maxErrorLength)
+		delivery.NextAttemptEpoch = now.Add(retryDelays[delivery.Attempts-1]).Unix()
+	}
+	if err != nil {
+		log.WithFields(f).Debugf("webhook delivery attempt %d failed, error: %v", delivery.Attempts, err)
+	}
+
+	if updateErr := s.repo.UpdateDelivery(ctx, delivery); updateErr != nil {
+		log.WithFields(f).WithError(updateErr).Warn("unable to store the webhook delivery attempt")
+	}
+}
+
+// send posts the signed payload to the subscription url and returns the response status code
+func (s *service) send(ctx context.Context, subscription *Subscription, delivery *Delivery) (int, error) {
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "EasyCLA-Webhooks")
+	req.Header.Set(EventTypeHeader, delivery.EventType)
+	req.Header.Set(DeliveryIDHeader, delivery.DeliveryID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, []byte(delivery.Payload)))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Debugf("unable to close the webhook response body, error: %v", closeErr)
+		}
+	}()
+	// drain a little of the body so the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096)) // nolint
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value for the payload - subscribers compute the same value to verify deliveries
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + ".")) // nolint
+	mac.Write(payload)                 // nolint
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func truncate(value string, maxLength int) string {
+	if len(value) <= maxLength {
+		return value
+	}
+	return value[:maxLength]
+}
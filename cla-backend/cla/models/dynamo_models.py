@@ -1833,21 +1833,9 @@ class User(model_interfaces.User):  # pylint: disable=too-many-public-methods
 
         # Check github org whitelist
         if github_username is not None:
-            github_orgs = cla.utils.lookup_github_organizations(github_username)
-            if "error" not in github_orgs:
-                # Fetch the list of orgs this user is part of
-                github_org_whitelist = ccla_signature.get_github_org_whitelist()
-                cla.log.debug(
-                    f"is_whitelisted - testing user github orgs: {github_orgs} with "
-                    f"CCLA github org whitelist values: {github_org_whitelist}"
-                )
-
-                if github_org_whitelist is not None:
-                    for dynamo_github_org in github_org_whitelist:
-                        # case insensitive search
-                        if dynamo_github_org.lower() in (s.lower() for s in github_orgs):
-                            self.log_debug("found matching github org for user")
-                            return True
+            if cla.utils.is_github_org_whitelisted(github_username, ccla_signature.get_github_org_whitelist()):
+                self.log_debug("found matching github org for user")
+                return True
         else:
             cla.log.debug(
                 "is_whitelisted - users github_username is not defined " "- skipping github org whitelist check"
@@ -3255,6 +3243,42 @@ class Store(key_value_store_interface.KeyValueStore):
         return exp_datetime.timestamp()
 
 
+class GitHubOrgMemberCacheModel(Model):
+    """
+    Represents the refresh of the cached members of a GitHub organization. The table is maintained by the GitHub
+    organization members job of the golang backend, the organization names are stored in lower case.
+    """
+
+    class Meta:
+        """Meta class for GitHubOrgMemberCache."""
+
+        table_name = "cla-{}-github-org-member-caches".format(stage)
+        if stage == "local":
+            host = "http://localhost:8000"
+
+    organization_name = UnicodeAttribute(hash_key=True)
+    installation_id = NumberAttribute(null=True)
+    member_count = NumberAttribute(null=True)
+    date_refreshed = UnicodeAttribute(null=True)
+
+
+class GitHubOrgMemberModel(Model):
+    """
+    Represents a cached member of a GitHub organization, the organization and user names are stored in lower case.
+    """
+
+    class Meta:
+        """Meta class for GitHubOrgMember."""
+
+        table_name = "cla-{}-github-org-members".format(stage)
+        if stage == "local":
+            host = "http://localhost:8000"
+
+    organization_name = UnicodeAttribute(hash_key=True)
+    github_username = UnicodeAttribute(range_key=True)
+    github_login = UnicodeAttribute(null=True)
+
+
 class GitHubOrgModel(BaseModel):
     """
     Represents a Github Organization in the database.
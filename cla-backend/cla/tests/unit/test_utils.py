@@ -158,7 +158,25 @@ class TestUtils(unittest.TestCase):
         self.mock_get.return_value.json.return_value = github_orgs
         signature = Signature()
         signature.get_github_org_whitelist = Mock(return_value=['foo-org'])
-        self.assertTrue(utils.is_whitelisted(signature, github_username='foo'))
+        with patch('cla.utils.lookup_cached_github_org_membership', return_value=None):
+            self.assertTrue(utils.is_whitelisted(signature, github_username='foo'))
+
+    def test_is_whitelisted_for_cached_github_org(self) -> None:
+        """
+        Test given github user passes github org check with the cached private members of the org
+        """
+        signature = Signature()
+        signature.get_github_org_whitelist = Mock(return_value=['foo-org', 'bar-org'])
+        with patch('cla.utils.lookup_cached_github_org_membership', side_effect=[False, True]), \
+                patch('cla.utils.lookup_github_organizations') as mock_lookup:
+            self.assertTrue(utils.is_whitelisted(signature, github_username='foo'))
+            # the public organizations of the user are not loaded when all approved orgs are cached
+            mock_lookup.assert_not_called()
+
+        with patch('cla.utils.lookup_cached_github_org_membership', return_value=False), \
+                patch('cla.utils.lookup_github_organizations', return_value=['foo-org']):
+            # a cached organization is not matched with the public organizations of the user
+            self.assertFalse(utils.is_whitelisted(signature, github_username='foo'))
 
 
 def test_append_email_help_sign_off_content():
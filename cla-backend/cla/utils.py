@@ -9,9 +9,10 @@ import inspect
 import json
 import os
 import urllib.parse
-from datetime import datetime
+from datetime import datetime, timedelta, timezone
 from typing import List, Optional
 
+import dateutil.parser
 import falcon
 import requests
 from hug.middleware import SessionMiddleware
@@ -21,7 +22,8 @@ import cla
 from cla.models import DoesNotExist
 from cla.models.dynamo_models import User, Signature, Repository, \
     Company, Project, Document, \
-    GitHubOrg, Gerrit, UserPermissions, Event, CompanyInvite, ProjectCLAGroup, CCLAWhitelistRequest
+    GitHubOrg, Gerrit, UserPermissions, Event, CompanyInvite, ProjectCLAGroup, CCLAWhitelistRequest, \
+    GitHubOrgMemberCacheModel, GitHubOrgMemberModel
 from cla.models.event_types import EventType
 
 API_BASE_URL = os.environ.get('CLA_API_BASE', '')
@@ -1301,6 +1303,89 @@ def lookup_github_organizations(github_username: str):
     return [github_org['login'] for github_org in r.json()]
 
 
+# the cached members of a GitHub organization are no longer trusted after this time, the same limit is used by the
+# GitHub organization members job of the golang backend which refreshes them
+GITHUB_ORG_MEMBERS_MAX_CACHE_AGE = timedelta(hours=24)
+
+
+def lookup_cached_github_org_membership(organization_name: str, github_username: str) -> Optional[bool]:
+    """
+    Checks the members of the GitHub organization cached with the GitHub app, which includes the private members.
+
+    :param organization_name: the GitHub organization name
+    :param github_username: the GitHub username of the user
+    :return: True or False when the organization was refreshed within the maximum cache age, None when the
+             organization is not cached so GitHub has to be asked
+    """
+    organization_key = organization_name.strip().lower()
+    try:
+        cache = GitHubOrgMemberCacheModel.get(organization_key)
+    except GitHubOrgMemberCacheModel.DoesNotExist:
+        return None
+    except Exception as err:
+        cla.log.warning(f'unable to load the member cache of the github organization: {organization_name}, '
+                        f'error: {err}')
+        return None
+
+    if not cache.date_refreshed:
+        return None
+    try:
+        date_refreshed = dateutil.parser.parse(cache.date_refreshed)
+    except (ValueError, OverflowError):
+        return None
+    if date_refreshed.tzinfo is None:
+        date_refreshed = date_refreshed.replace(tzinfo=timezone.utc)
+    if datetime.now(timezone.utc) - date_refreshed > GITHUB_ORG_MEMBERS_MAX_CACHE_AGE:
+        cla.log.debug(f'the member cache of the github organization: {organization_name} is stale')
+        return None
+
+    try:
+        GitHubOrgMemberModel.get(organization_key, github_username.strip().lower())
+        return True
+    except GitHubOrgMemberModel.DoesNotExist:
+        return False
+    except Exception as err:
+        cla.log.warning(f'unable to load the cached member: {github_username} of the github organization: '
+                        f'{organization_name}, error: {err}')
+        return None
+
+
+def is_github_org_whitelisted(github_username: str, github_org_whitelist) -> bool:
+    """
+    Checks whether the GitHub user is a member of one of the approved GitHub organizations. The organizations with
+    the GitHub app installed are checked against their cached members, the others against the public organizations
+    of the user.
+
+    :param github_username: the GitHub username of the user
+    :param github_org_whitelist: the approved GitHub organization names of the CCLA signature
+    :return: True if the user is a member of an approved organization, False otherwise
+    """
+    if not github_org_whitelist:
+        return False
+
+    github_orgs = None
+    for github_org in github_org_whitelist:
+        is_member = lookup_cached_github_org_membership(github_org, github_username)
+        if is_member is not None:
+            cla.log.debug(f'is_whitelisted - cached membership of github user: {github_username} '
+                          f'in github org: {github_org}: {is_member}')
+            if is_member:
+                return True
+            continue
+
+        # the public organizations are only loaded once, when an approved organization is not cached
+        if github_orgs is None:
+            github_orgs = lookup_github_organizations(github_username)
+            if isinstance(github_orgs, dict) and 'error' in github_orgs:
+                github_orgs = []
+            cla.log.debug(f'is_whitelisted - testing user github orgs: {github_orgs} with '
+                          f'CCLA github org whitelist values: {github_org_whitelist}')
+        # case insensitive search
+        if github_org.lower() in (s.lower() for s in github_orgs):
+            return True
+    return False
+
+
 def update_github_username(github_user: dict, user: User):
     """
     When provided a GitHub user model from the GitHub service, updates the CLA
@@ -1386,21 +1471,9 @@ def is_whitelisted(ccla_signature: Signature, email=None, github_username=None,
 
     # Check github org whitelist
     if github_username is not None:
-        github_orgs = cla.utils.lookup_github_organizations(github_username)
-        if "error" not in github_orgs:
-            # Fetch the list of orgs this user is part of
-            github_org_whitelist = ccla_signature.get_github_org_whitelist()
-            cla.log.debug(
-                f"is_whitelisted - testing user github orgs: {github_orgs} with "
-                f"CCLA github org whitelist values: {github_org_whitelist}"
-            )
-
-            if github_org_whitelist is not None:
-                for dynamo_github_org in github_org_whitelist:
-                    # case insensitive search
-                    if dynamo_github_org.lower() in (s.lower() for s in github_orgs):
-                        cla.log.debug("found matching github org for user")
-                        return True
+        if is_github_org_whitelisted(github_username, ccla_signature.get_github_org_whitelist()):
+            cla.log.debug("found matching github org for user")
+            return True
     else:
         cla.log.debug(
             "is_whitelisted - users github_username is not defined " "- skipping github org whitelist check"
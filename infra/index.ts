@@ -63,6 +63,24 @@ const eventsTable = buildEventsTable(importResources);
 const cclaWhitelistRequestsTable = buildCclaWhitelistRequestsTable(importResources);
 const metricsTable = buildMetricsTable(importResources);
 const projectsClaGroupsTable = buildProjectsClaGroupsTable(importResources);
+const featureFlagsTable = buildFeatureFlagsTable(importResources);
+const apiKeysTable = buildAPIKeysTable(importResources);
+const webhookSubscriptionsTable = buildWebhookSubscriptionsTable(importResources);
+const webhookDeliveriesTable = buildWebhookDeliveriesTable(importResources);
+const githubDriftReportsTable = buildGithubDriftReportsTable(importResources);
+const branchProtectionPoliciesTable = buildBranchProtectionPoliciesTable(importResources);
+const githubJobsTable = buildGithubJobsTable(importResources);
+const companyDomainVerificationsTable = buildCompanyDomainVerificationsTable(importResources);
+const dataSubjectRequestsTable = buildDataSubjectRequestsTable(importResources);
+const approvalListEntriesTable = buildApprovalListEntriesTable(importResources);
+const scimTokensTable = buildScimTokensTable(importResources);
+const scimResourcesTable = buildScimResourcesTable(importResources);
+const githubOrgMembersTable = buildGithubOrgMembersTable(importResources);
+const githubOrgMemberCachesTable = buildGithubOrgMemberCachesTable(importResources);
+const claManagerApprovalPoliciesTable = buildClaManagerApprovalPoliciesTable(importResources);
+const orphanedCclasTable = buildOrphanedCclasTable(importResources);
+const userAffiliationsTable = buildUserAffiliationsTable(importResources);
+const claStatusRateLimitsTable = buildClaStatusRateLimitsTable(importResources);
 
 /**
  * Build the Logo S3 Bucket.
@@ -1004,6 +1022,649 @@ function buildProjectsClaGroupsTable(importResources: boolean): aws.dynamodb.Tab
   );
 }
 
+/**
+ * Feature Flags Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildFeatureFlagsTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-feature-flags',
+    {
+      name: 'cla-' + stage + '-feature-flags',
+      attributes: [
+        { name: 'flag_name', type: 'S' },
+      ],
+      hashKey: 'flag_name',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-feature-flags' } : {},
+  );
+}
+
+/**
+ * API Keys Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildAPIKeysTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-api-keys',
+    {
+      name: 'cla-' + stage + '-api-keys',
+      attributes: [
+        { name: 'api_key_id', type: 'S' },
+        { name: 'key_hash', type: 'S' },
+        { name: 'foundation_sfid', type: 'S' },
+      ],
+      hashKey: 'api_key_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      globalSecondaryIndexes: [
+        {
+          name: 'key-hash-index',
+          hashKey: 'key_hash',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+        {
+          name: 'foundation-sfid-index',
+          hashKey: 'foundation_sfid',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+      ],
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-api-keys' } : {},
+  );
+}
+
+/**
+ * Webhook Subscriptions Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildWebhookSubscriptionsTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-webhook-subscriptions',
+    {
+      name: 'cla-' + stage + '-webhook-subscriptions',
+      attributes: [
+        { name: 'subscription_id', type: 'S' },
+        { name: 'foundation_sfid', type: 'S' },
+      ],
+      hashKey: 'subscription_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      globalSecondaryIndexes: [
+        {
+          name: 'foundation-sfid-index',
+          hashKey: 'foundation_sfid',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+      ],
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-webhook-subscriptions' } : {},
+  );
+}
+
+/**
+ * Webhook Deliveries Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildWebhookDeliveriesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-webhook-deliveries',
+    {
+      name: 'cla-' + stage + '-webhook-deliveries',
+      attributes: [
+        { name: 'delivery_id', type: 'S' },
+        { name: 'subscription_id', type: 'S' },
+        { name: 'date_created', type: 'S' },
+        { name: 'delivery_status', type: 'S' },
+        { name: 'next_attempt_epoch', type: 'N' },
+      ],
+      hashKey: 'delivery_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      globalSecondaryIndexes: [
+        {
+          name: 'subscription-id-index',
+          hashKey: 'subscription_id',
+          rangeKey: 'date_created',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+        {
+          name: 'delivery-status-index',
+          hashKey: 'delivery_status',
+          rangeKey: 'next_attempt_epoch',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+      ],
+      ttl: {
+        attributeName: 'expires',
+        enabled: true,
+      },
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-webhook-deliveries' } : {},
+  );
+}
+
+/**
+ * GitHub Drift Reports Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildGithubDriftReportsTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-github-drift-reports',
+    {
+      name: 'cla-' + stage + '-github-drift-reports',
+      attributes: [
+        { name: 'organization_name', type: 'S' },
+      ],
+      hashKey: 'organization_name',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-github-drift-reports' } : {},
+  );
+}
+
+/**
+ * Branch Protection Policies Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildBranchProtectionPoliciesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-branch-protection-policies',
+    {
+      name: 'cla-' + stage + '-branch-protection-policies',
+      attributes: [
+        { name: 'policy_id', type: 'S' },
+      ],
+      hashKey: 'policy_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-branch-protection-policies' } : {},
+  );
+}
+
+/**
+ * GitHub Jobs Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildGithubJobsTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-github-jobs',
+    {
+      name: 'cla-' + stage + '-github-jobs',
+      attributes: [
+        { name: 'job_id', type: 'S' },
+        { name: 'job_status', type: 'S' },
+        { name: 'next_run_epoch', type: 'N' },
+        { name: 'organization_name', type: 'S' },
+        { name: 'date_created', type: 'S' },
+        { name: 'installation_id', type: 'N' },
+      ],
+      hashKey: 'job_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      globalSecondaryIndexes: [
+        {
+          name: 'job-status-index',
+          hashKey: 'job_status',
+          rangeKey: 'next_run_epoch',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+        {
+          name: 'organization-name-index',
+          hashKey: 'organization_name',
+          rangeKey: 'date_created',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+        {
+          name: 'installation-id-index',
+          hashKey: 'installation_id',
+          rangeKey: 'date_created',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+      ],
+      ttl: {
+        attributeName: 'expires',
+        enabled: true,
+      },
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-github-jobs' } : {},
+  );
+}
+
+/**
+ * Company Domain Verifications Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildCompanyDomainVerificationsTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-company-domain-verifications',
+    {
+      name: 'cla-' + stage + '-company-domain-verifications',
+      attributes: [
+        { name: 'company_id', type: 'S' },
+        { name: 'domain', type: 'S' },
+      ],
+      hashKey: 'company_id',
+      rangeKey: 'domain',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      ttl: {
+        attributeName: 'expires',
+        enabled: true,
+      },
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-company-domain-verifications' } : {},
+  );
+}
+
+/**
+ * Data Subject Requests Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildDataSubjectRequestsTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-data-subject-requests',
+    {
+      name: 'cla-' + stage + '-data-subject-requests',
+      attributes: [
+        { name: 'request_id', type: 'S' },
+      ],
+      hashKey: 'request_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-data-subject-requests' } : {},
+  );
+}
+
+/**
+ * Approval List Entries Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildApprovalListEntriesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-approval-list-entries',
+    {
+      name: 'cla-' + stage + '-approval-list-entries',
+      attributes: [
+        { name: 'signature_id', type: 'S' },
+        { name: 'entry_id', type: 'S' },
+        { name: 'entry_status', type: 'S' },
+      ],
+      hashKey: 'signature_id',
+      rangeKey: 'entry_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      globalSecondaryIndexes: [
+        {
+          name: 'entry-status-index',
+          hashKey: 'entry_status',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+      ],
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-approval-list-entries' } : {},
+  );
+}
+
+/**
+ * SCIM Tokens Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildScimTokensTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-scim-tokens',
+    {
+      name: 'cla-' + stage + '-scim-tokens',
+      attributes: [
+        { name: 'token_id', type: 'S' },
+        { name: 'token_hash', type: 'S' },
+        { name: 'signature_id', type: 'S' },
+      ],
+      hashKey: 'token_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      globalSecondaryIndexes: [
+        {
+          name: 'token-hash-index',
+          hashKey: 'token_hash',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+        {
+          name: 'signature-id-index',
+          hashKey: 'signature_id',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+      ],
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-scim-tokens' } : {},
+  );
+}
+
+/**
+ * SCIM Resources Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildScimResourcesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-scim-resources',
+    {
+      name: 'cla-' + stage + '-scim-resources',
+      attributes: [
+        { name: 'signature_id', type: 'S' },
+        { name: 'resource_id', type: 'S' },
+      ],
+      hashKey: 'signature_id',
+      rangeKey: 'resource_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-scim-resources' } : {},
+  );
+}
+
+/**
+ * GitHub Org Members Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildGithubOrgMembersTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-github-org-members',
+    {
+      name: 'cla-' + stage + '-github-org-members',
+      attributes: [
+        { name: 'organization_name', type: 'S' },
+        { name: 'github_username', type: 'S' },
+      ],
+      hashKey: 'organization_name',
+      rangeKey: 'github_username',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-github-org-members' } : {},
+  );
+}
+
+/**
+ * GitHub Org Member Caches Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildGithubOrgMemberCachesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-github-org-member-caches',
+    {
+      name: 'cla-' + stage + '-github-org-member-caches',
+      attributes: [
+        { name: 'organization_name', type: 'S' },
+      ],
+      hashKey: 'organization_name',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-github-org-member-caches' } : {},
+  );
+}
+
+/**
+ * CLA Manager Approval Policies Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildClaManagerApprovalPoliciesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-cla-manager-approval-policies',
+    {
+      name: 'cla-' + stage + '-cla-manager-approval-policies',
+      attributes: [
+        { name: 'company_id', type: 'S' },
+      ],
+      hashKey: 'company_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-cla-manager-approval-policies' } : {},
+  );
+}
+
+/**
+ * Orphaned CCLAs Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildOrphanedCclasTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-orphaned-cclas',
+    {
+      name: 'cla-' + stage + '-orphaned-cclas',
+      attributes: [
+        { name: 'signature_id', type: 'S' },
+        { name: 'orphan_status', type: 'S' },
+        { name: 'company_sfid', type: 'S' },
+      ],
+      hashKey: 'signature_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      globalSecondaryIndexes: [
+        {
+          name: 'orphan-status-index',
+          hashKey: 'orphan_status',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+        {
+          name: 'company-sfid-index',
+          hashKey: 'company_sfid',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+      ],
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-orphaned-cclas' } : {},
+  );
+}
+
+/**
+ * User Affiliations Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildUserAffiliationsTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-user-affiliations',
+    {
+      name: 'cla-' + stage + '-user-affiliations',
+      attributes: [
+        { name: 'affiliation_id', type: 'S' },
+        { name: 'user_id', type: 'S' },
+      ],
+      hashKey: 'affiliation_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      globalSecondaryIndexes: [
+        {
+          name: 'user-id-index',
+          hashKey: 'user_id',
+          projectionType: 'ALL',
+          readCapacity: defaultReadCapacity,
+          writeCapacity: defaultWriteCapacity,
+        },
+      ],
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-user-affiliations' } : {},
+  );
+}
+
+/**
+ * CLA Status Rate Limits Table - request counts of the public CLA status check
+ * clients, one record per client and minute
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildClaStatusRateLimitsTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-cla-status-rate-limits',
+    {
+      name: 'cla-' + stage + '-cla-status-rate-limits',
+      attributes: [{ name: 'window_key', type: 'S' }],
+      hashKey: 'window_key',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: defaultWriteCapacity,
+      ttl: {
+        attributeName: 'expires',
+        enabled: true,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-cla-status-rate-limits' } : {},
+  );
+}
+
 // DynamoDB trigger events handler functions
 const dynamoDBProjectsEventLambdaName = "cla-backend-" + stage + "-dynamo-projects-lambda";
 const dynamoDBProjectsEventLambdaArn = "arn:aws:lambda:" + aws.getRegion().name + ":" + accountID + ":function:" + dynamoDBProjectsEventLambdaName;
@@ -1060,4 +1721,22 @@ export const sessionStoreTableName = sessionStoreTable.name;
 export const eventsTableName = eventsTable.name;
 export const cclaWhitelistRequestsTableName = cclaWhitelistRequestsTable.name;
 export const metricsTableName = metricsTable.name;
-export const projectsClaGroupsTableName = projectsClaGroupsTable.name
+export const projectsClaGroupsTableName = projectsClaGroupsTable.name;
+export const featureFlagsTableName = featureFlagsTable.name;
+export const apiKeysTableName = apiKeysTable.name;
+export const webhookSubscriptionsTableName = webhookSubscriptionsTable.name;
+export const webhookDeliveriesTableName = webhookDeliveriesTable.name;
+export const githubDriftReportsTableName = githubDriftReportsTable.name;
+export const branchProtectionPoliciesTableName = branchProtectionPoliciesTable.name;
+export const githubJobsTableName = githubJobsTable.name;
+export const companyDomainVerificationsTableName = companyDomainVerificationsTable.name;
+export const dataSubjectRequestsTableName = dataSubjectRequestsTable.name;
+export const approvalListEntriesTableName = approvalListEntriesTable.name;
+export const scimTokensTableName = scimTokensTable.name;
+export const scimResourcesTableName = scimResourcesTable.name;
+export const githubOrgMembersTableName = githubOrgMembersTable.name;
+export const githubOrgMemberCachesTableName = githubOrgMemberCachesTable.name;
+export const claManagerApprovalPoliciesTableName = claManagerApprovalPoliciesTable.name;
+export const orphanedCclasTableName = orphanedCclasTable.name;
+export const userAffiliationsTableName = userAffiliationsTable.name;
+export const claStatusRateLimitsTableName = claStatusRateLimitsTable.name;